@@ -0,0 +1,217 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go_remind/daemon"
+	"go_remind/pkg/parser"
+	"go_remind/pkg/reminder"
+	"go_remind/pkg/state"
+)
+
+// runAdd runs `go_remind add <time> <description>`: adds a standalone
+// reminder, as if typed into the TUI, prints when it's due, and exits.
+// The words may be quoted together or not. With "-", each line read from
+// stdin is added instead.
+func runAdd(store *state.Store, args []string) {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, `Usage: go_remind add "<time> <description>", e.g. go_remind add "+1h take out trash"`)
+		fmt.Fprintln(os.Stderr, "       go_remind add -    (one reminder per line of stdin)")
+		os.Exit(2)
+	}
+
+	now := time.Now()
+	added, err := addArgs(args, os.Stdin, now)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	saveAdded(store, added, now)
+}
+
+// addArgs returns the standalone reminders `add` was given: one from the
+// words of args, quoted together or not, or one per line of stdin for "-"
+func addArgs(args []string, stdin io.Reader, now time.Time) ([]*reminder.Reminder, error) {
+	var added []*reminder.Reminder
+	if len(args) == 1 && args[0] == "-" {
+		added = readEntries(stdin, now)
+	} else {
+		r, err := parser.ParseEntry(strings.Join(args, " "), now)
+		if err != nil {
+			return nil, err
+		}
+		added = []*reminder.Reminder{r}
+	}
+	if len(added) == 0 {
+		return nil, errors.New("no reminders to add")
+	}
+	for _, r := range added {
+		r.ID = reminder.NewID()
+		r.SourceFile = reminder.StandaloneSource
+	}
+	return added, nil
+}
+
+// runParse runs `go_remind parse [file|-]`: finds the reminders in a
+// markdown document, stdin by default, and adds them as a watched file's
+// reminders would be. Reminders already added by an earlier parse of the
+// same text are skipped.
+func runParse(store *state.Store, args []string) {
+	name := reminder.StandaloneSource
+	in := io.Reader(os.Stdin)
+	if len(args) >= 1 && args[0] != "-" {
+		f, err := os.Open(args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		in = f
+		if name, err = filepath.Abs(args[0]); err != nil {
+			name = args[0]
+		}
+	}
+
+	now := time.Now()
+	found, err := parser.ParseReader(in, name, now)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if len(found) == 0 {
+		fmt.Fprintln(os.Stderr, "No reminders found")
+		os.Exit(1)
+	}
+	saveAdded(store, found, now)
+}
+
+// readEntries parses each non-empty line of in as a reminder, in any form
+// pasting into the TUI accepts. Lines that don't parse are reported and
+// skipped.
+func readEntries(in io.Reader, now time.Time) []*reminder.Reminder {
+	var entries []*reminder.Reminder
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		parsed := parser.ParseText(line, now)
+		if len(parsed) == 0 {
+			fmt.Fprintf(os.Stderr, "Skipped: %s (no time found)\n", line)
+		}
+		entries = append(entries, parsed...)
+	}
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: reading stdin: %v\n", err)
+		os.Exit(1)
+	}
+	return entries
+}
+
+// saveAdded adds reminders to the state and prints each with when it's due
+func saveAdded(store *state.Store, reminders []*reminder.Reminder, now time.Time) {
+	if store == nil {
+		fmt.Fprintln(os.Stderr, "Error: no state store to add to")
+		os.Exit(1)
+	}
+	added, err := addReminders(store, reminders)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	for _, r := range added {
+		fmt.Printf("Added: %s\nDue:   %s (%s)\n", r.Description, r.DateTime.Format("Mon Jan 2, 2006 at 3:04 PM"), untilDue(r.DateTime, now))
+	}
+	if skipped := len(reminders) - len(added); skipped > 0 {
+		fmt.Printf("Skipped %d already added\n", skipped)
+	}
+}
+
+// addReminders saves reminders with the others, leaving out any whose ID
+// is already taken, and returns those added. A running daemon owns the
+// state, so they're sent there to be merged; otherwise the state file is
+// updated directly.
+func addReminders(store *state.Store, reminders []*reminder.Reminder) ([]*reminder.Reminder, error) {
+	if client, err := daemon.Dial(daemon.SocketPath(filepath.Dir(store.Path()))); err == nil {
+		defer client.Close()
+		existing := client.Reminders()
+		added := newReminders(existing, reminders)
+		if len(added) == 0 {
+			return nil, nil
+		}
+		if err := client.Save(append(existing, added...)); err != nil {
+			return nil, err
+		}
+		// Wait for the daemon to take them, so closing doesn't cut them off
+		select {
+		case <-client.Updates():
+		case err := <-client.Errors():
+			return nil, err
+		case <-time.After(2 * time.Second):
+		}
+		return added, nil
+	}
+
+	existing, err := store.Load()
+	if err != nil {
+		return nil, fmt.Errorf("could not load state: %w", err)
+	}
+	added := newReminders(existing, reminders)
+	if len(added) == 0 {
+		return nil, nil
+	}
+	all := append(existing, added...)
+	reminder.SortByDateTime(all)
+	return added, store.Save(all)
+}
+
+// newReminders returns the reminders whose IDs aren't in existing
+func newReminders(existing, reminders []*reminder.Reminder) []*reminder.Reminder {
+	taken := make(map[string]bool, len(existing))
+	for _, r := range existing {
+		taken[r.ID] = true
+	}
+	var fresh []*reminder.Reminder
+	for _, r := range reminders {
+		if !taken[r.ID] {
+			taken[r.ID] = true
+			fresh = append(fresh, r)
+		}
+	}
+	return fresh
+}
+
+// untilDue describes how far off t is, e.g. "in 1h 30m" or "5m ago"
+func untilDue(t, now time.Time) string {
+	d := t.Sub(now).Round(time.Minute)
+	if d < 0 {
+		return formatSpan(-d) + " ago"
+	}
+	return "in " + formatSpan(d)
+}
+
+// formatSpan formats d in days, hours, and minutes, leaving out the zeros
+func formatSpan(d time.Duration) string {
+	days := int(d / (24 * time.Hour))
+	hours := int(d % (24 * time.Hour) / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+	var parts []string
+	if days > 0 {
+		parts = append(parts, fmt.Sprintf("%dd", days))
+	}
+	if hours > 0 {
+		parts = append(parts, fmt.Sprintf("%dh", hours))
+	}
+	if minutes > 0 || len(parts) == 0 {
+		parts = append(parts, fmt.Sprintf("%dm", minutes))
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"go_remind/pkg/reminder"
+)
+
+func TestAddArgs(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.Local)
+
+	// The words may be quoted together or given one by one
+	for _, args := range [][]string{{"+1h take out trash #home"}, {"+1h", "take", "out", "trash", "#home"}} {
+		got, err := addArgs(args, nil, now)
+		if err != nil || len(got) != 1 {
+			t.Fatalf("addArgs(%q) = %v, %v; want one reminder", args, got, err)
+		}
+		r := got[0]
+		if r.Description != "take out trash" || !r.DateTime.Equal(now.Add(time.Hour)) || len(r.Tags) != 1 {
+			t.Errorf("addArgs(%q) = %q at %v tagged %v", args, r.Description, r.DateTime, r.Tags)
+		}
+		if r.ID == "" || r.SourceFile != reminder.StandaloneSource {
+			t.Errorf("addArgs(%q) gave ID %q from %q, want a standalone reminder with an ID", args, r.ID, r.SourceFile)
+		}
+	}
+
+	if _, err := addArgs([]string{"sometime", "maybe"}, nil, now); err == nil {
+		t.Error("addArgs() without a time should fail")
+	}
+
+	// "-" reads a reminder per line, skipping lines without a time
+	stdin := strings.NewReader("+1h Water the plants\n\nno time here\ntomorrow 9am Call mom\n")
+	got, err := addArgs([]string{"-"}, stdin, now)
+	if err != nil || len(got) != 2 || got[0].Description != "Water the plants" || got[1].Description != "Call mom" {
+		t.Errorf("addArgs(-) = %v, %v; want the two lines with times", got, err)
+	}
+	if _, err := addArgs([]string{"-"}, strings.NewReader("\n"), now); err == nil {
+		t.Error("addArgs(-) with nothing to add should fail")
+	}
+}
+
+func TestNewReminders(t *testing.T) {
+	existing := []*reminder.Reminder{{ID: "a"}, {ID: "b"}}
+	got := newReminders(existing, []*reminder.Reminder{{ID: "b"}, {ID: "c"}, {ID: "c"}})
+	if len(got) != 1 || got[0].ID != "c" {
+		t.Errorf("newReminders() = %v, want only c, once", got)
+	}
+}
+
+func TestUntilDue(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.Local)
+	tests := []struct {
+		due  time.Time
+		want string
+	}{
+		{now.Add(90 * time.Minute), "in 1h 30m"},
+		{now.Add(-5 * time.Minute), "5m ago"},
+		{now.Add(26 * time.Hour), "in 1d 2h"},
+		{now.Add(20 * time.Second), "in 0m"},
+		{now.Add(-(48*time.Hour + 3*time.Minute)), "2d 3m ago"},
+	}
+	for _, tt := range tests {
+		if got := untilDue(tt.due, now); got != tt.want {
+			t.Errorf("untilDue(%v) = %q, want %q", tt.due.Sub(now), got, tt.want)
+		}
+	}
+}
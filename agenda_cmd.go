@@ -0,0 +1,73 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"go_remind/config"
+	"go_remind/digest"
+	"go_remind/pkg/datetime"
+	"go_remind/pkg/reminder"
+	"go_remind/pkg/state"
+)
+
+// runAgenda runs `go_remind agenda`: prints a day's reminders grouped by
+// hour with checkboxes, as text, markdown, or HTML, for printing or pasting
+// into a journal
+func runAgenda(store *state.Store, cfg *config.Config, args []string) {
+	fs := flag.NewFlagSet("agenda", flag.ExitOnError)
+	date := fs.String("date", "today", "Day to print: today, tomorrow, yesterday, a weekday, or 2006-01-02")
+	format := fs.String("format", "text", "Output format: text, md, or html")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: go_remind agenda [--date today|tomorrow|<date>] [--format text|md|html]")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() > 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	f, err := digest.ParsePrintFormat(*format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+	now := time.Now()
+	day, err := parseDay(*date, now)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+
+	var reminders []*reminder.Reminder
+	if store != nil {
+		if reminders, err = store.Load(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: could not load state: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	fmt.Print(digest.Build(reminders, day).Printable(f, cfg.Conflicts.WindowDuration()))
+}
+
+// parseDay reads the day s names, relative to now
+func parseDay(s string, now time.Time) (time.Time, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch s {
+	case "today", "":
+		return now, nil
+	case "tomorrow":
+		return now.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return now.AddDate(0, 0, -1), nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, now.Location()); err == nil {
+		return t, nil
+	}
+	if t, err := datetime.Parse(s, now); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("could not read %q as a day: try today, tomorrow, friday, or 2006-01-02", s)
+}
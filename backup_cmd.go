@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go_remind/daemon"
+	"go_remind/pkg/state"
+)
+
+// runBackup runs `go_remind backup [file]`: writes the state, history, and
+// config to a single archive
+func runBackup(store *state.Store, configPath string, args []string) {
+	if store == nil {
+		fmt.Fprintln(os.Stderr, "Error: no state store to back up")
+		os.Exit(1)
+	}
+
+	path := "go_remind-backup-" + time.Now().Format("20060102-150405") + ".tar.gz"
+	if len(args) >= 1 {
+		path = args[0]
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if err := store.Backup(f, configPath); err != nil {
+		f.Close()
+		os.Remove(path)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if err := f.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Backed up to %s\n", path)
+}
+
+// runRestore runs `go_remind restore <file>`: replaces the state, history,
+// and config with those in a backup archive
+func runRestore(store *state.Store, configPath string, args []string) {
+	if len(args) < 1 {
+		fmt.Fprintln(os.Stderr, "Usage: go_remind restore <file>")
+		os.Exit(2)
+	}
+	if store == nil {
+		fmt.Fprintln(os.Stderr, "Error: no state store to restore into")
+		os.Exit(1)
+	}
+
+	// The daemon would overwrite the restored state with its own copy
+	if client, err := daemon.Dial(daemon.SocketPath(filepath.Dir(store.Path()))); err == nil {
+		client.Close()
+		fmt.Fprintln(os.Stderr, "Error: stop the daemon before restoring")
+		os.Exit(1)
+	}
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	if err := store.Restore(f, configPath); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Restored from %s (previous state kept in %s)\n", args[0], store.BackupDir())
+}
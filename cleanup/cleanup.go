@@ -0,0 +1,86 @@
+package cleanup
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go_remind/pkg/reminder"
+)
+
+// Rules say when old reminders are tidied up automatically. A zero duration
+// turns the rule off.
+type Rules struct {
+	AcknowledgeAfter time.Duration // Acknowledge triggered reminders this overdue
+	DeleteAfter      time.Duration // Delete reminders this long after they were acknowledged
+}
+
+// Enabled returns true if any rule is on
+func (r Rules) Enabled() bool {
+	return r.AcknowledgeAfter > 0 || r.DeleteAfter > 0
+}
+
+// Result lists what one pass of the rules changed
+type Result struct {
+	Acknowledged []*reminder.Reminder
+	Deleted      []*reminder.Reminder
+}
+
+// Empty returns true if nothing was cleaned up
+func (r Result) Empty() bool {
+	return len(r.Acknowledged) == 0 && len(r.Deleted) == 0
+}
+
+// Summary describes the result in one line, e.g.
+// "auto-acknowledged 3, deleted 2 old reminders"
+func (r Result) Summary() string {
+	var parts []string
+	if n := len(r.Acknowledged); n > 0 {
+		parts = append(parts, fmt.Sprintf("auto-acknowledged %d", n))
+	}
+	if n := len(r.Deleted); n > 0 {
+		parts = append(parts, fmt.Sprintf("deleted %d", n))
+	}
+	if len(parts) == 0 {
+		return "nothing to clean up"
+	}
+	noun := "reminders"
+	if len(r.Acknowledged)+len(r.Deleted) == 1 {
+		noun = "reminder"
+	}
+	return strings.Join(parts, ", ") + " old " + noun
+}
+
+// Apply runs the rules against reminders, acknowledging overdue ones in
+// place. Returns the reminders to keep and what changed. Reminders
+// acknowledged by this pass are not deleted by it, and yearly reminders,
+// which come back next year, are never deleted.
+func Apply(reminders []*reminder.Reminder, rules Rules, now time.Time) ([]*reminder.Reminder, Result) {
+	var res Result
+	if !rules.Enabled() {
+		return reminders, res
+	}
+
+	kept := make([]*reminder.Reminder, 0, len(reminders))
+	for _, r := range reminders {
+		switch {
+		case rules.DeleteAfter > 0 && r.Status == reminder.Acknowledged && r.Yearly.IsZero() && now.Sub(acknowledgedAt(r)) > rules.DeleteAfter:
+			res.Deleted = append(res.Deleted, r)
+			continue
+		case rules.AcknowledgeAfter > 0 && r.Status == reminder.Triggered && now.Sub(r.DateTime) > rules.AcknowledgeAfter:
+			r.Acknowledge(now)
+			res.Acknowledged = append(res.Acknowledged, r)
+		}
+		kept = append(kept, r)
+	}
+	return kept, res
+}
+
+// acknowledgedAt returns when r was acknowledged, falling back to its due
+// time for reminders saved before acknowledgment times were recorded
+func acknowledgedAt(r *reminder.Reminder) time.Time {
+	if r.AcknowledgedAt.IsZero() {
+		return r.DateTime
+	}
+	return r.AcknowledgedAt
+}
@@ -0,0 +1,51 @@
+package cleanup
+
+import (
+	"testing"
+	"time"
+
+	"go_remind/pkg/reminder"
+)
+
+func TestApply(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.Local)
+	days := func(n int) time.Duration { return time.Duration(n) * 24 * time.Hour }
+
+	stale := &reminder.Reminder{ID: "stale", DateTime: now.Add(-days(45)), Status: reminder.Triggered}
+	recent := &reminder.Reminder{ID: "recent", DateTime: now.Add(-days(3)), Status: reminder.Triggered}
+	old := &reminder.Reminder{ID: "old", DateTime: now.Add(-days(200)), Status: reminder.Acknowledged, AcknowledgedAt: now.Add(-days(120))}
+	// Due long ago but only just acknowledged
+	lateAck := &reminder.Reminder{ID: "late", DateTime: now.Add(-days(200)), Status: reminder.Acknowledged, AcknowledgedAt: now.Add(-days(5))}
+	legacy := &reminder.Reminder{ID: "legacy", DateTime: now.Add(-days(100)), Status: reminder.Acknowledged}
+	pending := &reminder.Reminder{ID: "pending", DateTime: now.Add(days(1)), Status: reminder.Pending}
+	// Acknowledged long ago, but comes back next year
+	yearly := &reminder.Reminder{ID: "yearly", DateTime: now.Add(-days(200)), Status: reminder.Acknowledged, AcknowledgedAt: now.Add(-days(120)), Yearly: reminder.Yearly{Month: time.November, Day: 14}}
+
+	kept, res := Apply([]*reminder.Reminder{stale, recent, old, lateAck, legacy, pending, yearly},
+		Rules{AcknowledgeAfter: days(30), DeleteAfter: days(90)}, now)
+
+	var keptIDs []string
+	for _, r := range kept {
+		keptIDs = append(keptIDs, r.ID)
+	}
+	if len(kept) != 5 || kept[0] != stale || kept[1] != recent || kept[2] != lateAck || kept[3] != pending || kept[4] != yearly {
+		t.Errorf("kept = %v, want stale, recent, late, pending, yearly", keptIDs)
+	}
+	if len(res.Acknowledged) != 1 || res.Acknowledged[0] != stale || stale.Status != reminder.Acknowledged || !stale.AcknowledgedAt.Equal(now) {
+		t.Errorf("Acknowledged = %v, stale status %v; want stale acknowledged now", res.Acknowledged, stale.Status)
+	}
+	if len(res.Deleted) != 2 {
+		t.Errorf("Deleted = %d reminders, want old and legacy", len(res.Deleted))
+	}
+	if got := res.Summary(); got != "auto-acknowledged 1, deleted 2 old reminders" {
+		t.Errorf("Summary() = %q", got)
+	}
+}
+
+func TestApplyDisabled(t *testing.T) {
+	r := &reminder.Reminder{DateTime: time.Now().Add(-1000 * time.Hour), Status: reminder.Triggered}
+	kept, res := Apply([]*reminder.Reminder{r}, Rules{}, time.Now())
+	if len(kept) != 1 || !res.Empty() || r.Status != reminder.Triggered {
+		t.Errorf("disabled rules changed reminders: kept %d, result %+v", len(kept), res)
+	}
+}
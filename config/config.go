@@ -0,0 +1,910 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+
+	"go_remind/pkg/datetime"
+	"go_remind/pkg/parser"
+	"go_remind/pkg/watcher"
+)
+
+const configFileName = "config.toml"
+
+// Config holds user settings loaded from ~/.go_remind/config.toml
+type Config struct {
+	Paths         []string           `toml:"paths"` // Watched when none is given on the command line
+	Notifications NotificationConfig `toml:"notifications"`
+	Digest        DigestConfig       `toml:"digest"`
+	Away          AwayConfig         `toml:"away"`
+	Report        ReportConfig       `toml:"report"`
+	Email         EmailConfig        `toml:"email"`
+	Sync          SyncConfig         `toml:"sync"`
+	Parser        ParserConfig       `toml:"parser"`
+	Cleanup       CleanupConfig      `toml:"cleanup"`
+	Duplicates    DuplicatesConfig   `toml:"duplicates"`
+	Descriptions  DescriptionsConfig `toml:"descriptions"`
+	Conflicts     ConflictsConfig    `toml:"conflicts"`
+	Merge         MergeConfig        `toml:"merge"`
+	Rules         RulesConfig        `toml:"rules"`
+	WorkHours     WorkHoursConfig    `toml:"work_hours"`
+	Sources       SourcesConfig      `toml:"sources"`
+	Watch         WatchConfig        `toml:"watch"`
+	UI            UIConfig           `toml:"ui"`
+	Keys          map[string]KeyList `toml:"keys"` // Action name -> keys
+	Workspaces    []WorkspaceConfig  `toml:"workspaces"`
+
+	// Named profiles, chosen with --profile
+	Profiles map[string]ProfileConfig `toml:"profiles"`
+}
+
+// DefaultProfile is the name shown for the settings without any profile
+const DefaultProfile = "default"
+
+// ProfileConfig overrides settings for one named profile, such as a separate
+// work vault. Each profile keeps its own state, history, and backups.
+type ProfileConfig struct {
+	Paths         []string `toml:"paths"`         // Files or directories to watch
+	Theme         string   `toml:"theme"`         // Theme name, e.g. "Nord"
+	Notifications *bool    `toml:"notifications"` // Overrides notifications.enabled
+}
+
+// ProfileNames returns the names of the configured profiles, sorted
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ForProfile returns the settings for the named profile: a copy of c with
+// the profile's overrides applied. Sync stays with the default profile, so
+// vaults don't get mixed on the remote. The empty name and DefaultProfile
+// return c itself.
+func (c *Config) ForProfile(name string) (*Config, error) {
+	if name == "" || name == DefaultProfile {
+		return c, nil
+	}
+	p, ok := c.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown profile %q", name)
+	}
+
+	out := *c
+	out.Paths = p.Paths
+	if p.Theme != "" {
+		out.UI.Theme = p.Theme
+	}
+	if p.Notifications != nil {
+		out.Notifications.Enabled = *p.Notifications
+	}
+	out.Sync.Enabled = false
+	return &out, nil
+}
+
+// KeyList is the keys bound to one action. A single string is accepted in
+// place of a list.
+type KeyList []string
+
+// UnmarshalTOML accepts either "x" or ["x", "y"]
+func (k *KeyList) UnmarshalTOML(v any) error {
+	switch v := v.(type) {
+	case string:
+		*k = KeyList{v}
+	case []any:
+		list := make(KeyList, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return fmt.Errorf("key must be a string, got %v", item)
+			}
+			list = append(list, s)
+		}
+		*k = list
+	default:
+		return fmt.Errorf("keys must be a string or list of strings, got %v", v)
+	}
+	return nil
+}
+
+// KeyBindings returns the [keys] section as plain string lists
+func (c *Config) KeyBindings() map[string][]string {
+	bindings := make(map[string][]string, len(c.Keys))
+	for action, list := range c.Keys {
+		bindings[action] = list
+	}
+	return bindings
+}
+
+// WorkspaceConfig is a named arrangement of the list to switch to in the
+// TUI: its filter, grouping, sort order, and layout. Empty settings are
+// left as they are when switching.
+type WorkspaceConfig struct {
+	Name   string `toml:"name"`
+	Filter string `toml:"filter,omitempty"` // As typed in the filter box, e.g. "#work"
+	Group  string `toml:"group,omitempty"`  // "time", "file", "tag", or "priority"
+	Sort   string `toml:"sort,omitempty"`   // e.g. "due" or "priority"
+	Layout string `toml:"layout,omitempty"` // "compact" or "card"
+}
+
+// MaxWorkspaces is how many workspaces there can be, one per number key
+const MaxWorkspaces = 9
+
+// AppendWorkspace adds w to the end of the config file at path as a
+// [[workspaces]] table, leaving the rest of the file, comments and all, as
+// it was
+func AppendWorkspace(path string, w WorkspaceConfig) error {
+	var buf bytes.Buffer
+	buf.WriteString("\n[[workspaces]]\n")
+	if err := toml.NewEncoder(&buf).Encode(w); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// NotificationConfig controls desktop notifications
+type NotificationConfig struct {
+	Enabled bool `toml:"enabled"`
+}
+
+// DigestConfig controls the daily morning digest
+type DigestConfig struct {
+	Enabled bool   `toml:"enabled"`
+	Time    string `toml:"time"`   // Time of day in 24h "HH:MM" format
+	Notify  bool   `toml:"notify"` // Send a desktop notification
+	Pane    bool   `toml:"pane"`   // Open the digest pane in the TUI
+}
+
+// AwayConfig controls what the TUI shows on start of the reminders that
+// came due while it was closed
+type AwayConfig struct {
+	Pane   bool `toml:"pane"`   // List them in the TUI
+	Notify bool `toml:"notify"` // Send one desktop notification summing them up
+}
+
+// ReportConfig controls the weekly report the daemon sends
+type ReportConfig struct {
+	Enabled bool   `toml:"enabled"`
+	Day     string `toml:"day"`   // Weekday it's sent, e.g. "monday"
+	Time    string `toml:"time"`  // Time of day in 24h "HH:MM" format
+	Email   bool   `toml:"email"` // Email it, through the [email] settings
+	Dir     string `toml:"dir"`   // Save each report here as markdown; empty to not save
+}
+
+// Weekday returns the parsed day the report is sent
+func (c ReportConfig) Weekday() (time.Weekday, error) {
+	day := strings.ToLower(strings.TrimSpace(c.Day))
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		if day == name || day == name[:3] {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid weekday %q", c.Day)
+}
+
+// EmailConfig is the SMTP server go_remind sends email through. The
+// password defaults to $GO_REMIND_SMTP_PASSWORD.
+type EmailConfig struct {
+	Server   string   `toml:"server"` // "host:port"; the port defaults to 587
+	Username string   `toml:"username"`
+	Password string   `toml:"password"`
+	From     string   `toml:"from"` // Defaults to the username
+	To       []string `toml:"to"`
+}
+
+// ParserConfig controls how reminders are recognized in markdown
+type ParserConfig struct {
+	// Keywords are extra trigger keywords accepted alongside remind_me.
+	// "r!" matches [r! ...]; a leading @ as in "@remind" matches @remind(...).
+	Keywords []string `toml:"keywords"`
+	// SkipCode ignores reminders in code blocks, inline code, and frontmatter
+	SkipCode bool `toml:"skip_code"`
+	// WriteBack rewrites a reminder's token in its file when it's edited in
+	// the TUI
+	WriteBack bool `toml:"write_back"`
+	// TagDefaults are when reminders with a tag and no time of their own
+	// fall, by tag, e.g. standup = "9:30am" or billpay = "1st"
+	TagDefaults map[string]string `toml:"tag_defaults"`
+	// MaxFileSize is the largest file parsed, e.g. "10MB"; "0" for no limit
+	MaxFileSize string `toml:"max_file_size"`
+}
+
+// MaxFileBytes returns the parsed max_file_size in bytes, 0 for no limit
+func (c ParserConfig) MaxFileBytes() int64 {
+	n, err := ParseSize(c.MaxFileSize)
+	if err != nil {
+		return parser.DefaultMaxFileSize
+	}
+	return n
+}
+
+// UIConfig controls the look of the TUI
+type UIConfig struct {
+	Background   string   `toml:"background"`    // "auto", "light", or "dark"
+	ASCII        bool     `toml:"ascii"`         // Plain ASCII symbols and borders, no emoji
+	HighContrast bool     `toml:"high_contrast"` // Start with the high-contrast theme
+	Theme        string   `toml:"theme"`         // Theme to start with, by name
+	SplitPane    bool     `toml:"split_pane"`    // Start with the detail pane beside the list
+	Alerts       bool     `toml:"alerts"`        // Pop up an alert when a reminder triggers
+	Bell         bool     `toml:"bell"`          // Ring the terminal bell when a reminder triggers
+	Columns      []string `toml:"columns"`       // The compact view's columns, in order; empty for the defaults
+}
+
+// CleanupConfig sets rules for tidying up old reminders automatically.
+// Ages accept days and weeks ("30d", "2w") as well as Go durations.
+// An empty age turns the rule off.
+type CleanupConfig struct {
+	AcknowledgeAfter string `toml:"acknowledge_after"` // Acknowledge triggered reminders this overdue
+	DeleteAfter      string `toml:"delete_after"`      // Delete reminders this long after acknowledgment
+	Interval         string `toml:"interval"`          // How often to check, e.g. "1h"
+}
+
+// AcknowledgeAge returns the parsed acknowledge_after age, or 0 if unset
+func (c CleanupConfig) AcknowledgeAge() time.Duration {
+	d, _ := ParseAge(c.AcknowledgeAfter)
+	return d
+}
+
+// DeleteAge returns the parsed delete_after age, or 0 if unset
+func (c CleanupConfig) DeleteAge() time.Duration {
+	d, _ := ParseAge(c.DeleteAfter)
+	return d
+}
+
+// CheckInterval returns the parsed cleanup interval
+func (c CleanupConfig) CheckInterval() time.Duration {
+	d, err := time.ParseDuration(c.Interval)
+	if err != nil || d <= 0 {
+		return time.Hour
+	}
+	return d
+}
+
+// DuplicatesConfig controls detection of the same reminder copied into
+// several files
+type DuplicatesConfig struct {
+	Tolerance string `toml:"tolerance"`  // Due times this close count as the same, e.g. "5m"
+	AutoMerge bool   `toml:"auto_merge"` // Merge duplicates as soon as they're found
+}
+
+// ToleranceDuration returns the parsed tolerance
+func (c DuplicatesConfig) ToleranceDuration() time.Duration {
+	d, err := time.ParseDuration(c.Tolerance)
+	if err != nil || d < 0 {
+		return 5 * time.Minute
+	}
+	return d
+}
+
+// DescriptionsConfig controls how descriptions typed into the TUI are
+// tidied and checked
+type DescriptionsConfig struct {
+	Tidy           bool `toml:"tidy"`            // Capitalize, collapse spaces, and drop trailing punctuation when adding
+	WarnDuplicates bool `toml:"warn_duplicates"` // Flag open reminders like the one being typed
+}
+
+// ConflictsConfig controls warnings about reminders booked close together
+type ConflictsConfig struct {
+	Window string `toml:"window"` // Reminders due this close conflict, e.g. "15m"; "0" turns warnings off
+}
+
+// WindowDuration returns the parsed window
+func (c ConflictsConfig) WindowDuration() time.Duration {
+	d, err := time.ParseDuration(c.Window)
+	if err != nil || d < 0 {
+		return 15 * time.Minute
+	}
+	return d
+}
+
+// MergeConfig controls how changes to watched files are merged into the
+// reminders
+type MergeConfig struct {
+	ConfirmRemovals int `toml:"confirm_removals"` // Ask first when a change removes this many; 0 never asks
+}
+
+// WatchConfig controls which files in watched directories are parsed and
+// watched
+type WatchConfig struct {
+	Ignore []string `toml:"ignore"` // Glob patterns for names of files and directories to skip, e.g. ".*"
+}
+
+// RulesConfig points at a Starlark script run over reminders as they are
+// parsed, for custom tagging, priority, and filtering
+type RulesConfig struct {
+	Script string `toml:"script"` // e.g. "~/.go_remind/rules.star"; empty turns rules off
+}
+
+// WorkHoursConfig is the user's working day, in 24h "HH:MM" format
+type WorkHoursConfig struct {
+	Start string `toml:"start"`
+	End   string `toml:"end"`
+}
+
+// SourcesConfig lists the remote systems reminders are pulled from
+type SourcesConfig struct {
+	Interval string               `toml:"interval"` // How often to refresh, e.g. "1h"
+	GitHub   []GitHubSourceConfig `toml:"github"`
+	GitLab   []GitLabSourceConfig `toml:"gitlab"`
+	Jira     []JiraSourceConfig   `toml:"jira"`
+	IMAP     []IMAPSourceConfig   `toml:"imap"`
+	Feeds    []FeedSourceConfig   `toml:"feed"`
+
+	// Watched path -> how often to parse it again, catching changes the
+	// watcher missed, e.g. on a network drive
+	Paths map[string]string `toml:"paths"`
+}
+
+// GitHubSourceConfig pulls the open issues and pull requests of a GitHub
+// repo whose milestone has a due date. The token defaults to $GITHUB_TOKEN.
+type GitHubSourceConfig struct {
+	Repo          string `toml:"repo"` // "owner/name"
+	Token         string `toml:"token"`
+	API           string `toml:"api"`            // API URL, for GitHub Enterprise
+	OnAcknowledge string `toml:"on_acknowledge"` // "comment", "label", or "" to do nothing
+	Comment       string `toml:"comment"`        // Comment posted on acknowledgment
+	Label         string `toml:"label"`          // Label added on acknowledgment
+	Interval      string `toml:"interval"`       // Overrides sources.interval
+}
+
+// GitLabSourceConfig pulls the open issues and merge requests of a GitLab
+// project with a due date or milestone deadline. The token defaults to
+// $GITLAB_TOKEN.
+type GitLabSourceConfig struct {
+	Project       string `toml:"project"` // "group/name"
+	Token         string `toml:"token"`
+	URL           string `toml:"url"`            // Instance URL, for self-hosted GitLab
+	OnAcknowledge string `toml:"on_acknowledge"` // "comment", "label", or "" to do nothing
+	Comment       string `toml:"comment"`        // Comment posted on acknowledgment
+	Label         string `toml:"label"`          // Label added on acknowledgment
+	Interval      string `toml:"interval"`       // Overrides sources.interval
+}
+
+// JiraSourceConfig pulls Jira tickets with a due date. The token defaults
+// to $JIRA_API_TOKEN; with an email it's a Jira Cloud API token, without
+// one a personal access token.
+type JiraSourceConfig struct {
+	URL      string `toml:"url"` // Site URL, e.g. "https://example.atlassian.net"
+	Email    string `toml:"email"`
+	Token    string `toml:"token"`
+	JQL      string `toml:"jql"`      // Defaults to your tickets with a due date
+	Interval string `toml:"interval"` // Overrides sources.interval
+}
+
+// IMAPSourceConfig turns flagged emails, or ones matching an IMAP search,
+// into follow-up reminders. The password defaults to
+// $GO_REMIND_IMAP_PASSWORD.
+type IMAPSourceConfig struct {
+	Server    string `toml:"server"` // "host:port"; the port defaults to 993
+	Username  string `toml:"username"`
+	Password  string `toml:"password"`
+	Mailbox   string `toml:"mailbox"`   // Defaults to INBOX
+	Flagged   bool   `toml:"flagged"`   // Follow up on flagged emails
+	Search    string `toml:"search"`    // IMAP search, e.g. `FROM "boss@example.com"`
+	Delay     string `toml:"delay"`     // How long after an email it's due, e.g. "48h"
+	Plaintext bool   `toml:"plaintext"` // Connect without TLS, for a local bridge
+	Interval  string `toml:"interval"`  // Overrides sources.interval
+}
+
+// FollowUpDelay returns the parsed delay, 24h by default
+func (c IMAPSourceConfig) FollowUpDelay() time.Duration {
+	d, err := time.ParseDuration(c.Delay)
+	if err != nil || d < 0 {
+		return 24 * time.Hour
+	}
+	return d
+}
+
+// FeedSourceConfig subscribes to an RSS or Atom feed whose entries have
+// dates, like a calendar of conference deadlines
+type FeedSourceConfig struct {
+	URL       string `toml:"url"`
+	Tag       string `toml:"tag"`       // Tag for its reminders; defaults to "feed"
+	Interval  string `toml:"interval"`  // How often to refresh; defaults to "24h"
+	Published bool   `toml:"published"` // Fall back to an entry's publication date
+}
+
+// RefreshInterval returns the parsed refresh interval, daily by default
+func (c FeedSourceConfig) RefreshInterval() time.Duration {
+	d, err := time.ParseDuration(c.Interval)
+	if err != nil || d <= 0 {
+		return 24 * time.Hour
+	}
+	return d
+}
+
+// RefreshInterval returns the parsed refresh interval
+func (c SourcesConfig) RefreshInterval() time.Duration {
+	d, err := time.ParseDuration(c.Interval)
+	if err != nil || d <= 0 {
+		return time.Hour
+	}
+	return d
+}
+
+// PathIntervals returns how often each watched path in Paths is parsed
+// again, by expanded path
+func (c SourcesConfig) PathIntervals() map[string]time.Duration {
+	intervals := make(map[string]time.Duration, len(c.Paths))
+	for path, interval := range c.Paths {
+		if d := ParseInterval(interval); d > 0 {
+			intervals[ExpandPath(path)] = d
+		}
+	}
+	return intervals
+}
+
+// ParseInterval parses a source's own refresh interval. It returns 0 when
+// none is set, or it's invalid, to use the interval set for all sources.
+func ParseInterval(s string) time.Duration {
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0
+	}
+	return d
+}
+
+// validInterval reports whether s is empty or a positive duration
+func validInterval(s string) bool {
+	return s == "" || ParseInterval(s) > 0
+}
+
+func validOnAcknowledge(action string) bool {
+	return action == "" || action == "comment" || action == "label"
+}
+
+// SyncConfig controls syncing state between machines
+type SyncConfig struct {
+	Enabled  bool             `toml:"enabled"`
+	Backend  string           `toml:"backend"`  // "git", "s3", "webdav", or "caldav"
+	Interval string           `toml:"interval"` // How often to sync, e.g. "5m"
+	Git      GitSyncConfig    `toml:"git"`
+	S3       S3SyncConfig     `toml:"s3"`
+	WebDAV   WebDAVSyncConfig `toml:"webdav"`
+	CalDAV   CalDAVSyncConfig `toml:"caldav"`
+}
+
+// GitSyncConfig configures the Git sync backend
+type GitSyncConfig struct {
+	Dir    string `toml:"dir"`    // Local clone of the sync repo
+	Remote string `toml:"remote"` // Remote to push/pull
+	Branch string `toml:"branch"` // Branch to sync on
+	File   string `toml:"file"`   // State file path within the repo
+}
+
+// S3SyncConfig configures the S3-compatible sync backend.
+// Credentials default to $AWS_ACCESS_KEY_ID and $AWS_SECRET_ACCESS_KEY.
+type S3SyncConfig struct {
+	Endpoint  string `toml:"endpoint"`
+	Region    string `toml:"region"`
+	Bucket    string `toml:"bucket"`
+	Key       string `toml:"key"`
+	AccessKey string `toml:"access_key"`
+	SecretKey string `toml:"secret_key"`
+}
+
+// WebDAVSyncConfig configures the WebDAV sync backend.
+// The password defaults to $GO_REMIND_WEBDAV_PASSWORD.
+type WebDAVSyncConfig struct {
+	URL      string `toml:"url"` // Full URL of the state file
+	Username string `toml:"username"`
+	Password string `toml:"password"`
+}
+
+// CalDAVSyncConfig configures syncing with a CalDAV task list.
+// The password defaults to $GO_REMIND_CALDAV_PASSWORD.
+type CalDAVSyncConfig struct {
+	URL      string `toml:"url"` // Collection URL of the task list
+	Username string `toml:"username"`
+	Password string `toml:"password"`
+}
+
+// SyncInterval returns the parsed sync interval
+func (c SyncConfig) SyncInterval() time.Duration {
+	d, err := time.ParseDuration(c.Interval)
+	if err != nil || d <= 0 {
+		return 5 * time.Minute
+	}
+	return d
+}
+
+// Default returns the configuration used when no config file exists
+func Default() *Config {
+	return &Config{
+		Notifications: NotificationConfig{
+			Enabled: true,
+		},
+		Digest: DigestConfig{
+			Enabled: false,
+			Time:    "08:00",
+			Notify:  true,
+			Pane:    true,
+		},
+		Away: AwayConfig{
+			Pane: true,
+		},
+		Report: ReportConfig{
+			Enabled: false,
+			Day:     "monday",
+			Time:    "08:00",
+		},
+		Sync: SyncConfig{
+			Enabled:  false,
+			Backend:  "git",
+			Interval: "5m",
+			Git: GitSyncConfig{
+				Dir:    "~/.go_remind/sync",
+				Remote: "origin",
+				Branch: "main",
+				File:   "reminders_state.json",
+			},
+			S3: S3SyncConfig{
+				Endpoint: "https://s3.amazonaws.com",
+				Region:   "us-east-1",
+				Key:      "go_remind/reminders_state.json",
+			},
+		},
+		Parser: ParserConfig{
+			SkipCode:    true,
+			MaxFileSize: "10MB",
+		},
+		Cleanup: CleanupConfig{
+			Interval: "1h",
+		},
+		Duplicates: DuplicatesConfig{
+			Tolerance: "5m",
+		},
+		Descriptions: DescriptionsConfig{
+			WarnDuplicates: true,
+		},
+		Conflicts: ConflictsConfig{
+			Window: "15m",
+		},
+		UI: UIConfig{
+			Background: "auto",
+			Alerts:     true,
+			Bell:       true,
+		},
+		WorkHours: WorkHoursConfig{
+			Start: "09:00",
+			End:   "17:00",
+		},
+		Sources: SourcesConfig{
+			Interval: "1h",
+		},
+		Watch: WatchConfig{
+			Ignore: slices.Clone(watcher.DefaultIgnore),
+		},
+	}
+}
+
+// DefaultPath returns the default config path (~/.go_remind/config.toml)
+func DefaultPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".go_remind", configFileName), nil
+}
+
+// Load reads the config file at path, filling unset values with defaults.
+// A missing file is not an error.
+func Load(path string) (*Config, error) {
+	cfg := Default()
+
+	if _, err := toml.DecodeFile(path, cfg); err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return cfg, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// Validate checks the config for invalid values
+func (c *Config) Validate() error {
+	if _, _, err := ParseClock(c.Digest.Time); err != nil {
+		return fmt.Errorf("digest.time: %w", err)
+	}
+	if _, err := c.Report.Weekday(); err != nil {
+		return fmt.Errorf("report.day: %w", err)
+	}
+	if _, _, err := ParseClock(c.Report.Time); err != nil {
+		return fmt.Errorf("report.time: %w", err)
+	}
+	if c.Report.Enabled && !c.Report.Email && c.Report.Dir == "" {
+		return fmt.Errorf("report: set email, dir, or both for the daemon to send it somewhere")
+	}
+	if c.Report.Email && (c.Email.Server == "" || len(c.Email.To) == 0) {
+		return fmt.Errorf("email: server and to are required to email the report")
+	}
+	if _, err := time.ParseDuration(c.Sync.Interval); err != nil {
+		return fmt.Errorf("sync.interval: %w", err)
+	}
+	if _, err := ParseAge(c.Cleanup.AcknowledgeAfter); err != nil {
+		return fmt.Errorf("cleanup.acknowledge_after: %w", err)
+	}
+	if _, err := ParseAge(c.Cleanup.DeleteAfter); err != nil {
+		return fmt.Errorf("cleanup.delete_after: %w", err)
+	}
+	if _, err := time.ParseDuration(c.Cleanup.Interval); err != nil {
+		return fmt.Errorf("cleanup.interval: %w", err)
+	}
+	if d, err := time.ParseDuration(c.Duplicates.Tolerance); err != nil || d < 0 {
+		return fmt.Errorf("duplicates.tolerance: invalid duration %q", c.Duplicates.Tolerance)
+	}
+	if d, err := time.ParseDuration(c.Conflicts.Window); err != nil || d < 0 {
+		return fmt.Errorf("conflicts.window: invalid duration %q", c.Conflicts.Window)
+	}
+	if c.Merge.ConfirmRemovals < 0 {
+		return fmt.Errorf("merge.confirm_removals: must be 0 or more, got %d", c.Merge.ConfirmRemovals)
+	}
+	if _, err := ParseSize(c.Parser.MaxFileSize); err != nil {
+		return fmt.Errorf("parser.max_file_size: %w", err)
+	}
+	for _, pattern := range c.Watch.Ignore {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("watch.ignore: invalid pattern %q", pattern)
+		}
+	}
+	if err := c.WorkHours.validate(); err != nil {
+		return err
+	}
+	switch c.UI.Background {
+	case "auto", "light", "dark":
+	default:
+		return fmt.Errorf("ui.background: must be auto, light, or dark, got %q", c.UI.Background)
+	}
+	for name := range c.Profiles {
+		if !validProfileName(name) {
+			return fmt.Errorf("profiles: invalid profile name %q (use letters, digits, - and _)", name)
+		}
+	}
+	if len(c.Workspaces) > MaxWorkspaces {
+		return fmt.Errorf("workspaces: at most %d, one per number key, got %d", MaxWorkspaces, len(c.Workspaces))
+	}
+	for _, w := range c.Workspaces {
+		if strings.TrimSpace(w.Name) == "" {
+			return fmt.Errorf("workspaces: every workspace needs a name")
+		}
+	}
+	for _, kw := range c.Parser.Keywords {
+		if kw == "" || kw == "@" || strings.ContainsAny(kw, " \t[]()") {
+			return fmt.Errorf("parser.keywords: invalid keyword %q", kw)
+		}
+	}
+	for tag, schedule := range c.Parser.TagDefaults {
+		if _, err := datetime.Next(schedule, time.Now()); err != nil {
+			return fmt.Errorf("parser.tag_defaults: invalid time %q for #%s", schedule, tag)
+		}
+	}
+	if d, err := time.ParseDuration(c.Sources.Interval); err != nil || d <= 0 {
+		return fmt.Errorf("sources.interval: invalid duration %q", c.Sources.Interval)
+	}
+	for _, gh := range c.Sources.GitHub {
+		if owner, name, ok := strings.Cut(gh.Repo, "/"); !ok || owner == "" || name == "" {
+			return fmt.Errorf("sources.github: repo must be owner/name, got %q", gh.Repo)
+		}
+		if !validOnAcknowledge(gh.OnAcknowledge) {
+			return fmt.Errorf("sources.github: on_acknowledge must be comment, label, or empty, got %q", gh.OnAcknowledge)
+		}
+		if !validInterval(gh.Interval) {
+			return fmt.Errorf("sources.github: invalid interval %q", gh.Interval)
+		}
+	}
+	for _, gl := range c.Sources.GitLab {
+		if gl.Project == "" {
+			return fmt.Errorf("sources.gitlab: project is required")
+		}
+		if !validOnAcknowledge(gl.OnAcknowledge) {
+			return fmt.Errorf("sources.gitlab: on_acknowledge must be comment, label, or empty, got %q", gl.OnAcknowledge)
+		}
+		if !validInterval(gl.Interval) {
+			return fmt.Errorf("sources.gitlab: invalid interval %q", gl.Interval)
+		}
+	}
+	for _, j := range c.Sources.Jira {
+		if !strings.HasPrefix(j.URL, "https://") && !strings.HasPrefix(j.URL, "http://") {
+			return fmt.Errorf("sources.jira: url must be the site's web address, got %q", j.URL)
+		}
+		if !validInterval(j.Interval) {
+			return fmt.Errorf("sources.jira: invalid interval %q", j.Interval)
+		}
+	}
+	for _, im := range c.Sources.IMAP {
+		if im.Server == "" || im.Username == "" {
+			return fmt.Errorf("sources.imap: server and username are required")
+		}
+		if !im.Flagged && strings.TrimSpace(im.Search) == "" {
+			return fmt.Errorf("sources.imap: set flagged, search, or both for %s", im.Username)
+		}
+		if d, err := time.ParseDuration(im.Delay); im.Delay != "" && (err != nil || d < 0) {
+			return fmt.Errorf("sources.imap: invalid delay %q", im.Delay)
+		}
+		if !validInterval(im.Interval) {
+			return fmt.Errorf("sources.imap: invalid interval %q", im.Interval)
+		}
+	}
+	for path, interval := range c.Sources.Paths {
+		if ParseInterval(interval) <= 0 {
+			return fmt.Errorf("sources.paths: invalid interval %q for %s", interval, path)
+		}
+	}
+	for _, f := range c.Sources.Feeds {
+		if !strings.HasPrefix(f.URL, "https://") && !strings.HasPrefix(f.URL, "http://") {
+			return fmt.Errorf("sources.feed: url must be the feed's web address, got %q", f.URL)
+		}
+		if !validInterval(f.Interval) {
+			return fmt.Errorf("sources.feed: invalid interval %q", f.Interval)
+		}
+		if strings.ContainsAny(f.Tag, " \t#") {
+			return fmt.Errorf("sources.feed: invalid tag %q", f.Tag)
+		}
+	}
+	return nil
+}
+
+// Bounds returns the start and end of the working day as offsets from
+// midnight. Invalid hours fall back to 09:00-17:00.
+func (c WorkHoursConfig) Bounds() (start, end time.Duration) {
+	if c.validate() != nil {
+		return 9 * time.Hour, 17 * time.Hour
+	}
+	sh, sm, _ := ParseClock(c.Start)
+	eh, em, _ := ParseClock(c.End)
+	return time.Duration(sh)*time.Hour + time.Duration(sm)*time.Minute,
+		time.Duration(eh)*time.Hour + time.Duration(em)*time.Minute
+}
+
+func (c WorkHoursConfig) validate() error {
+	sh, sm, err := ParseClock(c.Start)
+	if err != nil {
+		return fmt.Errorf("work_hours.start: %w", err)
+	}
+	eh, em, err := ParseClock(c.End)
+	if err != nil {
+		return fmt.Errorf("work_hours.end: %w", err)
+	}
+	if eh*60+em <= sh*60+sm {
+		return fmt.Errorf("work_hours: end %s must be after start %s", c.End, c.Start)
+	}
+	return nil
+}
+
+// WriteStarter writes a new config file at path with the settings chosen
+// during first-run setup. Everything else is left at its default.
+func WriteStarter(path string, c *Config) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	starter := struct {
+		Paths         []string           `toml:"paths"`
+		Notifications NotificationConfig `toml:"notifications"`
+		UI            struct {
+			Theme string `toml:"theme,omitempty"`
+		} `toml:"ui"`
+		WorkHours WorkHoursConfig `toml:"work_hours"`
+	}{
+		Paths:         c.Paths,
+		Notifications: c.Notifications,
+		WorkHours:     c.WorkHours,
+	}
+	starter.UI.Theme = c.UI.Theme
+
+	var buf bytes.Buffer
+	buf.WriteString("# go_remind config, written by first-run setup.\n# See the README for every setting.\n\n")
+	if err := toml.NewEncoder(&buf).Encode(starter); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
+
+// validProfileName reports whether name can be used as a profile's
+// directory name
+func validProfileName(name string) bool {
+	if name == "" || name == DefaultProfile {
+		return false
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+// ExpandPath expands a leading ~ to the user's home directory
+func ExpandPath(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(homeDir, strings.TrimPrefix(path, "~"))
+}
+
+// ParseClock parses a 24h "HH:MM" time of day into hour and minute
+func ParseClock(s string) (int, int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid time of day %q (want HH:MM)", s)
+	}
+	return t.Hour(), t.Minute(), nil
+}
+
+// ParseSize parses a size in bytes like "500KB", "10MB", or "1GB", where a
+// KB is 1024 bytes, or a plain number of bytes. An empty string is zero.
+func ParseSize(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	upper := strings.ToUpper(strings.TrimSpace(s))
+	var unit int64 = 1
+	for _, u := range []struct {
+		suffix string
+		bytes  int64
+	}{{"KB", 1 << 10}, {"MB", 1 << 20}, {"GB", 1 << 30}, {"B", 1}} {
+		if num, ok := strings.CutSuffix(upper, u.suffix); ok {
+			upper, unit = strings.TrimSpace(num), u.bytes
+			break
+		}
+	}
+	n, err := strconv.ParseInt(upper, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q (want e.g. 500KB or 10MB)", s)
+	}
+	return n * unit, nil
+}
+
+// ParseAge parses an age like "30d", "2w", or any Go duration such as "36h".
+// An empty string is zero.
+func ParseAge(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	units := map[byte]time.Duration{'d': 24 * time.Hour, 'w': 7 * 24 * time.Hour}
+	if unit, ok := units[s[len(s)-1]]; ok {
+		n, err := strconv.Atoi(s[:len(s)-1])
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid age %q (want e.g. 30d, 2w, or 12h)", s)
+		}
+		return time.Duration(n) * unit, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid age %q (want e.g. 30d, 2w, or 12h)", s)
+	}
+	return d, nil
+}
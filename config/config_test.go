@@ -0,0 +1,242 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	content := `[keys]
+detail = "i"
+up = ["up", "k", "ctrl+p"]
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	bindings := cfg.KeyBindings()
+	if got := bindings["detail"]; len(got) != 1 || got[0] != "i" {
+		t.Errorf("detail = %v, want [i]", got)
+	}
+	if got := bindings["up"]; len(got) != 3 || got[2] != "ctrl+p" {
+		t.Errorf("up = %v, want [up k ctrl+p]", got)
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	cfg, err := Load(filepath.Join(t.TempDir(), "missing.toml"))
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if !cfg.Notifications.Enabled || cfg.UI.Background != "auto" || !cfg.UI.Alerts || !cfg.UI.Bell {
+		t.Errorf("Load() of a missing file should return defaults, got %+v", cfg)
+	}
+}
+
+func TestLoadProfiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	content := `paths = ["~/notes"]
+
+[sync]
+enabled = true
+
+[profiles.work]
+paths = ["~/work"]
+theme = "Nord"
+notifications = false
+
+[profiles.personal]
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if names := cfg.ProfileNames(); len(names) != 2 || names[0] != "personal" || names[1] != "work" {
+		t.Errorf("ProfileNames() = %v, want [personal work]", names)
+	}
+
+	work, err := cfg.ForProfile("work")
+	if err != nil {
+		t.Fatalf("ForProfile() error: %v", err)
+	}
+	if len(work.Paths) != 1 || work.Paths[0] != "~/work" || work.UI.Theme != "Nord" {
+		t.Errorf("work profile = paths %v, theme %q; want [~/work] and Nord", work.Paths, work.UI.Theme)
+	}
+	if work.Notifications.Enabled || work.Sync.Enabled {
+		t.Errorf("work profile should have notifications and sync off")
+	}
+	if !cfg.Notifications.Enabled || !cfg.Sync.Enabled || cfg.Paths[0] != "~/notes" {
+		t.Errorf("ForProfile() changed the default settings")
+	}
+
+	if def, _ := cfg.ForProfile(DefaultProfile); def != cfg {
+		t.Errorf("ForProfile(%q) should return the default settings", DefaultProfile)
+	}
+	if _, err := cfg.ForProfile("school"); err == nil {
+		t.Error("ForProfile() of an unknown profile should fail")
+	}
+}
+
+func TestAppendWorkspace(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	content := "# My settings\npaths = [\"~/notes\"]\n\n[keys]\nquit = \"Q\"\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	triage := WorkspaceConfig{Name: "Morning triage", Filter: "#work", Group: "tag", Sort: "priority", Layout: "card"}
+	if err := AppendWorkspace(path, triage); err != nil {
+		t.Fatalf("AppendWorkspace() error: %v", err)
+	}
+	if err := AppendWorkspace(path, WorkspaceConfig{Name: "Everything"}); err != nil {
+		t.Fatalf("AppendWorkspace() error: %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	if !strings.HasPrefix(string(data), content) {
+		t.Errorf("AppendWorkspace() changed the existing settings:\n%s", data)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if len(cfg.Workspaces) != 2 || cfg.Workspaces[0] != triage || cfg.Workspaces[1].Name != "Everything" {
+		t.Errorf("Workspaces = %+v, want triage then Everything", cfg.Workspaces)
+	}
+	if keys := cfg.KeyBindings()["quit"]; len(keys) != 1 || keys[0] != "Q" {
+		t.Errorf("quit keys = %v, want the [keys] table intact", keys)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+	}{
+		{"bad digest time", func(c *Config) { c.Digest.Time = "8am" }},
+		{"bad report day", func(c *Config) { c.Report.Day = "someday" }},
+		{"report sent nowhere", func(c *Config) { c.Report.Enabled = true }},
+		{"report email without a server", func(c *Config) { c.Report.Email = true }},
+		{"bad sync interval", func(c *Config) { c.Sync.Interval = "often" }},
+		{"bad background", func(c *Config) { c.UI.Background = "purple" }},
+		{"keyword with space", func(c *Config) { c.Parser.Keywords = []string{"remind me"} }},
+		{"bad tag default", func(c *Config) { c.Parser.TagDefaults = map[string]string{"standup": "first thing"} }},
+		{"bad cleanup age", func(c *Config) { c.Cleanup.DeleteAfter = "3 months" }},
+		{"bad duplicate tolerance", func(c *Config) { c.Duplicates.Tolerance = "soon" }},
+		{"bad conflict window", func(c *Config) { c.Conflicts.Window = "-5m" }},
+		{"negative merge threshold", func(c *Config) { c.Merge.ConfirmRemovals = -1 }},
+		{"bad max file size", func(c *Config) { c.Parser.MaxFileSize = "huge" }},
+		{"bad ignore pattern", func(c *Config) { c.Watch.Ignore = []string{"[.md"} }},
+		{"github repo without owner", func(c *Config) { c.Sources.GitHub = []GitHubSourceConfig{{Repo: "repo"}} }},
+		{"bad github interval", func(c *Config) { c.Sources.GitHub = []GitHubSourceConfig{{Repo: "me/app", Interval: "-1m"}} }},
+		{"bad acknowledge action", func(c *Config) { c.Sources.GitLab = []GitLabSourceConfig{{Project: "g/p", OnAcknowledge: "close"}} }},
+		{"imap without a rule", func(c *Config) { c.Sources.IMAP = []IMAPSourceConfig{{Server: "imap.example.com", Username: "me"}} }},
+		{"feed without a url", func(c *Config) { c.Sources.Feeds = []FeedSourceConfig{{URL: "example.com/cfp.rss"}} }},
+		{"bad path interval", func(c *Config) { c.Sources.Paths = map[string]string{"~/notes": "hourly"} }},
+		{"bad profile name", func(c *Config) { c.Profiles = map[string]ProfileConfig{"my/work": {}} }},
+		{"workspace without a name", func(c *Config) { c.Workspaces = []WorkspaceConfig{{Filter: "#work"}} }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			tt.modify(cfg)
+			if err := cfg.Validate(); err == nil {
+				t.Error("Validate() should fail")
+			}
+		})
+	}
+
+	if err := Default().Validate(); err != nil {
+		t.Errorf("Default().Validate() error: %v", err)
+	}
+}
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"", 0},
+		{"30d", 30 * 24 * time.Hour},
+		{"2w", 14 * 24 * time.Hour},
+		{"36h", 36 * time.Hour},
+	}
+	for _, tt := range tests {
+		got, err := ParseAge(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseAge(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+	}
+	for _, bad := range []string{"d", "-3d", "soon", "1.5w"} {
+		if _, err := ParseAge(bad); err == nil {
+			t.Errorf("ParseAge(%q) should fail", bad)
+		}
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+	}{
+		{"", 0},
+		{"0", 0},
+		{"2048", 2048},
+		{"500KB", 500 << 10},
+		{"10MB", 10 << 20},
+		{"1 gb", 1 << 30},
+	}
+	for _, tt := range tests {
+		got, err := ParseSize(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseSize(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+	}
+	for _, bad := range []string{"MB", "-1KB", "big", "1.5MB"} {
+		if _, err := ParseSize(bad); err == nil {
+			t.Errorf("ParseSize(%q) should fail", bad)
+		}
+	}
+}
+
+func TestWriteStarter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".go_remind", "config.toml")
+	cfg := Default()
+	cfg.Paths = []string{"~/notes"}
+	cfg.Notifications.Enabled = false
+	cfg.UI.Theme = "Nord"
+	cfg.WorkHours = WorkHoursConfig{Start: "08:30", End: "16:30"}
+	if err := WriteStarter(path, cfg); err != nil {
+		t.Fatalf("WriteStarter() error: %v", err)
+	}
+
+	got, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if len(got.Paths) != 1 || got.Paths[0] != "~/notes" || got.Notifications.Enabled || got.UI.Theme != "Nord" {
+		t.Errorf("Load() = paths %v, notifications %v, theme %q; want what was written",
+			got.Paths, got.Notifications.Enabled, got.UI.Theme)
+	}
+	if got.WorkHours != cfg.WorkHours {
+		t.Errorf("work hours = %+v, want %+v", got.WorkHours, cfg.WorkHours)
+	}
+
+	cfg.WorkHours.End = "08:00"
+	if err := WriteStarter(path, cfg); err == nil {
+		t.Error("WriteStarter() should reject work hours ending before they start")
+	}
+}
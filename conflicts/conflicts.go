@@ -0,0 +1,130 @@
+// Package conflicts finds reminders booked so close together that one of
+// them is probably a mistake, and the free time between them.
+package conflicts
+
+import (
+	"sort"
+	"time"
+
+	"go_remind/pkg/reminder"
+)
+
+// open reports whether r still has a time to keep: not done or set aside
+func open(r *reminder.Reminder) bool {
+	return r.Status != reminder.Acknowledged && !r.Status.Parked()
+}
+
+// Near returns the open reminders due within window of at, earliest first,
+// leaving out except (the reminder being edited, if any). A window of 0
+// turns conflict checks off.
+func Near(reminders []*reminder.Reminder, at time.Time, window time.Duration, except *reminder.Reminder) []*reminder.Reminder {
+	if window <= 0 {
+		return nil
+	}
+	var near []*reminder.Reminder
+	for _, r := range reminders {
+		if r == except || !open(r) {
+			continue
+		}
+		if d := r.DateTime.Sub(at); d <= window && d >= -window {
+			near = append(near, r)
+		}
+	}
+	sort.SliceStable(near, func(i, j int) bool { return near[i].DateTime.Before(near[j].DateTime) })
+	return near
+}
+
+// Flag returns the open reminders that have another open reminder due
+// within window of them
+func Flag(reminders []*reminder.Reminder, window time.Duration) map[*reminder.Reminder]bool {
+	if window <= 0 {
+		return nil
+	}
+	var sorted []*reminder.Reminder
+	for _, r := range reminders {
+		if open(r) {
+			sorted = append(sorted, r)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].DateTime.Before(sorted[j].DateTime) })
+
+	// Sorted by time, a reminder conflicts with another exactly when it's
+	// within window of a neighbor
+	flagged := make(map[*reminder.Reminder]bool)
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].DateTime.Sub(sorted[i-1].DateTime) <= window {
+			flagged[sorted[i]] = true
+			flagged[sorted[i-1]] = true
+		}
+	}
+	return flagged
+}
+
+// slotStep aligns suggested start times to the quarter hour
+const slotStep = 15 * time.Minute
+
+// Slot is a stretch of free time. Anything starting at Start and lasting
+// the requested duration ends by End.
+type Slot struct {
+	Start time.Time
+	End   time.Time
+}
+
+// FreeSlots returns up to max free stretches of at least d, on the day of
+// from and the next, between dayStart and dayEnd (offsets from midnight)
+// and not before from. Time within window of an open reminder is busy, so
+// a reminder added at a slot's start doesn't conflict with anything.
+func FreeSlots(reminders []*reminder.Reminder, from time.Time, d, window, dayStart, dayEnd time.Duration, max int) []Slot {
+	var busy []time.Time
+	for _, r := range reminders {
+		if open(r) {
+			busy = append(busy, r.DateTime)
+		}
+	}
+	sort.Slice(busy, func(i, j int) bool { return busy[i].Before(busy[j]) })
+
+	var slots []Slot
+	midnight := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	for day := 0; day < 2 && len(slots) < max; day++ {
+		date := midnight.AddDate(0, 0, day)
+		dayOpen, dayClose := date.Add(dayStart), date.Add(dayEnd)
+		cursor := roundUp(laterOf(dayOpen, from))
+
+		for _, t := range busy {
+			if len(slots) == max {
+				break
+			}
+			busyFrom, busyTo := t.Add(-window), t.Add(window)
+			if !busyTo.After(cursor) || !busyFrom.Before(dayClose) {
+				continue
+			}
+			// A slot has to end by the time the busy time starts
+			if !cursor.Add(d).After(busyFrom) {
+				slots = append(slots, Slot{Start: cursor, End: busyFrom})
+			}
+			if next := roundUp(busyTo.Add(time.Nanosecond)); next.After(cursor) {
+				cursor = next
+			}
+		}
+		if len(slots) < max && !cursor.Add(d).After(dayClose) {
+			slots = append(slots, Slot{Start: cursor, End: dayClose})
+		}
+	}
+	return slots
+}
+
+// roundUp returns t, or the next quarter hour if t isn't on one
+func roundUp(t time.Time) time.Time {
+	r := t.Truncate(slotStep)
+	if r.Before(t) {
+		return r.Add(slotStep)
+	}
+	return r
+}
+
+func laterOf(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
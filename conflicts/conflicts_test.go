@@ -0,0 +1,126 @@
+package conflicts
+
+import (
+	"testing"
+	"time"
+
+	"go_remind/pkg/reminder"
+)
+
+func TestNear(t *testing.T) {
+	base := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+	standup := &reminder.Reminder{Description: "Standup", DateTime: base, Status: reminder.Pending}
+	review := &reminder.Reminder{Description: "Review", DateTime: base.Add(-10 * time.Minute), Status: reminder.Triggered}
+	lunch := &reminder.Reminder{Description: "Lunch", DateTime: base.Add(2 * time.Hour), Status: reminder.Pending}
+	done := &reminder.Reminder{Description: "Done", DateTime: base.Add(5 * time.Minute), Status: reminder.Acknowledged}
+	parked := &reminder.Reminder{Description: "Parked", DateTime: base.Add(5 * time.Minute), Status: reminder.Someday}
+	all := []*reminder.Reminder{standup, review, lunch, done, parked}
+
+	got := Near(all, base.Add(time.Minute), 15*time.Minute, nil)
+	if len(got) != 2 || got[0] != review || got[1] != standup {
+		t.Errorf("Near() = %v, want [Review Standup]", descriptions(got))
+	}
+	if got := Near(all, base, 15*time.Minute, standup); len(got) != 1 || got[0] != review {
+		t.Errorf("Near() except Standup = %v, want [Review]", descriptions(got))
+	}
+	if got := Near(all, base.Add(15*time.Minute), 15*time.Minute, nil); len(got) != 1 || got[0] != standup {
+		t.Errorf("Near() at the edge of the window = %v, want [Standup]", descriptions(got))
+	}
+	if got := Near(all, base, 0, nil); got != nil {
+		t.Errorf("Near() with window 0 = %v, want none", descriptions(got))
+	}
+}
+
+func TestFlag(t *testing.T) {
+	base := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+	a := &reminder.Reminder{Description: "A", DateTime: base, Status: reminder.Pending}
+	b := &reminder.Reminder{Description: "B", DateTime: base.Add(20 * time.Minute), Status: reminder.Pending}
+	c := &reminder.Reminder{Description: "C", DateTime: base.Add(30 * time.Minute), Status: reminder.Pending}
+	d := &reminder.Reminder{Description: "D", DateTime: base.Add(5 * time.Minute), Status: reminder.Acknowledged}
+
+	flagged := Flag([]*reminder.Reminder{c, a, b, d}, 15*time.Minute)
+	if flagged[a] || !flagged[b] || !flagged[c] || flagged[d] {
+		t.Errorf("Flag() = %v, want B and C", flagged)
+	}
+	if Flag([]*reminder.Reminder{a, b}, 0) != nil {
+		t.Error("Flag() with window 0 flagged reminders")
+	}
+}
+
+func descriptions(rs []*reminder.Reminder) []string {
+	out := make([]string, len(rs))
+	for i, r := range rs {
+		out[i] = r.Description
+	}
+	return out
+}
+
+func TestFreeSlots(t *testing.T) {
+	day := func(d, hour, min int) time.Time { return time.Date(2026, 3, 4+d, hour, min, 0, 0, time.UTC) }
+	reminders := []*reminder.Reminder{
+		{Description: "Standup", DateTime: day(0, 10, 0), Status: reminder.Pending},
+		{Description: "Review", DateTime: day(0, 11, 0), Status: reminder.Pending},
+		{Description: "Done", DateTime: day(0, 12, 0), Status: reminder.Acknowledged},
+		{Description: "Demo", DateTime: day(0, 16, 30), Status: reminder.Pending},
+		{Description: "Early", DateTime: day(1, 8, 50), Status: reminder.Pending},
+	}
+	const window = 15 * time.Minute
+	work := func(from time.Time, d time.Duration, max int) []Slot {
+		return FreeSlots(reminders, from, d, window, 9*time.Hour, 17*time.Hour, max)
+	}
+
+	tests := []struct {
+		name string
+		from time.Time
+		d    time.Duration
+		max  int
+		want []Slot
+	}{
+		{
+			name: "gaps between reminders",
+			from: day(0, 9, 7),
+			d:    30 * time.Minute,
+			max:  5,
+			want: []Slot{
+				{day(0, 9, 15), day(0, 9, 45)},
+				{day(0, 11, 30), day(0, 16, 15)},
+				{day(1, 9, 15), day(1, 17, 0)},
+			},
+		},
+		{
+			name: "too long for the morning gaps",
+			from: day(0, 9, 0),
+			d:    time.Hour,
+			max:  1,
+			want: []Slot{{day(0, 11, 30), day(0, 16, 15)}},
+		},
+		{
+			name: "after hours moves to tomorrow",
+			from: day(0, 18, 0),
+			d:    30 * time.Minute,
+			max:  5,
+			want: []Slot{{day(1, 9, 15), day(1, 17, 0)}},
+		},
+		{
+			name: "end of the day fits exactly",
+			from: day(0, 16, 45),
+			d:    15 * time.Minute,
+			max:  1,
+			want: []Slot{{day(0, 16, 45), day(0, 17, 0)}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := work(tt.from, tt.d, tt.max)
+			if len(got) != len(tt.want) {
+				t.Fatalf("FreeSlots() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Start.Equal(tt.want[i].Start) || !got[i].End.Equal(tt.want[i].End) {
+					t.Errorf("slot %d = %v-%v, want %v-%v", i, got[i].Start, got[i].End, tt.want[i].Start, tt.want[i].End)
+				}
+			}
+		})
+	}
+}
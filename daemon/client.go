@@ -0,0 +1,145 @@
+package daemon
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"go_remind/pkg/reminder"
+	"go_remind/statesync"
+)
+
+// Client is a connection to a running daemon. It implements the same Save
+// method as state.Store, so the TUI can use it in place of a local store.
+type Client struct {
+	conn net.Conn
+
+	mu   sync.Mutex // Guards enc and base
+	enc  *json.Encoder
+	base []*reminder.Reminder // Last server state applied by the caller
+
+	initial []*reminder.Reminder
+	updates chan []*reminder.Reminder
+	errs    chan error
+}
+
+// Dial connects to the daemon and waits for its initial state
+func Dial(socketPath string) (*Client, error) {
+	conn, err := net.DialTimeout("unix", socketPath, time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		conn:    conn,
+		enc:     json.NewEncoder(conn),
+		updates: make(chan []*reminder.Reminder, 10),
+		errs:    make(chan error, 10),
+	}
+
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+
+	// The server always starts with the full state
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if !scanner.Scan() {
+		conn.Close()
+		return nil, errors.New("daemon closed the connection")
+	}
+	conn.SetReadDeadline(time.Time{})
+
+	var msg message
+	if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil || msg.Type != msgState {
+		conn.Close()
+		return nil, fmt.Errorf("unexpected greeting from daemon")
+	}
+	if c.initial, err = decodeReminders(msg.Reminders); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	c.base = statesync.Snapshot(c.initial)
+
+	go c.readLoop(scanner)
+	return c, nil
+}
+
+// Reminders returns the state received when connecting
+func (c *Client) Reminders() []*reminder.Reminder {
+	return c.initial
+}
+
+// Updates delivers every state pushed by the daemon. The channel is closed
+// when the connection drops.
+func (c *Client) Updates() <-chan []*reminder.Reminder {
+	return c.updates
+}
+
+// Errors delivers errors reported by the daemon
+func (c *Client) Errors() <-chan error {
+	return c.errs
+}
+
+// Base returns the last server state the caller applied
+func (c *Client) Base() []*reminder.Reminder {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.base
+}
+
+// SetBase records that the caller has merged the given server state.
+// Later saves are merged by the daemon relative to it.
+func (c *Client) SetBase(reminders []*reminder.Reminder) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.base = statesync.Snapshot(reminders)
+}
+
+// Save sends the caller's reminders to the daemon to be merged
+func (c *Client) Save(reminders []*reminder.Reminder) error {
+	data, err := encodeReminders(reminders)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	base, err := encodeReminders(c.base)
+	if err != nil {
+		return err
+	}
+	return c.enc.Encode(message{Type: msgSave, Base: base, Reminders: data})
+}
+
+// Close disconnects from the daemon
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+func (c *Client) readLoop(scanner *bufio.Scanner) {
+	defer close(c.updates)
+
+	for scanner.Scan() {
+		var msg message
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			continue
+		}
+
+		switch msg.Type {
+		case msgState:
+			reminders, err := decodeReminders(msg.Reminders)
+			if err != nil {
+				continue
+			}
+			c.updates <- reminders
+		case msgError:
+			select {
+			case c.errs <- errors.New(msg.Error):
+			default:
+			}
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package daemon
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"go_remind/pkg/reminder"
+	"go_remind/pkg/state"
+)
+
+// startServer runs a daemon on a temporary socket
+func startServer(t *testing.T, reminders []*reminder.Reminder) (*Server, string) {
+	t.Helper()
+
+	// Unix socket paths are length-limited, so avoid t.TempDir's long names
+	dir, err := os.MkdirTemp("", "gr")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	store := state.NewStore(filepath.Join(dir, "state.json"))
+	server := NewServer(store, reminders, false)
+	socketPath := SocketPath(dir)
+	if err := server.Listen(socketPath); err != nil {
+		t.Fatalf("Listen() error: %v", err)
+	}
+	go server.Serve()
+	t.Cleanup(server.Close)
+	return server, socketPath
+}
+
+// nextUpdate waits for the next state pushed to c
+func nextUpdate(t *testing.T, c *Client) []*reminder.Reminder {
+	t.Helper()
+	select {
+	case reminders := <-c.Updates():
+		return reminders
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timeout waiting for daemon update")
+		return nil
+	}
+}
+
+func TestClientsShareState(t *testing.T) {
+	future := time.Now().Add(time.Hour).Truncate(time.Second)
+	initial := []*reminder.Reminder{{ID: "a", Description: "Existing", DateTime: future}}
+	_, socketPath := startServer(t, initial)
+
+	alice, err := Dial(socketPath)
+	if err != nil {
+		t.Fatalf("Dial() error: %v", err)
+	}
+	defer alice.Close()
+	bob, err := Dial(socketPath)
+	if err != nil {
+		t.Fatalf("Dial() error: %v", err)
+	}
+	defer bob.Close()
+
+	if len(alice.Reminders()) != 1 {
+		t.Fatalf("initial state has %d reminders, want 1", len(alice.Reminders()))
+	}
+
+	// Alice adds a reminder; Bob should see it
+	added := &reminder.Reminder{ID: "b", Description: "Added by Alice", DateTime: future, UpdatedAt: time.Now()}
+	if err := alice.Save(append(alice.Reminders(), added)); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	got := nextUpdate(t, bob)
+	if len(got) != 2 {
+		t.Fatalf("Bob sees %d reminders, want 2", len(got))
+	}
+}
+
+func TestStaleSaveDoesNotDropOtherClientsChanges(t *testing.T) {
+	future := time.Now().Add(time.Hour).Truncate(time.Second)
+	_, socketPath := startServer(t, nil)
+
+	alice, err := Dial(socketPath)
+	if err != nil {
+		t.Fatalf("Dial() error: %v", err)
+	}
+	defer alice.Close()
+	bob, err := Dial(socketPath)
+	if err != nil {
+		t.Fatalf("Dial() error: %v", err)
+	}
+	defer bob.Close()
+
+	a := &reminder.Reminder{ID: "a", Description: "Alice's", DateTime: future, UpdatedAt: time.Now()}
+	if err := alice.Save([]*reminder.Reminder{a}); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	nextUpdate(t, alice)
+	nextUpdate(t, bob)
+
+	// Bob saves without having applied Alice's change; it must survive
+	b := &reminder.Reminder{ID: "b", Description: "Bob's", DateTime: future, UpdatedAt: time.Now()}
+	if err := bob.Save([]*reminder.Reminder{b}); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	got := nextUpdate(t, alice)
+	if len(got) != 2 {
+		t.Errorf("state has %d reminders after concurrent saves, want 2", len(got))
+	}
+}
+
+func TestSecondDaemonRefused(t *testing.T) {
+	_, socketPath := startServer(t, nil)
+
+	other := NewServer(nil, nil, false)
+	if err := other.Listen(socketPath); err == nil {
+		t.Error("Listen() should fail while another daemon is running")
+	}
+}
+
+func TestConcurrentChangesSaveLatestState(t *testing.T) {
+	store := state.NewStore(filepath.Join(t.TempDir(), "state.json"))
+	server := NewServer(store, nil, false)
+	future := time.Now().Add(time.Hour)
+
+	// Saves from file updates at once mustn't interleave, and the last
+	// one written must hold every change
+	const files = 20
+	var wg sync.WaitGroup
+	for i := range files {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			path := fmt.Sprintf("/notes/%d.md", i)
+			server.ApplyFileUpdate(path, []*reminder.Reminder{{ID: path, Description: "Note", SourceFile: path, DateTime: future}})
+		}()
+	}
+	wg.Wait()
+
+	saved, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if len(saved) != files {
+		t.Errorf("saved %d reminders, want all %d", len(saved), files)
+	}
+}
@@ -0,0 +1,46 @@
+package daemon
+
+import (
+	"encoding/json"
+	"path/filepath"
+
+	"go_remind/pkg/reminder"
+	"go_remind/pkg/state"
+)
+
+const socketFileName = "daemon.sock"
+
+// Message types exchanged over the socket, one JSON object per line
+const (
+	msgState = "state" // Server -> client: the full current state
+	msgSave  = "save"  // Client -> server: client's reminders and the base they derive from
+	msgError = "error" // Server -> client: a request failed
+)
+
+// message is the wire format for all daemon traffic
+type message struct {
+	Type      string          `json:"type"`
+	Base      json.RawMessage `json:"base,omitempty"`
+	Reminders json.RawMessage `json:"reminders,omitempty"`
+	Error     string          `json:"error,omitempty"`
+}
+
+// SocketPath returns the daemon socket path for a state directory
+func SocketPath(stateDir string) string {
+	return filepath.Join(stateDir, socketFileName)
+}
+
+func encodeReminders(reminders []*reminder.Reminder) (json.RawMessage, error) {
+	if reminders == nil {
+		reminders = []*reminder.Reminder{}
+	}
+	data, err := state.Marshal(reminders)
+	return json.RawMessage(data), err
+}
+
+func decodeReminders(data json.RawMessage) ([]*reminder.Reminder, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	return state.Unmarshal(data)
+}
@@ -0,0 +1,285 @@
+package daemon
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"log"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"go_remind/notify"
+	"go_remind/pkg/clock"
+	"go_remind/pkg/reminder"
+	"go_remind/pkg/state"
+	"go_remind/statesync"
+)
+
+// Server owns the reminder state and serves it to TUI/CLI clients over a
+// Unix socket. It triggers reminders on its own, so notifications fire even
+// when no TUI is open.
+type Server struct {
+	store  *state.Store
+	notify bool
+	clock  clock.Clock
+
+	mu        sync.Mutex
+	reminders []*reminder.Reminder
+	files     reminder.FileIndex // reminders by source file, for merges
+	clients   map[*serverConn]bool
+
+	// saving is held from taking a snapshot until it's saved and sent, so
+	// saves from the ticker and clients never interleave or go out of order
+	saving sync.Mutex
+
+	listener net.Listener
+	done     chan struct{}
+}
+
+// serverConn is a single connected client
+type serverConn struct {
+	conn net.Conn
+	mu   sync.Mutex // Guards enc
+	enc  *json.Encoder
+}
+
+// NewServer creates a Server for the given initial reminders.
+// store may be nil to disable persistence.
+func NewServer(store *state.Store, reminders []*reminder.Reminder, notifications bool) *Server {
+	return &Server{
+		store:     store,
+		notify:    notifications,
+		clock:     clock.Real,
+		reminders: reminders,
+		clients:   make(map[*serverConn]bool),
+		done:      make(chan struct{}),
+	}
+}
+
+// Listen binds the Unix socket. A stale socket left by a crashed daemon is
+// replaced; a live one is reported as an error.
+func (s *Server) Listen(socketPath string) error {
+	if conn, err := net.Dial("unix", socketPath); err == nil {
+		conn.Close()
+		return errors.New("another daemon is already running on " + socketPath)
+	}
+	_ = os.Remove(socketPath)
+
+	l, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return err
+	}
+	s.listener = l
+	return nil
+}
+
+// Serve accepts clients and runs the trigger loop until Close is called
+func (s *Server) Serve() error {
+	go s.tickLoop()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			select {
+			case <-s.done:
+				return nil
+			default:
+				return err
+			}
+		}
+		go s.handle(conn)
+	}
+}
+
+// Close stops the server and disconnects all clients
+func (s *Server) Close() {
+	close(s.done)
+	if s.listener != nil {
+		s.listener.Close()
+	}
+	s.mu.Lock()
+	for c := range s.clients {
+		c.conn.Close()
+	}
+	s.mu.Unlock()
+}
+
+// Reminders returns a copy of the current reminders
+func (s *Server) Reminders() []*reminder.Reminder {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return statesync.Snapshot(s.reminders)
+}
+
+// ApplyFileUpdate merges reminders parsed from a watched file and pushes the
+// new state to clients
+func (s *Server) ApplyFileUpdate(filePath string, reminders []*reminder.Reminder) {
+	reminders = DropMuted(s.store, reminders, filePath)
+
+	s.mu.Lock()
+	s.reminders = s.files.MergeFromFile(s.reminders, filePath, reminders)
+	if err := reminder.ResolveRelative(s.reminders, s.clock.Now()); err != nil {
+		log.Printf("Warning: relative reminders: %v", err)
+	}
+	reminder.SortByDateTime(s.reminders)
+	s.mu.Unlock()
+
+	s.changed()
+}
+
+// DropMuted leaves out the parsed reminders that were deleted in a TUI,
+// which saves their mutes to store. files are the parsed files, as for
+// state.DropMuted.
+func DropMuted(store *state.Store, parsed []*reminder.Reminder, files ...string) []*reminder.Reminder {
+	if store == nil {
+		return parsed
+	}
+	mutes, err := store.LoadMuted()
+	if err != nil {
+		log.Printf("Warning: could not load muted reminders: %v", err)
+		return parsed
+	}
+	kept, left := state.DropMuted(mutes, parsed, files...)
+	if len(left) != len(mutes) {
+		if err := store.SaveMuted(left); err != nil {
+			log.Printf("Warning: could not save muted reminders: %v", err)
+		}
+	}
+	return kept
+}
+
+func (s *Server) handle(conn net.Conn) {
+	c := &serverConn{conn: conn, enc: json.NewEncoder(conn)}
+
+	s.mu.Lock()
+	s.clients[c] = true
+	snapshot := statesync.Snapshot(s.reminders)
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.clients, c)
+		s.mu.Unlock()
+		conn.Close()
+	}()
+
+	if err := c.sendState(snapshot); err != nil {
+		return
+	}
+
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		var msg message
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			c.send(message{Type: msgError, Error: "invalid message: " + err.Error()})
+			continue
+		}
+
+		switch msg.Type {
+		case msgSave:
+			if err := s.applySave(msg); err != nil {
+				c.send(message{Type: msgError, Error: err.Error()})
+			}
+		default:
+			c.send(message{Type: msgError, Error: "unknown message type " + msg.Type})
+		}
+	}
+}
+
+// applySave merges a client's reminders into the server state. The client's
+// base lets the merge tell its deletions apart from changes it hasn't seen.
+func (s *Server) applySave(msg message) error {
+	base, err := decodeReminders(msg.Base)
+	if err != nil {
+		return err
+	}
+	theirs, err := decodeReminders(msg.Reminders)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.reminders = statesync.Merge(base, s.reminders, theirs)
+	s.mu.Unlock()
+
+	s.changed()
+	return nil
+}
+
+// tickLoop triggers due reminders every second
+func (s *Server) tickLoop() {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			var triggered []*reminder.Reminder
+			rolled := false
+			now := s.clock.Now()
+			s.mu.Lock()
+			for _, r := range s.reminders {
+				if r.RollOver(now) {
+					rolled = true
+				}
+				if r.Status.Scheduled() && r.IsDueAt(now) {
+					r.Status = reminder.Triggered
+					triggered = append(triggered, r)
+				}
+			}
+			s.mu.Unlock()
+
+			if len(triggered) == 0 && !rolled {
+				continue
+			}
+			if s.notify {
+				for _, r := range triggered {
+					_ = notify.Send("Reminder", r.Description)
+				}
+			}
+			s.changed()
+		}
+	}
+}
+
+// changed persists the state and broadcasts it to every client
+func (s *Server) changed() {
+	s.saving.Lock()
+	defer s.saving.Unlock()
+
+	s.mu.Lock()
+	snapshot := statesync.Snapshot(s.reminders)
+	clients := make([]*serverConn, 0, len(s.clients))
+	for c := range s.clients {
+		clients = append(clients, c)
+	}
+	s.mu.Unlock()
+
+	if s.store != nil {
+		if err := s.store.Save(snapshot); err != nil {
+			log.Printf("Warning: could not save state: %v", err)
+		}
+	}
+	for _, c := range clients {
+		_ = c.sendState(snapshot)
+	}
+}
+
+func (c *serverConn) sendState(reminders []*reminder.Reminder) error {
+	data, err := encodeReminders(reminders)
+	if err != nil {
+		return err
+	}
+	return c.send(message{Type: msgState, Reminders: data})
+}
+
+func (c *serverConn) send(msg message) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.enc.Encode(msg)
+}
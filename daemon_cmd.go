@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"go_remind/config"
+	"go_remind/daemon"
+	"go_remind/pkg/reminder"
+	"go_remind/pkg/state"
+	"go_remind/pkg/watcher"
+	"go_remind/rules"
+)
+
+// runDaemon runs `go_remind daemon [path]`: a background server that owns the
+// state and watches paths, which TUI instances connect to. The rules script,
+// if any, is run over every parse.
+func runDaemon(store *state.Store, cfg *config.Config, engine *rules.Engine, paths []string) {
+	if store == nil {
+		fmt.Fprintln(os.Stderr, "Error: the daemon needs a state store")
+		os.Exit(1)
+	}
+
+	reminders, err := store.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not load state: %v\n", err)
+	}
+
+	var watched []<-chan watcher.FileEvent
+	for _, path := range paths {
+		fileReminders, events, w, err := watchPath(path, nil)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		defer w.Stop()
+		watched = append(watched, events)
+
+		fileReminders, err = engine.Apply(fileReminders)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: rules: %v\n", err)
+		}
+		reminders = reminder.MergeParsed(reminders, daemon.DropMuted(store, fileReminders))
+	}
+	if err := reminder.ResolveRelative(reminders, time.Now()); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: relative reminders: %v\n", err)
+	}
+	reminder.SortByDateTime(reminders)
+
+	server := daemon.NewServer(store, reminders, cfg.Notifications.Enabled)
+	for _, events := range watched {
+		go func() {
+			for event := range events {
+				fileReminders, err := engine.Apply(event.Reminders)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: rules: %v\n", err)
+				}
+				server.ApplyFileUpdate(event.FilePath, fileReminders)
+			}
+		}()
+	}
+	if cfg.Report.Enabled {
+		go scheduleReports(cfg, server.Reminders)
+	}
+	socketPath := daemon.SocketPath(filepath.Dir(store.Path()))
+	if err := server.Listen(socketPath); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	defer os.Remove(socketPath)
+
+	// Shut down cleanly on Ctrl+C or kill
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sigs
+		server.Close()
+	}()
+
+	fmt.Printf("go_remind daemon listening on %s\n", socketPath)
+	if err := server.Serve(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,172 @@
+package dedupe
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"go_remind/pkg/reminder"
+)
+
+// Group is a set of open reminders that look like copies of each other,
+// earliest due first
+type Group []*reminder.Reminder
+
+// Key identifies the group by the IDs of its reminders, so a group the
+// user chose to ignore can be recognized again
+func (g Group) Key() string {
+	ids := make([]string, len(g))
+	for i, r := range g {
+		ids[i] = r.ID
+	}
+	sort.Strings(ids)
+	return strings.Join(ids, ",")
+}
+
+// Normalize reduces a description to lowercase words, so that case,
+// punctuation, and spacing differences don't hide a duplicate
+func Normalize(desc string) string {
+	words := strings.FieldsFunc(strings.ToLower(desc), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	return strings.Join(words, " ")
+}
+
+// Similar reports whether two descriptions are probably the same reminder:
+// the same once normalized, or a typo apart, allowing one edit for every
+// eight letters
+func Similar(a, b string) bool {
+	x, y := []rune(Normalize(a)), []rune(Normalize(b))
+	if len(x) == 0 || len(y) == 0 {
+		return false
+	}
+	allowed := min(len(x), len(y)) / 8
+	if len(x)-len(y) > allowed || len(y)-len(x) > allowed {
+		return false
+	}
+	return distance(x, y) <= allowed
+}
+
+// distance returns the number of single letter insertions, deletions, and
+// substitutions that turn a into b
+func distance(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// Find returns the groups of open reminders with the same normalized
+// description due within tolerance of each other. Groups whose Key is in
+// ignored are left out.
+func Find(reminders []*reminder.Reminder, tolerance time.Duration, ignored map[string]bool) []Group {
+	byDesc := make(map[string][]*reminder.Reminder)
+	for _, r := range reminders {
+		if r.Status == reminder.Acknowledged {
+			continue
+		}
+		key := Normalize(r.Description)
+		byDesc[key] = append(byDesc[key], r)
+	}
+
+	var groups []Group
+	for _, same := range byDesc {
+		if len(same) < 2 {
+			continue
+		}
+		sort.SliceStable(same, func(i, j int) bool { return same[i].DateTime.Before(same[j].DateTime) })
+
+		// Chain reminders whose due times are each within tolerance of the last
+		group := Group{same[0]}
+		for _, r := range same[1:] {
+			if r.DateTime.Sub(group[len(group)-1].DateTime) <= tolerance {
+				group = append(group, r)
+				continue
+			}
+			groups = appendGroup(groups, group, ignored)
+			group = Group{r}
+		}
+		groups = appendGroup(groups, group, ignored)
+	}
+
+	sort.Slice(groups, func(i, j int) bool { return groups[i][0].DateTime.Before(groups[j][0].DateTime) })
+	return groups
+}
+
+func appendGroup(groups []Group, g Group, ignored map[string]bool) []Group {
+	if len(g) < 2 || ignored[g.Key()] {
+		return groups
+	}
+	return append(groups, g)
+}
+
+// Merge folds a group into its earliest reminder, combining tags and
+// keeping it triggered if any copy has triggered, as changed at now.
+// Returns the reminder kept and the copies to remove.
+func Merge(g Group, now time.Time) (kept *reminder.Reminder, removed []*reminder.Reminder) {
+	kept = g[0]
+	for _, r := range g[1:] {
+		for _, tag := range r.Tags {
+			if !hasTag(kept, tag) {
+				kept.Tags = append(kept.Tags, tag)
+			}
+		}
+		if r.Status == reminder.Triggered {
+			kept.Status = reminder.Triggered
+		}
+	}
+	kept.Touch(now)
+	return kept, g[1:]
+}
+
+func hasTag(r *reminder.Reminder, tag string) bool {
+	for _, t := range r.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// Remove returns reminders without the given ones
+func Remove(reminders, removed []*reminder.Reminder) []*reminder.Reminder {
+	drop := make(map[*reminder.Reminder]bool, len(removed))
+	for _, r := range removed {
+		drop[r] = true
+	}
+	kept := make([]*reminder.Reminder, 0, len(reminders))
+	for _, r := range reminders {
+		if !drop[r] {
+			kept = append(kept, r)
+		}
+	}
+	return kept
+}
+
+// Apply merges every duplicate group at now, returning the reminders to
+// keep and the copies removed
+func Apply(reminders []*reminder.Reminder, tolerance time.Duration, ignored map[string]bool, now time.Time) ([]*reminder.Reminder, []*reminder.Reminder) {
+	var removed []*reminder.Reminder
+	for _, g := range Find(reminders, tolerance, ignored) {
+		_, copies := Merge(g, now)
+		removed = append(removed, copies...)
+	}
+	if len(removed) == 0 {
+		return reminders, nil
+	}
+	return Remove(reminders, removed), removed
+}
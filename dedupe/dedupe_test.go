@@ -0,0 +1,70 @@
+package dedupe
+
+import (
+	"testing"
+	"time"
+
+	"go_remind/pkg/reminder"
+)
+
+func TestFind(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.Local)
+
+	a := &reminder.Reminder{ID: "a", DateTime: now, Description: "Call mom", SourceFile: "/notes/a.md", Status: reminder.Pending}
+	b := &reminder.Reminder{ID: "b", DateTime: now.Add(2 * time.Minute), Description: "call  Mom!", SourceFile: "/notes/b.md", Status: reminder.Pending}
+	// Same text but a day later is a different reminder
+	tomorrow := &reminder.Reminder{ID: "c", DateTime: now.Add(24 * time.Hour), Description: "Call mom", SourceFile: "/notes/c.md", Status: reminder.Pending}
+	done := &reminder.Reminder{ID: "d", DateTime: now, Description: "Call mom", SourceFile: "/notes/d.md", Status: reminder.Acknowledged}
+	other := &reminder.Reminder{ID: "e", DateTime: now, Description: "Standup", SourceFile: "/notes/a.md", Status: reminder.Pending}
+
+	all := []*reminder.Reminder{a, b, tomorrow, done, other}
+	groups := Find(all, 5*time.Minute, nil)
+	if len(groups) != 1 || len(groups[0]) != 2 || groups[0][0] != a || groups[0][1] != b {
+		t.Fatalf("Find() = %v, want [a b]", groups)
+	}
+	if got := groups[0].Key(); got != "a,b" {
+		t.Errorf("Key() = %q, want a,b", got)
+	}
+
+	if groups := Find(all, 5*time.Minute, map[string]bool{"a,b": true}); len(groups) != 0 {
+		t.Errorf("Find() with the group ignored = %v, want none", groups)
+	}
+}
+
+func TestApply(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.Local)
+	a := &reminder.Reminder{ID: "a", DateTime: now, Description: "Pay rent", Tags: []string{"home"}, Status: reminder.Pending}
+	b := &reminder.Reminder{ID: "b", DateTime: now.Add(time.Minute), Description: "Pay rent", Tags: []string{"home", "money"}, Status: reminder.Triggered}
+	c := &reminder.Reminder{ID: "c", DateTime: now, Description: "Water plants", Status: reminder.Pending}
+
+	kept, removed := Apply([]*reminder.Reminder{a, b, c}, time.Minute, nil, now)
+	if len(removed) != 1 || removed[0] != b || len(kept) != 2 || kept[0] != a || kept[1] != c {
+		t.Fatalf("Apply() kept %d reminders, removed %d; want a and c, b removed", len(kept), len(removed))
+	}
+	if len(a.Tags) != 2 || a.Tags[1] != "money" {
+		t.Errorf("merged tags = %v, want [home money]", a.Tags)
+	}
+	if a.Status != reminder.Triggered {
+		t.Errorf("merged status = %v, want triggered", a.Status)
+	}
+}
+
+func TestSimilar(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want bool
+	}{
+		{"Call mom", "call  Mom!", true},
+		{"Renew the passport", "Renew the pasport", true},
+		{"Renew the passport", "Renew the car insurance", false},
+		{"Call mom", "Call tom", true},
+		{"Call mom", "Call dad", false},
+		{"Pay", "Pat", false}, // Too short to allow a typo
+		{"", "", false},
+	}
+	for _, c := range cases {
+		if got := Similar(c.a, c.b); got != c.want {
+			t.Errorf("Similar(%q, %q) = %v, want %v", c.a, c.b, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// Package demo generates a realistic set of sample reminders for trying
+// go_remind out and for reproducible screenshots.
+package demo
+
+import (
+	"math/rand"
+	"time"
+
+	"go_remind/pkg/reminder"
+)
+
+// seed fixes the generated data, so the same day always looks the same
+const seed = 42
+
+// note is a markdown file the sample reminders pretend to come from, with
+// the kind of reminders found in it
+type note struct {
+	file         string
+	tags         []string
+	descriptions []string
+}
+
+var notes = []note{
+	{
+		file: "notes/work/standup.md",
+		tags: []string{"work", "meeting"},
+		descriptions: []string{
+			"Team standup", "Sprint planning", "Retro: bring the deploy timeline",
+			"1:1 with Priya", "Demo the new search to design", "Quarterly planning kickoff",
+		},
+	},
+	{
+		file: "notes/work/projects.md",
+		tags: []string{"work"},
+		descriptions: []string{
+			"Review Jamal's pull request", "Deploy billing fix to production", "Write release notes for v2.4",
+			"Renew the TLS certificate", "Update API docs for pagination", "Follow up on the flaky CI job",
+			"Rotate staging database credentials", "Triage new bug reports", "Send the incident postmortem",
+		},
+	},
+	{
+		file: "notes/home.md",
+		tags: []string{"home"},
+		descriptions: []string{
+			"Pay rent", "Water the plants", "Book the dentist", "Take the recycling out",
+			"Call the landlord about the heater", "Pick up dry cleaning", "Renew car insurance",
+		},
+	},
+	{
+		file: "notes/personal.md",
+		tags: []string{"personal"},
+		descriptions: []string{
+			"Call mom", "Buy a birthday gift for Sam", "Gym: leg day", "Finish the book club book",
+			"Plan the weekend hike", "Reply to Alex about dinner",
+		},
+	},
+	{
+		file: "notes/someday.md",
+		tags: []string{"ideas"},
+		descriptions: []string{
+			"Learn to make sourdough", "Try the new ramen place", "Set up a home backup server",
+		},
+	},
+}
+
+// Generate returns sample reminders spread around now: overdue ones,
+// today's, the coming weeks', finished ones, and a few parked. The same
+// reminders are generated every time; only their times follow now.
+func Generate(now time.Time) []*reminder.Reminder {
+	rng := rand.New(rand.NewSource(seed))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	var reminders []*reminder.Reminder
+	for _, n := range notes {
+		for line, desc := range n.descriptions {
+			// Mostly within the next two weeks, some past, a few far ahead
+			days := rng.Intn(21) - 5
+			if rng.Intn(8) == 0 {
+				days = 20 + rng.Intn(60)
+			}
+			at := today.AddDate(0, 0, days).Add(time.Duration(8+rng.Intn(11))*time.Hour + time.Duration(rng.Intn(4)*15)*time.Minute)
+
+			r := &reminder.Reminder{
+				ID:          reminder.FileID(n.file, desc),
+				DateTime:    at,
+				Description: desc,
+				Tags:        pickTags(rng, n.tags),
+				SourceFile:  n.file,
+				LineNumber:  3 + line*2,
+				Status:      reminder.Pending,
+				UpdatedAt:   now,
+			}
+			// Draw every number up front so the sequence doesn't depend on
+			// which branch is taken
+			triggered, waiting := rng.Intn(3) == 0, rng.Intn(10) == 0
+			ackDelay := time.Duration(rng.Intn(90)) * time.Minute
+			priority := rng.Intn(18)
+			switch {
+			case n.file == "notes/someday.md":
+				r.Status = reminder.Someday
+			case at.After(now):
+				if waiting {
+					r.Status = reminder.Waiting
+				}
+			case triggered:
+				r.Status = reminder.Triggered
+			default:
+				r.Acknowledge(at.Add(ackDelay))
+			}
+			if priority < 3 {
+				r.Priority = reminder.Priority(1 + priority)
+			}
+			reminders = append(reminders, r)
+		}
+	}
+
+	// Something due in a few minutes, and one snoozed, so every state shows
+	soon := &reminder.Reminder{
+		ID:          reminder.FileID("notes/work/standup.md", "Join the design review"),
+		DateTime:    now.Add(10 * time.Minute).Truncate(time.Minute),
+		Description: "Join the design review",
+		Tags:        []string{"work", "meeting"},
+		SourceFile:  "notes/work/standup.md",
+		Status:      reminder.Pending,
+		Priority:    reminder.PriorityHigh,
+		UpdatedAt:   now,
+	}
+	snoozed := &reminder.Reminder{
+		ID:          reminder.FileID(reminder.StandaloneSource, "Stretch and refill water"),
+		Description: "Stretch and refill water",
+		Tags:        []string{"personal"},
+		SourceFile:  reminder.StandaloneSource,
+		UpdatedAt:   now,
+	}
+	snoozed.DateTime = now
+	snoozed.Snooze(25*time.Minute, now)
+	reminders = append(reminders, soon, snoozed)
+
+	reminder.SortByDateTime(reminders)
+	return reminders
+}
+
+// pickTags returns the note's tags, sometimes with an extra one
+func pickTags(rng *rand.Rand, base []string) []string {
+	tags := append([]string(nil), base...)
+	switch rng.Intn(6) {
+	case 0:
+		tags = append(tags, "urgent")
+	case 1:
+		tags = append(tags, "followup")
+	}
+	return tags
+}
@@ -0,0 +1,36 @@
+package demo
+
+import (
+	"testing"
+	"time"
+
+	"go_remind/pkg/reminder"
+)
+
+func TestGenerate(t *testing.T) {
+	now := time.Date(2026, 6, 3, 10, 0, 0, 0, time.Local)
+	a, b := Generate(now), Generate(now)
+	if len(a) != len(b) {
+		t.Fatalf("Generate() returned %d then %d reminders, want the same", len(a), len(b))
+	}
+	for i := range a {
+		if a[i].ID != b[i].ID || !a[i].DateTime.Equal(b[i].DateTime) || a[i].Status != b[i].Status {
+			t.Fatalf("reminder %d differs between runs: %+v vs %+v", i, a[i], b[i])
+		}
+	}
+
+	seen := make(map[reminder.Status]bool)
+	ids := make(map[string]bool)
+	for _, r := range a {
+		seen[r.Status] = true
+		if ids[r.ID] {
+			t.Errorf("duplicate ID %s for %q", r.ID, r.Description)
+		}
+		ids[r.ID] = true
+	}
+	for _, s := range []reminder.Status{reminder.Pending, reminder.Triggered, reminder.Acknowledged, reminder.Snoozed, reminder.Someday} {
+		if !seen[s] {
+			t.Errorf("no %s reminder in the demo data", s.Name())
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"time"
+
+	"go_remind/config"
+	"go_remind/demo"
+	"go_remind/tui"
+)
+
+// runDemo runs `go_remind --demo`: the TUI with generated sample reminders
+// held only in memory. Nothing is read from or written to ~/.go_remind, and
+// no notifications are sent.
+func runDemo(baseCfg *config.Config, themesDir string) {
+	cfg := *baseCfg
+	cfg.Notifications.Enabled = false
+	cfg.Digest.Enabled = false
+	cfg.Sync.Enabled = false
+
+	model := tui.New(demo.Generate(time.Now()), nil, nil).WithConfig(&cfg).WithThemes(themesDir)
+	runTUI(model, nil)
+}
@@ -0,0 +1,124 @@
+package digest
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go_remind/conflicts"
+	"go_remind/pkg/reminder"
+)
+
+// Digest summarizes the state of reminders for a single day
+type Digest struct {
+	Date               time.Time
+	Today              []*reminder.Reminder // Open reminders due today, except waiting or someday
+	Overdue            []*reminder.Reminder // Open reminders due before today
+	CompletedYesterday int
+}
+
+// Build computes the digest for the day containing now
+func Build(reminders []*reminder.Reminder, now time.Time) Digest {
+	todayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	todayEnd := todayStart.AddDate(0, 0, 1)
+	yesterdayStart := todayStart.AddDate(0, 0, -1)
+
+	d := Digest{Date: todayStart}
+	for _, r := range reminders {
+		if r.Status == reminder.Acknowledged {
+			if !r.AcknowledgedAt.Before(yesterdayStart) && r.AcknowledgedAt.Before(todayStart) {
+				d.CompletedYesterday++
+			}
+			continue
+		}
+		if r.Status.Parked() {
+			continue // Set aside, not due
+		}
+
+		if r.DateTime.Before(todayStart) {
+			d.Overdue = append(d.Overdue, r)
+		} else if r.DateTime.Before(todayEnd) {
+			d.Today = append(d.Today, r)
+		}
+	}
+
+	reminder.SortByDateTime(d.Today)
+	reminder.SortByDateTime(d.Overdue)
+	return d
+}
+
+// Summary returns a one-line summary suitable for a notification title/body
+func (d Digest) Summary() string {
+	return fmt.Sprintf("%d today, %d overdue, %d completed yesterday",
+		len(d.Today), len(d.Overdue), d.CompletedYesterday)
+}
+
+// Body returns a short multi-line body listing today's reminders
+func (d Digest) Body() string {
+	var lines []string
+	lines = append(lines, d.Summary())
+	for _, r := range d.Today {
+		lines = append(lines, fmt.Sprintf("%s  %s", r.DateTime.Format("3:04pm"), r.Description))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// NextTime returns the first digest time (hour:minute) strictly after now
+func NextTime(now time.Time, hour, minute int) time.Time {
+	next := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, now.Location())
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+// Late returns the reminders whose time has passed at now: the overdue
+// ones and any of today's already due
+func (d Digest) Late(now time.Time) []*reminder.Reminder {
+	late := append([]*reminder.Reminder(nil), d.Overdue...)
+	for _, r := range d.Today {
+		if r.IsDueAt(now) {
+			late = append(late, r)
+		}
+	}
+	return late
+}
+
+// Conflicts returns today's reminders due within window of another of them
+func (d Digest) Conflicts(window time.Duration) map[*reminder.Reminder]bool {
+	return conflicts.Flag(d.Today, window)
+}
+
+// Agenda returns the day's reminders grouped by hour, after any overdue
+// from earlier days, for printing in a terminal. Reminders due within
+// conflictWindow of each other are marked.
+func (d Digest) Agenda(conflictWindow time.Duration) string {
+	clashes := d.Conflicts(conflictWindow)
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %d today, %d overdue\n", d.Date.Format("Monday, January 2"), len(d.Today), len(d.Overdue))
+
+	if len(d.Overdue) > 0 {
+		b.WriteString("\nOverdue\n")
+		for _, r := range d.Overdue {
+			fmt.Fprintf(&b, "  %-13s %s\n", r.DateTime.Format("Jan 2 3:04pm"), r.Description)
+		}
+	}
+
+	hour := -1
+	for _, r := range d.Today {
+		if r.DateTime.Hour() != hour {
+			hour = r.DateTime.Hour()
+			at := r.DateTime
+			fmt.Fprintf(&b, "\n%s\n", time.Date(at.Year(), at.Month(), at.Day(), hour, 0, 0, 0, at.Location()).Format("3pm"))
+		}
+		line := fmt.Sprintf("  %-7s %s", r.DateTime.Format("3:04pm"), r.Description)
+		if clashes[r] {
+			line += "  (conflict)"
+		}
+		b.WriteString(line + "\n")
+	}
+	if len(d.Today) == 0 {
+		b.WriteString("\nNothing due today\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,167 @@
+package digest
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"go_remind/pkg/reminder"
+)
+
+func TestBuild(t *testing.T) {
+	// Fixed reference time: Tuesday, January 13, 2026 at 8:00am
+	now := time.Date(2026, 1, 13, 8, 0, 0, 0, time.Local)
+
+	reminders := []*reminder.Reminder{
+		{Description: "Standup", DateTime: now.Add(2 * time.Hour), Status: reminder.Pending},
+		{Description: "Late tonight", DateTime: now.Add(15 * time.Hour), Status: reminder.Pending},
+		{Description: "Tomorrow", DateTime: now.Add(24 * time.Hour), Status: reminder.Pending},
+		{Description: "Missed", DateTime: now.Add(-30 * time.Hour), Status: reminder.Triggered},
+		{Description: "Done yesterday", DateTime: now.Add(-20 * time.Hour), Status: reminder.Acknowledged,
+			AcknowledgedAt: now.Add(-18 * time.Hour)},
+		{Description: "Done today", DateTime: now.Add(-time.Hour), Status: reminder.Acknowledged,
+			AcknowledgedAt: now.Add(-time.Minute)},
+		{Description: "Done long ago", DateTime: now.Add(-72 * time.Hour), Status: reminder.Acknowledged,
+			AcknowledgedAt: now.Add(-70 * time.Hour)},
+	}
+
+	d := Build(reminders, now)
+
+	if len(d.Today) != 2 {
+		t.Errorf("Today = %d reminders, want 2", len(d.Today))
+	}
+	if len(d.Overdue) != 1 || d.Overdue[0].Description != "Missed" {
+		t.Errorf("Overdue = %v, want [Missed]", d.Overdue)
+	}
+	if d.CompletedYesterday != 1 {
+		t.Errorf("CompletedYesterday = %d, want 1", d.CompletedYesterday)
+	}
+	if got, want := d.Summary(), "2 today, 1 overdue, 1 completed yesterday"; got != want {
+		t.Errorf("Summary() = %q, want %q", got, want)
+	}
+}
+
+func TestNextTime(t *testing.T) {
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		{
+			name: "before digest time fires today",
+			now:  time.Date(2026, 1, 13, 7, 0, 0, 0, time.Local),
+			want: time.Date(2026, 1, 13, 8, 0, 0, 0, time.Local),
+		},
+		{
+			name: "exactly at digest time fires tomorrow",
+			now:  time.Date(2026, 1, 13, 8, 0, 0, 0, time.Local),
+			want: time.Date(2026, 1, 14, 8, 0, 0, 0, time.Local),
+		},
+		{
+			name: "after digest time fires tomorrow",
+			now:  time.Date(2026, 1, 13, 17, 0, 0, 0, time.Local),
+			want: time.Date(2026, 1, 14, 8, 0, 0, 0, time.Local),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NextTime(tt.now, 8, 0); !got.Equal(tt.want) {
+				t.Errorf("NextTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAgenda(t *testing.T) {
+	now := time.Date(2026, 1, 13, 10, 0, 0, 0, time.UTC)
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2026, 1, day, hour, min, 0, 0, time.UTC)
+	}
+	reminders := []*reminder.Reminder{
+		{Description: "Missed", DateTime: at(12, 17, 0), Status: reminder.Triggered},
+		{Description: "Standup", DateTime: at(13, 9, 30), Status: reminder.Triggered},
+		{Description: "Review", DateTime: at(13, 9, 40), Status: reminder.Pending},
+		{Description: "Call mom", DateTime: at(13, 15, 0), Status: reminder.Pending},
+		{Description: "Done", DateTime: at(13, 8, 0), Status: reminder.Acknowledged, AcknowledgedAt: at(13, 8, 5)},
+	}
+
+	d := Build(reminders, now)
+	want := `Tuesday, January 13: 3 today, 1 overdue
+
+Overdue
+  Jan 12 5:00pm Missed
+
+9am
+  9:30am  Standup  (conflict)
+  9:40am  Review  (conflict)
+
+3pm
+  3:00pm  Call mom
+`
+	if got := d.Agenda(15 * time.Minute); got != want {
+		t.Errorf("Agenda() =\n%s\nwant\n%s", got, want)
+	}
+	if late := d.Late(now); len(late) != 3 {
+		t.Errorf("Late() = %d reminders, want 3", len(late))
+	}
+
+	empty := Build(nil, now)
+	if got := empty.Agenda(15 * time.Minute); !strings.Contains(got, "Nothing due today") {
+		t.Errorf("Agenda() with no reminders = %q, want it to say nothing is due", got)
+	}
+	if late := empty.Late(now); len(late) != 0 {
+		t.Errorf("Late() with no reminders = %d, want 0", len(late))
+	}
+}
+
+func TestPrintable(t *testing.T) {
+	now := time.Date(2026, 1, 13, 10, 0, 0, 0, time.UTC)
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2026, 1, day, hour, min, 0, 0, time.UTC)
+	}
+	reminders := []*reminder.Reminder{
+		{Description: "Missed", DateTime: at(12, 17, 0), Status: reminder.Triggered},
+		{Description: "Standup", DateTime: at(13, 9, 30), Tags: []string{"work"}, Status: reminder.Pending},
+		{Description: "Review <draft>", DateTime: at(13, 9, 40), Status: reminder.Pending},
+		{Description: "Call mom", DateTime: at(13, 15, 0), Status: reminder.Pending},
+	}
+	d := Build(reminders, now)
+
+	want := `Agenda for Tuesday, January 13, 2026
+====================================
+3 due, 1 carried over
+
+Carried over
+  [ ] Jan 12 5:00pm  Missed
+
+9am
+  [ ] 9:30am   Standup #work (conflict)
+  [ ] 9:40am   Review <draft> (conflict)
+
+3pm
+  [ ] 3:00pm   Call mom
+`
+	if got := d.Printable(PrintText, 15*time.Minute); got != want {
+		t.Errorf("Printable(text) =\n%s\nwant\n%s", got, want)
+	}
+
+	md := d.Printable(PrintMarkdown, 15*time.Minute)
+	for _, line := range []string{"# Agenda for Tuesday, January 13, 2026", "## 9am", "- [ ] 9:30am — Standup #work (conflict)", "- [ ] 3:00pm — Call mom"} {
+		if !strings.Contains(md, line+"\n") {
+			t.Errorf("Printable(md) missing %q:\n%s", line, md)
+		}
+	}
+
+	page := d.Printable(PrintHTML, 15*time.Minute)
+	if !strings.Contains(page, `<input type="checkbox"> <time datetime="2026-01-13T09:40:00Z">9:40am</time> Review &lt;draft&gt; (conflict)`) {
+		t.Errorf("Printable(html) should escape descriptions:\n%s", page)
+	}
+
+	if got := Build(nil, now).Printable(PrintText, 0); !strings.Contains(got, "Nothing due.") {
+		t.Errorf("Printable() with no reminders = %q, want it to say nothing is due", got)
+	}
+	if _, err := ParsePrintFormat("pdf"); err == nil {
+		t.Error("ParsePrintFormat(pdf) should fail")
+	}
+}
@@ -0,0 +1,129 @@
+package digest
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"go_remind/pkg/reminder"
+)
+
+// PrintFormat is a format of the printable agenda
+type PrintFormat string
+
+const (
+	PrintText     PrintFormat = "text"
+	PrintMarkdown PrintFormat = "md"
+	PrintHTML     PrintFormat = "html"
+)
+
+// ParsePrintFormat reads a printable agenda format by name
+func ParsePrintFormat(s string) (PrintFormat, error) {
+	switch f := PrintFormat(strings.ToLower(strings.TrimSpace(s))); f {
+	case "", "txt":
+		return PrintText, nil
+	case "markdown":
+		return PrintMarkdown, nil
+	case PrintText, PrintMarkdown, PrintHTML:
+		return f, nil
+	}
+	return "", fmt.Errorf("unknown agenda format %q: try text, md, or html", s)
+}
+
+// agendaSection is a heading of the printable agenda and the reminders
+// under it, each with the time to show
+type agendaSection struct {
+	title     string
+	timeFmt   string
+	width     int // Of the longest time in timeFmt, to line up descriptions
+	reminders []*reminder.Reminder
+}
+
+// sections splits the agenda into the reminders carried over from earlier
+// days, then the day's reminders by hour
+func (d Digest) sections() []agendaSection {
+	var sections []agendaSection
+	if len(d.Overdue) > 0 {
+		sections = append(sections, agendaSection{title: "Carried over", timeFmt: "Jan 2 3:04pm", width: 13, reminders: d.Overdue})
+	}
+	for _, r := range d.Today {
+		at := r.DateTime
+		title := time.Date(at.Year(), at.Month(), at.Day(), at.Hour(), 0, 0, 0, at.Location()).Format("3pm")
+		if n := len(sections); n == 0 || sections[n-1].title != title {
+			sections = append(sections, agendaSection{title: title, timeFmt: "3:04pm", width: 7})
+		}
+		last := &sections[len(sections)-1]
+		last.reminders = append(last.reminders, r)
+	}
+	return sections
+}
+
+// describe is a reminder's description with its tags, and a mark if it
+// conflicts with another
+func describe(r *reminder.Reminder, conflict bool) string {
+	s := r.Description
+	for _, tag := range r.Tags {
+		s += " #" + tag
+	}
+	if conflict {
+		s += " (conflict)"
+	}
+	return s
+}
+
+// Printable returns the day's agenda for printing or pasting into a
+// journal: the reminders carried over from earlier days, then the day's
+// grouped by hour, each with an empty checkbox. Reminders due within
+// conflictWindow of each other are marked.
+func (d Digest) Printable(f PrintFormat, conflictWindow time.Duration) string {
+	clashes := d.Conflicts(conflictWindow)
+	title := "Agenda for " + d.Date.Format("Monday, January 2, 2006")
+	summary := fmt.Sprintf("%d due, %d carried over", len(d.Today), len(d.Overdue))
+	sections := d.sections()
+
+	var b strings.Builder
+	switch f {
+	case PrintMarkdown:
+		fmt.Fprintf(&b, "# %s\n\n_%s_\n", title, summary)
+		for _, s := range sections {
+			fmt.Fprintf(&b, "\n## %s\n\n", s.title)
+			for _, r := range s.reminders {
+				fmt.Fprintf(&b, "- [ ] %s — %s\n", r.DateTime.Format(s.timeFmt), describe(r, clashes[r]))
+			}
+		}
+		if len(d.Today) == 0 {
+			b.WriteString("\nNothing due.\n")
+		}
+	case PrintHTML:
+		fmt.Fprintf(&b, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n", html.EscapeString(title))
+		b.WriteString("<style>\nbody { font-family: sans-serif; max-width: 40em; margin: 2em auto; }\n" +
+			"ul { list-style: none; padding-left: 0; }\nli { margin: 0.4em 0; }\n" +
+			"time { display: inline-block; min-width: 6em; color: #555; }\n</style>\n</head>\n<body>\n")
+		fmt.Fprintf(&b, "<h1>%s</h1>\n<p>%s</p>\n", html.EscapeString(title), html.EscapeString(summary))
+		for _, s := range sections {
+			fmt.Fprintf(&b, "<h2>%s</h2>\n<ul>\n", html.EscapeString(s.title))
+			for _, r := range s.reminders {
+				fmt.Fprintf(&b, "<li><input type=\"checkbox\"> <time datetime=\"%s\">%s</time> %s</li>\n",
+					r.DateTime.Format(time.RFC3339), r.DateTime.Format(s.timeFmt), html.EscapeString(describe(r, clashes[r])))
+			}
+			b.WriteString("</ul>\n")
+		}
+		if len(d.Today) == 0 {
+			b.WriteString("<p>Nothing due.</p>\n")
+		}
+		b.WriteString("</body>\n</html>\n")
+	default:
+		fmt.Fprintf(&b, "%s\n%s\n%s\n", title, strings.Repeat("=", len(title)), summary)
+		for _, s := range sections {
+			fmt.Fprintf(&b, "\n%s\n", s.title)
+			for _, r := range s.reminders {
+				fmt.Fprintf(&b, "  [ ] %-*s  %s\n", s.width, r.DateTime.Format(s.timeFmt), describe(r, clashes[r]))
+			}
+		}
+		if len(d.Today) == 0 {
+			b.WriteString("\nNothing due.\n")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,133 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"go_remind/pkg/parser"
+	"go_remind/pkg/reminder"
+)
+
+// MarkdownSnippet renders reminders as a markdown checklist of
+// [remind_me ...] tokens that go_remind can parse back
+func MarkdownSnippet(reminders []*reminder.Reminder, generated time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Reminders\n\n")
+	fmt.Fprintf(&b, "_Exported from go_remind on %s_\n\n", generated.Format("Jan 2, 2006 3:04pm"))
+
+	for _, r := range reminders {
+		check := " "
+		if r.Status == reminder.Acknowledged {
+			check = "x"
+		}
+		fmt.Fprintf(&b, "- [%s] %s\n", check, parser.FormatToken(r))
+	}
+	return b.String()
+}
+
+// Format is a way of writing reminders out
+type Format string
+
+const (
+	Snippet Format = "md"    // A checklist of tokens, as MarkdownSnippet
+	CSV     Format = "csv"   // A CSV file with a header row
+	Table   Format = "table" // A markdown table
+)
+
+// Formats are the formats reminders can be written out in
+var Formats = []Format{Snippet, CSV, Table}
+
+// ParseFormat reads a format by name; "markdown" is the snippet
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(strings.TrimSpace(s))); f {
+	case "", "markdown":
+		return Snippet, nil
+	case Snippet, CSV, Table:
+		return f, nil
+	}
+	return "", fmt.Errorf("unknown export format %q: try md, csv, or table", s)
+}
+
+// Ext returns the file extension for the format
+func (f Format) Ext() string {
+	if f == CSV {
+		return "csv"
+	}
+	return "md"
+}
+
+// Render writes reminders out in the format
+func Render(f Format, reminders []*reminder.Reminder, generated time.Time) string {
+	switch f {
+	case CSV:
+		return CSVReport(reminders)
+	case Table:
+		return MarkdownTable(reminders)
+	default:
+		return MarkdownSnippet(reminders, generated)
+	}
+}
+
+// columns are the report columns of CSV and the markdown table
+var columns = []string{"Time", "Description", "Tags", "Status", "Source"}
+
+// row returns the report columns for r
+func row(r *reminder.Reminder) []string {
+	tags := make([]string, len(r.Tags))
+	for i, tag := range r.Tags {
+		tags[i] = "#" + tag
+	}
+	return []string{
+		r.DateTime.Format("2006-01-02 15:04"),
+		r.Description,
+		strings.Join(tags, " "),
+		strings.ToLower(r.Status.String()),
+		source(r),
+	}
+}
+
+// source is where r came from: its remote source, its file and line, or
+// how it was added
+func source(r *reminder.Reminder) string {
+	switch {
+	case r.Source != "":
+		return r.Source
+	case r.LineNumber > 0:
+		return fmt.Sprintf("%s:%d", r.SourceFile, r.LineNumber)
+	default:
+		return r.SourceFile
+	}
+}
+
+// CSVReport renders reminders as CSV with a header row, one reminder per
+// row
+func CSVReport(reminders []*reminder.Reminder) string {
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	w.Write(columns)
+	for _, r := range reminders {
+		w.Write(row(r))
+	}
+	w.Flush() // Writing to a strings.Builder can't fail
+	return b.String()
+}
+
+// MarkdownTable renders reminders as a markdown table, one reminder per row
+func MarkdownTable(reminders []*reminder.Reminder) string {
+	var b strings.Builder
+	writeRow := func(cells []string) {
+		for i, cell := range cells {
+			cells[i] = strings.ReplaceAll(strings.ReplaceAll(cell, "|", `\|`), "\n", " ")
+		}
+		fmt.Fprintf(&b, "| %s |\n", strings.Join(cells, " | "))
+	}
+	writeRow(slices.Clone(columns))
+	b.WriteString("|" + strings.Repeat("---|", len(columns)) + "\n")
+	for _, r := range reminders {
+		writeRow(row(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,47 @@
+package export
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"go_remind/pkg/reminder"
+)
+
+func TestReports(t *testing.T) {
+	at := time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC)
+	reminders := []*reminder.Reminder{
+		{DateTime: at, Description: "Ship a, b | c", Tags: []string{"work", "q1"}, SourceFile: "/notes/plan.md", LineNumber: 4, Status: reminder.Triggered},
+		{DateTime: at.Add(time.Hour), Description: "Review PR", Source: "github:acme/app", Status: reminder.Acknowledged},
+	}
+
+	wantCSV := "Time,Description,Tags,Status,Source\n" +
+		"2026-03-02 09:30,\"Ship a, b | c\",#work #q1,triggered,/notes/plan.md:4\n" +
+		"2026-03-02 10:30,Review PR,,done,github:acme/app\n"
+	if got := Render(CSV, reminders, at); got != wantCSV {
+		t.Errorf("CSV:\n%s\nwant:\n%s", got, wantCSV)
+	}
+
+	wantTable := "| Time | Description | Tags | Status | Source |\n" +
+		"|---|---|---|---|---|\n" +
+		"| 2026-03-02 09:30 | Ship a, b \\| c | #work #q1 | triggered | /notes/plan.md:4 |\n" +
+		"| 2026-03-02 10:30 | Review PR |  | done | github:acme/app |\n"
+	if got := Render(Table, reminders, at); got != wantTable {
+		t.Errorf("table:\n%s\nwant:\n%s", got, wantTable)
+	}
+
+	if got := Render(Snippet, reminders, at); !strings.HasPrefix(got, "# Reminders") {
+		t.Errorf("snippet should be the markdown checklist, got:\n%s", got)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for in, want := range map[string]Format{"": Snippet, "markdown": Snippet, "CSV": CSV, " table ": Table} {
+		if got, err := ParseFormat(in); err != nil || got != want {
+			t.Errorf("ParseFormat(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("ParseFormat(xml) should fail")
+	}
+}
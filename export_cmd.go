@@ -0,0 +1,65 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"go_remind/export"
+	"go_remind/pkg/reminder"
+	"go_remind/pkg/state"
+)
+
+// runExport runs `go_remind export`: prints the saved reminders, or those
+// matching a filter as typed into the TUI, as CSV or a markdown table for
+// reports
+func runExport(store *state.Store, args []string) {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	format := fs.String("format", "csv", "Output format: csv, table (markdown table), or md (reminder tokens)")
+	filter := fs.String("filter", "", "Only reminders whose description contains this, or with this #tag")
+	out := fs.String("o", "", "Write to this file instead of stdout")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: go_remind export [--format csv|table|md] [--filter text|#tag] [-o file]")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() > 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	f, err := export.ParseFormat(*format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+
+	var reminders []*reminder.Reminder
+	if store != nil {
+		if reminders, err = store.Load(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: could not load state: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if *filter != "" {
+		var matched []*reminder.Reminder
+		for _, r := range reminders {
+			if r.Matches(*filter) {
+				matched = append(matched, r)
+			}
+		}
+		reminders = matched
+	}
+	reminder.SortByDateTime(reminders)
+
+	text := export.Render(f, reminders, time.Now())
+	if *out == "" {
+		fmt.Print(text)
+		return
+	}
+	if err := os.WriteFile(*out, []byte(text), 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Fprintf(os.Stderr, "Exported %d reminders to %s\n", len(reminders), *out)
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go_remind/pkg/parser"
+	"go_remind/pkg/reminder"
+	"go_remind/pkg/state"
+)
+
+// runImport runs `go_remind import --plain <file>`: adds each non-empty
+// line of a plain list as a reminder, read as "<time> <description>" like
+// the TUI's add box, and reports how each line went. Without --plain the
+// file is read as markdown, as `parse` does.
+func runImport(store *state.Store, args []string) {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	plain := fs.Bool("plain", false, "Read one \"<time> <description>\" reminder per line")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: go_remind import [--plain] <file>")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	if !*plain {
+		runParse(store, fs.Args())
+		return
+	}
+	if store == nil {
+		fmt.Fprintln(os.Stderr, "Error: no state store to import into")
+		os.Exit(1)
+	}
+
+	path := fs.Arg(0)
+	f, err := os.Open(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	defer f.Close()
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		abs = path
+	}
+
+	failed, err := importPlain(store, f, os.Stdout, abs, time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: reading %s: %v\n", path, err)
+		os.Exit(1)
+	}
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// importPlain adds each non-empty line of in as a reminder and writes how
+// each line went to out. name is the list's path; IDs derive from it, as
+// parse does, so importing the list again adds only the new lines. It
+// returns how many lines failed to parse.
+func importPlain(store *state.Store, in io.Reader, out io.Writer, name string, now time.Time) (failed int, err error) {
+	// Parse every line before saving, so the report can say which were
+	// already imported
+	type entry struct {
+		line int
+		r    *reminder.Reminder
+		err  error
+	}
+	var entries []entry
+	var parsed []*reminder.Reminder
+	scanner := bufio.NewScanner(in)
+	for n := 1; scanner.Scan(); n++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		r, err := parser.ParseEntry(text, now)
+		if err == nil {
+			r.ID = reminder.FileID(name, r.Description)
+			r.SourceFile = reminder.StandaloneSource
+			parsed = append(parsed, r)
+		}
+		entries = append(entries, entry{line: n, r: r, err: err})
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+
+	added, err := addReminders(store, parsed)
+	if err != nil {
+		return 0, err
+	}
+	isAdded := make(map[*reminder.Reminder]bool, len(added))
+	for _, r := range added {
+		isAdded[r] = true
+	}
+
+	for _, e := range entries {
+		switch {
+		case e.err != nil:
+			failed++
+			fmt.Fprintf(out, "line %d: failed: %v\n", e.line, e.err)
+		case isAdded[e.r]:
+			fmt.Fprintf(out, "line %d: added %s, due %s\n", e.line, e.r.Description, e.r.DateTime.Format("Mon Jan 2, 2006 at 3:04 PM"))
+		default:
+			fmt.Fprintf(out, "line %d: already imported %s\n", e.line, e.r.Description)
+		}
+	}
+	fmt.Fprintf(out, "Imported %d of %d lines", len(added), len(entries))
+	if failed > 0 {
+		fmt.Fprintf(out, ", %d failed", failed)
+	}
+	fmt.Fprintln(out)
+	return failed, nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go_remind/pkg/state"
+)
+
+func TestImportPlain(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.Local)
+	store := state.NewStore(filepath.Join(t.TempDir(), "state.json"))
+	list := "+1h Water the plants\n\nwhenever Call mom\n2026-06-02 09:00 Dentist\n"
+
+	run := func(text string) (string, int) {
+		t.Helper()
+		var out strings.Builder
+		failed, err := importPlain(store, strings.NewReader(text), &out, "/notes/list.txt", now)
+		if err != nil {
+			t.Fatalf("importPlain() error: %v", err)
+		}
+		return out.String(), failed
+	}
+
+	// Each line is reported by its number in the file, blank lines counted
+	out, failed := run(list)
+	for _, want := range []string{
+		"line 1: added Water the plants, due Mon Jun 1, 2026 at 1:00 PM\n",
+		"line 3: failed: ",
+		"line 4: added Dentist, due Tue Jun 2, 2026 at 9:00 AM\n",
+		"Imported 2 of 3 lines, 1 failed\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report missing %q:\n%s", want, out)
+		}
+	}
+	if failed != 1 {
+		t.Errorf("failed = %d, want 1", failed)
+	}
+
+	// Importing the list again adds only its new line
+	out, _ = run(list + "+2h Stretch\n")
+	for _, want := range []string{
+		"line 1: already imported Water the plants\n",
+		"line 4: already imported Dentist\n",
+		"line 5: added Stretch, ",
+		"Imported 1 of 4 lines, 1 failed\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report of a re-import missing %q:\n%s", want, out)
+		}
+	}
+	saved, err := store.Load()
+	if err != nil || len(saved) != 3 {
+		t.Errorf("saved %d reminders (%v), want 3", len(saved), err)
+	}
+}
@@ -4,35 +4,169 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"syscall"
 
 	tea "github.com/charmbracelet/bubbletea"
 
-	"go_remind/reminder"
-	"go_remind/state"
+	"go_remind/config"
+	"go_remind/daemon"
+	"go_remind/pkg/parser"
+	"go_remind/pkg/reminder"
+	"go_remind/pkg/state"
+	"go_remind/pkg/watcher"
+	"go_remind/rules"
+	"go_remind/sources"
+	"go_remind/statesync"
 	"go_remind/tui"
-	"go_remind/watcher"
 )
 
 func main() {
-	var reminders []*reminder.Reminder
-	var tuiEvents chan tui.FileUpdateMsg
-
 	// Parse flags
 	testDir := flag.Bool("test_dir", false, "Use test state directory (~/.go_remind/test/)")
+	configPath := flag.String("config", "", "Path to config file (default ~/.go_remind/config.toml)")
+	profile := flag.String("profile", "", "Named profile from the config, with its own state and watched paths")
+	demoMode := flag.Bool("demo", false, "Try go_remind with sample reminders kept in memory; nothing is saved")
 	flag.Parse()
 
-	// Create state store
-	var store *state.Store
-	var err error
-	if *testDir {
-		store, err = state.NewTestStore()
-	} else {
-		store, err = state.NewDefaultStore()
+	// Load user configuration
+	cfg := config.Default()
+	if *configPath == "" {
+		if p, err := config.DefaultPath(); err == nil {
+			*configPath = p
+		}
 	}
+	if *configPath != "" {
+		loaded, err := config.Load(*configPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not load config: %v\n", err)
+		} else {
+			cfg = loaded
+		}
+	}
+	// User themes live next to the config file
+	var themesDir string
+	if *configPath != "" {
+		themesDir = filepath.Join(filepath.Dir(*configPath), "themes")
+	}
+	tui.SetBackground(cfg.UI.Background)
+	tui.SetASCII(cfg.UI.ASCII)
+	if err := tui.SetKeyBindings(cfg.KeyBindings()); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v; using default keys\n", err)
+	}
+	if err := tui.SetColumns(cfg.UI.Columns); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v; using default columns\n", err)
+	}
+	parser.SetKeywords(cfg.Parser.Keywords)
+	parser.SetSkipCode(cfg.Parser.SkipCode)
+	parser.SetTagDefaults(cfg.Parser.TagDefaults)
+	parser.SetMaxFileSize(cfg.Parser.MaxFileBytes())
+	watcher.SetIgnore(cfg.Watch.Ignore)
+
+	// Get remaining arguments after flags
+	args := flag.Args()
+
+	if *demoMode {
+		runDemo(cfg, themesDir)
+		return
+	}
+	if firstRun(*configPath, *profile, args) {
+		cfg = runOnboarding(*configPath, cfg)
+	}
+
+	for {
+		next := run(cfg, *configPath, themesDir, *testDir, *profile, args)
+		if next == "" {
+			return
+		}
+		// Switched in the TUI: the new profile watches its own paths
+		*profile = next
+		args = nil
+	}
+}
+
+// run starts go_remind with the named profile. Returns the profile picked
+// in the TUI's profile switcher, or "" to exit.
+func run(baseCfg *config.Config, configPath, themesDir string, testDir bool, profile string, args []string) string {
+	var reminders []*reminder.Reminder
+	var tuiEvents chan tui.FileUpdateMsg
+
+	cfg, err := baseCfg.ForProfile(profile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if profile == config.DefaultProfile {
+		profile = ""
+	}
+
+	// Create state store
+	store, err := openStore(testDir, profile)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Warning: could not create state store: %v\n", err)
 	}
+	engine := loadRules(cfg)
+
+	if len(args) >= 1 {
+		switch args[0] {
+		case "daemon":
+			runDaemon(store, cfg, engine, watchedPaths(cfg, args[1:]))
+			return ""
+		case "backup":
+			runBackup(store, configPath, args[1:])
+			return ""
+		case "restore":
+			runRestore(store, configPath, args[1:])
+			return ""
+		case "today":
+			runToday(store, cfg)
+			return ""
+		case "agenda":
+			runAgenda(store, cfg, args[1:])
+			return ""
+		case "report":
+			runReport(store, cfg, args[1:])
+			return ""
+		case "add":
+			runAdd(store, args[1:])
+			return ""
+		case "quick":
+			runQuick(store, cfg, args[1:])
+			return ""
+		case "parse":
+			runParse(store, args[1:])
+			return ""
+		case "import":
+			runImport(store, args[1:])
+			return ""
+		case "export":
+			runExport(store, args[1:])
+			return ""
+		}
+	}
+	paths := watchedPaths(cfg, args)
+
+	// If a daemon is running, it owns the state and the watcher
+	if store != nil {
+		if client, err := daemon.Dial(daemon.SocketPath(filepath.Dir(store.Path()))); err == nil {
+			defer client.Close()
+			if len(args) >= 1 {
+				fmt.Fprintf(os.Stderr, "Warning: daemon is running, ignoring %s (pass it to the daemon instead)\n", args[0])
+			}
+			model := tui.New(client.Reminders(), nil, nil).WithConfig(cfg).WithThemes(themesDir).WithDaemon(client).
+				WithHistory(store.History()).WithActivity(store.Activity()).WithProfiles(profile, baseCfg.ProfileNames()).
+				WithWorkspaces(configPath)
+			if srcs := sources.New(cfg); len(srcs) > 0 {
+				model = model.WithSources(srcs, cfg.Sources.RefreshInterval()).WithSourceSettings(store)
+			}
+			model = model.WithMutes(store).WithIgnoredDuplicates(store).WithViewSettings(store).WithDrafts(store).WithInputHistory(store)
+			final, _ := runTUI(model, nil)
+			return final.SwitchProfile()
+		}
+	}
 
 	// Load saved state first
 	if store != nil {
@@ -45,88 +179,276 @@ func main() {
 		}
 	}
 
-	// Get remaining arguments after flags
-	args := flag.Args()
-
-	if len(args) >= 1 {
-		// File/directory mode
-		path := args[0]
-
-		absPath, err := filepath.Abs(path)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error resolving path: %v\n", err)
-			os.Exit(1)
+	if len(paths) >= 1 {
+		// File/directory mode. Fail fast on a bad path; the files are parsed
+		// in the background once the TUI is up.
+		for _, path := range paths {
+			if _, err := os.Stat(path); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
 		}
+		tuiEvents = make(chan tui.FileUpdateMsg, 10)
+	}
 
-		// Parse reminders from files
-		fileReminders, isDir, err := watcher.ParseInitial(absPath)
+	// Pull and merge state from other machines before starting
+	var syncer statesync.Syncer
+	if cfg.Sync.Enabled {
+		syncer, err = statesync.New(cfg.Sync)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error parsing: %v\n", err)
-			os.Exit(1)
+			fmt.Fprintf(os.Stderr, "Warning: could not set up sync: %v\n", err)
+		} else {
+			merged, err := syncer.Sync(reminders)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: sync failed: %v\n", err)
+			}
+			if merged != nil {
+				reminders = merged
+				if store != nil {
+					_ = store.Save(reminders)
+				}
+			}
 		}
+	}
+
+	reminder.SortByDateTime(reminders)
 
-		// Merge file reminders with saved state
-		// File reminders take precedence for deduplication
-		for _, fr := range fileReminders {
-			reminders = reminder.MergeFromFile(reminders, fr.SourceFile, []*reminder.Reminder{fr})
+	// Run the TUI
+	var tuiStore tui.Store
+	var history *state.History
+	var activity *state.ActivityLog
+	if store != nil {
+		tuiStore = store
+		history = store.History()
+		activity = store.Activity()
+	}
+	model := tui.New(reminders, tuiEvents, tuiStore).WithConfig(cfg).WithThemes(themesDir).WithHistory(history).
+		WithActivity(activity).WithProfiles(profile, baseCfg.ProfileNames()).WithWorkspaces(configPath)
+	if syncer != nil {
+		model = model.WithSyncer(syncer, cfg.Sync.SyncInterval())
+	}
+	var watchers watcherSet
+	if len(paths) >= 1 {
+		model = model.WithWatchedPaths(paths, parseWatched(engine)).
+			WithPathIntervals(cfg.Sources.PathIntervals()).WithWatcherHealth(watchers.health)
+	}
+	if srcs := sources.New(cfg); len(srcs) > 0 {
+		model = model.WithSources(srcs, cfg.Sources.RefreshInterval())
+	}
+	if store != nil {
+		model = model.WithSourceSettings(store).WithMutes(store).WithIgnoredDuplicates(store).WithViewSettings(store).WithDrafts(store).WithInputHistory(store)
+	}
+	var start func(p *tea.Program)
+	stopWatching := make(chan struct{})
+	if len(paths) >= 1 {
+		start = func(p *tea.Program) {
+			go watchInBackground(p, paths, engine, &watchers, tuiEvents, stopWatching)
 		}
+	}
+	final, stopped := runTUI(model, start)
+	close(stopWatching)
+	if stopped {
+		// Killed or the terminal closed: the state is saved, so exit
+		// without waiting on a sync
+		return ""
+	}
 
-		// Set up file watcher
-		w, err := watcher.New()
+	// Push final changes so other machines see them
+	if syncer != nil {
+		merged, err := syncer.Sync(final.Reminders())
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error creating watcher: %v\n", err)
-			os.Exit(1)
+			fmt.Fprintf(os.Stderr, "Warning: sync failed: %v\n", err)
 		}
-		defer w.Stop()
-
-		if isDir {
-			if err := w.WatchDirectory(absPath); err != nil {
-				fmt.Fprintf(os.Stderr, "Error watching directory: %v\n", err)
-				os.Exit(1)
-			}
-		} else {
-			// Watch the parent directory instead of the file directly.
-			// This handles editors that do atomic saves (write temp + rename).
-			parentDir := filepath.Dir(absPath)
-			if err := w.WatchDirectory(parentDir); err != nil {
-				fmt.Fprintf(os.Stderr, "Error watching directory: %v\n", err)
-				os.Exit(1)
-			}
+		if merged != nil && store != nil {
+			_ = store.Save(merged)
 		}
+	}
+	return final.SwitchProfile()
+}
 
-		tuiEvents = make(chan tui.FileUpdateMsg, 10)
+// firstRun reports whether go_remind hasn't been set up yet: there's no
+// config file and nothing to watch was given on the command line
+func firstRun(configPath, profile string, args []string) bool {
+	if configPath == "" || profile != "" || len(args) > 0 {
+		return false
+	}
+	_, err := os.Stat(configPath)
+	return os.IsNotExist(err)
+}
 
-		// Track which file to watch for single-file mode
-		watchPath := absPath
-		watchSingleFile := !isDir
+// runOnboarding runs the setup wizard and returns the config it wrote.
+// Skipping it writes the defaults, so it isn't shown again.
+func runOnboarding(configPath string, cfg *config.Config) *config.Config {
+	final, err := tea.NewProgram(tui.NewOnboarding(configPath, cfg), tea.WithAltScreen()).Run()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error running setup: %v\n", err)
+		os.Exit(1)
+	}
+	if setup := final.(tui.Onboarding).Config(); setup != nil {
+		return setup
+	}
+	if err := config.WriteStarter(configPath, cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not write config: %v\n", err)
+	}
+	return cfg
+}
 
-		w.Start()
-		go func() {
-			for event := range w.Events {
-				if event.Err != nil {
-					continue
-				}
-				// When watching a single file, filter out events for other files
-				if watchSingleFile && event.FilePath != watchPath {
-					continue
-				}
-				tuiEvents <- tui.FileUpdateMsg{
-					FilePath:  event.FilePath,
-					Reminders: event.Reminders,
-				}
-			}
-			close(tuiEvents)
-		}()
+// openStore opens the state store. Each named profile keeps its state,
+// history, and backups in its own directory under profiles/.
+func openStore(testDir bool, profile string) (*state.Store, error) {
+	var store *state.Store
+	var err error
+	if testDir {
+		store, err = state.NewTestStore()
+	} else {
+		store, err = state.NewDefaultStore()
+	}
+	if err != nil || profile == "" {
+		return store, err
 	}
+	return state.NewStoreInDir(filepath.Join(filepath.Dir(store.Path()), "profiles", profile))
+}
 
-	reminder.SortByDateTime(reminders)
+// loadRules loads the rules script set in the config, if any. A broken
+// script is reported and skipped rather than stopping go_remind.
+func loadRules(cfg *config.Config) *rules.Engine {
+	if cfg.Rules.Script == "" {
+		return nil
+	}
+	engine, err := rules.Load(config.ExpandPath(cfg.Rules.Script))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not load rules: %v\n", err)
+		return nil
+	}
+	return engine
+}
 
-	// Run the TUI
-	model := tui.New(reminders, tuiEvents, store)
-	p := tea.NewProgram(model, tea.WithAltScreen())
+// watchedPaths returns the path given on the command line, or else the
+// paths set in the config
+func watchedPaths(cfg *config.Config, args []string) []string {
+	if len(args) >= 1 {
+		return args[:1]
+	}
+	paths := make([]string, len(cfg.Paths))
+	for i, p := range cfg.Paths {
+		paths[i] = config.ExpandPath(p)
+	}
+	return paths
+}
+
+// shutdownSignals end the TUI gracefully: it quits, restoring the terminal,
+// and pending changes are saved before go_remind exits. Ctrl+C in the TUI
+// is a key press, not SIGINT.
+var shutdownSignals = []os.Signal{os.Interrupt, syscall.SIGTERM, syscall.SIGHUP}
+
+// runTUI runs the Bubble Tea program and returns the final model, and
+// whether it was stopped by a signal rather than quit by the user.
+// start, if non-nil, is called with the program before it runs.
+func runTUI(model tui.Model, start func(p *tea.Program)) (tui.Model, bool) {
+	// Signals are handled here rather than by Bubble Tea, to know whether
+	// one ended the program
+	p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithoutSignalHandler())
+	if start != nil {
+		start(p)
+	}
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, shutdownSignals...)
+	defer signal.Stop(sigs)
+	stopped := quitOnSignal(sigs, p.Quit)
 
-	if _, err := p.Run(); err != nil {
+	final, err := p.Run()
+	m := saveOnExit(final, model)
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error running TUI: %v\n", err)
 		os.Exit(1)
 	}
+	return m, stopped.Load()
+}
+
+// quitOnSignal calls quit when a signal arrives on sigs. The returned flag
+// is set once one has.
+func quitOnSignal(sigs <-chan os.Signal, quit func()) *atomic.Bool {
+	var stopped atomic.Bool
+	go func() {
+		if _, ok := <-sigs; ok {
+			stopped.Store(true)
+			quit()
+		}
+	}()
+	return &stopped
+}
+
+// saveOnExit writes the last changes of the model a program ended with,
+// which may still be waiting out the save delay, and returns it. model
+// stands in if the program ended without one.
+func saveOnExit(final tea.Model, model tui.Model) tui.Model {
+	m, ok := final.(tui.Model)
+	if !ok {
+		m = model
+	}
+	if err := m.FlushState(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not save state: %v\n", err)
+	}
+	return m
+}
+
+// watchInBackground parses the watched paths with progress shown in the TUI,
+// then forwards file changes until stop is closed. The rules script, if any,
+// is run over every parse. Each watcher is added to watchers.
+func watchInBackground(p *tea.Program, paths []string, engine *rules.Engine, watchers *watcherSet, tuiEvents chan<- tui.FileUpdateMsg, stop <-chan struct{}) {
+	var wg sync.WaitGroup
+	for _, path := range paths {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			watchOne(p, path, engine, watchers, tuiEvents, stop)
+		}()
+	}
+	wg.Wait()
+	close(tuiEvents)
+}
+
+// parseWatched returns a function that parses a watched file or directory
+// again, for a refresh from the sources view
+func parseWatched(engine *rules.Engine) func(path string) tui.InitialParseMsg {
+	return func(path string) tui.InitialParseMsg {
+		fileReminders, _, err := watcher.ParseInitial(path)
+		if err != nil {
+			return tui.InitialParseMsg{Path: path, Err: err}
+		}
+		fileReminders, rulesErr := engine.Apply(fileReminders)
+		return tui.InitialParseMsg{Path: path, Reminders: fileReminders, RulesErr: rulesErr}
+	}
+}
+
+// watchOne parses and watches a single file or directory
+func watchOne(p *tea.Program, path string, engine *rules.Engine, watchers *watcherSet, tuiEvents chan<- tui.FileUpdateMsg, stop <-chan struct{}) {
+	progress := func(done, total int) {
+		p.Send(tui.ProgressMsg{Op: "parse:" + path, Label: "Parsing notes", Done: done, Total: total})
+	}
+	p.Send(tui.ProgressMsg{Op: "parse:" + path, Label: "Parsing notes"})
+
+	fileReminders, events, w, err := watchPath(path, progress)
+	p.Send(tui.ProgressMsg{Op: "parse:" + path, Finished: true})
+	if err != nil {
+		p.Send(tui.InitialParseMsg{Path: path, Err: err})
+		return
+	}
+	fileReminders, rulesErr := engine.Apply(fileReminders)
+	p.Send(tui.InitialParseMsg{Path: path, Reminders: fileReminders, RulesErr: rulesErr})
+	watchers.add(path, w)
+
+	go func() {
+		<-stop
+		w.Stop()
+	}()
+	for event := range events {
+		fileReminders, rulesErr := engine.Apply(event.Reminders)
+		tuiEvents <- tui.FileUpdateMsg{
+			FilePath:  event.FilePath,
+			Reminders: fileReminders,
+			RulesErr:  rulesErr,
+		}
+	}
 }
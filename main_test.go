@@ -0,0 +1,59 @@
+package main
+
+import (
+	"os"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"go_remind/pkg/reminder"
+	"go_remind/tui"
+)
+
+// savingStore records what it's asked to save
+type savingStore struct {
+	mu    sync.Mutex
+	saves [][]*reminder.Reminder
+}
+
+func (s *savingStore) Save(reminders []*reminder.Reminder) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.saves = append(s.saves, reminders)
+	return nil
+}
+
+func TestShutdownSignalSavesPendingChanges(t *testing.T) {
+	store := &savingStore{}
+	model := tui.New(nil, nil, store)
+	var m tea.Model = model
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m, _ = m.Update(tui.FileUpdateMsg{FilePath: "/notes/a.md", Reminders: []*reminder.Reminder{
+		{ID: "a", Description: "Water the plants", SourceFile: "/notes/a.md", DateTime: time.Now().Add(time.Hour)},
+	}})
+
+	// A signal quits the program, and is reported
+	sigs := make(chan os.Signal, 1)
+	quit := make(chan struct{})
+	stopped := quitOnSignal(sigs, func() { close(quit) })
+	sigs <- syscall.SIGTERM
+	select {
+	case <-quit:
+	case <-time.After(2 * time.Second):
+		t.Fatal("a signal didn't quit the program")
+	}
+	if !stopped.Load() {
+		t.Error("quitting on a signal wasn't reported")
+	}
+
+	// The change still waiting out the save delay is written on the way out
+	saveOnExit(m, model)
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if len(store.saves) != 1 || len(store.saves[0]) != 1 || store.saves[0][0].Description != "Water the plants" {
+		t.Errorf("saved %v, want the pending change saved once", store.saves)
+	}
+}
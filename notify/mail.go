@@ -0,0 +1,56 @@
+package notify
+
+import (
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// Mailer sends email through an SMTP server, upgrading to TLS when the
+// server offers it
+type Mailer struct {
+	Server   string // "host:port"; the port defaults to 587
+	Username string // Empty to send without logging in
+	Password string
+	From     string // Defaults to Username
+	To       []string
+}
+
+// Mail emails a plain text message to every recipient
+func (m Mailer) Mail(subject, body string) error {
+	if len(m.To) == 0 {
+		return fmt.Errorf("no recipients to email")
+	}
+	addr := m.Server
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		addr = net.JoinHostPort(addr, "587")
+	}
+	host, _, _ := net.SplitHostPort(addr)
+
+	var auth smtp.Auth
+	if m.Username != "" {
+		auth = smtp.PlainAuth("", m.Username, m.Password, host)
+	}
+	from := m.From
+	if from == "" {
+		from = m.Username
+	}
+	return smtp.SendMail(addr, auth, from, m.To, message(from, m.To, subject, body, time.Now()))
+}
+
+// message formats an email with the headers mail clients expect
+func message(from string, to []string, subject, body string, date time.Time) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(body, "\r\n", "\n"), "\n", "\r\n"))
+	return []byte(b.String())
+}
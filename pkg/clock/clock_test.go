@@ -0,0 +1,26 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFake(t *testing.T) {
+	start := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	f := NewFake(start)
+	if got := f.Now(); !got.Equal(start) {
+		t.Errorf("Now() = %v, want %v", got, start)
+	}
+	f.Advance(90 * time.Second)
+	if got := f.Now(); !got.Equal(start.Add(90 * time.Second)) {
+		t.Errorf("after Advance, Now() = %v, want %v", got, start.Add(90*time.Second))
+	}
+	f.Set(start)
+	if got := f.Now(); !got.Equal(start) {
+		t.Errorf("after Set, Now() = %v, want %v", got, start)
+	}
+
+	if got := Fixed(start).Now(); !got.Equal(start) {
+		t.Errorf("Fixed().Now() = %v, want %v", got, start)
+	}
+}
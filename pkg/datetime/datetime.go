@@ -1,3 +1,5 @@
+// Package datetime parses the flexible times accepted in reminders:
+// relative offsets like +1h, weekdays, and absolute dates and times.
 package datetime
 
 import (
@@ -245,3 +247,110 @@ func parseWeekday(input string, relativeTo time.Time) (time.Time, bool) {
 	return time.Date(targetDate.Year(), targetDate.Month(), targetDate.Day(),
 		hour, min, 0, 0, time.Local), true
 }
+
+// offsetPattern matches signed offsets like +7d, -2h, +1w2d
+var offsetPattern = regexp.MustCompile(`^[+-](\d+[wdhm])+$`)
+
+// Offset is a signed shift by whole days and a duration. Days are added on
+// the calendar, so a shift by days keeps the time of day across DST changes.
+type Offset struct {
+	Days     int
+	Duration time.Duration
+}
+
+// Apply returns t shifted by the offset
+func (o Offset) Apply(t time.Time) time.Time {
+	return t.AddDate(0, 0, o.Days).Add(o.Duration)
+}
+
+// ParseOffset parses a signed offset like +7d, -2h, or +1w2d3h30m. Units
+// are w (weeks), d (days), h (hours), and m (minutes).
+func ParseOffset(input string) (Offset, error) {
+	input = strings.ToLower(strings.TrimSpace(input))
+	if !offsetPattern.MatchString(input) {
+		return Offset{}, fmt.Errorf("invalid offset %q: use e.g. +7d, -2h, +1w2d", input)
+	}
+	sign := 1
+	if input[0] == '-' {
+		sign = -1
+	}
+
+	var o Offset
+	num := 0
+	for _, char := range input[1:] {
+		if char >= '0' && char <= '9' {
+			num = num*10 + int(char-'0')
+			continue
+		}
+		switch char {
+		case 'w':
+			o.Days += sign * num * 7
+		case 'd':
+			o.Days += sign * num
+		case 'h':
+			o.Duration += time.Duration(sign*num) * time.Hour
+		case 'm':
+			o.Duration += time.Duration(sign*num) * time.Minute
+		}
+		num = 0
+	}
+	return o, nil
+}
+
+// dayOfMonthPattern matches a day of the month like "1st" or "15th", with
+// an optional time after it
+var dayOfMonthPattern = regexp.MustCompile(`^(\d{1,2})(?:st|nd|rd|th)(?:\s+(.+))?$`)
+
+// Next parses a schedule and returns its next time after relativeTo. A
+// time of day that has passed today falls tomorrow, and a day of the month
+// like "1st" or "15th 10am" falls in the next month that has it, at 9am
+// unless a time is given. Anything else is read as Parse reads it.
+func Next(input string, relativeTo time.Time) (time.Time, error) {
+	input = strings.TrimSpace(input)
+	if match := dayOfMonthPattern.FindStringSubmatch(strings.ToLower(input)); match != nil {
+		return nextDayOfMonth(match, relativeTo)
+	}
+
+	t, err := Parse(input, relativeTo)
+	if err != nil {
+		return t, err
+	}
+	if _, _, ok := timeOfDay(input); ok && !t.After(relativeTo) {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
+// nextDayOfMonth returns the next time a dayOfMonthPattern match falls
+func nextDayOfMonth(match []string, relativeTo time.Time) (time.Time, error) {
+	day, _ := strconv.Atoi(match[1])
+	if day < 1 || day > 31 {
+		return time.Time{}, fmt.Errorf("no day %d in a month", day)
+	}
+	hour, min := 9, 0
+	if match[2] != "" {
+		var ok bool
+		if hour, min, ok = timeOfDay(match[2]); !ok {
+			return time.Time{}, fmt.Errorf("unable to parse time in: %q", match[0])
+		}
+	}
+
+	// Months without the day, like February 30th, are skipped
+	for i := 0; i <= 12; i++ {
+		t := time.Date(relativeTo.Year(), relativeTo.Month()+time.Month(i), day, hour, min, 0, 0, time.Local)
+		if t.Day() == day && t.After(relativeTo) {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("no day %d in a month", day)
+}
+
+// timeOfDay parses a time-only input like "9:30am" or "15:00"
+func timeOfDay(input string) (hour, min int, ok bool) {
+	for _, format := range timeOnlyFormats {
+		if t, err := time.ParseInLocation(format, input, time.Local); err == nil {
+			return t.Hour(), t.Minute(), true
+		}
+	}
+	return 0, 0, false
+}
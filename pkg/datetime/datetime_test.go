@@ -183,3 +183,77 @@ func TestParse(t *testing.T) {
 		})
 	}
 }
+
+func TestParseOffset(t *testing.T) {
+	ref := time.Date(2026, 3, 7, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{input: "+7d", want: ref.AddDate(0, 0, 7)},
+		{input: "-2h", want: ref.Add(-2 * time.Hour)},
+		{input: "+1w2d", want: ref.AddDate(0, 0, 9)},
+		{input: "-1d30m", want: ref.AddDate(0, 0, -1).Add(-30 * time.Minute)},
+		{input: " +3H ", want: ref.Add(3 * time.Hour)},
+		{input: "7d", wantErr: true},
+		{input: "+", wantErr: true},
+		{input: "+2x", wantErr: true},
+		{input: "+d", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			o, err := ParseOffset(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseOffset(%q) = %+v, want an error", tt.input, o)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseOffset(%q) error: %v", tt.input, err)
+			}
+			if got := o.Apply(ref); !got.Equal(tt.want) {
+				t.Errorf("ParseOffset(%q).Apply() = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNext(t *testing.T) {
+	ref := time.Date(2026, 1, 30, 10, 0, 0, 0, time.Local)
+	tests := []struct {
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{input: "11am", want: time.Date(2026, 1, 30, 11, 0, 0, 0, time.Local)},
+		{input: "9:30am", want: time.Date(2026, 1, 31, 9, 30, 0, 0, time.Local)},
+		{input: "1st", want: time.Date(2026, 2, 1, 9, 0, 0, 0, time.Local)},
+		{input: "30th 3pm", want: time.Date(2026, 1, 30, 15, 0, 0, 0, time.Local)},
+		{input: "30th 9am", want: time.Date(2026, 3, 30, 9, 0, 0, 0, time.Local)}, // February has no 30th
+		{input: "friday", want: time.Date(2026, 2, 6, 9, 0, 0, 0, time.Local)},
+		{input: "+1h", want: ref.Add(time.Hour)},
+		{input: "32nd", wantErr: true},
+		{input: "1st noonish", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Next(tt.input, ref)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Next(%q) = %v, want an error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Next(%q) error: %v", tt.input, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("Next(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Budget: under 100ms for a 10,000-line note with 1,000 reminders. Most of
+// the time goes to trying datetime layouts on each reminder.
+func BenchmarkParseFile(b *testing.B) {
+	var sb strings.Builder
+	for i := 0; i < 10000; i++ {
+		switch {
+		case i%10 == 0:
+			fmt.Fprintf(&sb, "- [remind_me 2026-06-%02d 14:30 Follow up on item %d #work]\n", 1+i%28, i)
+		case i%100 == 55:
+			sb.WriteString("```\n[remind_me +1h not a reminder]\n```\n")
+		default:
+			fmt.Fprintf(&sb, "Line %d of meeting notes, with some ordinary prose in it.\n", i)
+		}
+	}
+	path := filepath.Join(b.TempDir(), "large.md")
+	if err := os.WriteFile(path, []byte(sb.String()), 0644); err != nil {
+		b.Fatal(err)
+	}
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := ParseFile(path, now); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
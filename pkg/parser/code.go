@@ -0,0 +1,113 @@
+package parser
+
+import "strings"
+
+// skipCode controls whether code and frontmatter are excluded from parsing
+var skipCode = true
+
+// SetSkipCode sets whether ParseFile ignores reminders inside fenced code
+// blocks, inline code spans, and YAML frontmatter. It defaults to true.
+func SetSkipCode(skip bool) {
+	skipCode = skip
+}
+
+// codeTracker follows a markdown file line by line, tracking the regions
+// whose reminders are examples rather than real ones
+type codeTracker struct {
+	lineNumber  int
+	frontmatter bool
+	fence       string // Opening fence of the current code block, if any
+}
+
+// filter returns the part of line that should be parsed. ok is false when
+// the whole line is inside frontmatter or a code block.
+func (c *codeTracker) filter(line string) (text string, ok bool) {
+	c.lineNumber++
+	trimmed := strings.TrimSpace(line)
+
+	if c.lineNumber == 1 && trimmed == "---" {
+		c.frontmatter = true
+		return "", false
+	}
+	if c.frontmatter {
+		if trimmed == "---" || trimmed == "..." {
+			c.frontmatter = false
+		}
+		return "", false
+	}
+
+	if c.fence != "" {
+		if strings.HasPrefix(trimmed, c.fence) && strings.Trim(trimmed, c.fence[:1]) == "" {
+			c.fence = ""
+		}
+		return "", false
+	}
+	if fence := openingFence(trimmed); fence != "" {
+		c.fence = fence
+		return "", false
+	}
+
+	return stripInlineCode(line), true
+}
+
+// openingFence returns the ``` or ~~~ run that opens a code block, or ""
+func openingFence(line string) string {
+	for _, ch := range []string{"`", "~"} {
+		n := len(line) - len(strings.TrimLeft(line, ch))
+		if n >= 3 {
+			// A backtick fence's info string can't contain backticks
+			if ch == "`" && strings.Contains(line[n:], "`") {
+				return ""
+			}
+			return line[:n]
+		}
+	}
+	return ""
+}
+
+// stripInlineCode blanks out `code spans`, matching backtick runs of equal
+// length as markdown does. An unmatched run is left as literal text.
+func stripInlineCode(line string) string {
+	var b strings.Builder
+	i := 0
+	for i < len(line) {
+		if line[i] != '`' {
+			b.WriteByte(line[i])
+			i++
+			continue
+		}
+
+		run := backtickRun(line, i)
+		end := -1
+		for j := i + run; j < len(line); {
+			if line[j] != '`' {
+				j++
+				continue
+			}
+			n := backtickRun(line, j)
+			if n == run {
+				end = j + n
+				break
+			}
+			j += n
+		}
+
+		if end < 0 {
+			b.WriteString(line[i : i+run])
+			i += run
+			continue
+		}
+		b.WriteString(strings.Repeat(" ", end-i))
+		i = end
+	}
+	return b.String()
+}
+
+// backtickRun returns the number of consecutive backticks starting at i
+func backtickRun(s string, i int) int {
+	n := 0
+	for i+n < len(s) && s[i+n] == '`' {
+		n++
+	}
+	return n
+}
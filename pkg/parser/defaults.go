@@ -0,0 +1,38 @@
+package parser
+
+import (
+	"strings"
+	"time"
+
+	"go_remind/pkg/datetime"
+)
+
+// tagDefaults are the times reminders without one fall, by lowercase tag
+var tagDefaults map[string]string
+
+// SetTagDefaults sets when a reminder written with a tag but no time falls,
+// by tag, e.g. "standup" to "9:30am" or "billpay" to "1st". Times are read
+// by datetime.Next, so they're always the next one to come. It should be
+// called once at startup, before any parsing.
+func SetTagDefaults(defaults map[string]string) {
+	tagDefaults = make(map[string]string, len(defaults))
+	for tag, at := range defaults {
+		tagDefaults[strings.ToLower(strings.TrimPrefix(tag, "#"))] = at
+	}
+}
+
+// TagDefault returns the default time of the first tag in text that has
+// one, for text written without a time
+func TagDefault(text string, relativeTo time.Time) (time.Time, bool) {
+	_, tags := ExtractTags(text)
+	for _, tag := range tags {
+		at, ok := tagDefaults[strings.ToLower(tag)]
+		if !ok {
+			continue
+		}
+		if t, err := datetime.Next(at, relativeTo); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
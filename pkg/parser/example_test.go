@@ -0,0 +1,20 @@
+package parser_test
+
+import (
+	"fmt"
+	"time"
+
+	"go_remind/pkg/parser"
+)
+
+func ExampleParseText() {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	notes := `# Errands
+- [remind_me +2h Pick up the dry cleaning #errands]
+- Nothing due here`
+
+	for _, r := range parser.ParseText(notes, now) {
+		fmt.Println(r.DateTime.Format("Jan 2 15:04"), r.Description, r.Tags)
+	}
+	// Output: Mar 2 11:00 Pick up the dry cleaning [errands]
+}
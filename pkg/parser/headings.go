@@ -0,0 +1,40 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+)
+
+// headingPattern matches a markdown ATX heading, e.g. "## Sprint 12", with
+// any closing #s left off the text
+var headingPattern = regexp.MustCompile(`^ {0,3}(#{1,6})[ \t]+(.*?)(?:[ \t]+#+)?[ \t]*$`)
+
+// ContextSeparator joins the headings of a reminder's context
+const ContextSeparator = " > "
+
+// headingTrail follows the headings above the line being parsed, one for
+// each level
+type headingTrail [6]string
+
+// update records line if it's a heading, forgetting the deeper headings it
+// ends. Reminder tokens are left out of a heading's text.
+func (h *headingTrail) update(line string) {
+	m := headingPattern.FindStringSubmatch(line)
+	if m == nil {
+		return
+	}
+	level := len(m[1])
+	h[level-1] = strings.Join(strings.Fields(remindPattern.ReplaceAllString(m[2], "")), " ")
+	clear(h[level:])
+}
+
+// String joins the headings, outermost first, e.g. "Project X > Sprint 12"
+func (h headingTrail) String() string {
+	var parts []string
+	for _, text := range h {
+		if text != "" {
+			parts = append(parts, text)
+		}
+	}
+	return strings.Join(parts, ContextSeparator)
+}
@@ -0,0 +1,59 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+)
+
+const (
+	maxLineLength  = 1 << 20  // Longer lines are cut short here
+	readBufferSize = 64 << 10 // Bytes read at a time
+	binarySniffLen = 8000     // Bytes looked at to tell a binary file, as git does
+)
+
+// DefaultMaxFileSize is the largest file ParseFile reads unless configured
+// otherwise
+const DefaultMaxFileSize = 10 << 20
+
+// maxFileSize is the largest file ParseFile reads, or 0 for no limit
+var maxFileSize int64 = DefaultMaxFileSize
+
+// ErrTooLarge is returned by ParseFile for a file over the size limit,
+// such as something large renamed to .md by accident
+var ErrTooLarge = errors.New("file is too large to parse")
+
+// ErrBinary is returned by ParseFile for a file that isn't text
+var ErrBinary = errors.New("file is binary, not text")
+
+// SetMaxFileSize sets the largest file, in bytes, that ParseFile reads; 0
+// means no limit. It should be called once at startup, before any parsing.
+func SetMaxFileSize(bytes int64) {
+	maxFileSize = bytes
+}
+
+// isBinary reports whether the start of br looks like a binary file: text
+// has no NUL bytes
+func isBinary(br *bufio.Reader) bool {
+	head, _ := br.Peek(binarySniffLen)
+	return bytes.IndexByte(head, 0) >= 0
+}
+
+// readLine returns the next line of br without its line ending. A line
+// longer than maxLineLength is cut short there and the rest skipped, so a
+// file with a huge line still parses.
+func readLine(br *bufio.Reader) (string, error) {
+	var line []byte
+	for {
+		chunk, isPrefix, err := br.ReadLine()
+		if err != nil {
+			return "", err
+		}
+		if room := maxLineLength - len(line); room > 0 {
+			line = append(line, chunk[:min(len(chunk), room)]...)
+		}
+		if !isPrefix {
+			return string(line), nil
+		}
+	}
+}
@@ -0,0 +1,312 @@
+// Package parser finds reminders in markdown, such as
+// [remind_me 3pm Call mom] tokens and task checkboxes with due dates.
+// It has no UI dependencies, so other tools can embed it.
+package parser
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"go_remind/pkg/datetime"
+	"go_remind/pkg/reminder"
+)
+
+// CanonicalKeyword is the trigger keyword used when writing reminders to files
+const CanonicalKeyword = "remind_me"
+
+// Pattern matches [remind_me <content>], plus any configured keywords
+var remindPattern = buildRemindPattern(nil)
+
+// SetKeywords configures extra trigger keywords recognized alongside
+// remind_me. "r!" matches [r! ...]; "@remind" matches @remind(...).
+// It should be called once at startup, before any parsing.
+func SetKeywords(keywords []string) {
+	remindPattern = buildRemindPattern(keywords)
+}
+
+// buildRemindPattern compiles the token regex. Bracket forms capture their
+// content in group 1 and @call forms in group 2.
+func buildRemindPattern(keywords []string) *regexp.Regexp {
+	bracket := []string{regexp.QuoteMeta(CanonicalKeyword)}
+	var call []string
+	for _, kw := range keywords {
+		if name, ok := strings.CutPrefix(kw, "@"); ok {
+			call = append(call, regexp.QuoteMeta(name))
+		} else if kw != CanonicalKeyword {
+			bracket = append(bracket, regexp.QuoteMeta(kw))
+		}
+	}
+
+	expr := `\[(?:` + strings.Join(bracket, "|") + `)\s+([^\]]+)\]`
+	if len(call) > 0 {
+		expr += `|@(?:` + strings.Join(call, "|") + `)\(([^)]+)\)`
+	}
+	return regexp.MustCompile(expr)
+}
+
+// tokenContent returns the captured content of a remindPattern match
+func tokenContent(match []string) string {
+	for _, group := range match[1:] {
+		if group != "" {
+			return strings.TrimSpace(group)
+		}
+	}
+	return ""
+}
+
+// Pattern matches #tag tokens (word characters after #, must be preceded by start or whitespace)
+var tagPattern = regexp.MustCompile(`(?:^|\s)#(\w+)`)
+
+// estimatePattern matches a time estimate token like ~30m, ~2h, or ~1h30m
+var estimatePattern = regexp.MustCompile(`(?:^|\s)~((?:\d+h)?(?:\d+m)?)(?:\s|$)`)
+
+// effortPattern matches an effort token: ^easy, ^medium, or ^hard
+var effortPattern = regexp.MustCompile(`(?i)(?:^|\s)\^(easy|medium|hard)(?:\s|$)`)
+
+// ParseFile reads a markdown file and extracts all reminders.
+// relativeTo is used as the base time for relative datetime parsing.
+// Files over the size limit and binary files aren't read, returning
+// ErrTooLarge or ErrBinary.
+func ParseFile(filepath string, relativeTo time.Time) ([]*reminder.Reminder, error) {
+	file, err := os.Open(filepath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	if maxFileSize > 0 && info.Size() > maxFileSize {
+		return nil, fmt.Errorf("%w (%d bytes, the limit is %d)", ErrTooLarge, info.Size(), maxFileSize)
+	}
+	br := bufio.NewReaderSize(file, readBufferSize)
+	if isBinary(br) {
+		return nil, ErrBinary
+	}
+	return ParseReader(br, filepath, relativeTo)
+}
+
+// ParseReader extracts all reminders from markdown read from r, as
+// ParseFile does from a file. name is recorded as the reminders' source
+// file and their IDs derive from it, so parsing the same text again yields
+// the same IDs.
+func ParseReader(r io.Reader, name string, relativeTo time.Time) ([]*reminder.Reminder, error) {
+	var reminders []*reminder.Reminder
+	br, ok := r.(*bufio.Reader)
+	if !ok {
+		br = bufio.NewReaderSize(r, readBufferSize)
+	}
+	lineNumber := 0
+	var code codeTracker
+	var headings headingTrail
+
+	for {
+		line, err := readLine(br)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading file: %w", err)
+		}
+		lineNumber++
+
+		if skipCode {
+			var ok bool
+			if line, ok = code.filter(line); !ok {
+				continue
+			}
+		}
+
+		// A reminder in a heading is under the headings above it
+		context := headings.String()
+		headings.update(line)
+
+		for _, found := range parseLine(line, relativeTo) {
+			found.ID = reminder.FileID(name, found.Description)
+			found.SourceFile = name
+			found.LineNumber = lineNumber
+			found.Context = context
+			reminders = append(reminders, found)
+		}
+	}
+
+	return reminders, nil
+}
+
+// Pattern matches a leading markdown list marker or checkbox, e.g. "- [ ] " or "1. "
+var listMarkerPattern = regexp.MustCompile(`^(?:[-*+]|\d+[.)])\s+(?:\[[ xX]\]\s+)?`)
+
+// ParseText extracts reminders from free-form text such as clipboard contents.
+// Lines in a recognized syntax yield those reminders; any other line is tried
+// as a plain "<time> <description>" entry.
+func ParseText(text string, relativeTo time.Time) []*reminder.Reminder {
+	var reminders []*reminder.Reminder
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if found := parseLine(line, relativeTo); len(found) > 0 {
+			reminders = append(reminders, found...)
+			continue
+		}
+		line = listMarkerPattern.ReplaceAllString(line, "")
+		if r, err := parseReminderContent(line, relativeTo); err == nil {
+			reminders = append(reminders, r)
+		}
+	}
+	return reminders
+}
+
+// tokenTimeFormat is the datetime format used when writing reminder tokens.
+// It is absolute so the token means the same thing whenever it is parsed.
+const tokenTimeFormat = "2006-01-02 15:04"
+
+// FormatToken renders a reminder as a [remind_me ...] markdown token that
+// parses back to the same time, description, estimate, and tags
+func FormatToken(r *reminder.Reminder) string {
+	parts := []string{"[" + CanonicalKeyword, r.DateTime.Format(tokenTimeFormat), r.Description}
+	if !r.Yearly.IsZero() {
+		parts[1] = FormatYearly(r.Yearly)
+	}
+	if !r.Relative.IsZero() {
+		parts[1] = FormatRelative(r.Relative)
+	}
+	if r.Estimate > 0 {
+		parts = append(parts, "~"+FormatEstimate(r.Estimate))
+	}
+	if r.Effort != reminder.EffortNone {
+		parts = append(parts, "^"+r.Effort.String())
+	}
+	for _, tag := range r.Tags {
+		parts = append(parts, "#"+tag)
+	}
+	return strings.Join(parts, " ") + "]"
+}
+
+// ExtractTags extracts #tag tokens from text and returns the cleaned text and tags.
+// Tags must be preceded by whitespace or be at the start of the string.
+func ExtractTags(text string) (cleanText string, tags []string) {
+	matches := tagPattern.FindAllStringSubmatch(text, -1)
+	for _, match := range matches {
+		if len(match) >= 2 {
+			tags = append(tags, match[1])
+		}
+	}
+
+	// Remove tag tokens from text (including the # prefix)
+	cleanText = tagPattern.ReplaceAllString(text, "")
+	cleanText = strings.TrimSpace(cleanText)
+	// Clean up any double spaces left behind
+	cleanText = strings.Join(strings.Fields(cleanText), " ")
+
+	return cleanText, tags
+}
+
+// ParseEntry parses a reminder typed as "<time> <description>", the same
+// as the content of a [remind_me ...] token, e.g. "+1h take out trash #home"
+func ParseEntry(text string, relativeTo time.Time) (*reminder.Reminder, error) {
+	return parseReminderContent(text, relativeTo)
+}
+
+// ExtractEstimate extracts a ~30m style time estimate from text and returns
+// the text without it. The first estimate wins; zero means none was given.
+func ExtractEstimate(text string) (cleanText string, estimate time.Duration) {
+	for _, match := range estimatePattern.FindAllStringSubmatchIndex(text, -1) {
+		token := text[match[2]:match[3]]
+		d, err := time.ParseDuration(token)
+		if token == "" || err != nil || d <= 0 {
+			continue
+		}
+		cleanText = strings.Join(strings.Fields(text[:match[0]]+" "+text[match[1]:]), " ")
+		return cleanText, d
+	}
+	return text, 0
+}
+
+// FormatEstimate formats an estimate as it's written in a token, e.g. "45m"
+// or "1h30m"
+func FormatEstimate(d time.Duration) string {
+	h, m := int(d.Hours()), int(d.Minutes())%60
+	switch {
+	case h == 0:
+		return fmt.Sprintf("%dm", m)
+	case m == 0:
+		return fmt.Sprintf("%dh", h)
+	default:
+		return fmt.Sprintf("%dh%dm", h, m)
+	}
+}
+
+// ExtractEffort extracts a ^easy style effort token from text and returns
+// the text without it. The first token wins.
+func ExtractEffort(text string) (cleanText string, effort reminder.Effort) {
+	match := effortPattern.FindStringSubmatchIndex(text)
+	if match == nil {
+		return text, reminder.EffortNone
+	}
+	effort, _ = reminder.ParseEffort(text[match[2]:match[3]])
+	cleanText = strings.Join(strings.Fields(text[:match[0]]+" "+text[match[1]:]), " ")
+	return cleanText, effort
+}
+
+// parseReminderContent parses the content inside [remind_me <content>]
+// It tries progressively longer prefixes as the datetime until one parses successfully.
+// The remainder becomes the description. Without a time, a tag's default
+// time is used. Content starting "every year" repeats yearly, and content
+// like "2d after #tag" is relative to another reminder.
+func parseReminderContent(content string, relativeTo time.Time) (*reminder.Reminder, error) {
+	if r, ok, err := parseYearly(content, relativeTo); ok {
+		return r, err
+	}
+	if r, ok := parseRelative(content, relativeTo); ok {
+		return r, nil
+	}
+	words := strings.Fields(content)
+	if len(words) < 2 {
+		return nil, fmt.Errorf("reminder must have both datetime and description")
+	}
+
+	// Try parsing from longest to shortest datetime prefix
+	// This ensures "friday 10am" is tried before "friday"
+	for numDateWords := len(words) - 1; numDateWords >= 1; numDateWords-- {
+		dateStr := strings.Join(words[:numDateWords], " ")
+		descStr := strings.Join(words[numDateWords:], " ")
+
+		parsedTime, err := datetime.Parse(dateStr, relativeTo)
+		if err == nil {
+			return newReminder(parsedTime, descStr, relativeTo), nil
+		}
+	}
+
+	if at, ok := TagDefault(content, relativeTo); ok {
+		return newReminder(at, content, relativeTo), nil
+	}
+	return nil, fmt.Errorf("could not parse datetime from: %s", content)
+}
+
+// newReminder returns a pending reminder due at at, taking its estimate,
+// effort, and tags out of desc
+func newReminder(at time.Time, desc string, relativeTo time.Time) *reminder.Reminder {
+	cleanDesc, estimate := ExtractEstimate(desc)
+	cleanDesc, effort := ExtractEffort(cleanDesc)
+	cleanDesc, tags := ExtractTags(cleanDesc)
+	return &reminder.Reminder{
+		DateTime:    at,
+		Description: cleanDesc,
+		Tags:        tags,
+		Estimate:    estimate,
+		Effort:      effort,
+		Status:      reminder.Pending,
+		UpdatedAt:   relativeTo,
+		CreatedAt:   relativeTo,
+	}
+}
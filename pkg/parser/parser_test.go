@@ -0,0 +1,967 @@
+package parser
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go_remind/pkg/reminder"
+)
+
+func TestParseFile(t *testing.T) {
+	// Create a temporary file for testing
+	tempFile, err := os.CreateTemp("", "parser_test_*.md")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	baseTime := time.Date(2026, 1, 13, 12, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name     string
+		content  string
+		expected int
+		checkFirst func(*testing.T, *reminder.Reminder)
+	}{
+		{
+			name: "single reminder",
+			content: `# Test File
+This has a [remind_me +1h Test reminder] in it.`,
+			expected: 1,
+			checkFirst: func(t *testing.T, r *reminder.Reminder) {
+				if r.Description != "Test reminder" {
+					t.Errorf("Expected description 'Test reminder', got '%s'", r.Description)
+				}
+				expectedTime := baseTime.Add(time.Hour)
+				if !r.DateTime.Equal(expectedTime) {
+					t.Errorf("Expected time %v, got %v", expectedTime, r.DateTime)
+				}
+			},
+		},
+		{
+			name: "multiple reminders same line",
+			content: `Multiple [remind_me +1h First] and [remind_me +2h Second] on same line.`,
+			expected: 2,
+			checkFirst: func(t *testing.T, r *reminder.Reminder) {
+				if r.Description != "First" {
+					t.Errorf("Expected first description 'First', got '%s'", r.Description)
+				}
+			},
+		},
+		{
+			name: "multiple reminders different lines",
+			content: `# Test
+Line 1: [remind_me +1h First reminder]
+Line 2: [remind_me +2h Second reminder]
+Line 3: [remind_me +3h Third reminder]`,
+			expected: 3,
+		},
+		{
+			name: "various datetime formats",
+			content: `# Test
+Relative: [remind_me +30m Relative time]
+Natural: [remind_me tomorrow 9am Natural language]
+Specific: [remind_me 2026-01-15T14:30 Specific datetime]
+Time only: [remind_me 3pm Time only today]`,
+			expected: 4,
+		},
+		{
+			name: "edge cases with whitespace",
+			content: `# Test
+Extra spaces: [remind_me    +1h   Lots of spaces   ]
+Tabs and spaces: [remind_me	+2h	Mixed whitespace	]`,
+			expected: 2,
+			checkFirst: func(t *testing.T, r *reminder.Reminder) {
+				if r.Description != "Lots of spaces" {
+					t.Errorf("Expected description 'Lots of spaces', got '%s'", r.Description)
+				}
+			},
+		},
+		{
+			name: "invalid reminders should be skipped",
+			content: `# Test
+Valid: [remind_me +1h Valid reminder]
+Invalid no desc: [remind_me +1h]
+Invalid no time: [remind_me No time here]
+Invalid empty: [remind_me]
+Another valid: [remind_me +2h Another valid]`,
+			expected: 2,
+		},
+		{
+			name: "complex datetime parsing",
+			content: `# Test
+Multi-word time: [remind_me Jan 15 3:30pm Call dentist]
+Weekday: [remind_me friday 10am Team meeting]
+Relative with units: [remind_me +1h30m Long meeting]`,
+			expected: 3,
+		},
+		{
+			name: "code and frontmatter are skipped",
+			content: "---\n" +
+				"example: [remind_me +1h In frontmatter]\n" +
+				"---\n" +
+				"Real: [remind_me +1h Real reminder]\n" +
+				"```markdown\n" +
+				"[remind_me +1h In fenced block]\n" +
+				"```\n" +
+				"~~~~\n" +
+				"[remind_me +1h In tilde block]\n" +
+				"~~~\n" +
+				"still inside: [remind_me +1h Short fence does not close]\n" +
+				"~~~~\n" +
+				"Type `[remind_me +1h In inline code]` to add one, or ``[remind_me +1h Double ` ticks]``\n" +
+				"Unclosed ` tick: [remind_me +2h After stray backtick]",
+			expected: 2,
+			checkFirst: func(t *testing.T, r *reminder.Reminder) {
+				if r.Description != "Real reminder" {
+					t.Errorf("Expected description 'Real reminder', got '%s'", r.Description)
+				}
+				if r.LineNumber != 4 {
+					t.Errorf("Expected line 4, got %d", r.LineNumber)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Write test content
+			err := os.WriteFile(tempFile.Name(), []byte(tt.content), 0644)
+			if err != nil {
+				t.Fatalf("Failed to write test content: %v", err)
+			}
+
+			// Parse the file
+			reminders, err := ParseFile(tempFile.Name(), baseTime)
+			if err != nil {
+				t.Fatalf("ParseFile failed: %v", err)
+			}
+
+			// Check count
+			if len(reminders) != tt.expected {
+				t.Errorf("Expected %d reminders, got %d", tt.expected, len(reminders))
+				for i, r := range reminders {
+					t.Logf("Reminder %d: '%s' at %v (line %d)", i, r.Description, r.DateTime, r.LineNumber)
+				}
+				return
+			}
+
+			// Check that all reminders have required fields
+			for i, r := range reminders {
+				if r.Description == "" {
+					t.Errorf("Reminder %d has empty description", i)
+				}
+				if r.DateTime.IsZero() {
+					t.Errorf("Reminder %d has zero datetime", i)
+				}
+				if r.SourceFile != tempFile.Name() {
+					t.Errorf("Reminder %d has wrong source file: expected %s, got %s", i, tempFile.Name(), r.SourceFile)
+				}
+				if r.LineNumber <= 0 {
+					t.Errorf("Reminder %d has invalid line number: %d", i, r.LineNumber)
+				}
+				if r.Status != reminder.Pending {
+					t.Errorf("Reminder %d has wrong status: expected %v, got %v", i, reminder.Pending, r.Status)
+				}
+			}
+
+			// Run custom check if provided
+			if tt.checkFirst != nil && len(reminders) > 0 {
+				tt.checkFirst(t, reminders[0])
+			}
+		})
+	}
+}
+
+func TestParseReminderContent(t *testing.T) {
+	baseTime := time.Date(2026, 1, 13, 12, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name        string
+		content     string
+		expectError bool
+		expectedDesc string
+	}{
+		{
+			name:         "simple relative time",
+			content:      "+1h Test reminder",
+			expectError:  false,
+			expectedDesc: "Test reminder",
+		},
+		{
+			name:         "multi-word description",
+			content:      "+30m This is a longer description",
+			expectError:  false,
+			expectedDesc: "This is a longer description",
+		},
+		{
+			name:         "complex datetime",
+			content:      "Jan 15 3:30pm Call the dentist",
+			expectError:  false,
+			expectedDesc: "Call the dentist",
+		},
+		{
+			name:        "no description",
+			content:     "+1h",
+			expectError: true,
+		},
+		{
+			name:        "no datetime",
+			content:     "Just description",
+			expectError: true,
+		},
+		{
+			name:        "empty content",
+			content:     "",
+			expectError: true,
+		},
+		{
+			name:        "single word",
+			content:     "single",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := parseReminderContent(tt.content, baseTime)
+			
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+				return
+			}
+
+			if r.Description != tt.expectedDesc {
+				t.Errorf("Expected description '%s', got '%s'", tt.expectedDesc, r.Description)
+			}
+
+			if r.Status != reminder.Pending {
+				t.Errorf("Expected status Pending, got %v", r.Status)
+			}
+		})
+	}
+}
+
+func TestExtractTags(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		expectedText string
+		expectedTags []string
+	}{
+		{
+			name:         "single tag",
+			input:        "Call mom #family",
+			expectedText: "Call mom",
+			expectedTags: []string{"family"},
+		},
+		{
+			name:         "multiple tags",
+			input:        "Meeting #work #important",
+			expectedText: "Meeting",
+			expectedTags: []string{"work", "important"},
+		},
+		{
+			name:         "no tags",
+			input:        "Call mom",
+			expectedText: "Call mom",
+			expectedTags: nil,
+		},
+		{
+			name:         "tag in middle",
+			input:        "Call #family mom today",
+			expectedText: "Call mom today",
+			expectedTags: []string{"family"},
+		},
+		{
+			name:         "tag at start",
+			input:        "#urgent Call mom",
+			expectedText: "Call mom",
+			expectedTags: []string{"urgent"},
+		},
+		{
+			name:         "adjacent hash not a tag",
+			input:        "Check item#urgent later",
+			expectedText: "Check item#urgent later",
+			expectedTags: nil,
+		},
+		{
+			name:         "multiple tags scattered",
+			input:        "#urgent Call #family mom #important",
+			expectedText: "Call mom",
+			expectedTags: []string{"urgent", "family", "important"},
+		},
+		{
+			name:         "tag with numbers",
+			input:        "Review PR #pr123",
+			expectedText: "Review PR",
+			expectedTags: []string{"pr123"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cleanText, tags := ExtractTags(tt.input)
+
+			if cleanText != tt.expectedText {
+				t.Errorf("Expected text '%s', got '%s'", tt.expectedText, cleanText)
+			}
+
+			if len(tags) != len(tt.expectedTags) {
+				t.Errorf("Expected %d tags, got %d: %v", len(tt.expectedTags), len(tags), tags)
+				return
+			}
+
+			for i, tag := range tags {
+				if tag != tt.expectedTags[i] {
+					t.Errorf("Expected tag[%d] '%s', got '%s'", i, tt.expectedTags[i], tag)
+				}
+			}
+		})
+	}
+}
+
+func TestExtractEstimate(t *testing.T) {
+	tests := []struct {
+		input        string
+		expectedText string
+		expected     time.Duration
+	}{
+		{"Write report ~30m", "Write report", 30 * time.Minute},
+		{"~2h Plan the offsite", "Plan the offsite", 2 * time.Hour},
+		{"Review ~1h30m the PR", "Review the PR", 90 * time.Minute},
+		{"Approx ~ 30m", "Approx ~ 30m", 0},
+		{"Bake for ~0m", "Bake for ~0m", 0},
+		{"Path ~/notes~30m", "Path ~/notes~30m", 0},
+		{"Call mom", "Call mom", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			text, estimate := ExtractEstimate(tt.input)
+			if text != tt.expectedText || estimate != tt.expected {
+				t.Errorf("ExtractEstimate(%q) = %q, %v; want %q, %v", tt.input, text, estimate, tt.expectedText, tt.expected)
+			}
+		})
+	}
+
+	for d, want := range map[time.Duration]string{45 * time.Minute: "45m", 2 * time.Hour: "2h", 90 * time.Minute: "1h30m"} {
+		if got := FormatEstimate(d); got != want {
+			t.Errorf("FormatEstimate(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestExtractEffort(t *testing.T) {
+	tests := []struct {
+		input        string
+		expectedText string
+		expected     reminder.Effort
+	}{
+		{"Reply to emails ^easy", "Reply to emails", reminder.EffortEasy},
+		{"^Hard Refactor the parser", "Refactor the parser", reminder.EffortHard},
+		{"Plan ^medium the offsite #work", "Plan the offsite #work", reminder.EffortMedium},
+		{"Tune x^easy", "Tune x^easy", reminder.EffortNone},
+		{"Climb ^everest", "Climb ^everest", reminder.EffortNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			text, effort := ExtractEffort(tt.input)
+			if text != tt.expectedText || effort != tt.expected {
+				t.Errorf("ExtractEffort(%q) = %q, %v; want %q, %v", tt.input, text, effort, tt.expectedText, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseReminderContentWithTags(t *testing.T) {
+	baseTime := time.Date(2026, 1, 13, 12, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name         string
+		content      string
+		expectedDesc string
+		expectedTags []string
+	}{
+		{
+			name:         "reminder with single tag",
+			content:      "+1h Call mom #family",
+			expectedDesc: "Call mom",
+			expectedTags: []string{"family"},
+		},
+		{
+			name:         "reminder with multiple tags",
+			content:      "+30m Meeting #work #important",
+			expectedDesc: "Meeting",
+			expectedTags: []string{"work", "important"},
+		},
+		{
+			name:         "reminder without tags",
+			content:      "+1h Call mom",
+			expectedDesc: "Call mom",
+			expectedTags: nil,
+		},
+		{
+			name:         "complex datetime with tags",
+			content:      "Jan 15 3:30pm Dentist appointment #health #important",
+			expectedDesc: "Dentist appointment",
+			expectedTags: []string{"health", "important"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := parseReminderContent(tt.content, baseTime)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if r.Description != tt.expectedDesc {
+				t.Errorf("Expected description '%s', got '%s'", tt.expectedDesc, r.Description)
+			}
+
+			if len(r.Tags) != len(tt.expectedTags) {
+				t.Errorf("Expected %d tags, got %d: %v", len(tt.expectedTags), len(r.Tags), r.Tags)
+				return
+			}
+
+			for i, tag := range r.Tags {
+				if tag != tt.expectedTags[i] {
+					t.Errorf("Expected tag[%d] '%s', got '%s'", i, tt.expectedTags[i], tag)
+				}
+			}
+		})
+	}
+}
+
+func TestRegexPattern(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected int
+	}{
+		{
+			name:     "single match",
+			input:    "This has [remind_me +1h test] in it",
+			expected: 1,
+		},
+		{
+			name:     "multiple matches",
+			input:    "First [remind_me +1h one] and [remind_me +2h two] here",
+			expected: 2,
+		},
+		{
+			name:     "no matches",
+			input:    "This has no reminders in it",
+			expected: 0,
+		},
+		{
+			name:     "malformed brackets",
+			input:    "This has [remind_me +1h unclosed and [remind_me +2h test] closed",
+			expected: 1,
+		},
+		{
+			name:     "nested brackets",
+			input:    "This has [remind_me +1h [nested] content] here",
+			expected: 1, // Matches up to first closing bracket: "[nested"
+		},
+		{
+			name:     "extra whitespace",
+			input:    "This has [remind_me    +1h   lots of spaces   ] here",
+			expected: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := remindPattern.FindAllStringSubmatch(tt.input, -1)
+			if len(matches) != tt.expected {
+				t.Errorf("Expected %d matches, got %d", tt.expected, len(matches))
+				for i, match := range matches {
+					t.Logf("Match %d: %v", i, match)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatTokenRoundTrip(t *testing.T) {
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.Local)
+	original := &reminder.Reminder{
+		DateTime:    time.Date(2025, 3, 1, 9, 30, 0, 0, time.Local),
+		Description: "Renew passport",
+		Tags:        []string{"admin", "travel"},
+		Estimate:    90 * time.Minute,
+		Effort:      reminder.EffortEasy,
+	}
+
+	token := FormatToken(original)
+	matches := remindPattern.FindAllStringSubmatch(token, -1)
+	if len(matches) != 1 {
+		t.Fatalf("FormatToken() = %q, does not match the reminder pattern", token)
+	}
+
+	parsed, err := parseReminderContent(matches[0][1], now)
+	if err != nil {
+		t.Fatalf("parseReminderContent(%q) error: %v", matches[0][1], err)
+	}
+	if !parsed.DateTime.Equal(original.DateTime) {
+		t.Errorf("DateTime = %v, want %v", parsed.DateTime, original.DateTime)
+	}
+	if parsed.Description != original.Description {
+		t.Errorf("Description = %q, want %q", parsed.Description, original.Description)
+	}
+	if len(parsed.Tags) != 2 || parsed.Tags[0] != "admin" || parsed.Tags[1] != "travel" {
+		t.Errorf("Tags = %v, want [admin travel]", parsed.Tags)
+	}
+	if parsed.Estimate != original.Estimate {
+		t.Errorf("Estimate = %v, want %v", parsed.Estimate, original.Estimate)
+	}
+	if parsed.Effort != original.Effort {
+		t.Errorf("Effort = %v, want %v", parsed.Effort, original.Effort)
+	}
+}
+
+func TestParseText(t *testing.T) {
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.Local)
+	text := `# Reminders
+
+- [ ] [remind_me 2025-02-01 09:00 Pay rent #bills]
+Buy milk and [remind_me +1h Call mom] later
++2h Water the plants
+1. tomorrow 9am Standup
+Just a note without a time`
+
+	got := ParseText(text, now)
+	want := []string{"Pay rent", "Call mom", "Water the plants", "Standup"}
+	if len(got) != len(want) {
+		t.Fatalf("ParseText() returned %d reminders, want %d", len(got), len(want))
+	}
+	for i, r := range got {
+		if r.Description != want[i] {
+			t.Errorf("reminder %d Description = %q, want %q", i, r.Description, want[i])
+		}
+	}
+	if len(got[0].Tags) != 1 || got[0].Tags[0] != "bills" {
+		t.Errorf("Tags = %v, want [bills]", got[0].Tags)
+	}
+}
+
+func TestParseReader(t *testing.T) {
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.Local)
+	text := `# Notes
+
+Released in 2025, see [remind_me +1h Call mom]
++2h Not a token, so not a reminder in a document`
+
+	got, err := ParseReader(strings.NewReader(text), "stdin", now)
+	if err != nil {
+		t.Fatalf("ParseReader() error: %v", err)
+	}
+	if len(got) != 1 || got[0].Description != "Call mom" || got[0].LineNumber != 3 || got[0].SourceFile != "stdin" {
+		t.Fatalf("ParseReader() = %+v, want Call mom from stdin line 3", got)
+	}
+	again, _ := ParseReader(strings.NewReader(text), "stdin", now)
+	if again[0].ID != got[0].ID {
+		t.Errorf("parsing the same text twice gave IDs %s and %s", got[0].ID, again[0].ID)
+	}
+}
+
+func TestTaskSyntax(t *testing.T) {
+	now := time.Date(2026, 1, 13, 12, 0, 0, 0, time.Local)
+	due := time.Date(2026, 1, 15, taskDueHour, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name       string
+		line       string
+		wantDesc   string
+		wantTags   int
+		wantStatus reminder.Status
+		wantNone   bool
+	}{
+		{
+			name:       "obsidian due date",
+			line:       "- [ ] Call mom 📅 2026-01-15",
+			wantDesc:   "Call mom",
+			wantStatus: reminder.Pending,
+		},
+		{
+			name:       "todo.txt due date",
+			line:       "* [ ] Call mom due:2026-01-15 #family",
+			wantDesc:   "Call mom",
+			wantTags:   1,
+			wantStatus: reminder.Pending,
+		},
+		{
+			name:       "checked task is acknowledged",
+			line:       "- [x] Call mom ⏫ 📅 2026-01-15 ✅ 2026-01-14",
+			wantDesc:   "Call mom",
+			wantStatus: reminder.Acknowledged,
+		},
+		{
+			name:     "task without due date",
+			line:     "- [ ] Call mom",
+			wantNone: true,
+		},
+		{
+			name:     "due date outside a task",
+			line:     "Call mom 📅 2026-01-15",
+			wantNone: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseLine(tt.line, now)
+			if tt.wantNone {
+				if len(got) != 0 {
+					t.Errorf("parseLine(%q) = %d reminders, want none", tt.line, len(got))
+				}
+				return
+			}
+			if len(got) != 1 {
+				t.Fatalf("parseLine(%q) = %d reminders, want 1", tt.line, len(got))
+			}
+			r := got[0]
+			if r.Description != tt.wantDesc {
+				t.Errorf("Description = %q, want %q", r.Description, tt.wantDesc)
+			}
+			if !r.DateTime.Equal(due) {
+				t.Errorf("DateTime = %v, want %v", r.DateTime, due)
+			}
+			if len(r.Tags) != tt.wantTags {
+				t.Errorf("Tags = %v, want %d tags", r.Tags, tt.wantTags)
+			}
+			if r.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v", r.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestTaskDueOnDSTDay(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("no time zone data: %v", err)
+	}
+	local := time.Local
+	time.Local = loc
+	t.Cleanup(func() { time.Local = local })
+
+	// The clocks go forward at 2am on 2026-03-08
+	now := time.Date(2026, 3, 6, 12, 0, 0, 0, loc)
+	got := parseLine("- [ ] Call mom 📅 2026-03-08", now)
+	if len(got) != 1 {
+		t.Fatalf("parseLine() = %d reminders, want 1", len(got))
+	}
+	if h := got[0].DateTime.Hour(); h != taskDueHour {
+		t.Errorf("due at %v, want %d:00", got[0].DateTime, taskDueHour)
+	}
+}
+
+func TestCheckedRemindMeToken(t *testing.T) {
+	now := time.Date(2026, 1, 13, 12, 0, 0, 0, time.Local)
+
+	got := parseLine("- [x] [remind_me +1h Done already]", now)
+	if len(got) != 1 {
+		t.Fatalf("parseLine() = %d reminders, want 1", len(got))
+	}
+	if got[0].Status != reminder.Acknowledged {
+		t.Errorf("Status = %v, want Acknowledged", got[0].Status)
+	}
+}
+
+func TestSetKeywords(t *testing.T) {
+	SetKeywords([]string{"r!", "due", "@remind"})
+	t.Cleanup(func() { SetKeywords(nil) })
+
+	now := time.Date(2026, 1, 13, 12, 0, 0, 0, time.Local)
+	line := "[remind_me +1h One] [r! +1h Two] [due +1h Three] @remind(+1h Four) [other +1h Five]"
+
+	got := parseLine(line, now)
+	want := []string{"One", "Two", "Three", "Four"}
+	if len(got) != len(want) {
+		t.Fatalf("parseLine() = %d reminders, want %d", len(got), len(want))
+	}
+	for i, r := range got {
+		if r.Description != want[i] {
+			t.Errorf("reminder %d Description = %q, want %q", i, r.Description, want[i])
+		}
+	}
+
+	// Written tokens always use the canonical keyword
+	if token := FormatToken(got[1]); !strings.HasPrefix(token, "[remind_me ") {
+		t.Errorf("FormatToken() = %q, want canonical remind_me keyword", token)
+	}
+}
+
+func TestSetSkipCode(t *testing.T) {
+	SetSkipCode(false)
+	t.Cleanup(func() { SetSkipCode(true) })
+
+	tempFile, err := os.CreateTemp("", "parser_test_*.md")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	content := "```\n[remind_me +1h In code]\n```\nInline `[remind_me +1h In span]`"
+	if err := os.WriteFile(tempFile.Name(), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test content: %v", err)
+	}
+
+	reminders, err := ParseFile(tempFile.Name(), time.Now())
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+	if len(reminders) != 2 {
+		t.Errorf("Expected 2 reminders with code skipping disabled, got %d", len(reminders))
+	}
+}
+
+func TestRewriteToken(t *testing.T) {
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.Local)
+	path := t.TempDir() + "/notes.md"
+	text := "# Notes\r\n\r\n- [remind_me 3pm Pay rent] and [remind_me +1h Call mom #family]\r\n- [ ] Water plants due:2025-01-16\r\n"
+	if err := os.WriteFile(path, []byte(text), 0600); err != nil {
+		t.Fatal(err)
+	}
+	rs, err := ParseFile(path, now)
+	if err != nil || len(rs) != 3 {
+		t.Fatalf("ParseFile() = %v, %v", rs, err)
+	}
+
+	// The token is found on its line even though the line has moved
+	old := rs[1]
+	old.LineNumber = 1
+	updated := *old
+	updated.DateTime = now.Add(24 * time.Hour)
+	updated.Description = "Call mom back"
+	if err := RewriteToken(old, &updated, now); err != nil {
+		t.Fatalf("RewriteToken() error: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	want := "- [remind_me 3pm Pay rent] and [remind_me 2025-01-16 10:00 Call mom back #family]\r\n"
+	if !strings.Contains(string(data), want) || !strings.HasPrefix(string(data), "# Notes\r\n") {
+		t.Errorf("file after RewriteToken():\n%q\nwant the line %q", data, want)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0600 {
+		t.Errorf("file mode = %v, want 0600 kept", info.Mode().Perm())
+	}
+
+	// A task line has no token to rewrite
+	if err := RewriteToken(rs[2], rs[2], now); err != ErrTokenNotFound {
+		t.Errorf("RewriteToken() of a task = %v, want ErrTokenNotFound", err)
+	}
+}
+
+func TestFindToken(t *testing.T) {
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.Local)
+	lines := []string{"# Plan\n", "- [remind_me friday Ship it] [remind_me 3pm Demo]\n", "- [remind_me 3pm Demo]\n"}
+	if i, start, end, ok := FindToken(lines, 3, "Demo", now); !ok || i != 2 || lines[i][start:end] != "[remind_me 3pm Demo]" {
+		t.Errorf("FindToken() on its line = %d %d %d %v, want line 2", i, start, end, ok)
+	}
+	if i, start, end, ok := FindToken(lines, 9, "Demo", now); !ok || i != 1 || lines[i][start:end] != "[remind_me 3pm Demo]" {
+		t.Errorf("FindToken() after the line moved = %d %d %d %v, want the second token on line 1", i, start, end, ok)
+	}
+	if _, _, _, ok := FindToken(lines, 1, "Retro", now); ok {
+		t.Error("FindToken() found a token that isn't there")
+	}
+
+	// Examples in frontmatter, fenced code, and code spans aren't the token
+	lines = []string{"---\n", "x: [remind_me 3pm Demo]\n", "---\n", "```\n", "[remind_me 3pm Demo]\n", "```\n", "`[remind_me 3pm Demo]` and [remind_me 3pm Demo]\n"}
+	for _, line := range []int{2, 5, 7} {
+		if i, start, _, ok := FindToken(lines, line, "Demo", now); !ok || i != 6 || start != 27 {
+			t.Errorf("FindToken() from line %d = line %d at %d, %v; want the token after the code span on line 6", line, i, start, ok)
+		}
+	}
+}
+
+func TestSetTagDefaults(t *testing.T) {
+	SetTagDefaults(map[string]string{"#Standup": "9:30am", "billpay": "1st"})
+	t.Cleanup(func() { SetTagDefaults(nil) })
+	now := time.Date(2026, 1, 13, 12, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		input string
+		want  time.Time
+		desc  string
+	}{
+		{"Daily sync #standup", time.Date(2026, 1, 14, 9, 30, 0, 0, time.Local), "Daily sync"},
+		{"Pay rent #home #billpay ~5m", time.Date(2026, 2, 1, 9, 0, 0, 0, time.Local), "Pay rent"},
+		{"3pm Daily sync #standup", time.Date(2026, 1, 13, 15, 0, 0, 0, time.Local), "Daily sync"}, // A time given wins
+	}
+	for _, tt := range tests {
+		r, err := ParseEntry(tt.input, now)
+		if err != nil {
+			t.Errorf("ParseEntry(%q) error: %v", tt.input, err)
+			continue
+		}
+		if !r.DateTime.Equal(tt.want) || r.Description != tt.desc {
+			t.Errorf("ParseEntry(%q) = %v %q, want %v %q", tt.input, r.DateTime, r.Description, tt.want, tt.desc)
+		}
+	}
+
+	// Without a time or a tag that has a default, it's still an error
+	if _, err := ParseEntry("Daily sync #work", now); err == nil {
+		t.Errorf("ParseEntry() without a time should fail")
+	}
+}
+
+func TestParseYearly(t *testing.T) {
+	now := time.Date(2026, 3, 20, 12, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		input  string
+		want   time.Time
+		yearly reminder.Yearly
+		desc   string
+	}{
+		{"every year Mar 14 Mom's birthday", time.Date(2027, 3, 14, 9, 0, 0, 0, time.Local), reminder.Yearly{Month: time.March, Day: 14}, "Mom's birthday"},
+		{"every year march 25th, 1958 7 days before Dad's birthday #family", time.Date(2026, 3, 18, 9, 0, 0, 0, time.Local), reminder.Yearly{Month: time.March, Day: 25, Since: 1958, Before: 7}, "Dad's birthday"},
+		{"Every year on Jun 1 2019 3d before Anniversary", time.Date(2026, 5, 29, 9, 0, 0, 0, time.Local), reminder.Yearly{Month: time.June, Day: 1, Since: 2019, Before: 3}, "Anniversary"},
+		{"every year Mar 20 Today's the day", time.Date(2026, 3, 20, 9, 0, 0, 0, time.Local), reminder.Yearly{Month: time.March, Day: 20}, "Today's the day"},
+	}
+	for _, tt := range tests {
+		r, err := ParseEntry(tt.input, now)
+		if err != nil {
+			t.Errorf("ParseEntry(%q) error: %v", tt.input, err)
+			continue
+		}
+		if !r.DateTime.Equal(tt.want) || r.Yearly != tt.yearly || r.Description != tt.desc {
+			t.Errorf("ParseEntry(%q) = %v %+v %q, want %v %+v %q", tt.input, r.DateTime, r.Yearly, r.Description, tt.want, tt.yearly, tt.desc)
+		}
+
+		// The token keeps the yearly date rather than this year's
+		back := ParseText(FormatToken(r), now)
+		if len(back) != 1 || back[0].Yearly != r.Yearly || !back[0].DateTime.Equal(r.DateTime) || back[0].Description != r.Description {
+			t.Errorf("FormatToken(%q) = %q, which doesn't parse back the same", tt.input, FormatToken(r))
+		}
+	}
+
+	for _, input := range []string{"every year Foo 14 Party", "every year Feb 30 Party"} {
+		if _, err := ParseEntry(input, now); err == nil {
+			t.Errorf("ParseEntry(%q) should fail", input)
+		}
+	}
+}
+
+func TestParseRelative(t *testing.T) {
+	now := time.Date(2026, 3, 20, 12, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		input    string
+		relative reminder.Relative
+		desc     string
+	}{
+		{"2d after #release Retro meeting", reminder.Relative{Tag: "release", Offset: 48 * time.Hour}, "Retro meeting"},
+		{"3 hours before #launch Final checks #work", reminder.Relative{Tag: "launch", Offset: -3 * time.Hour}, "Final checks"},
+		{"1w After #trip Unpack", reminder.Relative{Tag: "trip", Offset: 7 * 24 * time.Hour}, "Unpack"},
+		{"90m after #standup Follow up", reminder.Relative{Tag: "standup", Offset: 90 * time.Minute}, "Follow up"},
+	}
+	for _, tt := range tests {
+		r, err := ParseEntry(tt.input, now)
+		if err != nil {
+			t.Errorf("ParseEntry(%q) error: %v", tt.input, err)
+			continue
+		}
+		if r.Relative != tt.relative || r.Description != tt.desc || !r.DateTime.Equal(now.Add(tt.relative.Offset)) {
+			t.Errorf("ParseEntry(%q) = %+v %q at %v, want %+v %q", tt.input, r.Relative, r.Description, r.DateTime, tt.relative, tt.desc)
+		}
+
+		back := ParseText(FormatToken(r), now)
+		if len(back) != 1 || back[0].Relative != r.Relative || back[0].Description != r.Description {
+			t.Errorf("FormatToken(%q) = %q, which doesn't parse back the same", tt.input, FormatToken(r))
+		}
+	}
+}
+
+func TestParseFileLimits(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.Local)
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	// A line too long to keep is cut short, and the lines after still parse
+	long := write("long.md", "[remind_me 3pm Before] "+strings.Repeat("x", 3<<20)+"\n[remind_me 4pm After]\n")
+	reminders, err := ParseFile(long, now)
+	if err != nil || len(reminders) != 2 || reminders[1].LineNumber != 2 {
+		t.Errorf("long line: %d reminders, %v; want Before and After on line 2", len(reminders), err)
+	}
+
+	binary := write("image.md", "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR [remind_me 3pm Not real]")
+	if _, err := ParseFile(binary, now); !errors.Is(err, ErrBinary) {
+		t.Errorf("binary file: err = %v, want ErrBinary", err)
+	}
+
+	SetMaxFileSize(16)
+	defer SetMaxFileSize(DefaultMaxFileSize)
+	big := write("big.md", "[remind_me 3pm Too far in]")
+	if _, err := ParseFile(big, now); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("large file: err = %v, want ErrTooLarge", err)
+	}
+	SetMaxFileSize(0)
+	if reminders, err := ParseFile(big, now); err != nil || len(reminders) != 1 {
+		t.Errorf("with no limit: %d reminders, %v; want 1", len(reminders), err)
+	}
+}
+
+func TestHeadingContext(t *testing.T) {
+	content := `# Project X
+[remind_me 9am Kickoff]
+
+## Sprint 12 ##
+- [ ] Write the demo due:2026-03-06
+
+### Retro [remind_me 4pm Book a room]
+
+## Sprint 13
+[remind_me 10am Follow up]
+
+` + "```" + `
+# Not a heading
+` + "```" + `
+#notaheading [remind_me 11am Plan]
+`
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.Local)
+	reminders, err := ParseReader(strings.NewReader(content), "notes.md", now)
+	if err != nil {
+		t.Fatalf("ParseReader: %v", err)
+	}
+	want := map[string]string{
+		"Kickoff":        "Project X",
+		"Write the demo": "Project X > Sprint 12",
+		"Book a room":    "Project X > Sprint 12",
+		"Follow up":      "Project X > Sprint 13",
+		"Plan":           "Project X > Sprint 13",
+	}
+	found := 0
+	for _, r := range reminders {
+		if context, ok := want[r.Description]; ok {
+			found++
+			if r.Context != context {
+				t.Errorf("%q is under %q, want %q", r.Description, r.Context, context)
+			}
+		}
+	}
+	if found != len(want) {
+		t.Errorf("found %d of the %d reminders", found, len(want))
+	}
+}
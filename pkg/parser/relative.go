@@ -0,0 +1,60 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go_remind/pkg/reminder"
+)
+
+// relativePattern matches a time relative to a tagged reminder ahead of the
+// description, e.g. "2d after #release Retro meeting"
+var relativePattern = regexp.MustCompile(`(?i)^(\d+)\s*(m|mins?|minutes?|h|hrs?|hours?|d|days?|w|weeks?)\s+(after|before)\s+#(\w+)\s+(\S.*)$`)
+
+// offsetUnits are the lengths of the units a relative offset is written in,
+// by first letter
+var offsetUnits = map[byte]time.Duration{
+	'm': time.Minute,
+	'h': time.Hour,
+	'd': 24 * time.Hour,
+	'w': 7 * 24 * time.Hour,
+}
+
+// parseRelative parses content written relative to another reminder,
+// reporting false if it isn't. Until it's placed by
+// reminder.ResolveRelative, it's due as if that reminder were due now.
+func parseRelative(content string, relativeTo time.Time) (*reminder.Reminder, bool) {
+	match := relativePattern.FindStringSubmatch(strings.TrimSpace(content))
+	if match == nil {
+		return nil, false
+	}
+	n, _ := strconv.Atoi(match[1])
+	rel := reminder.Relative{Tag: match[4], Offset: time.Duration(n) * offsetUnits[strings.ToLower(match[2])[0]]}
+	if strings.EqualFold(match[3], "before") {
+		rel.Offset = -rel.Offset
+	}
+
+	r := newReminder(relativeTo.Add(rel.Offset), match[5], relativeTo)
+	r.Relative = rel
+	return r, true
+}
+
+// FormatRelative returns how rel is written in a token, e.g.
+// "2d after #release"
+func FormatRelative(rel reminder.Relative) string {
+	offset, dir := rel.Offset, "after"
+	if offset < 0 {
+		offset, dir = -offset, "before"
+	}
+	amount := fmt.Sprintf("%dm", int(offset/time.Minute))
+	switch {
+	case offset%offsetUnits['d'] == 0:
+		amount = fmt.Sprintf("%dd", int(offset/offsetUnits['d']))
+	case offset%time.Hour == 0:
+		amount = fmt.Sprintf("%dh", int(offset/time.Hour))
+	}
+	return amount + " " + dir + " #" + rel.Tag
+}
@@ -0,0 +1,74 @@
+package parser
+
+import (
+	"regexp"
+	"time"
+
+	"go_remind/pkg/reminder"
+)
+
+// Syntax recognizes one reminder notation within a single line of a note.
+// New note formats are supported by implementing Syntax and registering it.
+type Syntax interface {
+	// Name identifies the syntax, e.g. "remind_me"
+	Name() string
+	// Parse returns the reminders found in line, or nil if there are none
+	Parse(line string, relativeTo time.Time) []*reminder.Reminder
+}
+
+// syntaxes are tried in order for each line; the first that finds
+// reminders wins, so a line is never counted twice
+var syntaxes = []Syntax{remindMeSyntax{}, taskSyntax{}}
+
+// RegisterSyntax adds a syntax after the built-in ones
+func RegisterSyntax(s Syntax) {
+	syntaxes = append(syntaxes, s)
+}
+
+// Syntaxes returns the registered syntaxes in the order they are tried
+func Syntaxes() []Syntax {
+	return syntaxes
+}
+
+// parseLine runs the registered syntaxes over a line
+func parseLine(line string, relativeTo time.Time) []*reminder.Reminder {
+	for _, s := range syntaxes {
+		if found := s.Parse(line, relativeTo); len(found) > 0 {
+			markChecked(line, found, relativeTo)
+			return found
+		}
+	}
+	return nil
+}
+
+// Pattern matches a checked markdown checkbox at the start of a line
+var checkedPattern = regexp.MustCompile(`^\s*(?:[-*+]|\d+[.)])\s+\[[xX]\]`)
+
+// markChecked acknowledges reminders found on a ticked checkbox line
+func markChecked(line string, found []*reminder.Reminder, relativeTo time.Time) {
+	if !checkedPattern.MatchString(line) {
+		return
+	}
+	for _, r := range found {
+		r.Status = reminder.Acknowledged
+		r.AcknowledgedAt = relativeTo
+	}
+}
+
+// remindMeSyntax parses [remind_me <time> <description>] tokens
+type remindMeSyntax struct{}
+
+func (remindMeSyntax) Name() string { return "remind_me" }
+
+func (remindMeSyntax) Parse(line string, relativeTo time.Time) []*reminder.Reminder {
+	var found []*reminder.Reminder
+	for _, match := range remindPattern.FindAllStringSubmatch(line, -1) {
+		r, err := parseReminderContent(tokenContent(match), relativeTo)
+		if err != nil {
+			// Skip invalid reminders but could log warning
+			continue
+		}
+		found = append(found, r)
+	}
+	return found
+}
@@ -0,0 +1,65 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"go_remind/pkg/reminder"
+)
+
+// taskDueHour is the time of day a date-only task due date fires
+const taskDueHour = 9
+
+// Pattern matches a markdown task line: "- [ ] text" or "- [x] text"
+var taskPattern = regexp.MustCompile(`^\s*(?:[-*+]|\d+[.)])\s+\[[ xX]\]\s+(.+)$`)
+
+// Pattern matches a due date as written by Obsidian Tasks ("📅 2026-01-15")
+// or in todo.txt style ("due:2026-01-15")
+var taskDuePattern = regexp.MustCompile(`(?:📅\s*|\bdue:)(\d{4}-\d{2}-\d{2})`)
+
+// Pattern matches other Obsidian Tasks fields, which are dropped from the description
+var taskFieldPattern = regexp.MustCompile(`(?:[⏳🛫➕✅❌]\s*\d{4}-\d{2}-\d{2}|[🔺⏫🔼🔽⏬])`)
+
+// taskSyntax parses Obsidian Tasks / GitHub-style checkbox lines that carry a
+// due date, e.g. "- [ ] Call mom 📅 2026-01-15"
+type taskSyntax struct{}
+
+func (taskSyntax) Name() string { return "tasks" }
+
+func (taskSyntax) Parse(line string, relativeTo time.Time) []*reminder.Reminder {
+	match := taskPattern.FindStringSubmatch(line)
+	if match == nil {
+		return nil
+	}
+	text := match[1]
+
+	due := taskDuePattern.FindStringSubmatch(text)
+	if due == nil {
+		return nil
+	}
+	date, err := time.ParseInLocation("2006-01-02", due[1], time.Local)
+	if err != nil {
+		return nil
+	}
+
+	text = taskDuePattern.ReplaceAllString(text, "")
+	text = taskFieldPattern.ReplaceAllString(text, "")
+	text, estimate := ExtractEstimate(text)
+	text, effort := ExtractEffort(text)
+	desc, tags := ExtractTags(text)
+	if desc == "" {
+		return nil
+	}
+
+	return []*reminder.Reminder{{
+		DateTime:    time.Date(date.Year(), date.Month(), date.Day(), taskDueHour, 0, 0, 0, time.Local),
+		Description: strings.TrimSpace(desc),
+		Tags:        tags,
+		Estimate:    estimate,
+		Effort:      effort,
+		Status:      reminder.Pending,
+		UpdatedAt:   relativeTo,
+		CreatedAt:   relativeTo,
+	}}
+}
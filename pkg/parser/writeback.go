@@ -0,0 +1,110 @@
+package parser
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go_remind/pkg/reminder"
+)
+
+// ErrTokenNotFound is returned by RewriteToken when the reminder's token
+// isn't in its file, e.g. because it's a task line rather than a token
+var ErrTokenNotFound = errors.New("reminder token not found in its file")
+
+// RewriteToken replaces the token of old in its source file with one for
+// updated, so the file says what was edited elsewhere. The token is looked
+// for on old's line first, then anywhere in the file in case lines moved;
+// now places relative times while matching tokens. The file is replaced
+// atomically, so a watcher never parses half of it.
+func RewriteToken(old, updated *reminder.Reminder, now time.Time) error {
+	data, err := os.ReadFile(old.SourceFile)
+	if err != nil {
+		return err
+	}
+	lines := strings.SplitAfter(string(data), "\n")
+	i, start, end, ok := FindToken(lines, old.LineNumber, old.Description, now)
+	if !ok {
+		return ErrTokenNotFound
+	}
+	lines[i] = lines[i][:start] + FormatToken(updated) + lines[i][end:]
+	return writeFileAtomic(old.SourceFile, []byte(strings.Join(lines, "")))
+}
+
+// FindToken returns the index in lines of the line holding the token whose
+// description is desc, and the token's byte offsets in that line. The
+// token is looked for on line, counting from 1, first, then anywhere in
+// case lines moved. Tokens in code and frontmatter are skipped, as the
+// parser skips them. It reports false if no line holds it.
+func FindToken(lines []string, line int, desc string, now time.Time) (i, start, end int, ok bool) {
+	text := parsedText(lines)
+	if i = line - 1; i >= 0 && i < len(text) {
+		if start, end, ok = tokenSpan(text[i], desc, now); ok {
+			return i, start, end, true
+		}
+	}
+	for i := range text {
+		if start, end, ok = tokenSpan(text[i], desc, now); ok {
+			return i, start, end, true
+		}
+	}
+	return 0, 0, 0, false
+}
+
+// parsedText returns lines as the parser reads them, with code and
+// frontmatter blanked out. Inline code is blanked with spaces, so byte
+// offsets still point into lines.
+func parsedText(lines []string) []string {
+	if !skipCode {
+		return lines
+	}
+	text := make([]string, len(lines))
+	var code codeTracker
+	for i, line := range lines {
+		text[i], _ = code.filter(line)
+	}
+	return text
+}
+
+// tokenSpan returns the byte offsets of the token in line whose
+// description is desc, and whether there is one
+func tokenSpan(line, desc string, now time.Time) (start, end int, ok bool) {
+	matches := remindPattern.FindAllStringSubmatch(line, -1)
+	for i, loc := range remindPattern.FindAllStringIndex(line, -1) {
+		r, err := parseReminderContent(tokenContent(matches[i]), now)
+		if err != nil || r.Description != desc {
+			continue
+		}
+		return loc[0], loc[1], true
+	}
+	return 0, 0, false
+}
+
+// writeFileAtomic replaces path with data by writing a temporary file
+// beside it and renaming it over, keeping path's permissions
+func writeFileAtomic(path string, data []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // Fails harmlessly once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(info.Mode().Perm()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
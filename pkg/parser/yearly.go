@@ -0,0 +1,73 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go_remind/pkg/reminder"
+)
+
+// yearlyPattern matches a yearly date ahead of the description, e.g.
+// "every year Mar 14 1958 7 days before Mom's birthday". The start year and
+// advance warning are optional.
+var yearlyPattern = regexp.MustCompile(`(?i)^every\s+year\s+(?:on\s+)?([a-z]+)\.?\s+(\d{1,2})(?:st|nd|rd|th)?,?(?:\s+(\d{4}))?(?:\s+(\d{1,3})\s*(?:d|days?)\s+before)?\s+(\S.*)$`)
+
+// parseYearly parses content written as a yearly reminder, reporting false
+// if it isn't one. The reminder is due at the next occurrence, less any
+// advance warning.
+func parseYearly(content string, relativeTo time.Time) (*reminder.Reminder, bool, error) {
+	match := yearlyPattern.FindStringSubmatch(strings.TrimSpace(content))
+	if match == nil {
+		return nil, false, nil
+	}
+	month, ok := parseMonth(match[1])
+	if !ok {
+		return nil, true, fmt.Errorf("unknown month in yearly reminder: %s", match[1])
+	}
+	y := reminder.Yearly{Month: month}
+	y.Day, _ = strconv.Atoi(match[2])
+	if y.Day < 1 || y.Day > time.Date(2024, month+1, 0, 0, 0, 0, 0, time.Local).Day() {
+		return nil, true, fmt.Errorf("no day %d in %s", y.Day, month)
+	}
+	if match[3] != "" {
+		y.Since, _ = strconv.Atoi(match[3])
+	}
+	if match[4] != "" {
+		y.Before, _ = strconv.Atoi(match[4])
+	}
+
+	r := newReminder(y.Due(y.Next(relativeTo)), match[5], relativeTo)
+	r.Yearly = y
+	return r, true, nil
+}
+
+// parseMonth reads a month name or its abbreviation of at least three
+// letters, e.g. "Mar" or "march"
+func parseMonth(s string) (time.Month, bool) {
+	s = strings.ToLower(s)
+	if len(s) < 3 {
+		return 0, false
+	}
+	for m := time.January; m <= time.December; m++ {
+		if strings.HasPrefix(strings.ToLower(m.String()), s) {
+			return m, true
+		}
+	}
+	return 0, false
+}
+
+// FormatYearly returns how y is written in a token, e.g.
+// "every year Mar 14 1958 7d before"
+func FormatYearly(y reminder.Yearly) string {
+	s := fmt.Sprintf("every year %s %d", y.Month.String()[:3], y.Day)
+	if y.Since != 0 {
+		s += fmt.Sprintf(" %d", y.Since)
+	}
+	if y.Before != 0 {
+		s += fmt.Sprintf(" %dd before", y.Before)
+	}
+	return s
+}
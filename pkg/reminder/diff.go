@@ -0,0 +1,37 @@
+package reminder
+
+// FileDiff is what merging a file's newly parsed reminders changes
+type FileDiff struct {
+	Added   []*Reminder // New in the file
+	Removed []*Reminder // Gone from the file, so dropped
+	Kept    []*Reminder // Still in the file, or done, so kept as they are
+}
+
+// DiffFile returns what MergeFromFile would change, without changing
+// anything. Reminders are matched by description, as in the merge.
+func DiffFile(existing []*Reminder, filePath string, parsed []*Reminder) FileDiff {
+	inFile := make(map[string]bool, len(parsed))
+	for _, r := range parsed {
+		inFile[r.Description] = true
+	}
+
+	var d FileDiff
+	known := make(map[string]bool)
+	for _, r := range existing {
+		if r.SourceFile != filePath {
+			continue
+		}
+		known[r.Description] = true
+		if inFile[r.Description] || r.Status == Acknowledged {
+			d.Kept = append(d.Kept, r)
+		} else {
+			d.Removed = append(d.Removed, r)
+		}
+	}
+	for _, r := range parsed {
+		if !known[r.Description] {
+			d.Added = append(d.Added, r)
+		}
+	}
+	return d
+}
@@ -0,0 +1,37 @@
+package reminder
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDiffFile(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	file := func(desc string, status Status) *Reminder {
+		return &Reminder{Description: desc, DateTime: now, SourceFile: "/notes/x.md", Status: status}
+	}
+	existing := []*Reminder{
+		file("A", Pending),
+		file("B", Triggered),
+		file("Done", Acknowledged),
+		{Description: "Other", DateTime: now, SourceFile: "/notes/y.md", Status: Pending},
+	}
+	parsed := []*Reminder{file("A", Pending), file("C", Pending)}
+
+	d := DiffFile(existing, "/notes/x.md", parsed)
+	if len(d.Added) != 1 || d.Added[0].Description != "C" {
+		t.Errorf("Added = %v, want C", d.Added)
+	}
+	if len(d.Removed) != 1 || d.Removed[0] != existing[1] {
+		t.Errorf("Removed = %v, want B", d.Removed)
+	}
+	if len(d.Kept) != 2 || d.Kept[0] != existing[0] || d.Kept[1] != existing[2] {
+		t.Errorf("Kept = %v, want A and the done reminder", d.Kept)
+	}
+
+	// The diff agrees with what the merge does
+	merged := MergeFromFile(existing, "/notes/x.md", parsed)
+	if len(merged) != len(existing)-len(d.Removed)+len(d.Added) {
+		t.Errorf("MergeFromFile() left %d reminders, the diff expects %d", len(merged), len(existing)-len(d.Removed)+len(d.Added))
+	}
+}
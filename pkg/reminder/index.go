@@ -0,0 +1,65 @@
+package reminder
+
+// FileIndex keeps a list's reminders by source file, so that merging one
+// file's update looks only at that file's reminders instead of every
+// reminder's path. The zero value is ready to use.
+//
+// The index follows the list MergeFromFile returns, and is rebuilt when
+// it's given a list that was replaced or changed length. Sorting the list
+// in place is fine; moving a reminder to another file in place needs a
+// Reset.
+type FileIndex struct {
+	list   []*Reminder
+	byFile map[string][]*Reminder
+}
+
+// MergeFromFile does what the MergeFromFile function does, using the
+// index to find the file's reminders
+func (x *FileIndex) MergeFromFile(existing []*Reminder, filePath string, newReminders []*Reminder) []*Reminder {
+	result, kept := mergeFile(existing, x.inFile(existing, filePath), newReminders)
+	x.list = result
+	if len(kept) == 0 {
+		delete(x.byFile, filePath)
+	} else {
+		x.byFile[filePath] = kept
+	}
+	return result
+}
+
+// Reset makes the next merge rebuild the index
+func (x *FileIndex) Reset() {
+	x.list, x.byFile = nil, nil
+}
+
+// inFile returns the reminders of list from filePath, rebuilding the
+// index first if list isn't the one it was built for. A reminder that
+// has since moved to another file also rebuilds it.
+func (x *FileIndex) inFile(list []*Reminder, filePath string) []*Reminder {
+	if !x.indexes(list) {
+		x.rebuild(list)
+	}
+	for _, r := range x.byFile[filePath] {
+		if r.SourceFile != filePath {
+			x.rebuild(list)
+			break
+		}
+	}
+	return x.byFile[filePath]
+}
+
+// indexes reports whether the index was built for list
+func (x *FileIndex) indexes(list []*Reminder) bool {
+	if x.byFile == nil || len(list) != len(x.list) {
+		return false
+	}
+	return len(list) == 0 || &list[0] == &x.list[0]
+}
+
+// rebuild indexes list
+func (x *FileIndex) rebuild(list []*Reminder) {
+	x.list = list
+	x.byFile = make(map[string][]*Reminder)
+	for _, r := range list {
+		x.byFile[r.SourceFile] = append(x.byFile[r.SourceFile], r)
+	}
+}
@@ -0,0 +1,94 @@
+package reminder
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Relative places a reminder a fixed offset from another reminder, found by
+// tag, so that it moves when that one does. The zero value is a fixed time.
+type Relative struct {
+	Tag    string        // Tag of the reminder it's relative to, without '#'
+	Offset time.Duration // Negative for before it
+}
+
+// IsZero reports whether rel is a fixed time
+func (rel Relative) IsZero() bool {
+	return rel.Tag == ""
+}
+
+// errCycle marks a reminder whose chain of relative times loops back on
+// itself
+var errCycle = errors.New("its relative times go round in a loop")
+
+// ResolveRelative moves each reminder with a relative time to its offset
+// from the reminder tagged with its tag, following chains of them. The
+// first open reminder with the tag is used, or the first done one if none
+// are open. Only pending and triggered reminders move, so snoozes stick;
+// triggered ones moved into the future go back to pending. Reminders whose
+// tag isn't found, or whose chain loops back on itself, keep their time
+// and are named in the error.
+func ResolveRelative(reminders []*Reminder, now time.Time) error {
+	anchors := make(map[string]*Reminder)
+	for _, open := range []bool{true, false} {
+		for _, r := range reminders {
+			if (r.Status != Acknowledged) != open {
+				continue
+			}
+			for _, tag := range r.Tags {
+				if _, ok := anchors[strings.ToLower(tag)]; !ok {
+					anchors[strings.ToLower(tag)] = r
+				}
+			}
+		}
+	}
+
+	settled := make(map[*Reminder]error)
+	visiting := make(map[*Reminder]bool)
+	var place func(r *Reminder) error
+	place = func(r *Reminder) error {
+		if r.Relative.IsZero() {
+			return nil
+		}
+		if err, ok := settled[r]; ok {
+			return err
+		}
+		if visiting[r] {
+			return errCycle
+		}
+		visiting[r] = true
+		defer delete(visiting, r)
+
+		anchor := anchors[strings.ToLower(r.Relative.Tag)]
+		err := fmt.Errorf("no reminder is tagged #%s", r.Relative.Tag)
+		if anchor != nil {
+			err = place(anchor)
+		}
+		if err == nil {
+			r.moveTo(anchor.DateTime.Add(r.Relative.Offset), now)
+		}
+		settled[r] = err
+		return err
+	}
+
+	var errs []error
+	for _, r := range reminders {
+		if err := place(r); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", r.Description, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// moveTo moves a pending or triggered reminder to at
+func (r *Reminder) moveTo(at, now time.Time) {
+	if r.Status != Pending && r.Status != Triggered || r.DateTime.Equal(at) {
+		return
+	}
+	r.DateTime = at
+	if r.Status == Triggered && at.After(now) {
+		r.Status = Pending
+	}
+}
@@ -0,0 +1,42 @@
+package reminder
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestResolveRelative(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	release := &Reminder{Description: "Release", DateTime: now.Add(3 * day), Tags: []string{"Release"}, Status: Pending}
+	retro := &Reminder{Description: "Retro", DateTime: now, Tags: []string{"retro"}, Status: Triggered, Relative: Relative{Tag: "release", Offset: 2 * day}}
+	notes := &Reminder{Description: "Notes", DateTime: now, Status: Pending, Relative: Relative{Tag: "retro", Offset: time.Hour}}
+	snoozed := &Reminder{Description: "Snoozed", DateTime: now, Status: Snoozed, Relative: Relative{Tag: "release", Offset: -day}}
+
+	// Chains are followed in any order, and triggered reminders moved ahead are pending again
+	if err := ResolveRelative([]*Reminder{notes, retro, release, snoozed}, now); err != nil {
+		t.Fatalf("ResolveRelative() error: %v", err)
+	}
+	if !retro.DateTime.Equal(now.Add(5*day)) || retro.Status != Pending {
+		t.Errorf("retro at %v %v, want pending two days after the release", retro.DateTime, retro.Status)
+	}
+	if !notes.DateTime.Equal(now.Add(5*day + time.Hour)) {
+		t.Errorf("notes at %v, want an hour after the retro", notes.DateTime)
+	}
+	if !snoozed.DateTime.Equal(now) {
+		t.Errorf("snoozed reminder moved to %v", snoozed.DateTime)
+	}
+
+	// Missing tags and loops are reported, and those reminders stay put
+	a := &Reminder{Description: "A", DateTime: now, Tags: []string{"a"}, Status: Pending, Relative: Relative{Tag: "b", Offset: day}}
+	b := &Reminder{Description: "B", DateTime: now, Tags: []string{"b"}, Status: Pending, Relative: Relative{Tag: "a", Offset: day}}
+	lost := &Reminder{Description: "Lost", DateTime: now, Status: Pending, Relative: Relative{Tag: "nowhere", Offset: day}}
+	err := ResolveRelative([]*Reminder{a, b, lost}, now)
+	if err == nil || !strings.Contains(err.Error(), "A: its relative times go round in a loop") || !strings.Contains(err.Error(), "Lost: no reminder is tagged #nowhere") {
+		t.Errorf("ResolveRelative() error = %v, want the loop and the missing tag", err)
+	}
+	if !a.DateTime.Equal(now) || !b.DateTime.Equal(now) || !lost.DateTime.Equal(now) {
+		t.Errorf("unplaced reminders moved: %v %v %v", a.DateTime, b.DateTime, lost.DateTime)
+	}
+}
@@ -0,0 +1,679 @@
+// Package reminder defines the Reminder type shared by every part of
+// go_remind: its statuses, how it is acknowledged and snoozed, and how
+// reminders parsed from a file are merged with ones already known.
+package reminder
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Status represents the current state of a reminder
+type Status int
+
+const (
+	Pending      Status = iota // Waiting for trigger time
+	Triggered                  // Time reached, needs acknowledgment
+	Acknowledged               // User dismissed, show crossed out
+	Snoozed                    // Postponed, triggers again at its new time
+	Waiting                    // Blocked on someone else, never triggers
+	Someday                    // No hard date, never triggers
+)
+
+func (s Status) String() string {
+	switch s {
+	case Pending:
+		return "pending"
+	case Triggered:
+		return "TRIGGERED"
+	case Acknowledged:
+		return "done"
+	case Snoozed:
+		return "snoozed"
+	case Waiting:
+		return "waiting"
+	case Someday:
+		return "someday"
+	default:
+		return "unknown"
+	}
+}
+
+// statusNames are the stable names statuses are saved under
+var statusNames = map[Status]string{
+	Pending:      "pending",
+	Triggered:    "triggered",
+	Acknowledged: "acknowledged",
+	Snoozed:      "snoozed",
+	Waiting:      "waiting",
+	Someday:      "someday",
+}
+
+// Name returns the stable name the status is saved under
+func (s Status) Name() string {
+	if name, ok := statusNames[s]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// ParseStatus returns the status with the given saved name
+func ParseStatus(name string) (Status, bool) {
+	for s, n := range statusNames {
+		if n == name {
+			return s, true
+		}
+	}
+	return Pending, false
+}
+
+// Scheduled returns true if the status triggers when its time comes
+func (s Status) Scheduled() bool {
+	return s == Pending || s == Snoozed
+}
+
+// Parked returns true for statuses set aside without a firm due time
+func (s Status) Parked() bool {
+	return s == Waiting || s == Someday
+}
+
+// Priority is how urgent a reminder is, as set by a rules script
+type Priority int
+
+const (
+	PriorityNone Priority = iota
+	PriorityLow
+	PriorityMedium
+	PriorityHigh
+)
+
+// priorityNames are the names priorities are saved and scripted under
+var priorityNames = []string{"", "low", "medium", "high"}
+
+func (p Priority) String() string {
+	if p < 0 || int(p) >= len(priorityNames) {
+		return ""
+	}
+	return priorityNames[p]
+}
+
+// ParsePriority returns the priority with the given name; "" is none
+func ParsePriority(name string) (Priority, bool) {
+	for i, n := range priorityNames {
+		if n == name {
+			return Priority(i), true
+		}
+	}
+	return PriorityNone, false
+}
+
+// Effort is how much energy a reminder takes, from a ^easy token or set in
+// the TUI
+type Effort int
+
+const (
+	EffortNone Effort = iota
+	EffortEasy
+	EffortMedium
+	EffortHard
+)
+
+// effortNames are the names efforts are written and saved under
+var effortNames = []string{"", "easy", "medium", "hard"}
+
+func (e Effort) String() string {
+	if e < 0 || int(e) >= len(effortNames) {
+		return ""
+	}
+	return effortNames[e]
+}
+
+// ParseEffort returns the effort with the given name, ignoring case; ""
+// is none
+func ParseEffort(name string) (Effort, bool) {
+	for i, n := range effortNames {
+		if strings.EqualFold(n, name) {
+			return Effort(i), true
+		}
+	}
+	return EffortNone, false
+}
+
+// Label is a colored label set on a reminder in the TUI, like a star on
+// an email
+type Label int
+
+const (
+	LabelNone Label = iota
+	LabelRed
+	LabelYellow
+	LabelGreen
+	LabelBlue
+	LabelPurple
+)
+
+// labelNames are the names labels are filtered by and saved under
+var labelNames = []string{"", "red", "yellow", "green", "blue", "purple"}
+
+func (l Label) String() string {
+	if l < 0 || int(l) >= len(labelNames) {
+		return ""
+	}
+	return labelNames[l]
+}
+
+// ParseLabel returns the label with the given name, ignoring case; "" is
+// none
+func ParseLabel(name string) (Label, bool) {
+	for i, n := range labelNames {
+		if strings.EqualFold(n, name) {
+			return Label(i), true
+		}
+	}
+	return LabelNone, false
+}
+
+// StandaloneSource is the SourceFile of reminders added in the TUI rather
+// than parsed from a file
+const StandaloneSource = "(added in TUI)"
+
+// FromFile reports whether r was parsed from a markdown file, rather than
+// added in the TUI or pulled from a remote source
+func (r *Reminder) FromFile() bool {
+	return r.Source == "" && r.SourceFile != "" && r.SourceFile != StandaloneSource
+}
+
+// Reminder represents a single reminder parsed from markdown
+type Reminder struct {
+	ID          string // Stable identifier, used to match reminders across machines
+	DateTime    time.Time
+	Description string
+	Notes       string   // Lines written below the description in the TUI's edit box
+	Tags        []string // Tags extracted from content (e.g., #work, #urgent)
+	SourceFile  string   // For future multi-file support
+	Source      string   // Remote source it was pulled from, e.g. "github:owner/repo"; empty for notes and the TUI
+	From        string   // Sender of the email it was pulled from
+	LineNumber  int      // Helps user find it in their markdown
+	Context     string   // Headings above it in its file, e.g. "Project X > Sprint 12"
+	Status      Status
+	Priority    Priority      // Set by rules scripts
+	Estimate    time.Duration // How long it's expected to take, from a ~30m token; 0 if none
+	Effort      Effort        // How much energy it takes
+	Label       Label         // Colored label set in the TUI
+	Yearly      Yearly        // Repeats every year on a date; zero if it doesn't
+	Relative    Relative      // Due relative to another reminder; zero if fixed
+
+	AcknowledgedAt time.Time // When the reminder was last acknowledged
+	UpdatedAt      time.Time // When the user last changed the reminder
+	CreatedAt      time.Time // When the reminder was first parsed or added
+
+	Sessions []Session // Time worked on it, tracked with its timer
+}
+
+// Session is a span of time spent working on a reminder. End is zero while
+// the timer runs.
+type Session struct {
+	Start time.Time
+	End   time.Time
+}
+
+// NewID returns a random reminder ID
+func NewID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// homeDir is the user's home directory, which FileID keys notes under
+var homeDir, _ = os.UserHomeDir()
+
+// FileID returns a deterministic ID for a reminder parsed from a file. A
+// file under the home directory is keyed by its path from there, so the
+// same note yields the same ID on machines whose home directories differ,
+// e.g. /home/sam and /Users/sam.
+func FileID(sourceFile, description string) string {
+	sum := sha256.Sum256([]byte(homePath(sourceFile) + "\x00" + description))
+	return hex.EncodeToString(sum[:8])
+}
+
+// homePath returns path as ~/ and its path from the home directory, with
+// forward slashes, when it's under the home directory, or else unchanged
+func homePath(path string) string {
+	if homeDir == "" || !filepath.IsAbs(path) {
+		return path
+	}
+	rel, err := filepath.Rel(homeDir, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return path
+	}
+	return "~/" + filepath.ToSlash(rel)
+}
+
+// mentionPattern matches an @name mention of a person. Like a tag, it
+// must start a word, so email addresses aren't mentions.
+var mentionPattern = regexp.MustCompile(`(?:^|\s)@(\w+(?:[.-]\w+)*)`)
+
+// Mentions returns the people r's description mentions with @name, as
+// first written, without repeats. Unlike tags, mentions stay in the
+// description, since they're usually part of the sentence.
+func (r *Reminder) Mentions() []string {
+	var names []string
+	for _, match := range mentionPattern.FindAllStringSubmatch(r.Description, -1) {
+		if !slices.ContainsFunc(names, func(n string) bool { return strings.EqualFold(n, match[1]) }) {
+			names = append(names, match[1])
+		}
+	}
+	return names
+}
+
+// TidyDescription collapses runs of spaces in desc, drops the periods,
+// commas, colons, and semicolons it ends with, and capitalizes its first
+// word when that's all lowercase, so words like iPhone and links are left
+// alone. Question and exclamation marks are kept.
+func TidyDescription(desc string) string {
+	desc = strings.TrimRight(strings.Join(strings.Fields(desc), " "), ".,;: ")
+	first, _, _ := strings.Cut(desc, " ")
+	if first == "" || strings.ContainsFunc(first, func(r rune) bool { return !unicode.IsLower(r) && r != '\'' && r != '-' }) {
+		return desc
+	}
+	r, size := utf8.DecodeRuneInString(desc)
+	return string(unicode.ToUpper(r)) + desc[size:]
+}
+
+// Matches reports whether r matches a filter as typed into the TUI's
+// filter box: "#tag" matches a tag, "@name" a mention, "label:red" a
+// label, "is:triggered" a status by name, anything else part of the
+// description, the notes, or the headings it's under, ignoring case
+// either way
+func (r *Reminder) Matches(filter string) bool {
+	filter = strings.ToLower(filter)
+	if name, ok := strings.CutPrefix(filter, "is:"); ok {
+		return r.Status.Name() == name
+	}
+	if name, ok := strings.CutPrefix(filter, "label:"); ok {
+		label, ok := ParseLabel(name)
+		return ok && label != LabelNone && r.Label == label
+	}
+	if name, ok := strings.CutPrefix(filter, "@"); ok {
+		return slices.ContainsFunc(r.Mentions(), func(n string) bool { return strings.ToLower(n) == name })
+	}
+	if tag, ok := strings.CutPrefix(filter, "#"); ok {
+		for _, t := range r.Tags {
+			if strings.ToLower(t) == tag {
+				return true
+			}
+		}
+		return false
+	}
+	return strings.Contains(strings.ToLower(r.Description), filter) ||
+		strings.Contains(strings.ToLower(r.Notes), filter) ||
+		strings.Contains(strings.ToLower(r.Context), filter)
+}
+
+// Touch records that the reminder was changed by the user at now
+func (r *Reminder) Touch(now time.Time) {
+	r.UpdatedAt = now
+}
+
+// IsDueAt returns true if the reminder's time has passed at now
+func (r *Reminder) IsDueAt(now time.Time) bool {
+	return now.After(r.DateTime)
+}
+
+// Acknowledge marks the reminder as done and records when
+func (r *Reminder) Acknowledge(now time.Time) {
+	r.StopTimer(now)
+	r.Status = Acknowledged
+	r.AcknowledgedAt = now
+	r.UpdatedAt = now
+}
+
+// TimerRunning reports whether the reminder's timer is running
+func (r *Reminder) TimerRunning() bool {
+	return len(r.Sessions) > 0 && r.Sessions[len(r.Sessions)-1].End.IsZero()
+}
+
+// StartTimer starts timing work on the reminder, unless it's running
+func (r *Reminder) StartTimer(now time.Time) {
+	if r.TimerRunning() {
+		return
+	}
+	// Copies share the sessions, so they're never changed in place
+	r.Sessions = append(slices.Clip(r.Sessions), Session{Start: now})
+	r.UpdatedAt = now
+}
+
+// StopTimer ends the running session at now, if any
+func (r *Reminder) StopTimer(now time.Time) {
+	if !r.TimerRunning() {
+		return
+	}
+	sessions := slices.Clone(r.Sessions)
+	last := &sessions[len(sessions)-1]
+	last.End = now
+	if last.End.Before(last.Start) {
+		last.End = last.Start
+	}
+	r.Sessions = sessions
+	r.UpdatedAt = now
+}
+
+// TimeSpent returns the time worked on the reminder up to now, counting a
+// running timer
+func (r *Reminder) TimeSpent(now time.Time) time.Duration {
+	return r.TimeSpentBetween(time.Time{}, now)
+}
+
+// TimeSpentBetween returns the time worked on the reminder between start
+// and end, counting a running timer up to end
+func (r *Reminder) TimeSpentBetween(start, end time.Time) time.Duration {
+	var total time.Duration
+	for _, s := range r.Sessions {
+		from, to := s.Start, s.End
+		if to.IsZero() || to.After(end) {
+			to = end
+		}
+		if from.Before(start) {
+			from = start
+		}
+		if to.After(from) {
+			total += to.Sub(from)
+		}
+	}
+	return total
+}
+
+// Unacknowledge reopens the reminder as triggered or pending depending on
+// whether it's due at now
+func (r *Reminder) Unacknowledge(now time.Time) {
+	r.Reopen(now)
+}
+
+// Park sets the reminder aside as Waiting or Someday
+func (r *Reminder) Park(s Status, now time.Time) {
+	r.Status = s
+	r.Touch(now)
+}
+
+// Reopen returns a done or parked reminder to triggered or pending
+// depending on whether it's due at now
+func (r *Reminder) Reopen(now time.Time) {
+	if r.IsDueAt(now) {
+		r.Status = Triggered
+	} else {
+		r.Status = Pending
+	}
+	r.AcknowledgedAt = time.Time{}
+	r.Touch(now)
+}
+
+// Snooze postpones the reminder by d, adding to its existing due date
+func (r *Reminder) Snooze(d time.Duration, now time.Time) {
+	r.DateTime = r.DateTime.Add(d)
+	r.Status = Snoozed
+	r.Touch(now)
+}
+
+// Snoozeable returns true if the reminder can be snoozed
+// Acknowledged reminders cannot be snoozed
+func (r *Reminder) Snoozeable() bool {
+	return r.Status != Acknowledged
+}
+
+// SortByDateTime sorts a slice of reminders by their DateTime. Reminders
+// due at the same time are ordered by description and then ID, so the order
+// doesn't depend on how they were loaded.
+func SortByDateTime(reminders []*Reminder) {
+	sort.Slice(reminders, func(i, j int) bool {
+		a, b := reminders[i], reminders[j]
+		if !a.DateTime.Equal(b.DateTime) {
+			return a.DateTime.Before(b.DateTime)
+		}
+		if a.Description != b.Description {
+			return a.Description < b.Description
+		}
+		return a.ID < b.ID
+	})
+}
+
+// MergeFromFile merges new reminders from a file with existing reminders.
+// Deduplication is based on (SourceFile, Description):
+// - Existing reminders from the same file with matching descriptions are preserved (keeps original DateTime/Status)
+// - New reminders with no match are added
+// - Pending/triggered reminders from the file that no longer exist are removed
+// - Acknowledged reminders are always kept (even if removed from file)
+//
+// A FileIndex does the same without comparing every reminder's path.
+func MergeFromFile(existing []*Reminder, filePath string, newReminders []*Reminder) []*Reminder {
+	var own []*Reminder
+	for _, r := range existing {
+		if r.SourceFile == filePath {
+			own = append(own, r)
+		}
+	}
+	result, _ := mergeFile(existing, own, newReminders)
+	return result
+}
+
+// mergeFile merges a file's newly parsed reminders into own, the file's
+// reminders in existing. It returns the merged list and the file's
+// reminders in it. existing is only copied when something is removed or
+// added.
+func mergeFile(existing, own, newReminders []*Reminder) (result, kept []*Reminder) {
+	newByDesc := make(map[string]*Reminder, len(newReminders))
+	for _, r := range newReminders {
+		newByDesc[r.Description] = r
+	}
+
+	matched := make(map[*Reminder]bool, len(newReminders))
+	var dropped []*Reminder
+	kept = make([]*Reminder, 0, len(own)+len(newReminders))
+	for _, r := range own {
+		n := newByDesc[r.Description]
+		if n != nil {
+			matched[n] = true
+		}
+		if mergeParsed(r, n) {
+			kept = append(kept, r)
+		} else {
+			dropped = append(dropped, r)
+		}
+	}
+
+	// The full slice expression makes adding copy existing rather than
+	// write into the caller's spare capacity
+	result = existing[:len(existing):len(existing)]
+	if len(dropped) > 0 {
+		result = without(existing, dropped, len(newReminders))
+	}
+	for _, r := range newReminders {
+		if !matched[newByDesc[r.Description]] {
+			result = append(result, r)
+			kept = append(kept, r)
+		}
+	}
+	return result, kept
+}
+
+// without returns a copy of list without the drop reminders, with room
+// for extra more
+func without(list, drop []*Reminder, extra int) []*Reminder {
+	at := make([]int, 0, len(drop))
+	for _, r := range drop {
+		if i := slices.Index(list, r); i >= 0 {
+			at = append(at, i)
+		}
+	}
+	slices.Sort(at)
+
+	result := make([]*Reminder, 0, len(list)-len(at)+extra)
+	start := 0
+	for _, i := range at {
+		result = append(result, list[start:i]...)
+		start = i + 1
+	}
+	return append(result, list[start:]...)
+}
+
+// MergeFromSource merges reminders fetched from a remote source with the
+// ones already pulled from it, matching them by ID:
+//   - Matched reminders take the fetched description, tags, link, and
+//     sender, and the fetched due time unless snoozed; their status is kept
+//     unless the item was closed at the source
+//   - New reminders are added, unless already closed at the source
+//   - Open reminders from the source that weren't fetched are removed;
+//     acknowledged ones are kept
+//
+// now is when they're merged, which a moved due time is checked against.
+func MergeFromSource(existing []*Reminder, source string, fetched []*Reminder, now time.Time) []*Reminder {
+	byID := make(map[string]*Reminder, len(fetched))
+	for _, f := range fetched {
+		byID[f.ID] = f
+	}
+
+	result := make([]*Reminder, 0, len(existing)+len(fetched))
+	matched := make(map[string]bool, len(fetched))
+	for _, r := range existing {
+		if r.Source != source {
+			result = append(result, r)
+			continue
+		}
+		f, ok := byID[r.ID]
+		if !ok {
+			if r.Status == Acknowledged {
+				result = append(result, r)
+			}
+			continue
+		}
+		matched[r.ID] = true
+		r.Description = f.Description
+		r.Tags = f.Tags
+		r.SourceFile = f.SourceFile
+		r.From = f.From
+		if r.Status != Snoozed && !r.DateTime.Equal(f.DateTime) {
+			r.DateTime = f.DateTime
+			if r.Status == Triggered && !r.IsDueAt(now) {
+				r.Status = Pending
+			}
+		}
+		if f.Status == Acknowledged && r.Status != Acknowledged {
+			r.Acknowledge(f.AcknowledgedAt)
+		}
+		result = append(result, r)
+	}
+
+	for _, f := range fetched {
+		if !matched[f.ID] && f.Status != Acknowledged {
+			f.Source = source
+			if f.CreatedAt.IsZero() {
+				f.CreatedAt = now
+			}
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// MergeParsed merges reminders parsed from any number of files, as
+// MergeFromFile does for each file, in a single pass over existing. Every
+// reminder parsed from a file must be included, since the file's other
+// reminders are treated as removed.
+func MergeParsed(existing []*Reminder, parsed []*Reminder) []*Reminder {
+	var files []string
+	byFile := make(map[string][]*Reminder)
+	for _, r := range parsed {
+		if _, seen := byFile[r.SourceFile]; !seen {
+			files = append(files, r.SourceFile)
+		}
+		byFile[r.SourceFile] = append(byFile[r.SourceFile], r)
+	}
+	return mergeFiles(existing, files, byFile)
+}
+
+// mergeParsed merges n, r's line as just parsed from its file, into r, and
+// reports whether r is kept. n is nil when the line is gone from the file.
+func mergeParsed(r, n *Reminder) bool {
+	if r.Status == Acknowledged {
+		// Always keep acknowledged reminders
+		return true
+	}
+	if n == nil {
+		// Removed from the file
+		return false
+	}
+	// Keep the existing reminder (preserves DateTime and Status), unless
+	// its checkbox was ticked in the file. The estimate and line are only
+	// in the file, so they follow the file, as does an effort token;
+	// without one, effort set in the TUI is kept.
+	if n.Status == Acknowledged {
+		r.Acknowledge(n.AcknowledgedAt)
+	}
+	r.Estimate = n.Estimate
+	r.Context = n.Context
+	r.LineNumber = n.LineNumber
+	if n.Effort != EffortNone {
+		r.Effort = n.Effort
+	}
+	// A yearly date or relative time is also only written in the file;
+	// when it changes, so does the time
+	if n.Yearly != r.Yearly || n.Relative != r.Relative {
+		r.Yearly, r.Relative = n.Yearly, n.Relative
+		r.DateTime = n.DateTime
+	}
+	return true
+}
+
+// mergeFiles merges the new reminders of each file in files with existing
+func mergeFiles(existing []*Reminder, files []string, byFile map[string][]*Reminder) []*Reminder {
+	// Index the new reminders by file and description for quick lookup
+	newByDesc := make(map[string]map[string]*Reminder, len(byFile))
+	for file, reminders := range byFile {
+		descs := make(map[string]*Reminder, len(reminders))
+		for _, r := range reminders {
+			descs[r.Description] = r
+		}
+		newByDesc[file] = descs
+	}
+
+	// Build result: start with reminders from OTHER files + acknowledged from these files
+	result := make([]*Reminder, 0, len(existing))
+	matched := make(map[*Reminder]bool)
+
+	for _, r := range existing {
+		descs, updated := newByDesc[r.SourceFile]
+		if !updated {
+			// Keep reminders from other files unchanged
+			result = append(result, r)
+			continue
+		}
+
+		n := descs[r.Description]
+		if n != nil {
+			matched[n] = true
+		}
+		if mergeParsed(r, n) {
+			result = append(result, r)
+		}
+	}
+
+	// Add new reminders that weren't matched
+	for _, file := range files {
+		for _, r := range byFile[file] {
+			if !matched[newByDesc[file][r.Description]] {
+				result = append(result, r)
+			}
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,299 @@
+package reminder
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestMergeParsedKeepsState(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	a := &Reminder{Description: "A", DateTime: now, SourceFile: "/notes/x.md", LineNumber: 1, Status: Triggered}
+	b := &Reminder{Description: "B", DateTime: now.Add(time.Hour), SourceFile: "/notes/x.md", Status: Snoozed}
+	other := &Reminder{Description: "C", DateTime: now, SourceFile: "/notes/y.md", Status: Pending}
+
+	parsed := []*Reminder{
+		{Description: "A", DateTime: now, SourceFile: "/notes/x.md", LineNumber: 4, Status: Pending},
+		{Description: "B", DateTime: now, SourceFile: "/notes/x.md", Status: Pending},
+		{Description: "D", DateTime: now, SourceFile: "/notes/x.md", Status: Pending},
+	}
+	got := MergeParsed([]*Reminder{a, b, other}, parsed)
+
+	if len(got) != 4 || got[0] != a || got[1] != b || got[2] != other || got[3] != parsed[2] {
+		t.Fatalf("MergeParsed() = %v, want a, b, other, and the new D", got)
+	}
+	if a.Status != Triggered || b.Status != Snoozed || !b.DateTime.Equal(now.Add(time.Hour)) {
+		t.Errorf("existing reminders lost their state: a %v, b %v at %v", a.Status, b.Status, b.DateTime)
+	}
+	// The line follows the file, so a moved line is still found
+	if a.LineNumber != 4 {
+		t.Errorf("a is on line %d, want the parsed line 4", a.LineNumber)
+	}
+}
+
+func TestMergeFromSource(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	const source = "github:me/repo"
+
+	triggered := &Reminder{ID: "1", Description: "Old title", DateTime: now.Add(-time.Hour), Source: source, Status: Triggered}
+	snoozed := &Reminder{ID: "2", Description: "Snoozed", DateTime: now.Add(time.Hour), Source: source, Status: Snoozed}
+	closed := &Reminder{ID: "3", Description: "Closed", DateTime: now, Source: source, Status: Pending}
+	done := &Reminder{ID: "4", Description: "Done", DateTime: now, Source: source, Status: Acknowledged}
+	note := &Reminder{ID: "5", Description: "From a note", DateTime: now, SourceFile: "/notes/x.md", Status: Pending}
+
+	fetched := []*Reminder{
+		{ID: "1", Description: "New title", DateTime: now.Add(24 * time.Hour), SourceFile: "https://example.com/1"},
+		{ID: "2", Description: "Snoozed", DateTime: now},
+		{ID: "6", Description: "New issue", DateTime: now},
+		{ID: "7", Description: "Closed upstream", DateTime: now, Status: Acknowledged, AcknowledgedAt: now},
+	}
+	got := MergeFromSource([]*Reminder{triggered, snoozed, closed, done, note}, source, fetched, now)
+
+	if len(got) != 5 || got[0] != triggered || got[1] != snoozed || got[2] != done || got[3] != note {
+		t.Fatalf("MergeFromSource() = %v, want the closed one dropped and only the open new one added", got)
+	}
+	if triggered.Description != "New title" || triggered.SourceFile != "https://example.com/1" || triggered.Status != Pending {
+		t.Errorf("moved issue = %q from %s, %v; want the new title, link, and pending again", triggered.Description, triggered.SourceFile, triggered.Status)
+	}
+	if !snoozed.DateTime.Equal(now.Add(time.Hour)) {
+		t.Errorf("snoozed reminder moved to %v, want it kept at its snoozed time", snoozed.DateTime)
+	}
+	if got[4].Description != "New issue" || got[4].Source != source {
+		t.Errorf("new reminder %q from %q, want New issue from %s", got[4].Description, got[4].Source, source)
+	}
+
+	// Closed at the source after it was pulled
+	fetched[1].Status, fetched[1].AcknowledgedAt = Acknowledged, now
+	MergeFromSource(got, source, fetched, now)
+	if snoozed.Status != Acknowledged {
+		t.Errorf("reminder closed at the source is %v, want acknowledged", snoozed.Status)
+	}
+}
+
+// benchReminders returns n reminders spread over files files
+func benchReminders(n, files int) []*Reminder {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	rs := make([]*Reminder, n)
+	for i := range rs {
+		rs[i] = &Reminder{
+			Description: fmt.Sprintf("Reminder %d", i),
+			DateTime:    now.Add(time.Duration(i) * time.Minute),
+			SourceFile:  fmt.Sprintf("/notes/%d.md", i%files),
+			Status:      Pending,
+		}
+	}
+	return rs
+}
+
+// fileUpdate returns a fresh parse of file's reminders in list
+func fileUpdate(list []*Reminder, file string) []*Reminder {
+	var parsed []*Reminder
+	for _, r := range list {
+		if r.SourceFile == file {
+			p := *r
+			parsed = append(parsed, &p)
+		}
+	}
+	return parsed
+}
+
+// Budget: 1ms for one file's update among 5,000 reminders, with one line
+// edited back and forth
+func BenchmarkMergeFromFile(b *testing.B) {
+	reminders := benchReminders(5000, 100)
+	edited := fileUpdate(reminders, "/notes/7.md")
+	edited[0].Description += " (moved)"
+	updates := [][]*Reminder{edited, fileUpdate(reminders, "/notes/7.md")}
+
+	var files FileIndex
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		reminders = files.MergeFromFile(reminders, "/notes/7.md", updates[i%2])
+	}
+}
+
+// Budget: under 5ms to merge a startup parse of 5,000 reminders
+func BenchmarkMergeParsed(b *testing.B) {
+	existing := benchReminders(5000, 100)
+	parsed := benchReminders(5000, 100)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		MergeParsed(existing, parsed)
+	}
+}
+
+func TestFileIndex(t *testing.T) {
+	// descs returns the descriptions in list, sorted
+	descs := func(list []*Reminder) []string {
+		var ds []string
+		for _, r := range list {
+			ds = append(ds, r.SourceFile+": "+r.Description)
+		}
+		slices.Sort(ds)
+		return ds
+	}
+
+	var files FileIndex
+	list := benchReminders(50, 5)
+	plain := slices.Clone(list)
+
+	// Each merge matches MergeFromFile, whether the index is built,
+	// followed, or rebuilt for a list that grew
+	edited := fileUpdate(list, "/notes/2.md")
+	edited[0].Description = "Edited"
+	steps := []struct {
+		file   string
+		parsed []*Reminder
+	}{
+		{"/notes/1.md", fileUpdate(list, "/notes/1.md")},
+		{"/notes/2.md", edited},
+		{"/notes/2.md", edited[1:]},
+		{"/notes/9.md", []*Reminder{{Description: "New file", SourceFile: "/notes/9.md"}}},
+	}
+	for i, step := range steps {
+		list = files.MergeFromFile(list, step.file, step.parsed)
+		plain = MergeFromFile(plain, step.file, step.parsed)
+		if !slices.Equal(descs(list), descs(plain)) {
+			t.Fatalf("step %d: indexed merge = %v, want %v", i, descs(list), descs(plain))
+		}
+		SortByDateTime(list)
+		list = append(list, &Reminder{Description: fmt.Sprintf("Added %d", i), SourceFile: StandaloneSource})
+		plain = append(plain, list[len(list)-1])
+	}
+
+	// A reminder moved into a file in place is found after a Reset
+	list = files.MergeFromFile(list, "/notes/4.md", fileUpdate(list, "/notes/4.md"))
+	moved := list[len(list)-1]
+	moved.SourceFile = "/notes/3.md"
+	files.Reset()
+	list = files.MergeFromFile(list, "/notes/3.md", nil)
+	if slices.Contains(list, moved) {
+		t.Errorf("a reminder moved to /notes/3.md and gone from it was kept")
+	}
+}
+
+func TestFileID(t *testing.T) {
+	saved := homeDir
+	t.Cleanup(func() { homeDir = saved })
+
+	// The same note under two home directories has the same ID
+	homeDir = "/home/sam"
+	linux := FileID("/home/sam/notes/work.md", "Standup")
+	homeDir = "/Users/sam"
+	mac := FileID("/Users/sam/notes/work.md", "Standup")
+	if linux != mac {
+		t.Errorf("FileID differs across home directories: %s and %s", linux, mac)
+	}
+	if FileID("/Users/sam/notes/home.md", "Standup") == mac || FileID("/Users/sam/notes/work.md", "Lunch") == mac {
+		t.Error("FileID should differ for another file or description")
+	}
+
+	// Paths outside the home directory are kept as they are
+	for _, path := range []string{"/Users/samantha/notes/work.md", "/srv/notes/work.md", "notes/work.md"} {
+		if got := homePath(path); got != path {
+			t.Errorf("homePath(%q) = %q, want it unchanged", path, got)
+		}
+	}
+}
+
+func TestMatches(t *testing.T) {
+	r := &Reminder{Description: "Send Weekly report to @Dana", Notes: "Attach the receipts", Tags: []string{"Work"}, Context: "Acme > Q3 review", Label: LabelGreen, Status: Triggered}
+	cases := map[string]bool{
+		"weekly":       true,
+		"acme":         true,
+		"#acme":        false,
+		"REPORT":       true,
+		"#work":        true,
+		"#WORK":        true,
+		"#wor":         false,
+		"work":         false,
+		"invoice":      false,
+		"receipts":     true,
+		"label:green":  true,
+		"Label:Green":  true,
+		"label:red":    false,
+		"label:":       false,
+		"@dana":        true,
+		"@DANA":        true,
+		"@dan":         false,
+		"is:triggered": true,
+		"IS:Triggered": true,
+		"is:pending":   false,
+		"":             true,
+	}
+	for filter, want := range cases {
+		if got := r.Matches(filter); got != want {
+			t.Errorf("Matches(%q) = %v, want %v", filter, got, want)
+		}
+	}
+}
+
+func TestMentions(t *testing.T) {
+	cases := map[string][]string{
+		"Chase @alice about the budget":         {"alice"},
+		"@Bob and @mary-jane, then @bob again.": {"Bob", "mary-jane"},
+		"Reply to ada@example.com":              {},
+		"Sync with @dave.":                      {"dave"},
+		"Ping @ops.team when it's done":         {"ops.team"},
+		"Nobody here":                           {},
+	}
+	for desc, want := range cases {
+		r := &Reminder{Description: desc}
+		if got := r.Mentions(); !slices.Equal(got, want) {
+			t.Errorf("Mentions() of %q = %q, want %q", desc, got, want)
+		}
+	}
+}
+
+func TestTidyDescription(t *testing.T) {
+	cases := map[string]string{
+		"call  mom   about dinner.": "Call mom about dinner",
+		"review the PR;,":           "Review the PR",
+		"don't forget the keys":     "Don't forget the keys",
+		"is the build green?":       "Is the build green?",
+		"iPhone screen repair":      "iPhone screen repair",
+		"https://example.com/a":     "https://example.com/a",
+		"@alice budget...":          "@alice budget",
+		"Already tidy":              "Already tidy",
+		" . ":                       "",
+	}
+	for desc, want := range cases {
+		if got := TidyDescription(desc); got != want {
+			t.Errorf("TidyDescription(%q) = %q, want %q", desc, got, want)
+		}
+	}
+}
+
+func TestTimer(t *testing.T) {
+	start := time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC)
+	r := &Reminder{Description: "Write report", DateTime: start, Status: Pending}
+
+	r.StartTimer(start)
+	r.StartTimer(start.Add(time.Minute)) // Already running
+	if !r.TimerRunning() || len(r.Sessions) != 1 {
+		t.Fatalf("after start: running %v, %d sessions; want running, 1", r.TimerRunning(), len(r.Sessions))
+	}
+	if got := r.TimeSpent(start.Add(20 * time.Minute)); got != 20*time.Minute {
+		t.Errorf("TimeSpent() while running = %v, want 20m", got)
+	}
+
+	copied := *r
+	r.StopTimer(start.Add(30 * time.Minute))
+	if r.TimerRunning() || !copied.TimerRunning() {
+		t.Errorf("stopping should end the session without changing copies")
+	}
+
+	r.StartTimer(start.Add(time.Hour))
+	r.Acknowledge(start.Add(time.Hour + 15*time.Minute))
+	if r.TimerRunning() {
+		t.Error("acknowledging should stop the timer")
+	}
+	if got := r.TimeSpent(start.Add(3 * time.Hour)); got != 45*time.Minute {
+		t.Errorf("TimeSpent() = %v, want 45m", got)
+	}
+	if got := r.TimeSpentBetween(start.Add(10*time.Minute), start.Add(time.Hour+5*time.Minute)); got != 25*time.Minute {
+		t.Errorf("TimeSpentBetween() = %v, want 25m", got)
+	}
+}
@@ -0,0 +1,73 @@
+package reminder
+
+import "time"
+
+// yearlyHour is the time of day yearly reminders trigger
+const yearlyHour = 9
+
+// Yearly makes a reminder repeat on the same date every year, for
+// birthdays and anniversaries. The zero value doesn't repeat.
+type Yearly struct {
+	Month  time.Month
+	Day    int
+	Since  int // Year it started, such as a birth year; 0 if not known
+	Before int // Days ahead of the date to trigger, as a warning
+}
+
+// IsZero reports whether y doesn't repeat
+func (y Yearly) IsZero() bool {
+	return y.Month == 0
+}
+
+// On returns the date in year, at the hour yearly reminders trigger.
+// February 29th falls on the 28th in other years.
+func (y Yearly) On(year int) time.Time {
+	day := min(y.Day, time.Date(year, y.Month+1, 0, 0, 0, 0, 0, time.Local).Day())
+	return time.Date(year, y.Month, day, yearlyHour, 0, 0, 0, time.Local)
+}
+
+// Next returns the date's next occurrence, counting from's day itself
+func (y Yearly) Next(from time.Time) time.Time {
+	date := y.On(from.Year())
+	if date.Before(time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.Local)) {
+		date = y.On(from.Year() + 1)
+	}
+	return date
+}
+
+// Due returns when the reminder for date triggers, Before days ahead of it
+func (y Yearly) Due(date time.Time) time.Time {
+	return date.AddDate(0, 0, -y.Before)
+}
+
+// Years returns how many years it has been on date since it started, e.g.
+// the age on a birthday, or 0 without a start year
+func (y Yearly) Years(date time.Time) int {
+	if y.Since == 0 {
+		return 0
+	}
+	return date.Year() - y.Since
+}
+
+// Occurrence returns the date a yearly reminder is currently counting
+// down to
+func (r *Reminder) Occurrence() time.Time {
+	return r.DateTime.AddDate(0, 0, r.Yearly.Before)
+}
+
+// RollOver reopens an acknowledged yearly reminder for next year once its
+// date has passed, and reports whether it did
+func (r *Reminder) RollOver(now time.Time) bool {
+	if r.Yearly.IsZero() || r.Status != Acknowledged {
+		return false
+	}
+	next := r.Yearly.Next(now)
+	if !next.After(r.Occurrence()) {
+		return false
+	}
+	r.DateTime = r.Yearly.Due(next)
+	r.Status = Pending
+	r.AcknowledgedAt = time.Time{}
+	r.UpdatedAt = now
+	return true
+}
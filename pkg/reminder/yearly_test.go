@@ -0,0 +1,58 @@
+package reminder
+
+import (
+	"testing"
+	"time"
+)
+
+func TestYearlyNext(t *testing.T) {
+	date := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, yearlyHour, 0, 0, 0, time.Local) }
+	tests := []struct {
+		yearly Yearly
+		from   time.Time
+		want   time.Time
+	}{
+		{Yearly{Month: time.March, Day: 14}, date(2026, 1, 1), date(2026, 3, 14)},
+		{Yearly{Month: time.March, Day: 14}, time.Date(2026, 3, 14, 23, 0, 0, 0, time.Local), date(2026, 3, 14)}, // Still the day
+		{Yearly{Month: time.March, Day: 14}, date(2026, 3, 15), date(2027, 3, 14)},
+		{Yearly{Month: time.February, Day: 29}, date(2026, 1, 1), date(2026, 2, 28)},
+		{Yearly{Month: time.February, Day: 29}, date(2027, 3, 1), date(2028, 2, 29)},
+	}
+	for _, tt := range tests {
+		if got := tt.yearly.Next(tt.from); !got.Equal(tt.want) {
+			t.Errorf("%+v.Next(%v) = %v, want %v", tt.yearly, tt.from, got, tt.want)
+		}
+	}
+
+	y := Yearly{Month: time.March, Day: 14, Since: 1958, Before: 7}
+	if got := y.Years(date(2026, 3, 14)); got != 68 {
+		t.Errorf("Years() = %d, want 68", got)
+	}
+	if got := y.Due(date(2026, 3, 14)); !got.Equal(date(2026, 3, 7)) {
+		t.Errorf("Due() = %v, want a week ahead", got)
+	}
+}
+
+func TestRollOver(t *testing.T) {
+	y := Yearly{Month: time.March, Day: 14, Before: 7}
+	due := time.Date(2026, 3, 7, yearlyHour, 0, 0, 0, time.Local)
+	r := &Reminder{Description: "Mom's birthday", DateTime: due, Status: Acknowledged, AcknowledgedAt: due, Yearly: y}
+
+	// Acknowledged during the warning, it waits for the day itself to pass
+	if r.RollOver(time.Date(2026, 3, 14, 20, 0, 0, 0, time.Local)) {
+		t.Errorf("RollOver() before the day passed should do nothing")
+	}
+	now := time.Date(2026, 3, 15, 8, 0, 0, 0, time.Local)
+	if !r.RollOver(now) {
+		t.Fatalf("RollOver() after the day should reopen it")
+	}
+	if want := time.Date(2027, 3, 7, yearlyHour, 0, 0, 0, time.Local); !r.DateTime.Equal(want) || r.Status != Pending || !r.AcknowledgedAt.IsZero() {
+		t.Errorf("rolled over to %v %v, want %v pending", r.DateTime, r.Status, want)
+	}
+
+	// Reminders that aren't yearly, or aren't done with, stay as they are
+	plain := &Reminder{DateTime: due, Status: Acknowledged}
+	if plain.RollOver(now) || r.RollOver(now) {
+		t.Errorf("RollOver() should only reopen acknowledged yearly reminders")
+	}
+}
@@ -0,0 +1,220 @@
+package state
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go_remind/pkg/reminder"
+)
+
+const activityFileName = "activity.jsonl"
+
+// Actions recorded in the activity log
+const (
+	ActionCreated      = "created"
+	ActionEdited       = "edited"
+	ActionSnoozed      = "snoozed"
+	ActionTriggered    = "triggered"
+	ActionAcknowledged = "acknowledged"
+	ActionParked       = "parked" // Moved to waiting or someday
+	ActionReopened     = "reopened"
+	ActionDeleted      = "deleted"
+)
+
+// Change records one change to a reminder
+type Change struct {
+	ID          string    `json:"id"`
+	Description string    `json:"description"`
+	Action      string    `json:"action"`
+	Detail      string    `json:"detail,omitempty"` // e.g. what was edited, or when a snooze ends
+	At          time.Time `json:"at"`
+}
+
+// ActivityLog is an append-only log of every change to the reminders, one
+// JSON object per line. Changes are found by comparing each save with the
+// one before, so it covers the TUI, the daemon, and the CLI alike.
+type ActivityLog struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewActivityLog creates an ActivityLog backed by the given file
+func NewActivityLog(path string) *ActivityLog {
+	return &ActivityLog{path: path}
+}
+
+// Activity returns the activity log kept next to the state file
+func (s *Store) Activity() *ActivityLog {
+	if s.activity == nil {
+		s.activity = NewActivityLog(filepath.Join(filepath.Dir(s.path), activityFileName))
+	}
+	return s.activity
+}
+
+// Load reads every change in the log, oldest first
+func (a *ActivityLog) Load() ([]Change, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	f, err := os.Open(a.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil // Nothing changed yet
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var changes []Change
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var c Change
+		if err := json.Unmarshal(scanner.Bytes(), &c); err != nil {
+			continue // Skip a line torn by a crash mid-write
+		}
+		changes = append(changes, c)
+	}
+	return changes, scanner.Err()
+}
+
+// LoadFor reads the changes to the reminder with the given ID, oldest first
+func (a *ActivityLog) LoadFor(id string) ([]Change, error) {
+	changes, err := a.Load()
+	var mine []Change
+	for _, c := range changes {
+		if c.ID == id {
+			mine = append(mine, c)
+		}
+	}
+	return mine, err
+}
+
+// Record appends a change for each reminder that differs between before
+// and after, both in their saved order
+func (a *ActivityLog) Record(before, after []*reminder.Reminder, now time.Time) error {
+	changes := Diff(before, after, now)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	var lines []byte
+	for _, c := range changes {
+		data, err := json.Marshal(c)
+		if err != nil {
+			return err
+		}
+		lines = append(append(lines, data...), '\n')
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	f, err := os.OpenFile(a.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(lines); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Diff returns what changed from before to after: reminders created,
+// deleted, or changed, at most one change for each
+func Diff(before, after []*reminder.Reminder, now time.Time) []Change {
+	old := make(map[string]*reminder.Reminder, len(before))
+	for _, r := range before {
+		old[r.ID] = r
+	}
+
+	var changes []Change
+	seen := make(map[string]bool, len(after))
+	for _, r := range after {
+		seen[r.ID] = true
+		prev, ok := old[r.ID]
+		if !ok {
+			changes = append(changes, Change{ID: r.ID, Description: r.Description, Action: ActionCreated, At: now})
+			continue
+		}
+		if action, detail := transition(prev, r); action != "" {
+			changes = append(changes, Change{ID: r.ID, Description: r.Description, Action: action, Detail: detail, At: now})
+		}
+	}
+	for _, r := range before {
+		if !seen[r.ID] {
+			changes = append(changes, Change{ID: r.ID, Description: r.Description, Action: ActionDeleted, At: now})
+		}
+	}
+	return changes
+}
+
+// transition names the change from prev to r, or returns "" if nothing
+// the log tracks changed
+func transition(prev, r *reminder.Reminder) (action, detail string) {
+	parked := func(s reminder.Status) bool {
+		return s == reminder.Acknowledged || s == reminder.Waiting || s == reminder.Someday
+	}
+	switch {
+	case r.Status == prev.Status:
+	case r.Status == reminder.Snoozed:
+		return ActionSnoozed, "until " + r.DateTime.Format("Jan 2 15:04")
+	case r.Status == reminder.Acknowledged:
+		return ActionAcknowledged, ""
+	case r.Status == reminder.Waiting, r.Status == reminder.Someday:
+		return ActionParked, r.Status.Name()
+	case parked(prev.Status):
+		return ActionReopened, ""
+	case r.Status == reminder.Triggered && r.DateTime.Equal(prev.DateTime):
+		return ActionTriggered, ""
+	}
+
+	if edits := edits(prev, r); len(edits) > 0 {
+		return ActionEdited, strings.Join(edits, ", ")
+	}
+	return "", ""
+}
+
+// edits lists the fields the user can edit that differ from prev to r
+func edits(prev, r *reminder.Reminder) []string {
+	var changed []string
+	if r.Description != prev.Description {
+		changed = append(changed, "description")
+	}
+	if r.Notes != prev.Notes {
+		changed = append(changed, "notes")
+	}
+	if !r.DateTime.Equal(prev.DateTime) {
+		changed = append(changed, "due "+r.DateTime.Format("Jan 2 15:04"))
+	}
+	if !slices.Equal(r.Tags, prev.Tags) {
+		changed = append(changed, "tags")
+	}
+	if r.Priority != prev.Priority {
+		if r.Priority == reminder.PriorityNone {
+			changed = append(changed, "priority cleared")
+		} else {
+			changed = append(changed, "priority "+r.Priority.String())
+		}
+	}
+	if r.Effort != prev.Effort {
+		if r.Effort == reminder.EffortNone {
+			changed = append(changed, "effort cleared")
+		} else {
+			changed = append(changed, "effort "+r.Effort.String())
+		}
+	}
+	if r.Label != prev.Label {
+		if r.Label == reminder.LabelNone {
+			changed = append(changed, "label cleared")
+		} else {
+			changed = append(changed, "label "+r.Label.String())
+		}
+	}
+	return changed
+}
@@ -0,0 +1,96 @@
+package state
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go_remind/pkg/reminder"
+)
+
+func TestActivityLogRecordsEachSave(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(filepath.Join(dir, stateFileName))
+	due := time.Date(2026, 1, 13, 10, 0, 0, 0, time.UTC)
+	a := &reminder.Reminder{ID: "a", Description: "Standup", DateTime: due, Status: reminder.Pending}
+	b := &reminder.Reminder{ID: "b", Description: "Lunch", DateTime: due.Add(2 * time.Hour), Status: reminder.Pending}
+
+	save := func(rs ...*reminder.Reminder) {
+		t.Helper()
+		if err := store.Save(rs); err != nil {
+			t.Fatalf("Save() error: %v", err)
+		}
+	}
+	save(a, b)
+	save(a, b) // Nothing changed
+	a.Status = reminder.Triggered
+	save(a, b)
+	a.Acknowledge(due.Add(time.Minute))
+	save(a)
+
+	changes, err := store.Activity().Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	want := []struct{ id, action string }{
+		{"a", ActionCreated}, {"b", ActionCreated},
+		{"a", ActionTriggered},
+		{"a", ActionAcknowledged}, {"b", ActionDeleted},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("got %d changes, want %d: %+v", len(changes), len(want), changes)
+	}
+	for i, w := range want {
+		if changes[i].ID != w.id || changes[i].Action != w.action || changes[i].At.IsZero() {
+			t.Errorf("change %d = %+v, want %s %s", i, changes[i], w.id, w.action)
+		}
+	}
+
+	mine, err := store.Activity().LoadFor("b")
+	if err != nil || len(mine) != 2 || mine[1].Description != "Lunch" {
+		t.Errorf("LoadFor(b) = %+v, %v; want its creation and deletion", mine, err)
+	}
+}
+
+func TestDiffTransitions(t *testing.T) {
+	now := time.Date(2026, 1, 13, 10, 0, 0, 0, time.UTC)
+	base := reminder.Reminder{ID: "a", Description: "Standup", DateTime: now, Status: reminder.Pending}
+
+	tests := []struct {
+		name           string
+		change         func(r *reminder.Reminder)
+		action, detail string
+	}{
+		{"snoozed", func(r *reminder.Reminder) { r.DateTime = now.Add(time.Hour); r.Status = reminder.Snoozed }, ActionSnoozed, "until Jan 13 11:00"},
+		{"parked", func(r *reminder.Reminder) { r.Status = reminder.Waiting }, ActionParked, "waiting"},
+		{"edited", func(r *reminder.Reminder) { r.Description = "Sync"; r.Priority = reminder.PriorityHigh }, ActionEdited, "description, priority high"},
+		{"effort", func(r *reminder.Reminder) { r.Effort = reminder.EffortEasy }, ActionEdited, "effort easy"},
+		{"label", func(r *reminder.Reminder) { r.Label = reminder.LabelRed }, ActionEdited, "label red"},
+		{"notes", func(r *reminder.Reminder) { r.Notes = "Bring the printouts" }, ActionEdited, "notes"},
+		{"rescheduled", func(r *reminder.Reminder) { r.DateTime = now.AddDate(0, 0, 1) }, ActionEdited, "due Jan 14 10:00"},
+		{"untracked field", func(r *reminder.Reminder) { r.SourceFile = "notes.md" }, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prev, r := base, base
+			tt.change(&r)
+			changes := Diff([]*reminder.Reminder{&prev}, []*reminder.Reminder{&r}, now)
+			if tt.action == "" {
+				if len(changes) != 0 {
+					t.Errorf("Diff() = %+v, want nothing", changes)
+				}
+				return
+			}
+			if len(changes) != 1 || changes[0].Action != tt.action || changes[0].Detail != tt.detail {
+				t.Errorf("Diff() = %+v, want %s %q", changes, tt.action, tt.detail)
+			}
+		})
+	}
+
+	// Reopening a done reminder
+	done := base
+	done.Status = reminder.Acknowledged
+	if changes := Diff([]*reminder.Reminder{&done}, []*reminder.Reminder{&base}, now); len(changes) != 1 || changes[0].Action != ActionReopened {
+		t.Errorf("Diff() = %+v, want reopened", changes)
+	}
+}
@@ -0,0 +1,220 @@
+package state
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	backupDirName    = "backups"
+	keepDailyBackups = 7
+)
+
+// Archive entry names. Only these are read back by Restore.
+const (
+	archiveState    = stateFileName
+	archiveHistory  = historyFileName
+	archiveActivity = activityFileName
+	archiveConfig   = "config.toml"
+)
+
+// BackupDir returns the directory the automatic daily backups are kept in
+func (s *Store) BackupDir() string {
+	return filepath.Join(filepath.Dir(s.path), backupDirName)
+}
+
+// rotateBackups copies the state file into the backup directory if there
+// is no backup for today yet, then removes all but the newest daily backups.
+// The first save of each day keeps the state as it was before that day's
+// changes.
+func (s *Store) rotateBackups(now time.Time) error {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil // Nothing saved yet
+		}
+		return err
+	}
+
+	dir := s.BackupDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	today := filepath.Join(dir, dailyBackupName(now))
+	if _, err := os.Stat(today); os.IsNotExist(err) {
+		if err := os.WriteFile(today, data, 0644); err != nil {
+			return err
+		}
+	}
+
+	backups, err := s.DailyBackups()
+	if err != nil {
+		return err
+	}
+	for len(backups) > keepDailyBackups {
+		if err := os.Remove(backups[0]); err != nil {
+			return err
+		}
+		backups = backups[1:]
+	}
+	return nil
+}
+
+// dailyBackupName is the file name of the backup taken on the given day
+func dailyBackupName(day time.Time) string {
+	return strings.TrimSuffix(stateFileName, ".json") + "-" + day.Format("2006-01-02") + ".json"
+}
+
+// DailyBackups returns the paths of the automatic backups, oldest first
+func (s *Store) DailyBackups() ([]string, error) {
+	prefix := strings.TrimSuffix(stateFileName, ".json") + "-"
+	entries, err := os.ReadDir(s.BackupDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var paths []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasPrefix(e.Name(), prefix) && strings.HasSuffix(e.Name(), ".json") {
+			paths = append(paths, filepath.Join(s.BackupDir(), e.Name()))
+		}
+	}
+	sort.Strings(paths) // Dates sort by name
+	return paths, nil
+}
+
+// Backup writes a gzipped tar archive of the state file, the acknowledgment
+// history, the activity log, and the config file at configPath to w. Files that don't exist
+// yet are left out.
+func (s *Store) Backup(w io.Writer, configPath string) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	files := []struct{ name, path string }{
+		{archiveState, s.path},
+		{archiveHistory, s.History().path},
+		{archiveActivity, s.Activity().path},
+		{archiveConfig, configPath},
+	}
+	written := 0
+	for _, f := range files {
+		if f.path == "" {
+			continue
+		}
+		data, err := os.ReadFile(f.path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
+		}
+		hdr := &tar.Header{Name: f.name, Mode: 0644, Size: int64(len(data)), ModTime: s.now()}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := tw.Write(data); err != nil {
+			return err
+		}
+		written++
+	}
+	if written == 0 {
+		return errors.New("nothing to back up")
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// Restore replaces the state file, history, activity log, and config at configPath with
+// the contents of an archive written by Backup. The archived state is
+// checked before anything is replaced, and the current state is copied to
+// the backup directory first.
+func (s *Store) Restore(r io.Reader, configPath string) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("not a backup archive: %w", err)
+	}
+	defer gz.Close()
+
+	contents := make(map[string][]byte)
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("reading backup: %w", err)
+		}
+		switch hdr.Name {
+		case archiveState, archiveHistory, archiveActivity, archiveConfig:
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				return fmt.Errorf("reading backup: %w", err)
+			}
+			contents[hdr.Name] = data
+		}
+	}
+
+	stateData, ok := contents[archiveState]
+	if !ok {
+		return errors.New("backup has no state file")
+	}
+	if _, err := Unmarshal(stateData); err != nil {
+		return fmt.Errorf("backup state is invalid: %w", err)
+	}
+
+	if err := s.snapshot(s.now()); err != nil {
+		return fmt.Errorf("saving current state: %w", err)
+	}
+	if err := os.WriteFile(s.path, stateData, 0644); err != nil {
+		return err
+	}
+	if data, ok := contents[archiveHistory]; ok {
+		if err := os.WriteFile(s.History().path, data, 0644); err != nil {
+			return err
+		}
+	}
+	if data, ok := contents[archiveActivity]; ok {
+		if err := os.WriteFile(s.Activity().path, data, 0644); err != nil {
+			return err
+		}
+	}
+	if data, ok := contents[archiveConfig]; ok && configPath != "" {
+		if err := os.WriteFile(configPath, data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// snapshot copies the current state file into the backup directory under a
+// timestamped name that rotation leaves alone
+func (s *Store) snapshot(now time.Time) error {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	dir := s.BackupDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	name := "pre-restore-" + now.Format("20060102-150405") + ".json"
+	return os.WriteFile(filepath.Join(dir, name), data, 0644)
+}
@@ -0,0 +1,85 @@
+package state
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go_remind/pkg/reminder"
+)
+
+func TestRotateBackupsKeepsLastWeek(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(filepath.Join(dir, stateFileName))
+	if err := store.Save([]*reminder.Reminder{{ID: "a", Description: "First"}}); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	day := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	for i := range 10 {
+		if err := store.rotateBackups(day.AddDate(0, 0, i)); err != nil {
+			t.Fatalf("rotateBackups() error: %v", err)
+		}
+	}
+	// A second save on the same day keeps the morning's backup
+	if err := store.rotateBackups(day.AddDate(0, 0, 9).Add(time.Hour)); err != nil {
+		t.Fatalf("rotateBackups() error: %v", err)
+	}
+
+	backups, err := store.DailyBackups()
+	if err != nil {
+		t.Fatalf("DailyBackups() error: %v", err)
+	}
+	if len(backups) != keepDailyBackups {
+		t.Fatalf("got %d backups, want %d: %v", len(backups), keepDailyBackups, backups)
+	}
+	if got, want := filepath.Base(backups[0]), dailyBackupName(day.AddDate(0, 0, 3)); got != want {
+		t.Errorf("oldest backup = %s, want %s", got, want)
+	}
+}
+
+func TestBackupAndRestore(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(filepath.Join(dir, stateFileName))
+	configPath := filepath.Join(dir, "config.toml")
+	if err := os.WriteFile(configPath, []byte("[ui]\nascii = true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	original := []*reminder.Reminder{{ID: "a", Description: "Keep me", Status: reminder.Waiting}}
+	if err := store.Save(original); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	var archive bytes.Buffer
+	if err := store.Backup(&archive, configPath); err != nil {
+		t.Fatalf("Backup() error: %v", err)
+	}
+
+	// Lose everything, then restore
+	if err := store.Save(nil); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	if err := os.WriteFile(configPath, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Restore(bytes.NewReader(archive.Bytes()), configPath); err != nil {
+		t.Fatalf("Restore() error: %v", err)
+	}
+
+	got, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if len(got) != 1 || got[0].Description != "Keep me" || got[0].Status != reminder.Waiting {
+		t.Errorf("restored reminders = %+v, want the original", got)
+	}
+	if data, _ := os.ReadFile(configPath); string(data) != "[ui]\nascii = true\n" {
+		t.Errorf("restored config = %q", data)
+	}
+
+	if err := store.Restore(bytes.NewReader([]byte("not an archive")), configPath); err == nil {
+		t.Error("Restore() of garbage succeeded, want an error")
+	}
+}
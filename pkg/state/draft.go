@@ -0,0 +1,54 @@
+package state
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const draftFileName = "draft.json"
+
+// Draft is text typed into the TUI's add or edit box but not yet saved,
+// kept so it survives the terminal dying
+type Draft struct {
+	Text    string    `json:"text"`
+	Editing string    `json:"editing,omitempty"` // ID of the reminder being edited; empty for a new one
+	SavedAt time.Time `json:"saved_at"`
+}
+
+// LoadDraft reads the saved draft. None saved is not an error, and gives
+// an empty draft.
+func (s *Store) LoadDraft() (Draft, error) {
+	var draft Draft
+	data, err := os.ReadFile(s.draftPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return draft, nil
+		}
+		return draft, err
+	}
+	err = json.Unmarshal(data, &draft)
+	return draft, err
+}
+
+// SaveDraft saves the draft, replacing any saved before
+func (s *Store) SaveDraft(draft Draft) error {
+	data, err := json.MarshalIndent(draft, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.draftPath(), data, 0600)
+}
+
+// ClearDraft forgets the saved draft
+func (s *Store) ClearDraft() error {
+	if err := os.Remove(s.draftPath()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (s *Store) draftPath() string {
+	return filepath.Join(filepath.Dir(s.path), draftFileName)
+}
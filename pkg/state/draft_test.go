@@ -0,0 +1,31 @@
+package state
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDraftRoundTrip(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), stateFileName))
+	if draft, err := store.LoadDraft(); err != nil || draft != (Draft{}) {
+		t.Fatalf("LoadDraft() with nothing saved = %v, %v", draft, err)
+	}
+	want := Draft{Text: "friday 3pm Write up the launch plan", Editing: "a1", SavedAt: time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)}
+	if err := store.SaveDraft(want); err != nil {
+		t.Fatalf("SaveDraft() error: %v", err)
+	}
+	if got, err := store.LoadDraft(); err != nil || got != want {
+		t.Errorf("LoadDraft() = %v, %v, want %v", got, err, want)
+	}
+
+	if err := store.ClearDraft(); err != nil {
+		t.Fatalf("ClearDraft() error: %v", err)
+	}
+	if draft, err := store.LoadDraft(); err != nil || draft != (Draft{}) {
+		t.Errorf("LoadDraft() after clearing = %v, %v", draft, err)
+	}
+	if err := store.ClearDraft(); err != nil {
+		t.Errorf("ClearDraft() with nothing saved: %v", err)
+	}
+}
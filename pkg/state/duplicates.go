@@ -0,0 +1,37 @@
+package state
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+const ignoredDuplicatesFileName = "ignored_duplicates.json"
+
+// LoadIgnoredDuplicates reads the keys of the duplicate groups the user
+// said aren't duplicates. None saved yet is not an error.
+func (s *Store) LoadIgnoredDuplicates() ([]string, error) {
+	var keys []string
+	data, err := os.ReadFile(s.ignoredDuplicatesPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	err = json.Unmarshal(data, &keys)
+	return keys, err
+}
+
+// SaveIgnoredDuplicates saves the keys of the ignored duplicate groups
+func (s *Store) SaveIgnoredDuplicates(keys []string) error {
+	data, err := json.MarshalIndent(keys, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.ignoredDuplicatesPath(), data, 0644)
+}
+
+func (s *Store) ignoredDuplicatesPath() string {
+	return filepath.Join(filepath.Dir(s.path), ignoredDuplicatesFileName)
+}
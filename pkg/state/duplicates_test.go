@@ -0,0 +1,21 @@
+package state
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestIgnoredDuplicatesRoundTrip(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), stateFileName))
+	if keys, err := store.LoadIgnoredDuplicates(); err != nil || len(keys) != 0 {
+		t.Fatalf("LoadIgnoredDuplicates() with nothing saved = %v, %v", keys, err)
+	}
+	want := []string{"a1,b2", "c3,d4,e5"}
+	if err := store.SaveIgnoredDuplicates(want); err != nil {
+		t.Fatalf("SaveIgnoredDuplicates() error: %v", err)
+	}
+	if got, err := store.LoadIgnoredDuplicates(); err != nil || !slices.Equal(got, want) {
+		t.Errorf("LoadIgnoredDuplicates() = %v, %v, want %v", got, err, want)
+	}
+}
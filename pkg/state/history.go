@@ -0,0 +1,130 @@
+package state
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go_remind/pkg/reminder"
+)
+
+const historyFileName = "history.jsonl"
+
+// AckEvent records one acknowledgment of a reminder
+type AckEvent struct {
+	ID          string    `json:"id"`
+	Description string    `json:"description"`
+	DueAt       time.Time `json:"due_at"`
+	At          time.Time `json:"at"` // When it was acknowledged
+}
+
+// History is an append-only log of acknowledgment events, one JSON object
+// per line. It outlives the reminders themselves, so stats still count
+// reminders that were later deleted or reopened.
+type History struct {
+	path string
+
+	mu       sync.Mutex
+	recorded map[string]time.Time // Latest acknowledgment logged per reminder ID
+}
+
+// NewHistory creates a History backed by the given file
+func NewHistory(path string) *History {
+	return &History{path: path}
+}
+
+// History returns the acknowledgment log kept next to the state file
+func (s *Store) History() *History {
+	if s.history == nil {
+		s.history = NewHistory(filepath.Join(filepath.Dir(s.path), historyFileName))
+	}
+	return s.history
+}
+
+// Load reads every event in the log, oldest first
+func (h *History) Load() ([]AckEvent, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.load()
+}
+
+func (h *History) load() ([]AckEvent, error) {
+	f, err := os.Open(h.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil // Nothing acknowledged yet
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var events []AckEvent
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e AckEvent
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue // Skip a line torn by a crash mid-write
+		}
+		events = append(events, e)
+	}
+	return events, scanner.Err()
+}
+
+// Record appends an event for each reminder acknowledged since it was last
+// recorded. Reminders acknowledged before the log existed are backfilled
+// from their AcknowledgedAt time.
+func (h *History) Record(reminders []*reminder.Reminder) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.recorded == nil {
+		events, err := h.load()
+		if err != nil {
+			return err
+		}
+		h.recorded = make(map[string]time.Time, len(events))
+		for _, e := range events {
+			if e.At.After(h.recorded[e.ID]) {
+				h.recorded[e.ID] = e.At
+			}
+		}
+	}
+
+	var lines []byte
+	var added []AckEvent
+	for _, r := range reminders {
+		if r.Status != reminder.Acknowledged || r.AcknowledgedAt.IsZero() || !r.AcknowledgedAt.After(h.recorded[r.ID]) {
+			continue
+		}
+		e := AckEvent{ID: r.ID, Description: r.Description, DueAt: r.DateTime, At: r.AcknowledgedAt}
+		data, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		lines = append(append(lines, data...), '\n')
+		added = append(added, e)
+	}
+	if len(added) == 0 {
+		return nil
+	}
+
+	f, err := os.OpenFile(h.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(lines); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	for _, e := range added {
+		h.recorded[e.ID] = e.At
+	}
+	return nil
+}
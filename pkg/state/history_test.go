@@ -0,0 +1,66 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go_remind/pkg/reminder"
+)
+
+func TestHistoryRecordsNewAcknowledgments(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(filepath.Join(dir, stateFileName))
+
+	ackedAt := time.Date(2026, 1, 13, 10, 0, 0, 0, time.UTC)
+	r := &reminder.Reminder{ID: "a", Description: "Standup", DateTime: ackedAt.Add(-time.Hour), Status: reminder.Acknowledged, AcknowledgedAt: ackedAt}
+	open := &reminder.Reminder{ID: "b", Description: "Later", DateTime: ackedAt.Add(time.Hour), Status: reminder.Pending}
+
+	// Saving twice logs the acknowledgment once
+	for range 2 {
+		if err := store.Save([]*reminder.Reminder{r, open}); err != nil {
+			t.Fatalf("Save() error: %v", err)
+		}
+	}
+
+	// Acknowledging again, after a reopen, logs a second event
+	r.AcknowledgedAt = ackedAt.Add(24 * time.Hour)
+	if err := store.Save([]*reminder.Reminder{r, open}); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	// A fresh store picks up where the log left off
+	reopened := NewStore(filepath.Join(dir, stateFileName))
+	if err := reopened.Save([]*reminder.Reminder{r, open}); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	events, err := reopened.History().Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want 2: %+v", len(events), events)
+	}
+	if events[0].ID != "a" || !events[0].At.Equal(ackedAt) || events[0].Description != "Standup" {
+		t.Errorf("first event = %+v", events[0])
+	}
+}
+
+func TestHistorySkipsTornLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), historyFileName)
+	data := `{"id":"a","description":"Done","due_at":"2026-01-13T09:00:00Z","at":"2026-01-13T10:00:00Z"}` + "\n" + `{"id":"b","desc`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := NewHistory(path).Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if len(events) != 1 || !strings.HasPrefix(events[0].Description, "Done") {
+		t.Errorf("events = %+v, want just the complete line", events)
+	}
+}
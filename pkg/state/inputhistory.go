@@ -0,0 +1,44 @@
+package state
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+const inputHistoryFileName = "input_history.json"
+
+// InputHistory is what was entered into the TUI's add and filter boxes,
+// oldest first, so they can be recalled in later sessions
+type InputHistory struct {
+	Add    []string `json:"add,omitempty"`
+	Filter []string `json:"filter,omitempty"`
+}
+
+// LoadInputHistory reads the saved input history. None saved yet is not
+// an error.
+func (s *Store) LoadInputHistory() (InputHistory, error) {
+	var history InputHistory
+	data, err := os.ReadFile(s.inputHistoryPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return history, nil
+		}
+		return history, err
+	}
+	err = json.Unmarshal(data, &history)
+	return history, err
+}
+
+// SaveInputHistory saves the input history
+func (s *Store) SaveInputHistory(history InputHistory) error {
+	data, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.inputHistoryPath(), data, 0600)
+}
+
+func (s *Store) inputHistoryPath() string {
+	return filepath.Join(filepath.Dir(s.path), inputHistoryFileName)
+}
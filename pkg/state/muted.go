@@ -0,0 +1,88 @@
+package state
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"go_remind/pkg/reminder"
+)
+
+const mutedFileName = "muted.json"
+
+// Mute records a reminder from a markdown file that was deleted, so that
+// parsing the file again doesn't bring it back. It lasts until the
+// reminder's text changes or leaves the file.
+type Mute struct {
+	ID          string    `json:"id"`
+	Description string    `json:"description"`
+	SourceFile  string    `json:"source_file"`
+	MutedAt     time.Time `json:"muted_at"`
+}
+
+// MuteReminder returns the mute that keeps r deleted. It's keyed by the
+// file and current description, which is what parsing the file matches on.
+func MuteReminder(r *reminder.Reminder, now time.Time) Mute {
+	return Mute{ID: reminder.FileID(r.SourceFile, r.Description), Description: r.Description, SourceFile: r.SourceFile, MutedAt: now}
+}
+
+// DropMuted removes the muted reminders from those just parsed. Mutes for
+// the parsed files whose reminder wasn't found again are dropped too, since
+// its text changed; files names any parsed file that no reminder came from.
+// It returns the reminders to merge and the mutes left.
+func DropMuted(mutes []Mute, parsed []*reminder.Reminder, files ...string) ([]*reminder.Reminder, []Mute) {
+	if len(mutes) == 0 {
+		return parsed, mutes
+	}
+	muted := make(map[string]bool, len(mutes))
+	for _, mute := range mutes {
+		muted[mute.ID] = true
+	}
+	found := make(map[string]bool)
+	var kept []*reminder.Reminder
+	for _, r := range parsed {
+		files = append(files, r.SourceFile)
+		if id := reminder.FileID(r.SourceFile, r.Description); muted[id] {
+			found[id] = true
+			continue
+		}
+		kept = append(kept, r)
+	}
+
+	var left []Mute
+	for _, mute := range mutes {
+		if found[mute.ID] || !slices.Contains(files, mute.SourceFile) {
+			left = append(left, mute)
+		}
+	}
+	return kept, left
+}
+
+// LoadMuted reads the saved mutes. None saved yet is not an error.
+func (s *Store) LoadMuted() ([]Mute, error) {
+	var mutes []Mute
+	data, err := os.ReadFile(s.mutedPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	err = json.Unmarshal(data, &mutes)
+	return mutes, err
+}
+
+// SaveMuted saves the mutes
+func (s *Store) SaveMuted(mutes []Mute) error {
+	data, err := json.MarshalIndent(mutes, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.mutedPath(), data, 0644)
+}
+
+func (s *Store) mutedPath() string {
+	return filepath.Join(filepath.Dir(s.path), mutedFileName)
+}
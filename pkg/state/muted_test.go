@@ -0,0 +1,60 @@
+package state
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go_remind/pkg/reminder"
+)
+
+func TestDropMuted(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	parse := func(file string, descs ...string) []*reminder.Reminder {
+		var rs []*reminder.Reminder
+		for _, desc := range descs {
+			rs = append(rs, &reminder.Reminder{ID: reminder.FileID(file, desc), Description: desc, SourceFile: file})
+		}
+		return rs
+	}
+	mutes := []Mute{
+		MuteReminder(parse("notes.md", "Call mom")[0], now),
+		MuteReminder(parse("todo.md", "Pay rent")[0], now),
+	}
+
+	// A muted reminder stays out while its text is unchanged
+	kept, left := DropMuted(mutes, parse("notes.md", "Call mom", "Water plants"))
+	if len(kept) != 1 || kept[0].Description != "Water plants" {
+		t.Errorf("kept %v, want only Water plants", kept)
+	}
+	if len(left) != 2 {
+		t.Errorf("left %d mutes, want 2", len(left))
+	}
+
+	// Once the text changes, the mute is forgotten and the new text is added
+	kept, left = DropMuted(mutes, parse("notes.md", "Call mom tonight"))
+	if len(kept) != 1 || len(left) != 1 || left[0].SourceFile != "todo.md" {
+		t.Errorf("kept %v and left %v, want the changed reminder back and only todo.md's mute", kept, left)
+	}
+
+	// A file that no longer has any reminders forgets its mutes too
+	if _, left = DropMuted(mutes, nil, "todo.md"); len(left) != 1 || left[0].SourceFile != "notes.md" {
+		t.Errorf("left %v, want only notes.md's mute", left)
+	}
+}
+
+func TestMutedRoundTrip(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), stateFileName))
+	if mutes, err := store.LoadMuted(); err != nil || mutes != nil {
+		t.Fatalf("LoadMuted() with nothing saved = %v, %v", mutes, err)
+	}
+	r := &reminder.Reminder{ID: "a1", Description: "Call mom", SourceFile: "/notes/today.md"}
+	want := MuteReminder(r, time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC))
+	if err := store.SaveMuted([]Mute{want}); err != nil {
+		t.Fatalf("SaveMuted() error: %v", err)
+	}
+	got, err := store.LoadMuted()
+	if err != nil || len(got) != 1 || got[0] != want {
+		t.Errorf("LoadMuted() = %v, %v, want %v", got, err, want)
+	}
+}
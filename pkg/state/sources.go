@@ -0,0 +1,44 @@
+package state
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+const sourcesFileName = "sources.json"
+
+// SourceSettings are the choices made in the TUI about sources of reminders,
+// kept next to the state file so they outlive a restart
+type SourceSettings struct {
+	Removed []string `json:"removed,omitempty"` // Names of sources no longer fetched
+	Paused  []string `json:"paused,omitempty"`  // Names of sources not refreshed for now
+}
+
+// LoadSourceSettings reads the saved source settings. None saved yet is
+// not an error.
+func (s *Store) LoadSourceSettings() (SourceSettings, error) {
+	var settings SourceSettings
+	data, err := os.ReadFile(s.sourcesPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return settings, nil
+		}
+		return settings, err
+	}
+	err = json.Unmarshal(data, &settings)
+	return settings, err
+}
+
+// SaveSourceSettings saves the source settings
+func (s *Store) SaveSourceSettings(settings SourceSettings) error {
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.sourcesPath(), data, 0644)
+}
+
+func (s *Store) sourcesPath() string {
+	return filepath.Join(filepath.Dir(s.path), sourcesFileName)
+}
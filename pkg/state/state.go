@@ -0,0 +1,312 @@
+// Package state saves reminders to the versioned state file, and keeps the
+// acknowledgment history and backups alongside it.
+package state
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go_remind/pkg/clock"
+	"go_remind/pkg/reminder"
+)
+
+const stateFileName = "reminders_state.json"
+
+// stateVersion is the version of the state file format written by Marshal.
+// Version 1 was a bare array with numeric statuses; version 2 wraps it in
+// an object and saves statuses by name.
+const stateVersion = 2
+
+// Store handles persistence of reminders to disk
+type Store struct {
+	path     string
+	clock    clock.Clock  // nil reads the system clock
+	history  *History     // Created on first use
+	activity *ActivityLog // Created on first use
+}
+
+// NewStore creates a Store with a custom path
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// SetClock sets the clock that saves date backups and activity by
+func (s *Store) SetClock(c clock.Clock) {
+	s.clock = c
+}
+
+// now returns the current time from the store's clock
+func (s *Store) now() time.Time {
+	if s.clock == nil {
+		return clock.Real.Now()
+	}
+	return s.clock.Now()
+}
+
+// NewDefaultStore creates a Store using the default path (~/.go_remind/reminders_state.json)
+func NewDefaultStore() (*Store, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	return NewStoreInDir(filepath.Join(homeDir, ".go_remind"))
+}
+
+// NewTestStore creates a Store using the test path (~/.go_remind/test/reminders_state.json)
+func NewTestStore() (*Store, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	return NewStoreInDir(filepath.Join(homeDir, ".go_remind", "test"))
+}
+
+// NewStoreInDir creates a Store keeping its files in dir, creating the
+// directory if needed
+func NewStoreInDir(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &Store{
+		path: filepath.Join(dir, stateFileName),
+	}, nil
+}
+
+// Path returns the store's file path
+func (s *Store) Path() string {
+	return s.path
+}
+
+// stateFile is the JSON form of the state file
+type stateFile struct {
+	Version   int             `json:"version"`
+	Reminders []savedReminder `json:"reminders"`
+}
+
+// savedReminder is the JSON-serializable form of a reminder
+type savedReminder struct {
+	ID          string         `json:"id"`
+	DateTime    time.Time      `json:"datetime"`
+	Description string         `json:"description"`
+	Notes       string         `json:"notes,omitempty"`
+	Tags        []string       `json:"tags,omitempty"`
+	SourceFile  string         `json:"source_file"`
+	LineNumber  int            `json:"line,omitempty"`
+	Source      string         `json:"source,omitempty"`
+	From        string         `json:"from,omitempty"`
+	Context     string         `json:"context,omitempty"`
+	Status      savedStatus    `json:"status"`
+	Priority    string         `json:"priority,omitempty"`
+	Estimate    string         `json:"estimate,omitempty"` // A Go duration, e.g. "1h30m0s"
+	Effort      string         `json:"effort,omitempty"`
+	Label       string         `json:"label,omitempty"`
+	Yearly      *savedYearly   `json:"yearly,omitempty"`
+	Relative    *savedRelative `json:"relative,omitempty"`
+
+	AcknowledgedAt time.Time `json:"acknowledged_at,omitzero"`
+	UpdatedAt      time.Time `json:"updated_at,omitzero"`
+	CreatedAt      time.Time `json:"created_at,omitzero"`
+
+	Sessions []savedSession `json:"sessions,omitempty"`
+}
+
+// savedSession is the JSON form of a span of time worked on a reminder
+type savedSession struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end,omitzero"` // Zero while the timer runs
+}
+
+// savedYearly is the JSON form of the date a reminder repeats on every year
+type savedYearly struct {
+	Month  int `json:"month"`
+	Day    int `json:"day"`
+	Since  int `json:"since,omitempty"`
+	Before int `json:"before,omitempty"`
+}
+
+// savedRelative is the JSON form of a time relative to a tagged reminder
+type savedRelative struct {
+	Tag    string `json:"tag"`
+	Offset string `json:"offset"` // A Go duration, negative for before
+}
+
+// Load reads reminders from the state file
+func (s *Store) Load() ([]*reminder.Reminder, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil // No state file yet, that's OK
+		}
+		return nil, err
+	}
+
+	return Unmarshal(data)
+}
+
+// Save writes reminders to the state file, logs any new acknowledgments
+// to the history, and what changed since the last save to the activity
+// log. The previous state is kept as the day's backup first; a failed
+// backup is reported but doesn't stop the save.
+func (s *Store) Save(reminders []*reminder.Reminder) error {
+	data, err := Marshal(reminders)
+	if err != nil {
+		return err
+	}
+	before, beforeErr := s.Load()
+
+	now := s.now()
+	backupErr := s.rotateBackups(now)
+	if err := os.WriteFile(s.path, data, 0644); err != nil {
+		return err
+	}
+	if err := s.History().Record(reminders); err != nil {
+		return err
+	}
+	if beforeErr == nil {
+		if err := s.Activity().Record(before, reminders, now); err != nil {
+			return err
+		}
+	}
+	if backupErr != nil {
+		return fmt.Errorf("backup: %w", backupErr)
+	}
+	return nil
+}
+
+// Marshal encodes reminders in the state file format
+func Marshal(reminders []*reminder.Reminder) ([]byte, error) {
+	saved := make([]savedReminder, len(reminders))
+	for i, r := range reminders {
+		saved[i] = savedReminder{
+			ID:          r.ID,
+			DateTime:    r.DateTime,
+			Description: r.Description,
+			Notes:       r.Notes,
+			Tags:        r.Tags,
+			SourceFile:  r.SourceFile,
+			LineNumber:  r.LineNumber,
+			Source:      r.Source,
+			From:        r.From,
+			Context:     r.Context,
+			Status:      savedStatus(r.Status),
+			Priority:    r.Priority.String(),
+			Effort:      r.Effort.String(),
+			Label:       r.Label.String(),
+
+			AcknowledgedAt: r.AcknowledgedAt,
+			UpdatedAt:      r.UpdatedAt,
+			CreatedAt:      r.CreatedAt,
+		}
+		if r.Estimate > 0 {
+			saved[i].Estimate = r.Estimate.String()
+		}
+		if y := r.Yearly; !y.IsZero() {
+			saved[i].Yearly = &savedYearly{Month: int(y.Month), Day: y.Day, Since: y.Since, Before: y.Before}
+		}
+		if rel := r.Relative; !rel.IsZero() {
+			saved[i].Relative = &savedRelative{Tag: rel.Tag, Offset: rel.Offset.String()}
+		}
+		for _, session := range r.Sessions {
+			saved[i].Sessions = append(saved[i].Sessions, savedSession(session))
+		}
+	}
+
+	return json.MarshalIndent(stateFile{Version: stateVersion, Reminders: saved}, "", "  ")
+}
+
+// Unmarshal decodes reminders from the state file format, including
+// version 1 files. Reminders saved before IDs existed are given a
+// deterministic ID.
+func Unmarshal(data []byte) ([]*reminder.Reminder, error) {
+	var saved []savedReminder
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(data, &saved); err != nil {
+			return nil, err
+		}
+	} else {
+		var file stateFile
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, err
+		}
+		if file.Version > stateVersion {
+			return nil, fmt.Errorf("state file version %d is newer than this go_remind supports (%d); please upgrade", file.Version, stateVersion)
+		}
+		saved = file.Reminders
+	}
+
+	reminders := make([]*reminder.Reminder, len(saved))
+	for i, sr := range saved {
+		id := sr.ID
+		if id == "" {
+			id = reminder.FileID(sr.SourceFile, sr.Description)
+		}
+		priority, _ := reminder.ParsePriority(sr.Priority)
+		estimate, _ := time.ParseDuration(sr.Estimate)
+		effort, _ := reminder.ParseEffort(sr.Effort)
+		label, _ := reminder.ParseLabel(sr.Label)
+		reminders[i] = &reminder.Reminder{
+			ID:          id,
+			DateTime:    sr.DateTime,
+			Description: sr.Description,
+			Notes:       sr.Notes,
+			Tags:        sr.Tags,
+			SourceFile:  sr.SourceFile,
+			LineNumber:  sr.LineNumber,
+			Source:      sr.Source,
+			From:        sr.From,
+			Context:     sr.Context,
+			Status:      reminder.Status(sr.Status),
+			Priority:    priority,
+			Estimate:    estimate,
+			Effort:      effort,
+			Label:       label,
+
+			AcknowledgedAt: sr.AcknowledgedAt,
+			UpdatedAt:      sr.UpdatedAt,
+			CreatedAt:      sr.CreatedAt,
+		}
+		if y := sr.Yearly; y != nil {
+			reminders[i].Yearly = reminder.Yearly{Month: time.Month(y.Month), Day: y.Day, Since: y.Since, Before: y.Before}
+		}
+		if rel := sr.Relative; rel != nil {
+			offset, _ := time.ParseDuration(rel.Offset)
+			reminders[i].Relative = reminder.Relative{Tag: rel.Tag, Offset: offset}
+		}
+		for _, session := range sr.Sessions {
+			reminders[i].Sessions = append(reminders[i].Sessions, reminder.Session(session))
+		}
+	}
+
+	return reminders, nil
+}
+
+// savedStatus is a status saved by name. Version 1 files saved it as a number.
+type savedStatus reminder.Status
+
+// MarshalJSON writes the status name
+func (s savedStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(reminder.Status(s).Name())
+}
+
+// UnmarshalJSON reads a status name, or a number from a version 1 file
+func (s *savedStatus) UnmarshalJSON(data []byte) error {
+	var n int
+	if err := json.Unmarshal(data, &n); err == nil {
+		*s = savedStatus(n)
+		return nil
+	}
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	status, ok := reminder.ParseStatus(name)
+	if !ok {
+		return fmt.Errorf("unknown status %q", name)
+	}
+	*s = savedStatus(status)
+	return nil
+}
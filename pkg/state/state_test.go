@@ -0,0 +1,122 @@
+package state
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"go_remind/pkg/reminder"
+)
+
+func TestMarshalRoundTrip(t *testing.T) {
+	due := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	var reminders []*reminder.Reminder
+	for _, s := range []reminder.Status{reminder.Pending, reminder.Triggered, reminder.Acknowledged, reminder.Snoozed, reminder.Waiting, reminder.Someday} {
+		reminders = append(reminders, &reminder.Reminder{ID: s.Name(), DateTime: due, Description: s.Name(), Status: s})
+	}
+	reminders[0].Priority = reminder.PriorityHigh
+	reminders[1].Source = "github:me/repo"
+	reminders[2].From = "Ada <ada@example.com>"
+	reminders[2].Context = "Project X > Sprint 12"
+	reminders[2].SourceFile, reminders[2].LineNumber = "/notes/work.md", 12
+	reminders[2].Estimate = 90 * time.Minute
+	reminders[2].Effort = reminder.EffortHard
+	reminders[3].Label = reminder.LabelBlue
+	reminders[3].Notes = "Bring the printouts\n\n- agenda\n- budget"
+	reminders[3].Sessions = []reminder.Session{{Start: due, End: due.Add(time.Hour)}, {Start: due.Add(2 * time.Hour)}}
+	reminders[4].Yearly = reminder.Yearly{Month: time.March, Day: 14, Since: 1958, Before: 7}
+	reminders[5].Relative = reminder.Relative{Tag: "release", Offset: -48 * time.Hour}
+
+	data, err := Marshal(reminders)
+	if err != nil {
+		t.Fatalf("Marshal() error: %v", err)
+	}
+	if !strings.Contains(string(data), `"version": 2`) || !strings.Contains(string(data), `"status": "waiting"`) {
+		t.Errorf("Marshal() should write a version and status names:\n%s", data)
+	}
+
+	got, err := Unmarshal(data)
+	if err != nil {
+		t.Fatalf("Unmarshal() error: %v", err)
+	}
+	for i, r := range got {
+		if r.Status != reminders[i].Status {
+			t.Errorf("reminder %d status = %v, want %v", i, r.Status, reminders[i].Status)
+		}
+		if r.Priority != reminders[i].Priority {
+			t.Errorf("reminder %d priority = %v, want %v", i, r.Priority, reminders[i].Priority)
+		}
+		if r.Estimate != reminders[i].Estimate {
+			t.Errorf("reminder %d estimate = %v, want %v", i, r.Estimate, reminders[i].Estimate)
+		}
+		if r.Effort != reminders[i].Effort {
+			t.Errorf("reminder %d effort = %v, want %v", i, r.Effort, reminders[i].Effort)
+		}
+		if r.Label != reminders[i].Label {
+			t.Errorf("reminder %d label = %v, want %v", i, r.Label, reminders[i].Label)
+		}
+		if r.Notes != reminders[i].Notes {
+			t.Errorf("reminder %d notes = %q, want %q", i, r.Notes, reminders[i].Notes)
+		}
+		if r.Yearly != reminders[i].Yearly || r.Relative != reminders[i].Relative {
+			t.Errorf("reminder %d yearly %+v relative %+v, want %+v and %+v", i, r.Yearly, r.Relative, reminders[i].Yearly, reminders[i].Relative)
+		}
+		if r.Context != reminders[i].Context {
+			t.Errorf("reminder %d context = %q, want %q", i, r.Context, reminders[i].Context)
+		}
+		if r.SourceFile != reminders[i].SourceFile || r.LineNumber != reminders[i].LineNumber {
+			t.Errorf("reminder %d from %s:%d, want %s:%d", i, r.SourceFile, r.LineNumber, reminders[i].SourceFile, reminders[i].LineNumber)
+		}
+		if r.Source != reminders[i].Source || r.From != reminders[i].From {
+			t.Errorf("reminder %d source = %q from %q, want %q from %q", i, r.Source, r.From, reminders[i].Source, reminders[i].From)
+		}
+		if !slices.EqualFunc(r.Sessions, reminders[i].Sessions, func(a, b reminder.Session) bool {
+			return a.Start.Equal(b.Start) && a.End.Equal(b.End)
+		}) {
+			t.Errorf("reminder %d sessions = %v, want %v", i, r.Sessions, reminders[i].Sessions)
+		}
+	}
+}
+
+func TestUnmarshalVersion1(t *testing.T) {
+	data := `[
+  {"id": "a", "datetime": "2026-03-02T09:00:00Z", "description": "Old", "source_file": "notes.md", "status": 2},
+  {"datetime": "2026-03-03T09:00:00Z", "description": "No ID", "source_file": "notes.md", "status": 1}
+]`
+	got, err := Unmarshal([]byte(data))
+	if err != nil {
+		t.Fatalf("Unmarshal() error: %v", err)
+	}
+	if len(got) != 2 || got[0].Status != reminder.Acknowledged || got[1].Status != reminder.Triggered {
+		t.Fatalf("Unmarshal() = %+v, want acknowledged then triggered", got)
+	}
+	if got[1].ID != reminder.FileID("notes.md", "No ID") {
+		t.Errorf("missing ID = %q, want the file ID", got[1].ID)
+	}
+}
+
+func TestUnmarshalRejectsNewerVersion(t *testing.T) {
+	_, err := Unmarshal([]byte(`{"version": 99, "reminders": []}`))
+	if err == nil || !strings.Contains(err.Error(), "newer") {
+		t.Errorf("Unmarshal() error = %v, want a newer-version error", err)
+	}
+	if _, err := Unmarshal([]byte(`{"version": 2, "reminders": [{"status": "lost"}]}`)); err == nil {
+		t.Error("Unmarshal() should reject an unknown status name")
+	}
+}
+
+func TestSourceSettingsRoundTrip(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), stateFileName))
+	if settings, err := store.LoadSourceSettings(); err != nil || len(settings.Removed) != 0 {
+		t.Fatalf("LoadSourceSettings() before any save = %+v, %v; want none", settings, err)
+	}
+	if err := store.SaveSourceSettings(SourceSettings{Removed: []string{"feed:example.com/cfp.rss"}}); err != nil {
+		t.Fatalf("SaveSourceSettings() error: %v", err)
+	}
+	settings, err := store.LoadSourceSettings()
+	if err != nil || len(settings.Removed) != 1 || settings.Removed[0] != "feed:example.com/cfp.rss" {
+		t.Errorf("LoadSourceSettings() = %+v, %v", settings, err)
+	}
+}
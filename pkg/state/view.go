@@ -0,0 +1,52 @@
+package state
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+const viewFileName = "view.json"
+
+// ViewSettings are how the TUI last arranged the list, kept next to the
+// state file so the next session starts the same way
+type ViewSettings struct {
+	Sort          string `json:"sort,omitempty"`           // Name of the sort order
+	Group         string `json:"group,omitempty"`          // Name of what the sorted views are grouped by
+	Unsorted      bool   `json:"unsorted,omitempty"`       // Whether the sorted views were turned off
+	Layout        string `json:"layout,omitempty"`         // Name of the list layout
+	Theme         string `json:"theme,omitempty"`          // Name of the color theme
+	Filter        string `json:"filter,omitempty"`         // Text of the active filter
+	DateRange     string `json:"date_range,omitempty"`     // Name of the date range preset, if not any time
+	Selected      string `json:"selected,omitempty"`       // ID of the selected reminder
+	GridScroll    int    `json:"grid_scroll,omitempty"`    // First visible row of the card layout
+	CompactScroll int    `json:"compact_scroll,omitempty"` // First visible item of the compact layout
+}
+
+// LoadViewSettings reads the saved view settings. None saved yet is not an
+// error.
+func (s *Store) LoadViewSettings() (ViewSettings, error) {
+	var settings ViewSettings
+	data, err := os.ReadFile(s.viewPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return settings, nil
+		}
+		return settings, err
+	}
+	err = json.Unmarshal(data, &settings)
+	return settings, err
+}
+
+// SaveViewSettings saves the view settings
+func (s *Store) SaveViewSettings(settings ViewSettings) error {
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.viewPath(), data, 0644)
+}
+
+func (s *Store) viewPath() string {
+	return filepath.Join(filepath.Dir(s.path), viewFileName)
+}
@@ -0,0 +1,30 @@
+package watcher
+
+import "path/filepath"
+
+// DefaultIgnore are the names of the files and directories skipped unless
+// configured otherwise: hidden ones, which covers vim swap files, emacs lock
+// files, and folders like .git, then editor backups and temporary files
+var DefaultIgnore = []string{".*", "*~", "#*#", "*.swp", "*.tmp", "*___jb_*___"}
+
+// ignorePatterns are matched against the names of files and directories
+var ignorePatterns = DefaultIgnore
+
+// SetIgnore sets the glob patterns, matched against base names, of the
+// files and directories that are neither parsed nor watched. It should be
+// called once at startup, before any parsing.
+func SetIgnore(patterns []string) {
+	ignorePatterns = patterns
+}
+
+// Ignored reports whether the file or directory at path is skipped. Paths
+// given to watch directly are never skipped, even if their name matches.
+func Ignored(path string) bool {
+	name := filepath.Base(path)
+	for _, pattern := range ignorePatterns {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
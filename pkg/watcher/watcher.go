@@ -0,0 +1,536 @@
+// Package watcher parses a file or directory of notes and reports the
+// reminders in each file again whenever it changes.
+package watcher
+
+import (
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"go_remind/pkg/clock"
+	"go_remind/pkg/parser"
+	"go_remind/pkg/reminder"
+)
+
+const debounceDelay = 100 * time.Millisecond
+
+// maxFailures is how many failures a watcher remembers
+const maxFailures = 50
+
+// PollInterval is how often directories that couldn't be watched, past the
+// operating system's limit on watches, are checked for changes
+const PollInterval = 5 * time.Second
+
+// FileEvent is sent when files are updated with new reminders
+type FileEvent struct {
+	FilePath  string
+	Reminders []*reminder.Reminder
+	Err       error
+}
+
+// Failure is a path that couldn't be watched, or an error from the
+// operating system's file notifications
+type Failure struct {
+	Path string // Empty for errors not about one path
+	Err  string
+	At   time.Time
+}
+
+// Health is a snapshot of what a watcher is watching and what has gone
+// wrong since it started
+type Health struct {
+	Files     int
+	Dirs      int
+	Overflows int                  // Times events were lost because the kernel's queue filled
+	LastEvent map[string]time.Time // When each changed file last changed
+	Failures  []Failure            // Oldest first
+	Limited   bool                 // The operating system's limit on watches was reached
+	Polled    int                  // Directories checked every PollInterval since, instead of watched
+}
+
+// Watcher watches files/directories for changes and parses reminders. Only
+// directories are watched, which report changes to the files in them, so
+// a vault takes one watch per directory rather than per file.
+type Watcher struct {
+	fsWatcher *fsnotify.Watcher
+	Events    chan FileEvent
+	done      chan struct{}
+	clock     clock.Clock // Relative times in changed files are parsed against this
+	addWatch  func(path string) error
+	pollEvery time.Duration
+
+	// Debouncing
+	mu       sync.Mutex
+	pending  map[string]*time.Timer
+
+	// What's watched, also guarded by mu
+	dirs   map[string]bool // Watched or polled directories, true if all their markdown files are wanted
+	single map[string]bool // Files wanted from directories where not all are
+	files  map[string]bool // Markdown files known to be in the directories
+
+	// Health, also guarded by mu
+	lastEvent map[string]time.Time
+	overflows int
+	failures  []Failure
+	limited   bool
+	polled    map[string]map[string]time.Time // Directories past the limit, with their entries' mod times
+}
+
+// New creates a new Watcher
+func New() (*Watcher, error) {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Watcher{
+		fsWatcher: fsw,
+		Events:    make(chan FileEvent, 10),
+		done:      make(chan struct{}),
+		clock:     clock.Real,
+		addWatch:  fsw.Add,
+		pollEvery: PollInterval,
+		pending:   make(map[string]*time.Timer),
+		dirs:      make(map[string]bool),
+		single:    make(map[string]bool),
+		files:     make(map[string]bool),
+		lastEvent: make(map[string]time.Time),
+		polled:    make(map[string]map[string]time.Time),
+	}, nil
+}
+
+// SetClock sets the clock that relative times like +1h in changed files
+// are parsed against. Call it before Start.
+func (w *Watcher) SetClock(c clock.Clock) {
+	w.clock = c
+}
+
+// WatchFile watches a single file. Its directory is watched rather than
+// the file, so that editors that save by renaming a new file over it are
+// seen too.
+func (w *Watcher) WatchFile(path string) error {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(absPath); err != nil {
+		w.mu.Lock()
+		w.fail(absPath, err)
+		w.mu.Unlock()
+		return err
+	}
+	w.mu.Lock()
+	w.single[absPath] = true
+	w.files[absPath] = true
+	w.mu.Unlock()
+	return w.addDir(filepath.Dir(absPath), false)
+}
+
+// WatchDirectory watches a directory and every directory in it for changes
+// to markdown files
+func (w *Watcher) WatchDirectory(dir string) error {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	_, err = w.walk(absDir)
+	return err
+}
+
+// walk watches dir and the directories in it, and returns the markdown
+// files found
+func (w *Watcher) walk(dir string) ([]string, error) {
+	var found []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != dir && Ignored(path) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.IsDir() {
+			if err := w.addDir(path, true); err != nil {
+				log.Printf("Warning: could not watch directory %s: %v", path, err)
+			}
+		} else if filepath.Ext(path) == ".md" {
+			found = append(found, path)
+		}
+		return nil
+	})
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, path := range found {
+		w.files[path] = true
+	}
+	return found, err
+}
+
+// addDir watches the directory at path, recording it or why it couldn't be
+// watched. all says whether every markdown file in it is wanted, or only
+// those given to WatchFile. Past the limit on watches, the directory is
+// polled instead, so nothing is missed.
+func (w *Watcher) addDir(path string, all bool) error {
+	w.mu.Lock()
+	if wasAll, ok := w.dirs[path]; ok {
+		w.dirs[path] = wasAll || all
+		w.mu.Unlock()
+		return nil
+	}
+	w.mu.Unlock()
+
+	err := w.addWatch(path)
+	if isWatchLimit(err) {
+		times, _ := w.modTimes(path)
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		if !w.limited {
+			log.Printf("Warning: reached the limit on file watches; polling directories past it every %s", w.pollEvery)
+		}
+		w.limited = true
+		w.dirs[path] = all
+		w.polled[path] = times
+		return nil
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err != nil {
+		w.fail(path, err)
+	} else {
+		w.dirs[path] = all
+	}
+	return err
+}
+
+// wanted reports whether changes to the markdown file at path are
+// reported. Call it with mu held.
+func (w *Watcher) wanted(path string) bool {
+	return w.dirs[filepath.Dir(path)] || w.single[path]
+}
+
+// forget stops tracking a path that was removed or renamed away, and
+// everything in it if it was a directory
+func (w *Watcher) forget(path string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.files, path)
+	if _, ok := w.dirs[path]; !ok {
+		return
+	}
+	prefix := path + string(filepath.Separator)
+	for dir := range w.dirs {
+		if dir == path || strings.HasPrefix(dir, prefix) {
+			delete(w.dirs, dir)
+			delete(w.polled, dir)
+			w.fsWatcher.Remove(dir) // Fails harmlessly if already gone
+		}
+	}
+	for file := range w.files {
+		if strings.HasPrefix(file, prefix) {
+			delete(w.files, file)
+		}
+	}
+}
+
+// isWatchLimit reports whether err is from running out of watches: inotify
+// has a limit of its own, and kqueue needs an open file for each
+func isWatchLimit(err error) bool {
+	return errors.Is(err, syscall.ENOSPC) || errors.Is(err, syscall.EMFILE)
+}
+
+// skipped reports whether changes to path are ignored, which a file given
+// to WatchFile never is
+func (w *Watcher) skipped(path string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return !w.single[path] && Ignored(path)
+}
+
+// modTimes returns when each markdown file and directory in dir was last
+// modified
+func (w *Watcher) modTimes(dir string) (map[string]time.Time, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	times := make(map[string]time.Time, len(entries))
+	for _, e := range entries {
+		path := filepath.Join(dir, e.Name())
+		if !e.IsDir() && filepath.Ext(e.Name()) != ".md" || w.skipped(path) {
+			continue
+		}
+		if info, err := e.Info(); err == nil {
+			times[path] = info.ModTime()
+		}
+	}
+	return times, nil
+}
+
+// poll checks the polled directories every pollEvery until stopped
+func (w *Watcher) poll() {
+	ticker := time.NewTicker(w.pollEvery)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-ticker.C:
+			w.pollOnce()
+		}
+	}
+}
+
+// pollOnce compares each polled directory with when it was last checked,
+// reporting changed markdown files and watching new directories
+func (w *Watcher) pollOnce() {
+	w.mu.Lock()
+	dirs := make([]string, 0, len(w.polled))
+	for dir := range w.polled {
+		dirs = append(dirs, dir)
+	}
+	w.mu.Unlock()
+
+	for _, dir := range dirs {
+		times, err := w.modTimes(dir)
+		w.mu.Lock()
+		before := w.polled[dir]
+		if err != nil {
+			delete(w.polled, dir) // Gone, or unreadable since
+		} else {
+			w.polled[dir] = times
+		}
+		w.mu.Unlock()
+
+		for path, modTime := range times {
+			old, known := before[path]
+			switch {
+			case filepath.Ext(path) == ".md" && (!known || !old.Equal(modTime)):
+				w.changed(path)
+			case !known && filepath.Ext(path) != ".md":
+				w.newDirectory(path)
+			}
+		}
+		for path := range before {
+			if _, ok := times[path]; !ok && err == nil {
+				w.forget(path)
+			}
+		}
+	}
+}
+
+// fail records a failure. Call it with mu held.
+func (w *Watcher) fail(path string, err error) {
+	w.failures = append(w.failures, Failure{Path: path, Err: err.Error(), At: w.clock.Now()})
+	if len(w.failures) > maxFailures {
+		w.failures = w.failures[len(w.failures)-maxFailures:]
+	}
+}
+
+// Health returns what the watcher is watching and what has gone wrong
+func (w *Watcher) Health() Health {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	h := Health{
+		Files:     len(w.files),
+		Dirs:      len(w.dirs) - len(w.polled),
+		Overflows: w.overflows,
+		LastEvent: make(map[string]time.Time, len(w.lastEvent)),
+		Failures:  append([]Failure(nil), w.failures...),
+		Limited:   w.limited,
+		Polled:    len(w.polled),
+	}
+	for path, at := range w.lastEvent {
+		h.LastEvent[path] = at
+	}
+	return h
+}
+
+// Start begins watching for file changes
+func (w *Watcher) Start() {
+	go w.run()
+	go w.poll()
+}
+
+// Stop stops the watcher
+func (w *Watcher) Stop() {
+	close(w.done)
+	w.fsWatcher.Close()
+}
+
+func (w *Watcher) run() {
+	for {
+		select {
+		case <-w.done:
+			// Cancel any pending timers
+			w.mu.Lock()
+			for _, timer := range w.pending {
+				timer.Stop()
+			}
+			w.mu.Unlock()
+			return
+
+		case event, ok := <-w.fsWatcher.Events:
+			if !ok {
+				return
+			}
+			w.handle(event)
+
+		case err, ok := <-w.fsWatcher.Errors:
+			if !ok {
+				return
+			}
+			w.mu.Lock()
+			if errors.Is(err, fsnotify.ErrEventOverflow) {
+				w.overflows++
+			} else {
+				w.fail("", err)
+			}
+			w.mu.Unlock()
+			log.Printf("Watcher error: %v", err)
+		}
+	}
+}
+
+// handle acts on a change in a watched directory
+func (w *Watcher) handle(event fsnotify.Event) {
+	// A path renamed away is gone from here; its new name, if watched,
+	// arrives as a create
+	if event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename) {
+		w.forget(event.Name)
+		return
+	}
+	if w.skipped(event.Name) {
+		return
+	}
+	if event.Has(fsnotify.Create) {
+		if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+			w.newDirectory(event.Name)
+			return
+		}
+	}
+	if (event.Has(fsnotify.Write) || event.Has(fsnotify.Create)) && filepath.Ext(event.Name) == ".md" {
+		w.changed(event.Name)
+	}
+}
+
+// newDirectory watches a directory that appeared in a watched one, and
+// reports the notes already in it, e.g. when it was moved in. Directories
+// beside a single watched file are left alone.
+func (w *Watcher) newDirectory(dir string) {
+	w.mu.Lock()
+	all := w.dirs[filepath.Dir(dir)]
+	w.mu.Unlock()
+	if !all {
+		return
+	}
+	found, _ := w.walk(dir)
+	for _, path := range found {
+		w.changed(path)
+	}
+}
+
+// changed parses filePath again once it has stopped changing, reporting
+// its reminders on Events
+func (w *Watcher) changed(filePath string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.wanted(filePath) {
+		return
+	}
+	w.files[filePath] = true
+
+	// Debounce: reset timer for this file
+	if timer, exists := w.pending[filePath]; exists {
+		timer.Stop()
+	}
+	w.lastEvent[filePath] = w.clock.Now()
+	w.pending[filePath] = time.AfterFunc(debounceDelay, func() {
+		w.mu.Lock()
+		delete(w.pending, filePath)
+		w.mu.Unlock()
+
+		// Parse the file
+		reminders, err := parser.ParseFile(filePath, w.clock.Now())
+		if err != nil {
+			w.mu.Lock()
+			w.fail(filePath, err)
+			w.mu.Unlock()
+		}
+		w.Events <- FileEvent{
+			FilePath:  filePath,
+			Reminders: reminders,
+			Err:       err,
+		}
+	})
+}
+
+// ParseInitial parses a file or directory and returns initial reminders
+func ParseInitial(path string) ([]*reminder.Reminder, bool, error) {
+	return ParseInitialWithProgress(path, nil)
+}
+
+// ParseInitialWithProgress is ParseInitial, calling progress (if non-nil)
+// after each file is parsed with the number of files done and the total
+func ParseInitialWithProgress(path string, progress func(done, total int)) ([]*reminder.Reminder, bool, error) {
+	return ParseInitialAt(path, clock.Real.Now(), progress)
+}
+
+// ParseInitialAt is ParseInitialWithProgress with relative times parsed
+// against now
+func ParseInitialAt(path string, now time.Time, progress func(done, total int)) ([]*reminder.Reminder, bool, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, false, err
+	}
+
+	isDir := info.IsDir()
+
+	if !isDir {
+		reminders, err := parser.ParseFile(path, now)
+		return reminders, false, err
+	}
+
+	// It's a directory - find all .md files first so progress has a total
+	var files []string
+	err = filepath.Walk(path, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if filePath != path && Ignored(filePath) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.IsDir() && filepath.Ext(filePath) == ".md" {
+			files = append(files, filePath)
+		}
+		return nil
+	})
+
+	var allReminders []*reminder.Reminder
+	for i, filePath := range files {
+		reminders, parseErr := parser.ParseFile(filePath, now)
+		if parseErr != nil {
+			log.Printf("Warning: could not parse %s: %v", filePath, parseErr)
+		} else {
+			allReminders = append(allReminders, reminders...)
+		}
+		if progress != nil {
+			progress(i+1, len(files))
+		}
+	}
+
+	return allReminders, true, err
+}
@@ -3,10 +3,11 @@ package watcher
 import (
 	"os"
 	"path/filepath"
+	"syscall"
 	"testing"
 	"time"
 
-	"go_remind/reminder"
+	"go_remind/pkg/reminder"
 )
 
 func TestWatcherFileUpdates(t *testing.T) {
@@ -541,3 +542,258 @@ func TestWatcherRecursiveDirectories(t *testing.T) {
 		t.Errorf("Expected %d events, got %d", expectedEvents, receivedEvents)
 	}
 }
+
+func TestWatcherHealth(t *testing.T) {
+	tempDir := t.TempDir()
+	file := filepath.Join(tempDir, "notes.md")
+	if err := os.WriteFile(file, []byte("# Notes\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create watcher: %v", err)
+	}
+	defer w.Stop()
+	w.Start()
+	if err := w.WatchDirectory(tempDir); err != nil {
+		t.Fatalf("Failed to watch directory: %v", err)
+	}
+	missing := filepath.Join(tempDir, "missing.md")
+	if err := w.WatchFile(missing); err == nil {
+		t.Fatal("Expected an error watching a missing file")
+	}
+
+	h := w.Health()
+	if h.Files != 1 || h.Dirs != 1 {
+		t.Errorf("Watching %d files and %d dirs, want 1 and 1", h.Files, h.Dirs)
+	}
+	if len(h.Failures) != 1 || h.Failures[0].Path != missing {
+		t.Errorf("Failures = %+v, want one for %s", h.Failures, missing)
+	}
+
+	if err := os.WriteFile(file, []byte("[remind_me +1h Changed]"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-w.Events:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timeout waiting for file event")
+	}
+	if _, ok := w.Health().LastEvent[file]; !ok {
+		t.Errorf("LastEvent = %v, want an entry for %s", w.Health().LastEvent, file)
+	}
+}
+
+func TestWatcherPastWatchLimit(t *testing.T) {
+	tempDir := t.TempDir()
+	sub := filepath.Join(tempDir, "archive")
+	if err := os.Mkdir(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create watcher: %v", err)
+	}
+	defer w.Stop()
+	// The subdirectory is one watch too many
+	w.addWatch = func(path string) error {
+		if path == sub {
+			return syscall.ENOSPC
+		}
+		return w.fsWatcher.Add(path)
+	}
+	w.pollEvery = 20 * time.Millisecond
+	w.Start()
+	if err := w.WatchDirectory(tempDir); err != nil {
+		t.Fatalf("Failed to watch directory: %v", err)
+	}
+
+	h := w.Health()
+	if !h.Limited || h.Polled != 1 || len(h.Failures) != 0 {
+		t.Errorf("Limited %v, polling %d, failures %v; want limited, polling 1, no failures", h.Limited, h.Polled, h.Failures)
+	}
+
+	// Changes in the polled directory are still seen
+	file := filepath.Join(sub, "old.md")
+	if err := os.WriteFile(file, []byte("[remind_me +1h Polled]"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case event := <-w.Events:
+		if event.FilePath != file || len(event.Reminders) != 1 {
+			t.Errorf("Got %d reminders from %s, want 1 from %s", len(event.Reminders), event.FilePath, file)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timeout waiting for a polled file event")
+	}
+}
+
+func TestWatcherWatchesOnlyDirectories(t *testing.T) {
+	tempDir := t.TempDir()
+	for _, dir := range []string{"work", "home"} {
+		if err := os.Mkdir(filepath.Join(tempDir, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+		for _, name := range []string{"a.md", "b.md"} {
+			if err := os.WriteFile(filepath.Join(tempDir, dir, name), []byte("# Notes\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	w, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create watcher: %v", err)
+	}
+	defer w.Stop()
+	var watched []string
+	w.addWatch = func(path string) error {
+		watched = append(watched, path)
+		return w.fsWatcher.Add(path)
+	}
+	w.Start()
+	if err := w.WatchDirectory(tempDir); err != nil {
+		t.Fatalf("Failed to watch directory: %v", err)
+	}
+	for _, path := range watched {
+		if info, err := os.Stat(path); err != nil || !info.IsDir() {
+			t.Errorf("Watched %s, want only directories", path)
+		}
+	}
+	if h := w.Health(); h.Dirs != 3 || h.Files != 4 || len(watched) != 3 {
+		t.Errorf("Watching %d dirs with %d watches, knowing %d files; want 3, 3, and 4", h.Dirs, len(watched), h.Files)
+	}
+
+	// A directory of notes moved in is watched, and its notes reported
+	moved := filepath.Join(t.TempDir(), "trip")
+	if err := os.Mkdir(moved, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(moved, "pack.md"), []byte("[remind_me +1h Pack]"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	dest := filepath.Join(tempDir, "trip")
+	if err := os.Rename(moved, dest); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case event := <-w.Events:
+		if event.FilePath != filepath.Join(dest, "pack.md") || len(event.Reminders) != 1 {
+			t.Errorf("Got %d reminders from %s, want 1 from the moved note", len(event.Reminders), event.FilePath)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timeout waiting for the moved note")
+	}
+
+	// Removing a directory forgets it and its notes
+	if err := os.RemoveAll(filepath.Join(tempDir, "home")); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for h := w.Health(); h.Dirs != 3 || h.Files != 3; h = w.Health() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Watching %d dirs and %d files after removing one, want 3 and 3", h.Dirs, h.Files)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestIgnoredFiles(t *testing.T) {
+	tempDir := t.TempDir()
+	files := map[string]string{
+		"notes.md":       "[remind_me +1h Real]",
+		".hidden.md":     "[remind_me +1h Hidden]",
+		".#notes.md":     "[remind_me +1h Lock file]",
+		".git/stash.md":  "[remind_me +1h In .git]",
+		"drafts/idea.md": "[remind_me +1h Idea]",
+	}
+	for name, content := range files {
+		path := filepath.Join(tempDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	reminders, _, err := ParseInitial(tempDir)
+	if err != nil {
+		t.Fatalf("ParseInitial: %v", err)
+	}
+	if len(reminders) != 2 {
+		t.Errorf("Got %d reminders, want only Real and Idea", len(reminders))
+	}
+
+	// With no patterns, everything is parsed
+	SetIgnore(nil)
+	if reminders, _, _ := ParseInitial(tempDir); len(reminders) != 5 {
+		t.Errorf("Got %d reminders with nothing ignored, want 5", len(reminders))
+	}
+	SetIgnore(DefaultIgnore)
+
+	// An editor's temporary file changing is not an event
+	w, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create watcher: %v", err)
+	}
+	defer w.Stop()
+	w.Start()
+	if err := w.WatchDirectory(tempDir); err != nil {
+		t.Fatalf("Failed to watch directory: %v", err)
+	}
+	if h := w.Health(); h.Dirs != 2 || h.Files != 2 {
+		t.Errorf("Watching %d dirs and %d files, want 2 and 2", h.Dirs, h.Files)
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, ".notes.md.swp.md"), []byte("[remind_me +1h Swap]"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	notes := filepath.Join(tempDir, "notes.md")
+	if err := os.WriteFile(notes, []byte("[remind_me +2h Real]"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case event := <-w.Events:
+		if event.FilePath != notes {
+			t.Errorf("Got an event for %s, want only %s", event.FilePath, notes)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timeout waiting for file event")
+	}
+}
+
+func TestWatchIgnoredFileDirectly(t *testing.T) {
+	// A file watched by name is watched even though its name is ignored
+	path := filepath.Join(t.TempDir(), ".todo.md")
+	if err := os.WriteFile(path, []byte("[remind_me +1h One]"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if reminders, _, err := ParseInitial(path); err != nil || len(reminders) != 1 {
+		t.Fatalf("ParseInitial(%s) = %d reminders, %v; want 1", path, len(reminders), err)
+	}
+
+	w, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create watcher: %v", err)
+	}
+	defer w.Stop()
+	if err := w.WatchFile(path); err != nil {
+		t.Fatalf("Failed to watch file: %v", err)
+	}
+	w.Start()
+	time.Sleep(100 * time.Millisecond)
+
+	if err := os.WriteFile(path, []byte("[remind_me +1h One]\n[remind_me +2h Two]"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case event := <-w.Events:
+		if event.FilePath != path || len(event.Reminders) != 2 {
+			t.Errorf("Got %d reminders from %s, want 2 from %s", len(event.Reminders), event.FilePath, path)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timeout waiting for an event for the directly watched file")
+	}
+}
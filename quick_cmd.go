@@ -0,0 +1,51 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"go_remind/config"
+	"go_remind/pkg/reminder"
+	"go_remind/pkg/state"
+	"go_remind/tui"
+)
+
+// runQuick runs `go_remind quick`: a single add box with a live preview,
+// meant for a tmux popup or dropdown terminal. The reminder is saved as
+// soon as it's entered, and go_remind exits.
+func runQuick(store *state.Store, cfg *config.Config, args []string) {
+	fs := flag.NewFlagSet("quick", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: go_remind quick")
+		fmt.Fprintln(os.Stderr, "Opens just the add box; enter saves the reminder and exits, esc cancels.")
+		fmt.Fprintln(os.Stderr, `For example, in tmux: bind r display-popup -E -w 80 -h 6 "go_remind quick"`)
+	}
+	fs.Parse(args)
+	if fs.NArg() > 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	if store == nil {
+		fmt.Fprintln(os.Stderr, "Error: no state store to add to")
+		os.Exit(1)
+	}
+
+	final, err := tea.NewProgram(tui.NewQuickCapture(cfg)).Run()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	r := final.(tui.QuickCapture).Reminder()
+	if r == nil {
+		return // Cancelled
+	}
+	if _, err := addReminders(store, []*reminder.Reminder{r}); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Added: %s, due %s (%s)\n", r.Description, r.DateTime.Format("Mon Jan 2 at 3:04 PM"), untilDue(r.DateTime, time.Now()))
+}
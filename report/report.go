@@ -0,0 +1,187 @@
+// Package report summarizes a week of reminders for a weekly review: what
+// was completed and added, what's overdue and carried over, and which tags
+// were busiest.
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go_remind/pkg/reminder"
+)
+
+// Period is how far back a report looks
+const Period = 7 * 24 * time.Hour
+
+// busiestTags is how many tags a report lists
+const busiestTags = 5
+
+// TagCount is how many of the week's reminders had a tag
+type TagCount struct {
+	Tag   string
+	Count int
+}
+
+// Tracked is the time worked on a reminder during the week, with its timer
+type Tracked struct {
+	Reminder *reminder.Reminder
+	Spent    time.Duration
+}
+
+// Report summarizes the reminders of the week before End
+type Report struct {
+	Start, End  time.Time
+	Completed   []*reminder.Reminder // Acknowledged during the week
+	Added       []*reminder.Reminder // Created during the week
+	CarriedOver []*reminder.Reminder // Open and overdue at the end of it
+	Tags        []TagCount           // Busiest first
+	Tracked     []Tracked            // Most time first
+}
+
+// TimeTracked is the total time worked on reminders during the week
+func (r Report) TimeTracked() time.Duration {
+	var total time.Duration
+	for _, t := range r.Tracked {
+		total += t.Spent
+	}
+	return total
+}
+
+// Build computes the report for the week before now
+func Build(reminders []*reminder.Reminder, now time.Time) Report {
+	r := Report{Start: now.Add(-Period), End: now}
+	during := func(t time.Time) bool { return !t.Before(r.Start) && t.Before(r.End) }
+
+	counts := map[string]int{}
+	for _, rem := range reminders {
+		active := false
+		if rem.Status == reminder.Acknowledged && during(rem.AcknowledgedAt) {
+			r.Completed = append(r.Completed, rem)
+			active = true
+		}
+		if during(rem.CreatedAt) {
+			r.Added = append(r.Added, rem)
+			active = true
+		}
+		if rem.Status != reminder.Acknowledged && !rem.Status.Parked() && rem.IsDueAt(now) {
+			r.CarriedOver = append(r.CarriedOver, rem)
+			active = true
+		}
+		if spent := rem.TimeSpentBetween(r.Start, r.End); spent > 0 {
+			r.Tracked = append(r.Tracked, Tracked{Reminder: rem, Spent: spent})
+			active = true
+		}
+		if active || during(rem.DateTime) {
+			for _, tag := range rem.Tags {
+				counts[strings.ToLower(tag)]++
+			}
+		}
+	}
+
+	sort.SliceStable(r.Completed, func(i, j int) bool { return r.Completed[i].AcknowledgedAt.Before(r.Completed[j].AcknowledgedAt) })
+	sort.SliceStable(r.Added, func(i, j int) bool { return r.Added[i].CreatedAt.Before(r.Added[j].CreatedAt) })
+	reminder.SortByDateTime(r.CarriedOver)
+	sort.SliceStable(r.Tracked, func(i, j int) bool { return r.Tracked[i].Spent > r.Tracked[j].Spent })
+
+	for tag, n := range counts {
+		r.Tags = append(r.Tags, TagCount{Tag: tag, Count: n})
+	}
+	sort.Slice(r.Tags, func(i, j int) bool {
+		if r.Tags[i].Count != r.Tags[j].Count {
+			return r.Tags[i].Count > r.Tags[j].Count
+		}
+		return r.Tags[i].Tag < r.Tags[j].Tag
+	})
+	if len(r.Tags) > busiestTags {
+		r.Tags = r.Tags[:busiestTags]
+	}
+	return r
+}
+
+// Title names the week the report covers
+func (r Report) Title() string {
+	return fmt.Sprintf("Weekly report: %s – %s", r.Start.Format("Jan 2"), r.End.Format("Jan 2, 2006"))
+}
+
+// Summary returns a one-line summary, for an email subject or notification
+func (r Report) Summary() string {
+	s := fmt.Sprintf("%d completed, %d added, %d overdue carried over", len(r.Completed), len(r.Added), len(r.CarriedOver))
+	if total := r.TimeTracked(); total > 0 {
+		s += ", " + formatSpent(total) + " tracked"
+	}
+	return s
+}
+
+// Markdown renders the report as a markdown document
+func (r Report) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n%s\n", r.Title(), r.Summary())
+
+	list := func(title string, reminders []*reminder.Reminder, when func(*reminder.Reminder) string) {
+		fmt.Fprintf(&b, "\n## %s (%d)\n\n", title, len(reminders))
+		if len(reminders) == 0 {
+			b.WriteString("None.\n")
+			return
+		}
+		for _, rem := range reminders {
+			fmt.Fprintf(&b, "- %s — %s%s\n", when(rem), rem.Description, tagList(rem.Tags))
+		}
+	}
+	list("Completed", r.Completed, func(rem *reminder.Reminder) string {
+		return rem.AcknowledgedAt.Format("Mon Jan 2")
+	})
+	list("Added", r.Added, func(rem *reminder.Reminder) string {
+		return "due " + rem.DateTime.Format("Mon Jan 2")
+	})
+	list("Overdue, carried over", r.CarriedOver, func(rem *reminder.Reminder) string {
+		return "due " + rem.DateTime.Format("Mon Jan 2")
+	})
+
+	if len(r.Tracked) > 0 {
+		fmt.Fprintf(&b, "\n## Time tracked (%s)\n\n", formatSpent(r.TimeTracked()))
+		for _, t := range r.Tracked {
+			fmt.Fprintf(&b, "- %s — %s%s\n", formatSpent(t.Spent), t.Reminder.Description, tagList(t.Reminder.Tags))
+		}
+	}
+
+	b.WriteString("\n## Busiest tags\n\n")
+	if len(r.Tags) == 0 {
+		b.WriteString("None.\n")
+		return b.String()
+	}
+	b.WriteString("| Tag | Reminders |\n|---|---|\n")
+	for _, t := range r.Tags {
+		fmt.Fprintf(&b, "| #%s | %d |\n", t.Tag, t.Count)
+	}
+	return b.String()
+}
+
+// tagList renders tags after a description, e.g. " #work #q1"
+func tagList(tags []string) string {
+	var s string
+	for _, tag := range tags {
+		s += " #" + tag
+	}
+	return s
+}
+
+// formatSpent formats time worked, e.g. "45m" or "3h 20m"
+func formatSpent(d time.Duration) string {
+	if d < time.Hour {
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// NextTime returns the first time strictly after now that falls on day at
+// hour:minute
+func NextTime(now time.Time, day time.Weekday, hour, minute int) time.Time {
+	next := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, now.Location())
+	next = next.AddDate(0, 0, (int(day)-int(next.Weekday())+7)%7)
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 7)
+	}
+	return next
+}
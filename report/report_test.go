@@ -0,0 +1,91 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"go_remind/pkg/reminder"
+)
+
+func TestBuild(t *testing.T) {
+	// Monday, March 9, 2026 at 8:00am
+	now := time.Date(2026, 3, 9, 8, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 10, 0, 0, 0, time.UTC) }
+
+	shipped := &reminder.Reminder{Description: "Ship release", Tags: []string{"work"}, DateTime: day(4), Status: reminder.Acknowledged, AcknowledgedAt: day(4), CreatedAt: day(1),
+		Sessions: []reminder.Session{{Start: day(2).Add(-3 * time.Hour), End: day(2).Add(-time.Hour)}, {Start: day(3), End: day(3).Add(90 * time.Minute)}}}
+	review := &reminder.Reminder{Description: "Review PR", Tags: []string{"Work"}, DateTime: day(12), Status: reminder.Pending, CreatedAt: day(5)}
+	expenses := &reminder.Reminder{Description: "Expenses", Tags: []string{"admin"}, DateTime: day(6), Status: reminder.Triggered, CreatedAt: day(1)}
+	reminders := []*reminder.Reminder{
+		shipped, review, expenses,
+		{Description: "Old and done", Tags: []string{"home"}, DateTime: day(1), Status: reminder.Acknowledged, AcknowledgedAt: day(1)},
+		{Description: "Someday", Tags: []string{"home"}, DateTime: day(3), Status: reminder.Someday, CreatedAt: day(1)},
+	}
+
+	r := Build(reminders, now)
+	if len(r.Completed) != 1 || r.Completed[0] != shipped {
+		t.Errorf("Completed = %v, want [Ship release]", r.Completed)
+	}
+	if len(r.Added) != 1 || r.Added[0] != review {
+		t.Errorf("Added = %v, want [Review PR]", r.Added)
+	}
+	if len(r.CarriedOver) != 1 || r.CarriedOver[0] != expenses {
+		t.Errorf("CarriedOver = %v, want [Expenses]", r.CarriedOver)
+	}
+	// Tags count case-insensitively; the someday reminder was due during
+	// the week, the old one wasn't
+	want := []TagCount{{"work", 2}, {"admin", 1}, {"home", 1}}
+	if len(r.Tags) != len(want) {
+		t.Fatalf("Tags = %v, want %v", r.Tags, want)
+	}
+	for i := range want {
+		if r.Tags[i] != want[i] {
+			t.Errorf("Tags = %v, want %v", r.Tags, want)
+			break
+		}
+	}
+
+	// Only the hour of the first session inside the week counts
+	if len(r.Tracked) != 1 || r.Tracked[0].Spent != 150*time.Minute {
+		t.Errorf("Tracked = %v, want 2h 30m on Ship release", r.Tracked)
+	}
+
+	md := r.Markdown()
+	for _, line := range []string{
+		"# Weekly report: Mar 2 – Mar 9, 2026",
+		"1 completed, 1 added, 1 overdue carried over, 2h 30m tracked",
+		"## Time tracked (2h 30m)",
+		"- 2h 30m — Ship release #work",
+		"- Wed Mar 4 — Ship release #work",
+		"- due Thu Mar 12 — Review PR #Work",
+		"## Overdue, carried over (1)",
+		"| #work | 2 |",
+	} {
+		if !strings.Contains(md, line+"\n") {
+			t.Errorf("Markdown() missing %q:\n%s", line, md)
+		}
+	}
+}
+
+func TestNextTime(t *testing.T) {
+	// Wednesday, March 4, 2026
+	now := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		day        time.Weekday
+		hour, want int // want is the day of March
+	}{
+		{time.Monday, 8, 9},
+		{time.Wednesday, 13, 4}, // Later today
+		{time.Wednesday, 12, 11},
+		{time.Wednesday, 8, 11},
+		{time.Friday, 8, 6},
+	}
+	for _, tt := range tests {
+		got := NextTime(now, tt.day, tt.hour, 0)
+		want := time.Date(2026, 3, tt.want, tt.hour, 0, 0, 0, time.UTC)
+		if !got.Equal(want) {
+			t.Errorf("NextTime(%s %d:00) = %v, want %v", tt.day, tt.hour, got, want)
+		}
+	}
+}
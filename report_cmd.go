@@ -0,0 +1,104 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go_remind/config"
+	"go_remind/notify"
+	"go_remind/pkg/reminder"
+	"go_remind/pkg/state"
+	"go_remind/report"
+)
+
+// runReport runs `go_remind report`: prints a markdown summary of the past
+// week, and emails it with --email
+func runReport(store *state.Store, cfg *config.Config, args []string) {
+	fs := flag.NewFlagSet("report", flag.ExitOnError)
+	email := fs.Bool("email", false, "Also email the report, through the [email] settings")
+	out := fs.String("o", "", "Write to this file instead of stdout")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: go_remind report [--email] [-o file]")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() > 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	if *email && (cfg.Email.Server == "" || len(cfg.Email.To) == 0) {
+		fmt.Fprintln(os.Stderr, "Error: set server and to in the [email] section of the config to email the report")
+		os.Exit(2)
+	}
+
+	var reminders []*reminder.Reminder
+	if store != nil {
+		var err error
+		if reminders, err = store.Load(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: could not load state: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	r := report.Build(reminders, time.Now())
+	if *out == "" {
+		fmt.Print(r.Markdown())
+	} else if err := os.WriteFile(*out, []byte(r.Markdown()), 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if *email {
+		if err := mailer(cfg.Email).Mail(r.Title(), r.Markdown()); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: could not email the report: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "Emailed the report to %s\n", cfg.Email.To[0])
+	}
+}
+
+// mailer returns the mailer for the [email] settings
+func mailer(c config.EmailConfig) notify.Mailer {
+	password := c.Password
+	if password == "" {
+		password = os.Getenv("GO_REMIND_SMTP_PASSWORD")
+	}
+	return notify.Mailer{Server: c.Server, Username: c.Username, Password: password, From: c.From, To: c.To}
+}
+
+// scheduleReports sends the weekly report at the configured day and time,
+// forever, reading the reminders as they are then. Failures are logged
+// and the next week's report is tried anyway.
+func scheduleReports(cfg *config.Config, reminders func() []*reminder.Reminder) {
+	day, err := cfg.Report.Weekday()
+	if err != nil {
+		return
+	}
+	hour, minute, err := config.ParseClock(cfg.Report.Time)
+	if err != nil {
+		return
+	}
+	for {
+		next := report.NextTime(time.Now(), day, hour, minute)
+		time.Sleep(time.Until(next))
+
+		r := report.Build(reminders(), time.Now())
+		if cfg.Report.Dir != "" {
+			dir := config.ExpandPath(cfg.Report.Dir)
+			path := filepath.Join(dir, "report-"+r.End.Format("2006-01-02")+".md")
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				log.Printf("Warning: could not save the weekly report: %v", err)
+			} else if err := os.WriteFile(path, []byte(r.Markdown()), 0644); err != nil {
+				log.Printf("Warning: could not save the weekly report: %v", err)
+			}
+		}
+		if cfg.Report.Email {
+			if err := mailer(cfg.Email).Mail(r.Title(), r.Markdown()); err != nil {
+				log.Printf("Warning: could not email the weekly report: %v", err)
+			}
+		}
+	}
+}
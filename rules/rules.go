@@ -0,0 +1,201 @@
+// Package rules runs a user's Starlark script over parsed reminders, so
+// custom tagging, priority, and filtering rules can be written without
+// changing go_remind.
+//
+// The script defines a function named rule that is called with each
+// reminder as it is parsed:
+//
+//	def rule(r):
+//	    if "deploy" in r.description.lower():
+//	        r.tags = r.tags + ["infra"]
+//	        r.priority = "high"
+//	    if "lunch" in r.description:
+//	        return False  # drop the reminder
+//
+// Returning False leaves the reminder out; any other result keeps it.
+package rules
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"go.starlark.net/starlark"
+
+	"go_remind/pkg/reminder"
+)
+
+// ruleFunc is the name of the function every script must define
+const ruleFunc = "rule"
+
+// Engine evaluates a loaded rules script
+type Engine struct {
+	path string
+	rule starlark.Callable
+}
+
+// Load reads and runs the script at path, which must define a rule function
+func Load(path string) (*Engine, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return compile(path, src)
+}
+
+func compile(path string, src []byte) (*Engine, error) {
+	thread := &starlark.Thread{Name: "load " + path}
+	globals, err := starlark.ExecFile(thread, path, src, nil)
+	if err != nil {
+		return nil, fmt.Errorf("rules script: %w", err)
+	}
+	globals.Freeze()
+
+	fn, ok := globals[ruleFunc].(starlark.Callable)
+	if !ok {
+		return nil, fmt.Errorf("rules script %s: no %s(r) function", path, ruleFunc)
+	}
+	return &Engine{path: path, rule: fn}, nil
+}
+
+// Path returns the file the script was loaded from
+func (e *Engine) Path() string {
+	return e.path
+}
+
+// Apply runs the rule over each reminder, updating it in place, and returns
+// the ones the rule kept. A reminder the rule fails on is kept unchanged,
+// and the first such error is returned once every reminder has been seen.
+// A nil Engine keeps everything.
+func (e *Engine) Apply(reminders []*reminder.Reminder) ([]*reminder.Reminder, error) {
+	if e == nil {
+		return reminders, nil
+	}
+
+	thread := &starlark.Thread{Name: "rules"}
+	var firstErr error
+	kept := make([]*reminder.Reminder, 0, len(reminders))
+	for _, r := range reminders {
+		keep, err := e.applyOne(thread, r)
+		if err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("%s: %w", r.Description, err)
+		}
+		if keep {
+			kept = append(kept, r)
+		}
+	}
+	return kept, firstErr
+}
+
+// applyOne runs the rule on a copy of r, so a rule that fails halfway
+// leaves r as it was
+func (e *Engine) applyOne(thread *starlark.Thread, r *reminder.Reminder) (bool, error) {
+	v := newValue(r)
+	result, err := starlark.Call(thread, e.rule, starlark.Tuple{v}, nil)
+	if err != nil {
+		if evalErr, ok := err.(*starlark.EvalError); ok {
+			return true, fmt.Errorf("%s", evalErr.Backtrace())
+		}
+		return true, err
+	}
+	v.apply(r)
+	return result != starlark.False, nil
+}
+
+// value is the reminder as the script sees it. description, tags, and
+// priority can be set; the rest are read-only.
+type value struct {
+	r           *reminder.Reminder
+	description string
+	tags        []string
+	priority    reminder.Priority
+}
+
+var _ starlark.HasSetField = (*value)(nil)
+
+func newValue(r *reminder.Reminder) *value {
+	return &value{
+		r:           r,
+		description: r.Description,
+		tags:        append([]string(nil), r.Tags...),
+		priority:    r.Priority,
+	}
+}
+
+// apply copies the script's changes back to the reminder
+func (v *value) apply(r *reminder.Reminder) {
+	r.Description = v.description
+	r.Tags = v.tags
+	r.Priority = v.priority
+}
+
+func (v *value) String() string        { return fmt.Sprintf("reminder(%q)", v.description) }
+func (v *value) Type() string          { return "reminder" }
+func (v *value) Freeze()               {}
+func (v *value) Truth() starlark.Bool  { return starlark.True }
+func (v *value) Hash() (uint32, error) { return 0, fmt.Errorf("unhashable type: reminder") }
+
+func (v *value) AttrNames() []string {
+	return []string{"description", "due", "priority", "source", "status", "tags"}
+}
+
+func (v *value) Attr(name string) (starlark.Value, error) {
+	switch name {
+	case "description":
+		return starlark.String(v.description), nil
+	case "tags":
+		list := make([]starlark.Value, len(v.tags))
+		for i, t := range v.tags {
+			list[i] = starlark.String(t)
+		}
+		return starlark.NewList(list), nil
+	case "priority":
+		return starlark.String(v.priority.String()), nil
+	case "due":
+		return starlark.String(v.r.DateTime.Format(time.RFC3339)), nil
+	case "source":
+		return starlark.String(v.r.SourceFile), nil
+	case "status":
+		return starlark.String(strings.ToLower(v.r.Status.String())), nil
+	}
+	return nil, nil
+}
+
+func (v *value) SetField(name string, val starlark.Value) error {
+	switch name {
+	case "description":
+		s, ok := starlark.AsString(val)
+		if !ok || s == "" {
+			return fmt.Errorf("description must be a non-empty string, got %s", val.Type())
+		}
+		v.description = s
+	case "tags":
+		iter, ok := val.(starlark.Iterable)
+		if !ok {
+			return fmt.Errorf("tags must be a list of strings, got %s", val.Type())
+		}
+		var tags []string
+		it := iter.Iterate()
+		defer it.Done()
+		var x starlark.Value
+		for it.Next(&x) {
+			s, ok := starlark.AsString(x)
+			if !ok {
+				return fmt.Errorf("tags must be a list of strings, got %s in it", x.Type())
+			}
+			tags = append(tags, s)
+		}
+		v.tags = tags
+	case "priority":
+		s, _ := starlark.AsString(val)
+		p, ok := reminder.ParsePriority(s)
+		if !ok {
+			return fmt.Errorf("priority must be \"low\", \"medium\", \"high\", or \"\", got %s", val.String())
+		}
+		v.priority = p
+	default:
+		return starlark.NoSuchAttrError(fmt.Sprintf("reminder has no settable field .%s", name))
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package rules
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"go_remind/pkg/reminder"
+)
+
+const testScript = `
+def rule(r):
+    if "deploy" in r.description.lower():
+        r.tags = r.tags + ["infra"]
+        r.priority = "high"
+    if r.description.startswith("lunch"):
+        return False
+`
+
+func TestApply(t *testing.T) {
+	e, err := compile("test.star", []byte(testScript))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.Local)
+	deploy := &reminder.Reminder{DateTime: now, Description: "Deploy the API", Tags: []string{"work"}}
+	lunch := &reminder.Reminder{DateTime: now, Description: "lunch with Sam"}
+	other := &reminder.Reminder{DateTime: now, Description: "Water plants"}
+
+	kept, err := e.Apply([]*reminder.Reminder{deploy, lunch, other})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(kept) != 2 || kept[0] != deploy || kept[1] != other {
+		t.Fatalf("Apply() kept %d reminders, want deploy and other", len(kept))
+	}
+	if len(deploy.Tags) != 2 || deploy.Tags[1] != "infra" {
+		t.Errorf("tags = %v, want [work infra]", deploy.Tags)
+	}
+	if deploy.Priority != reminder.PriorityHigh {
+		t.Errorf("priority = %v, want high", deploy.Priority)
+	}
+	if other.Priority != reminder.PriorityNone || len(other.Tags) != 0 {
+		t.Errorf("untouched reminder changed: priority %v, tags %v", other.Priority, other.Tags)
+	}
+}
+
+func TestApplyError(t *testing.T) {
+	e, err := compile("test.star", []byte(`
+def rule(r):
+    r.tags = ["seen"]
+    r.priority = "urgent"
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := &reminder.Reminder{Description: "Call mom"}
+	kept, err := e.Apply([]*reminder.Reminder{r})
+	if err == nil || !strings.Contains(err.Error(), "priority") {
+		t.Fatalf("Apply() error = %v, want a priority error", err)
+	}
+	if len(kept) != 1 || len(r.Tags) != 0 {
+		t.Errorf("failed rule should keep the reminder unchanged, got %d kept, tags %v", len(kept), r.Tags)
+	}
+}
+
+func TestCompileWithoutRule(t *testing.T) {
+	if _, err := compile("test.star", []byte("x = 1\n")); err == nil {
+		t.Error("compile() should fail without a rule function")
+	}
+	var e *Engine
+	if kept, err := e.Apply([]*reminder.Reminder{{Description: "a"}}); err != nil || len(kept) != 1 {
+		t.Errorf("nil Engine Apply() = %d, %v; want everything kept", len(kept), err)
+	}
+}
@@ -0,0 +1,222 @@
+package sources
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+
+	"go_remind/config"
+	"go_remind/pkg/clock"
+	"go_remind/pkg/reminder"
+)
+
+const (
+	// maxFeedSize bounds how much of a feed is read
+	maxFeedSize = 10 << 20
+	// maxFeedAge is how long after its date an entry is still pulled in,
+	// so a passed deadline stays until it's acknowledged
+	maxFeedAge = 30 * 24 * time.Hour
+)
+
+// Feed pulls the dated entries of an RSS or Atom feed
+type Feed struct {
+	URL       string
+	Tag       string
+	Every     time.Duration // How often to refresh
+	Published bool          // Fall back to an entry's publication date
+	DayStart  time.Duration // Time of day an entry with only a date is due
+	Clock     clock.Clock
+	Client    *http.Client
+}
+
+// NewFeed creates a feed source from its config
+func NewFeed(cfg config.FeedSourceConfig, dayStart time.Duration) *Feed {
+	return &Feed{
+		URL:       cfg.URL,
+		Tag:       firstNonEmpty(cfg.Tag, "feed"),
+		Every:     cfg.RefreshInterval(),
+		Published: cfg.Published,
+		DayStart:  dayStart,
+		Clock:     clock.Real,
+		Client:    &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// Name returns "feed:" and the feed's URL without its scheme
+func (f *Feed) Name() string {
+	u := strings.TrimPrefix(strings.TrimPrefix(f.URL, "https://"), "http://")
+	return "feed:" + u
+}
+
+// Interval returns how often the feed is refreshed
+func (f *Feed) Interval() time.Duration {
+	return f.Every
+}
+
+// feedDoc is the part of an RSS 2.0, RSS 1.0, or Atom document that's read
+type feedDoc struct {
+	Channel struct {
+		Items []feedEntry `xml:"item"`
+	} `xml:"channel"`
+	Items   []feedEntry `xml:"item"`  // RSS 1.0 puts items beside the channel
+	Entries []feedEntry `xml:"entry"` // Atom
+}
+
+// feedEntry is an RSS item or Atom entry
+type feedEntry struct {
+	Title     string     `xml:"title"`
+	GUID      string     `xml:"guid"` // RSS
+	ID        string     `xml:"id"`   // Atom
+	Links     []feedLink `xml:"link"`
+	PubDate   string     `xml:"pubDate"`
+	DCDate    string     `xml:"http://purl.org/dc/elements/1.1/ date"`
+	Published string     `xml:"published"`
+	Updated   string     `xml:"updated"`
+	StartDate string     `xml:"http://purl.org/rss/1.0/modules/event/ startdate"`
+}
+
+// feedLink is an RSS link, with the URL as text, or an Atom link, with
+// the URL in href
+type feedLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	URL  string `xml:",chardata"`
+}
+
+// Fetch returns a reminder for each entry with a date, deduplicated by
+// its GUID. Entries dated more than maxFeedAge ago are skipped.
+func (f *Feed) Fetch() ([]*reminder.Reminder, error) {
+	req, err := http.NewRequest(http.MethodGet, f.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/xml;q=0.9, */*;q=0.8")
+	resp, err := f.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("GET %s: %s", req.URL.Redacted(), resp.Status)
+	}
+
+	var doc feedDoc
+	dec := xml.NewDecoder(io.LimitReader(resp.Body, maxFeedSize))
+	dec.Strict = false
+	dec.Entity = xml.HTMLEntity
+	dec.CharsetReader = func(_ string, r io.Reader) (io.Reader, error) { return r, nil }
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid feed at %s: %w", req.URL.Redacted(), err)
+	}
+
+	cutoff := f.Clock.Now().Add(-maxFeedAge)
+	seen := make(map[string]bool)
+	var reminders []*reminder.Reminder
+	entries := append(append(doc.Channel.Items, doc.Items...), doc.Entries...)
+	for _, e := range entries {
+		title := strings.Join(strings.Fields(e.Title), " ")
+		link := e.link()
+		guid := firstNonEmpty(strings.TrimSpace(e.GUID), strings.TrimSpace(e.ID), link, title)
+		if guid == "" || seen[guid] {
+			continue
+		}
+		due, ok := f.date(e, title)
+		if !ok || due.Before(cutoff) {
+			continue
+		}
+		seen[guid] = true
+		reminders = append(reminders, &reminder.Reminder{
+			ID:          reminder.FileID(f.Name(), guid),
+			DateTime:    due,
+			Description: firstNonEmpty(title, link),
+			Tags:        []string{f.Tag},
+			SourceFile:  link,
+			Source:      f.Name(),
+			Status:      reminder.Pending,
+		})
+	}
+	return reminders, nil
+}
+
+// link returns the entry's web page
+func (e feedEntry) link() string {
+	for _, l := range e.Links {
+		if l.Href != "" && (l.Rel == "" || l.Rel == "alternate") {
+			return strings.TrimSpace(l.Href)
+		}
+		if l.Href == "" && strings.TrimSpace(l.URL) != "" {
+			return strings.TrimSpace(l.URL)
+		}
+	}
+	return ""
+}
+
+// date returns when an entry is due: its event start date, else a date in
+// its title, else its publication date if f.Published
+func (f *Feed) date(e feedEntry, title string) (time.Time, bool) {
+	if t, ok := f.parseTime(e.StartDate); ok {
+		return t, true
+	}
+	if t, ok := dateInText(title); ok {
+		return t.Add(f.DayStart), true
+	}
+	if f.Published {
+		for _, s := range []string{e.PubDate, e.DCDate, e.Published, e.Updated} {
+			if t, ok := f.parseTime(s); ok {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// parseTime reads an RFC 822 or RFC 3339 timestamp, or a bare date, which
+// is due at the start of the working day
+func (f *Feed) parseTime(s string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, false
+	}
+	if t, err := mail.ParseDate(s); err == nil {
+		return t.Local(), true
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.Local(), true
+	}
+	if len(s) == len(time.DateOnly) {
+		return dueOn(s, f.DayStart)
+	}
+	return time.Time{}, false
+}
+
+var (
+	// isoDate matches a date like 2026-09-01
+	isoDate = regexp.MustCompile(`\b(\d{4}-\d{2}-\d{2})\b`)
+	// monthFirst matches a date like "Sep 1, 2026" or "September 1 2026"
+	monthFirst = regexp.MustCompile(`(?i)\b(jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec)[a-z]*\.? (\d{1,2}),? (\d{4})\b`)
+	// dayFirst matches a date like "1 September 2026"
+	dayFirst = regexp.MustCompile(`(?i)\b(\d{1,2}) (jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec)[a-z]*\.? (\d{4})\b`)
+)
+
+// dateInText returns midnight on the first date written in s
+func dateInText(s string) (time.Time, bool) {
+	var text string
+	if m := isoDate.FindStringSubmatch(s); m != nil {
+		t, err := time.ParseInLocation(time.DateOnly, m[1], time.Local)
+		return t, err == nil
+	}
+	if m := monthFirst.FindStringSubmatch(s); m != nil {
+		text = m[1] + " " + m[2] + " " + m[3]
+	} else if m := dayFirst.FindStringSubmatch(s); m != nil {
+		text = m[2] + " " + m[1] + " " + m[3]
+	} else {
+		return time.Time{}, false
+	}
+	t, err := time.ParseInLocation("Jan 2 2006", strings.ToUpper(text[:1])+strings.ToLower(text[1:]), time.Local)
+	return t, err == nil
+}
@@ -0,0 +1,133 @@
+package sources
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"go_remind/config"
+	"go_remind/pkg/reminder"
+)
+
+// GitHub pulls the open issues and pull requests of a repo whose
+// milestone has a due date. GitHub issues have no due date of their own.
+type GitHub struct {
+	Repo          string // "owner/name"
+	Token         string
+	API           string // API URL without a trailing slash
+	OnAcknowledge string // "comment", "label", or ""
+	Comment       string
+	Label         string
+	DayStart      time.Duration // Time of day a milestone is due
+	Every         time.Duration // How often to refresh, 0 for the default
+	Client        *http.Client
+}
+
+// NewGitHub creates a GitHub source from its config
+func NewGitHub(cfg config.GitHubSourceConfig, dayStart time.Duration) *GitHub {
+	return &GitHub{
+		Repo:          cfg.Repo,
+		Token:         envToken(cfg.Token, "GITHUB_TOKEN"),
+		API:           strings.TrimSuffix(firstNonEmpty(cfg.API, "https://api.github.com"), "/"),
+		OnAcknowledge: cfg.OnAcknowledge,
+		Comment:       firstNonEmpty(cfg.Comment, defaultComment),
+		Label:         firstNonEmpty(cfg.Label, defaultLabel),
+		DayStart:      dayStart,
+		Every:         config.ParseInterval(cfg.Interval),
+		Client:        &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// Name returns "github:owner/name"
+func (g *GitHub) Name() string {
+	return "github:" + g.Repo
+}
+
+// Interval returns how often the repo is refreshed
+func (g *GitHub) Interval() time.Duration {
+	return g.Every
+}
+
+// githubIssue is the part of an issue or pull request that's read
+type githubIssue struct {
+	Number    int    `json:"number"`
+	Title     string `json:"title"`
+	HTMLURL   string `json:"html_url"`
+	Milestone *struct {
+		DueOn string `json:"due_on"`
+	} `json:"milestone"`
+}
+
+// Fetch returns a reminder for each open issue or pull request whose
+// milestone is due, linked to it by URL
+func (g *GitHub) Fetch() ([]*reminder.Reminder, error) {
+	var reminders []*reminder.Reminder
+	url := g.API + "/repos/" + g.Repo + "/issues?state=open&per_page=100"
+	for page := 0; url != "" && page < maxPages; page++ {
+		req, err := g.request(http.MethodGet, url)
+		if err != nil {
+			return nil, err
+		}
+		var issues []githubIssue
+		header, err := doJSON(g.Client, req, nil, &issues)
+		if err != nil {
+			return nil, err
+		}
+		for _, is := range issues {
+			if is.Milestone == nil {
+				continue
+			}
+			due, ok := dueOn(is.Milestone.DueOn, g.DayStart)
+			if !ok {
+				continue
+			}
+			reminders = append(reminders, &reminder.Reminder{
+				ID:          reminder.FileID(g.Name(), is.HTMLURL),
+				DateTime:    due,
+				Description: fmt.Sprintf("%s (%s#%d)", is.Title, path.Base(g.Repo), is.Number),
+				Tags:        []string{"github"},
+				SourceFile:  is.HTMLURL,
+				Source:      g.Name(),
+				Status:      reminder.Pending,
+			})
+		}
+		url = nextPage(header)
+	}
+	return reminders, nil
+}
+
+// Acknowledge comments on or labels the issue behind r, as configured
+func (g *GitHub) Acknowledge(r *reminder.Reminder) error {
+	// Pull requests take comments and labels through the issues API too
+	issue := g.API + "/repos/" + g.Repo + "/issues/" + path.Base(r.SourceFile)
+	var url string
+	var body any
+	switch g.OnAcknowledge {
+	case "comment":
+		url, body = issue+"/comments", map[string]string{"body": g.Comment}
+	case "label":
+		url, body = issue+"/labels", map[string][]string{"labels": {g.Label}}
+	default:
+		return nil
+	}
+	req, err := g.request(http.MethodPost, url)
+	if err != nil {
+		return err
+	}
+	_, err = doJSON(g.Client, req, body, nil)
+	return err
+}
+
+func (g *GitHub) request(method, url string) (*http.Request, error) {
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if g.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+g.Token)
+	}
+	return req, nil
+}
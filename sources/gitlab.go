@@ -0,0 +1,149 @@
+package sources
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"go_remind/config"
+	"go_remind/pkg/reminder"
+)
+
+// GitLab pulls the open issues and merge requests of a project that have
+// a due date, or a milestone with one
+type GitLab struct {
+	Project       string // "group/name"
+	Token         string
+	URL           string // Instance URL without a trailing slash
+	OnAcknowledge string // "comment", "label", or ""
+	Comment       string
+	Label         string
+	DayStart      time.Duration // Time of day an item is due
+	Every         time.Duration // How often to refresh, 0 for the default
+	Client        *http.Client
+}
+
+// NewGitLab creates a GitLab source from its config
+func NewGitLab(cfg config.GitLabSourceConfig, dayStart time.Duration) *GitLab {
+	return &GitLab{
+		Project:       cfg.Project,
+		Token:         envToken(cfg.Token, "GITLAB_TOKEN"),
+		URL:           strings.TrimSuffix(firstNonEmpty(cfg.URL, "https://gitlab.com"), "/"),
+		OnAcknowledge: cfg.OnAcknowledge,
+		Comment:       firstNonEmpty(cfg.Comment, defaultComment),
+		Label:         firstNonEmpty(cfg.Label, defaultLabel),
+		DayStart:      dayStart,
+		Every:         config.ParseInterval(cfg.Interval),
+		Client:        &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// Name returns "gitlab:group/name"
+func (g *GitLab) Name() string {
+	return "gitlab:" + g.Project
+}
+
+// Interval returns how often the project is refreshed
+func (g *GitLab) Interval() time.Duration {
+	return g.Every
+}
+
+// gitlabItem is the part of an issue or merge request that's read
+type gitlabItem struct {
+	IID       int    `json:"iid"`
+	Title     string `json:"title"`
+	WebURL    string `json:"web_url"`
+	DueDate   string `json:"due_date"`
+	Milestone *struct {
+		DueDate string `json:"due_date"`
+	} `json:"milestone"`
+}
+
+// Fetch returns a reminder for each open issue or merge request that's
+// due, linked to it by URL
+func (g *GitLab) Fetch() ([]*reminder.Reminder, error) {
+	var reminders []*reminder.Reminder
+	for _, kind := range []struct{ path, ref string }{{"issues", "#"}, {"merge_requests", "!"}} {
+		next := g.api() + "/" + kind.path + "?state=opened&per_page=100"
+		for page := 0; next != "" && page < maxPages; page++ {
+			req, err := g.request(http.MethodGet, next)
+			if err != nil {
+				return nil, err
+			}
+			var items []gitlabItem
+			header, err := doJSON(g.Client, req, nil, &items)
+			if err != nil {
+				return nil, err
+			}
+			for _, it := range items {
+				date := it.DueDate
+				if date == "" && it.Milestone != nil {
+					date = it.Milestone.DueDate
+				}
+				due, ok := dueOn(date, g.DayStart)
+				if !ok {
+					continue
+				}
+				reminders = append(reminders, &reminder.Reminder{
+					ID:          reminder.FileID(g.Name(), it.WebURL),
+					DateTime:    due,
+					Description: fmt.Sprintf("%s (%s%s%d)", it.Title, path.Base(g.Project), kind.ref, it.IID),
+					Tags:        []string{"gitlab"},
+					SourceFile:  it.WebURL,
+					Source:      g.Name(),
+					Status:      reminder.Pending,
+				})
+			}
+			next = nextPage(header)
+		}
+	}
+	return reminders, nil
+}
+
+// Acknowledge comments on or labels the issue or merge request behind r,
+// as configured
+func (g *GitLab) Acknowledge(r *reminder.Reminder) error {
+	kind := "issues"
+	if strings.Contains(r.SourceFile, "/merge_requests/") {
+		kind = "merge_requests"
+	}
+	item := g.api() + "/" + kind + "/" + path.Base(r.SourceFile)
+
+	var req *http.Request
+	var body any
+	var err error
+	switch g.OnAcknowledge {
+	case "comment":
+		req, err = g.request(http.MethodPost, item+"/notes")
+		body = map[string]string{"body": g.Comment}
+	case "label":
+		req, err = g.request(http.MethodPut, item)
+		body = map[string]string{"add_labels": g.Label}
+	default:
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	_, err = doJSON(g.Client, req, body, nil)
+	return err
+}
+
+// api returns the project's API URL
+func (g *GitLab) api() string {
+	return g.URL + "/api/v4/projects/" + url.PathEscape(g.Project)
+}
+
+func (g *GitLab) request(method, target string) (*http.Request, error) {
+	req, err := http.NewRequest(method, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	if g.Token != "" {
+		req.Header.Set("PRIVATE-TOKEN", g.Token)
+	}
+	return req, nil
+}
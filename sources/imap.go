@@ -0,0 +1,192 @@
+package sources
+
+import (
+	"mime"
+	"net"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"go_remind/config"
+	"go_remind/pkg/reminder"
+)
+
+// maxMessages bounds how many of the newest matching emails are read
+const maxMessages = 100
+
+// IMAP turns emails in a mailbox into follow-up reminders
+type IMAP struct {
+	Server    string // "host:port"
+	Username  string
+	Password  string
+	Mailbox   string
+	Criteria  string        // IMAP search for the emails to follow up on
+	Delay     time.Duration // How long after an email it's due
+	Plaintext bool          // Connect without TLS
+	Every     time.Duration // How often to refresh, 0 for the default
+}
+
+// NewIMAP creates an IMAP source from its config
+func NewIMAP(cfg config.IMAPSourceConfig) *IMAP {
+	server := cfg.Server
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		port := "993"
+		if cfg.Plaintext {
+			port = "143"
+		}
+		server = net.JoinHostPort(server, port)
+	}
+	return &IMAP{
+		Server:    server,
+		Username:  cfg.Username,
+		Password:  envToken(cfg.Password, "GO_REMIND_IMAP_PASSWORD"),
+		Mailbox:   firstNonEmpty(cfg.Mailbox, "INBOX"),
+		Criteria:  searchCriteria(cfg.Flagged, strings.TrimSpace(cfg.Search)),
+		Delay:     cfg.FollowUpDelay(),
+		Plaintext: cfg.Plaintext,
+		Every:     config.ParseInterval(cfg.Interval),
+	}
+}
+
+// searchCriteria finds undeleted emails that are flagged, match search,
+// or either
+func searchCriteria(flagged bool, search string) string {
+	switch {
+	case flagged && search != "":
+		return "UNDELETED OR FLAGGED (" + search + ")"
+	case flagged:
+		return "UNDELETED FLAGGED"
+	default:
+		return "UNDELETED (" + search + ")"
+	}
+}
+
+// Name returns "imap:user/mailbox"
+func (m *IMAP) Name() string {
+	return "imap:" + m.Username + "/" + m.Mailbox
+}
+
+// Interval returns how often the mailbox is checked
+func (m *IMAP) Interval() time.Duration {
+	return m.Every
+}
+
+// Fetch returns a "Follow up" reminder for each of the newest emails that
+// match, due Delay after the email was sent and linked to it by
+// Message-ID. The mailbox is opened read-only, so nothing is marked read.
+func (m *IMAP) Fetch() ([]*reminder.Reminder, error) {
+	c, err := dialIMAP(m.Server, m.Plaintext)
+	if err != nil {
+		return nil, err
+	}
+	defer c.close()
+
+	if _, err := c.command("LOGIN %s %s", imapQuote(m.Username), imapQuote(m.Password)); err != nil {
+		return nil, err
+	}
+	if _, err := c.command("EXAMINE %s", imapQuote(m.Mailbox)); err != nil {
+		return nil, err
+	}
+	data, err := c.command("UID SEARCH %s", m.Criteria)
+	if err != nil {
+		return nil, err
+	}
+	var uids []string
+	for _, resp := range data {
+		if len(resp.fields) > 0 && resp.fields[0] == "SEARCH" {
+			for _, f := range resp.fields[1:] {
+				if uid, ok := f.(string); ok {
+					uids = append(uids, uid)
+				}
+			}
+		}
+	}
+	if len(uids) == 0 {
+		return nil, nil
+	}
+	if len(uids) > maxMessages {
+		uids = uids[len(uids)-maxMessages:]
+	}
+
+	data, err = c.command("UID FETCH %s (INTERNALDATE BODY.PEEK[HEADER.FIELDS (DATE FROM SUBJECT MESSAGE-ID)])", strings.Join(uids, ","))
+	if err != nil {
+		return nil, err
+	}
+	var reminders []*reminder.Reminder
+	for _, resp := range data {
+		if len(resp.fields) < 3 || resp.fields[1] != "FETCH" {
+			continue
+		}
+		items, _ := resp.fields[2].([]any)
+		var header, received string
+		for i := 0; i+1 < len(items); i += 2 {
+			key, _ := items[i].(string)
+			value, _ := items[i+1].(string)
+			switch {
+			case key == "INTERNALDATE":
+				received = value
+			case strings.HasPrefix(key, "BODY["):
+				header = value
+			}
+		}
+		if r := m.followUp(header, received); r != nil {
+			reminders = append(reminders, r)
+		}
+	}
+	return reminders, nil
+}
+
+// followUp makes the reminder for an email from its header and the time
+// the server received it
+func (m *IMAP) followUp(header, received string) *reminder.Reminder {
+	msg, err := mail.ReadMessage(strings.NewReader(strings.TrimRight(header, "\r\n") + "\r\n\r\n"))
+	if err != nil {
+		return nil
+	}
+	sent, err := msg.Header.Date()
+	if err != nil {
+		if sent, err = time.Parse("_2-Jan-2006 15:04:05 -0700", received); err != nil {
+			return nil
+		}
+	}
+
+	subject := decodeHeader(msg.Header.Get("Subject"))
+	if subject == "" {
+		subject = "(no subject)"
+	}
+	from := decodeHeader(msg.Header.Get("From"))
+	if addr, err := mail.ParseAddress(msg.Header.Get("From")); err == nil {
+		from = addr.Address
+		if addr.Name != "" {
+			from = addr.Name + " <" + addr.Address + ">"
+		}
+	}
+
+	// Mail clients open mid: links (RFC 2392) to the message
+	var link string
+	key := from + "\x00" + sent.String() + "\x00" + subject
+	if id := strings.Trim(msg.Header.Get("Message-Id"), "<> \t"); id != "" {
+		link = "mid:" + url.PathEscape(id)
+		key = link
+	}
+
+	return &reminder.Reminder{
+		ID:          reminder.FileID(m.Name(), key),
+		DateTime:    sent.Add(m.Delay).Local(),
+		Description: "Follow up: " + subject,
+		Tags:        []string{"email"},
+		SourceFile:  link,
+		Source:      m.Name(),
+		From:        from,
+		Status:      reminder.Pending,
+	}
+}
+
+// decodeHeader decodes MIME encoded-words, like =?UTF-8?Q?...?=
+func decodeHeader(s string) string {
+	if decoded, err := new(mime.WordDecoder).DecodeHeader(s); err == nil {
+		return strings.TrimSpace(decoded)
+	}
+	return strings.TrimSpace(s)
+}
@@ -0,0 +1,102 @@
+package sources
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"go_remind/config"
+	"go_remind/pkg/reminder"
+)
+
+// fakeIMAP serves one session, answering each command from replies by
+// its verb, and records the commands it receives
+func fakeIMAP(t *testing.T, replies map[string]string) (addr string, commands chan string) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	commands = make(chan string, 10)
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		defer close(commands)
+		fmt.Fprint(conn, "* OK [CAPABILITY IMAP4rev1] ready (for testing)\r\n")
+		r := bufio.NewReader(conn)
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			tag, cmd, _ := strings.Cut(strings.TrimSpace(line), " ")
+			commands <- cmd
+			verb, _, _ := strings.Cut(cmd, " ")
+			if verb == "UID" {
+				verb, _, _ = strings.Cut(strings.TrimPrefix(cmd, "UID "), " ")
+			}
+			fmt.Fprint(conn, replies[verb]+tag+" OK done\r\n")
+			if verb == "LOGOUT" {
+				return
+			}
+		}
+	}()
+	return ln.Addr().String(), commands
+}
+
+func TestIMAP(t *testing.T) {
+	flagged := "Date: Mon, 02 Mar 2026 09:30:00 +0000\r\n" +
+		"From: Ada Lovelace <ada@example.com>\r\n" +
+		"Subject: =?UTF-8?Q?Contract_r=C3=A9view?=\r\n" +
+		"Message-ID: <abc@mail.example.com>\r\n\r\n"
+	undated := "From: bob@example.com\r\nSubject: Lunch?\r\n\r\n"
+	addr, commands := fakeIMAP(t, map[string]string{
+		"EXAMINE": "* 2 EXISTS\r\n",
+		"SEARCH":  "* SEARCH 4 7\r\n",
+		"FETCH": fmt.Sprintf("* 1 FETCH (UID 4 INTERNALDATE \"02-Mar-2026 09:31:00 +0000\" BODY[HEADER.FIELDS (DATE FROM SUBJECT MESSAGE-ID)] {%d}\r\n%s)\r\n", len(flagged), flagged) +
+			fmt.Sprintf("* 2 FETCH (BODY[HEADER.FIELDS (DATE FROM SUBJECT MESSAGE-ID)] {%d}\r\n%s INTERNALDATE \" 3-Mar-2026 12:00:00 +0100\" UID 7)\r\n", len(undated), undated),
+		"LOGOUT": "* BYE logging out\r\n",
+	})
+
+	im := NewIMAP(config.IMAPSourceConfig{Server: addr, Username: "me", Password: `p"w`, Flagged: true, Search: `FROM "bob"`, Delay: "48h", Plaintext: true})
+	got, err := im.Fetch()
+	if err != nil {
+		t.Fatalf("Fetch() error: %v", err)
+	}
+
+	var sent []string
+	for cmd := range commands {
+		sent = append(sent, cmd)
+	}
+	want := []string{`LOGIN "me" "p\"w"`, `EXAMINE "INBOX"`, `UID SEARCH UNDELETED OR FLAGGED (FROM "bob")`}
+	if len(sent) < 4 || strings.Join(sent[:3], "|") != strings.Join(want, "|") || !strings.HasPrefix(sent[3], "UID FETCH 4,7 ") {
+		t.Errorf("commands = %q", sent)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("Fetch() returned %d reminders, want 2", len(got))
+	}
+	r := got[0]
+	if r.Description != "Follow up: Contract réview" || r.From != "Ada Lovelace <ada@example.com>" || r.SourceFile != "mid:abc@mail.example.com" || r.Source != "imap:me/INBOX" {
+		t.Errorf("flagged email = %q from %q, %s (%s)", r.Description, r.From, r.SourceFile, r.Source)
+	}
+	if due := time.Date(2026, 3, 4, 9, 30, 0, 0, time.UTC); !r.DateTime.Equal(due) {
+		t.Errorf("due = %v, want 48h after it was sent, %v", r.DateTime, due)
+	}
+	if r.ID != reminder.FileID("imap:me/INBOX", "mid:abc@mail.example.com") {
+		t.Errorf("ID should be stable per Message-ID, got %s", r.ID)
+	}
+
+	// Without a Date or Message-ID, it's due after the server got it
+	r = got[1]
+	if due := time.Date(2026, 3, 5, 11, 0, 0, 0, time.UTC); !r.DateTime.Equal(due) || r.From != "bob@example.com" || r.SourceFile != "" {
+		t.Errorf("undated email due %v from %q, %q; want %v", r.DateTime, r.From, r.SourceFile, due)
+	}
+}
@@ -0,0 +1,248 @@
+package sources
+
+import (
+	"bufio"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// imapConn is a minimal IMAP4rev1 client: enough to log in, search a
+// mailbox, and read message headers
+type imapConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+	tag  int
+}
+
+// imapResponse is one response from the server
+type imapResponse struct {
+	tag    string // "*" when untagged
+	status string // OK, NO, BAD, BYE, or PREAUTH; "" for data
+	text   string // Text after the status
+	fields []any  // Data: strings, and []any for parenthesized lists
+}
+
+// dialIMAP connects to server and reads its greeting. The whole session
+// must finish within requestTimeout.
+func dialIMAP(server string, plaintext bool) (*imapConn, error) {
+	dialer := &net.Dialer{Timeout: requestTimeout}
+	var conn net.Conn
+	var err error
+	if plaintext {
+		conn, err = dialer.Dial("tcp", server)
+	} else {
+		host, _, _ := net.SplitHostPort(server)
+		conn, err = tls.DialWithDialer(dialer, "tcp", server, &tls.Config{ServerName: host})
+	}
+	if err != nil {
+		return nil, err
+	}
+	_ = conn.SetDeadline(time.Now().Add(requestTimeout))
+
+	c := &imapConn{conn: conn, r: bufio.NewReader(conn)}
+	greeting, err := c.read()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if greeting.status != "OK" && greeting.status != "PREAUTH" {
+		conn.Close()
+		return nil, fmt.Errorf("imap: %s refused the connection: %s", server, greeting.text)
+	}
+	return c, nil
+}
+
+// command sends a command and returns the untagged data the server sends
+// before completing it. A completion other than OK is an error.
+func (c *imapConn) command(format string, args ...any) ([]imapResponse, error) {
+	c.tag++
+	tag := "a" + strconv.Itoa(c.tag)
+	if _, err := fmt.Fprintf(c.conn, tag+" "+format+"\r\n", args...); err != nil {
+		return nil, err
+	}
+
+	var data []imapResponse
+	for {
+		resp, err := c.read()
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case resp.tag == tag && resp.status == "OK":
+			return data, nil
+		case resp.tag == tag:
+			verb, _, _ := strings.Cut(format, " ")
+			return nil, fmt.Errorf("imap: %s failed: %s", verb, resp.text)
+		case resp.tag == "*" && resp.status == "BYE":
+			return nil, fmt.Errorf("imap: server closed the connection: %s", resp.text)
+		case resp.tag == "*":
+			data = append(data, resp)
+		}
+	}
+}
+
+// close logs out and closes the connection
+func (c *imapConn) close() {
+	_, _ = c.command("LOGOUT")
+	c.conn.Close()
+}
+
+// read reads one response, with any literals it carries
+func (c *imapConn) read() (imapResponse, error) {
+	tag, err := c.atom()
+	if err != nil {
+		return imapResponse{}, err
+	}
+	resp := imapResponse{tag: tag}
+	if b, err := c.r.ReadByte(); err != nil || b != ' ' {
+		return resp, errors.New("imap: malformed response")
+	}
+	word, err := c.atom()
+	if err != nil {
+		return resp, err
+	}
+	switch strings.ToUpper(word) {
+	case "OK", "NO", "BAD", "BYE", "PREAUTH":
+		// Status text is free-form, so it isn't parsed
+		text, err := c.r.ReadString('\n')
+		if err != nil {
+			return resp, err
+		}
+		resp.status = strings.ToUpper(word)
+		resp.text = strings.TrimSpace(text)
+		return resp, nil
+	}
+	rest, err := c.list(false)
+	if err != nil {
+		return resp, err
+	}
+	resp.fields = append([]any{word}, rest...)
+	return resp, nil
+}
+
+// list reads fields up to the end of the line, or of the list when inner
+func (c *imapConn) list(inner bool) ([]any, error) {
+	var fields []any
+	for {
+		b, err := c.r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		switch b {
+		case ' ', '\r':
+		case '\n':
+			if inner {
+				return nil, errors.New("imap: unterminated list")
+			}
+			return fields, nil
+		case ')':
+			if !inner {
+				return nil, errors.New("imap: unexpected )")
+			}
+			return fields, nil
+		case '(':
+			l, err := c.list(true)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, l)
+		case '"':
+			s, err := c.quoted()
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, s)
+		case '{':
+			s, err := c.literal()
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, s)
+		default:
+			_ = c.r.UnreadByte()
+			s, err := c.atom()
+			if err != nil {
+				return nil, err
+			}
+			if s == "NIL" {
+				s = ""
+			}
+			fields = append(fields, s)
+		}
+	}
+}
+
+// atom reads an atom. A bracketed section, like the one in
+// BODY[HEADER.FIELDS (SUBJECT)], is part of the atom.
+func (c *imapConn) atom() (string, error) {
+	var sb strings.Builder
+	depth := 0
+	for {
+		b, err := c.r.ReadByte()
+		if err != nil {
+			return "", err
+		}
+		if b == '\r' || b == '\n' || (depth == 0 && (b == ' ' || b == '(' || b == ')')) {
+			return sb.String(), c.r.UnreadByte()
+		}
+		switch b {
+		case '[':
+			depth++
+		case ']':
+			if depth > 0 {
+				depth--
+			}
+		}
+		sb.WriteByte(b)
+	}
+}
+
+// quoted reads a quoted string after its opening quote
+func (c *imapConn) quoted() (string, error) {
+	var sb strings.Builder
+	for {
+		b, err := c.r.ReadByte()
+		if err != nil {
+			return "", err
+		}
+		switch b {
+		case '"':
+			return sb.String(), nil
+		case '\\':
+			if b, err = c.r.ReadByte(); err != nil {
+				return "", err
+			}
+		case '\r', '\n':
+			return "", errors.New("imap: unterminated string")
+		}
+		sb.WriteByte(b)
+	}
+}
+
+// literal reads a {size} literal after its opening brace
+func (c *imapConn) literal() (string, error) {
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	size, err := strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(line), "}"))
+	if err != nil || size < 0 {
+		return "", fmt.Errorf("imap: bad literal {%s", strings.TrimSpace(line))
+	}
+	buf := make([]byte, size)
+	if _, err := io.ReadFull(c.r, buf); err != nil {
+		return "", err
+	}
+	return string(buf), nil
+}
+
+// imapQuote quotes s as an IMAP string
+func imapQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
@@ -0,0 +1,148 @@
+package sources
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go_remind/config"
+	"go_remind/pkg/reminder"
+)
+
+// defaultJQL finds your tickets with a due date, including ones done in
+// the last month so their reminders can be marked done too
+const defaultJQL = "assignee = currentUser() AND duedate is not EMPTY AND (statusCategory != Done OR updated >= -30d)"
+
+// Jira pulls tickets with a due date from a Jira site
+type Jira struct {
+	URL      string // Site URL without a trailing slash
+	Email    string // With an API token, for Jira Cloud
+	Token    string // API token, or a personal access token without Email
+	JQL      string
+	DayStart time.Duration // Time of day a ticket is due
+	Every    time.Duration // How often to refresh, 0 for the default
+	Client   *http.Client
+}
+
+// NewJira creates a Jira source from its config
+func NewJira(cfg config.JiraSourceConfig, dayStart time.Duration) *Jira {
+	return &Jira{
+		URL:      strings.TrimSuffix(cfg.URL, "/"),
+		Email:    cfg.Email,
+		Token:    envToken(cfg.Token, "JIRA_API_TOKEN"),
+		JQL:      firstNonEmpty(cfg.JQL, defaultJQL),
+		DayStart: dayStart,
+		Every:    config.ParseInterval(cfg.Interval),
+		Client:   &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// Name returns "jira:" and the site's host
+func (j *Jira) Name() string {
+	if u, err := url.Parse(j.URL); err == nil && u.Host != "" {
+		return "jira:" + u.Host
+	}
+	return "jira:" + j.URL
+}
+
+// Interval returns how often the site is refreshed
+func (j *Jira) Interval() time.Duration {
+	return j.Every
+}
+
+// jiraSearch is the part of a search response that's read
+type jiraSearch struct {
+	Total  int `json:"total"`
+	Issues []struct {
+		Key    string `json:"key"`
+		Fields struct {
+			Summary        string `json:"summary"`
+			DueDate        string `json:"duedate"`
+			ResolutionDate string `json:"resolutiondate"`
+			Updated        string `json:"updated"`
+			Project        struct {
+				Key string `json:"key"`
+			} `json:"project"`
+			Status struct {
+				StatusCategory struct {
+					Key string `json:"key"`
+				} `json:"statusCategory"`
+			} `json:"status"`
+		} `json:"fields"`
+	} `json:"issues"`
+}
+
+// Fetch returns a reminder for each ticket the JQL finds, tagged with its
+// project key and linked to it. Tickets in a done status come back
+// acknowledged.
+func (j *Jira) Fetch() ([]*reminder.Reminder, error) {
+	var reminders []*reminder.Reminder
+	for page, start := 0, 0; page < maxPages; page++ {
+		query := url.Values{
+			"jql":        {j.JQL},
+			"fields":     {"summary,duedate,resolutiondate,updated,project,status"},
+			"startAt":    {strconv.Itoa(start)},
+			"maxResults": {"100"},
+		}
+		req, err := http.NewRequest(http.MethodGet, j.URL+"/rest/api/2/search?"+query.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+		var result jiraSearch
+		if _, err := doJSON(j.Client, j.authorize(req), nil, &result); err != nil {
+			return nil, err
+		}
+
+		for _, is := range result.Issues {
+			due, ok := dueOn(is.Fields.DueDate, j.DayStart)
+			if !ok {
+				continue
+			}
+			link := j.URL + "/browse/" + is.Key
+			r := &reminder.Reminder{
+				ID:          reminder.FileID(j.Name(), link),
+				DateTime:    due,
+				Description: is.Fields.Summary + " (" + is.Key + ")",
+				SourceFile:  link,
+				Source:      j.Name(),
+				Status:      reminder.Pending,
+			}
+			if is.Fields.Project.Key != "" {
+				r.Tags = []string{is.Fields.Project.Key}
+			}
+			if is.Fields.Status.StatusCategory.Key == "done" {
+				r.Status = reminder.Acknowledged
+				r.AcknowledgedAt = jiraTime(firstNonEmpty(is.Fields.ResolutionDate, is.Fields.Updated))
+			}
+			reminders = append(reminders, r)
+		}
+
+		start += len(result.Issues)
+		if len(result.Issues) == 0 || start >= result.Total {
+			break
+		}
+	}
+	return reminders, nil
+}
+
+// jiraTime reads a Jira timestamp like 2026-03-04T10:00:00.000+0000
+func jiraTime(s string) time.Time {
+	t, err := time.Parse("2006-01-02T15:04:05.000-0700", s)
+	if err != nil {
+		return time.Time{}
+	}
+	return t.Local()
+}
+
+func (j *Jira) authorize(req *http.Request) *http.Request {
+	req.Header.Set("Accept", "application/json")
+	switch {
+	case j.Email != "":
+		req.SetBasicAuth(j.Email, j.Token)
+	case j.Token != "":
+		req.Header.Set("Authorization", "Bearer "+j.Token)
+	}
+	return req
+}
@@ -0,0 +1,143 @@
+// Package sources pulls reminders from remote systems, such as the due
+// dates of issues in a tracker.
+package sources
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"time"
+
+	"go_remind/config"
+	"go_remind/pkg/reminder"
+)
+
+const (
+	// maxPages bounds how many pages of results a fetch follows
+	maxPages = 10
+	// requestTimeout bounds each API request
+	requestTimeout = 30 * time.Second
+	// defaultComment is posted on an item when its reminder is acknowledged
+	defaultComment = "Acknowledged in go_remind"
+	// defaultLabel is added to an item when its reminder is acknowledged
+	defaultLabel = "reminded"
+)
+
+// Source is a remote system whose dated items become reminders
+type Source interface {
+	// Name identifies the source, e.g. "github:owner/repo". It is saved
+	// with each reminder pulled from the source.
+	Name() string
+	// Fetch returns a reminder for every open item with a due date
+	Fetch() ([]*reminder.Reminder, error)
+}
+
+// Acknowledger is a Source that reports back when one of its reminders is
+// acknowledged, e.g. by commenting on the issue
+type Acknowledger interface {
+	Acknowledge(r *reminder.Reminder) error
+}
+
+// Scheduled is a Source that refreshes on its own interval rather than
+// the one set for all sources. An interval of 0 uses that one.
+type Scheduled interface {
+	Interval() time.Duration
+}
+
+// New creates the sources configured in cfg. Items due on a date rather
+// than at a time are due at the start of the working day.
+func New(cfg *config.Config) []Source {
+	dayStart, _ := cfg.WorkHours.Bounds()
+	var sources []Source
+	for _, gh := range cfg.Sources.GitHub {
+		sources = append(sources, NewGitHub(gh, dayStart))
+	}
+	for _, gl := range cfg.Sources.GitLab {
+		sources = append(sources, NewGitLab(gl, dayStart))
+	}
+	for _, j := range cfg.Sources.Jira {
+		sources = append(sources, NewJira(j, dayStart))
+	}
+	for _, im := range cfg.Sources.IMAP {
+		sources = append(sources, NewIMAP(im))
+	}
+	for _, f := range cfg.Sources.Feeds {
+		sources = append(sources, NewFeed(f, dayStart))
+	}
+	return sources
+}
+
+// dueOn returns dayStart on the date that s starts with, "2006-01-02"
+// or an RFC 3339 timestamp, in local time
+func dueOn(s string, dayStart time.Duration) (time.Time, bool) {
+	if len(s) < len(time.DateOnly) {
+		return time.Time{}, false
+	}
+	date, err := time.ParseInLocation(time.DateOnly, s[:len(time.DateOnly)], time.Local)
+	if err != nil {
+		return time.Time{}, false
+	}
+	// Set the clock time rather than adding to midnight, which is off by
+	// an hour on the days the clocks change
+	y, m, d := date.Date()
+	return time.Date(y, m, d, 0, 0, 0, int(dayStart), time.Local), true
+}
+
+// linkNext matches the next page in a Link header
+var linkNext = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// nextPage returns the URL of the next page of results, or ""
+func nextPage(h http.Header) string {
+	if m := linkNext.FindStringSubmatch(h.Get("Link")); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// doJSON sends body, if any, as JSON and decodes a successful response
+// into out, if given. It returns the response headers for paging.
+func doJSON(client *http.Client, req *http.Request, body, out any) (http.Header, error) {
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(data))
+		req.ContentLength = int64(len(data))
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("%s %s: %s", req.Method, req.URL.Redacted(), resp.Status)
+	}
+	if out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return resp.Header, nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return nil, fmt.Errorf("invalid response from %s: %w", req.URL.Redacted(), err)
+	}
+	return resp.Header, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func envToken(token, name string) string {
+	return firstNonEmpty(token, os.Getenv(name))
+}
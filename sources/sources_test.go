@@ -0,0 +1,237 @@
+package sources
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go_remind/config"
+	"go_remind/pkg/clock"
+	"go_remind/pkg/reminder"
+)
+
+// fakeAPI serves canned JSON by path and records the writes it receives
+type fakeAPI struct {
+	t      *testing.T
+	pages  map[string]string // escaped path?query -> JSON
+	next   map[string]string // escaped path?query -> path?query of the next page
+	mu     sync.Mutex
+	writes []string // "METHOD path body"
+	server *httptest.Server
+}
+
+func newFakeAPI(t *testing.T) *fakeAPI {
+	f := &fakeAPI{t: t, pages: map[string]string{}, next: map[string]string{}}
+	f.server = httptest.NewServer(f)
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := r.URL.EscapedPath()
+	if r.URL.RawQuery != "" {
+		key += "?" + r.URL.RawQuery
+	}
+	if r.Method != http.MethodGet {
+		body, _ := io.ReadAll(r.Body)
+		f.mu.Lock()
+		f.writes = append(f.writes, r.Method+" "+key+" "+strings.TrimSpace(string(body)))
+		f.mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+		return
+	}
+	page, ok := f.pages[key]
+	if !ok {
+		// Match on the path alone when the query isn't given
+		page, ok = f.pages[r.URL.EscapedPath()]
+	}
+	if !ok {
+		f.t.Errorf("unexpected request %s", key)
+		http.NotFound(w, r)
+		return
+	}
+	if next := f.next[key]; next != "" {
+		w.Header().Set("Link", fmt.Sprintf(`<%s%s>; rel="next", <%s/last>; rel="last"`, f.server.URL, next, f.server.URL))
+	}
+	io.WriteString(w, page)
+}
+
+func TestGitHub(t *testing.T) {
+	api := newFakeAPI(t)
+	api.pages["/repos/me/app/issues?state=open&per_page=100"] = `[
+		{"number": 1, "title": "Ship it", "html_url": "https://github.com/me/app/issues/1", "milestone": {"due_on": "2026-03-04T08:00:00Z"}},
+		{"number": 2, "title": "No milestone", "html_url": "https://github.com/me/app/issues/2", "milestone": null}
+	]`
+	api.next["/repos/me/app/issues?state=open&per_page=100"] = "/repos/me/app/issues?page=2"
+	api.pages["/repos/me/app/issues?page=2"] = `[
+		{"number": 3, "title": "Review", "html_url": "https://github.com/me/app/pull/3", "milestone": {"due_on": "2026-03-05T08:00:00Z"}}
+	]`
+
+	gh := NewGitHub(config.GitHubSourceConfig{Repo: "me/app", API: api.server.URL, OnAcknowledge: "comment"}, 9*time.Hour)
+	got, err := gh.Fetch()
+	if err != nil {
+		t.Fatalf("Fetch() error: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("Fetch() returned %d reminders, want the 2 with a due milestone", len(got))
+	}
+	want := time.Date(2026, 3, 4, 9, 0, 0, 0, time.Local)
+	if r := got[0]; r.Description != "Ship it (app#1)" || !r.DateTime.Equal(want) || r.SourceFile != "https://github.com/me/app/issues/1" || r.Source != "github:me/app" {
+		t.Errorf("issue = %q at %v from %s (%s)", r.Description, r.DateTime, r.SourceFile, r.Source)
+	}
+	if got[0].ID == got[1].ID || got[0].ID != reminder.FileID("github:me/app", "https://github.com/me/app/issues/1") {
+		t.Errorf("IDs should be stable per issue URL: %s, %s", got[0].ID, got[1].ID)
+	}
+
+	if err := gh.Acknowledge(got[1]); err != nil {
+		t.Fatalf("Acknowledge() error: %v", err)
+	}
+	if len(api.writes) != 1 || api.writes[0] != `POST /repos/me/app/issues/3/comments {"body":"Acknowledged in go_remind"}` {
+		t.Errorf("writes = %q, want a comment on #3", api.writes)
+	}
+}
+
+func TestGitLab(t *testing.T) {
+	api := newFakeAPI(t)
+	api.pages["/api/v4/projects/team%2Fsite/issues?state=opened&per_page=100"] = `[
+		{"iid": 7, "title": "Launch", "web_url": "https://gitlab.com/team/site/-/issues/7", "due_date": "2026-03-04"},
+		{"iid": 8, "title": "Via milestone", "web_url": "https://gitlab.com/team/site/-/issues/8", "due_date": null, "milestone": {"due_date": "2026-03-06"}},
+		{"iid": 9, "title": "Undated", "web_url": "https://gitlab.com/team/site/-/issues/9"}
+	]`
+	api.pages["/api/v4/projects/team%2Fsite/merge_requests?state=opened&per_page=100"] = `[
+		{"iid": 4, "title": "Redesign", "web_url": "https://gitlab.com/team/site/-/merge_requests/4", "milestone": {"due_date": "2026-03-05"}}
+	]`
+
+	gl := NewGitLab(config.GitLabSourceConfig{Project: "team/site", URL: api.server.URL + "/", OnAcknowledge: "label", Label: "seen"}, 10*time.Hour)
+	got, err := gl.Fetch()
+	if err != nil {
+		t.Fatalf("Fetch() error: %v", err)
+	}
+	var descs []string
+	for _, r := range got {
+		descs = append(descs, r.Description)
+	}
+	if strings.Join(descs, "|") != "Launch (site#7)|Via milestone (site#8)|Redesign (site!4)" {
+		t.Errorf("Fetch() = %q", descs)
+	}
+	if want := time.Date(2026, 3, 6, 10, 0, 0, 0, time.Local); !got[1].DateTime.Equal(want) {
+		t.Errorf("milestone due = %v, want %v", got[1].DateTime, want)
+	}
+
+	if err := gl.Acknowledge(got[2]); err != nil {
+		t.Fatalf("Acknowledge() error: %v", err)
+	}
+	var body map[string]string
+	method, rest, _ := strings.Cut(api.writes[0], " ")
+	path, data, _ := strings.Cut(rest, " ")
+	_ = json.Unmarshal([]byte(data), &body)
+	if method != http.MethodPut || path != "/api/v4/projects/team%2Fsite/merge_requests/4" || body["add_labels"] != "seen" {
+		t.Errorf("write = %q, want the label added to !4", api.writes[0])
+	}
+}
+
+func TestJira(t *testing.T) {
+	api := newFakeAPI(t)
+	api.pages["/rest/api/2/search"] = `{"total": 3, "issues": [
+		{"key": "OPS-1", "fields": {"summary": "Renew certs", "duedate": "2026-03-04", "project": {"key": "OPS"}, "status": {"statusCategory": {"key": "indeterminate"}}}},
+		{"key": "OPS-2", "fields": {"summary": "Rotate keys", "duedate": "2026-03-02", "resolutiondate": "2026-03-01T16:30:00.000+0000", "project": {"key": "OPS"}, "status": {"statusCategory": {"key": "done"}}}},
+		{"key": "WEB-9", "fields": {"summary": "No due date", "duedate": null, "project": {"key": "WEB"}, "status": {"statusCategory": {"key": "new"}}}}
+	]}`
+
+	j := NewJira(config.JiraSourceConfig{URL: api.server.URL, Email: "me@example.com", Token: "secret"}, 9*time.Hour)
+	got, err := j.Fetch()
+	if err != nil {
+		t.Fatalf("Fetch() error: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("Fetch() returned %d reminders, want the 2 with a due date", len(got))
+	}
+	if r := got[0]; r.Description != "Renew certs (OPS-1)" || r.SourceFile != api.server.URL+"/browse/OPS-1" || len(r.Tags) != 1 || r.Tags[0] != "OPS" || r.Status != reminder.Pending {
+		t.Errorf("open ticket = %q from %s, tags %v, %v", r.Description, r.SourceFile, r.Tags, r.Status)
+	}
+	done := time.Date(2026, 3, 1, 16, 30, 0, 0, time.UTC)
+	if r := got[1]; r.Status != reminder.Acknowledged || !r.AcknowledgedAt.Equal(done) {
+		t.Errorf("done ticket = %v at %v, want acknowledged at %v", r.Status, r.AcknowledgedAt, done)
+	}
+	if !strings.HasPrefix(j.Name(), "jira:127.0.0.1") {
+		t.Errorf("Name() = %q, want jira: and the site's host", j.Name())
+	}
+}
+
+func TestFeed(t *testing.T) {
+	api := newFakeAPI(t)
+	api.pages["/cfp.rss"] = `<?xml version="1.0"?>
+<rss version="2.0" xmlns:ev="http://purl.org/rss/1.0/modules/event/">
+<channel><title>CFP deadlines</title>
+	<item><title>GopherCon talks</title><link>https://example.com/gophercon</link><guid>cfp-1</guid><ev:startdate>2026-04-01T17:00:00Z</ev:startdate></item>
+	<item><title>ICSE papers due Sep 1, 2026</title><link>https://example.com/icse</link><guid>cfp-2</guid></item>
+	<item><title>ICSE papers due Sep 1, 2026 (again)</title><guid>cfp-2</guid></item>
+	<item><title>Long gone 2025-01-10</title><guid>cfp-3</guid></item>
+	<item><title>No date at all</title><guid>cfp-4</guid><pubDate>Mon, 02 Mar 2026 10:00:00 +0000</pubDate></item>
+</channel></rss>`
+	api.pages["/term.atom"] = `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom"><title>Term dates</title>
+	<entry><title>Exams begin</title><id>urn:term:exams</id><link rel="alternate" href="https://uni.example/exams"/><published>2026-05-11T09:00:00Z</published></entry>
+</feed>`
+	now := clock.Fixed(time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC))
+
+	rss := NewFeed(config.FeedSourceConfig{URL: api.server.URL + "/cfp.rss", Tag: "cfp"}, 9*time.Hour)
+	rss.Clock = now
+	got, err := rss.Fetch()
+	if err != nil {
+		t.Fatalf("Fetch() error: %v", err)
+	}
+	var descs []string
+	for _, r := range got {
+		descs = append(descs, r.Description)
+	}
+	if strings.Join(descs, "|") != "GopherCon talks|ICSE papers due Sep 1, 2026" {
+		t.Fatalf("Fetch() = %q, want dated entries once each, without the long-gone one", descs)
+	}
+	if want := time.Date(2026, 4, 1, 17, 0, 0, 0, time.UTC); !got[0].DateTime.Equal(want) || got[0].SourceFile != "https://example.com/gophercon" || got[0].Tags[0] != "cfp" {
+		t.Errorf("event entry due %v from %s, tags %v", got[0].DateTime, got[0].SourceFile, got[0].Tags)
+	}
+	if want := time.Date(2026, 9, 1, 9, 0, 0, 0, time.Local); !got[1].DateTime.Equal(want) {
+		t.Errorf("date in title = %v, want %v", got[1].DateTime, want)
+	}
+	if got[1].ID != reminder.FileID(rss.Name(), "cfp-2") {
+		t.Errorf("ID should come from the GUID, got %s", got[1].ID)
+	}
+	if rss.Interval() != 24*time.Hour {
+		t.Errorf("Interval() = %v, want daily by default", rss.Interval())
+	}
+
+	atom := NewFeed(config.FeedSourceConfig{URL: api.server.URL + "/term.atom", Published: true}, 9*time.Hour)
+	atom.Clock = now
+	got, err = atom.Fetch()
+	if err != nil {
+		t.Fatalf("Fetch() error: %v", err)
+	}
+	if len(got) != 1 || got[0].SourceFile != "https://uni.example/exams" || !got[0].DateTime.Equal(time.Date(2026, 5, 11, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("Atom Fetch() = %+v, want the entry at its published time", got)
+	}
+}
+
+func TestDueOnDSTDay(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("no time zone data: %v", err)
+	}
+	local := time.Local
+	time.Local = loc
+	t.Cleanup(func() { time.Local = local })
+
+	// The clocks go forward at 2am on 2026-03-08 and back on 2026-11-01
+	for _, s := range []string{"2026-03-08", "2026-11-01T15:04:05Z"} {
+		got, ok := dueOn(s, 9*time.Hour+30*time.Minute)
+		if !ok || got.Hour() != 9 || got.Minute() != 30 {
+			t.Errorf("dueOn(%q) = %v, %v, want 9:30 that day", s, got, ok)
+		}
+	}
+}
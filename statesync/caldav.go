@@ -0,0 +1,245 @@
+package statesync
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go_remind/pkg/reminder"
+	"go_remind/pkg/state"
+)
+
+// calendarQuery asks a CalDAV collection for every VTODO with its ETag
+const calendarQuery = `<?xml version="1.0" encoding="utf-8"?>
+<c:calendar-query xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">
+  <d:prop><d:getetag/><c:calendar-data/></d:prop>
+  <c:filter><c:comp-filter name="VCALENDAR"><c:comp-filter name="VTODO"/></c:comp-filter></c:filter>
+</c:calendar-query>`
+
+// CalDAVSync syncs reminders with a CalDAV task list, one VTODO per
+// reminder, so task apps on other devices can see and complete them.
+// Done reminders are COMPLETED tasks; every other status is NEEDS-ACTION.
+// Tasks without a due date are left alone.
+type CalDAVSync struct {
+	URL      string // Collection URL of the task list
+	Username string
+	Password string
+	Client   *http.Client
+	BasePath string // Local copy of the last synced state, used as the merge base
+}
+
+// NewCalDAVSync creates a CalDAVSync for the task list at url
+func NewCalDAVSync(url, username, password, basePath string) *CalDAVSync {
+	return &CalDAVSync{
+		URL:      url,
+		Username: username,
+		Password: password,
+		Client:   &http.Client{Timeout: 30 * time.Second},
+		BasePath: basePath,
+	}
+}
+
+// remoteTodo is a task as stored on the server
+type remoteTodo struct {
+	href   string
+	etag   string
+	data   string
+	fields todoFields
+}
+
+// Sync merges local reminders with the tasks on the server, then writes
+// back the tasks that differ and deletes the ones removed locally
+func (c *CalDAVSync) Sync(local []*reminder.Reminder) ([]*reminder.Reminder, error) {
+	base, err := loadBase(c.BasePath)
+	if err != nil {
+		return nil, err
+	}
+
+attempts:
+	for attempt := 0; attempt < maxConflictRetries; attempt++ {
+		todos, err := c.list()
+		if err != nil {
+			return nil, err
+		}
+		now := time.Now()
+
+		// Tasks are read on top of the reminders they came from, so fields
+		// a VTODO doesn't carry, like the source file, are kept
+		known := indexByID(base)
+		for _, l := range local {
+			known[l.ID] = l
+		}
+		byID := make(map[string]remoteTodo, len(todos))
+		remote := make([]*reminder.Reminder, 0, len(todos))
+		for _, t := range todos {
+			r := &reminder.Reminder{SourceFile: reminder.StandaloneSource}
+			if k, ok := known[t.fields.uid]; ok {
+				copied := *k
+				r = &copied
+			}
+			t.fields.apply(r, now)
+			byID[r.ID] = t
+			remote = append(remote, r)
+		}
+
+		merged := Merge(base, Snapshot(local), remote)
+		keep := make(map[string]bool, len(merged))
+		for _, r := range merged {
+			keep[r.ID] = true
+			t, onServer := byID[r.ID]
+			var err error
+			switch {
+			case !onServer:
+				err = c.put(c.href(r.ID), newTodo(r, now), "")
+			case !t.fields.matches(r):
+				err = c.put(t.href, patchTodo(t.data, r, now), t.etag)
+			}
+			if errors.Is(err, ErrPreconditionFailed) {
+				continue attempts
+			}
+			if err != nil {
+				return merged, fmt.Errorf("upload failed: %w", err)
+			}
+		}
+		for id, t := range byID {
+			if keep[id] {
+				continue
+			}
+			err := c.delete(t.href, t.etag)
+			if errors.Is(err, ErrPreconditionFailed) {
+				continue attempts
+			}
+			if err != nil {
+				return merged, fmt.Errorf("delete failed: %w", err)
+			}
+		}
+
+		data, err := state.Marshal(merged)
+		if err != nil {
+			return nil, err
+		}
+		return merged, saveBase(c.BasePath, data)
+	}
+
+	return nil, fmt.Errorf("gave up after %d conflicting writes", maxConflictRetries)
+}
+
+// href returns the URL a new task for the reminder with id is created at
+func (c *CalDAVSync) href(id string) string {
+	return strings.TrimSuffix(c.URL, "/") + "/" + url.PathEscape(id) + ".ics"
+}
+
+// multistatus is the part of a calendar-query response that's read
+type multistatus struct {
+	Responses []struct {
+		Href     string `xml:"DAV: href"`
+		Propstat []struct {
+			Prop struct {
+				ETag string `xml:"DAV: getetag"`
+				Data string `xml:"urn:ietf:params:xml:ns:caldav calendar-data"`
+			} `xml:"DAV: prop"`
+		} `xml:"DAV: propstat"`
+	} `xml:"DAV: response"`
+}
+
+// list fetches every task in the collection that has a due date
+func (c *CalDAVSync) list() ([]remoteTodo, error) {
+	req, err := http.NewRequest("REPORT", c.URL, strings.NewReader(calendarQuery))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Depth", "1")
+	req.Header.Set("Content-Type", "application/xml; charset=utf-8")
+	resp, err := c.Client.Do(c.authorize(req))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusMultiStatus {
+		return nil, fmt.Errorf("REPORT %s: %s", req.URL.Redacted(), resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var ms multistatus
+	if err := xml.Unmarshal(body, &ms); err != nil {
+		return nil, fmt.Errorf("invalid REPORT response: %w", err)
+	}
+
+	collection, err := url.Parse(c.URL)
+	if err != nil {
+		return nil, err
+	}
+	var todos []remoteTodo
+	for _, r := range ms.Responses {
+		href, err := url.Parse(r.Href)
+		if err != nil {
+			continue
+		}
+		for _, ps := range r.Propstat {
+			fields, ok := parseTodo(ps.Prop.Data)
+			if !ok || !fields.hasDue {
+				continue
+			}
+			todos = append(todos, remoteTodo{
+				href:   collection.ResolveReference(href).String(),
+				etag:   ps.Prop.ETag,
+				data:   ps.Prop.Data,
+				fields: fields,
+			})
+		}
+	}
+	return todos, nil
+}
+
+// put writes a task if its ETag still matches etag (an empty etag means
+// the task must not exist yet)
+func (c *CalDAVSync) put(href, ics, etag string) error {
+	req, err := http.NewRequest(http.MethodPut, href, strings.NewReader(ics))
+	if err != nil {
+		return err
+	}
+	setPrecondition(req, etag)
+	req.Header.Set("Content-Type", "text/calendar; charset=utf-8")
+	return doPut(c.Client, c.authorize(req))
+}
+
+// delete removes a task if it hasn't changed since it was listed
+func (c *CalDAVSync) delete(href, etag string) error {
+	req, err := http.NewRequest(http.MethodDelete, href, nil)
+	if err != nil {
+		return err
+	}
+	if etag != "" {
+		req.Header.Set("If-Match", etag)
+	}
+	resp, err := c.Client.Do(c.authorize(req))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	case http.StatusPreconditionFailed:
+		return ErrPreconditionFailed
+	default:
+		return fmt.Errorf("DELETE %s: %s", req.URL.Redacted(), resp.Status)
+	}
+}
+
+func (c *CalDAVSync) authorize(req *http.Request) *http.Request {
+	if c.Username != "" {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+	return req
+}
@@ -0,0 +1,183 @@
+package statesync
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go_remind/pkg/reminder"
+)
+
+// fakeCalDAV is an in-memory CalDAV collection of .ics files
+type fakeCalDAV struct {
+	mu       sync.Mutex
+	tasks    map[string]string // path -> iCalendar data
+	versions map[string]int
+}
+
+func newFakeCalDAV() *fakeCalDAV {
+	return &fakeCalDAV{tasks: map[string]string{}, versions: map[string]int{}}
+}
+
+func (f *fakeCalDAV) etag(path string) string {
+	return fmt.Sprintf(`"%s-%d"`, path, f.versions[path])
+}
+
+// set stores a task as if a task app on another device wrote it
+func (f *fakeCalDAV) set(path, ics string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.tasks[path] = ics
+	f.versions[path]++
+}
+
+func (f *fakeCalDAV) get(path string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.tasks[path]
+}
+
+func (f *fakeCalDAV) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	path := r.URL.Path
+	_, exists := f.tasks[path]
+	switch r.Method {
+	case "REPORT":
+		paths := make([]string, 0, len(f.tasks))
+		for p := range f.tasks {
+			paths = append(paths, p)
+		}
+		sort.Strings(paths)
+		var b strings.Builder
+		b.WriteString(`<?xml version="1.0"?><d:multistatus xmlns:d="DAV:" xmlns:cal="urn:ietf:params:xml:ns:caldav">`)
+		for _, p := range paths {
+			fmt.Fprintf(&b, `<d:response><d:href>%s</d:href><d:propstat><d:prop><d:getetag>%s</d:getetag><cal:calendar-data>%s</cal:calendar-data></d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat></d:response>`,
+				p, strings.ReplaceAll(f.etag(p), `"`, "&quot;"), f.tasks[p])
+		}
+		b.WriteString(`</d:multistatus>`)
+		w.WriteHeader(http.StatusMultiStatus)
+		io.WriteString(w, b.String())
+	case http.MethodPut:
+		if match := r.Header.Get("If-Match"); match != "" && (!exists || match != f.etag(path)) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		if r.Header.Get("If-None-Match") == "*" && exists {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		data, _ := io.ReadAll(r.Body)
+		f.tasks[path] = string(data)
+		f.versions[path]++
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodDelete:
+		if match := r.Header.Get("If-Match"); match != "" && match != f.etag(path) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		delete(f.tasks, path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestCalDAVSync(t *testing.T) {
+	dav := newFakeCalDAV()
+	server := httptest.NewServer(dav)
+	defer server.Close()
+	c := NewCalDAVSync(server.URL+"/tasks/", "", "", filepath.Join(t.TempDir(), "base.json"))
+
+	now := time.Now().Truncate(time.Second)
+	due := now.Add(24 * time.Hour)
+	report := &reminder.Reminder{ID: "a", Description: "Send report, v2", Tags: []string{"work"}, DateTime: due, SourceFile: "notes.md", LineNumber: 3, UpdatedAt: now}
+	done := &reminder.Reminder{ID: "b", Description: "Pay rent", DateTime: due, Status: reminder.Acknowledged, AcknowledgedAt: now, UpdatedAt: now}
+
+	local, err := c.Sync([]*reminder.Reminder{report, done})
+	if err != nil {
+		t.Fatalf("Sync() error: %v", err)
+	}
+	if len(local) != 2 || len(dav.tasks) != 2 {
+		t.Fatalf("%d reminders, %d tasks after first sync; want 2 and 2", len(local), len(dav.tasks))
+	}
+	ics := dav.get("/tasks/a.ics")
+	for _, want := range []string{"SUMMARY:Send report\\, v2", "STATUS:NEEDS-ACTION", "CATEGORIES:work", "DUE:" + due.UTC().Format(icsTimeFormat)} {
+		if !strings.Contains(ics, want) {
+			t.Errorf("task a missing %q:\n%s", want, ics)
+		}
+	}
+	if !strings.Contains(dav.get("/tasks/b.ics"), "STATUS:COMPLETED") {
+		t.Errorf("done reminder should be a COMPLETED task:\n%s", dav.get("/tasks/b.ics"))
+	}
+
+	// The phone completes the report and adds a task with an alarm; a task
+	// without a due date is ignored
+	later := now.Add(time.Minute).UTC().Format(icsTimeFormat)
+	dav.set("/tasks/a.ics", strings.Replace(strings.Replace(ics, "STATUS:NEEDS-ACTION", "STATUS:COMPLETED", 1),
+		"LAST-MODIFIED:"+now.UTC().Format(icsTimeFormat), "LAST-MODIFIED:"+later, 1))
+	dav.set("/tasks/phone.ics", "BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nUID:phone-1\r\nSUMMARY:Buy milk\r\n"+
+		"DUE;TZID=UTC:"+due.UTC().Format("20060102T150405")+"\r\nSTATUS:NEEDS-ACTION\r\nLAST-MODIFIED:"+later+"\r\n"+
+		"BEGIN:VALARM\r\nACTION:DISPLAY\r\nSUMMARY:Alarm\r\nTRIGGER:-PT15M\r\nEND:VALARM\r\nEND:VTODO\r\nEND:VCALENDAR\r\n")
+	dav.set("/tasks/undated.ics", "BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nUID:undated\r\nSUMMARY:Someday\r\nEND:VTODO\r\nEND:VCALENDAR\r\n")
+
+	local, err = c.Sync(local)
+	if err != nil {
+		t.Fatalf("second Sync() error: %v", err)
+	}
+	byID := indexByID(local)
+	if len(local) != 3 || byID["undated"] != nil {
+		t.Fatalf("got %d reminders, want the 2 plus the phone's dated task", len(local))
+	}
+	if r := byID["a"]; r.Status != reminder.Acknowledged || r.SourceFile != "notes.md" || r.LineNumber != 3 {
+		t.Errorf("report = %v from %s:%d, want done and still from notes.md:3", r.Status, r.SourceFile, r.LineNumber)
+	}
+	if r := byID["phone-1"]; r.Description != "Buy milk" || !r.DateTime.Equal(due) || r.SourceFile != reminder.StandaloneSource {
+		t.Errorf("phone task = %q at %v from %s", r.Description, r.DateTime, r.SourceFile)
+	}
+
+	// Deleting rent and renaming the phone task here reach the server,
+	// and the alarm the phone set is kept
+	milk := byID["phone-1"]
+	milk.Description = "Buy oat milk"
+	milk.UpdatedAt = now.Add(2 * time.Minute)
+	if _, err := c.Sync([]*reminder.Reminder{byID["a"], milk}); err != nil {
+		t.Fatalf("third Sync() error: %v", err)
+	}
+	if _, ok := dav.tasks["/tasks/b.ics"]; ok {
+		t.Errorf("deleted reminder's task is still on the server")
+	}
+	phone := dav.get("/tasks/phone.ics")
+	if !strings.Contains(phone, "SUMMARY:Buy oat milk") || !strings.Contains(phone, "SUMMARY:Alarm") || strings.Count(phone, "SUMMARY:") != 2 {
+		t.Errorf("phone task not patched in place:\n%s", phone)
+	}
+	if dav.get("/tasks/undated.ics") == "" {
+		t.Errorf("task without a due date was deleted")
+	}
+}
+
+func TestParseTodo(t *testing.T) {
+	long := strings.Repeat("word ", 30)
+	ics := newTodo(&reminder.Reminder{ID: "x", Description: long + "a;b\\c\nd", Tags: []string{"one", "two"},
+		DateTime: time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)}, time.Now())
+	for _, line := range strings.Split(ics, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line not folded: %q", line)
+		}
+	}
+	f, ok := parseTodo(ics)
+	if !ok || f.summary != long+"a;b\\c\nd" || strings.Join(f.categories, ",") != "one,two" {
+		t.Errorf("parseTodo() = %+v, %v; want it to read back what was written", f, ok)
+	}
+
+	date, ok := parseTodo("BEGIN:VTODO\nUID:d\nDUE;VALUE=DATE:20260304\nEND:VTODO\n")
+	if want := time.Date(2026, 3, 4, 0, 0, 0, 0, time.Local); !ok || !date.due.Equal(want) {
+		t.Errorf("all-day due = %v, want %v", date.due, want)
+	}
+}
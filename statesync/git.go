@@ -0,0 +1,136 @@
+package statesync
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go_remind/pkg/reminder"
+	"go_remind/pkg/state"
+)
+
+// GitSync syncs reminder state through a file committed to a Git repository.
+// Dir must be an existing clone with Branch checked out.
+type GitSync struct {
+	Dir    string // Local clone of the sync repo
+	Remote string // Remote name, e.g. "origin"
+	Branch string // Branch to sync on, e.g. "main"
+	File   string // State file path within the repo
+}
+
+// Sync merges local reminders with the remote copy, commits, and pushes.
+// The merged reminders are returned even when the push fails, so the caller
+// can keep working offline and retry on the next sync.
+func (g *GitSync) Sync(local []*reminder.Reminder) ([]*reminder.Reminder, error) {
+	remoteRef := g.Remote + "/" + g.Branch
+
+	// Fetch may fail if the remote branch doesn't exist yet (first sync)
+	_, fetchErr := g.git("fetch", g.Remote, g.Branch)
+	_, refErr := g.git("rev-parse", "--verify", "--quiet", remoteRef)
+	hasRemote := fetchErr == nil && refErr == nil
+	_, headErr := g.git("rev-parse", "--verify", "--quiet", "HEAD")
+	hasHead := headErr == nil
+
+	var base, remote []*reminder.Reminder
+	var err error
+	if hasRemote {
+		if remote, err = g.readAt(remoteRef); err != nil {
+			return nil, err
+		}
+		if hasHead {
+			if mergeBase, err := g.git("merge-base", "HEAD", remoteRef); err == nil {
+				if base, err = g.readAt(mergeBase); err != nil {
+					return nil, err
+				}
+			}
+		}
+	} else if hasHead {
+		if base, err = g.readAt("HEAD"); err != nil {
+			return nil, err
+		}
+		remote = base
+	}
+
+	merged := Merge(base, Snapshot(local), remote)
+
+	// Build the new commit on top of the remote branch so the push fast-forwards.
+	// Local-only commits are safe to drop: their content is already in local.
+	if hasRemote {
+		if _, err := g.git("reset", "--hard", remoteRef); err != nil {
+			return merged, err
+		}
+	}
+
+	data, err := state.Marshal(merged)
+	if err != nil {
+		return merged, err
+	}
+	if err := os.WriteFile(filepath.Join(g.Dir, g.File), data, 0644); err != nil {
+		return merged, err
+	}
+	if _, err := g.git("add", g.File); err != nil {
+		return merged, err
+	}
+
+	_, diffErr := g.git("diff", "--cached", "--quiet")
+	changed := diffErr != nil
+	if changed || !hasHead {
+		host, _ := os.Hostname()
+		msg := fmt.Sprintf("go_remind sync from %s at %s", host, time.Now().Format(time.RFC3339))
+		if _, err := g.git("commit", "--allow-empty", "-m", msg); err != nil {
+			return merged, err
+		}
+	}
+
+	// Nothing to push if the merged state matches the remote
+	if hasRemote && !changed {
+		return merged, nil
+	}
+	if _, err := g.git("push", g.Remote, "HEAD:"+g.Branch); err != nil {
+		return merged, fmt.Errorf("push failed: %w", err)
+	}
+	return merged, nil
+}
+
+// readAt reads the state file as of the given revision.
+// A revision without the file yields no reminders.
+func (g *GitSync) readAt(rev string) ([]*reminder.Reminder, error) {
+	path := filepath.ToSlash(g.File)
+	// ls-tree lists nothing, without failing, when rev lacks the file; any
+	// other failure has to stop the sync rather than look like no reminders
+	listed, err := g.git("ls-tree", "--name-only", rev, "--", path)
+	if err != nil {
+		return nil, err
+	}
+	if listed == "" {
+		return nil, nil
+	}
+	data, err := g.git("show", rev+":"+path)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(data) == "" {
+		return nil, nil
+	}
+	return state.Unmarshal([]byte(data))
+}
+
+// git runs a git command in the sync repo and returns its trimmed stdout
+func (g *GitSync) git(args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", g.Dir}, args...)...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			return "", fmt.Errorf("git %s: %w", args[0], err)
+		}
+		return "", fmt.Errorf("git %s: %s", args[0], msg)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
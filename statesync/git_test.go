@@ -0,0 +1,61 @@
+package statesync
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"go_remind/pkg/reminder"
+	"go_remind/pkg/state"
+)
+
+func TestGitReadAt(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	for _, who := range []string{"AUTHOR", "COMMITTER"} {
+		t.Setenv("GIT_"+who+"_NAME", "Test")
+		t.Setenv("GIT_"+who+"_EMAIL", "test@example.com")
+	}
+	dir := t.TempDir()
+	g := &GitSync{Dir: dir, File: "sync/reminders.json"}
+	commit := func(name string, data []byte) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+		for _, args := range [][]string{{"add", name}, {"commit", "-m", "add " + name}} {
+			if _, err := g.git(args...); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if _, err := g.git("init"); err != nil {
+		t.Fatal(err)
+	}
+
+	// A revision without the state file has no reminders
+	commit("README.md", []byte("notes"))
+	if got, err := g.readAt("HEAD"); got != nil || err != nil {
+		t.Errorf("readAt() without the file = %v, %v; want nothing and no error", got, err)
+	}
+
+	// A revision that can't be read is an error, not an empty remote
+	if _, err := g.readAt("origin/missing"); err == nil {
+		t.Error("readAt() of a missing revision should fail")
+	}
+
+	data, err := state.Marshal([]*reminder.Reminder{{ID: "a", Description: "Pay rent"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	commit(g.File, data)
+	if got, err := g.readAt("HEAD"); err != nil || len(got) != 1 || got[0].Description != "Pay rent" {
+		t.Errorf("readAt() = %v, %v; want the committed reminder", got, err)
+	}
+}
@@ -0,0 +1,72 @@
+package statesync
+
+import (
+	"go_remind/pkg/reminder"
+)
+
+// Merge performs a three-way merge of two reminder sets keyed by reminder ID.
+// base is the last state both sides agreed on and is used to tell deletions
+// apart from additions:
+//   - Reminders present on both sides keep the most recently updated version
+//   - Reminders only on one side are kept if they are new (absent from base)
+//     or were changed since base; otherwise the other side deleted them
+//
+// Reminders from local are updated in place so existing pointers stay valid.
+func Merge(base, local, remote []*reminder.Reminder) []*reminder.Reminder {
+	baseByID := indexByID(base)
+	remoteByID := indexByID(remote)
+	localIDs := make(map[string]bool, len(local))
+
+	var result []*reminder.Reminder
+	for _, l := range local {
+		localIDs[l.ID] = true
+
+		r, inRemote := remoteByID[l.ID]
+		if inRemote {
+			if r.UpdatedAt.After(l.UpdatedAt) {
+				*l = *r
+			}
+			result = append(result, l)
+			continue
+		}
+
+		// Only local: deleted remotely unless new or changed since base
+		if b, inBase := baseByID[l.ID]; inBase && !l.UpdatedAt.After(b.UpdatedAt) {
+			continue
+		}
+		result = append(result, l)
+	}
+
+	for _, r := range remote {
+		if localIDs[r.ID] {
+			continue
+		}
+
+		// Only remote: deleted locally unless new or changed since base
+		if b, inBase := baseByID[r.ID]; inBase && !r.UpdatedAt.After(b.UpdatedAt) {
+			continue
+		}
+		result = append(result, r)
+	}
+
+	reminder.SortByDateTime(result)
+	return result
+}
+
+// Snapshot returns copies of reminders that are safe to use from another goroutine
+func Snapshot(reminders []*reminder.Reminder) []*reminder.Reminder {
+	snapshot := make([]*reminder.Reminder, len(reminders))
+	for i, r := range reminders {
+		c := *r
+		snapshot[i] = &c
+	}
+	return snapshot
+}
+
+func indexByID(reminders []*reminder.Reminder) map[string]*reminder.Reminder {
+	byID := make(map[string]*reminder.Reminder, len(reminders))
+	for _, r := range reminders {
+		byID[r.ID] = r
+	}
+	return byID
+}
@@ -0,0 +1,154 @@
+package statesync
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go_remind/pkg/reminder"
+)
+
+func TestMerge(t *testing.T) {
+	t0 := time.Date(2026, 1, 13, 12, 0, 0, 0, time.Local)
+	t1 := t0.Add(time.Minute)
+	t2 := t0.Add(2 * time.Minute)
+
+	mk := func(id, desc string, updated time.Time) *reminder.Reminder {
+		return &reminder.Reminder{ID: id, Description: desc, DateTime: t0, UpdatedAt: updated}
+	}
+
+	tests := []struct {
+		name   string
+		base   []*reminder.Reminder
+		local  []*reminder.Reminder
+		remote []*reminder.Reminder
+		want   map[string]string // ID -> description
+	}{
+		{
+			name:   "newest remote edit wins",
+			base:   []*reminder.Reminder{mk("a", "orig", t0)},
+			local:  []*reminder.Reminder{mk("a", "local", t1)},
+			remote: []*reminder.Reminder{mk("a", "remote", t2)},
+			want:   map[string]string{"a": "remote"},
+		},
+		{
+			name:   "newest local edit wins",
+			base:   []*reminder.Reminder{mk("a", "orig", t0)},
+			local:  []*reminder.Reminder{mk("a", "local", t2)},
+			remote: []*reminder.Reminder{mk("a", "remote", t1)},
+			want:   map[string]string{"a": "local"},
+		},
+		{
+			name:   "additions on both sides are kept",
+			local:  []*reminder.Reminder{mk("a", "local", t1)},
+			remote: []*reminder.Reminder{mk("b", "remote", t1)},
+			want:   map[string]string{"a": "local", "b": "remote"},
+		},
+		{
+			name:   "remote deletion removes unchanged local",
+			base:   []*reminder.Reminder{mk("a", "orig", t0), mk("b", "keep", t0)},
+			local:  []*reminder.Reminder{mk("a", "orig", t0), mk("b", "keep", t0)},
+			remote: []*reminder.Reminder{mk("b", "keep", t0)},
+			want:   map[string]string{"b": "keep"},
+		},
+		{
+			name:   "local deletion removes unchanged remote",
+			base:   []*reminder.Reminder{mk("a", "orig", t0)},
+			local:  nil,
+			remote: []*reminder.Reminder{mk("a", "orig", t0)},
+			want:   map[string]string{},
+		},
+		{
+			name:   "edit beats concurrent deletion",
+			base:   []*reminder.Reminder{mk("a", "orig", t0)},
+			local:  nil,
+			remote: []*reminder.Reminder{mk("a", "edited", t1)},
+			want:   map[string]string{"a": "edited"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Merge(tt.base, tt.local, tt.remote)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Merge() returned %d reminders, want %d", len(got), len(tt.want))
+			}
+			for _, r := range got {
+				if want, ok := tt.want[r.ID]; !ok || r.Description != want {
+					t.Errorf("reminder %s = %q, want %q", r.ID, r.Description, want)
+				}
+			}
+		})
+	}
+}
+
+func TestMergeUpdatesLocalInPlace(t *testing.T) {
+	t0 := time.Date(2026, 1, 13, 12, 0, 0, 0, time.Local)
+	local := &reminder.Reminder{ID: "a", Description: "old", UpdatedAt: t0}
+	remote := &reminder.Reminder{ID: "a", Description: "new", UpdatedAt: t0.Add(time.Minute)}
+
+	got := Merge(nil, []*reminder.Reminder{local}, []*reminder.Reminder{remote})
+	if len(got) != 1 || got[0] != local {
+		t.Fatalf("Merge() should reuse the local pointer")
+	}
+	if local.Description != "new" {
+		t.Errorf("local.Description = %q, want %q", local.Description, "new")
+	}
+}
+
+func TestGitSyncBetweenClones(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	root := t.TempDir()
+	remoteDir := filepath.Join(root, "remote.git")
+	run := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	run(root, "init", "--quiet", "--bare", "--initial-branch=main", remoteDir)
+
+	clone := func(name string) *GitSync {
+		dir := filepath.Join(root, name)
+		run(root, "clone", "--quiet", remoteDir, dir)
+		run(dir, "checkout", "--quiet", "-B", "main")
+		run(dir, "config", "user.name", "test")
+		run(dir, "config", "user.email", "test@example.com")
+		return &GitSync{Dir: dir, Remote: "origin", Branch: "main", File: "state.json"}
+	}
+	laptop := clone("laptop")
+	desktop := clone("desktop")
+
+	now := time.Now().Truncate(time.Second)
+	a := &reminder.Reminder{ID: "a", Description: "From laptop", DateTime: now, UpdatedAt: now}
+	if _, err := laptop.Sync([]*reminder.Reminder{a}); err != nil {
+		t.Fatalf("laptop Sync() error: %v", err)
+	}
+
+	b := &reminder.Reminder{ID: "b", Description: "From desktop", DateTime: now, UpdatedAt: now}
+	merged, err := desktop.Sync([]*reminder.Reminder{b})
+	if err != nil {
+		t.Fatalf("desktop Sync() error: %v", err)
+	}
+	if len(merged) != 2 {
+		t.Fatalf("desktop has %d reminders after sync, want 2", len(merged))
+	}
+
+	merged, err = laptop.Sync([]*reminder.Reminder{a})
+	if err != nil {
+		t.Fatalf("laptop second Sync() error: %v", err)
+	}
+	if len(merged) != 2 {
+		t.Errorf("laptop has %d reminders after sync, want 2", len(merged))
+	}
+}
@@ -0,0 +1,102 @@
+package statesync
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go_remind/pkg/reminder"
+	"go_remind/pkg/state"
+)
+
+// maxConflictRetries bounds how often a sync retries after losing an ETag race
+const maxConflictRetries = 3
+
+var (
+	// ErrNotFound is returned by ObjectStore.Get when no remote state exists yet
+	ErrNotFound = errors.New("remote state not found")
+	// ErrPreconditionFailed is returned by ObjectStore.Put when the remote
+	// changed since it was read
+	ErrPreconditionFailed = errors.New("remote state changed concurrently")
+)
+
+// ObjectStore is a remote location holding a single state file
+type ObjectStore interface {
+	// Get returns the remote file and its ETag, or ErrNotFound
+	Get() (data []byte, etag string, err error)
+	// Put writes the remote file only if its ETag still matches etag
+	// (an empty etag means the file must not exist yet)
+	Put(data []byte, etag string) error
+}
+
+// ObjectSync syncs reminder state through an ObjectStore using optimistic
+// concurrency: a write only succeeds if nobody else wrote in between,
+// otherwise the remote is downloaded and merged again.
+type ObjectSync struct {
+	Store    ObjectStore
+	BasePath string // Local copy of the last synced state, used as the merge base
+}
+
+// Sync merges local reminders with the remote copy and uploads the result
+func (o *ObjectSync) Sync(local []*reminder.Reminder) ([]*reminder.Reminder, error) {
+	base, err := loadBase(o.BasePath)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; attempt < maxConflictRetries; attempt++ {
+		remoteData, etag, err := o.Store.Get()
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+
+		var remote []*reminder.Reminder
+		if len(remoteData) > 0 {
+			if remote, err = state.Unmarshal(remoteData); err != nil {
+				return nil, fmt.Errorf("invalid remote state: %w", err)
+			}
+		}
+
+		merged := Merge(base, Snapshot(local), remote)
+		data, err := state.Marshal(merged)
+		if err != nil {
+			return nil, err
+		}
+
+		if !bytes.Equal(data, remoteData) {
+			err = o.Store.Put(data, etag)
+			if errors.Is(err, ErrPreconditionFailed) {
+				continue
+			}
+			if err != nil {
+				return merged, fmt.Errorf("upload failed: %w", err)
+			}
+		}
+
+		return merged, saveBase(o.BasePath, data)
+	}
+
+	return nil, fmt.Errorf("gave up after %d conflicting writes", maxConflictRetries)
+}
+
+// loadBase reads the last synced state kept at path, the base of the next
+// three-way merge
+func loadBase(path string) ([]*reminder.Reminder, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return state.Unmarshal(data)
+}
+
+func saveBase(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
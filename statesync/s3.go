@@ -0,0 +1,152 @@
+package statesync
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// S3Store stores the state file in an S3-compatible bucket (AWS, MinIO, R2, ...)
+// using path-style URLs and AWS Signature Version 4
+type S3Store struct {
+	Endpoint  string // e.g. https://s3.us-east-1.amazonaws.com
+	Region    string
+	Bucket    string
+	Key       string // Object key of the state file
+	AccessKey string
+	SecretKey string
+	Client    *http.Client
+
+	now func() time.Time // Overridable for tests
+}
+
+// NewS3Store creates an S3Store for the given bucket and key
+func NewS3Store(endpoint, region, bucket, key, accessKey, secretKey string) *S3Store {
+	return &S3Store{
+		Endpoint:  strings.TrimSuffix(endpoint, "/"),
+		Region:    region,
+		Bucket:    bucket,
+		Key:       strings.TrimPrefix(key, "/"),
+		AccessKey: accessKey,
+		SecretKey: secretKey,
+		Client:    &http.Client{Timeout: 30 * time.Second},
+		now:       time.Now,
+	}
+}
+
+// Get downloads the state object
+func (s *S3Store) Get() ([]byte, string, error) {
+	req, err := s.newRequest(http.MethodGet, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	s.sign(req, nil)
+	return doGet(s.Client, req)
+}
+
+// Put uploads the state object if the remote ETag still matches
+func (s *S3Store) Put(data []byte, etag string) error {
+	req, err := s.newRequest(http.MethodPut, data)
+	if err != nil {
+		return err
+	}
+	setPrecondition(req, etag)
+	req.Header.Set("Content-Type", "application/json")
+	s.sign(req, data)
+	return doPut(s.Client, req)
+}
+
+func (s *S3Store) newRequest(method string, body []byte) (*http.Request, error) {
+	u, err := url.Parse(s.Endpoint + "/" + s.Bucket + "/" + s.Key)
+	if err != nil {
+		return nil, err
+	}
+	return http.NewRequest(method, u.String(), bytes.NewReader(body))
+}
+
+// sign adds AWS Signature Version 4 headers to req. All headers already set on
+// req are signed, so preconditions must be set before calling sign.
+func (s *S3Store) sign(req *http.Request, payload []byte) {
+	now := s.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(payload)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	// Canonical headers: host plus every header on the request, sorted
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		uriEncodePath(req.URL.Path),
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.Region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+s.SecretKey), date)
+	key = hmacSHA256(key, s.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.AccessKey, scope, signedHeaders, signature))
+}
+
+// uriEncodePath encodes each path segment as required by SigV4
+func uriEncodePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, seg := range segments {
+		var b strings.Builder
+		for _, c := range []byte(seg) {
+			if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') ||
+				c == '-' || c == '_' || c == '.' || c == '~' {
+				b.WriteByte(c)
+			} else {
+				fmt.Fprintf(&b, "%%%02X", c)
+			}
+		}
+		segments[i] = b.String()
+	}
+	return strings.Join(segments, "/")
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,68 @@
+package statesync
+
+import (
+	"fmt"
+	"os"
+
+	"go_remind/config"
+	"go_remind/pkg/reminder"
+)
+
+// Syncer exchanges reminder state with a remote copy
+type Syncer interface {
+	// Sync merges local reminders with the remote copy and publishes the result.
+	// The merged reminders may be returned alongside an error if publishing failed.
+	Sync(local []*reminder.Reminder) ([]*reminder.Reminder, error)
+}
+
+// New creates the Syncer configured in cfg
+func New(cfg config.SyncConfig) (Syncer, error) {
+	switch cfg.Backend {
+	case "git":
+		return &GitSync{
+			Dir:    config.ExpandPath(cfg.Git.Dir),
+			Remote: cfg.Git.Remote,
+			Branch: cfg.Git.Branch,
+			File:   cfg.Git.File,
+		}, nil
+	case "s3":
+		s3 := cfg.S3
+		if s3.Bucket == "" {
+			return nil, fmt.Errorf("sync.s3.bucket is required")
+		}
+		accessKey := firstNonEmpty(s3.AccessKey, os.Getenv("AWS_ACCESS_KEY_ID"))
+		secretKey := firstNonEmpty(s3.SecretKey, os.Getenv("AWS_SECRET_ACCESS_KEY"))
+		return &ObjectSync{
+			Store:    NewS3Store(s3.Endpoint, s3.Region, s3.Bucket, s3.Key, accessKey, secretKey),
+			BasePath: config.ExpandPath("~/.go_remind/sync_base_s3.json"),
+		}, nil
+	case "webdav":
+		dav := cfg.WebDAV
+		if dav.URL == "" {
+			return nil, fmt.Errorf("sync.webdav.url is required")
+		}
+		password := firstNonEmpty(dav.Password, os.Getenv("GO_REMIND_WEBDAV_PASSWORD"))
+		return &ObjectSync{
+			Store:    NewWebDAVStore(dav.URL, dav.Username, password),
+			BasePath: config.ExpandPath("~/.go_remind/sync_base_webdav.json"),
+		}, nil
+	case "caldav":
+		dav := cfg.CalDAV
+		if dav.URL == "" {
+			return nil, fmt.Errorf("sync.caldav.url is required")
+		}
+		password := firstNonEmpty(dav.Password, os.Getenv("GO_REMIND_CALDAV_PASSWORD"))
+		return NewCalDAVSync(dav.URL, dav.Username, password, config.ExpandPath("~/.go_remind/sync_base_caldav.json")), nil
+	default:
+		return nil, fmt.Errorf("unknown sync backend %q", cfg.Backend)
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
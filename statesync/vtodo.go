@@ -0,0 +1,310 @@
+package statesync
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"go_remind/pkg/reminder"
+)
+
+const (
+	icsTimeFormat = "20060102T150405Z"
+	icsDateFormat = "20060102"
+)
+
+// ownedProps are the VTODO properties go_remind writes. Any others a task
+// app added are left as they are.
+var ownedProps = []string{"SUMMARY", "DUE", "STATUS", "COMPLETED", "PERCENT-COMPLETE", "CATEGORIES", "LAST-MODIFIED", "DTSTAMP"}
+
+// icsProp is one unfolded content line: NAME;PARAMS:VALUE
+type icsProp struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// todoFields is what a VTODO says about a reminder
+type todoFields struct {
+	uid        string
+	summary    string
+	due        time.Time
+	hasDue     bool
+	done       bool
+	completed  time.Time
+	categories []string
+	modified   time.Time
+}
+
+// parseTodo reads the first VTODO in an iCalendar object
+func parseTodo(ics string) (todoFields, bool) {
+	var f todoFields
+	inTodo, found, nested := false, false, 0
+	for _, line := range unfold(ics) {
+		p := parseProp(line)
+		switch {
+		case p.name == "BEGIN" && p.value == "VTODO":
+			inTodo, found = true, true
+		case p.name == "END" && p.value == "VTODO":
+			return f, f.uid != ""
+		case !inTodo:
+		case p.name == "BEGIN":
+			nested++
+		case p.name == "END":
+			nested--
+		case nested > 0:
+		case p.name == "UID":
+			f.uid = p.value
+		case p.name == "SUMMARY":
+			f.summary = unescapeText(p.value)
+		case p.name == "DUE":
+			f.due, f.hasDue = parseICSTime(p)
+		case p.name == "STATUS":
+			f.done = p.value == "COMPLETED" || p.value == "CANCELLED"
+		case p.name == "COMPLETED":
+			f.completed, _ = parseICSTime(p)
+		case p.name == "CATEGORIES":
+			for _, c := range splitText(p.value) {
+				if c = strings.TrimPrefix(strings.TrimSpace(c), "#"); c != "" {
+					f.categories = append(f.categories, c)
+				}
+			}
+		case p.name == "LAST-MODIFIED":
+			f.modified, _ = parseICSTime(p)
+		case p.name == "DTSTAMP" && f.modified.IsZero():
+			// Not every task app sets LAST-MODIFIED
+			f.modified, _ = parseICSTime(p)
+		}
+	}
+	return f, found && f.uid != ""
+}
+
+// apply overlays the task's fields onto r, leaving the fields a VTODO
+// doesn't carry alone
+func (f todoFields) apply(r *reminder.Reminder, now time.Time) {
+	r.ID = f.uid
+	r.Description = f.summary
+	r.DateTime = f.due
+	r.Tags = f.categories
+	switch {
+	case f.done && r.Status != reminder.Acknowledged:
+		r.Status = reminder.Acknowledged
+		r.AcknowledgedAt = f.completed
+		if r.AcknowledgedAt.IsZero() {
+			r.AcknowledgedAt = f.modified
+		}
+	case !f.done && r.Status == reminder.Acknowledged:
+		// Reopened in the task app
+		r.Status = reminder.Pending
+		r.AcknowledgedAt = time.Time{}
+	}
+	if r.Status == reminder.Triggered && !r.IsDueAt(now) {
+		r.Status = reminder.Pending
+	}
+	r.UpdatedAt = f.modified
+}
+
+// matches reports whether the task already says what r does, to the second
+func (f todoFields) matches(r *reminder.Reminder) bool {
+	return f.summary == r.Description &&
+		f.hasDue && f.due.Truncate(time.Second).Equal(r.DateTime.Truncate(time.Second)) &&
+		f.done == (r.Status == reminder.Acknowledged) &&
+		slices.Equal(f.categories, r.Tags)
+}
+
+// todoProps returns the properties go_remind writes for r
+func todoProps(r *reminder.Reminder, now time.Time) []string {
+	status := "NEEDS-ACTION"
+	if r.Status == reminder.Acknowledged {
+		status = "COMPLETED"
+	}
+	modified := r.UpdatedAt
+	if modified.IsZero() {
+		modified = now
+	}
+	props := []string{
+		"DTSTAMP:" + now.UTC().Format(icsTimeFormat),
+		"SUMMARY:" + escapeText(r.Description),
+		"DUE:" + r.DateTime.UTC().Format(icsTimeFormat),
+		"STATUS:" + status,
+	}
+	if r.Status == reminder.Acknowledged {
+		completed := r.AcknowledgedAt
+		if completed.IsZero() {
+			completed = modified
+		}
+		props = append(props, "COMPLETED:"+completed.UTC().Format(icsTimeFormat), "PERCENT-COMPLETE:100")
+	}
+	if len(r.Tags) > 0 {
+		tags := make([]string, len(r.Tags))
+		for i, t := range r.Tags {
+			tags[i] = escapeText(t)
+		}
+		props = append(props, "CATEGORIES:"+strings.Join(tags, ","))
+	}
+	return append(props, "LAST-MODIFIED:"+modified.UTC().Format(icsTimeFormat))
+}
+
+// newTodo renders r as a new iCalendar object holding one VTODO
+func newTodo(r *reminder.Reminder, now time.Time) string {
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//go_remind//EN",
+		"BEGIN:VTODO",
+		"UID:" + r.ID,
+	}
+	lines = append(lines, todoProps(r, now)...)
+	lines = append(lines, "END:VTODO", "END:VCALENDAR")
+	return fold(lines)
+}
+
+// patchTodo rewrites the properties go_remind owns in an existing task,
+// keeping alarms, notes and anything else the task app stored
+func patchTodo(ics string, r *reminder.Reminder, now time.Time) string {
+	var lines []string
+	inTodo, nested := false, 0
+	for _, line := range unfold(ics) {
+		p := parseProp(line)
+		switch {
+		case p.name == "BEGIN" && p.value == "VTODO":
+			inTodo = true
+		case p.name == "END" && p.value == "VTODO" && inTodo:
+			lines = append(lines, todoProps(r, now)...)
+			inTodo = false
+		case !inTodo:
+		case p.name == "BEGIN":
+			nested++
+		case p.name == "END":
+			nested--
+		case nested == 0 && slices.Contains(ownedProps, p.name):
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return fold(lines)
+}
+
+// unfold splits an iCalendar object into content lines, joining lines
+// continued with a leading space or tab
+func unfold(ics string) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(ics, "\r\n", "\n"), "\n") {
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// fold joins content lines with CRLF, splitting any longer than 75 bytes
+func fold(lines []string) string {
+	var b strings.Builder
+	for _, line := range lines {
+		// Continuation lines start with a space, leaving room for 74
+		for limit := 75; len(line) > limit; limit = 74 {
+			cut := limit
+			for cut > 1 && !utf8Start(line[cut]) {
+				cut--
+			}
+			b.WriteString(line[:cut] + "\r\n ")
+			line = line[cut:]
+		}
+		b.WriteString(line + "\r\n")
+	}
+	return b.String()
+}
+
+func utf8Start(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+func parseProp(line string) icsProp {
+	// The value starts after the first colon outside a quoted parameter
+	quoted, colon := false, -1
+	for i, c := range line {
+		if c == '"' {
+			quoted = !quoted
+		} else if c == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return icsProp{name: strings.ToUpper(line)}
+	}
+	p := icsProp{value: line[colon+1:], params: map[string]string{}}
+	parts := strings.Split(line[:colon], ";")
+	p.name = strings.ToUpper(parts[0])
+	for _, param := range parts[1:] {
+		if k, v, ok := strings.Cut(param, "="); ok {
+			p.params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+		}
+	}
+	return p
+}
+
+// parseICSTime reads a DATE-TIME in UTC, in a TZID, or floating (local),
+// or a DATE as local midnight
+func parseICSTime(p icsProp) (time.Time, bool) {
+	v := p.value
+	if p.params["VALUE"] == "DATE" || len(v) == len(icsDateFormat) {
+		t, err := time.ParseInLocation(icsDateFormat, v, time.Local)
+		return t, err == nil
+	}
+	if strings.HasSuffix(v, "Z") {
+		t, err := time.Parse(icsTimeFormat, v)
+		return t.Local(), err == nil
+	}
+	loc := time.Local
+	if tzid := p.params["TZID"]; tzid != "" {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			loc = l
+		}
+	}
+	t, err := time.ParseInLocation("20060102T150405", v, loc)
+	return t.Local(), err == nil
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+func unescapeText(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+			if s[i] == 'n' || s[i] == 'N' {
+				b.WriteByte('\n')
+			} else {
+				b.WriteByte(s[i])
+			}
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// splitText splits a list value on unescaped commas
+func splitText(s string) []string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case ',':
+			parts = append(parts, unescapeText(s[start:i]))
+			start = i + 1
+		}
+	}
+	return append(parts, unescapeText(s[start:]))
+}
@@ -0,0 +1,104 @@
+package statesync
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// WebDAVStore stores the state file on a WebDAV server
+type WebDAVStore struct {
+	URL      string // Full URL of the state file
+	Username string
+	Password string
+	Client   *http.Client
+}
+
+// NewWebDAVStore creates a WebDAVStore for the file at url
+func NewWebDAVStore(url, username, password string) *WebDAVStore {
+	return &WebDAVStore{
+		URL:      url,
+		Username: username,
+		Password: password,
+		Client:   &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Get downloads the state file
+func (w *WebDAVStore) Get() ([]byte, string, error) {
+	req, err := http.NewRequest(http.MethodGet, w.URL, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	return doGet(w.Client, w.authorize(req))
+}
+
+// Put uploads the state file if the remote ETag still matches
+func (w *WebDAVStore) Put(data []byte, etag string) error {
+	req, err := http.NewRequest(http.MethodPut, w.URL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	setPrecondition(req, etag)
+	req.Header.Set("Content-Type", "application/json")
+	return doPut(w.Client, w.authorize(req))
+}
+
+func (w *WebDAVStore) authorize(req *http.Request) *http.Request {
+	if w.Username != "" {
+		req.SetBasicAuth(w.Username, w.Password)
+	}
+	return req
+}
+
+// setPrecondition makes a PUT conditional on the remote ETag
+func setPrecondition(req *http.Request, etag string) {
+	if etag == "" {
+		req.Header.Set("If-None-Match", "*")
+	} else {
+		req.Header.Set("If-Match", etag)
+	}
+}
+
+// doGet performs a GET and maps the response onto the ObjectStore contract
+func doGet(client *http.Client, req *http.Request) ([]byte, string, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, "", ErrNotFound
+	case resp.StatusCode != http.StatusOK:
+		return nil, "", fmt.Errorf("GET %s: %s", req.URL.Redacted(), resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, resp.Header.Get("ETag"), nil
+}
+
+// doPut performs a conditional PUT and maps the response onto the ObjectStore contract
+func doPut(client *http.Client, req *http.Request) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
+		return nil
+	case http.StatusPreconditionFailed, http.StatusConflict:
+		return ErrPreconditionFailed
+	default:
+		return fmt.Errorf("PUT %s: %s", req.URL.Redacted(), resp.Status)
+	}
+}
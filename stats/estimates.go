@@ -0,0 +1,69 @@
+package stats
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"go_remind/pkg/reminder"
+)
+
+// Estimates compares the estimated and tracked time of done reminders,
+// for one tag or all of them
+type Estimates struct {
+	Tag       string // Empty for every reminder, tagged or not
+	Count     int
+	Estimated time.Duration
+	Tracked   time.Duration
+}
+
+// Over returns how far the tracked time ran over the estimate, as a
+// fraction of it: 0.25 is 25% over, -0.1 is 10% under
+func (e Estimates) Over() float64 {
+	if e.Estimated <= 0 {
+		return 0
+	}
+	return float64(e.Tracked-e.Estimated) / float64(e.Estimated)
+}
+
+// EstimateAccuracy totals the estimated and tracked time of the done
+// reminders that have both, per tag with the most reminders first, and
+// across all of them
+func EstimateAccuracy(reminders []*reminder.Reminder, now time.Time) (byTag []Estimates, total Estimates) {
+	tags := map[string]*Estimates{}
+	for _, r := range reminders {
+		tracked := r.TimeSpent(now)
+		if r.Status != reminder.Acknowledged || r.Estimate <= 0 || tracked <= 0 {
+			continue
+		}
+		add := func(e *Estimates) {
+			e.Count++
+			e.Estimated += r.Estimate
+			e.Tracked += tracked
+		}
+		add(&total)
+		seen := map[string]bool{}
+		for _, tag := range r.Tags {
+			tag = strings.ToLower(tag)
+			if seen[tag] {
+				continue
+			}
+			seen[tag] = true
+			if tags[tag] == nil {
+				tags[tag] = &Estimates{Tag: tag}
+			}
+			add(tags[tag])
+		}
+	}
+
+	for _, e := range tags {
+		byTag = append(byTag, *e)
+	}
+	sort.Slice(byTag, func(i, j int) bool {
+		if byTag[i].Count != byTag[j].Count {
+			return byTag[i].Count > byTag[j].Count
+		}
+		return byTag[i].Tag < byTag[j].Tag
+	})
+	return byTag, total
+}
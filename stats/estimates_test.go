@@ -0,0 +1,40 @@
+package stats
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"go_remind/pkg/reminder"
+)
+
+func TestEstimateAccuracy(t *testing.T) {
+	now := time.Date(2026, 1, 16, 12, 0, 0, 0, time.UTC)
+	worked := func(d time.Duration) []reminder.Session {
+		return []reminder.Session{{Start: now.Add(-d), End: now}}
+	}
+	reminders := []*reminder.Reminder{
+		{Tags: []string{"work"}, Estimate: time.Hour, Sessions: worked(90 * time.Minute), Status: reminder.Acknowledged},
+		{Tags: []string{"Work", "writing"}, Estimate: time.Hour, Sessions: worked(30 * time.Minute), Status: reminder.Acknowledged},
+		{Tags: []string{"writing"}, Estimate: 2 * time.Hour, Sessions: worked(time.Hour), Status: reminder.Acknowledged},
+		{Estimate: 30 * time.Minute, Sessions: worked(45 * time.Minute), Status: reminder.Acknowledged},
+		{Tags: []string{"work"}, Estimate: time.Hour, Sessions: worked(3 * time.Hour), Status: reminder.Pending}, // Not done
+		{Tags: []string{"work"}, Sessions: worked(time.Hour), Status: reminder.Acknowledged},                     // No estimate
+		{Tags: []string{"work"}, Estimate: time.Hour, Status: reminder.Acknowledged},                             // Not tracked
+	}
+
+	byTag, total := EstimateAccuracy(reminders, now)
+	want := []Estimates{
+		{Tag: "work", Count: 2, Estimated: 2 * time.Hour, Tracked: 2 * time.Hour},
+		{Tag: "writing", Count: 2, Estimated: 3 * time.Hour, Tracked: 90 * time.Minute},
+	}
+	if len(byTag) != len(want) || byTag[0] != want[0] || byTag[1] != want[1] {
+		t.Errorf("byTag = %+v, want %+v", byTag, want)
+	}
+	if total.Count != 4 || total.Estimated != 4*time.Hour+30*time.Minute || total.Tracked != 225*time.Minute {
+		t.Errorf("total = %+v, want 4 reminders, 4h30m estimated, 3h45m tracked", total)
+	}
+	if over := byTag[1].Over(); math.Abs(over+0.5) > 1e-9 {
+		t.Errorf("writing Over() = %v, want -0.5", over)
+	}
+}
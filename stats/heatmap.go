@@ -0,0 +1,105 @@
+package stats
+
+import (
+	"time"
+
+	"go_remind/pkg/reminder"
+)
+
+// Heatmap counts reminders by day of week and hour of day
+type Heatmap [7][24]int
+
+// BuildHeatmap counts every reminder, done or not, by when it was due
+func BuildHeatmap(reminders []*reminder.Reminder) Heatmap {
+	var h Heatmap
+	for _, r := range reminders {
+		if r.DateTime.IsZero() {
+			continue
+		}
+		h[r.DateTime.Weekday()][r.DateTime.Hour()]++
+	}
+	return h
+}
+
+// Max returns the largest count in any hour
+func (h Heatmap) Max() int {
+	most := 0
+	for day := range h {
+		for hour := range h[day] {
+			most = max(most, h[day][hour])
+		}
+	}
+	return most
+}
+
+// Total returns the number of reminders counted
+func (h Heatmap) Total() int {
+	total := 0
+	for day := range h {
+		total += h.DayTotal(time.Weekday(day))
+	}
+	return total
+}
+
+// DayTotal returns the number of reminders due on a day of the week
+func (h Heatmap) DayTotal(day time.Weekday) int {
+	total := 0
+	for _, n := range h[day] {
+		total += n
+	}
+	return total
+}
+
+// HourTotal returns the number of reminders due in an hour, across all days
+func (h Heatmap) HourTotal(hour int) int {
+	total := 0
+	for day := range h {
+		total += h[day][hour]
+	}
+	return total
+}
+
+// Busiest returns the day and hour with the most reminders. The earliest
+// wins a tie.
+func (h Heatmap) Busiest() (time.Weekday, int) {
+	bestDay, bestHour := time.Sunday, 0
+	for day := range h {
+		for hour := range h[day] {
+			if h[day][hour] > h[bestDay][bestHour] {
+				bestDay, bestHour = time.Weekday(day), hour
+			}
+		}
+	}
+	return bestDay, bestHour
+}
+
+// BusiestDay returns the day of the week with the most reminders
+func (h Heatmap) BusiestDay() time.Weekday {
+	best := time.Sunday
+	for day := time.Monday; day <= time.Saturday; day++ {
+		if h.DayTotal(day) > h.DayTotal(best) {
+			best = day
+		}
+	}
+	return best
+}
+
+// BusiestHour returns the hour of the day with the most reminders
+func (h Heatmap) BusiestHour() int {
+	best := 0
+	for hour := 1; hour < 24; hour++ {
+		if h.HourTotal(hour) > h.HourTotal(best) {
+			best = hour
+		}
+	}
+	return best
+}
+
+// Level buckets a count into 0 (none) through levels-1 (the most), for
+// shading a cell
+func Level(count, most, levels int) int {
+	if count <= 0 || most <= 0 || levels < 2 {
+		return 0
+	}
+	return 1 + (min(count, most)*(levels-1)-1)/most
+}
@@ -0,0 +1,60 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"go_remind/pkg/reminder"
+)
+
+func TestBuildHeatmap(t *testing.T) {
+	// Tuesday, January 13, 2026
+	tue9 := time.Date(2026, 1, 13, 9, 15, 0, 0, time.Local)
+	reminders := []*reminder.Reminder{
+		{Description: "Standup", DateTime: tue9, Status: reminder.Acknowledged},
+		{Description: "Standup", DateTime: tue9.AddDate(0, 0, 7), Status: reminder.Pending},
+		{Description: "Review", DateTime: tue9.Add(30 * time.Minute), Status: reminder.Triggered},
+		{Description: "Gym", DateTime: time.Date(2026, 1, 17, 18, 0, 0, 0, time.Local), Status: reminder.Pending},
+		{Description: "No time"},
+	}
+
+	h := BuildHeatmap(reminders)
+
+	if got := h[time.Tuesday][9]; got != 3 {
+		t.Errorf("Tuesday 9am = %d, want 3", got)
+	}
+	if got := h[time.Saturday][18]; got != 1 {
+		t.Errorf("Saturday 6pm = %d, want 1", got)
+	}
+	if h.Total() != 4 || h.Max() != 3 {
+		t.Errorf("Total = %d, Max = %d, want 4 and 3", h.Total(), h.Max())
+	}
+	if h.DayTotal(time.Tuesday) != 3 || h.HourTotal(18) != 1 {
+		t.Errorf("DayTotal(Tuesday) = %d, HourTotal(18) = %d, want 3 and 1", h.DayTotal(time.Tuesday), h.HourTotal(18))
+	}
+	if day, hour := h.Busiest(); day != time.Tuesday || hour != 9 {
+		t.Errorf("Busiest = %v %d, want Tuesday 9", day, hour)
+	}
+	if h.BusiestDay() != time.Tuesday || h.BusiestHour() != 9 {
+		t.Errorf("BusiestDay = %v, BusiestHour = %d, want Tuesday and 9", h.BusiestDay(), h.BusiestHour())
+	}
+}
+
+func TestLevel(t *testing.T) {
+	tests := []struct {
+		count int
+		want  int
+	}{
+		{0, 0},
+		{1, 1},
+		{2, 1},
+		{3, 2},
+		{6, 3},
+		{8, 4},
+	}
+	for _, tt := range tests {
+		if got := Level(tt.count, 8, 5); got != tt.want {
+			t.Errorf("Level(%d, 8, 5) = %d, want %d", tt.count, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package stats
+
+import (
+	"time"
+
+	"go_remind/pkg/reminder"
+	"go_remind/pkg/state"
+)
+
+// Streak is a run of consecutive days on which everything due was
+// acknowledged. Days with nothing due neither extend nor break a streak.
+type Streak struct {
+	Current int
+	Longest int
+}
+
+// dayTally counts the reminders due on one day and how many were acknowledged
+type dayTally struct {
+	due  int
+	done int
+}
+
+// Streaks computes completion streaks from the current reminders plus the
+// acknowledgment history, which covers reminders since deleted. Today only
+// counts once it's complete; an unfinished today doesn't break the streak.
+func Streaks(reminders []*reminder.Reminder, history []state.AckEvent, now time.Time) Streak {
+	today := startOfDay(now)
+	tallies := make(map[time.Time]*dayTally)
+	tally := func(due time.Time, done bool) {
+		day := startOfDay(due.In(now.Location()))
+		if day.After(today) {
+			return
+		}
+		t := tallies[day]
+		if t == nil {
+			t = &dayTally{}
+			tallies[day] = t
+		}
+		t.due++
+		if done {
+			t.done++
+		}
+	}
+
+	current := make(map[string]bool, len(reminders))
+	for _, r := range reminders {
+		current[r.ID] = true
+		if r.Status.Parked() {
+			continue // Not due on any particular day
+		}
+		tally(r.DateTime, r.Status == reminder.Acknowledged)
+	}
+	logged := make(map[string]bool)
+	for _, e := range history {
+		if current[e.ID] || logged[e.ID] {
+			continue
+		}
+		logged[e.ID] = true
+		tally(e.DueAt, true)
+	}
+
+	if len(tallies) == 0 {
+		return Streak{}
+	}
+
+	// Walk every day from the first one with anything due through today
+	first := today
+	for day := range tallies {
+		if day.Before(first) {
+			first = day
+		}
+	}
+
+	var s Streak
+	run := 0
+	for day := first; !day.After(today); day = day.AddDate(0, 0, 1) {
+		t := tallies[day]
+		switch {
+		case t == nil:
+			// Nothing due
+		case t.done == t.due:
+			run++
+			s.Longest = max(s.Longest, run)
+		case day.Equal(today):
+			// Still in progress
+		default:
+			run = 0
+		}
+	}
+	s.Current = run
+	return s
+}
+
+// Contributions counts acknowledgments per day for the given number of
+// weeks ending with the week containing now. Each column is a week,
+// Sunday first, like GitHub's contribution graph.
+func Contributions(history []state.AckEvent, now time.Time, weeks int) [][7]int {
+	if weeks < 1 {
+		return nil
+	}
+	today := startOfDay(now)
+	start := today.AddDate(0, 0, -int(today.Weekday())-7*(weeks-1))
+
+	grid := make([][7]int, weeks)
+	for _, e := range history {
+		day := startOfDay(e.At.In(now.Location()))
+		if day.Before(start) || day.After(today) {
+			continue
+		}
+		days := daysBetween(start, day)
+		grid[days/7][days%7]++
+	}
+	return grid
+}
+
+// startOfDay returns midnight at the start of t's day, in t's location
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// daysBetween counts calendar days from a to b, both at midnight. Rounding
+// absorbs daylight saving shifts.
+func daysBetween(a, b time.Time) int {
+	return int((b.Sub(a) + 12*time.Hour) / (24 * time.Hour))
+}
@@ -0,0 +1,72 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"go_remind/pkg/reminder"
+	"go_remind/pkg/state"
+)
+
+func TestStreaks(t *testing.T) {
+	// Friday, January 16, 2026 at noon
+	now := time.Date(2026, 1, 16, 12, 0, 0, 0, time.Local)
+	day := func(offset, hour int) time.Time {
+		return time.Date(2026, 1, 16+offset, hour, 0, 0, 0, time.Local)
+	}
+	done := func(id string, due time.Time) *reminder.Reminder {
+		return &reminder.Reminder{ID: id, DateTime: due, Status: reminder.Acknowledged, AcknowledgedAt: due.Add(time.Hour)}
+	}
+
+	reminders := []*reminder.Reminder{
+		done("a", day(-6, 9)),
+		{ID: "b", DateTime: day(-5, 9), Status: reminder.Triggered}, // Missed: breaks the streak
+		done("c", day(-4, 9)),
+		done("d", day(-3, 9)),
+		// Nothing due two days ago
+		done("e", day(-1, 9)),
+		done("f", day(-1, 17)),
+		{ID: "g", DateTime: day(0, 15), Status: reminder.Pending}, // Today, still open
+		{ID: "h", DateTime: day(3, 9), Status: reminder.Pending},  // Future
+	}
+	history := []state.AckEvent{
+		{ID: "deleted", DueAt: day(-8, 9), At: day(-8, 10)},
+		{ID: "deleted-2", DueAt: day(-7, 9), At: day(-7, 10)},
+		{ID: "e", DueAt: day(-1, 9), At: day(-1, 10)},
+	}
+
+	got := Streaks(reminders, history, now)
+	if got.Current != 3 || got.Longest != 3 {
+		t.Errorf("Streaks = %+v, want current 3, longest 3", got)
+	}
+
+	// Finishing today extends the current streak
+	reminders[6] = done("g", day(0, 11))
+	if got := Streaks(reminders, history, now); got.Current != 4 || got.Longest != 4 {
+		t.Errorf("after finishing today, Streaks = %+v, want current 4, longest 4", got)
+	}
+
+	if got := Streaks(nil, nil, now); got != (Streak{}) {
+		t.Errorf("Streaks of nothing = %+v, want zero", got)
+	}
+}
+
+func TestContributions(t *testing.T) {
+	// Friday, January 16, 2026; the week began Sunday, January 11
+	now := time.Date(2026, 1, 16, 12, 0, 0, 0, time.Local)
+	history := []state.AckEvent{
+		{ID: "a", At: time.Date(2026, 1, 16, 9, 0, 0, 0, time.Local)},
+		{ID: "b", At: time.Date(2026, 1, 16, 10, 0, 0, 0, time.Local)},
+		{ID: "c", At: time.Date(2026, 1, 11, 8, 0, 0, 0, time.Local)},
+		{ID: "d", At: time.Date(2026, 1, 5, 8, 0, 0, 0, time.Local)},  // Monday, the week before
+		{ID: "e", At: time.Date(2025, 12, 1, 8, 0, 0, 0, time.Local)}, // Too old
+	}
+
+	grid := Contributions(history, now, 2)
+	if len(grid) != 2 {
+		t.Fatalf("got %d weeks, want 2", len(grid))
+	}
+	if grid[1][time.Friday] != 2 || grid[1][time.Sunday] != 1 || grid[0][time.Monday] != 1 {
+		t.Errorf("Contributions = %v", grid)
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"go_remind/config"
+	"go_remind/digest"
+	"go_remind/pkg/reminder"
+	"go_remind/pkg/state"
+)
+
+// runToday runs `go_remind today`: prints today's reminders grouped by hour
+// and exits with status 1 if anything is overdue, for shell startup files
+// and scripts
+func runToday(store *state.Store, cfg *config.Config) {
+	var reminders []*reminder.Reminder
+	if store != nil {
+		var err error
+		if reminders, err = store.Load(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: could not load state: %v\n", err)
+			os.Exit(2)
+		}
+	}
+
+	now := time.Now()
+	d := digest.Build(reminders, now)
+
+	fmt.Print(d.Agenda(cfg.Conflicts.WindowDuration()))
+	if len(d.Late(now)) > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,145 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"go_remind/pkg/reminder"
+	"go_remind/pkg/state"
+)
+
+// WithActivity returns a copy of the model whose activity view and detail
+// history read changes from log
+func (m Model) WithActivity(log *state.ActivityLog) Model {
+	m.activity = log
+	return m
+}
+
+// loadActivity reads the changes to the reminder with the given ID, or
+// every change if id is ""
+func (m *Model) loadActivity(id string) []state.Change {
+	if m.activity == nil {
+		return nil
+	}
+	var changes []state.Change
+	var err error
+	if id == "" {
+		changes, err = m.activity.Load()
+	} else {
+		changes, err = m.activity.LoadFor(id)
+	}
+	if err != nil {
+		m.toastError("Could not read activity: " + err.Error())
+	}
+	return changes
+}
+
+// openActivity loads the activity log and shows the activity view
+func (m *Model) openActivity() {
+	m.activityChanges = m.loadActivity("")
+	m.activityScroll = 0
+	m.mode = modeActivity
+}
+
+func (m Model) updateActivityMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.Type == tea.KeyEscape, msg.String() == "q", key.Matches(msg, keys.Activity):
+		m.mode = modeNormal
+		m.activityChanges = nil
+	case key.Matches(msg, keys.Up):
+		if m.activityScroll > 0 {
+			m.activityScroll--
+		}
+	case key.Matches(msg, keys.Down):
+		if m.activityScroll < len(m.activityChanges)-1 {
+			m.activityScroll++
+		}
+	}
+	return m, nil
+}
+
+// activityView renders every change to the reminders, newest first
+func (m Model) activityView() string {
+	var b strings.Builder
+	b.WriteString(inputLabelStyle.Render("Activity"))
+	b.WriteString("\n\n")
+
+	if len(m.activityChanges) == 0 {
+		b.WriteString(inputHintStyle.Render("Nothing recorded yet"))
+		b.WriteString("\n\n")
+	}
+	visible := max(m.height-8, 5)
+	shown := 0
+	for i := len(m.activityChanges) - 1 - m.activityScroll; i >= 0 && shown < visible; i-- {
+		c := m.activityChanges[i]
+		desc := ansi.Truncate(c.Description, max(m.width-46, 20), glyphs.Ellipsis)
+		b.WriteString(formatChange(c, desc))
+		b.WriteString("\n")
+		shown++
+	}
+	if len(m.activityChanges) > visible {
+		b.WriteString("\n")
+		b.WriteString(inputHintStyle.Render(fmt.Sprintf("%d of %d changes, %s/%s to scroll",
+			min(m.activityScroll+visible, len(m.activityChanges)), len(m.activityChanges), glyphs.Up, glyphs.Down)))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(inputHintStyle.Render("Press esc to close"))
+	return b.String()
+}
+
+// formatChange renders one change as a line: when, what happened, then
+// label, usually the reminder's description
+func formatChange(c state.Change, label string) string {
+	line := sourceStyle.Render(c.At.Local().Format("Jan 2 15:04")) + "  " +
+		changeStyle(c.Action).Render(fmt.Sprintf("%-12s", c.Action))
+	if label != "" {
+		line += "  " + normalStyle.Render(label)
+	}
+	if c.Detail != "" {
+		line += inputHintStyle.Render("  " + c.Detail)
+	}
+	return line
+}
+
+// changeStyle colors an action like the status it leads to
+func changeStyle(action string) lipgloss.Style {
+	switch action {
+	case state.ActionTriggered:
+		return triggeredStyle
+	case state.ActionSnoozed:
+		return snoozedStyle
+	case state.ActionParked:
+		return waitingStyle
+	case state.ActionAcknowledged, state.ActionDeleted:
+		return sourceStyle
+	default:
+		return tagStyle
+	}
+}
+
+// detailHistoryView renders the changes to r in the detail view, newest
+// first
+func (m Model) detailHistoryView(r *reminder.Reminder) string {
+	if m.activity == nil {
+		return inputHintStyle.Render("History is kept with the local state file") + "\n"
+	}
+	if m.detailChangesID != r.ID {
+		// The split pane follows the cursor without reading the log
+		return inputHintStyle.Render("Press "+keys.Detail.Help().Key+" to load its history") + "\n"
+	}
+	if len(m.detailChanges) == 0 {
+		return inputHintStyle.Render("No changes recorded yet") + "\n"
+	}
+	var b strings.Builder
+	for i := len(m.detailChanges) - 1; i >= 0; i-- {
+		b.WriteString(formatChange(m.detailChanges[i], ""))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/lipgloss"
+
+	"go_remind/pkg/reminder"
+)
+
+// addInputMaxLines is how tall the add box grows before it scrolls
+const addInputMaxLines = 6
+
+// newAddInput creates the add and edit box. Enter saves, so a new line is
+// alt+enter, or ctrl+j in terminals that don't send alt+enter.
+func newAddInput() textarea.Model {
+	ta := textarea.New()
+	ta.Placeholder = "+1h Call mom  or  Jan 15 2:30pm Meeting"
+	ta.CharLimit = 4000
+	ta.ShowLineNumbers = false
+	ta.Prompt = ""
+	ta.MaxHeight = addInputMaxLines
+	ta.SetWidth(60)
+	ta.SetHeight(1)
+	ta.KeyMap.InsertNewline = key.NewBinding(key.WithKeys("alt+enter", "ctrl+j"), key.WithHelp("alt+enter", "new line"))
+	ta.FocusedStyle.CursorLine = lipgloss.NewStyle()
+	ta.FocusedStyle.Base = lipgloss.NewStyle()
+	ta.BlurredStyle.Base = lipgloss.NewStyle()
+	return ta
+}
+
+// fitAddInput grows or shrinks the add box to its lines
+func (m *Model) fitAddInput() {
+	m.addInput.SetHeight(min(max(m.addInput.LineCount(), 1), addInputMaxLines))
+}
+
+// splitEntry splits what's typed into the add box into its first line,
+// the reminder's time and description, and the notes on the lines below
+func splitEntry(text string) (entry, notes string) {
+	entry, notes, _ = strings.Cut(text, "\n")
+	return strings.TrimSpace(entry), strings.TrimRight(strings.TrimLeft(notes, "\r\n"), " \t\r\n")
+}
+
+// detailLines wraps r's description and then its notes to width, keeping
+// the notes' line breaks and blank lines
+func detailLines(r *reminder.Reminder, width int) []string {
+	lines := wrapText(r.Description, width)
+	if r.Notes == "" {
+		return lines
+	}
+	lines = append(lines, "")
+	for _, line := range strings.Split(r.Notes, "\n") {
+		if strings.TrimSpace(line) == "" {
+			lines = append(lines, "")
+			continue
+		}
+		lines = append(lines, wrapText(line, width)...)
+	}
+	return lines
+}
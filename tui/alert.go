@@ -0,0 +1,220 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"go_remind/pkg/reminder"
+)
+
+// queueAlert lines up an alert for r, which just triggered
+func (m *Model) queueAlert(r *reminder.Reminder) {
+	if m.alertsEnabled {
+		m.alerts = append(m.alerts, r.ID)
+	}
+}
+
+// checkAlerts drops alerts for reminders that were dealt with some other
+// way, and pops up the next one once the list is showing
+func (m *Model) checkAlerts() {
+	var pending []string
+	for _, id := range m.alerts {
+		if r := m.reminderByID(id); r != nil && r.Status == reminder.Triggered {
+			pending = append(pending, id)
+		}
+	}
+	m.alerts = pending
+	switch {
+	case len(m.alerts) == 0 && m.mode == modeAlert:
+		m.mode = modeNormal
+	case len(m.alerts) > 0 && m.mode == modeNormal:
+		m.mode = modeAlert
+	}
+}
+
+// reminderByID returns the reminder with the given ID, or nil
+func (m Model) reminderByID(id string) *reminder.Reminder {
+	for _, r := range m.reminders {
+		if r.ID == id {
+			return r
+		}
+	}
+	return nil
+}
+
+// alertReminder returns the reminder the alert is showing, or nil
+func (m Model) alertReminder() *reminder.Reminder {
+	if len(m.alerts) == 0 {
+		return nil
+	}
+	return m.reminderByID(m.alerts[0])
+}
+
+// nextAlert moves on to the next alert, or back to the list
+func (m *Model) nextAlert() {
+	if len(m.alerts) > 0 {
+		m.alerts = m.alerts[1:]
+	}
+	m.mode = modeNormal
+	m.checkAlerts()
+}
+
+func (m Model) updateAlertMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	r := m.alertReminder()
+	if r == nil {
+		m.nextAlert()
+		return m, nil
+	}
+
+	snooze := func(d time.Duration, label string) {
+		r.Snooze(d, m.now())
+		reminder.SortByDateTime(m.reminders)
+		m.refreshList()
+		m.saveState()
+		m.toastInfo("Snoozed " + label + ": " + r.Description)
+		m.nextAlert()
+	}
+
+	switch {
+	case key.Matches(msg, keys.Acknowledge):
+		r.Acknowledge(m.now())
+		m.refreshList()
+		m.saveState()
+		m.toastSuccess("Acknowledged: " + r.Description)
+		m.nextAlert()
+		return m, m.acknowledgeAtSources(r)
+	case key.Matches(msg, keys.Snooze5m):
+		snooze(5*time.Minute, "5 minutes")
+	case key.Matches(msg, keys.Snooze1h):
+		snooze(time.Hour, "1 hour")
+	case key.Matches(msg, keys.Snooze1d):
+		snooze(24*time.Hour, "1 day")
+	case key.Matches(msg, keys.OpenLink):
+		m.nextAlert()
+		return m, m.openSource(r)
+	case key.Matches(msg, keys.Detail):
+		m.nextAlert()
+		m.recordJump()
+		m.openDetail(r)
+	case msg.Type == tea.KeyEsc:
+		// Leave it triggered in the list
+		m.nextAlert()
+	}
+	return m, nil
+}
+
+// SourceOpenedMsg reports that the editor opened on a source file exited
+type SourceOpenedMsg struct{ err error }
+
+// openSource opens where r came from: the page or email for links, and
+// for notes the file in $VISUAL or $EDITOR at r's line, or else in the
+// default app
+func (m *Model) openSource(r *reminder.Reminder) tea.Cmd {
+	if isLink(r.SourceFile) {
+		return m.openTarget(r.SourceFile)
+	}
+	if _, err := os.Stat(r.SourceFile); err != nil {
+		m.toastInfo("No source file to open")
+		return nil
+	}
+	args := editorCommand()
+	if len(args) == 0 {
+		return m.openTarget(r.SourceFile)
+	}
+	if r.LineNumber > 0 {
+		args = append(args, fmt.Sprintf("+%d", r.LineNumber))
+	}
+	cmd := exec.Command(args[0], append(args[1:], r.SourceFile)...)
+	return execProcess(cmd, func(err error) tea.Msg { return SourceOpenedMsg{err: err} })
+}
+
+// withAlert draws the alert box over the middle of view
+func (m Model) withAlert(view string) string {
+	if m.mode != modeAlert {
+		return view
+	}
+	box := m.alertView()
+	if box == "" {
+		return view
+	}
+
+	width := m.width
+	if width <= 0 {
+		width = lipgloss.Width(view)
+	}
+	lines := strings.Split(view, "\n")
+	boxLines := strings.Split(box, "\n")
+	boxWidth := lipgloss.Width(box)
+	x := max((width-boxWidth)/2, 0)
+	y := max((len(lines)-len(boxLines))/2, 0)
+	for i, line := range boxLines {
+		row := y + i
+		for row >= len(lines) {
+			lines = append(lines, "")
+		}
+		left := ansi.Truncate(lines[row], x, "")
+		if pad := x - lipgloss.Width(left); pad > 0 {
+			left += strings.Repeat(" ", pad)
+		}
+		lines[row] = left + line + ansi.TruncateLeft(lines[row], x+boxWidth, "")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// alertView renders the alert for the reminder that triggered: its
+// description, how long it's been overdue in big digits, and the keys
+// that deal with it
+func (m Model) alertView() string {
+	r := m.alertReminder()
+	if r == nil {
+		return ""
+	}
+
+	title := glyphs.Triggered + " Reminder due"
+	if len(m.alerts) > 1 {
+		title += fmt.Sprintf(" (1 of %d)", len(m.alerts))
+	}
+	overdue := formatElapsed(m.now().Sub(r.DateTime))
+	big := bigText(overdue)
+	if m.width > 0 && lipgloss.Width(big) > m.width-10 {
+		big = overdue // Too narrow for big digits
+	}
+
+	keyHint := func(b key.Binding, desc string) string {
+		return inputLabelStyle.Render(b.Help().Key) + inputHintStyle.Render(" "+desc)
+	}
+	hints := strings.Join([]string{
+		keyHint(keys.Acknowledge, "done"),
+		keyHint(keys.Snooze5m, "5m"),
+		keyHint(keys.Snooze1h, "1h"),
+		keyHint(keys.Snooze1d, "1d"),
+		keyHint(keys.OpenLink, "open source"),
+		keyHint(keys.Detail, "detail"),
+		inputLabelStyle.Render("esc") + inputHintStyle.Render(" later"),
+	}, "  ")
+
+	content := lipgloss.JoinVertical(lipgloss.Center,
+		triggeredStyle.Bold(true).Render(title),
+		"",
+		titleStyle.Render(r.Description),
+		sourceStyle.Render("Due "+r.DateTime.Format("Mon Jan 2 at 3:04 PM")),
+		"",
+		triggeredStyle.Render(big),
+		inputHintStyle.Render("overdue"),
+		"",
+		hints,
+	)
+	return lipgloss.NewStyle().
+		Border(glyphs.Border).
+		BorderForeground(triggeredStyle.GetForeground()).
+		Padding(1, 3).
+		Render(content)
+}
@@ -0,0 +1,60 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"go_remind/pkg/reminder"
+)
+
+// ringBell sounds the terminal bell, which most terminals also use to
+// flag the window or tab as wanting attention. Replaced in tests.
+var ringBell = func() {
+	_, _ = os.Stdout.WriteString("\a")
+}
+
+// windowTitle is the terminal title for the current state, e.g.
+// "go_remind — 2 due!"
+func (m Model) windowTitle() string {
+	n := 0
+	for _, r := range m.reminders {
+		if r.Status == reminder.Triggered {
+			n++
+		}
+	}
+	if n == 0 {
+		return "go_remind"
+	}
+	return fmt.Sprintf("go_remind — %d due!", n)
+}
+
+// checkAttention keeps the terminal title up to date and rings the bell
+// when reminders trigger, however they came to, so a TUI in a background
+// tab still gets noticed. Reminders already triggered at startup don't
+// ring.
+func (m *Model) checkAttention() tea.Cmd {
+	triggered := make(map[string]bool)
+	ring := false
+	for _, r := range m.reminders {
+		if r.Status != reminder.Triggered {
+			continue
+		}
+		triggered[r.ID] = true
+		if m.seenTriggered != nil && !m.seenTriggered[r.ID] {
+			ring = true
+		}
+	}
+	m.seenTriggered = triggered
+	if ring && m.bellEnabled {
+		ringBell()
+	}
+
+	title := m.windowTitle()
+	if title == m.title {
+		return nil
+	}
+	m.title = title
+	return tea.SetWindowTitle(title)
+}
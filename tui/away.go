@@ -0,0 +1,131 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"go_remind/pkg/reminder"
+)
+
+// awayShown is how many of the reminders that came due while the TUI was
+// closed the "While you were away" box lists
+const awayShown = 10
+
+// StartupMsg is sent once as the TUI starts, to catch up on what came due
+// while it was closed
+type StartupMsg struct{}
+
+// startupCmd sends StartupMsg
+func startupCmd() tea.Msg {
+	return StartupMsg{}
+}
+
+// catchUp triggers the reminders that came due while the TUI was closed
+// straight away, rather than on the first tick, and lists them in the
+// "While you were away" box in place of an alert for each. Returns a
+// command that sends one notification summing them up, if that's on.
+func (m *Model) catchUp(now time.Time) tea.Cmd {
+	var missed []*reminder.Reminder
+	for _, r := range m.reminders {
+		if r.Status.Scheduled() && r.IsDueAt(now) {
+			r.Status = reminder.Triggered
+			missed = append(missed, r)
+		}
+	}
+	if len(missed) == 0 {
+		return nil
+	}
+	m.missed = nil
+	for _, r := range missed {
+		m.missed = append(m.missed, r.ID)
+	}
+	m.refreshList()
+	m.saveState()
+	if m.config.Away.Pane && m.mode == modeNormal {
+		m.mode = modeAway
+	}
+	if m.config.Away.Notify && m.config.Notifications.Enabled {
+		return notifyCmd("While you were away, "+awaySummary(len(missed)), awayBody(missed))
+	}
+	return nil
+}
+
+// awaySummary says how many reminders came due while the TUI was closed
+func awaySummary(n int) string {
+	return counted(n, "reminder", "reminders") + " came due"
+}
+
+// awayBody lists the descriptions of the reminders that came due, for the
+// notification
+func awayBody(missed []*reminder.Reminder) string {
+	lines := make([]string, 0, min(len(missed), awayShown)+1)
+	for i, r := range missed {
+		if i == awayShown {
+			lines = append(lines, fmt.Sprintf("and %d more", len(missed)-i))
+			break
+		}
+		lines = append(lines, r.Description)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// missedReminders returns the reminders that came due while the TUI was
+// closed and are still around, soonest first
+func (m Model) missedReminders() []*reminder.Reminder {
+	var rs []*reminder.Reminder
+	for _, id := range m.missed {
+		if r := m.reminderByID(id); r != nil {
+			rs = append(rs, r)
+		}
+	}
+	return rs
+}
+
+// updateAwayMode closes the "While you were away" box: enter goes on to
+// the triggered reminders, and esc to the list as it was
+func (m Model) updateAwayMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.Type == tea.KeyEnter, key.Matches(msg, keys.Triggered):
+		m.mode = modeNormal
+		if !m.showingTriggered() {
+			m.toggleTriggered()
+		}
+	case msg.String() == "esc", msg.String() == "q":
+		m.mode = modeNormal
+	}
+	return m, nil
+}
+
+// awayView lists the reminders that came due while the TUI was closed
+func (m Model) awayView() string {
+	missed := m.missedReminders()
+	var b strings.Builder
+	b.WriteString(inputLabelStyle.Render(glyphs.Triggered + " While you were away"))
+	b.WriteString("\n")
+	b.WriteString(inputHintStyle.Render(awaySummary(len(missed)) + " while go_remind was closed:"))
+	b.WriteString("\n\n")
+
+	for i, r := range missed {
+		if i == awayShown {
+			b.WriteString(inputHintStyle.Render(fmt.Sprintf("  %s and %d more", glyphs.Ellipsis, len(missed)-i)))
+			b.WriteString("\n")
+			break
+		}
+		when := sourceStyle.Render(fmt.Sprintf("%-17s", r.DateTime.Format("Mon Jan 2 3:04pm")))
+		b.WriteString("  " + statusStyle(r.Status).Render(statusGlyph(r.Status)) + " " + when + " ")
+		b.WriteString(normalStyle.Render(ansi.Truncate(r.Description, max(m.width-40, 20), glyphs.Ellipsis)))
+		b.WriteString("\n")
+	}
+
+	sep := " " + glyphs.Bullet + " "
+	b.WriteString("\n")
+	b.WriteString(inputHintStyle.Render("enter to show the triggered reminders" + sep + "esc to close"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modalBox(b.String()))
+}
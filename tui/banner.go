@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"github.com/charmbracelet/x/ansi"
+
+	"go_remind/pkg/reminder"
+)
+
+// listView is the filter and date range the list had before it was
+// narrowed to the triggered reminders, to go back to
+type listView struct {
+	filter    string
+	dateRange dateRange
+}
+
+// triggeredCount counts the triggered reminders, whether the list shows
+// them or not
+func (m Model) triggeredCount() int {
+	n := 0
+	for _, r := range m.reminders {
+		if r.Status == reminder.Triggered {
+			n++
+		}
+	}
+	return n
+}
+
+// triggeredFilter narrows the list to the triggered reminders
+const triggeredFilter = "is:triggered"
+
+// showingTriggered reports whether the list is narrowed to just the
+// triggered reminders
+func (m Model) showingTriggered() bool {
+	return m.filterInput.Value() == triggeredFilter && m.dateRange == rangeAny
+}
+
+// toggleTriggered narrows the list to the triggered reminders, whatever
+// it was filtered to, or goes back to how it was
+func (m *Model) toggleTriggered() {
+	if m.showingTriggered() {
+		back := listView{}
+		if m.triggeredReturn != nil {
+			back = *m.triggeredReturn
+		}
+		m.triggeredReturn = nil
+		m.filterInput.SetValue(back.filter)
+		m.setDateRange(back.dateRange)
+		m.saveViewSettings()
+		m.toastInfo("Back to the list as it was")
+		return
+	}
+	n := m.triggeredCount()
+	if n == 0 {
+		m.toastInfo("Nothing has triggered")
+		return
+	}
+	m.recordJump()
+	m.triggeredReturn = &listView{filter: m.filterInput.Value(), dateRange: m.dateRange}
+	m.clearSearch()
+	m.filterInput.SetValue(triggeredFilter)
+	m.setDateRange(rangeAny)
+	m.saveViewSettings()
+	m.toastInfo("Showing " + counted(n, "triggered reminder", "triggered reminders"))
+}
+
+// bannerHeight is how many lines the triggered banner takes above the list
+func (m Model) bannerHeight() int {
+	if m.triggeredCount() == 0 {
+		return 0
+	}
+	return 1
+}
+
+// triggeredBanner counts the triggered reminders above the list, so none
+// goes unseen however the list is scrolled or filtered. It's "" when
+// nothing has triggered.
+func (m Model) triggeredBanner() string {
+	n := m.triggeredCount()
+	if n == 0 {
+		return ""
+	}
+	hint := "press " + keys.Triggered.Help().Key + " to view"
+	if m.showingTriggered() {
+		hint = "press " + keys.Triggered.Help().Key + " to go back"
+	}
+	banner := triggeredStyle.Bold(true).Render(glyphs.Triggered+" "+counted(n, "triggered reminder", "triggered reminders")) +
+		inputHintStyle.Render("  "+glyphs.Bullet+"  "+hint)
+	return ansi.Truncate(banner, max(m.width-appStyle.GetHorizontalPadding(), 10), glyphs.Ellipsis)
+}
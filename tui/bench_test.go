@@ -0,0 +1,33 @@
+package tui
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"go_remind/pkg/clock"
+	"go_remind/pkg/reminder"
+)
+
+// Budget: under 5ms to filter and section 5,000 reminders
+func BenchmarkGetFilteredReminders(b *testing.B) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	statuses := []reminder.Status{reminder.Pending, reminder.Triggered, reminder.Acknowledged, reminder.Snoozed}
+	rs := make([]*reminder.Reminder, 5000)
+	for i := range rs {
+		rs[i] = &reminder.Reminder{
+			Description: fmt.Sprintf("Reminder %d about the deploy", i),
+			DateTime:    now.Add(time.Duration(i-500) * 37 * time.Minute),
+			Tags:        []string{"work"},
+			SourceFile:  fmt.Sprintf("/notes/%d.md", i%100),
+			Status:      statuses[i%len(statuses)],
+		}
+	}
+	m := New(rs, nil, nil).WithClock(clock.Fixed(now))
+	m.filterInput.SetValue("deploy")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.getFilteredReminders()
+	}
+}
@@ -4,11 +4,10 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
-	"time"
 
 	"github.com/charmbracelet/lipgloss"
 
-	"go_remind/reminder"
+	"go_remind/pkg/reminder"
 )
 
 func (m Model) gridViewContent() string {
@@ -17,7 +16,7 @@ func (m Model) gridViewContent() string {
 		return normalStyle.Render("No reminders")
 	}
 
-	cardWidth := 38
+	cardWidth := cardWidthFor(m.width)
 	cols := m.gridColumns
 	if cols < 1 {
 		cols = 1
@@ -32,7 +31,7 @@ func (m Model) gridViewContent() string {
 
 		// Add scroll up indicator
 		if m.gridScroll > 0 {
-			rows = append(rows, sourceStyle.Render(fmt.Sprintf("  ↑ %d more rows above", m.gridScroll)))
+			rows = append(rows, sourceStyle.Render(fmt.Sprintf("  %s %d more rows above", glyphs.Up, m.gridScroll)))
 		}
 
 		startRow := m.gridScroll
@@ -55,46 +54,14 @@ func (m Model) gridViewContent() string {
 
 		// Add scroll down indicator
 		if endRow < totalRows {
-			rows = append(rows, sourceStyle.Render(fmt.Sprintf("  ↓ %d more rows below", totalRows-endRow)))
+			rows = append(rows, sourceStyle.Render(fmt.Sprintf("  %s %d more rows below", glyphs.Down, totalRows-endRow)))
 		}
 
 		return lipgloss.JoinVertical(lipgloss.Left, rows...)
 	}
 
 	// Sort into sections with proper row tracking
-	now := time.Now()
-	todayEnd := time.Date(now.Year(), now.Month(), now.Day(), 23, 59, 59, 0, now.Location())
-	tomorrowEnd := todayEnd.Add(24 * time.Hour)
-
-	// Calculate week boundaries (week starts on Sunday)
-	daysUntilEndOfWeek := (7 - int(now.Weekday())) % 7
-	thisWeekEnd := time.Date(now.Year(), now.Month(), now.Day()+daysUntilEndOfWeek, 23, 59, 59, 0, now.Location())
-	nextWeekEnd := thisWeekEnd.Add(7 * 24 * time.Hour)
-
-	// Calculate month boundaries
-	thisMonthEnd := time.Date(now.Year(), now.Month()+1, 0, 23, 59, 59, 0, now.Location())
-	nextMonthEnd := time.Date(now.Year(), now.Month()+2, 0, 23, 59, 59, 0, now.Location())
-
-	var due, comingUp, tomorrow, laterThisWeek, nextWeek, laterThisMonth, beyondNextMonth []*reminder.Reminder
-	for _, r := range items {
-		if r.DateTime.Before(now) {
-			due = append(due, r)
-		} else if r.DateTime.Before(todayEnd) {
-			comingUp = append(comingUp, r)
-		} else if r.DateTime.Before(tomorrowEnd) {
-			tomorrow = append(tomorrow, r)
-		} else if r.DateTime.Before(thisWeekEnd) {
-			laterThisWeek = append(laterThisWeek, r)
-		} else if r.DateTime.Before(nextWeekEnd) {
-			nextWeek = append(nextWeek, r)
-		} else if r.DateTime.Before(thisMonthEnd) {
-			laterThisMonth = append(laterThisMonth, r)
-		} else if r.DateTime.Before(nextMonthEnd) {
-			beyondNextMonth = append(beyondNextMonth, r)
-		} else {
-			beyondNextMonth = append(beyondNextMonth, r)
-		}
-	}
+	groups, titles := m.groupSections(items, m.now())
 
 	sectionStyle := lipgloss.NewStyle().
 		Foreground(titleStyle.GetForeground()).
@@ -102,13 +69,13 @@ func (m Model) gridViewContent() string {
 		MarginTop(1).
 		MarginBottom(1)
 
-	var sections []string
+	sections := []string{m.rollupView(groups, titles)}
 	globalIdx := 0
 	currentRow := 0
 
 	// Add scroll up indicator for sorted view
 	if m.gridScroll > 0 {
-		sections = append(sections, sourceStyle.Render(fmt.Sprintf("  ↑ %d more rows above", m.gridScroll)))
+		sections = append(sections, sourceStyle.Render(fmt.Sprintf("  %s %d more rows above", glyphs.Up, m.gridScroll)))
 	}
 
 	// Helper to add a section
@@ -126,17 +93,13 @@ func (m Model) gridViewContent() string {
 		}
 	}
 
-	addSection(due, "Due")
-	addSection(comingUp, "Coming Up!")
-	addSection(tomorrow, "Tomorrow")
-	addSection(laterThisWeek, "Later This Week")
-	addSection(nextWeek, "Next Week")
-	addSection(laterThisMonth, "Later This Month")
-	addSection(beyondNextMonth, "Next Month & Beyond")
+	for i, group := range groups {
+		addSection(group, titles[i])
+	}
 
 	// Add scroll down indicator
 	if m.gridScroll+visibleRows < totalRows {
-		sections = append(sections, sourceStyle.Render(fmt.Sprintf("  ↓ %d more rows below", totalRows-m.gridScroll-visibleRows)))
+		sections = append(sections, sourceStyle.Render(fmt.Sprintf("  %s %d more rows below", glyphs.Down, totalRows-m.gridScroll-visibleRows)))
 	}
 
 	if len(sections) == 0 {
@@ -171,30 +134,25 @@ func (m Model) renderSectionWithRowTracking(items []*reminder.Reminder, title st
 
 	header := ""
 	if hasVisibleRows {
-		header = sectionStyle.Render(title)
+		header = sectionHeader(sectionStyle, title, items)
 	}
 
 	return header, lipgloss.JoinVertical(lipgloss.Left, rows...), currentRow, globalIdx
 }
 
+// cardDescWidth is how wide a card width wide lets its description be,
+// less its border and padding
+func cardDescWidth(width int) int {
+	return width - 4
+}
+
 func (m Model) renderCard(r *reminder.Reminder, index, width int) string {
 	timeStr := r.DateTime.Format("Jan 2 3:04pm")
 	source := filepath.Base(r.SourceFile)
 	isSelected := index == m.gridIndex
 
-	var style lipgloss.Style
-	var borderColor lipgloss.TerminalColor
-	switch r.Status {
-	case reminder.Triggered:
-		style = triggeredStyle
-		borderColor = triggeredStyle.GetForeground()
-	case reminder.Acknowledged:
-		style = acknowledgedStyle
-		borderColor = acknowledgedStyle.GetForeground()
-	default:
-		style = normalStyle
-		borderColor = normalStyle.GetForeground()
-	}
+	style := statusStyle(r.Status)
+	borderColor := style.GetForeground()
 
 	if isSelected {
 		borderColor = selectedItemStyle.GetForeground()
@@ -204,45 +162,27 @@ func (m Model) renderCard(r *reminder.Reminder, index, width int) string {
 	}
 
 	cardStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(glyphs.Border).
 		BorderForeground(borderColor).
 		Padding(0, 1).
 		Width(width).
 		Height(4).
 		MarginRight(1)
 
-	desc := r.Description
-	maxWidth := width - 4
-
-	// Wrap description to two lines at word boundaries
-	var line1, line2 string
-	if len(desc) <= maxWidth {
-		line1 = desc
-	} else {
-		// Find last space before maxWidth
-		breakPoint := maxWidth
-		for i := maxWidth; i > 0; i-- {
-			if desc[i] == ' ' {
-				breakPoint = i
-				break
-			}
-		}
-		line1 = desc[:breakPoint]
-		line2 = strings.TrimSpace(desc[breakPoint:])
-
-		// Truncate line2 if too long
-		if len(line2) > maxWidth {
-			line2 = line2[:maxWidth-3] + "..."
-		}
+	// Wrap the description to two lines
+	lines, _ := fitLines(titled(r), cardDescWidth(width), 2)
+	query := m.highlightQuery()
+	for i, line := range lines {
+		lines[i] = highlightMatches(line, 0, query, style)
 	}
+	descContent := strings.Join(lines, "\n")
 
-	descContent := style.Render(line1)
-	if line2 != "" {
-		descContent += "\n" + style.Render(line2)
+	// Build bottom line with time, source, and optionally tags. On tiny
+	// terminals the source goes on its own line.
+	bottomLine := style.Render(statusGlyph(r.Status)) + labelDot(r) + " " + sourceStyle.Render(timeStr+" "+glyphs.Bullet+" "+source)
+	if m.width > 0 && m.width < tinyWidth {
+		bottomLine = style.Render(statusGlyph(r.Status)) + labelDot(r) + " " + sourceStyle.Render(timeStr) + "\n" + sourceStyle.Render(source)
 	}
-
-	// Build bottom line with time, source, and optionally tags
-	bottomLine := sourceStyle.Render(timeStr + " • " + source)
 	if len(r.Tags) > 0 {
 		tagStrs := make([]string, len(r.Tags))
 		for i, tag := range r.Tags {
@@ -250,6 +190,12 @@ func (m Model) renderCard(r *reminder.Reminder, index, width int) string {
 		}
 		bottomLine += " " + tagStyle.Render(strings.Join(tagStrs, " "))
 	}
+	if r.Effort != reminder.EffortNone {
+		bottomLine += " " + sourceStyle.Render("^"+r.Effort.String())
+	}
+	if !r.Yearly.IsZero() {
+		bottomLine += " " + sourceStyle.Render(yearlyLabel(r))
+	}
 
 	content := descContent + "\n" + bottomLine
 	return cardStyle.Render(content)
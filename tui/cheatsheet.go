@@ -0,0 +1,180 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// defaultKeys are the bindings before any [keys] remapping, so the
+// cheatsheet can show what a remapped key replaced
+var defaultKeys = keys
+
+// cheatsheetSection is a titled group of actions, by config name
+type cheatsheetSection struct {
+	title   string
+	actions []string
+}
+
+// normalSections organizes every action for the main list
+var normalSections = []cheatsheetSection{
+	{"Navigation", []string{"up", "down", "left", "right", "prev_section", "next_section", "goto_first", "goto_last", "jump_back", "jump_forward", "set_mark", "goto_mark"}},
+	{"Reminders", []string{"acknowledge", "unacknowledge", "snooze_5m", "snooze_1h", "snooze_1d", "waiting", "someday", "timer", "effort", "label", "edit", "reschedule", "delete", "detail", "open_link", "yank", "shift", "undo"}},
+	{"Views & tools", []string{"filter", "search", "next_match", "prev_match", "command", "add", "compose", "paste", "export_view", "theme", "contrast", "layout", "split", "sort", "sort_order", "group", "low_energy", "digest", "focus", "timeline", "stats", "activity", "orphans", "muted", "people", "triggered", "merge_log", "watchers", "duplicates", "sources", "profiles", "workspaces", "help", "cheatsheet", "quit"}},
+}
+
+// detailSections are the actions available in the detail view
+var detailSections = []cheatsheetSection{
+	{"Detail view", []string{"detail", "up", "down", "acknowledge", "unacknowledge", "snooze_5m", "snooze_1h", "snooze_1d", "waiting", "someday", "timer", "effort", "label", "edit", "compose", "reschedule", "open_link", "delete", "jump_back"}},
+}
+
+// cheatsheetRow is one rendered line of the cheatsheet
+type cheatsheetRow struct {
+	keys       string
+	desc       string
+	defaultKey string // Set when the key was remapped
+}
+
+// openCheatsheet shows the keybinding overlay for the current mode
+func (m *Model) openCheatsheet() tea.Cmd {
+	m.helpReturn = m.mode
+	m.helpScroll = 0
+	m.helpSearch.Reset()
+	m.mode = modeCheatsheet
+	return m.helpSearch.Focus()
+}
+
+func (m Model) updateCheatsheetMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEscape:
+		if m.helpSearch.Value() != "" {
+			m.helpSearch.Reset()
+			m.helpScroll = 0
+			return m, nil
+		}
+		m.helpSearch.Blur()
+		m.mode = m.helpReturn
+		return m, nil
+	case tea.KeyF1, tea.KeyEnter:
+		m.helpSearch.Blur()
+		m.mode = m.helpReturn
+		return m, nil
+	case tea.KeyUp:
+		if m.helpScroll > 0 {
+			m.helpScroll--
+		}
+		return m, nil
+	case tea.KeyDown:
+		m.helpScroll++
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.helpSearch, cmd = m.helpSearch.Update(msg)
+	m.helpScroll = 0
+	return m, cmd
+}
+
+// cheatsheetRows returns the sections for the mode the cheatsheet was
+// opened from, filtered by the search text
+func (m Model) cheatsheetRows() (titles []string, rows [][]cheatsheetRow) {
+	sections := normalSections
+	if m.helpReturn == modeDetail {
+		sections = detailSections
+	}
+
+	current := keys.bindingNames()
+	defaults := defaultKeys.bindingNames()
+	query := strings.ToLower(strings.TrimSpace(m.helpSearch.Value()))
+
+	for _, section := range sections {
+		var sectionRows []cheatsheetRow
+		for _, action := range section.actions {
+			b := current[action]
+			if !b.Enabled() {
+				continue
+			}
+			row := cheatsheetRow{keys: b.Help().Key, desc: b.Help().Desc}
+			if def := defaults[action].Help().Key; def != row.keys {
+				row.defaultKey = def
+			}
+			if query != "" && !row.matches(query) {
+				continue
+			}
+			sectionRows = append(sectionRows, row)
+		}
+		if len(sectionRows) > 0 {
+			titles = append(titles, section.title)
+			rows = append(rows, sectionRows)
+		}
+	}
+	return titles, rows
+}
+
+// matches reports whether the row's keys or description contain query
+func (r cheatsheetRow) matches(query string) bool {
+	return strings.Contains(strings.ToLower(r.keys), query) ||
+		strings.Contains(strings.ToLower(r.desc), query) ||
+		strings.Contains(strings.ToLower(r.defaultKey), query)
+}
+
+// cheatsheetView renders the full-screen keybinding overlay
+func (m Model) cheatsheetView() string {
+	context := "Reminders"
+	if m.helpReturn == modeDetail {
+		context = "Detail view"
+	}
+
+	var lines []string
+	titles, rows := m.cheatsheetRows()
+	for i, title := range titles {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, titleStyle.UnsetMarginLeft().Render(title))
+		for _, row := range rows[i] {
+			line := "  " + selectedItemStyle.Render(fmt.Sprintf("%-12s", row.keys)) + " " + normalStyle.Render(row.desc)
+			if row.defaultKey != "" {
+				line += inputHintStyle.Render(fmt.Sprintf("  (default %s)", row.defaultKey))
+			}
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) == 0 {
+		lines = append(lines, inputHintStyle.Render("No matching keys"))
+	}
+
+	// Scroll when the list is taller than the screen
+	visible := m.height - 12
+	if visible < 5 {
+		visible = 5
+	}
+	scroll := m.helpScroll
+	if maxScroll := len(lines) - visible; scroll > maxScroll {
+		scroll = max(maxScroll, 0)
+	}
+	end := min(scroll+visible, len(lines))
+
+	var b strings.Builder
+	b.WriteString(inputLabelStyle.Render(glyphs.Keyboard + " Keyboard Shortcuts - " + context))
+	b.WriteString("\n\n")
+	b.WriteString(inputLabelStyle.Render(glyphs.Search+" ") + m.helpSearch.View())
+	b.WriteString("\n\n")
+	b.WriteString(strings.Join(lines[scroll:end], "\n"))
+	b.WriteString("\n\n")
+	b.WriteString(inputHintStyle.Render(fmt.Sprintf("type to search %s %s/%s scroll %s esc to close", glyphs.Bullet, glyphs.Up, glyphs.Down, glyphs.Bullet)))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modalBox(b.String()))
+}
+
+// newCheatsheetSearch creates the search input for the cheatsheet
+func newCheatsheetSearch() textinput.Model {
+	ti := textinput.New()
+	ti.Placeholder = "search keys..."
+	ti.CharLimit = 50
+	ti.Width = 30
+	return ti
+}
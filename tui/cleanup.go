@@ -0,0 +1,49 @@
+package tui
+
+import (
+	"time"
+
+	"go_remind/cleanup"
+)
+
+// cleanupRules returns the configured cleanup rules
+func (m *Model) cleanupRules() cleanup.Rules {
+	if m.config == nil {
+		return cleanup.Rules{}
+	}
+	return cleanup.Rules{
+		AcknowledgeAfter: m.config.Cleanup.AcknowledgeAge(),
+		DeleteAfter:      m.config.Cleanup.DeleteAge(),
+	}
+}
+
+// scheduleCleanup sets the next time the cleanup rules run. The first run
+// happens on the first tick after startup.
+func (m *Model) scheduleCleanup(next time.Time) {
+	m.nextCleanup = time.Time{}
+	if m.cleanupRules().Enabled() {
+		m.nextCleanup = next
+	}
+}
+
+// checkCleanup applies the cleanup rules if they're due and reports what
+// changed as a toast. Deleted file reminders are muted, as when deleted by
+// hand, so parsing their file again doesn't bring them back as new.
+func (m *Model) checkCleanup(now time.Time) {
+	if m.nextCleanup.IsZero() || now.Before(m.nextCleanup) {
+		return
+	}
+	m.scheduleCleanup(now.Add(m.config.Cleanup.CheckInterval()))
+
+	kept, res := cleanup.Apply(m.reminders, m.cleanupRules(), now)
+	if res.Empty() {
+		return
+	}
+	m.reminders = kept
+	for _, r := range res.Deleted {
+		m.mute(r)
+	}
+	m.refreshList()
+	m.saveState()
+	m.toastInfo("Cleanup: " + res.Summary())
+}
@@ -0,0 +1,245 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"go_remind/pkg/reminder"
+)
+
+// column is one of the columns of a compact line
+type column int
+
+const (
+	columnTime column = iota
+	columnCountdown
+	columnStatus
+	columnPriority
+	columnTags
+	columnDescription
+	columnSource
+)
+
+// columnNames are the names columns are configured by, in column order
+var columnNames = []string{"time", "countdown", "status", "priority", "tags", "description", "source"}
+
+// defaultColumns are the compact line's columns unless [ui] columns says
+// otherwise
+var defaultColumns = []column{columnTime, columnStatus, columnDescription, columnSource}
+
+// compactColumns are the compact line's columns, in order
+var compactColumns = defaultColumns
+
+// droppedFirst are the columns given up, in order, when the description
+// would be squeezed below minDescriptionWidth
+var droppedFirst = []column{columnSource, columnTags, columnCountdown, columnPriority}
+
+// minDescriptionWidth is the narrowest the description column gets while
+// there are other columns to drop
+const minDescriptionWidth = 20
+
+// SetColumns sets the compact line's columns and their order by name, e.g.
+// {"time", "description"}. None leaves the defaults. Unknown or repeated
+// names, or leaving out the description, are reported and nothing is
+// changed.
+func SetColumns(names []string) error {
+	if len(names) == 0 {
+		compactColumns = defaultColumns
+		return nil
+	}
+	cols := make([]column, 0, len(names))
+	for _, name := range names {
+		i := slices.Index(columnNames, strings.ToLower(name))
+		if i < 0 {
+			return fmt.Errorf("ui.columns: unknown column %q (want %s)", name, strings.Join(columnNames, ", "))
+		}
+		if slices.Contains(cols, column(i)) {
+			return fmt.Errorf("ui.columns: %q is listed twice", name)
+		}
+		cols = append(cols, column(i))
+	}
+	if !slices.Contains(cols, columnDescription) {
+		return fmt.Errorf("ui.columns: the description column can't be left out")
+	}
+	compactColumns = cols
+	return nil
+}
+
+// columnWidth returns how wide a column is on a compact line width wide.
+// The description's width is whatever the others leave.
+func columnWidth(c column, width int) int {
+	switch c {
+	case columnTime:
+		if width > 0 && width < tinyWidth {
+			return len("12/24 12:00")
+		}
+		return len("Dec 24 12:00pm")
+	case columnCountdown:
+		return len("in 100d 5h")
+	case columnStatus:
+		if width > 0 && width < narrowWidth {
+			return 4
+		}
+		return len("TRIGGERED")
+	case columnPriority:
+		return len("!!!")
+	case columnTags:
+		return 16
+	case columnSource:
+		return 18
+	}
+	return 0
+}
+
+// compactCell is one column of a compact line, padded to its width
+type compactCell struct {
+	column column
+	text   string
+}
+
+// compactLayout returns the columns that fit on a compact line width wide,
+// with their widths. The source column only shows on wide terminals, and
+// the others in droppedFirst go before the description gets too narrow.
+// Without a width, the description isn't cut.
+func compactLayout(width int) ([]column, []int) {
+	cols := slices.Clone(compactColumns)
+	if width > 0 && width < narrowWidth {
+		cols = slices.DeleteFunc(cols, func(c column) bool { return c == columnSource })
+	}
+	descWidth := func() int {
+		rest := width - 2 // The icon and its space
+		for _, c := range cols {
+			if c != columnDescription {
+				rest -= columnWidth(c, width) + 1
+			}
+		}
+		return rest
+	}
+	for _, c := range droppedFirst {
+		if width <= 0 || descWidth() >= minDescriptionWidth {
+			break
+		}
+		cols = slices.DeleteFunc(cols, func(d column) bool { return d == c })
+	}
+
+	widths := make([]int, len(cols))
+	for i, c := range cols {
+		widths[i] = columnWidth(c, width)
+		if c == columnDescription {
+			widths[i] = -1
+			if width > 0 {
+				widths[i] = max(descWidth(), 1)
+			}
+		}
+	}
+	return cols, widths
+}
+
+// compactCells lays out r as a compact line width wide, after its icon.
+// now is what the countdown counts from.
+func compactCells(r *reminder.Reminder, width int, now time.Time) []compactCell {
+	cols, widths := compactLayout(width)
+	cells := make([]compactCell, len(cols))
+	for i, c := range cols {
+		text := columnText(c, r, width, now)
+		if c == columnDescription && !slices.Contains(cols, columnPriority) {
+			text = titled(r)
+		}
+		if widths[i] >= 0 {
+			text = ansi.Truncate(text, widths[i], glyphs.Ellipsis)
+			if i < len(cols)-1 {
+				text += strings.Repeat(" ", widths[i]-ansi.StringWidth(text))
+			}
+		}
+		cells[i] = compactCell{column: c, text: text}
+	}
+	return cells
+}
+
+// columnText returns what r shows in a column, before it's fitted
+func columnText(c column, r *reminder.Reminder, width int, now time.Time) string {
+	switch c {
+	case columnTime:
+		if width > 0 && width < tinyWidth {
+			return r.DateTime.Format("1/2 15:04")
+		}
+		return r.DateTime.Format("Jan 2 3:04pm")
+	case columnCountdown:
+		if r.Status == reminder.Acknowledged {
+			return ""
+		}
+		d := r.DateTime.Sub(now)
+		if d < 0 {
+			return formatCountdown(-d) + " ago"
+		}
+		return "in " + formatCountdown(d)
+	case columnStatus:
+		return statusLabel(r.Status, width)
+	case columnPriority:
+		return strings.Repeat("!", int(r.Priority))
+	case columnTags:
+		tags := make([]string, len(r.Tags))
+		for i, tag := range r.Tags {
+			tags[i] = "#" + tag
+		}
+		return strings.Join(tags, " ")
+	case columnDescription:
+		return r.Description
+	case columnSource:
+		return filepath.Base(r.SourceFile)
+	}
+	return ""
+}
+
+// labelDotWidth is the room kept after every description for a label's
+// dot, so the columns after it line up whether there's one or not
+func labelDotWidth() int {
+	return lipgloss.Width(" " + glyphs.Label)
+}
+
+// compactCut reports whether r's description is cut short on a compact
+// line width wide
+func compactCut(r *reminder.Reminder, width int) bool {
+	cols, widths := compactLayout(width - labelDotWidth())
+	i := slices.Index(cols, columnDescription)
+	text := r.Description
+	if !slices.Contains(cols, columnPriority) {
+		text = titled(r)
+	}
+	return widths[i] >= 0 && ansi.StringWidth(text) > widths[i]
+}
+
+// renderCompactLine renders r as one line of the compact view in style,
+// with query highlighted in the description, its label's dot after it, and
+// the source dimmed
+func renderCompactLine(icon string, r *reminder.Reminder, width int, now time.Time, query string, style lipgloss.Style) string {
+	var b strings.Builder
+	b.WriteString(style.Render(icon + " "))
+	cells := compactCells(r, width-labelDotWidth(), now)
+	for i, cell := range cells {
+		if i > 0 {
+			b.WriteString(style.Render(" "))
+		}
+		switch cell.column {
+		case columnDescription:
+			text := strings.TrimRight(cell.text, " ")
+			dot := labelDot(r)
+			b.WriteString(highlightMatches(text, 0, query, style) + dot)
+			if i < len(cells)-1 {
+				pad := ansi.StringWidth(cell.text) - ansi.StringWidth(text) + labelDotWidth() - lipgloss.Width(dot)
+				b.WriteString(style.Render(strings.Repeat(" ", pad)))
+			}
+		case columnSource:
+			b.WriteString(sourceStyle.Render(cell.text))
+		default:
+			b.WriteString(style.Render(cell.text))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,423 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"go_remind/export"
+	"go_remind/pkg/datetime"
+	"go_remind/pkg/reminder"
+)
+
+// commandAction is what a bulk command does to each reminder it selects
+type commandAction struct {
+	verb    string // Shown in the confirmation, e.g. "Acknowledge"
+	arg     string // "to" or "by" if the action takes a time or an offset
+	applies func(r *reminder.Reminder) bool
+	apply   func(r *reminder.Reminder, c *bulkCommand, now time.Time) // nil to delete
+}
+
+func notDone(r *reminder.Reminder) bool { return r.Status != reminder.Acknowledged }
+
+// commandActions are the actions of command mode, by name
+var commandActions = map[string]commandAction{
+	"ack":        ackAction,
+	"done":       ackAction,
+	"reopen":     {verb: "Reopen", applies: func(r *reminder.Reminder) bool { return !notDone(r) || r.Status.Parked() }, apply: reopen},
+	"reschedule": rescheduleAction,
+	"move":       rescheduleAction,
+	"shift":      {verb: "Shift", arg: "by", applies: notDone, apply: retimeAfter},
+	"wait":       parkAction("Mark waiting", reminder.Waiting),
+	"someday":    parkAction("Mark someday", reminder.Someday),
+	"delete":     {verb: "Delete", applies: func(*reminder.Reminder) bool { return true }},
+}
+
+var (
+	ackAction = commandAction{verb: "Acknowledge", applies: notDone, apply: func(r *reminder.Reminder, _ *bulkCommand, now time.Time) {
+		r.Acknowledge(now)
+	}}
+	rescheduleAction = commandAction{verb: "Reschedule", arg: "to", applies: notDone, apply: retimeAfter}
+)
+
+func parkAction(verb string, status reminder.Status) commandAction {
+	return commandAction{
+		verb:    verb,
+		applies: func(r *reminder.Reminder) bool { return notDone(r) && r.Status != status },
+		apply:   func(r *reminder.Reminder, _ *bulkCommand, now time.Time) { r.Park(status, now) },
+	}
+}
+
+func reopen(r *reminder.Reminder, _ *bulkCommand, now time.Time) { r.Reopen(now) }
+
+func retimeAfter(r *reminder.Reminder, c *bulkCommand, now time.Time) { retime(r, c.after(r), now) }
+
+// bulkCommand is a parsed command: an action, the reminders it selects, and
+// the time or offset it takes
+type bulkCommand struct {
+	action  commandAction
+	filters []func(r *reminder.Reminder) bool
+	at      time.Time       // For "to"
+	offset  datetime.Offset // For "by"
+	argText string
+}
+
+// parseCommand parses a command like "reschedule overdue to tomorrow 9am"
+// or "ack all #standup before today". Selectors are combined: a reminder
+// must match each of them.
+func parseCommand(input string, now time.Time) (*bulkCommand, error) {
+	words := strings.Fields(strings.ToLower(strings.TrimPrefix(strings.TrimSpace(input), ":")))
+	if len(words) == 0 {
+		return nil, fmt.Errorf("type a command, e.g. ack overdue")
+	}
+	action, ok := commandActions[words[0]]
+	if !ok {
+		return nil, fmt.Errorf("unknown command %q: try ack, reopen, reschedule, shift, wait, someday, delete, filter, or export", words[0])
+	}
+	cmd := &bulkCommand{action: action}
+
+	selectors := words[1:]
+	if action.arg != "" {
+		i := indexOf(selectors, action.arg)
+		if i < 0 || i == len(selectors)-1 {
+			return nil, fmt.Errorf("%s needs %q, e.g. %s", words[0], action.arg, commandExample(action.arg, words[0]))
+		}
+		cmd.argText = strings.Join(selectors[i+1:], " ")
+		selectors = selectors[:i]
+
+		var err error
+		if action.arg == "to" {
+			cmd.at, err = datetime.Parse(cmd.argText, now)
+		} else {
+			cmd.offset, err = datetime.ParseOffset(cmd.argText)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(selectors) == 0 {
+		return nil, fmt.Errorf("say which reminders: all, overdue, today, tomorrow, #tag, before <date>, after <date>")
+	}
+	today := startOfDay(now)
+	for i := 0; i < len(selectors); i++ {
+		word := selectors[i]
+		switch {
+		case word == "all":
+			cmd.filters = append(cmd.filters, func(*reminder.Reminder) bool { return true })
+		case word == "overdue":
+			cmd.filters = append(cmd.filters, func(r *reminder.Reminder) bool {
+				return notDone(r) && !r.Status.Parked() && r.IsDueAt(now)
+			})
+		case word == "today", word == "tomorrow":
+			start := today
+			if word == "tomorrow" {
+				start = today.AddDate(0, 0, 1)
+			}
+			end := start.AddDate(0, 0, 1)
+			cmd.filters = append(cmd.filters, func(r *reminder.Reminder) bool {
+				return !r.DateTime.Before(start) && r.DateTime.Before(end)
+			})
+		case strings.HasPrefix(word, "#") && len(word) > 1:
+			tag := word[1:]
+			cmd.filters = append(cmd.filters, func(r *reminder.Reminder) bool {
+				for _, t := range r.Tags {
+					if strings.ToLower(t) == tag {
+						return true
+					}
+				}
+				return false
+			})
+		case word == "before", word == "after":
+			// The longest run of words that reads as a time
+			var when time.Time
+			n := 0
+			for j := len(selectors); j > i+1; j-- {
+				if t, ok := parseCommandTime(strings.Join(selectors[i+1:j], " "), now); ok {
+					when, n = t, j-i-1
+					break
+				}
+			}
+			if n == 0 {
+				return nil, fmt.Errorf("%s needs a date, e.g. %s today", word, word)
+			}
+			if word == "before" {
+				cmd.filters = append(cmd.filters, func(r *reminder.Reminder) bool { return r.DateTime.Before(when) })
+			} else {
+				cmd.filters = append(cmd.filters, func(r *reminder.Reminder) bool { return !r.DateTime.Before(when) })
+			}
+			i += n
+		default:
+			return nil, fmt.Errorf("don't know which reminders %q means", word)
+		}
+	}
+	return cmd, nil
+}
+
+// parseCommandTime reads the time after before or after. Day names mean
+// the start of that day.
+func parseCommandTime(s string, now time.Time) (time.Time, bool) {
+	today := startOfDay(now)
+	switch s {
+	case "today":
+		return today, true
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), true
+	case "yesterday":
+		return today.AddDate(0, 0, -1), true
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, now.Location()); err == nil {
+		return t, true
+	}
+	if t, err := datetime.Parse(s, now); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func commandExample(arg, name string) string {
+	if arg == "to" {
+		return name + " overdue to tomorrow 9am"
+	}
+	return name + " #launch by +7d"
+}
+
+// pluralReminders formats a number of reminders
+func pluralReminders(n int) string {
+	if n == 1 {
+		return "1 reminder"
+	}
+	return fmt.Sprintf("%d reminders", n)
+}
+
+func indexOf(words []string, word string) int {
+	for i, w := range words {
+		if w == word {
+			return i
+		}
+	}
+	return -1
+}
+
+// targets returns the reminders the command selects and can act on
+func (c *bulkCommand) targets(reminders []*reminder.Reminder) []*reminder.Reminder {
+	var matched []*reminder.Reminder
+	for _, r := range reminders {
+		if !c.action.applies(r) {
+			continue
+		}
+		ok := true
+		for _, f := range c.filters {
+			if !f(r) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			matched = append(matched, r)
+		}
+	}
+	return matched
+}
+
+// after returns when r will be due once the command has run
+func (c *bulkCommand) after(r *reminder.Reminder) time.Time {
+	switch c.action.arg {
+	case "to":
+		return c.at
+	case "by":
+		return c.offset.Apply(r.DateTime)
+	}
+	return r.DateTime
+}
+
+func newCommandInput() textinput.Model {
+	ti := textinput.New()
+	ti.Prompt = ":"
+	ti.Placeholder = "ack overdue  or  reschedule #standup today to tomorrow 9am"
+	ti.CharLimit = 200
+	ti.Width = 60
+	return ti
+}
+
+// openCommand starts command mode
+func (m *Model) openCommand() tea.Cmd {
+	m.commandInput.Reset()
+	m.command = nil
+	m.inputError = ""
+	m.mode = modeCommand
+	return m.commandInput.Focus()
+}
+
+func (m Model) updateCommandMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Confirming a parsed command
+	if m.command != nil {
+		switch msg.String() {
+		case "y", "enter":
+			cmd := m.runCommand(m.command)
+			m.command = nil
+			m.commandInput.Blur()
+			m.mode = modeNormal
+			return m, cmd
+		case "n", "esc":
+			// Back to editing the command
+			m.command = nil
+			return m, m.commandInput.Focus()
+		}
+		return m, nil
+	}
+
+	switch msg.Type {
+	case tea.KeyEscape:
+		m.commandInput.Blur()
+		m.inputError = ""
+		m.mode = modeNormal
+		return m, nil
+	case tea.KeyEnter:
+		if name, ok := strings.CutPrefix(strings.ToLower(strings.TrimSpace(m.commandInput.Value())), "filter"); ok {
+			// Not a bulk command: there's nothing to confirm
+			d, ok := parseDateRange(name)
+			if !ok {
+				m.inputError = "filter by today, next 3 days, this week, overdue, or clear"
+				return m, nil
+			}
+			m.setDateRange(d)
+			m.commandInput.Blur()
+			m.inputError = ""
+			m.mode = modeNormal
+			return m, nil
+		}
+		if name, ok := strings.CutPrefix(strings.ToLower(strings.TrimSpace(m.commandInput.Value())), "export"); ok {
+			f, err := export.ParseFormat(name)
+			if err != nil {
+				m.inputError = err.Error()
+				return m, nil
+			}
+			m.commandInput.Blur()
+			m.inputError = ""
+			m.mode = modeNormal
+			m.exportView(f)
+			return m, nil
+		}
+		cmd, err := parseCommand(m.commandInput.Value(), m.now())
+		if err != nil {
+			m.inputError = err.Error()
+			return m, nil
+		}
+		if len(cmd.targets(m.reminders)) == 0 {
+			m.inputError = "no reminders match"
+			return m, nil
+		}
+		m.command = cmd
+		m.inputError = ""
+		m.commandInput.Blur()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.commandInput, cmd = m.commandInput.Update(msg)
+	m.inputError = ""
+	return m, cmd
+}
+
+// runCommand applies the command to the reminders it selects. Everything
+// but delete can be undone. The returned command tells remote sources
+// about any acknowledgments.
+func (m *Model) runCommand(c *bulkCommand) tea.Cmd {
+	targets := c.targets(m.reminders)
+	now := m.now()
+	var acked []*reminder.Reminder
+	if c.action.apply == nil {
+		deleted := make(map[*reminder.Reminder]bool, len(targets))
+		for _, r := range targets {
+			deleted[r] = true
+		}
+		kept := m.reminders[:0]
+		for _, r := range m.reminders {
+			if !deleted[r] {
+				kept = append(kept, r)
+			}
+		}
+		m.reminders = kept
+		m.lastBulk = nil
+	} else {
+		m.rememberBulk(targets)
+		for _, r := range targets {
+			wasDone := r.Status == reminder.Acknowledged
+			c.action.apply(r, c, now)
+			if !wasDone && r.Status == reminder.Acknowledged {
+				acked = append(acked, r)
+			}
+		}
+		reminder.SortByDateTime(m.reminders)
+	}
+	m.refreshList()
+	m.saveState()
+
+	msg := c.action.verb + ": " + pluralReminders(len(targets))
+	if c.action.apply != nil {
+		msg += " (" + keys.Undo.Help().Key + " to undo)"
+	}
+	m.toastSuccess(msg)
+	return m.acknowledgeAtSources(acked...)
+}
+
+// commandView renders the command line, or the confirmation once a command
+// has been entered
+func (m Model) commandView() string {
+	var b strings.Builder
+	if m.command == nil {
+		b.WriteString(inputBoxStyle.Render(m.commandInput.View()))
+		b.WriteString("\n")
+		b.WriteString(inputHintStyle.Render("  <ack|reopen|reschedule|shift|wait|someday|delete> <all|overdue|today|tomorrow|#tag|before ...|after ...> [to <time>|by <offset>]"))
+		b.WriteString("\n")
+		b.WriteString(inputHintStyle.Render("  filter <today|next 3 days|this week|overdue|clear>    export <md|csv|table>"))
+		if marks := m.marksHint(); marks != "" {
+			b.WriteString("\n")
+			b.WriteString(inputHintStyle.Render("  marks  " + marks))
+		}
+		if m.inputError != "" {
+			b.WriteString("\n")
+			b.WriteString(triggeredStyle.Render("  " + glyphs.Warning + " " + m.inputError))
+		}
+		return b.String()
+	}
+
+	c := m.command
+	targets := c.targets(m.reminders)
+	title := c.action.verb + " " + pluralReminders(len(targets))
+	if c.action.arg != "" {
+		title += " " + c.action.arg + " " + c.argText
+	}
+	b.WriteString(inputLabelStyle.Render(title + "?"))
+	b.WriteString("\n\n")
+
+	const format = "Mon Jan 2 3:04pm"
+	for i, r := range targets {
+		if i == shiftPreviewRows {
+			b.WriteString(inputHintStyle.Render(fmt.Sprintf("  %s and %d more", glyphs.Ellipsis, len(targets)-i)))
+			b.WriteString("\n")
+			break
+		}
+		line := fmt.Sprintf("  %-16s", r.DateTime.Format(format))
+		if c.action.arg != "" {
+			line += " " + glyphs.Cursor + " " + fmt.Sprintf("%-16s", c.after(r).Format(format))
+		}
+		b.WriteString(normalStyle.Render(line))
+		b.WriteString("  " + sourceStyle.Render(ansi.Truncate(r.Description, 40, glyphs.Ellipsis)))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(inputHintStyle.Render("y or enter to run " + glyphs.Bullet + " n or esc to edit the command"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modalBox(b.String()))
+}
@@ -0,0 +1,163 @@
+package tui
+
+import (
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"go_remind/pkg/reminder"
+)
+
+// composeHints follow the reminder in the file opened to compose it, and
+// are taken out again when it's read back
+var composeHints = []string{
+	"# The first line is when and what, e.g. +1h Call mom or fri 9am Review the PR ~1h.",
+	"# The lines below it are notes. Save and quit to save the reminder, or",
+	"# empty the first line to cancel. These lines are removed.",
+}
+
+// ComposedMsg reports that the editor composing a reminder exited
+type ComposedMsg struct {
+	path    string // The file it was composed in
+	editing string // The ID of the reminder being edited; "" for a new one
+	started string // The text the file started with, hints aside
+	err     error
+}
+
+// execProcess runs cmd with the TUI suspended until it exits. Replaced in
+// tests.
+var execProcess = tea.ExecProcess
+
+// editorCommand returns $VISUAL or else $EDITOR split into its program and
+// arguments, or none when neither is set
+func editorCommand() []string {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	return strings.Fields(editor)
+}
+
+// compose opens text in $VISUAL or $EDITOR to write a reminder there
+// instead of the add box, with hints on the format below it. editing is
+// the reminder it changes, or nil for a new one.
+func (m *Model) compose(text string, editing *reminder.Reminder) tea.Cmd {
+	args := editorCommand()
+	if len(args) == 0 {
+		m.toastError("Set $VISUAL or $EDITOR to compose in an editor")
+		return nil
+	}
+	f, err := os.CreateTemp("", "go_remind-*.md")
+	if err != nil {
+		m.toastError("Could not compose: " + err.Error())
+		return nil
+	}
+	msg := ComposedMsg{path: f.Name(), started: text}
+	if editing != nil {
+		msg.editing = editing.ID
+	}
+	_, err = f.WriteString(text + "\n\n" + strings.Join(composeHints, "\n") + "\n")
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		m.toastError("Could not compose: " + err.Error())
+		return nil
+	}
+	cmd := exec.Command(args[0], append(args[1:], f.Name())...)
+	return execProcess(cmd, func(err error) tea.Msg {
+		msg.err = err
+		return msg
+	})
+}
+
+// composeFromAdd hands what's in the add or edit box to the editor. The
+// draft is kept until the reminder saves, in case the editor is lost.
+func (m *Model) composeFromAdd() tea.Cmd {
+	text, editing := m.addInput.Value(), m.editingReminder
+	cmd := m.compose(text, editing)
+	if cmd != nil {
+		m.closeAdd()
+	}
+	return cmd
+}
+
+// applyComposed saves the reminder written in the editor. When it can't
+// be saved, what was written is put in the add box with the reason, so
+// nothing is lost.
+func (m *Model) applyComposed(msg ComposedMsg) tea.Cmd {
+	data, err := os.ReadFile(msg.path)
+	os.Remove(msg.path)
+	text := msg.started
+	if err == nil {
+		lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+		lines = slices.DeleteFunc(lines, func(line string) bool {
+			return slices.Contains(composeHints, strings.TrimRight(line, " \t"))
+		})
+		text = strings.Join(lines, "\n")
+	}
+	entry, notes := splitEntry(text)
+	text = entry
+	if notes != "" {
+		text += "\n" + notes
+	}
+
+	var editing *reminder.Reminder
+	if msg.editing != "" {
+		if editing = m.reminderByID(msg.editing); editing == nil {
+			return m.reopenAdd(text, nil, "The reminder was deleted while composing; enter adds it as new")
+		}
+	}
+	switch {
+	case msg.err != nil:
+		return m.reopenAdd(text, editing, "Editor: "+msg.err.Error())
+	case err != nil:
+		return m.reopenAdd(text, editing, "Could not read what was composed: "+err.Error())
+	case entry == "":
+		m.discardDraft()
+		m.toastInfo("Compose cancelled")
+		return nil
+	case editing != nil && text == editText(editing):
+		m.discardDraft()
+		m.toastInfo("No changes")
+		return nil
+	}
+
+	if editing != nil {
+		before := *editing
+		err = m.updateReminder(editing, text)
+		if err == nil {
+			m.writeBack(&before, editing)
+		}
+	} else {
+		err = m.addReminder(text)
+	}
+	if err != nil {
+		return m.reopenAdd(text, editing, err.Error())
+	}
+	m.rememberInput(&m.addHistory, text)
+	m.discardDraft()
+	return nil
+}
+
+// reopenAdd opens the add or edit box on text with problem shown under it
+func (m *Model) reopenAdd(text string, editing *reminder.Reminder, problem string) tea.Cmd {
+	m.mode = modeAdd
+	m.detailReminder = nil
+	m.detailScroll = 0
+	m.editingReminder = editing
+	m.addInput.SetValue(text)
+	m.addInput.Focus()
+	m.addInput.CursorEnd()
+	m.draftSaved = text
+	m.draftPrompt = false
+	resetInputHistory(&m.addHistory)
+	m.fitAddInput()
+	m.inputError = problem
+	return textarea.Blink
+}
@@ -0,0 +1,55 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go_remind/conflicts"
+	"go_remind/pkg/datetime"
+	"go_remind/pkg/parser"
+)
+
+// maxConflictsShown is how many conflicting reminders a warning names
+const maxConflictsShown = 3
+
+// inputTime returns the time an add or edit input starts with, or its
+// tag's default time, read the same way as when the reminder is saved, and
+// the description after it
+func (m Model) inputTime(input string) (time.Time, string, bool) {
+	words := strings.Fields(input)
+	for n := len(words) - 1; n >= 1; n-- {
+		if t, err := datetime.Parse(strings.Join(words[:n], " "), m.now()); err == nil {
+			return t, strings.Join(words[n:], " "), true
+		}
+	}
+	if t, ok := parser.TagDefault(input, m.now()); ok {
+		return t, strings.TrimSpace(input), true
+	}
+	return time.Time{}, strings.TrimSpace(input), false
+}
+
+// conflictWarning names the reminders due close to the time in input, or
+// returns "" if there are none
+func (m Model) conflictWarning(input string) string {
+	entry, _ := splitEntry(input)
+	at, _, ok := m.inputTime(entry)
+	if !ok {
+		return ""
+	}
+	window := m.config.Conflicts.WindowDuration()
+	near := conflicts.Near(m.reminders, at, window, m.editingReminder)
+	if len(near) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, maxConflictsShown)
+	for i, r := range near {
+		if i == maxConflictsShown {
+			names = append(names, fmt.Sprintf("%d more", len(near)-i))
+			break
+		}
+		names = append(names, fmt.Sprintf("%s (%s)", r.Description, r.DateTime.Format("Mon 3:04pm")))
+	}
+	return fmt.Sprintf("Within %s of %s. Press enter again to save anyway", formatDuration(window), strings.Join(names, ", "))
+}
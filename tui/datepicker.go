@@ -0,0 +1,241 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"go_remind/pkg/reminder"
+)
+
+// pickerField is the part of the date picker that keys change
+type pickerField int
+
+const (
+	pickDay pickerField = iota
+	pickHour
+	pickMinute
+)
+
+// minuteStep is how far the minute spinner moves per key press
+const minuteStep = 5
+
+// datePicker picks a day from a month calendar and a time from hour and
+// minute spinners
+type datePicker struct {
+	at    time.Time
+	field pickerField
+}
+
+// move changes the focused field by steps: days, hours, or minuteStep
+// minutes. The time of day is kept when the day changes, and the day
+// when the time wraps.
+func (p *datePicker) move(steps int) {
+	at := p.at
+	switch p.field {
+	case pickDay:
+		p.at = at.AddDate(0, 0, steps)
+	case pickHour:
+		hour := (at.Hour() + steps + 24) % 24
+		p.at = time.Date(at.Year(), at.Month(), at.Day(), hour, at.Minute(), 0, 0, at.Location())
+	case pickMinute:
+		minute := (at.Minute()/minuteStep*minuteStep + steps*minuteStep + 60) % 60
+		p.at = time.Date(at.Year(), at.Month(), at.Day(), at.Hour(), minute, 0, 0, at.Location())
+	}
+}
+
+// moveMonth changes the day by months, keeping it within the new month
+func (p *datePicker) moveMonth(months int) {
+	at := p.at
+	first := time.Date(at.Year(), at.Month()+time.Month(months), 1, at.Hour(), at.Minute(), 0, 0, at.Location())
+	day := at.Day()
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+	p.at = first.AddDate(0, 0, day-1)
+}
+
+// openReschedule shows the date picker for r, starting at its current time
+func (m *Model) openReschedule(r *reminder.Reminder) {
+	if r == nil {
+		return
+	}
+	m.pickerReturn = modeNormal
+	if m.mode == modeDetail {
+		m.pickerReturn = modeDetail
+	}
+	m.pickerReminder = r
+	m.picker = datePicker{at: r.DateTime.Truncate(time.Minute)}
+	m.mode = modeReschedule
+}
+
+func (m Model) updateRescheduleMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEscape:
+		m.mode = m.pickerReturn
+		m.pickerReminder = nil
+		return m, nil
+	case tea.KeyEnter:
+		m.reschedule(m.pickerReminder, m.picker.at)
+		m.mode = m.pickerReturn
+		m.pickerReminder = nil
+		return m, nil
+	case tea.KeyTab:
+		m.picker.field = (m.picker.field + 1) % (pickMinute + 1)
+		return m, nil
+	case tea.KeyShiftTab:
+		m.picker.field = (m.picker.field + pickMinute) % (pickMinute + 1)
+		return m, nil
+	case tea.KeyPgUp:
+		m.picker.moveMonth(-1)
+		return m, nil
+	case tea.KeyPgDown:
+		m.picker.moveMonth(1)
+		return m, nil
+	}
+
+	switch {
+	case msg.String() == "[":
+		m.picker.moveMonth(-1)
+	case msg.String() == "]":
+		m.picker.moveMonth(1)
+	case msg.String() == ".":
+		// Today, keeping the time
+		now, at := m.now(), m.picker.at
+		m.picker.at = time.Date(now.Year(), now.Month(), now.Day(), at.Hour(), at.Minute(), 0, 0, at.Location())
+	case key.Matches(msg, keys.Left):
+		if m.picker.field == pickDay {
+			m.picker.move(-1)
+		}
+	case key.Matches(msg, keys.Right):
+		if m.picker.field == pickDay {
+			m.picker.move(1)
+		}
+	case key.Matches(msg, keys.Up):
+		if m.picker.field == pickDay {
+			m.picker.move(-7)
+		} else {
+			m.picker.move(1)
+		}
+	case key.Matches(msg, keys.Down):
+		if m.picker.field == pickDay {
+			m.picker.move(7)
+		} else {
+			m.picker.move(-1)
+		}
+	}
+	return m, nil
+}
+
+// reschedule moves r to at, leaving its description and tags alone
+func (m *Model) reschedule(r *reminder.Reminder, at time.Time) {
+	if r == nil || r.DateTime.Equal(at) {
+		return
+	}
+	retime(r, at, m.now())
+	reminder.SortByDateTime(m.reminders)
+	m.refreshList()
+	m.saveState()
+	m.toastSuccess("Rescheduled to " + at.Format("Mon Jan 2 3:04pm") + ": " + r.Description)
+}
+
+// retime moves r to at, triggering it if that's already past or returning
+// it to pending if it's now in the future
+func retime(r *reminder.Reminder, at, now time.Time) {
+	r.DateTime = at
+	r.UpdatedAt = now
+	if r.IsDueAt(now) {
+		if r.Status.Scheduled() {
+			r.Status = reminder.Triggered
+		}
+	} else if r.Status == reminder.Triggered {
+		r.Status = reminder.Pending
+	}
+}
+
+// rescheduleView renders the date picker
+func (m Model) rescheduleView() string {
+	p := m.picker
+	var b strings.Builder
+	b.WriteString(inputLabelStyle.Render(glyphs.Calendar + " Reschedule"))
+	if m.pickerReminder != nil {
+		b.WriteString(normalStyle.Render("  " + m.pickerReminder.Description))
+	}
+	b.WriteString("\n\n")
+
+	monthStyle := normalStyle
+	if p.field == pickDay {
+		monthStyle = inputLabelStyle
+	}
+	b.WriteString(monthStyle.Render(fmt.Sprintf("%-20s", p.at.Format("January 2006"))))
+	b.WriteString("\n")
+	b.WriteString(sourceStyle.Render("Su Mo Tu We Th Fr Sa"))
+	b.WriteString("\n")
+
+	// One row per week, with blanks before the 1st
+	now := m.now()
+	first := time.Date(p.at.Year(), p.at.Month(), 1, 0, 0, 0, 0, p.at.Location())
+	days := first.AddDate(0, 1, -1).Day()
+	b.WriteString(strings.Repeat("   ", int(first.Weekday())))
+	for day := 1; day <= days; day++ {
+		date := first.AddDate(0, 0, day-1)
+		cell := fmt.Sprintf("%2d", day)
+		switch {
+		case day == p.at.Day() && p.field == pickDay:
+			cell = selectedItemStyle.Render(cell)
+		case day == p.at.Day():
+			cell = inputLabelStyle.Render(cell)
+		case sameDay(date, now):
+			cell = tagStyle.Render(cell)
+		default:
+			cell = normalStyle.Render(cell)
+		}
+		b.WriteString(cell)
+		if date.Weekday() == time.Saturday {
+			b.WriteString("\n")
+		} else if day < days {
+			b.WriteString(" ")
+		}
+	}
+	if first.AddDate(0, 0, days-1).Weekday() != time.Saturday {
+		b.WriteString("\n")
+	}
+
+	// Time spinner
+	spin := func(value string, field pickerField) string {
+		if p.field == field {
+			return selectedItemStyle.Render(glyphs.Up + value + glyphs.Down)
+		}
+		return normalStyle.Render(" " + value + " ")
+	}
+	b.WriteString("\n")
+	b.WriteString(inputHintStyle.Render("Time "))
+	b.WriteString(spin(p.at.Format("15"), pickHour))
+	b.WriteString(normalStyle.Render(":"))
+	b.WriteString(spin(p.at.Format("04"), pickMinute))
+	b.WriteString("\n\n")
+
+	if m.pickerReminder != nil {
+		b.WriteString(inputHintStyle.Render("From " + m.pickerReminder.DateTime.Format("Mon Jan 2 3:04pm")))
+		b.WriteString("\n")
+	}
+	b.WriteString(normalStyle.Render("To   " + p.at.Format("Mon Jan 2 3:04pm")))
+	b.WriteString("\n\n")
+
+	b.WriteString(inputHintStyle.Render("arrows to move " + glyphs.Bullet + " [ ] month " + glyphs.Bullet + " . today " + glyphs.Bullet + " tab time"))
+	b.WriteString("\n")
+	b.WriteString(inputHintStyle.Render("enter to reschedule " + glyphs.Bullet + " esc to cancel"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modalBox(b.String()))
+}
+
+// sameDay reports whether a and b fall on the same calendar day
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
@@ -0,0 +1,81 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	"go_remind/pkg/reminder"
+)
+
+// dateRange is a preset that narrows the list to reminders due in a span
+// of time, on top of the text or tag filter
+type dateRange int
+
+const (
+	rangeAny dateRange = iota
+	rangeToday
+	rangeNext3Days
+	rangeThisWeek
+	rangeOverdue
+)
+
+// dateRangeNames are shown in the status bar and the filter box, in the
+// order of the number keys that pick them
+var dateRangeNames = []string{"any time", "today", "next 3 days", "this week", "overdue"}
+
+// dateRangeAliases are the other names the command palette accepts
+var dateRangeAliases = map[string]dateRange{
+	"all":   rangeAny,
+	"any":   rangeAny,
+	"clear": rangeAny,
+	"off":   rangeAny,
+	"3d":    rangeNext3Days,
+	"week":  rangeThisWeek,
+}
+
+// parseDateRange reads a date range by name or alias
+func parseDateRange(s string) (dateRange, bool) {
+	s = strings.Join(strings.Fields(strings.ToLower(s)), " ")
+	for i, name := range dateRangeNames {
+		if s == name {
+			return dateRange(i), true
+		}
+	}
+	d, ok := dateRangeAliases[s]
+	return d, ok
+}
+
+// contains reports whether r falls in the range at now. Done and parked
+// reminders are never overdue.
+func (d dateRange) contains(r *reminder.Reminder, now time.Time) bool {
+	today := startOfDay(now)
+	switch d {
+	case rangeToday:
+		return !r.DateTime.Before(today) && r.DateTime.Before(today.AddDate(0, 0, 1))
+	case rangeNext3Days:
+		return !r.DateTime.Before(today) && r.DateTime.Before(today.AddDate(0, 0, 3))
+	case rangeThisWeek:
+		return !r.DateTime.Before(today) && !r.DateTime.After(newSectionBounds(now).thisWeekEnd)
+	case rangeOverdue:
+		return notDone(r) && !r.Status.Parked() && r.IsDueAt(now)
+	default:
+		return true
+	}
+}
+
+// setDateRange narrows the list to d, moving the cursor back to the top
+func (m *Model) setDateRange(d dateRange) {
+	m.dateRange = d
+	m.gridIndex, m.gridScroll = 0, 0
+	m.compactIndex, m.compactScroll = 0, 0
+	m.refreshList()
+}
+
+// dateRangeHint lists the number keys that pick a range in the filter box
+func dateRangeHint() string {
+	var parts []string
+	for i := 1; i < len(dateRangeNames); i++ {
+		parts = append(parts, string(rune('0'+i))+" "+dateRangeNames[i])
+	}
+	return strings.Join(parts, "  ") + "  0 " + dateRangeNames[rangeAny]
+}
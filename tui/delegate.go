@@ -4,12 +4,13 @@ import (
 	"fmt"
 	"io"
 	"path/filepath"
+	"time"
 
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
-	"go_remind/reminder"
+	"go_remind/pkg/reminder"
 )
 
 // reminderItem wraps a Reminder to implement list.Item
@@ -30,7 +31,10 @@ func (i reminderItem) FilterValue() string {
 }
 
 // itemDelegate handles rendering of list items
-type itemDelegate struct{}
+type itemDelegate struct {
+	query string           // Highlighted in descriptions, lowercase
+	now   func() time.Time // What countdowns count from; the wall clock if nil
+}
 
 func (d itemDelegate) Height() int {
 	if currentLayout == LayoutCard {
@@ -63,58 +67,32 @@ func (d itemDelegate) Render(w io.Writer, m list.Model, index int, listItem list
 
 func (d itemDelegate) renderCompact(w io.Writer, m list.Model, index int, i reminderItem) {
 	r := i.reminder
-	timeStr := r.DateTime.Format("Jan 2 3:04pm")
-	source := filepath.Base(r.SourceFile)
-
-	var statusIcon string
-	var style lipgloss.Style
-
-	switch r.Status {
-	case reminder.Triggered:
-		statusIcon = "🔔"
-		style = triggeredStyle
-	case reminder.Acknowledged:
-		statusIcon = "✓"
-		style = acknowledgedStyle
-	default:
-		statusIcon = "○"
-		style = normalStyle
-	}
+	statusIcon := statusGlyph(r.Status)
+	style := statusStyle(r.Status)
 
 	isSelected := index == m.Index()
 	if isSelected {
-		statusIcon = "▸"
+		statusIcon = glyphs.Cursor
 		if r.Status != reminder.Triggered && r.Status != reminder.Acknowledged {
 			style = selectedItemStyle
 		}
 	}
 
-	// Use more space for description - no truncation, let it wrap naturally
-	line := fmt.Sprintf("%s %-18s %-12s %s", statusIcon, timeStr, r.Status.String(), r.Description)
-	styledLine := style.Render(line)
-	sourcePart := sourceStyle.Render("  " + source)
-
-	fmt.Fprintf(w, "%s%s", styledLine, sourcePart)
+	now := time.Now
+	if d.now != nil {
+		now = d.now
+	}
+	fmt.Fprint(w, renderCompactLine(statusIcon, r, m.Width(), now(), d.query, style))
 }
 
 func (d itemDelegate) renderCard(w io.Writer, m list.Model, index int, i reminderItem) {
 	r := i.reminder
-	timeStr := r.DateTime.Format("Mon Jan 2 • 3:04pm")
+	timeStr := r.DateTime.Format("Mon Jan 2") + " " + glyphs.Bullet + " " + r.DateTime.Format("3:04pm")
 	source := filepath.Base(r.SourceFile)
 	isSelected := index == m.Index()
 
-	var style, borderColor lipgloss.Style
-	switch r.Status {
-	case reminder.Triggered:
-		style = triggeredStyle
-		borderColor = lipgloss.NewStyle().Foreground(triggeredStyle.GetForeground())
-	case reminder.Acknowledged:
-		style = acknowledgedStyle
-		borderColor = lipgloss.NewStyle().Foreground(acknowledgedStyle.GetForeground())
-	default:
-		style = normalStyle
-		borderColor = lipgloss.NewStyle().Foreground(normalStyle.GetForeground())
-	}
+	style := statusStyle(r.Status)
+	borderColor := lipgloss.NewStyle().Foreground(style.GetForeground())
 
 	if isSelected {
 		borderColor = lipgloss.NewStyle().Foreground(selectedItemStyle.GetForeground())
@@ -123,14 +101,23 @@ func (d itemDelegate) renderCard(w io.Writer, m list.Model, index int, i reminde
 		}
 	}
 
+	cardWidth := 60
+	if m.Width() > 0 && m.Width()-cardGutter < cardWidth {
+		cardWidth = m.Width() - cardGutter
+	}
 	cardStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(glyphs.Border).
 		BorderForeground(borderColor.GetForeground()).
 		Padding(0, 1).
-		Width(60)
-
-	desc := style.Render(r.Description)
-	meta := sourceStyle.Render(timeStr + "  •  " + source + "  •  " + r.Status.String())
+		Width(cardWidth)
+
+	desc := highlightMatches(titled(r), 0, d.query, style)
+	sep := "  " + glyphs.Bullet + "  "
+	status := statusLabel(r.Status, m.Width())
+	meta := sourceStyle.Render(timeStr+sep+source+sep+status) + labelDot(r)
+	if m.Width() > 0 && m.Width() < tinyWidth {
+		meta = sourceStyle.Render(timeStr+"\n"+source+sep+status) + labelDot(r)
+	}
 	content := desc + "\n" + meta
 
 	fmt.Fprint(w, cardStyle.Render(content))
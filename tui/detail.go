@@ -2,20 +2,37 @@ package tui
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 
-	"go_remind/reminder"
+	"go_remind/pkg/parser"
+	"go_remind/pkg/reminder"
 )
 
+// Tabs of the detail view
+const (
+	detailTabInfo = iota
+	detailTabNotes
+	detailTabHistory
+	detailTabRelated
+	detailTabSource
+	detailTabLinks
+)
+
+// detailTabNames are shown above the detail view, in order
+var detailTabNames = []string{"Info", "Notes", "History", "Related", "Source", "Links"}
+
+// detailInfoLines is how much of the description the Info tab shows
+const detailInfoLines = 3
+
 func (m Model) detailView() string {
 	if m.detailReminder == nil {
 		return ""
 	}
 
-	r := m.detailReminder
-
 	// Detail card
 	cardWidth := m.width - 8
 	if cardWidth < 40 {
@@ -24,66 +41,146 @@ func (m Model) detailView() string {
 	if cardWidth > 100 {
 		cardWidth = 100
 	}
+	detailCard := m.detailCard(m.detailReminder, cardWidth, true)
 
-	var statusStyle lipgloss.Style
-	switch r.Status {
-	case reminder.Triggered:
-		statusStyle = triggeredStyle
-	case reminder.Acknowledged:
-		statusStyle = acknowledgedStyle
-	default:
-		statusStyle = normalStyle
-	}
+	// Center the card
+	cardStyle := lipgloss.NewStyle().
+		Width(m.width).
+		Height(m.height).
+		AlignHorizontal(lipgloss.Center).
+		AlignVertical(lipgloss.Center)
+
+	return cardStyle.Render(detailCard)
+}
 
+// detailCard renders r's details on the current tab in a card cardWidth
+// wide. An unfocused card, in the split pane, has a dim border.
+func (m Model) detailCard(r *reminder.Reminder, cardWidth int, focused bool) string {
+	border := statusStyle(r.Status).GetForeground()
+	if !focused {
+		border = sourceStyle.GetForeground()
+	}
 	detailCardStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(statusStyle.GetForeground()).
+		Border(glyphs.Border).
+		BorderForeground(border).
 		Padding(1, 2).
 		Width(cardWidth)
 
-	// Content with scrolling
+	var content strings.Builder
+	content.WriteString(m.detailTabsView())
+	content.WriteString("\n\n")
+	switch m.detailTab {
+	case detailTabNotes:
+		content.WriteString(m.detailNotesView(r, cardWidth))
+	case detailTabHistory:
+		content.WriteString(m.detailHistoryView(r))
+	case detailTabRelated:
+		content.WriteString(m.detailRelatedView(r, cardWidth))
+	case detailTabSource:
+		content.WriteString(m.detailSourceView(r, cardWidth))
+	case detailTabLinks:
+		content.WriteString(m.detailLinksView(r))
+	default:
+		content.WriteString(m.detailInfoView(r, cardWidth))
+	}
+	content.WriteString("\n\n")
+	switch {
+	case !m.splitActive():
+		content.WriteString(inputHintStyle.Render("Press ESC to close"))
+	case focused:
+		content.WriteString(inputHintStyle.Render("Press ESC to return to the list"))
+	default:
+		content.WriteString(inputHintStyle.Render("Press " + keys.Detail.Help().Key + " to focus"))
+	}
+
+	return detailCardStyle.Render(content.String())
+}
+
+// detailInfoView renders the detail view's Info tab: the start of the
+// description and the reminder's metadata
+func (m Model) detailInfoView(r *reminder.Reminder, cardWidth int) string {
+	style := statusStyle(r.Status)
+
 	var content strings.Builder
 	content.WriteString(inputLabelStyle.Render("Description:"))
 	content.WriteString("\n\n")
 
-	// Wrap description text
+	// The rest, and any notes, are on the Notes tab
 	descLines := wrapText(r.Description, cardWidth-4)
-	visibleLines := m.height - 15
-	if visibleLines < 5 {
-		visibleLines = 5
-	}
-
-	startLine := m.detailScroll
-	endLine := startLine + visibleLines
-	if endLine > len(descLines) {
-		endLine = len(descLines)
-	}
-	if startLine >= len(descLines) {
-		startLine = len(descLines) - 1
-		if startLine < 0 {
-			startLine = 0
-		}
+	shown := min(len(descLines), detailInfoLines)
+	for _, line := range descLines[:shown] {
+		content.WriteString(normalStyle.Render(line))
+		content.WriteString("\n")
 	}
-
-	for i := startLine; i < endLine; i++ {
-		content.WriteString(normalStyle.Render(descLines[i]))
+	if all := detailLines(r, cardWidth-4); len(all) > shown {
+		content.WriteString(inputHintStyle.Render(fmt.Sprintf("%s %d more lines on the Notes tab", glyphs.Ellipsis, len(all)-shown)))
 		content.WriteString("\n")
 	}
 
 	content.WriteString("\n")
-	content.WriteString(sourceStyle.Render("─────────────────────────────────"))
+	content.WriteString(sourceStyle.Render(strings.Repeat(glyphs.Rule, 33)))
 	content.WriteString("\n\n")
 
 	// Metadata
+	if r.Context != "" {
+		content.WriteString(inputHintStyle.Render("Under: "))
+		content.WriteString(normalStyle.Render(r.Context))
+		content.WriteString("\n")
+	}
+
 	timeStr := r.DateTime.Format("Monday, January 2, 2006 at 3:04 PM")
 	content.WriteString(inputHintStyle.Render("Time: "))
 	content.WriteString(normalStyle.Render(timeStr))
 	content.WriteString("\n")
 
+	if !r.Yearly.IsZero() {
+		content.WriteString(inputHintStyle.Render("Repeats: "))
+		content.WriteString(normalStyle.Render(yearlyText(r)))
+		content.WriteString("\n")
+	}
+	if !r.Relative.IsZero() {
+		content.WriteString(inputHintStyle.Render("Relative: "))
+		content.WriteString(normalStyle.Render(parser.FormatRelative(r.Relative)))
+		content.WriteString("\n")
+	}
+
 	content.WriteString(inputHintStyle.Render("Status: "))
-	content.WriteString(statusStyle.Render(r.Status.String()))
+	content.WriteString(style.Render(statusGlyph(r.Status) + " " + r.Status.String()))
 	content.WriteString("\n")
 
+	if r.Priority != reminder.PriorityNone {
+		content.WriteString(inputHintStyle.Render("Priority: "))
+		content.WriteString(normalStyle.Render(r.Priority.String()))
+		content.WriteString("\n")
+	}
+
+	if r.Effort != reminder.EffortNone {
+		content.WriteString(inputHintStyle.Render("Effort: "))
+		content.WriteString(normalStyle.Render(r.Effort.String()))
+		content.WriteString("\n")
+	}
+
+	if r.Label != reminder.LabelNone {
+		content.WriteString(inputHintStyle.Render("Label:"))
+		content.WriteString(labelDot(r) + " " + normalStyle.Render(r.Label.String()))
+		content.WriteString("\n")
+	}
+
+	if r.Estimate > 0 {
+		content.WriteString(inputHintStyle.Render("Estimate: "))
+		content.WriteString(normalStyle.Render(parser.FormatEstimate(r.Estimate)))
+		content.WriteString("\n")
+	}
+
+	if len(r.Sessions) > 0 {
+		content.WriteString(inputHintStyle.Render("Time spent: "))
+		content.WriteString(normalStyle.Render(formatSpent(r.TimeSpent(m.now()))))
+		if r.TimerRunning() {
+			content.WriteString(triggeredStyle.Render(" (" + glyphs.Timer + " running)"))
+		}
+		content.WriteString("\n")
+	}
+
 	if len(r.Tags) > 0 {
 		content.WriteString(inputHintStyle.Render("Tags: "))
 		tagStrs := make([]string, len(r.Tags))
@@ -94,33 +191,224 @@ func (m Model) detailView() string {
 		content.WriteString("\n")
 	}
 
+	if people := r.Mentions(); len(people) > 0 {
+		content.WriteString(inputHintStyle.Render("People: "))
+		content.WriteString(normalStyle.Render("@" + strings.Join(people, "  @")))
+		content.WriteString("\n")
+	}
+
+	if r.From != "" {
+		content.WriteString(inputHintStyle.Render("From: "))
+		content.WriteString(normalStyle.Render(r.From))
+		content.WriteString("\n")
+	}
+
 	if r.SourceFile != "" {
 		content.WriteString(inputHintStyle.Render("Source: "))
-		content.WriteString(sourceStyle.Render(r.SourceFile))
+		if isLink(r.SourceFile) {
+			// Clickable in terminals that support hyperlinks
+			content.WriteString(ansi.SetHyperlink(r.SourceFile) + sourceStyle.Render(r.SourceFile) + ansi.ResetHyperlink())
+			content.WriteString(inputHintStyle.Render(" (" + keys.OpenLink.Help().Key + " to open)"))
+		} else {
+			content.WriteString(sourceStyle.Render(r.SourceFile))
+			if r.LineNumber > 0 {
+				content.WriteString(sourceStyle.Render(fmt.Sprintf(":%d", r.LineNumber)))
+			}
+		}
+		if m.missingSources[r.SourceFile] {
+			content.WriteString(triggeredStyle.Render(" (deleted)"))
+		}
+		content.WriteString("\n")
+	}
+
+	if r.Source != "" {
+		content.WriteString(inputHintStyle.Render("Pulled from: "))
+		content.WriteString(sourceStyle.Render(r.Source))
+		content.WriteString("\n")
+	}
+
+	if !r.UpdatedAt.IsZero() {
+		content.WriteString(inputHintStyle.Render("Updated: "))
+		content.WriteString(normalStyle.Render(r.UpdatedAt.Format("Jan 2, 2006 at 3:04 PM")))
+		content.WriteString("\n")
+	}
+
+	if !r.AcknowledgedAt.IsZero() {
+		content.WriteString(inputHintStyle.Render("Acknowledged: "))
+		content.WriteString(normalStyle.Render(r.AcknowledgedAt.Format("Jan 2, 2006 at 3:04 PM")))
+		content.WriteString("\n")
+	}
+
+	return content.String()
+}
+
+// detailNotesView renders the detail view's Notes tab: the whole
+// description and the notes below it, scrolled
+func (m Model) detailNotesView(r *reminder.Reminder, cardWidth int) string {
+	var content strings.Builder
+
+	// Wrap description text
+	descLines := detailLines(r, cardWidth-4)
+	visibleLines := m.height - 15
+	if visibleLines < 5 {
+		visibleLines = 5
+	}
+
+	startLine := m.detailScroll
+	endLine := startLine + visibleLines
+	if endLine > len(descLines) {
+		endLine = len(descLines)
+	}
+	if startLine >= len(descLines) {
+		startLine = len(descLines) - 1
+		if startLine < 0 {
+			startLine = 0
+		}
+	}
+
+	for i := startLine; i < endLine; i++ {
+		content.WriteString(normalStyle.Render(descLines[i]))
 		content.WriteString("\n")
 	}
 
 	// Scroll indicator
 	if len(descLines) > visibleLines {
 		content.WriteString("\n")
-		scrollInfo := fmt.Sprintf("(showing lines %d-%d of %d, use ↑/↓ or k/j to scroll)",
-			startLine+1, endLine, len(descLines))
+		scrollInfo := fmt.Sprintf("(showing lines %d-%d of %d, use %s/%s or k/j to scroll)",
+			startLine+1, endLine, len(descLines), glyphs.Up, glyphs.Down)
 		content.WriteString(inputHintStyle.Render(scrollInfo))
+		content.WriteString("\n")
 	}
 
-	content.WriteString("\n\n")
-	content.WriteString(inputHintStyle.Render("Press ESC to close"))
+	return content.String()
+}
 
-	detailCard := detailCardStyle.Render(content.String())
+// related returns the other reminders that share a tag or a source file
+// with r, by due date
+func (m Model) related(r *reminder.Reminder) []*reminder.Reminder {
+	var rs []*reminder.Reminder
+	for _, other := range m.reminders {
+		if other == r {
+			continue
+		}
+		sameFile := other.SourceFile == r.SourceFile && r.SourceFile != "" && r.SourceFile != reminder.StandaloneSource && !isLink(r.SourceFile)
+		if sameFile || sharesTag(r, other) {
+			rs = append(rs, other)
+		}
+	}
+	return rs
+}
 
-	// Center the card
-	cardStyle := lipgloss.NewStyle().
-		Width(m.width).
-		Height(m.height).
-		AlignHorizontal(lipgloss.Center).
-		AlignVertical(lipgloss.Center)
+// sharesTag reports whether a and b have a tag in common
+func sharesTag(a, b *reminder.Reminder) bool {
+	for _, t := range a.Tags {
+		if slices.Contains(b.Tags, t) {
+			return true
+		}
+	}
+	return false
+}
 
-	return cardStyle.Render(detailCard)
+// detailRelatedView renders the detail view's Related tab: the reminders
+// with the same tag or from the same file, one selected
+func (m Model) detailRelatedView(r *reminder.Reminder, cardWidth int) string {
+	rs := m.related(r)
+	if len(rs) == 0 {
+		return inputHintStyle.Render("Nothing shares a tag or file with this reminder") + "\n"
+	}
+
+	var content strings.Builder
+	visible := max(m.height-15, 5)
+	start := max(0, min(m.detailScroll-visible+1, len(rs)-visible))
+	for i := start; i < len(rs) && i < start+visible; i++ {
+		other := rs[i]
+		cursor := "  "
+		descStyle := normalStyle
+		if i == m.detailScroll {
+			cursor = glyphs.Cursor + " "
+			descStyle = selectedItemStyle
+		}
+		why := "same file"
+		if sharesTag(r, other) {
+			why = "#" + strings.Join(sharedTags(r, other), " #")
+		}
+		line := statusStyle(other.Status).Render(statusGlyph(other.Status)) + " " +
+			sourceStyle.Render(other.DateTime.Format("Jan 2 15:04")) + "  "
+		width := cardWidth - 4 - ansi.StringWidth(cursor+line) - ansi.StringWidth(why) - 2
+		line += descStyle.Render(ansi.Truncate(other.Description, max(width, 10), glyphs.Ellipsis))
+		content.WriteString(cursor + line + "  " + tagStyle.Render(why) + "\n")
+	}
+	content.WriteString("\n")
+	content.WriteString(inputHintStyle.Render("enter to open"))
+	content.WriteString("\n")
+	return content.String()
+}
+
+// sharedTags returns the tags of a that b also has
+func sharedTags(a, b *reminder.Reminder) []string {
+	var shared []string
+	for _, t := range a.Tags {
+		if slices.Contains(b.Tags, t) {
+			shared = append(shared, t)
+		}
+	}
+	return shared
+}
+
+// switchDetailTab moves by step through the detail view's tabs, loading
+// the reminder's history or file when that's the one shown
+func (m *Model) switchDetailTab(step int) {
+	m.detailTab = (m.detailTab + step + len(detailTabNames)) % len(detailTabNames)
+	m.detailScroll = 0
+	m.loadDetailHistory()
+	m.loadDetailSource()
+}
+
+// loadDetailHistory loads the detail reminder's changes if they're shown
+func (m *Model) loadDetailHistory() {
+	if m.detailTab == detailTabHistory && m.detailReminder != nil {
+		m.detailChanges = m.loadActivity(m.detailReminder.ID)
+		m.detailChangesID = m.detailReminder.ID
+	}
+}
+
+// scrollDetailDown scrolls the detail view down a line, or on the Related
+// and Links tabs selects the next reminder or link
+func (m *Model) scrollDetailDown() {
+	if m.detailTab == detailTabRelated && m.detailScroll >= len(m.related(m.detailReminder))-1 {
+		return
+	}
+	if m.detailTab == detailTabLinks && m.detailScroll >= len(links(m.detailReminder))-1 {
+		return
+	}
+	m.detailScroll++
+}
+
+// openDetail shows r in the detail view, on its Info tab, or focuses the
+// split pane on it, staying on the tab it shows
+func (m *Model) openDetail(r *reminder.Reminder) {
+	m.mode = modeDetail
+	m.detailReminder = r
+	m.detailScroll = 0
+	if !m.splitActive() {
+		m.detailTab = detailTabInfo
+	}
+	m.loadDetailHistory()
+	m.loadDetailSource()
+}
+
+// detailTabsView renders the detail view's tabs, the current one
+// highlighted
+func (m Model) detailTabsView() string {
+	tabs := make([]string, len(detailTabNames))
+	for i, name := range detailTabNames {
+		if i == m.detailTab {
+			tabs[i] = selectedItemStyle.Render("[" + name + "]")
+		} else {
+			tabs[i] = inputHintStyle.Render(" " + name + " ")
+		}
+	}
+	return strings.Join(tabs, " ") + inputHintStyle.Render("   tab/shift+tab to switch")
 }
 
 func wrapText(text string, width int) []string {
@@ -0,0 +1,112 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"go_remind/config"
+	"go_remind/digest"
+	"go_remind/notify"
+	"go_remind/pkg/reminder"
+)
+
+// scheduleDigest sets the next time the daily digest should fire
+func (m *Model) scheduleDigest(now time.Time) {
+	m.nextDigest = time.Time{}
+	if !m.config.Digest.Enabled {
+		return
+	}
+	hour, minute, err := config.ParseClock(m.config.Digest.Time)
+	if err != nil {
+		return
+	}
+	m.nextDigest = digest.NextTime(now, hour, minute)
+}
+
+// checkDigest fires the digest if its scheduled time has passed.
+// Returns a command that sends the notification, if any.
+func (m *Model) checkDigest(now time.Time) tea.Cmd {
+	if m.nextDigest.IsZero() || now.Before(m.nextDigest) {
+		return nil
+	}
+	m.scheduleDigest(now)
+
+	d := digest.Build(m.reminders, now)
+	if m.config.Digest.Pane && m.mode == modeNormal {
+		m.mode = modeDigest
+	}
+	if m.config.Digest.Notify && m.config.Notifications.Enabled {
+		return notifyCmd("Good morning! "+d.Summary(), d.Body())
+	}
+	return nil
+}
+
+// notifyCmd sends a desktop notification in the background
+func notifyCmd(title, body string) tea.Cmd {
+	return func() tea.Msg {
+		_ = notify.Send(title, body) // Best effort, nothing to do on failure
+		return nil
+	}
+}
+
+func (m Model) updateDigestMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "enter", "q":
+		m.mode = modeNormal
+	default:
+		if key.Matches(msg, keys.Digest) {
+			m.mode = modeNormal
+		}
+	}
+	return m, nil
+}
+
+// digestView renders the digest dashboard pane
+func (m Model) digestView() string {
+	d := digest.Build(m.reminders, m.now())
+
+	var b strings.Builder
+	b.WriteString(inputLabelStyle.Render(glyphs.Digest + " Daily Digest - " + d.Date.Format("Monday, January 2")))
+	b.WriteString("\n\n")
+	b.WriteString(normalStyle.Render(fmt.Sprintf("%d due today", len(d.Today))))
+	b.WriteString(sourceStyle.Render("  " + glyphs.Bullet + "  "))
+	b.WriteString(triggeredStyle.Render(fmt.Sprintf("%d overdue", len(d.Overdue))))
+	b.WriteString(sourceStyle.Render("  " + glyphs.Bullet + "  "))
+	b.WriteString(acknowledgedStyle.UnsetStrikethrough().Render(fmt.Sprintf("%d completed yesterday", d.CompletedYesterday)))
+	b.WriteString("\n")
+
+	clashes := d.Conflicts(m.config.Conflicts.WindowDuration())
+	writeGroup := func(title string, items []*reminder.Reminder, style lipgloss.Style, format string) {
+		if len(items) == 0 {
+			return
+		}
+		b.WriteString("\n")
+		b.WriteString(titleStyle.UnsetMarginLeft().Render(title))
+		b.WriteString("\n")
+		for _, r := range items {
+			b.WriteString(style.Render(fmt.Sprintf("  %-14s %s", r.DateTime.Format(format), r.Description)))
+			if clashes[r] {
+				b.WriteString(triggeredStyle.Render("  " + glyphs.Warning + " conflict"))
+			}
+			b.WriteString("\n")
+		}
+	}
+	writeGroup("Overdue", d.Overdue, triggeredStyle, "Jan 2 3:04pm")
+	writeGroup("Today", d.Today, normalStyle, "3:04pm")
+
+	if len(d.Today) == 0 && len(d.Overdue) == 0 {
+		b.WriteString("\n")
+		b.WriteString(normalStyle.Render("Nothing scheduled today. Enjoy!"))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(inputHintStyle.Render("Press ESC to close"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modalBox(b.String()))
+}
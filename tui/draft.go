@@ -0,0 +1,121 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"go_remind/pkg/state"
+)
+
+// DraftStore keeps what's typed into the add or edit box until it's saved,
+// so it survives the terminal dying; satisfied by *state.Store
+type DraftStore interface {
+	LoadDraft() (state.Draft, error)
+	SaveDraft(draft state.Draft) error
+	ClearDraft() error
+}
+
+// WithDrafts returns a copy of the model that saves the add and edit box's
+// text to store as it's typed, and offers it back the next time the box
+// opens for the same reminder
+func (m Model) WithDrafts(store DraftStore) Model {
+	m.drafts = store
+	return m
+}
+
+// editingID returns the ID of the reminder the add box is editing, or ""
+// when it's adding one
+func (m Model) editingID() string {
+	if m.editingReminder != nil {
+		return m.editingReminder.ID
+	}
+	return ""
+}
+
+// offerDraft asks whether to restore a draft left unsaved in the add or
+// edit box, when one was left for the reminder it's opening on. Call it
+// once the box has its starting text.
+func (m *Model) offerDraft() {
+	m.draftSaved = m.addInput.Value()
+	m.draftOwned = false
+	m.draftPrompt = false
+	if m.drafts == nil {
+		return
+	}
+	draft, err := m.drafts.LoadDraft()
+	if err != nil {
+		m.toastError("Could not load draft: " + err.Error())
+		return
+	}
+	if draft.Text == "" || draft.Editing != m.editingID() || draft.Text == m.addInput.Value() {
+		return
+	}
+	m.draft = draft
+	m.draftPrompt = true
+}
+
+// updateDraftPrompt answers whether to restore the draft: y puts it in
+// the box, n throws it away, and esc closes the box, leaving it to be
+// offered again
+func (m Model) updateDraftPrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y":
+		m.draftPrompt = false
+		m.draftOwned = true
+		m.draftSaved = m.draft.Text
+		m.addInput.SetValue(m.draft.Text)
+		m.addInput.CursorEnd()
+		m.fitAddInput()
+	case "n":
+		m.draftPrompt = false
+		m.draftOwned = true
+		m.discardDraft()
+	case "esc":
+		m.draftPrompt = false
+		m.closeAdd()
+	}
+	return m, nil
+}
+
+// saveDraft saves the add or edit box's text if it changed since last
+// saved. It runs every tick, so at most a second of typing is lost.
+func (m *Model) saveDraft() {
+	if m.drafts == nil || m.mode != modeAdd || m.draftPrompt {
+		return
+	}
+	text := m.addInput.Value()
+	if text == m.draftSaved {
+		return
+	}
+	m.draftSaved = text
+	m.draftOwned = true
+	if strings.TrimSpace(text) == "" || (m.editingReminder != nil && text == editText(m.editingReminder)) {
+		m.discardDraft()
+		return
+	}
+	draft := state.Draft{Text: text, Editing: m.editingID(), SavedAt: m.now()}
+	if err := m.drafts.SaveDraft(draft); err != nil {
+		m.toastError("Could not save draft: " + err.Error())
+	}
+}
+
+// discardDraft forgets the saved draft once the box it came from is
+// saved or cancelled. A draft left for another reminder is kept.
+func (m *Model) discardDraft() {
+	if m.drafts == nil || !m.draftOwned {
+		return
+	}
+	if err := m.drafts.ClearDraft(); err != nil {
+		m.toastError("Could not clear draft: " + err.Error())
+	}
+}
+
+// draftPromptView asks whether to restore the draft, showing its start
+func (m Model) draftPromptView() string {
+	text := ansi.Truncate(m.draft.Text, max(m.width-40, 20), glyphs.Ellipsis)
+	return inputLabelStyle.Render("  Restore the draft left unsaved at "+m.draft.SavedAt.Local().Format("Mon 3:04pm")+"? ") +
+		normalStyle.Render(text) + "\n" +
+		inputHintStyle.Render("  y to restore "+glyphs.Bullet+" n to start over "+glyphs.Bullet+" esc to close")
+}
@@ -0,0 +1,222 @@
+package tui
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"go_remind/dedupe"
+	"go_remind/pkg/parser"
+	"go_remind/pkg/reminder"
+)
+
+// IgnoredDuplicatesStore keeps the duplicate groups the user said aren't
+// duplicates between sessions; satisfied by *state.Store
+type IgnoredDuplicatesStore interface {
+	LoadIgnoredDuplicates() ([]string, error)
+	SaveIgnoredDuplicates(keys []string) error
+}
+
+// WithIgnoredDuplicates returns a copy of the model that remembers the
+// duplicate groups ignored in earlier sessions, saving new ones to store
+func (m Model) WithIgnoredDuplicates(store IgnoredDuplicatesStore) Model {
+	m.ignoredStore = store
+	keys, err := store.LoadIgnoredDuplicates()
+	if err != nil {
+		m.toastError("Could not load ignored duplicates: " + err.Error())
+		return m
+	}
+	for _, k := range keys {
+		m.ignoredDupes[k] = true
+	}
+	return m
+}
+
+// ignoreDuplicates stops flagging g as duplicates, and saves that
+func (m *Model) ignoreDuplicates(g dedupe.Group) {
+	m.ignoredDupes[g.Key()] = true
+	if m.ignoredStore == nil {
+		return
+	}
+	keys := slices.Sorted(maps.Keys(m.ignoredDupes))
+	if err := m.ignoredStore.SaveIgnoredDuplicates(keys); err != nil {
+		m.toastError("Could not save ignored duplicates: " + err.Error())
+	}
+}
+
+// findDuplicates returns the duplicate groups the user hasn't ignored
+func (m Model) findDuplicates() []dedupe.Group {
+	return dedupe.Find(m.reminders, m.config.Duplicates.ToleranceDuration(), m.ignoredDupes)
+}
+
+// checkDuplicates looks for duplicates once reminders have been loaded or
+// changed on disk. With auto_merge on they are merged straight away;
+// otherwise newly found ones are announced.
+func (m *Model) checkDuplicates() {
+	if !m.dupeCheckDue {
+		return
+	}
+	m.dupeCheckDue = false
+
+	if m.config.Duplicates.AutoMerge {
+		kept, removed := dedupe.Apply(m.reminders, m.config.Duplicates.ToleranceDuration(), m.ignoredDupes, m.now())
+		if len(removed) == 0 {
+			return
+		}
+		m.reminders = kept
+		m.muteCopies(removed)
+		m.refreshList()
+		m.saveState()
+		m.toastInfo(fmt.Sprintf("Merged %d duplicate reminder(s)", len(removed)))
+		return
+	}
+
+	groups := m.findDuplicates()
+	if len(groups) > m.dupesAnnounced {
+		m.toastInfo(fmt.Sprintf("%d possible duplicate(s) (press %s)", len(groups), keys.Duplicates.Help().Key))
+	}
+	m.dupesAnnounced = len(groups)
+}
+
+// typedDuplicates returns the open reminders whose description is like
+// the one typed into the add box, leaving out the one being edited
+func (m Model) typedDuplicates(input string) []*reminder.Reminder {
+	if !m.config.Descriptions.WarnDuplicates {
+		return nil
+	}
+	entry, _ := splitEntry(input)
+	typed, err := parser.ParseEntry(entry, m.now())
+	if err != nil {
+		return nil
+	}
+	var similar []*reminder.Reminder
+	for _, r := range m.reminders {
+		if r != m.editingReminder && r.Status != reminder.Acknowledged && dedupe.Similar(r.Description, typed.Description) {
+			similar = append(similar, r)
+		}
+	}
+	return similar
+}
+
+// duplicateWarning names the open reminders like the one typed into the
+// add box, or returns "" if there are none
+func (m Model) duplicateWarning(input string) string {
+	similar := m.typedDuplicates(input)
+	if len(similar) == 0 {
+		return ""
+	}
+	names := make([]string, 0, maxConflictsShown)
+	for i, r := range similar {
+		if i == maxConflictsShown {
+			names = append(names, fmt.Sprintf("%d more", len(similar)-i))
+			break
+		}
+		names = append(names, fmt.Sprintf("%s (%s)", r.Description, r.DateTime.Format("Mon Jan 2 3:04pm")))
+	}
+	return "Already open: " + strings.Join(names, ", ")
+}
+
+// openDuplicates shows the duplicate groups panel
+func (m *Model) openDuplicates() {
+	m.dupeGroups = m.findDuplicates()
+	if len(m.dupeGroups) == 0 {
+		m.toastInfo("No duplicate reminders")
+		return
+	}
+	m.dupeIndex = 0
+	m.mode = modeDuplicates
+}
+
+func (m Model) updateDuplicatesMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		m.mode = modeNormal
+		return m, nil
+	case "m":
+		m.mergeDuplicates(m.dupeGroups[m.dupeIndex : m.dupeIndex+1])
+	case "M":
+		m.mergeDuplicates(m.dupeGroups)
+	case "i":
+		m.ignoreDuplicates(m.dupeGroups[m.dupeIndex])
+		m.toastInfo("Ignored: " + m.dupeGroups[m.dupeIndex][0].Description)
+	default:
+		switch {
+		case key.Matches(msg, keys.Up):
+			if m.dupeIndex > 0 {
+				m.dupeIndex--
+			}
+		case key.Matches(msg, keys.Down):
+			if m.dupeIndex < len(m.dupeGroups)-1 {
+				m.dupeIndex++
+			}
+		case key.Matches(msg, keys.Duplicates):
+			m.mode = modeNormal
+		}
+		return m, nil
+	}
+
+	m.dupeGroups = m.findDuplicates()
+	m.dupesAnnounced = len(m.dupeGroups)
+	if len(m.dupeGroups) == 0 {
+		m.mode = modeNormal
+	} else if m.dupeIndex >= len(m.dupeGroups) {
+		m.dupeIndex = len(m.dupeGroups) - 1
+	}
+	return m, nil
+}
+
+// mergeDuplicates folds each group into its earliest reminder
+func (m *Model) mergeDuplicates(groups []dedupe.Group) {
+	var removed []*reminder.Reminder
+	for _, g := range groups {
+		_, copies := dedupe.Merge(g, m.now())
+		removed = append(removed, copies...)
+	}
+	m.reminders = dedupe.Remove(m.reminders, removed)
+	m.muteCopies(removed)
+	m.refreshList()
+	m.saveState()
+	m.toastSuccess(fmt.Sprintf("Merged %d duplicate reminder(s)", len(removed)))
+}
+
+// muteCopies mutes the merged copies that came from files, so they aren't
+// added back the next time their file is parsed
+func (m *Model) muteCopies(removed []*reminder.Reminder) {
+	for _, r := range removed {
+		m.mute(r)
+	}
+}
+
+// duplicatesView renders the duplicate groups with where each copy came from
+func (m Model) duplicatesView() string {
+	var b strings.Builder
+	b.WriteString(inputLabelStyle.Render(fmt.Sprintf("%s %d possible duplicate(s)", glyphs.Warning, len(m.dupeGroups))))
+	b.WriteString("\n\n")
+
+	for i, g := range m.dupeGroups {
+		cursor := "  "
+		style := normalStyle
+		if i == m.dupeIndex {
+			cursor = glyphs.Cursor + " "
+			style = selectedItemStyle
+		}
+		b.WriteString(cursor + style.Render(g[0].Description))
+		b.WriteString("\n")
+		for _, r := range g {
+			line := fmt.Sprintf("    %-20s %s", r.DateTime.Format("Mon Jan 2 3:04pm"), r.SourceFile)
+			b.WriteString(sourceStyle.Render(line))
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("\n")
+	sep := " " + glyphs.Bullet + " "
+	b.WriteString(inputHintStyle.Render("m merge into the first" + sep + "i not duplicates" + sep + "M merge all" + sep + "esc to close"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modalBox(b.String()))
+}
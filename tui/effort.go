@@ -0,0 +1,48 @@
+package tui
+
+import "go_remind/pkg/reminder"
+
+// cycleEffort steps r's effort from none through easy, medium, and hard,
+// and back to none
+func (m *Model) cycleEffort(r *reminder.Reminder) {
+	if r == nil {
+		return
+	}
+	r.Effort = (r.Effort + 1) % (reminder.EffortHard + 1)
+	r.UpdatedAt = m.now()
+	m.refreshList()
+	m.saveState()
+	if r.Effort == reminder.EffortNone {
+		m.toastInfo("Cleared effort: " + r.Description)
+		return
+	}
+	m.toastInfo("Effort " + r.Effort.String() + ": " + r.Description)
+}
+
+// toggleLowEnergy turns low energy mode on or off. While it's on, the
+// sorted views list easy reminders first in each section and hard ones
+// last, for triage at the end of the day.
+func (m *Model) toggleLowEnergy() {
+	m.lowEnergy = !m.lowEnergy
+	m.gridIndex, m.gridScroll = 0, 0
+	m.compactIndex, m.compactScroll = 0, 0
+	m.sortEnabled = true // The order only shows in the sorted views
+	if m.lowEnergy {
+		m.toastInfo("Low energy mode: easy reminders first")
+	} else {
+		m.toastInfo("Low energy mode off")
+	}
+}
+
+// effortRank orders reminders for low energy mode: easy, then medium or
+// unlabeled, then hard
+func effortRank(r *reminder.Reminder) int {
+	switch r.Effort {
+	case reminder.EffortEasy:
+		return 0
+	case reminder.EffortHard:
+		return 2
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,41 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+
+	"go_remind/export"
+	"go_remind/pkg/parser"
+)
+
+// yankSelected copies the selected reminder to the clipboard as a markdown token
+func (m *Model) yankSelected() {
+	r := m.selectedReminder()
+	if r == nil {
+		return
+	}
+	token := parser.FormatToken(r)
+	if err := copyToClipboard(token); err != nil {
+		m.toastError("Copy failed: " + err.Error())
+		return
+	}
+	m.toastSuccess("Copied: " + token)
+}
+
+// exportView writes the current filtered view to a file in the working
+// directory, in format f
+func (m *Model) exportView(f export.Format) {
+	items := m.getFilteredReminders()
+	if len(items) == 0 {
+		m.toastInfo("Nothing to export")
+		return
+	}
+
+	now := m.now()
+	path := fmt.Sprintf("reminders-%s.%s", now.Format("20060102-150405"), f.Ext())
+	if err := os.WriteFile(path, []byte(export.Render(f, items, now)), 0644); err != nil {
+		m.toastError("Export failed: " + err.Error())
+		return
+	}
+	m.toastSuccess(fmt.Sprintf("Exported %d reminders to %s", len(items), path))
+}
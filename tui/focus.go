@@ -0,0 +1,137 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"go_remind/pkg/reminder"
+)
+
+// bigDigits draws the focus view's countdown, five rows per character;
+// "#" is filled in with the darkest shade
+var bigDigits = map[rune][5]string{
+	'0': {"#####", "#   #", "#   #", "#   #", "#####"},
+	'1': {"  #  ", " ##  ", "  #  ", "  #  ", " ### "},
+	'2': {"#####", "    #", "#####", "#    ", "#####"},
+	'3': {"#####", "    #", " ####", "    #", "#####"},
+	'4': {"#   #", "#   #", "#####", "    #", "    #"},
+	'5': {"#####", "#    ", "#####", "    #", "#####"},
+	'6': {"#####", "#    ", "#####", "#   #", "#####"},
+	'7': {"#####", "    #", "   # ", "  #  ", "  #  "},
+	'8': {"#####", "#   #", "#####", "#   #", "#####"},
+	'9': {"#####", "#   #", "#####", "    #", "#####"},
+	':': {" ", "#", " ", "#", " "},
+}
+
+// openFocus shows the next reminder due, full screen, with a countdown
+func (m *Model) openFocus() {
+	next := nextDue(m.reminders, m.now())
+	if next == nil {
+		m.toastInfo("Nothing coming up to focus on")
+		return
+	}
+	m.focusID = next.ID
+	m.mode = modeFocus
+}
+
+func (m Model) updateFocusMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Any key goes back to the list
+	m.mode = modeNormal
+	return m, nil
+}
+
+// checkFocus leaves the focus view once its reminder triggers, with the
+// cursor on it, and otherwise follows whichever reminder is due next, in
+// case one was added or rescheduled ahead of it
+func (m *Model) checkFocus(now time.Time) {
+	if m.mode != modeFocus {
+		return
+	}
+	if r := m.reminderByID(m.focusID); r != nil && r.Status == reminder.Triggered {
+		m.mode = modeNormal
+		m.selectReminder(r)
+		return
+	}
+	next := nextDue(m.reminders, now)
+	if next == nil {
+		m.mode = modeNormal
+		return
+	}
+	m.focusID = next.ID
+}
+
+// selectReminder moves the cursor to r, if it's in the current view
+func (m *Model) selectReminder(r *reminder.Reminder) {
+	for i, item := range m.getFilteredReminders() {
+		if item == r {
+			m.selectIndex(i)
+			return
+		}
+	}
+}
+
+// focusView renders the focused reminder and a big countdown to it,
+// centered on an otherwise empty screen
+func (m Model) focusView() string {
+	now := m.now()
+	r := m.reminderByID(m.focusID)
+	if r == nil {
+		return ""
+	}
+
+	countdown := formatElapsed(r.DateTime.Sub(now))
+	big := bigText(countdown)
+	if m.width > 0 && lipgloss.Width(big) > m.width-4 {
+		big = countdown // Too narrow for big digits
+	}
+
+	lines := []string{
+		inputHintStyle.Render("Next up"),
+		"",
+		titleStyle.Render(r.Description),
+		"",
+		triggeredStyle.Render(big),
+		"",
+		normalStyle.Render(r.DateTime.Format("Monday, January 2 at 3:04 PM")),
+	}
+	if len(r.Tags) > 0 {
+		tags := make([]string, len(r.Tags))
+		for i, tag := range r.Tags {
+			tags[i] = "#" + tag
+		}
+		lines = append(lines, tagStyle.Render(strings.Join(tags, " ")))
+	}
+	lines = append(lines, "", inputHintStyle.Render("Press any key to return"))
+
+	content := lipgloss.JoinVertical(lipgloss.Center, lines...)
+	if m.width <= 0 || m.height <= 0 {
+		return content
+	}
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, content)
+}
+
+// bigText draws s in big digits, one column apart
+func bigText(s string) string {
+	fill := glyphs.Shades[len(glyphs.Shades)-1]
+	var rows [5]strings.Builder
+	for i, c := range s {
+		font, ok := bigDigits[c]
+		if !ok {
+			continue
+		}
+		for row := range rows {
+			if i > 0 {
+				rows[row].WriteString(" ")
+			}
+			rows[row].WriteString(strings.ReplaceAll(font[row], "#", fill))
+		}
+	}
+	lines := make([]string, len(rows))
+	for i := range rows {
+		lines[i] = rows[i].String()
+	}
+	return strings.Join(lines, "\n")
+}
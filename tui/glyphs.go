@@ -0,0 +1,165 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/lipgloss"
+
+	"go_remind/pkg/reminder"
+)
+
+// glyphSet holds the symbols drawn by the UI, so they can be swapped for
+// plain ASCII on terminals and screen readers that can't handle them
+type glyphSet struct {
+	Triggered    string
+	Acknowledged string
+	Pending      string
+	Snoozed      string
+	Waiting      string
+	Someday      string
+	Cursor       string
+	Up           string
+	Down         string
+	Bullet       string
+	Dot          string
+	Ellipsis     string
+	Info         string
+	Success      string
+	Error        string
+	Warning      string
+	Search       string
+	Digest       string
+	Streak       string
+	Stats        string
+	Paste        string
+	Keyboard     string
+	Theme        string
+	Profile      string
+	Edit         string
+	Add          string
+	Calendar     string
+	Timer        string
+	Label        string
+	Rule         string
+	Shades       []string // Lightest to darkest, for the heatmap
+	Border       lipgloss.Border
+	Spinner      spinner.Spinner
+}
+
+var unicodeGlyphs = glyphSet{
+	Triggered:    "🔔",
+	Acknowledged: "✓",
+	Pending:      "○",
+	Snoozed:      "◷",
+	Waiting:      "‖",
+	Someday:      "◌",
+	Cursor:       "▸",
+	Up:           "↑",
+	Down:         "↓",
+	Bullet:       "•",
+	Dot:          "·",
+	Ellipsis:     "…",
+	Info:         "ℹ",
+	Success:      "✓",
+	Error:        "✗",
+	Warning:      "⚠",
+	Search:       "🔍",
+	Digest:       "☀",
+	Streak:       "🔥",
+	Stats:        "▦",
+	Paste:        "📋",
+	Keyboard:     "⌨",
+	Theme:        "🎨",
+	Profile:      "👤",
+	Edit:         "✏️ ",
+	Add:          "➕",
+	Calendar:     "📅",
+	Timer:        "⏱",
+	Label:        "●",
+	Rule:         "─",
+	Shades:       []string{"·", "░", "▒", "▓", "█"},
+	Border:       lipgloss.RoundedBorder(),
+	Spinner:      spinner.Dot,
+}
+
+var asciiGlyphs = glyphSet{
+	Triggered:    "!",
+	Acknowledged: "x",
+	Pending:      "o",
+	Snoozed:      "z",
+	Waiting:      "w",
+	Someday:      "~",
+	Cursor:       ">",
+	Up:           "^",
+	Down:         "v",
+	Bullet:       "|",
+	Dot:          "-",
+	Ellipsis:     "...",
+	Info:         "i",
+	Success:      "ok",
+	Error:        "ERROR",
+	Warning:      "!",
+	Search:       "Search:",
+	Digest:       "*",
+	Streak:       "*",
+	Stats:        "#",
+	Paste:        "+",
+	Keyboard:     "?",
+	Theme:        "*",
+	Profile:      "@",
+	Edit:         "*",
+	Add:          "+",
+	Calendar:     "#",
+	Timer:        "T",
+	Label:        "*",
+	Rule:         "-",
+	Shades:       []string{".", ":", "+", "*", "#"},
+	Border: lipgloss.Border{
+		Top: "-", Bottom: "-", Left: "|", Right: "|",
+		TopLeft: "+", TopRight: "+", BottomLeft: "+", BottomRight: "+",
+	},
+	Spinner: spinner.Line,
+}
+
+// glyphs is the active glyph set
+var glyphs = unicodeGlyphs
+
+// arrowNames replace arrow symbols in help text in ASCII mode
+var arrowNames = strings.NewReplacer("↑", "up", "↓", "down", "←", "left", "→", "right")
+
+// SetASCII switches the UI to plain ASCII symbols and borders. Call it
+// before creating the model.
+func SetASCII(ascii bool) {
+	if !ascii {
+		glyphs = unicodeGlyphs
+		return
+	}
+	glyphs = asciiGlyphs
+	for name, symbol := range keySymbols {
+		keySymbols[name] = arrowNames.Replace(symbol)
+	}
+	for _, km := range []*keyMap{&keys, &defaultKeys} {
+		for _, b := range km.bindingNames() {
+			b.SetHelp(arrowNames.Replace(b.Help().Key), b.Help().Desc)
+		}
+	}
+}
+
+// statusGlyph returns the symbol for a reminder status
+func statusGlyph(status reminder.Status) string {
+	switch status {
+	case reminder.Triggered:
+		return glyphs.Triggered
+	case reminder.Acknowledged:
+		return glyphs.Acknowledged
+	case reminder.Snoozed:
+		return glyphs.Snoozed
+	case reminder.Waiting:
+		return glyphs.Waiting
+	case reminder.Someday:
+		return glyphs.Someday
+	default:
+		return glyphs.Pending
+	}
+}
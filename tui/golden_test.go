@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"go_remind/pkg/clock"
+	"go_remind/pkg/reminder"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata/golden")
+
+// goldenNow is the fixed clock the golden views are rendered at
+var goldenNow = time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+
+// goldenReminders covers each section and status
+func goldenReminders() []*reminder.Reminder {
+	at := func(days, hour, min int) time.Time {
+		return time.Date(2026, 3, 4+days, hour, min, 0, 0, time.UTC)
+	}
+	rs := []*reminder.Reminder{
+		{Description: "Submit expense report", DateTime: at(-1, 17, 0), Status: reminder.Triggered, Tags: []string{"work"}, SourceFile: "/notes/work.md"},
+		{Description: "Team standup", DateTime: at(0, 9, 30), Status: reminder.Acknowledged, AcknowledgedAt: at(0, 9, 31), Tags: []string{"work", "meeting"}, SourceFile: "/notes/work.md"},
+		{Description: "Call mom", DateTime: at(0, 15, 0), Status: reminder.Pending, Tags: []string{"personal"}, SourceFile: "/notes/home.md"},
+		{Description: "Deploy billing fix", DateTime: at(0, 16, 0), Status: reminder.Pending, Priority: reminder.PriorityHigh, Tags: []string{"work"}, SourceFile: "/notes/work.md"},
+		{Description: "Water the plants", DateTime: at(1, 8, 0), Status: reminder.Snoozed, SourceFile: reminder.StandaloneSource},
+		{Description: "Book the dentist", DateTime: at(3, 11, 0), Status: reminder.Pending, SourceFile: "/notes/home.md"},
+		{Description: "Renew car insurance", DateTime: at(20, 12, 0), Status: reminder.Pending, Tags: []string{"home"}, SourceFile: "/notes/home.md"},
+		{Description: "Hear back from the landlord", DateTime: at(2, 10, 0), Status: reminder.Waiting, SourceFile: "/notes/home.md"},
+		{Description: "Learn to make sourdough", DateTime: at(30, 10, 0), Status: reminder.Someday, Tags: []string{"ideas"}, SourceFile: "/notes/someday.md"},
+	}
+	for _, r := range rs {
+		r.ID = reminder.FileID(r.SourceFile, r.Description)
+	}
+	reminder.SortByDateTime(rs)
+	return rs
+}
+
+// renderGolden renders the model at the given size and fixed clock, with
+// colors stripped
+func renderGolden(t *testing.T, m Model, layout LayoutMode, width, height int) string {
+	t.Helper()
+	saved := currentLayout
+	currentLayout = layout
+	t.Cleanup(func() { currentLayout = saved })
+
+	m = m.WithClock(clock.Fixed(goldenNow))
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: width, Height: height})
+	return ansi.Strip(updated.(Model).View())
+}
+
+// checkGolden compares got with testdata/golden/<name>.golden, rewriting
+// the file instead when run with -update
+func checkGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", "golden", name+".golden")
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading golden file (run go test ./tui -run TestGolden -update to create it): %v", err)
+	}
+	if got != string(want) {
+		t.Errorf("%s view changed; run go test ./tui -run TestGolden -update if this is intended\n--- got ---\n%s\n--- want ---\n%s", name, got, want)
+	}
+}
+
+func TestGoldenViews(t *testing.T) {
+	detail := New(goldenReminders(), nil, nil)
+	detail.mode = modeDetail
+	detail.detailReminder = detail.reminders[3]
+
+	tests := []struct {
+		name   string
+		model  Model
+		layout LayoutMode
+		width  int
+		height int
+	}{
+		{"welcome", New(nil, nil, nil), LayoutCard, 100, 30},
+		{"grid", New(goldenReminders(), nil, nil), LayoutCard, 100, 40},
+		{"compact", New(goldenReminders(), nil, nil), LayoutCompact, 100, 40},
+		{"compact_narrow", New(goldenReminders(), nil, nil), LayoutCompact, 50, 40},
+		{"detail", detail, LayoutCard, 100, 30},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkGolden(t, tt.name, renderGolden(t, tt.model, tt.layout, tt.width, tt.height))
+		})
+	}
+}
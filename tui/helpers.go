@@ -5,45 +5,25 @@ import (
 	"strings"
 	"time"
 
-	"go_remind/datetime"
-	"go_remind/parser"
-	"go_remind/reminder"
+	"go_remind/pkg/parser"
+	"go_remind/pkg/reminder"
 )
 
-// setStatusMessage sets a temporary status message that will be displayed
-func (m *Model) setStatusMessage(msg string) {
-	m.statusMessage = msg
-	m.statusMessageTime = time.Now()
-}
-
-// saveState persists the current reminders to disk
+// saveState persists the current reminders to disk. The write happens in
+// the background shortly after the last change.
 func (m *Model) saveState() {
-	if m.store == nil {
+	if m.saver == nil {
 		return
 	}
-	// Save in background to avoid blocking UI
-	go func() {
-		_ = m.store.Save(m.reminders) // Ignore errors for now
-	}()
+	m.saver.request(m.reminders)
 }
 
 // refreshList updates the list items from the current reminders, applying filter if active
 func (m *Model) refreshList() {
-	var filtered []*reminder.Reminder
-	filterText := strings.ToLower(m.filterInput.Value())
-
-	if filterText == "" {
-		filtered = m.reminders
-	} else {
-		for _, r := range m.reminders {
-			if strings.Contains(strings.ToLower(r.Description), filterText) {
-				filtered = append(filtered, r)
-			}
-		}
-	}
-
-	items := remindersToItems(filtered)
+	m.resolveRelative()
+	items := remindersToItems(m.matchingReminders())
 	m.list.SetItems(items)
+	m.refreshHighlight()
 }
 
 // selectedReminder returns the currently selected reminder, or nil if none
@@ -84,13 +64,30 @@ func (m *Model) snooze(duration time.Duration) {
 	if r == nil || !r.Snoozeable() {
 		return
 	}
-	// Add duration to existing due date
-	r.DateTime = r.DateTime.Add(duration)
-	r.Status = reminder.Pending
+	r.Snooze(duration, m.now())
 	reminder.SortByDateTime(m.reminders)
 	m.refreshList()
 	m.saveState()
-	m.setStatusMessage(fmt.Sprintf("Snoozed %s: %s", formatDuration(duration), r.Description))
+	m.toastInfo(fmt.Sprintf("Snoozed %s: %s", formatDuration(duration), r.Description))
+}
+
+// togglePark moves a reminder into the Waiting or Someday status, or reopens
+// it if it already has that status
+func (m *Model) togglePark(r *reminder.Reminder, status reminder.Status) {
+	if r == nil || r.Status == reminder.Acknowledged {
+		return
+	}
+	if r.Status == status {
+		r.Reopen(m.now())
+		m.refreshList()
+		m.saveState()
+		m.toastInfo("Reopened: " + r.Description)
+		return
+	}
+	r.Park(status, m.now())
+	m.refreshList()
+	m.saveState()
+	m.toastInfo(fmt.Sprintf("Marked %s: %s", status, r.Description))
 }
 
 // formatDuration formats a duration for display
@@ -118,7 +115,11 @@ func formatDuration(d time.Duration) string {
 
 // deleteCurrentReminder removes the currently selected reminder from tracking
 func (m *Model) deleteCurrentReminder() {
-	r := m.selectedReminder()
+	m.deleteReminder(m.selectedReminder())
+}
+
+// deleteReminder removes r and saves
+func (m *Model) deleteReminder(r *reminder.Reminder) {
 	if r == nil {
 		return
 	}
@@ -129,13 +130,15 @@ func (m *Model) deleteCurrentReminder() {
 			break
 		}
 	}
+	m.mute(r)
 	m.refreshList()
 	m.saveState()
 }
 
-// addReminder parses the input and adds a new reminder
+// addReminder parses the input and adds a new reminder. Lines below the
+// first are its notes.
 func (m *Model) addReminder(input string) error {
-	input = strings.TrimSpace(input)
+	input, notes := splitEntry(input)
 	if input == "" {
 		return fmt.Errorf("empty input")
 	}
@@ -146,39 +149,51 @@ func (m *Model) addReminder(input string) error {
 		return fmt.Errorf("need both time and description (e.g., '+1h Call mom')")
 	}
 
-	now := time.Now()
-
-	// Try parsing from longest to shortest datetime prefix
-	for numDateWords := len(words) - 1; numDateWords >= 1; numDateWords-- {
-		dateStr := strings.Join(words[:numDateWords], " ")
-		descStr := strings.Join(words[numDateWords:], " ")
-
-		parsedTime, err := datetime.Parse(dateStr, now)
-		if err == nil {
-			// Extract tags from description
-			cleanDesc, tags := parser.ExtractTags(descStr)
-			r := &reminder.Reminder{
-				DateTime:    parsedTime,
-				Description: cleanDesc,
-				Tags:        tags,
-				SourceFile:  "(added in TUI)",
-				Status:      reminder.Pending,
-			}
-			m.reminders = append(m.reminders, r)
-			reminder.SortByDateTime(m.reminders)
-			m.refreshList()
-			m.saveState()
-			m.setStatusMessage("Added: " + cleanDesc)
-			return nil
-		}
+	now := m.now()
+	r, err := parser.ParseEntry(input, now)
+	if err != nil {
+		return fmt.Errorf("couldn't parse time from input")
+	}
+	r.ID = reminder.NewID()
+	r.SourceFile = reminder.StandaloneSource
+	r.Notes = notes
+	if m.config.Descriptions.Tidy {
+		r.Description = reminder.TidyDescription(r.Description)
 	}
+	m.reminders = append(m.reminders, r)
+	reminder.SortByDateTime(m.reminders)
+	m.refreshList()
+	m.saveState()
+	m.toastSuccess("Added: " + r.Description)
+	return nil
+}
 
-	return fmt.Errorf("couldn't parse time from input")
+// editText is a reminder as it's edited in the add box: yyyy-mm-dd hh:mm
+// description, or its yearly date or relative time if it has one, then its
+// estimate and effort, and its notes on the lines below
+func editText(r *reminder.Reminder) string {
+	text := r.DateTime.Format("2006-01-02 15:04") + " " + r.Description
+	if !r.Yearly.IsZero() {
+		text = parser.FormatYearly(r.Yearly) + " " + r.Description
+	}
+	if !r.Relative.IsZero() {
+		text = parser.FormatRelative(r.Relative) + " " + r.Description
+	}
+	if r.Estimate > 0 {
+		text += " ~" + parser.FormatEstimate(r.Estimate)
+	}
+	if r.Effort != reminder.EffortNone {
+		text += " ^" + r.Effort.String()
+	}
+	if r.Notes != "" {
+		text += "\n" + r.Notes
+	}
+	return text
 }
 
 // updateReminder parses the input and updates an existing reminder
 func (m *Model) updateReminder(r *reminder.Reminder, input string) error {
-	input = strings.TrimSpace(input)
+	input, notes := splitEntry(input)
 	if input == "" {
 		return fmt.Errorf("empty input")
 	}
@@ -188,39 +203,35 @@ func (m *Model) updateReminder(r *reminder.Reminder, input string) error {
 		return fmt.Errorf("need both time and description (e.g., '+1h Call mom')")
 	}
 
-	now := time.Now()
-
-	// Try parsing from longest to shortest datetime prefix
-	for numDateWords := len(words) - 1; numDateWords >= 1; numDateWords-- {
-		dateStr := strings.Join(words[:numDateWords], " ")
-		descStr := strings.Join(words[numDateWords:], " ")
-
-		parsedTime, err := datetime.Parse(dateStr, now)
-		if err == nil {
-			// Extract tags from description
-			cleanDesc, tags := parser.ExtractTags(descStr)
-			r.DateTime = parsedTime
-			r.Description = cleanDesc
-			r.Tags = tags
-			// Update status based on new time
-			if now.After(parsedTime) {
-				if r.Status != reminder.Acknowledged {
-					r.Status = reminder.Triggered
-				}
-			} else {
-				if r.Status == reminder.Triggered {
-					r.Status = reminder.Pending
-				}
-			}
-			reminder.SortByDateTime(m.reminders)
-			m.refreshList()
-			m.saveState()
-			m.setStatusMessage("Edited: " + cleanDesc)
-			return nil
+	now := m.now()
+	parsed, err := parser.ParseEntry(input, now)
+	if err != nil {
+		return fmt.Errorf("couldn't parse time from input")
+	}
+	r.DateTime = parsed.DateTime
+	r.Description = parsed.Description
+	r.Notes = notes
+	r.Tags = parsed.Tags
+	r.Estimate = parsed.Estimate
+	r.Effort = parsed.Effort
+	r.Yearly = parsed.Yearly
+	r.Relative = parsed.Relative
+	r.UpdatedAt = now
+	// Update status based on new time
+	if now.After(r.DateTime) {
+		if r.Status.Scheduled() {
+			r.Status = reminder.Triggered
+		}
+	} else {
+		if r.Status == reminder.Triggered {
+			r.Status = reminder.Pending
 		}
 	}
-
-	return fmt.Errorf("couldn't parse time from input")
+	reminder.SortByDateTime(m.reminders)
+	m.refreshList()
+	m.saveState()
+	m.toastSuccess("Edited: " + r.Description)
+	return nil
 }
 
 // getAllTags returns all unique tags from all reminders
@@ -251,30 +262,40 @@ func (m Model) getMatchingTags(prefix string) []string {
 }
 
 func (m Model) getFilteredReminders() []*reminder.Reminder {
-	filterText := strings.ToLower(m.filterInput.Value())
-	if filterText == "" {
-		return m.reminders
+	filtered := m.matchingReminders()
+	if m.sortEnabled {
+		return m.sectionOrder(m.sortItems(filtered, m.now()), m.now())
 	}
+	return filtered
+}
 
-	var filtered []*reminder.Reminder
-
-	// Check if filtering by tag (starts with #)
-	if strings.HasPrefix(filterText, "#") {
-		tagFilter := strings.TrimPrefix(filterText, "#")
-		for _, r := range m.reminders {
-			for _, tag := range r.Tags {
-				if strings.ToLower(tag) == tagFilter {
-					filtered = append(filtered, r)
-					break
-				}
-			}
+// matchingReminders returns the reminders matching the filter and date
+// range, in saved order
+func (m Model) matchingReminders() []*reminder.Reminder {
+	if m.dateRange == rangeAny {
+		return m.textMatches()
+	}
+	var inRange []*reminder.Reminder
+	now := m.now()
+	for _, r := range m.textMatches() {
+		if m.dateRange.contains(r, now) {
+			inRange = append(inRange, r)
 		}
-		return filtered
 	}
+	return inRange
+}
 
-	// Filter by description
+// textMatches returns the reminders matching the text or tag filter, in
+// saved order
+func (m Model) textMatches() []*reminder.Reminder {
+	filterText := m.filterInput.Value()
+	if filterText == "" {
+		return m.reminders
+	}
+
+	var filtered []*reminder.Reminder
 	for _, r := range m.reminders {
-		if strings.Contains(strings.ToLower(r.Description), filterText) {
+		if r.Matches(filterText) {
 			filtered = append(filtered, r)
 		}
 	}
@@ -323,92 +344,18 @@ func (m *Model) calculateGridRow(itemIndex int) int {
 		return itemIndex / m.gridColumns
 	}
 
-	// With sections, we need to calculate based on section membership
-	items := m.getFilteredReminders()
-	if len(items) == 0 {
-		return 0
-	}
-
-	now := time.Now()
-	todayEnd := time.Date(now.Year(), now.Month(), now.Day(), 23, 59, 59, 0, now.Location())
-	tomorrowEnd := todayEnd.Add(24 * time.Hour)
-
-	// Calculate week boundaries (week starts on Sunday)
-	daysUntilEndOfWeek := (7 - int(now.Weekday())) % 7
-	thisWeekEnd := time.Date(now.Year(), now.Month(), now.Day()+daysUntilEndOfWeek, 23, 59, 59, 0, now.Location())
-	nextWeekEnd := thisWeekEnd.Add(7 * 24 * time.Hour)
-
-	// Calculate month boundaries
-	thisMonthEnd := time.Date(now.Year(), now.Month()+1, 0, 23, 59, 59, 0, now.Location())
-	nextMonthEnd := time.Date(now.Year(), now.Month()+2, 0, 23, 59, 59, 0, now.Location())
-
-	// Count items in each section
-	var dueCount, comingUpCount, tomorrowCount, laterThisWeekCount, nextWeekCount, laterThisMonthCount int
-	for _, r := range items {
-		if r.DateTime.Before(now) {
-			dueCount++
-		} else if r.DateTime.Before(todayEnd) {
-			comingUpCount++
-		} else if r.DateTime.Before(tomorrowEnd) {
-			tomorrowCount++
-		} else if r.DateTime.Before(thisWeekEnd) {
-			laterThisWeekCount++
-		} else if r.DateTime.Before(nextWeekEnd) {
-			nextWeekCount++
-		} else if r.DateTime.Before(thisMonthEnd) {
-			laterThisMonthCount++
-		} else if r.DateTime.Before(nextMonthEnd) {
-			// beyondNextMonth - we don't need to count, it's the last section
-		}
-	}
-
-	// Calculate rows per section (ceiling division)
+	// With sections, each section starts on a new row
 	cols := m.gridColumns
-	ceilDiv := func(a, b int) int {
-		if a == 0 {
-			return 0
-		}
-		return (a + b - 1) / b
-	}
-
-	dueRows := ceilDiv(dueCount, cols)
-	comingUpRows := ceilDiv(comingUpCount, cols)
-	tomorrowRows := ceilDiv(tomorrowCount, cols)
-	laterThisWeekRows := ceilDiv(laterThisWeekCount, cols)
-	nextWeekRows := ceilDiv(nextWeekCount, cols)
-	laterThisMonthRows := ceilDiv(laterThisMonthCount, cols)
-
-	// Calculate cumulative counts and rows
-	cumCounts := []int{
-		dueCount,
-		dueCount + comingUpCount,
-		dueCount + comingUpCount + tomorrowCount,
-		dueCount + comingUpCount + tomorrowCount + laterThisWeekCount,
-		dueCount + comingUpCount + tomorrowCount + laterThisWeekCount + nextWeekCount,
-		dueCount + comingUpCount + tomorrowCount + laterThisWeekCount + nextWeekCount + laterThisMonthCount,
-	}
-	cumRows := []int{
-		dueRows,
-		dueRows + comingUpRows,
-		dueRows + comingUpRows + tomorrowRows,
-		dueRows + comingUpRows + tomorrowRows + laterThisWeekRows,
-		dueRows + comingUpRows + tomorrowRows + laterThisWeekRows + nextWeekRows,
-		dueRows + comingUpRows + tomorrowRows + laterThisWeekRows + nextWeekRows + laterThisMonthRows,
-	}
-
-	// Determine which section the item is in and calculate row
-	if itemIndex < cumCounts[0] {
-		return itemIndex / cols
-	}
-	for i := 0; i < len(cumCounts)-1; i++ {
-		if itemIndex < cumCounts[i+1] {
-			indexInSection := itemIndex - cumCounts[i]
-			return cumRows[i] + indexInSection/cols
+	row, sectionStart := 0, 0
+	groups, _ := m.groupSections(m.getFilteredReminders(), m.now())
+	for _, group := range groups {
+		if itemIndex < sectionStart+len(group) {
+			return row + (itemIndex-sectionStart)/cols
 		}
+		sectionStart += len(group)
+		row += (len(group) + cols - 1) / cols
 	}
-	// Last section (beyond next month)
-	indexInSection := itemIndex - cumCounts[len(cumCounts)-1]
-	return cumRows[len(cumRows)-1] + indexInSection/cols
+	return row
 }
 
 // scrollCompactToSelection ensures the selected item is visible
@@ -434,7 +381,7 @@ func (m *Model) scrollCompactToSelection() {
 func (m *Model) visibleGridRows() int {
 	// Card height: 4 content + 2 border + 1 margin = 7 lines per row
 	cardRowHeight := 7
-	availableHeight := m.height - 6 // leave room for help bar and scroll indicators (2 lines)
+	availableHeight := m.height - 7 - m.bannerHeight() // leave room for status bar, help bar and scroll indicators (2 lines)
 	if availableHeight < cardRowHeight {
 		return 1
 	}
@@ -444,7 +391,7 @@ func (m *Model) visibleGridRows() int {
 // visibleCompactItems returns how many items fit in the available height
 // Each item is 1 line, plus we account for ~3 section headers
 func (m *Model) visibleCompactItems() int {
-	availableHeight := m.height - 6 // leave room for help bar, scroll indicators, and some headers
+	availableHeight := m.height - 7 - m.bannerHeight() // leave room for status bar, help bar, scroll indicators, and some headers
 	if availableHeight < 1 {
 		return 1
 	}
@@ -458,44 +405,14 @@ func (m *Model) getSectionBoundaries() []int {
 		return []int{0}
 	}
 
-	now := time.Now()
-	todayEnd := time.Date(now.Year(), now.Month(), now.Day(), 23, 59, 59, 0, now.Location())
-	tomorrowEnd := todayEnd.Add(24 * time.Hour)
-	daysUntilEndOfWeek := (7 - int(now.Weekday())) % 7
-	thisWeekEnd := time.Date(now.Year(), now.Month(), now.Day()+daysUntilEndOfWeek, 23, 59, 59, 0, now.Location())
-	nextWeekEnd := thisWeekEnd.Add(7 * 24 * time.Hour)
-	thisMonthEnd := time.Date(now.Year(), now.Month()+1, 0, 23, 59, 59, 0, now.Location())
-	nextMonthEnd := time.Date(now.Year(), now.Month()+2, 0, 23, 59, 59, 0, now.Location())
-
-	// Count items in each section
-	var counts [7]int
-	for _, r := range items {
-		if r.DateTime.Before(now) {
-			counts[0]++
-		} else if r.DateTime.Before(todayEnd) {
-			counts[1]++
-		} else if r.DateTime.Before(tomorrowEnd) {
-			counts[2]++
-		} else if r.DateTime.Before(thisWeekEnd) {
-			counts[3]++
-		} else if r.DateTime.Before(nextWeekEnd) {
-			counts[4]++
-		} else if r.DateTime.Before(thisMonthEnd) {
-			counts[5]++
-		} else if r.DateTime.Before(nextMonthEnd) {
-			counts[6]++
-		} else {
-			counts[6]++
-		}
-	}
-
 	// Build list of section start indices (only for non-empty sections)
 	var boundaries []int
 	idx := 0
-	for _, count := range counts {
-		if count > 0 {
+	groups, _ := m.groupSections(items, m.now())
+	for _, group := range groups {
+		if len(group) > 0 {
 			boundaries = append(boundaries, idx)
-			idx += count
+			idx += len(group)
 		}
 	}
 
@@ -0,0 +1,197 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"go_remind/pkg/state"
+)
+
+// inputHistoryMax is how many entries each box's history keeps
+const inputHistoryMax = 200
+
+// InputHistoryStore keeps what was entered into the add and filter boxes
+// between sessions; satisfied by *state.Store
+type InputHistoryStore interface {
+	LoadInputHistory() (state.InputHistory, error)
+	SaveInputHistory(history state.InputHistory) error
+}
+
+// historyInput is a text box whose history can be recalled into it
+type historyInput interface {
+	Value() string
+	SetValue(s string)
+	CursorEnd()
+}
+
+// inputHistory is what was entered into a text box, oldest first, and
+// where up and down or a ctrl+r search have got to in it
+type inputHistory struct {
+	entries []string
+	index   int    // Entry shown in the box; len(entries) when it's new text
+	typed   string // The new text, kept while stepping through entries
+
+	searching bool
+	query     string
+	found     int // Entry matching query; -1 when none does
+}
+
+// WithInputHistory returns a copy of the model that recalls what was
+// entered into the add and filter boxes in earlier sessions, saving new
+// entries to store
+func (m Model) WithInputHistory(store InputHistoryStore) Model {
+	m.inputHistory = store
+	history, err := store.LoadInputHistory()
+	if err != nil {
+		m.toastError("Could not load input history: " + err.Error())
+		return m
+	}
+	m.addHistory.entries = history.Add
+	m.filterHistory.entries = history.Filter
+	return m
+}
+
+// rememberInput adds what was entered into a box to its history, moving
+// it to the end if it was there already, and saves it
+func (m *Model) rememberInput(h *inputHistory, text string) {
+	if strings.TrimSpace(text) == "" {
+		return
+	}
+	h.entries = slices.DeleteFunc(h.entries, func(e string) bool { return e == text })
+	h.entries = append(h.entries, text)
+	if len(h.entries) > inputHistoryMax {
+		h.entries = h.entries[len(h.entries)-inputHistoryMax:]
+	}
+	if m.inputHistory == nil {
+		return
+	}
+	history := state.InputHistory{Add: m.addHistory.entries, Filter: m.filterHistory.entries}
+	if err := m.inputHistory.SaveInputHistory(history); err != nil {
+		m.toastError("Could not save input history: " + err.Error())
+	}
+}
+
+// resetInputHistory starts a box's history over at the new text, for when
+// the box opens
+func resetInputHistory(h *inputHistory) {
+	h.index = len(h.entries)
+	h.typed = ""
+	h.searching = false
+}
+
+// updateInputHistory handles the keys that recall a box's history: up and
+// down step through it, and ctrl+r searches it backwards, as in a shell.
+// It reports whether it used msg.
+func updateInputHistory(h *inputHistory, input historyInput, msg tea.KeyMsg) bool {
+	if h.searching {
+		return updateHistorySearch(h, input, msg)
+	}
+	switch msg.Type {
+	case tea.KeyUp:
+		if h.index == 0 {
+			return true
+		}
+		if h.index == len(h.entries) {
+			h.typed = input.Value()
+		}
+		h.index--
+		input.SetValue(h.entries[h.index])
+	case tea.KeyDown:
+		if h.index == len(h.entries) {
+			return true
+		}
+		h.index++
+		if h.index == len(h.entries) {
+			input.SetValue(h.typed)
+		} else {
+			input.SetValue(h.entries[h.index])
+		}
+	case tea.KeyCtrlR:
+		if h.index == len(h.entries) {
+			h.typed = input.Value()
+		}
+		h.searching = true
+		h.query = ""
+		h.found = -1
+		return true
+	default:
+		return false
+	}
+	input.CursorEnd()
+	return true
+}
+
+// updateHistorySearch handles a key during a ctrl+r search. Typing narrows
+// it, ctrl+r again finds the next older match, enter puts the match in the
+// box to edit, and esc goes back to what was there before.
+func updateHistorySearch(h *inputHistory, input historyInput, msg tea.KeyMsg) bool {
+	switch msg.Type {
+	case tea.KeyCtrlR:
+		h.findOlder(h.found)
+		return true
+	case tea.KeyBackspace:
+		if h.query != "" {
+			_, size := utf8.DecodeLastRuneInString(h.query)
+			h.query = h.query[:len(h.query)-size]
+			h.findOlder(len(h.entries))
+		}
+		return true
+	case tea.KeyRunes, tea.KeySpace:
+		h.query += string(msg.Runes)
+		h.findOlder(len(h.entries))
+		return true
+	case tea.KeyEscape:
+		h.searching = false
+		if h.index == len(h.entries) {
+			input.SetValue(h.typed)
+		} else {
+			input.SetValue(h.entries[h.index])
+		}
+		input.CursorEnd()
+		return true
+	case tea.KeyEnter:
+		h.searching = false
+		if h.found >= 0 {
+			h.index = h.found
+			input.SetValue(h.entries[h.found])
+			input.CursorEnd()
+		}
+	}
+	return true
+}
+
+// findOlder finds the newest entry before the one at index before that
+// contains the query, ignoring case. It stays on the current match when
+// there are no older ones.
+func (h *inputHistory) findOlder(before int) {
+	query := strings.ToLower(h.query)
+	if before < 0 {
+		before = len(h.entries)
+	}
+	for i := before - 1; i >= 0; i-- {
+		if strings.Contains(strings.ToLower(h.entries[i]), query) {
+			h.found = i
+			return
+		}
+	}
+	if before == len(h.entries) {
+		h.found = -1
+	}
+}
+
+// historySearchView shows a ctrl+r search in progress, as a shell does
+func (h inputHistory) historySearchView() string {
+	match := ""
+	if h.found >= 0 {
+		match = strings.ReplaceAll(h.entries[h.found], "\n", " ")
+	}
+	prompt := "(reverse-i-search)`" + h.query + "': "
+	if h.found < 0 && h.query != "" {
+		prompt = "(failed reverse-i-search)`" + h.query + "': "
+	}
+	return inputHintStyle.Render("  "+prompt) + normalStyle.Render(match) + "\n" +
+		inputHintStyle.Render("  ctrl+r for older "+glyphs.Bullet+" enter to use it "+glyphs.Bullet+" esc to cancel")
+}
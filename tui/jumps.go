@@ -0,0 +1,99 @@
+package tui
+
+// jumpListMax is how many positions the jump list remembers
+const jumpListMax = 100
+
+// jump is a position in the jump list: a reminder, and whether its detail
+// view was open
+type jump struct {
+	id     string
+	detail bool
+}
+
+// currentJump returns the cursor's position, or false when nothing is
+// selected
+func (m Model) currentJump() (jump, bool) {
+	if m.mode == modeDetail && m.detailReminder != nil {
+		return jump{id: m.detailReminder.ID, detail: true}, true
+	}
+	if r := m.selectedReminder(); r != nil {
+		return jump{id: r.ID}, true
+	}
+	return jump{}, false
+}
+
+// recordJump remembers the cursor's position before a jump moves it, so
+// jumpBack can return to it. Positions gone back past are forgotten, as
+// in a browser's history.
+func (m *Model) recordJump() {
+	j, ok := m.currentJump()
+	if !ok {
+		return
+	}
+	m.jumps = m.jumps[:m.jumpIndex]
+	if n := len(m.jumps); n == 0 || m.jumps[n-1] != j {
+		m.jumps = append(m.jumps, j)
+	}
+	if len(m.jumps) > jumpListMax {
+		m.jumps = m.jumps[len(m.jumps)-jumpListMax:]
+	}
+	m.jumpIndex = len(m.jumps)
+}
+
+// jumpBack returns the cursor to where it was before the last jump,
+// skipping positions whose reminders are gone or filtered out
+func (m *Model) jumpBack() {
+	current, ok := m.currentJump()
+	if ok && m.jumpIndex == len(m.jumps) {
+		// Remember where we are, so jumpForward can come back
+		if n := len(m.jumps); n > 0 && m.jumps[n-1] == current {
+			m.jumpIndex--
+		} else {
+			m.jumps = append(m.jumps, current)
+		}
+	}
+	for i := m.jumpIndex - 1; i >= 0; i-- {
+		if m.jumps[i] != current && m.jumpTo(m.jumps[i]) {
+			m.jumpIndex = i
+			return
+		}
+	}
+	m.toastInfo("No earlier position to jump back to")
+}
+
+// jumpForward undoes jumpBack
+func (m *Model) jumpForward() {
+	current, _ := m.currentJump()
+	for i := m.jumpIndex + 1; i < len(m.jumps); i++ {
+		if m.jumps[i] != current && m.jumpTo(m.jumps[i]) {
+			m.jumpIndex = i
+			return
+		}
+	}
+	m.toastInfo("No later position to jump forward to")
+}
+
+// jumpTo moves the cursor to j, opening or closing the detail view to
+// match, and reports whether j's reminder could be shown
+func (m *Model) jumpTo(j jump) bool {
+	r := m.reminderByID(j.id)
+	if r == nil {
+		return false
+	}
+	if j.detail {
+		m.openDetail(r)
+		return true
+	}
+	for i, item := range m.getFilteredReminders() {
+		if item == r {
+			if m.mode == modeDetail {
+				m.mode = modeNormal
+				m.detailReminder = nil
+				m.detailScroll = 0
+			}
+			m.selectIndex(i)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,164 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// bindingNames maps the action names used in the [keys] config section to
+// the bindings they control
+func (k *keyMap) bindingNames() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"up":            &k.Up,
+		"down":          &k.Down,
+		"left":          &k.Left,
+		"right":         &k.Right,
+		"prev_section":  &k.PrevSection,
+		"next_section":  &k.NextSection,
+		"goto_first":    &k.GotoFirst,
+		"goto_last":     &k.GotoLast,
+		"jump_back":     &k.JumpBack,
+		"jump_forward":  &k.JumpForward,
+		"set_mark":      &k.SetMark,
+		"goto_mark":     &k.GotoMark,
+		"acknowledge":   &k.Acknowledge,
+		"unacknowledge": &k.Unacknowledge,
+		"delete":        &k.Delete,
+		"snooze_5m":     &k.Snooze5m,
+		"snooze_1h":     &k.Snooze1h,
+		"snooze_1d":     &k.Snooze1d,
+		"waiting":       &k.Waiting,
+		"someday":       &k.Someday,
+		"timer":         &k.Timer,
+		"effort":        &k.Effort,
+		"label":         &k.Label,
+		"filter":        &k.Filter,
+		"search":        &k.Search,
+		"next_match":    &k.NextMatch,
+		"prev_match":    &k.PrevMatch,
+		"add":           &k.Add,
+		"edit":          &k.Edit,
+		"compose":       &k.Compose,
+		"reschedule":    &k.Reschedule,
+		"shift":         &k.Shift,
+		"undo":          &k.Undo,
+		"command":       &k.Command,
+		"detail":        &k.Detail,
+		"open_link":     &k.OpenLink,
+		"yank":          &k.Yank,
+		"export_view":   &k.ExportView,
+		"paste":         &k.Paste,
+		"theme":         &k.Theme,
+		"contrast":      &k.Contrast,
+		"layout":        &k.Layout,
+		"split":         &k.Split,
+		"sort":          &k.Sort,
+		"sort_order":    &k.SortOrder,
+		"group":         &k.Group,
+		"low_energy":    &k.LowEnergy,
+		"digest":        &k.Digest,
+		"focus":         &k.Focus,
+		"stats":         &k.Stats,
+		"activity":      &k.Activity,
+		"orphans":       &k.Orphans,
+		"muted":         &k.Muted,
+		"people":        &k.People,
+		"triggered":     &k.Triggered,
+		"merge_log":     &k.MergeLog,
+		"watchers":      &k.WatcherHealth,
+		"timeline":      &k.Timeline,
+		"duplicates":    &k.Duplicates,
+		"sources":       &k.Sources,
+		"profiles":      &k.Profiles,
+		"workspaces":    &k.Workspaces,
+		"help":          &k.Help,
+		"cheatsheet":    &k.Cheatsheet,
+		"quit":          &k.Quit,
+	}
+}
+
+// doubledBindings are pressed twice to act, vim-style (dd, gg)
+var doubledBindings = map[string]bool{"delete": true, "goto_first": true}
+
+// searchBindings only act while a search is kept, so they may share keys
+// with the other bindings but not with each other
+var searchBindings = map[string]bool{"next_match": true, "prev_match": true}
+
+// keySymbols are shown in help in place of key names
+var keySymbols = map[string]string{
+	"up":    "↑",
+	"down":  "↓",
+	"left":  "←",
+	"right": "→",
+	" ":     "space",
+	"f1":    "F1",
+}
+
+// SetKeyBindings remaps actions to the given keys, e.g. {"detail": {"i"}}.
+// Unknown actions and keys bound to more than one action are reported and
+// nothing is changed.
+func SetKeyBindings(overrides map[string][]string) error {
+	remapped := keys
+	bindings := remapped.bindingNames()
+
+	for name, keyNames := range overrides {
+		b, ok := bindings[name]
+		if !ok {
+			return fmt.Errorf("keys: unknown action %q", name)
+		}
+		if len(keyNames) == 0 {
+			return fmt.Errorf("keys.%s: no keys given", name)
+		}
+
+		display := make([]string, len(keyNames))
+		for i, k := range keyNames {
+			display[i] = k
+			if sym, ok := keySymbols[k]; ok {
+				display[i] = sym
+			}
+			if doubledBindings[name] {
+				display[i] += display[i]
+			}
+		}
+		b.SetKeys(keyNames...)
+		b.SetHelp(strings.Join(display, "/"), b.Help().Desc)
+	}
+
+	if err := checkConflicts(bindings); err != nil {
+		return err
+	}
+	keys = remapped
+	return nil
+}
+
+// checkConflicts reports keys bound to more than one action that can be
+// pressed at the same time
+func checkConflicts(bindings map[string]*key.Binding) error {
+	type scopedKey struct {
+		search bool
+		key    string
+	}
+	owners := make(map[scopedKey][]string)
+	for name, b := range bindings {
+		for _, k := range b.Keys() {
+			sk := scopedKey{searchBindings[name], k}
+			owners[sk] = append(owners[sk], name)
+		}
+	}
+
+	var conflicts []string
+	for sk, names := range owners {
+		if len(names) > 1 {
+			sort.Strings(names)
+			conflicts = append(conflicts, fmt.Sprintf("%q is bound to %s", sk.key, strings.Join(names, " and ")))
+		}
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+	sort.Strings(conflicts)
+	return fmt.Errorf("keys: conflicting bindings: %s", strings.Join(conflicts, "; "))
+}
@@ -9,38 +9,82 @@ import (
 type keyMap struct {
 	Up            key.Binding
 	Down          key.Binding
+	Left          key.Binding
+	Right         key.Binding
 	PrevSection   key.Binding
 	NextSection   key.Binding
 	GotoFirst     key.Binding
 	GotoLast      key.Binding
+	JumpBack      key.Binding
+	JumpForward   key.Binding
+	SetMark       key.Binding
+	GotoMark      key.Binding
 	Acknowledge   key.Binding
 	Unacknowledge key.Binding
 	Delete        key.Binding
 	Snooze5m      key.Binding
 	Snooze1h      key.Binding
 	Snooze1d      key.Binding
+	Waiting       key.Binding
+	Someday       key.Binding
+	Timer         key.Binding
+	Effort        key.Binding
+	Label         key.Binding
 	Filter        key.Binding
+	Search        key.Binding
+	NextMatch     key.Binding
+	PrevMatch     key.Binding
 	Add           key.Binding
 	Edit          key.Binding
+	Compose       key.Binding
+	Reschedule    key.Binding
+	Shift         key.Binding
+	Undo          key.Binding
+	Command       key.Binding
 	Detail        key.Binding
+	OpenLink      key.Binding
+	Yank          key.Binding
+	ExportView    key.Binding
+	Paste         key.Binding
 	Theme         key.Binding
+	Contrast      key.Binding
 	Layout        key.Binding
+	Split         key.Binding
 	Sort          key.Binding
+	Group         key.Binding
+	LowEnergy     key.Binding
+	SortOrder     key.Binding
+	Digest        key.Binding
+	Focus         key.Binding
+	Timeline      key.Binding
+	Stats         key.Binding
+	Activity      key.Binding
+	Orphans       key.Binding
+	Muted         key.Binding
+	People        key.Binding
+	Triggered     key.Binding
+	MergeLog      key.Binding
+	WatcherHealth key.Binding
+	Duplicates    key.Binding
+	Sources       key.Binding
+	Profiles      key.Binding
+	Workspaces    key.Binding
 	Help          key.Binding
+	Cheatsheet    key.Binding
 	Quit          key.Binding
 }
 
 // ShortHelp returns key bindings for the short help view
 func (k keyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Acknowledge, k.Filter, k.Add, k.Help, k.Quit}
+	return []key.Binding{k.Acknowledge, k.Filter, k.Add, k.Help, k.Cheatsheet, k.Quit}
 }
 
 // FullHelp returns key bindings for the full help view
 func (k keyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.Up, k.Down, k.PrevSection, k.NextSection, k.GotoFirst, k.GotoLast},
-		{k.Acknowledge, k.Unacknowledge, k.Snooze5m, k.Snooze1h, k.Snooze1d, k.Delete},
-		{k.Filter, k.Add, k.Edit, k.Detail, k.Theme, k.Layout, k.Sort, k.Help, k.Quit},
+		{k.Up, k.Down, k.Left, k.Right, k.PrevSection, k.NextSection, k.GotoFirst, k.GotoLast, k.JumpBack, k.JumpForward, k.SetMark, k.GotoMark},
+		{k.Acknowledge, k.Unacknowledge, k.Snooze5m, k.Snooze1h, k.Snooze1d, k.Waiting, k.Someday, k.Timer, k.Effort, k.Label, k.Delete},
+		{k.Filter, k.Search, k.NextMatch, k.PrevMatch, k.Add, k.Edit, k.Compose, k.Reschedule, k.Shift, k.Undo, k.Command, k.Detail, k.OpenLink, k.Yank, k.ExportView, k.Paste, k.Theme, k.Contrast, k.Layout, k.Split, k.Sort, k.SortOrder, k.Group, k.LowEnergy, k.Digest, k.Focus, k.Timeline, k.Stats, k.Activity, k.Orphans, k.Muted, k.People, k.Triggered, k.MergeLog, k.WatcherHealth, k.Duplicates, k.Sources, k.Profiles, k.Workspaces, k.Help, k.Cheatsheet, k.Quit},
 	}
 }
 
@@ -55,6 +99,14 @@ var keys = keyMap{
 		key.WithKeys("down", "j"),
 		key.WithHelp("↓/j", "down"),
 	),
+	Left: key.NewBinding(
+		key.WithKeys("left", "h"),
+		key.WithHelp("←/h", "left"),
+	),
+	Right: key.NewBinding(
+		key.WithKeys("right", "l"),
+		key.WithHelp("→/l", "right"),
+	),
 	PrevSection: key.NewBinding(
 		key.WithKeys("{"),
 		key.WithHelp("{", "prev section"),
@@ -71,6 +123,22 @@ var keys = keyMap{
 		key.WithKeys("G"),
 		key.WithHelp("G", "last"),
 	),
+	JumpBack: key.NewBinding(
+		key.WithKeys("ctrl+o"),
+		key.WithHelp("ctrl+o", "jump back"),
+	),
+	JumpForward: key.NewBinding(
+		key.WithKeys("tab"), // What terminals send for ctrl+i
+		key.WithHelp("ctrl+i", "jump forward"),
+	),
+	SetMark: key.NewBinding(
+		key.WithKeys("m"),
+		key.WithHelp("m", "mark (then a-z)"),
+	),
+	GotoMark: key.NewBinding(
+		key.WithKeys("'"),
+		key.WithHelp("'", "go to mark (then a-z)"),
+	),
 	Acknowledge: key.NewBinding(
 		key.WithKeys("enter", " "),
 		key.WithHelp("enter", "done"),
@@ -95,10 +163,42 @@ var keys = keyMap{
 		key.WithKeys("3"),
 		key.WithHelp("3", "snooze 1d"),
 	),
+	Waiting: key.NewBinding(
+		key.WithKeys("w"),
+		key.WithHelp("w", "waiting"),
+	),
+	Someday: key.NewBinding(
+		key.WithKeys("z"),
+		key.WithHelp("z", "someday"),
+	),
+	Timer: key.NewBinding(
+		key.WithKeys("T"),
+		key.WithHelp("T", "start/stop timer"),
+	),
+	Effort: key.NewBinding(
+		key.WithKeys("E"),
+		key.WithHelp("E", "effort"),
+	),
+	Label: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "color label"),
+	),
 	Filter: key.NewBinding(
 		key.WithKeys("/"),
 		key.WithHelp("/", "filter"),
 	),
+	Search: key.NewBinding(
+		key.WithKeys("f"),
+		key.WithHelp("f", "find"),
+	),
+	NextMatch: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "next match"),
+	),
+	PrevMatch: key.NewBinding(
+		key.WithKeys("N"),
+		key.WithHelp("N", "previous match"),
+	),
 	Add: key.NewBinding(
 		key.WithKeys("n"),
 		key.WithHelp("n", "new"),
@@ -107,26 +207,146 @@ var keys = keyMap{
 		key.WithKeys("e"),
 		key.WithHelp("e", "edit"),
 	),
+	Compose: key.NewBinding(
+		key.WithKeys("a"),
+		key.WithHelp("a", "compose in $EDITOR"),
+	),
+	Reschedule: key.NewBinding(
+		key.WithKeys("R"),
+		key.WithHelp("R", "reschedule"),
+	),
+	Shift: key.NewBinding(
+		key.WithKeys("S"),
+		key.WithHelp("S", "shift all in view"),
+	),
+	Undo: key.NewBinding(
+		key.WithKeys("U"),
+		key.WithHelp("U", "undo bulk change"),
+	),
+	Command: key.NewBinding(
+		key.WithKeys(":"),
+		key.WithHelp(":", "command"),
+	),
 	Detail: key.NewBinding(
 		key.WithKeys("K"),
 		key.WithHelp("K", "detail"),
 	),
+	OpenLink: key.NewBinding(
+		key.WithKeys("o"),
+		key.WithHelp("o", "open link"),
+	),
+	Yank: key.NewBinding(
+		key.WithKeys("y"),
+		key.WithHelp("y", "copy as markdown"),
+	),
+	ExportView: key.NewBinding(
+		key.WithKeys("Y"),
+		key.WithHelp("Y", "export view"),
+	),
+	Paste: key.NewBinding(
+		key.WithKeys("p"),
+		key.WithHelp("p", "paste from clipboard"),
+	),
 	Theme: key.NewBinding(
 		key.WithKeys("t"),
 		key.WithHelp("t", "theme"),
 	),
+	Contrast: key.NewBinding(
+		key.WithKeys("C"),
+		key.WithHelp("C", "high contrast"),
+	),
 	Layout: key.NewBinding(
 		key.WithKeys("v"),
 		key.WithHelp("v", "view"),
 	),
+	Split: key.NewBinding(
+		key.WithKeys("|"),
+		key.WithHelp("|", "split pane"),
+	),
 	Sort: key.NewBinding(
 		key.WithKeys("s"),
 		key.WithHelp("s", "sort"),
 	),
+	SortOrder: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "sort order"),
+	),
+	Group: key.NewBinding(
+		key.WithKeys("b"),
+		key.WithHelp("b", "group by"),
+	),
+	LowEnergy: key.NewBinding(
+		key.WithKeys("L"),
+		key.WithHelp("L", "low energy mode"),
+	),
+	Digest: key.NewBinding(
+		key.WithKeys("D"),
+		key.WithHelp("D", "digest"),
+	),
+	Focus: key.NewBinding(
+		key.WithKeys("Z"),
+		key.WithHelp("Z", "focus on next due"),
+	),
+	Stats: key.NewBinding(
+		key.WithKeys("H"),
+		key.WithHelp("H", "stats"),
+	),
+	Activity: key.NewBinding(
+		key.WithKeys("A"),
+		key.WithHelp("A", "activity"),
+	),
+	Orphans: key.NewBinding(
+		key.WithKeys("O"),
+		key.WithHelp("O", "orphans"),
+	),
+	Timeline: key.NewBinding(
+		key.WithKeys("W"),
+		key.WithHelp("W", "timeline"),
+	),
+	Muted: key.NewBinding(
+		key.WithKeys("M"),
+		key.WithHelp("M", "muted"),
+	),
+	Triggered: key.NewBinding(
+		key.WithKeys("!"),
+		key.WithHelp("!", "triggered"),
+	),
+	People: key.NewBinding(
+		key.WithKeys("@"),
+		key.WithHelp("@", "people"),
+	),
+	MergeLog: key.NewBinding(
+		key.WithKeys("I"),
+		key.WithHelp("I", "merge log"),
+	),
+	WatcherHealth: key.NewBinding(
+		key.WithKeys("X"),
+		key.WithHelp("X", "watcher health"),
+	),
+	Duplicates: key.NewBinding(
+		key.WithKeys("="),
+		key.WithHelp("=", "duplicates"),
+	),
+	Sources: key.NewBinding(
+		key.WithKeys("F"),
+		key.WithHelp("F", "sources"),
+	),
+	Profiles: key.NewBinding(
+		key.WithKeys("P"),
+		key.WithHelp("P", "profiles"),
+	),
+	Workspaces: key.NewBinding(
+		key.WithKeys("V"),
+		key.WithHelp("V", "workspaces"),
+	),
 	Help: key.NewBinding(
 		key.WithKeys("?"),
 		key.WithHelp("?", "help"),
 	),
+	Cheatsheet: key.NewBinding(
+		key.WithKeys("f1"),
+		key.WithHelp("F1", "all keys"),
+	),
 	Quit: key.NewBinding(
 		key.WithKeys("q", "ctrl+c"),
 		key.WithHelp("q", "quit"),
@@ -0,0 +1,41 @@
+package tui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	"go_remind/pkg/reminder"
+)
+
+// labelColors are the colors labels are drawn in, by label
+var labelColors = []lipgloss.AdaptiveColor{
+	reminder.LabelRed:    {Light: "160", Dark: "203"},
+	reminder.LabelYellow: {Light: "136", Dark: "221"},
+	reminder.LabelGreen:  {Light: "28", Dark: "114"},
+	reminder.LabelBlue:   {Light: "25", Dark: "75"},
+	reminder.LabelPurple: {Light: "91", Dark: "141"},
+}
+
+// cycleLabel steps r's label through the colors and back to none
+func (m *Model) cycleLabel(r *reminder.Reminder) {
+	if r == nil {
+		return
+	}
+	r.Label = (r.Label + 1) % reminder.Label(len(labelColors))
+	r.UpdatedAt = m.now()
+	m.refreshList()
+	m.saveState()
+	if r.Label == reminder.LabelNone {
+		m.toastInfo("Cleared label: " + r.Description)
+		return
+	}
+	m.toastInfo("Label " + r.Label.String() + ": " + r.Description)
+}
+
+// labelDot renders r's label as a dot in its color, after a space, or ""
+// without one
+func labelDot(r *reminder.Reminder) string {
+	if r.Label <= reminder.LabelNone || int(r.Label) >= len(labelColors) {
+		return ""
+	}
+	return " " + lipgloss.NewStyle().Foreground(labelColors[r.Label]).Render(glyphs.Label)
+}
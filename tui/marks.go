@@ -0,0 +1,87 @@
+package tui
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// markLetter returns the mark a key names, a to z, or false for any other
+// key
+func markLetter(s string) (rune, bool) {
+	if len(s) != 1 || s[0] < 'a' || s[0] > 'z' {
+		return 0, false
+	}
+	return rune(s[0]), true
+}
+
+// takeMarkKey finishes a mark command with the letter after it. Anything
+// but a letter cancels it.
+func (m *Model) takeMarkKey(s string) {
+	setting := m.pendingMark
+	m.pendingMark, m.pendingGotoMark = false, false
+	letter, ok := markLetter(s)
+	switch {
+	case !ok && s != "esc":
+		m.toastInfo("Marks are named a to z")
+	case !ok:
+	case setting:
+		m.setMark(letter)
+	default:
+		m.gotoMark(letter)
+	}
+}
+
+// setMark bookmarks the selected reminder as letter, replacing any
+// reminder marked with it before
+func (m *Model) setMark(letter rune) {
+	r := m.selectedReminder()
+	if r == nil {
+		m.toastInfo("Nothing selected to mark")
+		return
+	}
+	if m.marks == nil {
+		m.marks = make(map[rune]string)
+	}
+	m.marks[letter] = r.ID
+	m.toastSuccess("Marked '" + string(letter) + ": " + r.Description)
+}
+
+// gotoMark moves the cursor to the reminder marked letter. If the filter
+// hides it, the filter is cleared so it shows.
+func (m *Model) gotoMark(letter rune) {
+	id, ok := m.marks[letter]
+	if !ok {
+		m.toastInfo("No mark '" + string(letter))
+		return
+	}
+	r := m.reminderByID(id)
+	if r == nil {
+		delete(m.marks, letter)
+		m.toastError("The reminder marked '" + string(letter) + " is gone")
+		return
+	}
+	m.recordJump()
+	if !slices.Contains(m.getFilteredReminders(), r) {
+		m.filterInput.Reset()
+		m.dateRange = rangeAny
+		m.refreshList()
+		m.saveViewSettings()
+		m.toastInfo("Cleared the filter to show mark '" + string(letter))
+	}
+	m.selectReminder(r)
+}
+
+// marksHint lists the marks for the command line, e.g. "'a Standup", or
+// returns "" when there are none
+func (m Model) marksHint() string {
+	var parts []string
+	for _, letter := range slices.Sorted(maps.Keys(m.marks)) {
+		if r := m.reminderByID(m.marks[letter]); r != nil {
+			parts = append(parts, "'"+string(letter)+" "+ansi.Truncate(r.Description, 24, glyphs.Ellipsis))
+		}
+	}
+	return strings.Join(parts, "  ")
+}
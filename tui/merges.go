@@ -0,0 +1,253 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"go_remind/pkg/reminder"
+	"go_remind/pkg/state"
+)
+
+// mergeLogMax is how many file updates the merge log keeps
+const mergeLogMax = 200
+
+// mergeRecord is what one file update changed, by description. It's kept
+// for the session so a reminder that went missing can be traced.
+type mergeRecord struct {
+	at                   time.Time
+	file                 string
+	added, removed, kept []string
+	rejected             bool // Held for confirmation and turned down
+}
+
+// applyFileUpdate merges a watched file's newly parsed reminders
+func (m *Model) applyFileUpdate(msg FileUpdateMsg) {
+	m.logMerge(msg, false)
+	m.reminders = m.files.MergeFromFile(m.reminders, msg.FilePath, m.dropMuted(msg.Reminders, msg.FilePath))
+	reminder.SortByDateTime(m.reminders)
+	m.refreshList()
+	m.saveState()
+	m.dupeCheckDue = true
+	m.reloadDetailSource(msg.FilePath)
+	if msg.RulesErr != nil {
+		m.toastError("Rules: " + msg.RulesErr.Error())
+	} else if !m.takeWriteBack(msg.FilePath) {
+		m.toastInfo(fmt.Sprintf("File updated: %d reminders", len(msg.Reminders)))
+	}
+}
+
+// fileDiff returns what merging msg would change
+func (m Model) fileDiff(msg FileUpdateMsg) reminder.FileDiff {
+	parsed, _ := state.DropMuted(m.muted, msg.Reminders, msg.FilePath)
+	return reminder.DiffFile(m.reminders, msg.FilePath, parsed)
+}
+
+// holdMerge holds back a file update that would remove at least
+// merge.confirm_removals reminders, to be previewed and confirmed first,
+// and reports whether it did. A newer update to a held file replaces it,
+// or goes through if it no longer removes as many.
+func (m *Model) holdMerge(msg FileUpdateMsg) bool {
+	threshold := m.config.Merge.ConfirmRemovals
+	if threshold <= 0 {
+		return false
+	}
+	large := len(m.fileDiff(msg).Removed) >= threshold
+	i := slices.IndexFunc(m.heldMerges, func(held FileUpdateMsg) bool { return held.FilePath == msg.FilePath })
+	switch {
+	case i >= 0 && large:
+		m.heldMerges[i] = msg
+	case i >= 0:
+		m.heldMerges = slices.Delete(m.heldMerges, i, i+1)
+	case large:
+		m.heldMerges = append(m.heldMerges, msg)
+	}
+	m.checkMergePreview()
+	return large
+}
+
+// checkMergePreview shows the next held file update once the list is
+// showing, and leaves the preview once there are none
+func (m *Model) checkMergePreview() {
+	switch {
+	case len(m.heldMerges) == 0 && m.mode == modeMergePreview:
+		m.mode = modeNormal
+	case len(m.heldMerges) > 0 && m.mode == modeNormal:
+		m.mode = modeMergePreview
+	}
+}
+
+func (m Model) updateMergePreviewMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	held := m.heldMerges[0]
+	switch {
+	case msg.String() == "y", msg.Type == tea.KeyEnter:
+		m.heldMerges = m.heldMerges[1:]
+		m.mode = modeNormal
+		m.applyFileUpdate(held)
+	case msg.String() == "n", msg.Type == tea.KeyEscape:
+		m.heldMerges = m.heldMerges[1:]
+		m.mode = modeNormal
+		m.logMerge(held, true)
+		m.toastInfo("Kept the reminders from " + filepath.Base(held.FilePath) + " as they were")
+	default:
+		return m, nil
+	}
+	m.checkMergePreview()
+	return m, nil
+}
+
+// mergePreviewView shows what a held file update would add, remove, and
+// keep
+func (m Model) mergePreviewView() string {
+	held := m.heldMerges[0]
+	diff := m.fileDiff(held)
+	limit := max((m.height-14)/3, 3)
+
+	var b strings.Builder
+	b.WriteString(triggeredStyle.Render(glyphs.Warning + " Large change to " + filepath.Base(held.FilePath)))
+	b.WriteString("\n")
+	b.WriteString(inputHintStyle.Render(held.FilePath))
+	b.WriteString("\n\n")
+	b.WriteString(normalStyle.Render(fmt.Sprintf("Merging it would remove %s. Apply the change?", pluralReminders(len(diff.Removed)))))
+	b.WriteString("\n\n")
+
+	writeDiff(&b, descriptions(diff.Removed), descriptions(diff.Added), descriptions(diff.Kept), limit)
+
+	sep := " " + glyphs.Bullet + " "
+	hint := "y apply" + sep + "n keep the reminders as they are"
+	if more := len(m.heldMerges) - 1; more > 0 {
+		hint += sep + fmt.Sprintf("%d more to review", more)
+	}
+	b.WriteString(inputHintStyle.Render(hint))
+	return b.String()
+}
+
+// writeDiff writes the descriptions of the reminders a file update
+// removes, adds, and keeps, up to limit of each
+func writeDiff(b *strings.Builder, removed, added, kept []string, limit int) {
+	section := func(title, sign string, style lipgloss.Style, descs []string) {
+		b.WriteString(inputLabelStyle.Render(fmt.Sprintf("%s (%d)", title, len(descs))))
+		b.WriteString("\n")
+		for i, desc := range descs {
+			if i == limit {
+				b.WriteString(inputHintStyle.Render(fmt.Sprintf("  %s and %d more", glyphs.Ellipsis, len(descs)-limit)))
+				b.WriteString("\n")
+				break
+			}
+			b.WriteString(style.Render("  " + sign + " " + desc))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+	section("Removed", "-", triggeredStyle, removed)
+	section("Added", "+", selectedItemStyle, added)
+	section("Kept", " ", sourceStyle, kept)
+}
+
+// descriptions returns the reminders' descriptions
+func descriptions(rs []*reminder.Reminder) []string {
+	descs := make([]string, len(rs))
+	for i, r := range rs {
+		descs[i] = r.Description
+	}
+	return descs
+}
+
+// logMerge records what msg changes, or would have if it weren't
+// rejected, in the merge log
+func (m *Model) logMerge(msg FileUpdateMsg, rejected bool) {
+	diff := m.fileDiff(msg)
+	m.mergeLog = append(m.mergeLog, mergeRecord{
+		at:       m.now(),
+		file:     msg.FilePath,
+		added:    descriptions(diff.Added),
+		removed:  descriptions(diff.Removed),
+		kept:     descriptions(diff.Kept),
+		rejected: rejected,
+	})
+	if len(m.mergeLog) > mergeLogMax {
+		m.mergeLog = m.mergeLog[len(m.mergeLog)-mergeLogMax:]
+	}
+}
+
+// openMergeLog shows the merge log, newest first
+func (m *Model) openMergeLog() {
+	m.mergeLogIndex = 0
+	m.mode = modeMergeLog
+}
+
+func (m Model) updateMergeLogMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.Type == tea.KeyEscape, msg.String() == "q", key.Matches(msg, keys.MergeLog):
+		m.mode = modeNormal
+	case key.Matches(msg, keys.Up):
+		if m.mergeLogIndex > 0 {
+			m.mergeLogIndex--
+		}
+	case key.Matches(msg, keys.Down):
+		if m.mergeLogIndex < len(m.mergeLog)-1 {
+			m.mergeLogIndex++
+		}
+	}
+	return m, nil
+}
+
+// mergeLogView lists the file updates merged this session, newest first,
+// with what the selected one added, removed, and kept
+func (m Model) mergeLogView() string {
+	var b strings.Builder
+	b.WriteString(inputLabelStyle.Render("Merge log"))
+	b.WriteString(inputHintStyle.Render("  (file updates this session, newest first)"))
+	b.WriteString("\n\n")
+
+	if len(m.mergeLog) == 0 {
+		b.WriteString(inputHintStyle.Render("No files have been updated yet"))
+		b.WriteString("\n\n")
+		b.WriteString(inputHintStyle.Render("Press esc to close"))
+		return b.String()
+	}
+
+	// A window of updates around the selected one, then its details
+	rows := max((m.height-12)/3, 3)
+	first := max(min(m.mergeLogIndex-rows/2, len(m.mergeLog)-rows), 0)
+	for i := first; i < len(m.mergeLog) && i < first+rows; i++ {
+		rec := m.mergeLog[len(m.mergeLog)-1-i]
+		cursor := "  "
+		style := normalStyle
+		if i == m.mergeLogIndex {
+			cursor = glyphs.Cursor + " "
+			style = selectedItemStyle
+		}
+		b.WriteString(cursor + sourceStyle.Render(rec.at.Format("15:04:05")) + "  " + style.Render(filepath.Base(rec.file)) + "  " + mergeSummary(rec))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	rec := m.mergeLog[len(m.mergeLog)-1-m.mergeLogIndex]
+	b.WriteString(inputHintStyle.Render(rec.file))
+	b.WriteString("\n\n")
+	writeDiff(&b, rec.removed, rec.added, rec.kept, max((m.height-rows-14)/3, 3))
+
+	sep := " " + glyphs.Bullet + " "
+	b.WriteString(inputHintStyle.Render(fmt.Sprintf("%d of %d", m.mergeLogIndex+1, len(m.mergeLog)) + sep + glyphs.Up + "/" + glyphs.Down + " another update" + sep + "esc to close"))
+	return b.String()
+}
+
+// mergeSummary counts what a file update changed
+func mergeSummary(rec mergeRecord) string {
+	counts := fmt.Sprintf("%d added, %d removed, %d kept", len(rec.added), len(rec.removed), len(rec.kept))
+	switch {
+	case rec.rejected:
+		return triggeredStyle.Render(counts + ", not applied")
+	case len(rec.removed) > 0:
+		return triggeredStyle.Render(counts)
+	default:
+		return sourceStyle.Render(counts)
+	}
+}
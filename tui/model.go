@@ -5,11 +5,21 @@ import (
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 
-	"go_remind/reminder"
-	"go_remind/state"
+	"go_remind/config"
+	"go_remind/conflicts"
+	"go_remind/daemon"
+	"go_remind/dedupe"
+	"go_remind/pkg/clock"
+	"go_remind/pkg/reminder"
+	"go_remind/pkg/state"
+	"go_remind/pkg/watcher"
+	"go_remind/sources"
+	"go_remind/statesync"
 )
 
 // Input modes
@@ -21,6 +31,30 @@ const (
 	modeAdd
 	modeTheme
 	modeDetail
+	modeDigest
+	modePaste
+	modeCheatsheet
+	modeStats
+	modeOrphans
+	modeDuplicates
+	modeProfiles
+	modeReschedule
+	modeShift
+	modeCommand
+	modeSlots
+	modeSources
+	modeActivity
+	modeSearch
+	modeFocus
+	modeAlert
+	modeMuted
+	modeTimeline
+	modeWorkspaces
+	modeMergePreview
+	modeMergeLog
+	modeWatcherHealth
+	modePeople
+	modeAway
 )
 
 // TickMsg is sent every second to check for triggered reminders
@@ -30,14 +64,25 @@ type TickMsg time.Time
 type FileUpdateMsg struct {
 	FilePath  string
 	Reminders []*reminder.Reminder
+	RulesErr  error // The rules script failed on some of the reminders
+}
+
+// Store persists reminders; satisfied by *state.Store and *daemon.Client
+type Store interface {
+	Save(reminders []*reminder.Reminder) error
 }
 
 // Model is the Bubble Tea model for the reminder TUI
 type Model struct {
 	list          list.Model
 	reminders     []*reminder.Reminder
+	files         *reminder.FileIndex // reminders by source file, for merges
 	watcherEvents <-chan FileUpdateMsg
-	store         *state.Store
+	store         Store
+	saver         *saver         // nil without a store
+	daemon        *daemon.Client // non-nil when the daemon owns the state
+	config        *config.Config
+	clock         clock.Clock
 	pendingDelete bool
 	pendingG      bool
 	width         int
@@ -54,40 +99,211 @@ type Model struct {
 
 	// Sorting
 	sortEnabled bool
+	grouping    grouping  // What the sorted views are divided by
+	sortOrder   sortOrder // Order within each section
+	lowEnergy   bool      // Easy reminders first within each section
+
+	focusID string // Reminder the focus view counts down to
+
+	// Alerts for reminders that triggered while the TUI was open
+	alertsEnabled bool
+	alerts        []string // IDs, oldest first
+	missed        []string // IDs of the reminders that came due while the TUI was closed
+
+	// Terminal title and bell
+	bellEnabled   bool
+	title         string          // Last title set
+	seenTriggered map[string]bool // IDs triggered as of the last check; nil before the first
 
 	// Input handling
 	mode            inputMode
 	filterInput     textinput.Model
-	addInput        textinput.Model
+	dateRange       dateRange // Narrows the list along with the filter
+	triggeredReturn *listView // How the list was before showing just the triggered reminders
+	addInput        textarea.Model
 	inputError      string
 	editingReminder *reminder.Reminder // non-nil when editing an existing reminder
+	conflictWarned  string             // Input already warned about double-booking
 
 	// Theme picker
 	themeIndex    int
 	previewTheme  int
 	originalTheme int
+	plainTheme    int                     // Theme to restore when high contrast is turned off
+	themeEvents   <-chan ThemesChangedMsg // nil unless user themes are watched
 
 	// Detail view
-	detailReminder *reminder.Reminder
-	detailScroll   int
+	detailReminder  *reminder.Reminder
+	detailScroll    int
+	detailTab       int            // detailTabInfo or detailTabHistory
+	detailChanges   []state.Change // The reminder's history, loaded on its tab
+	detailChangesID string         // Whose history detailChanges is
+	detailSource    sourcePreview  // The reminder's file, read on its tab
+
+	// Split pane: the list with a live detail pane beside it
+	splitPane bool // Turned on; only shown when the terminal is wide enough
+
+	// Date picker for rescheduling
+	picker         datePicker
+	pickerReminder *reminder.Reminder
+	pickerReturn   inputMode // Mode to go back to on close
+
+	// Bulk shift of the reminders in view
+	shiftInput textinput.Model
+	lastBulk   []changedReminder // For undo, after a bulk shift or command
+
+	// Search, which moves the cursor between matches without filtering
+	searchInput textinput.Model
+	searchFrom  int // Cursor position when the search was opened
+
+	// Command mode
+	commandInput textinput.Model
+	command      *bulkCommand // Waiting to be confirmed
+
+	// Free slot finder, opened from add mode
+	slotInput textinput.Model
+	slots     []conflicts.Slot
+	slotIndex int
+
+	// Keybinding cheatsheet
+	helpSearch textinput.Model
+	helpReturn inputMode // Mode to go back to on close
+	helpScroll int
+
+	// Paste-to-add preview
+	pasteReminders []*reminder.Reminder
+
+	// Daily digest
+	nextDigest time.Time
+
+	// Automatic cleanup rules
+	nextCleanup time.Time
+
+	// Reminders whose source file was deleted
+	missingSources map[string]bool
+	nextOrphanScan time.Time
+	orphanIndex    int // Selected file in the orphans panel
+	repointInput   textinput.Model
+
+	// Reminders deleted from files, kept out when the files are parsed again
+	mutes      MuteStore // nil without a local state store
+	muted      []state.Mute
+	mutedIndex int // Selected in the muted panel
+
+	peopleIndex int // Selected in the People view
+
+	// What's typed into the add or edit box, saved as a draft until it's
+	// saved or cancelled
+	drafts      DraftStore  // nil without a local state store
+	draft       state.Draft // Offered back when the box opens
+	draftPrompt bool        // Asking whether to restore draft
+	draftSaved  string      // The box's text when last saved
+	draftOwned  bool        // Whether the saved draft is this box's, to clear when it closes
+
+	// What was entered into the add and filter boxes, for up, down, and ctrl+r
+	inputHistory  InputHistoryStore // nil without a local state store
+	addHistory    inputHistory
+	filterHistory inputHistory
+
+	// The tags the timeline can show, and which it's showing
+	timelineTags  []string
+	timelineIndex int
+
+	// File updates that remove enough reminders to ask first, oldest first
+	heldMerges []FileUpdateMsg
+
+	// What each file update changed this session, oldest first
+	mergeLog      []mergeRecord
+	mergeLogIndex int // Selected in the merge log, counting from the newest
+
+	// Positions jumped from, oldest first, and where jumpBack and
+	// jumpForward have moved to among them; len(jumps) when at neither
+	jumps     []jump
+	jumpIndex int
+
+	// Marked reminders' IDs by letter, and whether the letter after m or '
+	// is awaited
+	marks           map[rune]string
+	pendingMark     bool
+	pendingGotoMark bool
+
+	// The file watchers' health by watched path, how many problems they'd
+	// had at the last check, and whether running out of watches was told
+	watcherHealth    func() map[string]watcher.Health
+	watcherProblems  int
+	watchLimitWarned bool
+
+	// Files whose tokens were rewritten after an edit, and when
+	wroteBack map[string]time.Time
+
+	// Why relative reminders couldn't all be placed, last time they were
+	relativeErr string
+
+	// The same reminder copied into several files
+	dupeCheckDue   bool // Look for duplicates on the next tick
+	dupesAnnounced int
+	ignoredDupes   map[string]bool // Keys of groups the user said aren't duplicates
+	ignoredStore   IgnoredDuplicatesStore
+	dupeGroups     []dedupe.Group
+	dupeIndex      int
+
+	// Profiles
+	profile      string   // Active profile's name
+	profiles     []string // Every profile, the default first
+	profileIndex int      // Selected in the switcher
+	nextProfile  string   // Set when quitting to switch profiles
+
+	// Workspaces, kept in m.config
+	workspacePath  string // Config file new ones are saved to
+	workspaceIndex int    // Selected in the switcher
+	workspaceInput textinput.Model
+
+	// Stats view
+	history    *state.History   // nil without a local state store
+	ackHistory []state.AckEvent // Loaded when the stats view opens
+
+	// Activity view
+	activity        *state.ActivityLog // nil without a local state store
+	activityChanges []state.Change     // Loaded when the activity view opens
+	activityScroll  int
+
+	// Sync between machines
+	syncer       statesync.Syncer
+	syncInterval time.Duration
+	nextSync     time.Time
+	syncing      bool
+
+	// Sources of reminders: remote ones such as issue trackers, and
+	// watched files and directories
+	remoteSources  []sources.Source
+	sourceInterval time.Duration
+	watched        []watchedPath
+	parsePath      func(path string) InitialParseMsg
+	sourceStates   map[string]*sourceState // By source name or watched path
+	sourceSettings SourceSettingsStore     // nil without a local state store
+	viewSettings   ViewSettingsStore       // nil without a local state store
+	pendingSelect  string                  // ID of the saved selection, until it's restored
+	sourceIndex    int                     // Selected in the sources view
 
 	// Help
 	help help.Model
 	keys keyMap
 
-	// Status message (shown after actions)
-	statusMessage     string
-	statusMessageTime time.Time
+	// Toasts (shown after actions)
+	toasts []toast
+
+	// Background operations shown with a spinner
+	progress   chan ProgressMsg
+	operations []ProgressMsg
+	spinner    spinner.Model
 }
 
 // New creates a new TUI model with the given reminders
-func New(reminders []*reminder.Reminder, watcherEvents <-chan FileUpdateMsg, store *state.Store) Model {
+func New(reminders []*reminder.Reminder, watcherEvents <-chan FileUpdateMsg, store Store) Model {
 	// Apply default theme
 	themes[0].applyStyles()
 
-	items := remindersToItems(reminders)
-
-	l := list.New(items, itemDelegate{}, 80, 20)
+	l := list.New(nil, itemDelegate{}, 80, 20)
 	l.Title = ""
 	l.Styles.Title = titleStyle
 	l.SetShowStatusBar(false)
@@ -100,36 +316,120 @@ func New(reminders []*reminder.Reminder, watcherEvents <-chan FileUpdateMsg, sto
 	fi.CharLimit = 100
 	fi.Width = 40
 
-	// Add reminder input
-	ai := textinput.New()
-	ai.Placeholder = "+1h Call mom  or  Jan 15 2:30pm Meeting"
-	ai.CharLimit = 200
-	ai.Width = 50
-
 	h := help.New()
+	h.ShortSeparator = " " + glyphs.Bullet + " "
+	h.Ellipsis = glyphs.Ellipsis
+
+	sp := spinner.New()
+	sp.Spinner = glyphs.Spinner
+
+	progress := make(chan ProgressMsg, 32)
+	var sv *saver
+	if store != nil {
+		sv = newSaver(store, saveDelay, progress)
+	}
+
+	m := Model{
+		list:           l,
+		reminders:      reminders,
+		files:          new(reminder.FileIndex),
+		watcherEvents:  watcherEvents,
+		store:          store,
+		saver:          sv,
+		config:         config.Default(),
+		clock:          clock.Real,
+		mode:           modeNormal,
+		filterInput:    fi,
+		addInput:       newAddInput(),
+		help:           h,
+		keys:           keys,
+		sortEnabled:    true,
+		progress:       progress,
+		helpSearch:     newCheatsheetSearch(),
+		repointInput:   newRepointInput(),
+		shiftInput:     newShiftInput(),
+		workspaceInput: newWorkspaceInput(),
+		commandInput:   newCommandInput(),
+		searchInput:    newSearchInput(),
+		slotInput:      newSlotInput(),
+		dupeCheckDue:   true,
+		ignoredDupes:   make(map[string]bool),
+		spinner:        sp,
+	}
+	m.placeRelative()
+	return m
+}
+
+// placeRelative places relative reminders and lists every reminder, before
+// the first render; any that can't be placed are toasted on the first
+// refresh
+func (m *Model) placeRelative() {
+	_ = reminder.ResolveRelative(m.reminders, m.now())
+	m.list.SetItems(remindersToItems(m.reminders))
+}
 
-	return Model{
-		list:          l,
-		reminders:     reminders,
-		watcherEvents: watcherEvents,
-		store:         store,
-		mode:          modeNormal,
-		filterInput:   fi,
-		addInput:      ai,
-		help:          h,
-		keys:          keys,
-		sortEnabled:   true,
+// now returns the current time from the model's clock
+func (m Model) now() time.Time {
+	return m.clock.Now()
+}
+
+// WithClock returns a copy of the model that reads the time from c, for
+// tests and reproducible rendering. A store that keeps time, such as
+// *state.Store, is given c too.
+func (m Model) WithClock(c clock.Clock) Model {
+	m.clock = c
+	if s, ok := m.store.(interface{ SetClock(clock.Clock) }); ok {
+		s.SetClock(c)
 	}
+	m.placeRelative()
+	return m
+}
+
+// WithConfig returns a copy of the model using the given user configuration
+func (m Model) WithConfig(cfg *config.Config) Model {
+	m.config = cfg
+	m.useConfigTheme()
+	m.setHighContrast(cfg.UI.HighContrast)
+	m.splitPane = cfg.UI.SplitPane
+	m.alertsEnabled = cfg.UI.Alerts
+	m.bellEnabled = cfg.UI.Bell
+	m.layoutList()
+	m.scheduleDigest(m.now())
+	m.scheduleCleanup(m.now())
+	return m
+}
+
+// WithHistory returns a copy of the model whose stats view reads
+// acknowledgments from h
+func (m Model) WithHistory(h *state.History) Model {
+	m.history = h
+	return m
+}
+
+// Reminders returns the model's current reminders
+func (m Model) Reminders() []*reminder.Reminder {
+	return m.reminders
 }
 
 // Init initializes the model and starts the tick timer
 func (m Model) Init() tea.Cmd {
 	cmds := []tea.Cmd{
+		startupCmd,
 		tickCmd(),
+		m.waitForProgress(),
 	}
 	if m.watcherEvents != nil {
 		cmds = append(cmds, m.waitForFileUpdate())
 	}
+	if m.themeEvents != nil {
+		cmds = append(cmds, m.waitForThemeChange())
+	}
+	if m.daemon != nil {
+		cmds = append(cmds, m.waitForDaemonState(), m.waitForDaemonError())
+	}
+	if m.saver != nil {
+		cmds = append(cmds, m.waitForSaveError())
+	}
 	return tea.Batch(cmds...)
 }
 
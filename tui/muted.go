@@ -0,0 +1,150 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"go_remind/pkg/reminder"
+	"go_remind/pkg/state"
+)
+
+// MuteStore keeps the reminders deleted from files between sessions;
+// satisfied by *state.Store
+type MuteStore interface {
+	LoadMuted() ([]state.Mute, error)
+	SaveMuted(mutes []state.Mute) error
+}
+
+// WithMutes returns a copy of the model that keeps reminders deleted from
+// files out when the files are parsed again, saving them to store
+func (m Model) WithMutes(store MuteStore) Model {
+	m.mutes = store
+	m.loadMuted()
+	return m
+}
+
+// loadMuted reads the mutes again, as a daemon may have changed them
+func (m *Model) loadMuted() {
+	if m.mutes == nil {
+		return
+	}
+	mutes, err := m.mutes.LoadMuted()
+	if err != nil {
+		m.toastError("Could not load muted reminders: " + err.Error())
+		return
+	}
+	m.muted = mutes
+}
+
+// saveMuted saves the mutes
+func (m *Model) saveMuted() {
+	if m.mutes == nil {
+		return
+	}
+	if err := m.mutes.SaveMuted(m.muted); err != nil {
+		m.toastError("Could not save muted reminders: " + err.Error())
+	}
+}
+
+// mute keeps r, which is being deleted, from coming back the next time its
+// file is parsed
+func (m *Model) mute(r *reminder.Reminder) {
+	if !r.FromFile() {
+		return
+	}
+	m.loadMuted()
+	m.muted = append(m.muted, state.MuteReminder(r, m.now()))
+	m.saveMuted()
+}
+
+// dropMuted leaves the muted reminders out of those parsed from files,
+// forgetting mutes whose text has changed
+func (m *Model) dropMuted(parsed []*reminder.Reminder, files ...string) []*reminder.Reminder {
+	kept, left := state.DropMuted(m.muted, parsed, files...)
+	if len(left) != len(m.muted) {
+		m.muted = left
+		m.saveMuted()
+	}
+	return kept
+}
+
+// openMuted shows the muted reminders panel
+func (m *Model) openMuted() {
+	m.loadMuted()
+	if len(m.muted) == 0 {
+		m.toastInfo("No muted reminders")
+		return
+	}
+	m.mutedIndex = 0
+	m.mode = modeMuted
+}
+
+func (m Model) updateMutedMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.String() == "esc", msg.String() == "q", key.Matches(msg, keys.Muted):
+		m.mode = modeNormal
+	case msg.String() == "u", msg.Type == tea.KeyEnter:
+		cmd := m.unmute(m.mutedIndex)
+		if len(m.muted) == 0 {
+			m.mode = modeNormal
+		} else if m.mutedIndex >= len(m.muted) {
+			m.mutedIndex = len(m.muted) - 1
+		}
+		return m, cmd
+	case key.Matches(msg, keys.Up):
+		if m.mutedIndex > 0 {
+			m.mutedIndex--
+		}
+	case key.Matches(msg, keys.Down):
+		if m.mutedIndex < len(m.muted)-1 {
+			m.mutedIndex++
+		}
+	}
+	return m, nil
+}
+
+// unmute forgets the i'th mute and parses its file again, which brings the
+// reminder back
+func (m *Model) unmute(i int) tea.Cmd {
+	mute := m.muted[i]
+	m.muted = slices.Delete(m.muted, i, i+1)
+	m.saveMuted()
+
+	if w, ok := m.watchedFor(absPath(mute.SourceFile)); ok {
+		m.toastSuccess("Unmuted: " + mute.Description)
+		return m.refreshPath(w.path)
+	}
+	m.toastSuccess("Unmuted: " + mute.Description + " (back when its file is next parsed)")
+	return nil
+}
+
+func (m Model) mutedView() string {
+	var b strings.Builder
+	b.WriteString(inputLabelStyle.Render(fmt.Sprintf("Muted reminders (%d)", len(m.muted))))
+	b.WriteString("\n")
+	b.WriteString(inputHintStyle.Render("Deleted, but still in their files:"))
+	b.WriteString("\n\n")
+
+	for i, mute := range m.muted {
+		cursor := "  "
+		style := normalStyle
+		if i == m.mutedIndex {
+			cursor = glyphs.Cursor + " "
+			style = selectedItemStyle
+		}
+		b.WriteString(cursor + style.Render(mute.Description) + sourceStyle.Render("  "+filepath.Base(mute.SourceFile)))
+		b.WriteString("\n")
+	}
+
+	sep := " " + glyphs.Bullet + " "
+	b.WriteString("\n")
+	b.WriteString(inputHintStyle.Render("u unmute" + sep + "esc to close"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modalBox(b.String()))
+}
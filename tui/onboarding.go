@@ -0,0 +1,244 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"go_remind/config"
+)
+
+// onboardingStep is a page of the first-run setup wizard
+type onboardingStep int
+
+const (
+	stepNotes onboardingStep = iota
+	stepTheme
+	stepNotifications
+	stepWorkHours
+)
+
+// Onboarding is the first-run setup wizard. It asks for the notes to watch,
+// a theme, notifications, and work hours, then writes the config file.
+type Onboarding struct {
+	configPath string
+	cfg        *config.Config
+	step       onboardingStep
+	notesInput textinput.Model
+	hoursInput textinput.Model
+	themeIndex int
+	err        string
+	done       bool
+	width      int
+	height     int
+}
+
+// NewOnboarding creates the setup wizard, starting from cfg and writing the
+// result to configPath
+func NewOnboarding(configPath string, cfg *config.Config) Onboarding {
+	themes[0].applyStyles()
+
+	notes := textinput.New()
+	notes.Placeholder = "~/notes (leave empty to skip)"
+	notes.CharLimit = 500
+	notes.Width = 50
+	notes.Focus()
+
+	hours := textinput.New()
+	hours.Placeholder = "09:00-17:00"
+	hours.CharLimit = 11
+	hours.Width = 20
+	hours.SetValue(cfg.WorkHours.Start + "-" + cfg.WorkHours.End)
+
+	setup := *cfg
+	return Onboarding{
+		configPath: configPath,
+		cfg:        &setup,
+		notesInput: notes,
+		hoursInput: hours,
+	}
+}
+
+// Config returns the config written by the wizard, or nil if it was
+// cancelled
+func (o Onboarding) Config() *config.Config {
+	if !o.done {
+		return nil
+	}
+	return o.cfg
+}
+
+func (o Onboarding) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (o Onboarding) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		o.width, o.height = msg.Width, msg.Height
+		return o, nil
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyCtrlC:
+			return o, tea.Quit
+		case tea.KeyEscape:
+			if o.step > stepNotes {
+				o.step--
+				o.err = ""
+				return o, o.focusStep()
+			}
+			return o, tea.Quit
+		case tea.KeyEnter:
+			return o.next()
+		}
+		return o.updateStep(msg)
+	}
+	return o, nil
+}
+
+// updateStep handles keys other than enter and esc on the current page
+func (o Onboarding) updateStep(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch o.step {
+	case stepNotes:
+		o.notesInput, cmd = o.notesInput.Update(msg)
+	case stepWorkHours:
+		o.hoursInput, cmd = o.hoursInput.Update(msg)
+	case stepTheme:
+		switch {
+		case msg.Type == tea.KeyUp, key.Matches(msg, keys.Up):
+			if o.themeIndex > 0 {
+				o.themeIndex--
+			}
+		case msg.Type == tea.KeyDown, key.Matches(msg, keys.Down):
+			if o.themeIndex < len(themes)-1 {
+				o.themeIndex++
+			}
+		}
+		themes[o.themeIndex].applyStyles()
+	case stepNotifications:
+		switch msg.String() {
+		case "y":
+			o.cfg.Notifications.Enabled = true
+		case "n":
+			o.cfg.Notifications.Enabled = false
+		case " ", "left", "right", "h", "l":
+			o.cfg.Notifications.Enabled = !o.cfg.Notifications.Enabled
+		}
+	}
+	return o, cmd
+}
+
+// next checks the answer on the current page and moves on, writing the
+// config after the last page
+func (o Onboarding) next() (tea.Model, tea.Cmd) {
+	o.err = ""
+	switch o.step {
+	case stepNotes:
+		o.cfg.Paths = nil
+		if path := strings.TrimSpace(o.notesInput.Value()); path != "" {
+			if _, err := os.Stat(config.ExpandPath(path)); err != nil {
+				o.err = fmt.Sprintf("no such file or directory: %s", path)
+				return o, nil
+			}
+			o.cfg.Paths = []string{path}
+		}
+	case stepTheme:
+		o.cfg.UI.Theme = themes[o.themeIndex].Name
+	case stepWorkHours:
+		start, end, ok := strings.Cut(strings.TrimSpace(o.hoursInput.Value()), "-")
+		o.cfg.WorkHours = config.WorkHoursConfig{Start: strings.TrimSpace(start), End: strings.TrimSpace(end)}
+		if !ok {
+			o.err = "enter hours as start-end, e.g. 09:00-17:00"
+			return o, nil
+		}
+		if err := config.WriteStarter(o.configPath, o.cfg); err != nil {
+			o.err = err.Error()
+			return o, nil
+		}
+		o.done = true
+		return o, tea.Quit
+	}
+	o.step++
+	return o, o.focusStep()
+}
+
+// focusStep focuses the text input of the current page, if it has one
+func (o *Onboarding) focusStep() tea.Cmd {
+	o.notesInput.Blur()
+	o.hoursInput.Blur()
+	switch o.step {
+	case stepNotes:
+		return o.notesInput.Focus()
+	case stepWorkHours:
+		return o.hoursInput.Focus()
+	}
+	return nil
+}
+
+func (o Onboarding) View() string {
+	var b strings.Builder
+	b.WriteString(welcomeTitleStyle.Render("Welcome to Go Remind Me!"))
+	b.WriteString("\n")
+	b.WriteString(inputHintStyle.Render(fmt.Sprintf("Setup %d of %d", o.step+1, stepWorkHours+1)))
+	b.WriteString("\n\n")
+
+	switch o.step {
+	case stepNotes:
+		b.WriteString(inputLabelStyle.Render("Which notes should be watched for reminders?"))
+		b.WriteString("\n\n")
+		b.WriteString(o.notesInput.View())
+		b.WriteString("\n\n")
+		b.WriteString(inputHintStyle.Render("A markdown file or a directory. Reminders look like [remind_me 3pm Call mom]."))
+	case stepTheme:
+		b.WriteString(inputLabelStyle.Render("Pick a theme"))
+		b.WriteString("\n\n")
+		for i, t := range themes {
+			cursor := "  "
+			name := normalStyle.Render(t.Name)
+			if i == o.themeIndex {
+				cursor = glyphs.Cursor + " "
+				name = selectedItemStyle.Render(t.Name)
+			}
+			b.WriteString(cursor + name + "\n")
+		}
+	case stepNotifications:
+		b.WriteString(inputLabelStyle.Render("Show desktop notifications when reminders are due?"))
+		b.WriteString("\n\n")
+		yes, no := normalStyle.Render("  yes  "), normalStyle.Render("  no  ")
+		if o.cfg.Notifications.Enabled {
+			yes = selectedItemStyle.Render(glyphs.Cursor + " yes")
+		} else {
+			no = selectedItemStyle.Render(glyphs.Cursor + " no")
+		}
+		b.WriteString(yes + "   " + no)
+		b.WriteString("\n\n")
+		b.WriteString(inputHintStyle.Render("y or n, or space to switch"))
+	case stepWorkHours:
+		b.WriteString(inputLabelStyle.Render("What are your work hours?"))
+		b.WriteString("\n\n")
+		b.WriteString(o.hoursInput.View())
+		b.WriteString("\n\n")
+		b.WriteString(inputHintStyle.Render("24h start-end, e.g. 09:00-17:00"))
+	}
+
+	if o.err != "" {
+		b.WriteString("\n\n")
+		b.WriteString(triggeredStyle.Render(glyphs.Warning + " " + o.err))
+	}
+
+	b.WriteString("\n\n")
+	sep := " " + glyphs.Bullet + " "
+	hint := "enter to continue" + sep + "esc to go back" + sep + "ctrl+c to skip setup"
+	if o.step == stepNotes {
+		hint = "enter to continue" + sep + "esc to skip setup"
+	}
+	b.WriteString(inputHintStyle.Render(hint))
+
+	return lipgloss.Place(o.width, o.height, lipgloss.Center, lipgloss.Center, modalBox(b.String()))
+}
@@ -0,0 +1,168 @@
+package tui
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"go_remind/pkg/reminder"
+)
+
+// LinkOpenedMsg reports that the opener for a link exited
+type LinkOpenedMsg struct {
+	target string
+	err    error
+}
+
+// openURL opens url in the default browser or app, or mail client for a
+// mid: link, with the TUI suspended until the opener exits, so nothing it
+// prints lands on the screen. Replaced in tests.
+var openURL = func(url string) tea.Cmd {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	return tea.ExecProcess(cmd, func(err error) tea.Msg { return LinkOpenedMsg{target: url, err: err} })
+}
+
+// isLink reports whether a reminder's source is a link, like the issue
+// or email it was pulled from
+func isLink(source string) bool {
+	return strings.HasPrefix(source, "https://") || strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "mid:")
+}
+
+// urlPattern matches the URLs in a reminder's text, up to a space or
+// whatever closes a markdown link
+var urlPattern = regexp.MustCompile(`(?:https?://|file://|mailto:)[^\s<>"'()\[\]]+`)
+
+// pathPrefixes start the file paths in a reminder's text
+var pathPrefixes = []string{"/", "~/", "./", "../"}
+
+// link is a URL or file path found in a reminder: its text, and what
+// opening it opens
+type link struct {
+	text   string
+	target string
+}
+
+// links returns the links in r: the page or email it was pulled from,
+// then the URLs and file paths in its description. Relative paths are
+// relative to its file.
+func links(r *reminder.Reminder) []link {
+	var ls []link
+	add := func(text, target string) {
+		if !slices.ContainsFunc(ls, func(l link) bool { return l.target == target }) {
+			ls = append(ls, link{text: text, target: target})
+		}
+	}
+	if isLink(r.SourceFile) {
+		add(r.SourceFile, r.SourceFile)
+	}
+	for _, url := range urlPattern.FindAllString(r.Description, -1) {
+		url = strings.TrimRight(url, ".,;:!?")
+		add(url, url)
+	}
+	for _, field := range strings.Fields(r.Description) {
+		path := strings.TrimRight(strings.TrimLeft(field, `("'<[`), `.,;:!?)"'>]`)
+		if len(path) < 2 || !slices.ContainsFunc(pathPrefixes, func(p string) bool { return strings.HasPrefix(path, p) }) {
+			continue
+		}
+		add(path, resolvePath(path, r))
+	}
+	return ls
+}
+
+// resolvePath expands a leading ~ in path, and makes a relative path
+// relative to r's file
+func resolvePath(path string, r *reminder.Reminder) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+		return path
+	}
+	if !filepath.IsAbs(path) && r.FromFile() {
+		return filepath.Join(filepath.Dir(r.SourceFile), path)
+	}
+	return path
+}
+
+// openLink opens r's link. With more than one, its detail view is opened
+// on the Links tab to pick one.
+func (m *Model) openLink(r *reminder.Reminder) tea.Cmd {
+	if r == nil {
+		return nil
+	}
+	ls := links(r)
+	switch {
+	case len(ls) == 0:
+		m.toastInfo("No link to open")
+		return nil
+	case len(ls) == 1:
+		return m.openTarget(ls[0].target)
+	}
+	if m.mode != modeDetail || m.detailReminder != r {
+		m.recordJump()
+		m.openDetail(r)
+	}
+	m.detailTab = detailTabLinks
+	m.detailScroll = 0
+	m.toastInfo(counted(len(ls), "link", "links") + ": pick one to open")
+	return nil
+}
+
+// openTarget opens a link's target
+func (m *Model) openTarget(target string) tea.Cmd {
+	m.toastInfo("Opening " + target)
+	return openURL(target)
+}
+
+// openSelectedLink opens the link selected on the detail view's Links tab
+func (m *Model) openSelectedLink() tea.Cmd {
+	if ls := links(m.detailReminder); m.detailScroll < len(ls) {
+		return m.openTarget(ls[m.detailScroll].target)
+	}
+	return nil
+}
+
+// detailLinksView renders the detail view's Links tab: the URLs and file
+// paths in r, one selected
+func (m Model) detailLinksView(r *reminder.Reminder) string {
+	ls := links(r)
+	if len(ls) == 0 {
+		return inputHintStyle.Render("No URLs or file paths in this reminder") + "\n"
+	}
+
+	var content strings.Builder
+	visible := max(m.height-15, 5)
+	start := max(0, min(m.detailScroll-visible+1, len(ls)-visible))
+	for i := start; i < len(ls) && i < start+visible; i++ {
+		l := ls[i]
+		cursor := "  "
+		style := normalStyle
+		if i == m.detailScroll {
+			cursor = glyphs.Cursor + " "
+			style = selectedItemStyle
+		}
+		content.WriteString(cursor + style.Render(l.text))
+		if l.target != l.text {
+			content.WriteString(sourceStyle.Render("  " + l.target))
+		}
+		content.WriteString("\n")
+	}
+	content.WriteString("\n")
+	content.WriteString(inputHintStyle.Render("enter or " + keys.OpenLink.Help().Key + " to open"))
+	content.WriteString("\n")
+	return content.String()
+}
@@ -0,0 +1,313 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"go_remind/config"
+	"go_remind/pkg/reminder"
+)
+
+// orphanScanInterval is how often source files are checked for deletion
+const orphanScanInterval = time.Minute
+
+// OrphanScanMsg reports which source files no longer exist
+type OrphanScanMsg struct {
+	missing map[string]bool
+}
+
+// checkOrphans starts a background scan for deleted source files if one is
+// due. The first scan happens on the first tick after startup.
+func (m *Model) checkOrphans(now time.Time) tea.Cmd {
+	if now.Before(m.nextOrphanScan) {
+		return nil
+	}
+	m.nextOrphanScan = now.Add(orphanScanInterval)
+
+	sources := make(map[string]bool)
+	for _, r := range m.reminders {
+		if filepath.IsAbs(r.SourceFile) {
+			sources[r.SourceFile] = true
+		}
+	}
+	return func() tea.Msg {
+		missing := make(map[string]bool)
+		for path := range sources {
+			if _, err := os.Stat(path); os.IsNotExist(err) {
+				missing[path] = true
+			}
+		}
+		return OrphanScanMsg{missing: missing}
+	}
+}
+
+// applyOrphanScan records the missing source files and announces any newly
+// orphaned reminders
+func (m *Model) applyOrphanScan(msg OrphanScanMsg) {
+	newlyMissing := 0
+	for path := range msg.missing {
+		if !m.missingSources[path] {
+			newlyMissing++
+		}
+	}
+	changed := newlyMissing > 0 || len(msg.missing) != len(m.missingSources)
+	m.missingSources = msg.missing
+	if !changed {
+		return
+	}
+	m.refreshList()
+	if newlyMissing > 0 {
+		m.toastInfo(fmt.Sprintf("%d orphaned reminder(s): source file deleted (press %s)",
+			len(m.orphans()), keys.Orphans.Help().Key))
+	}
+}
+
+// orphans returns the reminders whose source file no longer exists
+func (m Model) orphans() []*reminder.Reminder {
+	var orphaned []*reminder.Reminder
+	for _, r := range m.reminders {
+		if m.missingSources[r.SourceFile] {
+			orphaned = append(orphaned, r)
+		}
+	}
+	return orphaned
+}
+
+// orphanFiles returns the missing source files that still have reminders,
+// sorted by path
+func (m Model) orphanFiles() []string {
+	seen := make(map[string]bool)
+	var files []string
+	for _, r := range m.orphans() {
+		if !seen[r.SourceFile] {
+			seen[r.SourceFile] = true
+			files = append(files, r.SourceFile)
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+// openOrphans shows the orphaned reminders panel
+func (m *Model) openOrphans() {
+	if len(m.orphanFiles()) == 0 {
+		m.toastInfo("No orphaned reminders")
+		return
+	}
+	m.orphanIndex = 0
+	m.repointInput.Reset()
+	m.repointInput.Blur()
+	m.inputError = ""
+	m.mode = modeOrphans
+}
+
+func (m Model) updateOrphansMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.repointInput.Focused() {
+		return m.updateRepointInput(msg)
+	}
+
+	files := m.orphanFiles()
+	switch msg.String() {
+	case "esc", "q":
+		m.mode = modeNormal
+		return m, nil
+	case "s":
+		m.keepStandalone(files[m.orphanIndex : m.orphanIndex+1])
+	case "S":
+		m.keepStandalone(files)
+	case "d":
+		m.deleteOrphans(files[m.orphanIndex : m.orphanIndex+1])
+	case "D":
+		m.deleteOrphans(files)
+	case "r":
+		m.repointInput.SetValue(filepath.Dir(files[m.orphanIndex]) + string(filepath.Separator))
+		m.repointInput.CursorEnd()
+		return m, m.repointInput.Focus()
+	default:
+		switch {
+		case key.Matches(msg, keys.Up):
+			if m.orphanIndex > 0 {
+				m.orphanIndex--
+			}
+		case key.Matches(msg, keys.Down):
+			if m.orphanIndex < len(files)-1 {
+				m.orphanIndex++
+			}
+		case key.Matches(msg, keys.Orphans):
+			m.mode = modeNormal
+		}
+		return m, nil
+	}
+
+	m.closeOrphansIfDone()
+	return m, nil
+}
+
+func (m Model) updateRepointInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEscape:
+		m.repointInput.Blur()
+		m.inputError = ""
+		return m, nil
+	case tea.KeyEnter:
+		if err := m.repoint(m.orphanFiles()[m.orphanIndex], m.repointInput.Value()); err != nil {
+			m.inputError = err.Error()
+			return m, nil
+		}
+		m.repointInput.Blur()
+		m.inputError = ""
+		m.closeOrphansIfDone()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.repointInput, cmd = m.repointInput.Update(msg)
+	return m, cmd
+}
+
+// closeOrphansIfDone returns to the list once no orphans are left, and
+// otherwise keeps the selection in range
+func (m *Model) closeOrphansIfDone() {
+	files := m.orphanFiles()
+	if len(files) == 0 {
+		m.mode = modeNormal
+		return
+	}
+	if m.orphanIndex >= len(files) {
+		m.orphanIndex = len(files) - 1
+	}
+}
+
+// keepStandalone detaches the reminders of the given files so they no
+// longer belong to any file
+func (m *Model) keepStandalone(files []string) {
+	n := 0
+	for _, r := range m.reminders {
+		if containsString(files, r.SourceFile) {
+			r.SourceFile = reminder.StandaloneSource
+			r.Touch(m.now())
+			n++
+		}
+	}
+	m.refreshList()
+	m.saveState()
+	m.toastSuccess(fmt.Sprintf("Kept %d reminder(s) as standalone", n))
+}
+
+// deleteOrphans removes the reminders of the given files
+func (m *Model) deleteOrphans(files []string) {
+	var kept []*reminder.Reminder
+	n := 0
+	for _, r := range m.reminders {
+		if containsString(files, r.SourceFile) {
+			n++
+			continue
+		}
+		kept = append(kept, r)
+	}
+	m.reminders = kept
+	m.refreshList()
+	m.saveState()
+	m.toastInfo(fmt.Sprintf("Deleted %d orphaned reminder(s)", n))
+}
+
+// repoint moves the reminders of a deleted file to another file, e.g. the
+// one it was renamed to
+func (m *Model) repoint(from, to string) error {
+	to, err := filepath.Abs(config.ExpandPath(strings.TrimSpace(to)))
+	if err != nil {
+		return err
+	}
+	if info, err := os.Stat(to); err != nil || info.IsDir() {
+		return fmt.Errorf("no such file: %s", to)
+	}
+
+	n := 0
+	for _, r := range m.reminders {
+		if r.SourceFile == from {
+			r.SourceFile = to
+			r.Touch(m.now())
+			n++
+		}
+	}
+	m.files.Reset()
+	delete(m.missingSources, from)
+	m.refreshList()
+	m.saveState()
+	m.toastSuccess(fmt.Sprintf("Moved %d reminder(s) to %s", n, filepath.Base(to)))
+	return nil
+}
+
+// containsString reports whether s is in list
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// orphansView renders the missing source files and what can be done with
+// their reminders
+func (m Model) orphansView() string {
+	var b strings.Builder
+	b.WriteString(inputLabelStyle.Render(glyphs.Warning + " Orphaned reminders"))
+	b.WriteString("\n")
+	b.WriteString(inputHintStyle.Render("These files were deleted or moved:"))
+	b.WriteString("\n\n")
+
+	orphans := m.orphans()
+	for i, path := range m.orphanFiles() {
+		count := 0
+		for _, r := range orphans {
+			if r.SourceFile == path {
+				count++
+			}
+		}
+		cursor := "  "
+		style := normalStyle
+		if i == m.orphanIndex {
+			cursor = glyphs.Cursor + " "
+			style = selectedItemStyle
+		}
+		b.WriteString(cursor + style.Render(path) + sourceStyle.Render(fmt.Sprintf("  (%d)", count)))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	if m.repointInput.Focused() {
+		b.WriteString(inputLabelStyle.Render("New path: ") + m.repointInput.View())
+		b.WriteString("\n")
+		if m.inputError != "" {
+			b.WriteString(triggeredStyle.Render("  " + glyphs.Warning + " " + m.inputError))
+			b.WriteString("\n")
+		}
+		b.WriteString(inputHintStyle.Render("enter to move " + glyphs.Bullet + " esc to cancel"))
+	} else {
+		sep := " " + glyphs.Bullet + " "
+		b.WriteString(inputHintStyle.Render("s keep as standalone" + sep + "d delete" + sep + "r re-point to another file"))
+		b.WriteString("\n")
+		b.WriteString(inputHintStyle.Render("S/D for every file" + sep + "esc to close"))
+	}
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modalBox(b.String()))
+}
+
+// newRepointInput creates the path input of the orphans panel
+func newRepointInput() textinput.Model {
+	ti := textinput.New()
+	ti.Placeholder = "path to the file the reminders moved to"
+	ti.CharLimit = 500
+	ti.Width = 50
+	return ti
+}
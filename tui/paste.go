@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"go_remind/pkg/parser"
+	"go_remind/pkg/reminder"
+)
+
+// ClipboardMsg carries the clipboard contents read for paste-to-add
+type ClipboardMsg struct {
+	Text string
+	Err  error
+}
+
+// readClipboardCmd reads the system clipboard in the background
+func readClipboardCmd() tea.Cmd {
+	return func() tea.Msg {
+		text, err := clipboard.ReadAll()
+		return ClipboardMsg{Text: text, Err: err}
+	}
+}
+
+// applyClipboard parses pasted text and opens the confirmation preview
+func (m *Model) applyClipboard(msg ClipboardMsg) {
+	if msg.Err != nil {
+		m.toastError("Paste failed: " + msg.Err.Error())
+		return
+	}
+
+	parsed := parser.ParseText(msg.Text, m.now())
+	if len(parsed) == 0 {
+		m.toastInfo("No reminders found in clipboard")
+		return
+	}
+	m.pasteReminders = parsed
+	m.mode = modePaste
+}
+
+func (m Model) updatePasteMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter", "y":
+		m.addPasted()
+		m.mode = modeNormal
+	case "esc", "n", "q":
+		m.pasteReminders = nil
+		m.mode = modeNormal
+	}
+	return m, nil
+}
+
+// addPasted adds the previewed reminders to the list
+func (m *Model) addPasted() {
+	now := m.now()
+	for _, r := range m.pasteReminders {
+		r.ID = reminder.NewID()
+		r.SourceFile = reminder.StandaloneSource
+		r.UpdatedAt = now
+		r.CreatedAt = now
+		m.reminders = append(m.reminders, r)
+	}
+	reminder.SortByDateTime(m.reminders)
+	m.refreshList()
+	m.saveState()
+
+	if len(m.pasteReminders) == 1 {
+		m.toastSuccess("Added: " + m.pasteReminders[0].Description)
+	} else {
+		m.toastSuccess(fmt.Sprintf("Added %d reminders from clipboard", len(m.pasteReminders)))
+	}
+	m.pasteReminders = nil
+}
+
+// pasteView renders the preview of reminders parsed from the clipboard
+func (m Model) pasteView() string {
+	var b strings.Builder
+	b.WriteString(inputLabelStyle.Render(fmt.Sprintf("%s Add %d reminder(s) from clipboard?", glyphs.Paste, len(m.pasteReminders))))
+	b.WriteString("\n\n")
+
+	for _, r := range m.pasteReminders {
+		line := fmt.Sprintf("  %-20s %s", r.DateTime.Format("Mon Jan 2 3:04pm"), r.Description)
+		b.WriteString(normalStyle.Render(line))
+		if len(r.Tags) > 0 {
+			b.WriteString(" ")
+			b.WriteString(tagStyle.Render("#" + strings.Join(r.Tags, " #")))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(inputHintStyle.Render("enter/y to add " + glyphs.Bullet + " esc/n to cancel"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modalBox(b.String()))
+}
@@ -0,0 +1,151 @@
+package tui
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"go_remind/pkg/reminder"
+)
+
+// peopleShown is how many of the selected person's open reminders the
+// People view lists
+const peopleShown = 8
+
+// person is someone @mentioned in reminders
+type person struct {
+	mention string               // As first written, without the @
+	open    []*reminder.Reminder // Not yet acknowledged, soonest first
+	waiting int                  // How many of open are waiting on them
+}
+
+// displayName capitalizes a mention for display, e.g. "alice" as "Alice"
+func (p person) displayName() string {
+	r, size := utf8.DecodeRuneInString(p.mention)
+	return string(unicode.ToUpper(r)) + p.mention[size:]
+}
+
+// people returns everyone mentioned in a reminder, most open reminders
+// first. A name is the same person however it's capitalized.
+func (m Model) people() []person {
+	var ps []person
+	for _, r := range m.reminders {
+		for _, name := range r.Mentions() {
+			i := slices.IndexFunc(ps, func(p person) bool { return strings.EqualFold(p.mention, name) })
+			if i < 0 {
+				ps = append(ps, person{mention: name})
+				i = len(ps) - 1
+			}
+			if r.Status == reminder.Acknowledged {
+				continue
+			}
+			ps[i].open = append(ps[i].open, r)
+			if r.Status == reminder.Waiting {
+				ps[i].waiting++
+			}
+		}
+	}
+	for _, p := range ps {
+		reminder.SortByDateTime(p.open)
+	}
+	slices.SortStableFunc(ps, func(a, b person) int {
+		return cmp.Or(cmp.Compare(len(b.open), len(a.open)), cmp.Compare(strings.ToLower(a.mention), strings.ToLower(b.mention)))
+	})
+	return ps
+}
+
+// openPeople shows the People view
+func (m *Model) openPeople() {
+	if len(m.people()) == 0 {
+		m.toastInfo("No one is @mentioned in a reminder")
+		return
+	}
+	m.peopleIndex = 0
+	m.mode = modePeople
+}
+
+func (m Model) updatePeopleMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	ps := m.people()
+	switch {
+	case msg.String() == "esc", msg.String() == "q", key.Matches(msg, keys.People):
+		m.mode = modeNormal
+	case msg.Type == tea.KeyEnter:
+		m.mode = modeNormal
+		m.filterByPerson(ps[m.peopleIndex])
+	case key.Matches(msg, keys.Up):
+		if m.peopleIndex > 0 {
+			m.peopleIndex--
+		}
+	case key.Matches(msg, keys.Down):
+		if m.peopleIndex < len(ps)-1 {
+			m.peopleIndex++
+		}
+	}
+	return m, nil
+}
+
+// filterByPerson filters the list to the reminders mentioning p
+func (m *Model) filterByPerson(p person) {
+	m.filterInput.SetValue("@" + strings.ToLower(p.mention))
+	m.gridIndex, m.gridScroll = 0, 0
+	m.compactIndex, m.compactScroll = 0, 0
+	m.refreshList()
+	m.saveViewSettings()
+	m.toastInfo("Showing reminders mentioning " + p.displayName())
+}
+
+// peopleView lists everyone mentioned with how many open reminders
+// mention them, and the selected person's open reminders
+func (m Model) peopleView() string {
+	ps := m.people()
+	var b strings.Builder
+	b.WriteString(inputLabelStyle.Render(fmt.Sprintf("People (%d)", len(ps))))
+	b.WriteString("\n")
+	b.WriteString(inputHintStyle.Render("Everyone @mentioned in a reminder:"))
+	b.WriteString("\n\n")
+
+	for i, p := range ps {
+		cursor := "  "
+		style := normalStyle
+		if i == m.peopleIndex {
+			cursor = glyphs.Cursor + " "
+			style = selectedItemStyle
+		}
+		b.WriteString(cursor + style.Render(p.displayName()) + sourceStyle.Render("  "+counted(len(p.open), "open reminder", "open reminders")))
+		if p.waiting > 0 {
+			b.WriteString(waitingStyle.Render("  waiting on " + p.displayName() + ": " + counted(p.waiting, "item", "items")))
+		}
+		b.WriteString("\n")
+	}
+
+	selected := ps[m.peopleIndex]
+	b.WriteString("\n")
+	if len(selected.open) == 0 {
+		b.WriteString(inputHintStyle.Render("Nothing open mentions " + selected.displayName()))
+		b.WriteString("\n")
+	}
+	for i, r := range selected.open {
+		if i == peopleShown {
+			b.WriteString(inputHintStyle.Render(fmt.Sprintf("  %s and %d more", glyphs.Ellipsis, len(selected.open)-i)))
+			b.WriteString("\n")
+			break
+		}
+		line := statusStyle(r.Status).Render(statusGlyph(r.Status)) + " " + sourceStyle.Render(r.DateTime.Format("Jan 2 15:04")) + "  "
+		b.WriteString("  " + line + normalStyle.Render(ansi.Truncate(r.Description, max(m.width-40, 20), glyphs.Ellipsis)))
+		b.WriteString("\n")
+	}
+
+	sep := " " + glyphs.Bullet + " "
+	b.WriteString("\n")
+	b.WriteString(inputHintStyle.Render("enter to filter by them" + sep + "esc to close"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modalBox(b.String()))
+}
@@ -0,0 +1,137 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"go_remind/pkg/parser"
+	"go_remind/pkg/reminder"
+)
+
+// sourcePreviewContext is how many lines of its file the Source tab shows
+// above and below a reminder
+const sourcePreviewContext = 5
+
+// sourcePreview is the part of a reminder's file around its token, read
+// when the Source tab is shown
+type sourcePreview struct {
+	id         string // Whose file it is
+	path       string
+	lines      []string // Without line endings
+	first      int      // The line number of lines[0]
+	line       int      // The reminder's line number; 0 if it wasn't found
+	start, end int      // The token's byte offsets in its line; equal for a task line
+	err        error
+}
+
+// loadDetailSource reads the lines around the detail reminder from its
+// file if the Source tab is shown. The token is looked for by its
+// description, since the file may have changed since it was parsed.
+func (m *Model) loadDetailSource() {
+	r := m.detailReminder
+	if m.detailTab != detailTabSource || r == nil || !r.FromFile() {
+		return
+	}
+	p := sourcePreview{id: r.ID, path: r.SourceFile}
+	defer func() { m.detailSource = p }()
+
+	data, err := os.ReadFile(r.SourceFile)
+	if err != nil {
+		p.err = err
+		return
+	}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimSuffix(lines[i], "\r")
+	}
+	i, start, end, ok := parser.FindToken(lines, r.LineNumber, r.Description, m.now())
+	switch {
+	case ok:
+		p.line, p.start, p.end = i+1, start, end
+	case r.LineNumber > 0 && r.LineNumber <= len(lines):
+		p.line = r.LineNumber // A task line, or the token changed
+	default:
+		p.lines = lines[:min(len(lines), 2*sourcePreviewContext+1)]
+		p.first = 1
+		return
+	}
+	from := max(p.line-1-sourcePreviewContext, 0)
+	p.lines = lines[from:min(p.line+sourcePreviewContext, len(lines))]
+	p.first = from + 1
+}
+
+// reloadDetailSource reads the Source tab's file again when it changes
+func (m *Model) reloadDetailSource(path string) {
+	if m.detailSource.path == path {
+		m.loadDetailSource()
+	}
+}
+
+// detailSourceView renders the detail view's Source tab: the lines around
+// r in its file, with its token highlighted
+func (m Model) detailSourceView(r *reminder.Reminder, cardWidth int) string {
+	switch {
+	case !r.FromFile():
+		if r.Source != "" {
+			return inputHintStyle.Render("Pulled from "+r.Source+", so there's no file to show") + "\n"
+		}
+		return inputHintStyle.Render("Added in the TUI, so there's no file to show") + "\n"
+	case m.detailSource.id != r.ID:
+		// The split pane follows the cursor without reading files
+		return inputHintStyle.Render("Press "+keys.Detail.Help().Key+" to load its file") + "\n"
+	case m.detailSource.err != nil:
+		return triggeredStyle.Render(glyphs.Warning+" Could not read "+r.SourceFile+": "+m.detailSource.err.Error()) + "\n"
+	}
+
+	p := m.detailSource
+	var b strings.Builder
+	gutter := len(fmt.Sprint(p.first + len(p.lines)))
+	width := max(cardWidth-4-gutter-3, 10)
+	for i, line := range p.lines {
+		n := p.first + i
+		b.WriteString(sourceStyle.Render(fmt.Sprintf("%*d %s ", gutter, n, glyphs.Bullet)))
+		if n == p.line {
+			b.WriteString(highlightToken(line, p.start, p.end, width))
+		} else {
+			b.WriteString(normalStyle.Render(ansi.Truncate(detab(line), width, glyphs.Ellipsis)))
+		}
+		b.WriteString("\n")
+	}
+	if p.line == 0 {
+		b.WriteString("\n")
+		b.WriteString(inputHintStyle.Render("Its token is no longer in the file"))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// highlightToken renders a reminder's line cut to width, its token from
+// byte start to end highlighted, or the whole line for a task line
+func highlightToken(line string, start, end, width int) string {
+	if start == end {
+		return selectedItemStyle.Render(ansi.Truncate(detab(line), width, glyphs.Ellipsis))
+	}
+	var b strings.Builder
+	for i, part := range []string{detab(line[:start]), line[start:end], detab(line[end:])} {
+		if width <= 0 {
+			break
+		}
+		part = ansi.Truncate(part, width, glyphs.Ellipsis)
+		width -= ansi.StringWidth(part)
+		if i == 1 {
+			b.WriteString(selectedItemStyle.Reverse(true).Render(part))
+		} else {
+			b.WriteString(normalStyle.Render(part))
+		}
+	}
+	return b.String()
+}
+
+// detab expands tabs, which would otherwise be drawn at the terminal's
+// width for them
+func detab(s string) string {
+	return strings.ReplaceAll(s, "\t", "    ")
+}
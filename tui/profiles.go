@@ -0,0 +1,92 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"go_remind/config"
+)
+
+// WithProfiles returns a copy of the model running the active profile, with
+// the others offered in the profile switcher. An empty active name is the
+// default profile.
+func (m Model) WithProfiles(active string, names []string) Model {
+	if active == "" {
+		active = config.DefaultProfile
+	}
+	m.profile = active
+	m.profiles = append([]string{config.DefaultProfile}, names...)
+	return m
+}
+
+// SwitchProfile returns the profile picked in the switcher when the TUI
+// quit to change to it, or "" if it just quit
+func (m Model) SwitchProfile() string {
+	return m.nextProfile
+}
+
+// openProfiles shows the profile switcher
+func (m *Model) openProfiles() {
+	if len(m.profiles) < 2 {
+		m.toastInfo("No profiles configured")
+		return
+	}
+	m.profileIndex = 0
+	for i, name := range m.profiles {
+		if name == m.profile {
+			m.profileIndex = i
+		}
+	}
+	m.mode = modeProfiles
+}
+
+func (m Model) updateProfilesMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.Type == tea.KeyEscape, key.Matches(msg, keys.Profiles):
+		m.mode = modeNormal
+	case msg.Type == tea.KeyEnter:
+		m.mode = modeNormal
+		if name := m.profiles[m.profileIndex]; name != m.profile {
+			m.nextProfile = name
+			m.saveViewSettings()
+			return m, tea.Quit
+		}
+	case key.Matches(msg, keys.Up):
+		if m.profileIndex > 0 {
+			m.profileIndex--
+		}
+	case key.Matches(msg, keys.Down):
+		if m.profileIndex < len(m.profiles)-1 {
+			m.profileIndex++
+		}
+	}
+	return m, nil
+}
+
+// profilesView renders the profile switcher
+func (m Model) profilesView() string {
+	var b strings.Builder
+	b.WriteString(inputLabelStyle.Render(glyphs.Profile + " Switch Profile"))
+	b.WriteString(inputHintStyle.Render("  (" + keys.Up.Help().Key + " " + keys.Down.Help().Key + " to choose, enter to switch, esc to cancel)"))
+	b.WriteString("\n\n")
+
+	for i, name := range m.profiles {
+		cursor := "  "
+		if i == m.profileIndex {
+			cursor = glyphs.Cursor + " "
+		}
+		label := name
+		if name == m.profile {
+			label += " (current)"
+		}
+		if i == m.profileIndex {
+			label = selectedItemStyle.Render(label)
+		} else {
+			label = normalStyle.Render(label)
+		}
+		b.WriteString(cursor + label + "\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,126 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"go_remind/pkg/reminder"
+)
+
+// ProgressMsg reports the state of a long-running background operation.
+// Operations send one with Finished set when they are done. The TUI's own
+// operations report on an internal channel; callers outside the package
+// deliver them with tea.Program.Send.
+type ProgressMsg struct {
+	Op       string // Identifies the operation, e.g. "save"
+	Label    string // Shown next to the spinner, e.g. "Parsing notes"
+	Done     int    // Units completed so far
+	Total    int    // Total units, or 0 if unknown
+	Finished bool
+}
+
+// InitialParseMsg delivers reminders parsed from a watched path after
+// startup, so the TUI can open before a large directory is parsed, or when
+// the path is refreshed in the sources view
+type InitialParseMsg struct {
+	Path      string // The watched file or directory
+	Reminders []*reminder.Reminder
+	Err       error
+	RulesErr  error // The rules script failed on some of the reminders
+}
+
+// reportProgress sends progress without blocking; if the UI is behind, the
+// update is dropped rather than stalling the operation
+func (m *Model) reportProgress(msg ProgressMsg) {
+	select {
+	case m.progress <- msg:
+	default:
+	}
+}
+
+// waitForProgress waits for the next progress report
+func (m Model) waitForProgress() tea.Cmd {
+	return func() tea.Msg {
+		return <-m.progress
+	}
+}
+
+// applyProgress records a progress report. Returns a command that starts the
+// spinner when the first operation begins.
+func (m *Model) applyProgress(msg ProgressMsg) tea.Cmd {
+	wasIdle := len(m.operations) == 0
+
+	for i, op := range m.operations {
+		if op.Op != msg.Op {
+			continue
+		}
+		if msg.Finished {
+			m.operations = append(m.operations[:i], m.operations[i+1:]...)
+		} else {
+			m.operations[i] = msg
+		}
+		return nil
+	}
+	if msg.Finished {
+		return nil
+	}
+
+	m.operations = append(m.operations, msg)
+	if wasIdle {
+		return m.spinner.Tick
+	}
+	return nil
+}
+
+// updateSpinner advances the spinner while any operation is running
+func (m *Model) updateSpinner(msg spinner.TickMsg) tea.Cmd {
+	if len(m.operations) == 0 {
+		return nil // Stop ticking until the next operation starts
+	}
+	var cmd tea.Cmd
+	m.spinner, cmd = m.spinner.Update(msg)
+	return cmd
+}
+
+// applyInitialParse merges reminders parsed at startup, or on a refresh,
+// into the current state
+func (m *Model) applyInitialParse(msg InitialParseMsg) {
+	if !m.takeInitialParse(msg) {
+		return
+	}
+	if msg.Err != nil {
+		m.toastError("Parsing failed: " + msg.Err.Error())
+	}
+	if msg.RulesErr != nil {
+		m.toastError("Rules: " + msg.RulesErr.Error())
+	}
+	if len(msg.Reminders) == 0 {
+		return
+	}
+	m.reminders = reminder.MergeParsed(m.reminders, m.dropMuted(msg.Reminders, msg.Path))
+	reminder.SortByDateTime(m.reminders)
+	m.refreshList()
+	m.saveState()
+	m.scheduleCleanup(m.now()) // Check the newly loaded reminders on the next tick
+	m.dupeCheckDue = true
+	m.toastInfo(fmt.Sprintf("Loaded %d reminders from files", len(msg.Reminders)))
+}
+
+// progressSegment renders the spinner and running operations for the status bar
+func (m Model) progressSegment() string {
+	if len(m.operations) == 0 {
+		return ""
+	}
+	var labels []string
+	for _, op := range m.operations {
+		label := op.Label
+		if op.Total > 0 {
+			label += fmt.Sprintf(" %d/%d", op.Done, op.Total)
+		}
+		labels = append(labels, label)
+	}
+	return m.spinner.View() + inputLabelStyle.Render(strings.Join(labels, ", ")+glyphs.Ellipsis)
+}
@@ -0,0 +1,142 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"go_remind/config"
+	"go_remind/pkg/clock"
+	"go_remind/pkg/parser"
+	"go_remind/pkg/reminder"
+)
+
+// QuickCapture is a minimal TUI with just the add box and a live preview
+// of the reminder it will add, for a tmux popup or dropdown terminal bound
+// to a hotkey. It exits as soon as a reminder is entered.
+type QuickCapture struct {
+	input textinput.Model
+	clock clock.Clock
+	added *reminder.Reminder
+	err   string
+}
+
+// NewQuickCapture creates the quick capture box, in the theme named in cfg
+func NewQuickCapture(cfg *config.Config) QuickCapture {
+	themes[themeIndexByName(cfg.UI.Theme)].applyStyles()
+
+	input := textinput.New()
+	input.Placeholder = "+1h Call mom #family"
+	input.CharLimit = 500
+	input.Width = 60
+	input.Focus()
+	return QuickCapture{input: input, clock: clock.Real}
+}
+
+// WithClock returns a copy that reads the time from c, for tests
+func (q QuickCapture) WithClock(c clock.Clock) QuickCapture {
+	q.clock = c
+	return q
+}
+
+// Reminder returns the reminder entered, or nil if capture was cancelled
+func (q QuickCapture) Reminder() *reminder.Reminder {
+	return q.added
+}
+
+func (q QuickCapture) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (q QuickCapture) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		q.input.Width = max(msg.Width-len(q.input.Prompt)-8, 20)
+		return q, nil
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyCtrlC, tea.KeyEscape:
+			return q, tea.Quit
+		case tea.KeyEnter:
+			r, err := q.parse()
+			if err != nil {
+				q.err = err.Error()
+				return q, nil
+			}
+			q.added = r
+			return q, tea.Quit
+		}
+	}
+	var cmd tea.Cmd
+	q.input, cmd = q.input.Update(msg)
+	q.err = ""
+	return q, cmd
+}
+
+// parse reads the input as a reminder, the same as the TUI's add box
+func (q QuickCapture) parse() (*reminder.Reminder, error) {
+	r, err := parser.ParseEntry(strings.TrimSpace(q.input.Value()), q.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	r.ID = reminder.NewID()
+	r.SourceFile = reminder.StandaloneSource
+	return r, nil
+}
+
+func (q QuickCapture) View() string {
+	if q.added != nil {
+		return "" // Leave nothing behind in the terminal
+	}
+
+	var b strings.Builder
+	b.WriteString(inputBoxStyle.Render(inputLabelStyle.Render(glyphs.Add+" Remind me: ") + q.input.View()))
+	b.WriteString("\n")
+	b.WriteString(q.previewView())
+	b.WriteString("\n")
+	b.WriteString(inputHintStyle.Render("  enter to add " + glyphs.Bullet + " esc to cancel"))
+	return b.String()
+}
+
+// previewView shows what the input would add: when it's due, the
+// description, and any tags, estimate, and effort
+func (q QuickCapture) previewView() string {
+	if q.err != "" {
+		return triggeredStyle.Render("  " + glyphs.Warning + " " + q.err)
+	}
+	if strings.TrimSpace(q.input.Value()) == "" {
+		return inputHintStyle.Render("  <time> <description>, e.g. tomorrow 9am Standup")
+	}
+	r, err := q.parse()
+	if err != nil {
+		return sourceStyle.Render("  " + glyphs.Calendar + " no time yet")
+	}
+
+	line := "  " + glyphs.Calendar + " " + r.DateTime.Format("Mon Jan 2 at 3:04 PM") + " " + glyphs.Dot + " " + formatRelativeDue(r.DateTime.Sub(q.clock.Now()))
+	var details []string
+	for _, tag := range r.Tags {
+		details = append(details, tagStyle.Render("#"+tag))
+	}
+	if r.Estimate > 0 {
+		details = append(details, sourceStyle.Render("~"+parser.FormatEstimate(r.Estimate)))
+	}
+	if r.Effort != reminder.EffortNone {
+		details = append(details, sourceStyle.Render("^"+r.Effort.String()))
+	}
+	preview := selectedItemStyle.Render(line) + "  " + normalStyle.Render(r.Description)
+	if len(details) > 0 {
+		preview += "  " + strings.Join(details, " ")
+	}
+	return preview
+}
+
+// formatRelativeDue describes how far off a due time is, e.g. "in 2h 5m"
+// or "3m ago"
+func formatRelativeDue(d time.Duration) string {
+	if d < 0 {
+		return formatCountdown(-d) + " ago"
+	}
+	return "in " + formatCountdown(d)
+}
@@ -0,0 +1,22 @@
+package tui
+
+import (
+	"strings"
+
+	"go_remind/pkg/reminder"
+)
+
+// resolveRelative moves reminders with relative times to follow the
+// reminders they're relative to, toasting when some can't be placed. The
+// same problem is only toasted once.
+func (m *Model) resolveRelative() {
+	err := reminder.ResolveRelative(m.reminders, m.now())
+	msg := ""
+	if err != nil {
+		msg = strings.ReplaceAll(err.Error(), "\n", "; ")
+	}
+	if msg != m.relativeErr && msg != "" {
+		m.toastError("Can't place " + msg)
+	}
+	m.relativeErr = msg
+}
@@ -0,0 +1,54 @@
+package tui
+
+import (
+	"errors"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"go_remind/daemon"
+	"go_remind/pkg/reminder"
+	"go_remind/statesync"
+)
+
+var errDaemonDisconnected = errors.New("disconnected, changes are no longer saved")
+
+// DaemonStateMsg is sent when the daemon pushes a new state
+type DaemonStateMsg []*reminder.Reminder
+
+// DaemonErrorMsg is sent when the daemon rejects a request
+type DaemonErrorMsg struct{ err error }
+
+// WithDaemon returns a copy of the model that reads and saves its state
+// through a daemon connection instead of a local store
+func (m Model) WithDaemon(c *daemon.Client) Model {
+	m.daemon = c
+	m.store = c
+	m.saver = newSaver(c, saveDelay, m.progress)
+	return m
+}
+
+// waitForDaemonState waits for the next state pushed by the daemon
+func (m Model) waitForDaemonState() tea.Cmd {
+	return func() tea.Msg {
+		reminders, ok := <-m.daemon.Updates()
+		if !ok {
+			return DaemonErrorMsg{err: errDaemonDisconnected}
+		}
+		return DaemonStateMsg(reminders)
+	}
+}
+
+// waitForDaemonError waits for the next error reported by the daemon
+func (m Model) waitForDaemonError() tea.Cmd {
+	return func() tea.Msg {
+		return DaemonErrorMsg{err: <-m.daemon.Errors()}
+	}
+}
+
+// applyDaemonState merges a pushed state, keeping local changes the daemon
+// hasn't seen yet
+func (m *Model) applyDaemonState(msg DaemonStateMsg) {
+	m.reminders = statesync.Merge(m.daemon.Base(), m.reminders, msg)
+	m.daemon.SetBase(msg)
+	m.refreshList()
+}
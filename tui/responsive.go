@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"go_remind/pkg/reminder"
+)
+
+// Width breakpoints below which the views drop or shrink detail
+const (
+	narrowWidth = 100 // Compact lines drop the source column and abbreviate status
+	tinyWidth   = 60  // Compact lines use a short date; cards stack their metadata
+)
+
+// Card sizes in the grid
+const (
+	maxCardWidth = 38
+	minCardWidth = 20
+	cardGutter   = 2 // Border and right margin around each card
+)
+
+// shortStatusNames abbreviate reminder states on narrow terminals
+var shortStatusNames = map[reminder.Status]string{
+	reminder.Pending:      "pend",
+	reminder.Triggered:    "DUE",
+	reminder.Acknowledged: "done",
+	reminder.Snoozed:      "snz",
+	reminder.Waiting:      "wait",
+	reminder.Someday:      "smdy",
+}
+
+// statusLabel returns the status text for the given terminal width
+func statusLabel(status reminder.Status, width int) string {
+	if width > 0 && width < narrowWidth {
+		return shortStatusNames[status]
+	}
+	return status.String()
+}
+
+// titled returns the description prefixed with one "!" per priority level,
+// so reminders a rules script marked urgent stand out in the list
+func titled(r *reminder.Reminder) string {
+	if r.Priority == reminder.PriorityNone {
+		return r.Description
+	}
+	return strings.Repeat("!", int(r.Priority)) + " " + r.Description
+}
+
+// fitLines wraps text at spaces into at most n lines width columns wide,
+// breaking words too long for a line, and cuts the last line short with
+// an ellipsis when the text doesn't fit. It reports whether it did.
+func fitLines(text string, width, n int) (lines []string, cut bool) {
+	lines = strings.Split(ansi.Wrap(text, max(width, 1), ""), "\n")
+	if len(lines) <= n {
+		return lines, false
+	}
+	lines[n-1] = ansi.Truncate(lines[n-1]+" "+lines[n], width, glyphs.Ellipsis)
+	return lines[:n], true
+}
+
+// cardWidthFor returns the card width that fits the terminal, shrinking
+// cards below the usual width when not even one fits
+func cardWidthFor(width int) int {
+	if width == 0 {
+		return maxCardWidth
+	}
+	w := width - 4 - cardGutter
+	if w > maxCardWidth {
+		return maxCardWidth
+	}
+	if w < minCardWidth {
+		return minCardWidth
+	}
+	return w
+}
+
+// gridColumnsFor returns how many cards fit side by side
+func gridColumnsFor(width int) int {
+	cols := (width - 4) / (maxCardWidth + cardGutter)
+	if cols < 1 {
+		return 1
+	}
+	return cols
+}
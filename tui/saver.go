@@ -0,0 +1,119 @@
+package tui
+
+import (
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"go_remind/pkg/reminder"
+)
+
+// saveDelay is how long the state is left unsaved after a change, so a
+// burst of changes is written once
+const saveDelay = 500 * time.Millisecond
+
+// SaveErrorMsg is sent when writing the state fails
+type SaveErrorMsg struct{ err error }
+
+// saver writes the state in the background. Requests are debounced until
+// saveDelay after the last one, only the latest state is written, and
+// writes never overlap.
+type saver struct {
+	store    Store
+	delay    time.Duration
+	progress chan<- ProgressMsg
+	errors   chan error
+
+	mu      sync.Mutex
+	pending []*reminder.Reminder // Latest state not yet written, nil if none
+	timer   *time.Timer
+
+	writing sync.Mutex // Held while the store is being written
+}
+
+func newSaver(store Store, delay time.Duration, progress chan<- ProgressMsg) *saver {
+	return &saver{
+		store:    store,
+		delay:    delay,
+		progress: progress,
+		errors:   make(chan error, 1),
+	}
+}
+
+// request schedules reminders to be saved, replacing any state still
+// waiting. The reminders are copied, so later changes in the UI don't race
+// with the write.
+func (s *saver) request(reminders []*reminder.Reminder) {
+	snapshot := make([]*reminder.Reminder, len(reminders))
+	for i, r := range reminders {
+		c := *r
+		c.Tags = append([]string(nil), r.Tags...)
+		snapshot[i] = &c
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pending = snapshot
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+	s.timer = time.AfterFunc(s.delay, func() {
+		if err := s.flush(); err != nil {
+			s.report(err)
+		}
+	})
+}
+
+// flush writes the waiting state now, if there is one
+func (s *saver) flush() error {
+	s.writing.Lock()
+	defer s.writing.Unlock()
+
+	s.mu.Lock()
+	reminders := s.pending
+	s.pending = nil
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+	s.mu.Unlock()
+	if reminders == nil {
+		return nil
+	}
+
+	s.sendProgress(ProgressMsg{Op: "save", Label: "Saving"})
+	defer s.sendProgress(ProgressMsg{Op: "save", Finished: true})
+	return s.store.Save(reminders)
+}
+
+// report passes a failed write to the UI, dropping it if an earlier failure
+// hasn't been shown yet
+func (s *saver) report(err error) {
+	select {
+	case s.errors <- err:
+	default:
+	}
+}
+
+func (s *saver) sendProgress(msg ProgressMsg) {
+	select {
+	case s.progress <- msg:
+	default:
+	}
+}
+
+// waitForSaveError waits for the next failed write
+func (m Model) waitForSaveError() tea.Cmd {
+	return func() tea.Msg {
+		return SaveErrorMsg{err: <-m.saver.errors}
+	}
+}
+
+// FlushState writes any change still waiting to be saved. Call it once the
+// TUI has quit so the last changes aren't lost.
+func (m Model) FlushState() error {
+	if m.saver == nil {
+		return nil
+	}
+	return m.saver.flush()
+}
@@ -0,0 +1,202 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func newSearchInput() textinput.Model {
+	ti := textinput.New()
+	ti.Placeholder = "text to find..."
+	ti.CharLimit = 100
+	ti.Width = 40
+	return ti
+}
+
+// openSearch starts search mode. Unlike the filter, a search leaves every
+// reminder in the list and moves the cursor between the ones that match.
+func (m *Model) openSearch() tea.Cmd {
+	m.searchInput.Reset()
+	m.searchFrom = m.cursorIndex()
+	m.recordJump()
+	m.mode = modeSearch
+	return m.searchInput.Focus()
+}
+
+func (m Model) updateSearchMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEscape:
+		m.mode = modeNormal
+		m.searchInput.Blur()
+		m.clearSearch()
+		return m, nil
+	case tea.KeyEnter:
+		m.mode = modeNormal
+		m.searchInput.Blur()
+		if m.searchInput.Value() != "" && len(m.searchMatches()) == 0 {
+			m.toastInfo("No matches for " + m.searchInput.Value())
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.searchInput, cmd = m.searchInput.Update(msg)
+	m.refreshHighlight()
+	// Follow the first match after where the search started, as it's typed
+	m.selectIndex(m.searchFrom)
+	if m.searchInput.Value() != "" {
+		m.jumpToMatch(0)
+	}
+	return m, cmd
+}
+
+// searching reports whether a search was applied and n/N move between its
+// matches
+func (m Model) searching() bool {
+	return m.mode == modeNormal && m.searchInput.Value() != ""
+}
+
+// clearSearch ends the search, leaving the cursor where it is
+func (m *Model) clearSearch() {
+	m.searchInput.Reset()
+	m.refreshHighlight()
+}
+
+// refreshHighlight passes the text to highlight to the list's delegate
+func (m *Model) refreshHighlight() {
+	m.list.SetDelegate(itemDelegate{query: m.highlightQuery(), now: m.now})
+}
+
+// highlightQuery is the text to highlight in descriptions: the search, or
+// else the text filter. Tag filters match tags, not descriptions, so they
+// aren't highlighted.
+func (m Model) highlightQuery() string {
+	if q := m.searchInput.Value(); q != "" {
+		return strings.ToLower(q)
+	}
+	if q := m.filterInput.Value(); !strings.HasPrefix(q, "#") {
+		return strings.ToLower(q)
+	}
+	return ""
+}
+
+// searchMatches returns the positions in the list of the reminders whose
+// description, or the headings it's under, contains the search
+func (m Model) searchMatches() []int {
+	query := strings.ToLower(m.searchInput.Value())
+	var matches []int
+	for i, r := range m.getFilteredReminders() {
+		if strings.Contains(strings.ToLower(r.Description), query) || strings.Contains(strings.ToLower(r.Context), query) {
+			matches = append(matches, i)
+		}
+	}
+	return matches
+}
+
+// jumpToMatch moves the cursor to the next match after it for step 1, the
+// previous one for -1, wrapping around the list, or to the first match at
+// or after it for 0
+func (m *Model) jumpToMatch(step int) {
+	matches := m.searchMatches()
+	if len(matches) == 0 {
+		return
+	}
+	current := m.cursorIndex()
+	pick := -1
+	switch {
+	case step < 0:
+		pick = len(matches) - 1 // Wrap to the last match
+		for i := len(matches) - 1; i >= 0; i-- {
+			if matches[i] < current {
+				pick = i
+				break
+			}
+		}
+	default:
+		pick = 0 // Wrap to the first match
+		for i, idx := range matches {
+			if idx > current || (step == 0 && idx == current) {
+				pick = i
+				break
+			}
+		}
+	}
+	m.selectIndex(matches[pick])
+	if step != 0 {
+		m.toastInfo(fmt.Sprintf("Match %d of %d", pick+1, len(matches)))
+	}
+}
+
+// cursorIndex returns the position of the cursor in the current layout
+func (m Model) cursorIndex() int {
+	if currentLayout == LayoutCard {
+		return m.gridIndex
+	} else if m.sortEnabled {
+		return m.compactIndex
+	}
+	return m.list.Index()
+}
+
+// selectIndex moves the cursor to position i in the current layout
+func (m *Model) selectIndex(i int) {
+	if currentLayout == LayoutCard {
+		m.gridIndex = i
+		m.scrollToSelection()
+	} else if m.sortEnabled {
+		m.compactIndex = i
+		m.scrollToSelection()
+	} else {
+		m.list.Select(i)
+	}
+}
+
+// highlightMatches renders line in base with every case-insensitive
+// occurrence of query at or after byte offset from reversed
+func highlightMatches(line string, from int, query string, base lipgloss.Style) string {
+	if query == "" || from > len(line) {
+		return base.Render(line)
+	}
+	lower := strings.ToLower(line)
+	if len(lower) != len(line) {
+		// Lowercasing changed the byte offsets, so they can't be mapped back
+		return base.Render(line)
+	}
+	match := base.Reverse(true)
+	var b strings.Builder
+	start := 0
+	for i := from; ; {
+		j := strings.Index(lower[i:], query)
+		if j < 0 {
+			break
+		}
+		j += i
+		if j > start {
+			b.WriteString(base.Render(line[start:j]))
+		}
+		b.WriteString(match.Render(line[j : j+len(query)]))
+		start = j + len(query)
+		i = start
+	}
+	if start == 0 {
+		return base.Render(line)
+	}
+	if start < len(line) {
+		b.WriteString(base.Render(line[start:]))
+	}
+	return b.String()
+}
+
+// searchView renders the search box
+func (m Model) searchView() string {
+	label := inputLabelStyle.Render(glyphs.Search + " Find: ")
+	hint := inputHintStyle.Render("  (enter to keep, then n/N for next/previous; esc to clear)")
+	status := ""
+	if m.searchInput.Value() != "" {
+		status = inputHintStyle.Render(fmt.Sprintf("  %d found", len(m.searchMatches())))
+	}
+	return inputBoxStyle.Render(label + m.searchInput.View() + status + hint)
+}
@@ -0,0 +1,245 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"go_remind/pkg/reminder"
+)
+
+// sectionTitles are the headings of the sorted views, in display order.
+// Orphaned, waiting, and someday reminders are listed last whatever their
+// time.
+var sectionTitles = []string{
+	"Due",
+	"Coming Up!",
+	"Tomorrow",
+	"Later This Week",
+	"Next Week",
+	"Later This Month",
+	"Next Month & Beyond",
+	"Orphaned",
+	"Waiting",
+	"Someday",
+}
+
+// Sections not decided by date
+const (
+	orphanedSection = 7
+	waitingSection  = 8
+	somedaySection  = 9
+)
+
+// sectionBounds are the times that divide the sorted views into sections
+type sectionBounds struct {
+	now          time.Time
+	todayEnd     time.Time
+	tomorrowEnd  time.Time
+	thisWeekEnd  time.Time // Weeks end on Saturday
+	nextWeekEnd  time.Time
+	thisMonthEnd time.Time
+}
+
+func newSectionBounds(now time.Time) sectionBounds {
+	todayEnd := time.Date(now.Year(), now.Month(), now.Day(), 23, 59, 59, 0, now.Location())
+	daysUntilEndOfWeek := (7 - int(now.Weekday())) % 7
+	thisWeekEnd := time.Date(now.Year(), now.Month(), now.Day()+daysUntilEndOfWeek, 23, 59, 59, 0, now.Location())
+	return sectionBounds{
+		now:          now,
+		todayEnd:     todayEnd,
+		tomorrowEnd:  todayEnd.Add(24 * time.Hour),
+		thisWeekEnd:  thisWeekEnd,
+		nextWeekEnd:  thisWeekEnd.Add(7 * 24 * time.Hour),
+		thisMonthEnd: time.Date(now.Year(), now.Month()+1, 0, 23, 59, 59, 0, now.Location()),
+	}
+}
+
+// section returns the index in sectionTitles that r is listed under
+func (b sectionBounds) section(r *reminder.Reminder) int {
+	switch {
+	case r.Status == reminder.Waiting:
+		return waitingSection
+	case r.Status == reminder.Someday:
+		return somedaySection
+	case r.DateTime.Before(b.now):
+		return 0
+	case r.DateTime.Before(b.todayEnd):
+		return 1
+	case r.DateTime.Before(b.tomorrowEnd):
+		return 2
+	case r.DateTime.Before(b.thisWeekEnd):
+		return 3
+	case r.DateTime.Before(b.nextWeekEnd):
+		return 4
+	case r.DateTime.Before(b.thisMonthEnd):
+		return 5
+	default:
+		return 6
+	}
+}
+
+// grouping is what the sorted views divide reminders by
+type grouping int
+
+const (
+	groupByTime grouping = iota
+	groupByFile
+	groupByTag
+	groupByPriority
+)
+
+// groupingNames are shown in the status bar, in the order the group key
+// cycles through them
+var groupingNames = []string{"time", "file", "tag", "priority"}
+
+// Headings of reminders with nothing to group them by
+const (
+	noFileGroup     = "No File"
+	untaggedGroup   = "Untagged"
+	noPriorityGroup = "No Priority"
+)
+
+// groupSections splits items into the sections of the current grouping,
+// keeping their order within each, and returns them with their titles.
+// Sections may be empty.
+func (m Model) groupSections(items []*reminder.Reminder, now time.Time) ([][]*reminder.Reminder, []string) {
+	switch m.grouping {
+	case groupByFile:
+		return groupByKey(items, func(r *reminder.Reminder) string { return r.SourceFile }, noFileGroup)
+	case groupByTag:
+		// A reminder is listed once, under its first tag, so the sections
+		// still split the list into runs
+		return groupByKey(items, func(r *reminder.Reminder) string {
+			if len(r.Tags) == 0 {
+				return ""
+			}
+			return "#" + r.Tags[0]
+		}, untaggedGroup)
+	case groupByPriority:
+		return m.groupByPriority(items)
+	}
+
+	bounds := newSectionBounds(now)
+	groups := make([][]*reminder.Reminder, len(sectionTitles))
+	for _, r := range items {
+		i := bounds.section(r)
+		if m.missingSources[r.SourceFile] {
+			i = orphanedSection
+		}
+		groups[i] = append(groups[i], r)
+	}
+	return groups, sectionTitles
+}
+
+// groupByKey splits items by key, with sections in alphabetical order.
+// Items whose key is "" go last, under fallback.
+func groupByKey(items []*reminder.Reminder, key func(*reminder.Reminder) string, fallback string) ([][]*reminder.Reminder, []string) {
+	byKey := make(map[string][]*reminder.Reminder)
+	for _, r := range items {
+		k := key(r)
+		byKey[k] = append(byKey[k], r)
+	}
+	titles := make([]string, 0, len(byKey))
+	for k := range byKey {
+		if k != "" {
+			titles = append(titles, k)
+		}
+	}
+	sort.Slice(titles, func(i, j int) bool { return strings.ToLower(titles[i]) < strings.ToLower(titles[j]) })
+
+	groups := make([][]*reminder.Reminder, 0, len(byKey))
+	for _, k := range titles {
+		groups = append(groups, byKey[k])
+	}
+	if rest, ok := byKey[""]; ok {
+		groups = append(groups, rest)
+		titles = append(titles, fallback)
+	}
+	return groups, titles
+}
+
+// groupByPriority splits items by priority, highest first
+func (m Model) groupByPriority(items []*reminder.Reminder) ([][]*reminder.Reminder, []string) {
+	levels := []reminder.Priority{reminder.PriorityHigh, reminder.PriorityMedium, reminder.PriorityLow, reminder.PriorityNone}
+	groups := make([][]*reminder.Reminder, len(levels))
+	titles := make([]string, len(levels))
+	for i, p := range levels {
+		titles[i] = noPriorityGroup
+		if p != reminder.PriorityNone {
+			titles[i] = strings.ToUpper(p.String()[:1]) + p.String()[1:]
+		}
+		for _, r := range items {
+			if r.Priority == p {
+				groups[i] = append(groups[i], r)
+			}
+		}
+	}
+	return groups, titles
+}
+
+// sectionHeader renders the heading of a section: its title, how many
+// reminders it holds, and how many of those are still unacknowledged
+func sectionHeader(style lipgloss.Style, title string, group []*reminder.Reminder) string {
+	text := fmt.Sprintf("%s (%d", title, len(group))
+	n := unacknowledged(group)
+	if n == 0 {
+		return style.Render(text + ")")
+	}
+	plain := style.UnsetMargins()
+	return style.Render(plain.Render(text+", ") + triggeredStyle.Bold(true).Render(fmt.Sprintf("%d unacknowledged", n)) + plain.Render(")"))
+}
+
+// unacknowledged counts the triggered reminders in group
+func unacknowledged(group []*reminder.Reminder) int {
+	n := 0
+	for _, r := range group {
+		if r.Status == reminder.Triggered {
+			n++
+		}
+	}
+	return n
+}
+
+// rollupView renders the line above the sorted views summing up every
+// section, so the load shows without scrolling
+func (m Model) rollupView(groups [][]*reminder.Reminder, titles []string) string {
+	var parts []string
+	total := 0
+	for i, group := range groups {
+		if len(group) > 0 {
+			parts = append(parts, fmt.Sprintf("%s %d", titles[i], len(group)))
+			total += unacknowledged(group)
+		}
+	}
+	line := sourceStyle.Render(strings.Join(parts, " "+glyphs.Dot+" "))
+	if total > 0 {
+		line = triggeredStyle.Bold(true).Render(fmt.Sprintf("%d unacknowledged", total)) + sourceStyle.Render(" "+glyphs.Dot+" ") + line
+	}
+	width := m.listWidth() - appStyle.GetHorizontalPadding()
+	return ansi.Truncate(line, max(width, 20), glyphs.Ellipsis)
+}
+
+// cycleGrouping switches the sorted views to the next grouping
+func (m *Model) cycleGrouping() {
+	m.grouping = (m.grouping + 1) % grouping(len(groupingNames))
+	m.gridIndex, m.gridScroll = 0, 0
+	m.compactIndex, m.compactScroll = 0, 0
+	m.sortEnabled = true // Groups only show in the sorted views
+	m.saveViewSettings()
+	m.toastInfo("Group by " + groupingNames[m.grouping])
+}
+
+// sectionOrder returns items in the order the sorted views list them
+func (m Model) sectionOrder(items []*reminder.Reminder, now time.Time) []*reminder.Reminder {
+	ordered := make([]*reminder.Reminder, 0, len(items))
+	groups, _ := m.groupSections(items, now)
+	for _, group := range groups {
+		ordered = append(ordered, group...)
+	}
+	return ordered
+}
@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"slices"
+
+	"go_remind/pkg/state"
+)
+
+// ViewSettingsStore keeps how the list was arranged between sessions;
+// satisfied by *state.Store
+type ViewSettingsStore interface {
+	LoadViewSettings() (state.ViewSettings, error)
+	SaveViewSettings(settings state.ViewSettings) error
+}
+
+// WithViewSettings returns a copy of the model that starts the way the last
+// session ended: sort order, grouping, layout, theme, filter, scroll
+// offsets, and selected reminder. It saves them to store as they change and
+// on quit.
+func (m Model) WithViewSettings(store ViewSettingsStore) Model {
+	m.viewSettings = store
+	settings, err := store.LoadViewSettings()
+	if err != nil {
+		m.toastError("Could not load view settings: " + err.Error())
+		return m
+	}
+	if i := slices.Index(sortOrderNames, settings.Sort); i >= 0 {
+		m.sortOrder = sortOrder(i)
+	}
+	if i := slices.Index(groupingNames, settings.Group); i >= 0 {
+		m.grouping = grouping(i)
+	}
+	if i := slices.Index(layoutNames, settings.Layout); i >= 0 {
+		currentLayout = LayoutMode(i)
+	}
+	m.restoreTheme(settings.Theme)
+	m.sortEnabled = !settings.Unsorted
+	if i := slices.Index(dateRangeNames, settings.DateRange); i >= 0 {
+		m.dateRange = dateRange(i)
+	}
+	m.filterInput.SetValue(settings.Filter)
+	m.gridScroll, m.compactScroll = settings.GridScroll, settings.CompactScroll
+	m.pendingSelect = settings.Selected
+	m.refreshList()
+	m.restoreSelection()
+	return m
+}
+
+// restoreTheme switches to the saved theme, if it's still loaded
+func (m *Model) restoreTheme(name string) {
+	i := themeIndexByName(name)
+	if name == "" || themes[i].Name != name {
+		return
+	}
+	if name == highContrastTheme {
+		m.setHighContrast(true)
+		return
+	}
+	m.setHighContrast(false)
+	m.themeIndex = i
+	themes[i].applyStyles()
+}
+
+// restoreSelection moves the cursor back to the reminder selected when the
+// last session ended. The reminders and window size may both arrive after
+// startup, so it waits until both have.
+func (m *Model) restoreSelection() {
+	if m.pendingSelect == "" || m.height == 0 {
+		return
+	}
+	r := m.reminderByID(m.pendingSelect)
+	if r == nil {
+		return
+	}
+	m.pendingSelect = ""
+	m.selectReminder(r)
+}
+
+// saveViewSettings saves how the list is arranged for the next session
+func (m *Model) saveViewSettings() {
+	if m.viewSettings == nil {
+		return
+	}
+	settings := state.ViewSettings{
+		Sort:          sortOrderNames[m.sortOrder],
+		Group:         groupingNames[m.grouping],
+		Unsorted:      !m.sortEnabled,
+		Layout:        layoutNames[currentLayout],
+		Theme:         themes[m.themeIndex].Name,
+		Filter:        m.filterInput.Value(),
+		GridScroll:    m.gridScroll,
+		CompactScroll: m.compactScroll,
+	}
+	if m.dateRange != rangeAny {
+		settings.DateRange = dateRangeNames[m.dateRange]
+	}
+	if r := m.selectedReminder(); r != nil {
+		settings.Selected = r.ID
+	}
+	if err := m.viewSettings.SaveViewSettings(settings); err != nil {
+		m.toastError("Could not save view settings: " + err.Error())
+	}
+}
@@ -0,0 +1,165 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"go_remind/pkg/datetime"
+	"go_remind/pkg/reminder"
+)
+
+// shiftPreviewRows is how many reminders the bulk shift preview lists
+const shiftPreviewRows = 10
+
+// changedReminder is a reminder as it was before a bulk change, for undo
+type changedReminder struct {
+	r      *reminder.Reminder
+	before reminder.Reminder
+}
+
+func newShiftInput() textinput.Model {
+	ti := textinput.New()
+	ti.Placeholder = "+7d, -2h, +1w2d"
+	ti.CharLimit = 20
+	ti.Width = 20
+	return ti
+}
+
+// openShift asks for an offset to move every reminder in the view by
+func (m *Model) openShift() tea.Cmd {
+	if len(m.shiftTargets()) == 0 {
+		m.toastInfo("No open reminders in view to shift")
+		return nil
+	}
+	m.shiftInput.Reset()
+	m.inputError = ""
+	m.mode = modeShift
+	return m.shiftInput.Focus()
+}
+
+// shiftTargets returns the reminders in the current view a bulk shift
+// moves. Done reminders keep the time they were due.
+func (m Model) shiftTargets() []*reminder.Reminder {
+	var targets []*reminder.Reminder
+	for _, r := range m.getFilteredReminders() {
+		if r.Status != reminder.Acknowledged {
+			targets = append(targets, r)
+		}
+	}
+	return targets
+}
+
+func (m Model) updateShiftMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEscape:
+		m.mode = modeNormal
+		m.shiftInput.Blur()
+		m.inputError = ""
+		return m, nil
+	case tea.KeyEnter:
+		offset, err := datetime.ParseOffset(m.shiftInput.Value())
+		if err != nil {
+			m.inputError = err.Error()
+			return m, nil
+		}
+		m.shift(m.shiftTargets(), offset)
+		m.mode = modeNormal
+		m.shiftInput.Blur()
+		m.inputError = ""
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.shiftInput, cmd = m.shiftInput.Update(msg)
+	m.inputError = ""
+	return m, cmd
+}
+
+// shift moves each reminder by offset, remembering where they were so the
+// shift can be undone
+func (m *Model) shift(targets []*reminder.Reminder, offset datetime.Offset) {
+	now := m.now()
+	m.rememberBulk(targets)
+	for _, r := range targets {
+		retime(r, offset.Apply(r.DateTime), now)
+	}
+	reminder.SortByDateTime(m.reminders)
+	m.refreshList()
+	m.saveState()
+	m.toastSuccess(fmt.Sprintf("Shifted %s by %s (%s to undo)", pluralReminders(len(targets)), strings.TrimSpace(m.shiftInput.Value()), keys.Undo.Help().Key))
+}
+
+// rememberBulk keeps the reminders as they are before a bulk change, so
+// undoBulk can put them back
+func (m *Model) rememberBulk(targets []*reminder.Reminder) {
+	m.lastBulk = make([]changedReminder, len(targets))
+	for i, r := range targets {
+		m.lastBulk[i] = changedReminder{r: r, before: *r}
+	}
+}
+
+// undoBulk puts back the time and status of the reminders changed by the
+// last bulk change
+func (m *Model) undoBulk() {
+	if len(m.lastBulk) == 0 {
+		m.toastInfo("Nothing to undo")
+		return
+	}
+	now := m.now()
+	for _, c := range m.lastBulk {
+		c.r.DateTime = c.before.DateTime
+		c.r.Status = c.before.Status
+		c.r.AcknowledgedAt = c.before.AcknowledgedAt
+		c.r.UpdatedAt = now
+	}
+	n := len(m.lastBulk)
+	m.lastBulk = nil
+	reminder.SortByDateTime(m.reminders)
+	m.refreshList()
+	m.saveState()
+	m.toastInfo("Undid change to " + pluralReminders(n))
+}
+
+// shiftView renders the offset input with a before/after preview
+func (m Model) shiftView() string {
+	targets := m.shiftTargets()
+
+	var b strings.Builder
+	b.WriteString(inputLabelStyle.Render(glyphs.Calendar + " Shift " + pluralReminders(len(targets)) + " in view"))
+	b.WriteString("\n\n")
+	b.WriteString(inputHintStyle.Render("By: "))
+	b.WriteString(m.shiftInput.View())
+	b.WriteString("\n\n")
+
+	const format = "Mon Jan 2 3:04pm"
+	offset, err := datetime.ParseOffset(m.shiftInput.Value())
+	for i, r := range targets {
+		if i == shiftPreviewRows {
+			b.WriteString(inputHintStyle.Render(fmt.Sprintf("  %s and %d more", glyphs.Ellipsis, len(targets)-i)))
+			b.WriteString("\n")
+			break
+		}
+		line := fmt.Sprintf("  %-16s", r.DateTime.Format(format))
+		if err == nil {
+			line += " " + glyphs.Cursor + " " + fmt.Sprintf("%-16s", offset.Apply(r.DateTime).Format(format))
+		}
+		b.WriteString(normalStyle.Render(line))
+		b.WriteString("  " + sourceStyle.Render(ansi.Truncate(r.Description, 40, glyphs.Ellipsis)))
+		b.WriteString("\n")
+	}
+
+	if m.inputError != "" {
+		b.WriteString("\n")
+		b.WriteString(triggeredStyle.Render(glyphs.Warning + " " + m.inputError))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(inputHintStyle.Render("w weeks, d days, h hours, m minutes " + glyphs.Bullet + " enter to shift " + glyphs.Bullet + " esc to cancel"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modalBox(b.String()))
+}
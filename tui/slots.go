@@ -0,0 +1,127 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"go_remind/conflicts"
+)
+
+// maxSlots is how many free slots are suggested
+const maxSlots = 5
+
+// slotTimeFormat is how a picked slot is written into the add input
+const slotTimeFormat = "2006-01-02 15:04"
+
+func newSlotInput() textinput.Model {
+	ti := textinput.New()
+	ti.Placeholder = "30m"
+	ti.CharLimit = 10
+	ti.Width = 10
+	return ti
+}
+
+// openSlots asks, from add mode, how long a slot to look for
+func (m *Model) openSlots() tea.Cmd {
+	m.addInput.Blur()
+	m.slotInput.Reset()
+	m.slotIndex = 0
+	m.slots = nil
+	m.inputError = ""
+	m.mode = modeSlots
+	return m.slotInput.Focus()
+}
+
+// findSlots suggests free slots for the duration typed so far
+func (m *Model) findSlots() {
+	m.slotIndex = 0
+	m.slots = nil
+	d, err := time.ParseDuration(strings.TrimSpace(m.slotInput.Value()))
+	if err != nil || d <= 0 {
+		return
+	}
+	start, end := m.config.WorkHours.Bounds()
+	m.slots = conflicts.FreeSlots(m.reminders, m.now(), d, m.config.Conflicts.WindowDuration(), start, end, maxSlots)
+}
+
+func (m Model) updateSlotsMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEscape:
+		m.slotInput.Blur()
+		m.mode = modeAdd
+		return m, m.addInput.Focus()
+	case tea.KeyEnter:
+		if len(m.slots) == 0 {
+			return m, nil
+		}
+		// Replace any time already typed, keeping the description and notes
+		entry, notes := splitEntry(m.addInput.Value())
+		_, desc, _ := m.inputTime(entry)
+		text := strings.TrimSpace(m.slots[m.slotIndex].Start.Format(slotTimeFormat) + " " + desc)
+		if notes != "" {
+			text += "\n" + notes
+		}
+		m.addInput.SetValue(text)
+		m.addInput.CursorEnd()
+		m.fitAddInput()
+		m.slotInput.Blur()
+		m.mode = modeAdd
+		return m, m.addInput.Focus()
+	case tea.KeyUp:
+		if m.slotIndex > 0 {
+			m.slotIndex--
+		}
+		return m, nil
+	case tea.KeyDown, tea.KeyTab:
+		if m.slotIndex < len(m.slots)-1 {
+			m.slotIndex++
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.slotInput, cmd = m.slotInput.Update(msg)
+	m.findSlots()
+	return m, cmd
+}
+
+// slotsView renders the duration input and the free slots found
+func (m Model) slotsView() string {
+	var b strings.Builder
+	b.WriteString(inputBoxStyle.Render(inputLabelStyle.Render(glyphs.Calendar+" Find a free slot for: ") + m.slotInput.View()))
+	b.WriteString("\n")
+
+	start, end := m.config.WorkHours.Bounds()
+	hours := fmt.Sprintf("%02d:%02d-%02d:%02d", int(start.Hours()), int(start.Minutes())%60, int(end.Hours()), int(end.Minutes())%60)
+	switch {
+	case strings.TrimSpace(m.slotInput.Value()) == "":
+		b.WriteString(inputHintStyle.Render("  How long? e.g. 30m or 1h30m. Slots are within work hours " + hours + ", today and tomorrow."))
+	case len(m.slots) == 0:
+		if _, err := time.ParseDuration(strings.TrimSpace(m.slotInput.Value())); err != nil {
+			b.WriteString(inputHintStyle.Render("  Type a duration like 30m or 1h30m"))
+		} else {
+			b.WriteString(inputHintStyle.Render("  No free slot that long in work hours " + hours + " today or tomorrow"))
+		}
+	default:
+		today := m.now()
+		for i, s := range m.slots {
+			day := "Today"
+			if !sameDay(s.Start, today) {
+				day = "Tomorrow"
+			}
+			line := fmt.Sprintf("%-8s %s  (free until %s)", day, s.Start.Format("3:04pm"), s.End.Format("3:04pm"))
+			if i == m.slotIndex {
+				b.WriteString("  " + selectedItemStyle.Render(glyphs.Cursor+" "+line))
+			} else {
+				b.WriteString("    " + normalStyle.Render(line))
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString(inputHintStyle.Render("  " + glyphs.Up + "/" + glyphs.Down + " to choose " + glyphs.Bullet + " enter to use it " + glyphs.Bullet + " esc to go back"))
+	}
+	return b.String()
+}
@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"go_remind/pkg/reminder"
+)
+
+// sortOrder is the order of reminders within each section of the sorted
+// views
+type sortOrder int
+
+const (
+	sortDueAsc sortOrder = iota
+	sortDueDesc
+	sortPriority
+	sortUrgency
+	sortRecent
+	sortAlpha
+)
+
+// sortOrderNames are the names sort orders are saved under, in the order
+// the sort order key cycles through them
+var sortOrderNames = []string{"due", "due-desc", "priority", "urgency", "recent", "alpha"}
+
+// label is the sort order as shown in the status bar
+func (o sortOrder) label() string {
+	switch o {
+	case sortDueAsc:
+		return "due " + glyphs.Up
+	case sortDueDesc:
+		return "due " + glyphs.Down
+	case sortRecent:
+		return "newest"
+	case sortAlpha:
+		return "a-z"
+	default:
+		return sortOrderNames[o]
+	}
+}
+
+// cycleSortOrder switches the sorted views to the next sort order
+func (m *Model) cycleSortOrder() {
+	m.sortOrder = (m.sortOrder + 1) % sortOrder(len(sortOrderNames))
+	m.gridIndex, m.gridScroll = 0, 0
+	m.compactIndex, m.compactScroll = 0, 0
+	m.sortEnabled = true // The order only shows in the sorted views
+	m.saveViewSettings()
+	m.toastInfo("Sort by " + m.sortOrder.label())
+}
+
+// sortItems returns items in the current sort order, easy reminders first
+// in low energy mode. Ties are broken by due time, and then keep their
+// saved order.
+func (m Model) sortItems(items []*reminder.Reminder, now time.Time) []*reminder.Reminder {
+	sorted := slices.Clone(items)
+	slices.SortStableFunc(sorted, func(a, b *reminder.Reminder) int {
+		if m.lowEnergy {
+			if c := effortRank(a) - effortRank(b); c != 0 {
+				return c
+			}
+		}
+		if c := m.sortOrder.compare(a, b, now); c != 0 {
+			return c
+		}
+		return a.DateTime.Compare(b.DateTime)
+	})
+	return sorted
+}
+
+// compare orders a and b by the sort order alone, returning 0 for a tie
+func (o sortOrder) compare(a, b *reminder.Reminder, now time.Time) int {
+	switch o {
+	case sortDueDesc:
+		return b.DateTime.Compare(a.DateTime)
+	case sortPriority:
+		return int(b.Priority) - int(a.Priority)
+	case sortUrgency:
+		if c := urgency(b, now) - urgency(a, now); c != 0 {
+			return c
+		}
+		return int(b.Priority) - int(a.Priority)
+	case sortRecent:
+		return addedAt(b).Compare(addedAt(a))
+	case sortAlpha:
+		return strings.Compare(strings.ToLower(a.Description), strings.ToLower(b.Description))
+	default:
+		return 0 // Due time is the tiebreak anyway
+	}
+}
+
+// urgency ranks how soon r needs attention: overdue, then due within the
+// day, then everything else, with done and parked reminders last
+func urgency(r *reminder.Reminder, now time.Time) int {
+	switch {
+	case r.Status == reminder.Acknowledged || r.Status.Parked():
+		return 0
+	case r.Status == reminder.Triggered || r.IsDueAt(now):
+		return 3
+	case r.DateTime.Before(now.Add(24 * time.Hour)):
+		return 2
+	default:
+		return 1
+	}
+}
+
+// addedAt is when r was added, falling back to its last change for
+// reminders saved before that was recorded
+func addedAt(r *reminder.Reminder) time.Time {
+	if !r.CreatedAt.IsZero() {
+		return r.CreatedAt
+	}
+	return r.UpdatedAt
+}
@@ -0,0 +1,573 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"go_remind/pkg/reminder"
+	"go_remind/pkg/state"
+	"go_remind/sources"
+)
+
+// sourceState is how refreshing one source of reminders is going: a
+// remote source, or a watched file or directory
+type sourceState struct {
+	fetching  bool
+	paused    bool      // Paused in the sources view: its reminders stay, but aren't refreshed
+	removed   bool      // Removed in the sources view: not refreshed, and its reminders deleted
+	next      time.Time // When to fetch next
+	refreshed time.Time // Last successful fetch or parse
+	err       error     // From the last fetch or parse
+	failures  int       // Fetches or parses that failed in a row
+	failing   time.Time // When the first of those failed
+}
+
+const (
+	// retryDelay is how soon a source that failed is tried again, doubled
+	// for each failure after the first
+	retryDelay = time.Minute
+	// maxRetryDelay caps the backoff, unless the source's interval is longer
+	maxRetryDelay = time.Hour
+)
+
+// record notes how a fetch or parse went and schedules the next one:
+// interval from now if it worked, else sooner, backing off while it
+// keeps failing
+func (st *sourceState) record(now time.Time, err error, interval time.Duration) {
+	st.err = err
+	if err == nil {
+		st.failures = 0
+		st.refreshed = now
+		st.next = now.Add(interval)
+		return
+	}
+	if st.failures == 0 {
+		st.failing = now
+	}
+	st.failures++
+	limit := max(interval, maxRetryDelay)
+	delay := retryDelay
+	for i := 1; i < st.failures && delay < limit; i++ {
+		delay *= 2
+	}
+	st.next = now.Add(min(delay, limit))
+}
+
+// staleSince returns when the source last refreshed, or first failed if
+// it never has, while it's failing. It's zero when the source is fine, or
+// isn't being refreshed.
+func (st *sourceState) staleSince() time.Time {
+	if st.failures == 0 || st.paused || st.removed {
+		return time.Time{}
+	}
+	if st.refreshed.IsZero() {
+		return st.failing
+	}
+	return st.refreshed
+}
+
+// watchedPath is a file or directory of notes that's watched for changes
+type watchedPath struct {
+	path  string // Absolute
+	dir   bool
+	every time.Duration // How often to parse it again, 0 to rely on the watcher
+}
+
+// SourceDoneMsg is sent when a remote source has been fetched
+type SourceDoneMsg struct {
+	name      string
+	reminders []*reminder.Reminder
+	err       error
+}
+
+// SourceAckMsg is sent when a remote source has been told about an
+// acknowledgment
+type SourceAckMsg struct {
+	name        string
+	description string
+	err         error
+}
+
+// SourceSettingsStore keeps the choices made in the sources view;
+// satisfied by *state.Store
+type SourceSettingsStore interface {
+	LoadSourceSettings() (state.SourceSettings, error)
+	SaveSourceSettings(settings state.SourceSettings) error
+}
+
+// WithSources returns a copy of the model that pulls reminders from srcs
+// now and every interval after
+func (m Model) WithSources(srcs []sources.Source, interval time.Duration) Model {
+	m.remoteSources = srcs
+	m.sourceInterval = interval
+	for _, s := range srcs {
+		m.addSourceState(s.Name())
+	}
+	return m
+}
+
+// WithWatchedPaths returns a copy of the model that lists the watched
+// files and directories in the sources view. parse parses one of them
+// again to refresh it.
+func (m Model) WithWatchedPaths(paths []string, parse func(path string) InitialParseMsg) Model {
+	m.parsePath = parse
+	for _, p := range paths {
+		abs := absPath(p)
+		info, err := os.Stat(abs)
+		m.watched = append(m.watched, watchedPath{path: abs, dir: err == nil && info.IsDir()})
+		m.addSourceState(abs)
+	}
+	return m
+}
+
+// WithPathIntervals returns a copy of the model that parses the watched
+// paths in intervals again that often, catching changes the watcher
+// missed. Call it after WithWatchedPaths.
+func (m Model) WithPathIntervals(intervals map[string]time.Duration) Model {
+	watched := make([]watchedPath, len(m.watched))
+	for i, w := range m.watched {
+		for path, every := range intervals {
+			if absPath(path) == w.path {
+				w.every = every
+			}
+		}
+		watched[i] = w
+	}
+	m.watched = watched
+	return m
+}
+
+// WithSourceSettings returns a copy of the model that keeps the sources
+// paused or removed in the sources view in store. Call it after
+// WithSources and WithWatchedPaths.
+func (m Model) WithSourceSettings(store SourceSettingsStore) Model {
+	m.sourceSettings = store
+	settings, err := store.LoadSourceSettings()
+	if err != nil {
+		m.toastError("Could not load source settings: " + err.Error())
+		return m
+	}
+	for _, name := range settings.Removed {
+		if st, ok := m.sourceStates[name]; ok {
+			st.removed = true
+		}
+	}
+	for _, name := range settings.Paused {
+		if st, ok := m.sourceStates[name]; ok {
+			st.paused = true
+		}
+	}
+	return m
+}
+
+func (m *Model) addSourceState(name string) {
+	if m.sourceStates == nil {
+		m.sourceStates = make(map[string]*sourceState)
+	}
+	m.sourceStates[name] = &sourceState{}
+}
+
+// absPath returns path made absolute, as reminders' source files are
+func absPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
+
+// checkSources starts a background fetch of each source that's due
+func (m *Model) checkSources(now time.Time) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, s := range m.remoteSources {
+		st := m.sourceStates[s.Name()]
+		if st.fetching || st.paused || st.removed || now.Before(st.next) {
+			continue
+		}
+		cmds = append(cmds, m.fetchSource(s))
+	}
+	for _, w := range m.watched {
+		st := m.sourceStates[w.path]
+		if (w.every == 0 && st.failures == 0) || st.fetching || st.paused || st.removed {
+			continue
+		}
+		if st.next.IsZero() {
+			st.next = now.Add(w.every) // Parsed at startup
+			continue
+		}
+		if !now.Before(st.next) {
+			cmds = append(cmds, m.refreshPath(w.path))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// fetchSource fetches a remote source in the background
+func (m *Model) fetchSource(s sources.Source) tea.Cmd {
+	m.sourceStates[s.Name()].fetching = true
+	return func() tea.Msg {
+		fetched, err := s.Fetch()
+		return SourceDoneMsg{name: s.Name(), reminders: fetched, err: err}
+	}
+}
+
+// refreshInterval returns how often the named source is fetched, or
+// watched path parsed again
+func (m Model) refreshInterval(name string) time.Duration {
+	for _, w := range m.watched {
+		if w.path == name {
+			return w.every
+		}
+	}
+	for _, s := range m.remoteSources {
+		if sched, ok := s.(sources.Scheduled); ok && s.Name() == name && sched.Interval() > 0 {
+			return sched.Interval()
+		}
+	}
+	return m.sourceInterval
+}
+
+// applySource merges what a source returned with the reminders pulled
+// from it before
+func (m *Model) applySource(msg SourceDoneMsg) {
+	now := m.now()
+	st := m.sourceStates[msg.name]
+	st.fetching = false
+	if st.removed {
+		return // Removed while it was being fetched
+	}
+	st.record(now, msg.err, m.refreshInterval(msg.name))
+	if msg.err != nil {
+		if st.failures == 1 {
+			m.toastError(msg.name + ": " + msg.err.Error())
+		}
+		return
+	}
+
+	m.reminders = reminder.MergeFromSource(m.reminders, msg.name, msg.reminders, m.now())
+	reminder.SortByDateTime(m.reminders)
+	m.refreshList()
+	m.saveState()
+	m.dupeCheckDue = true
+}
+
+// watchedFor returns the watched path that file is, or is in
+func (m Model) watchedFor(file string) (watchedPath, bool) {
+	for _, w := range m.watched {
+		if file == w.path || (w.dir && strings.HasPrefix(file, w.path+string(filepath.Separator))) {
+			return w, true
+		}
+	}
+	return watchedPath{}, false
+}
+
+// takeFileUpdate reports whether changes to file should be merged, and
+// records when its watched path was last refreshed
+func (m *Model) takeFileUpdate(file string) bool {
+	w, ok := m.watchedFor(file)
+	if !ok {
+		return true
+	}
+	st := m.sourceStates[w.path]
+	if st.paused || st.removed {
+		return false
+	}
+	st.record(m.now(), nil, w.every)
+	return true
+}
+
+// takeInitialParse reports whether a parse of a watched path should be
+// merged, and records how it went
+func (m *Model) takeInitialParse(msg InitialParseMsg) bool {
+	if msg.Path == "" {
+		return true
+	}
+	st, ok := m.sourceStates[absPath(msg.Path)]
+	if !ok {
+		return true
+	}
+	st.fetching = false
+	if st.paused || st.removed {
+		return false
+	}
+	st.record(m.now(), msg.Err, m.refreshInterval(absPath(msg.Path)))
+	return true
+}
+
+// refreshPath parses a watched path again in the background
+func (m *Model) refreshPath(path string) tea.Cmd {
+	if m.parsePath == nil {
+		return nil
+	}
+	m.sourceStates[path].fetching = true
+	parse := m.parsePath
+	return func() tea.Msg {
+		return parse(path)
+	}
+}
+
+// acknowledgeAtSources tells the sources of rs, if they take it, that the
+// reminders were acknowledged
+func (m Model) acknowledgeAtSources(rs ...*reminder.Reminder) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, r := range rs {
+		if r.Source == "" {
+			continue
+		}
+		for _, s := range m.remoteSources {
+			ack, ok := s.(sources.Acknowledger)
+			if !ok || s.Name() != r.Source {
+				continue
+			}
+			acked := *r
+			cmds = append(cmds, func() tea.Msg {
+				return SourceAckMsg{name: acked.Source, description: acked.Description, err: ack.Acknowledge(&acked)}
+			})
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// applySourceAck reports a source that couldn't be told about an
+// acknowledgment
+func (m *Model) applySourceAck(msg SourceAckMsg) {
+	if msg.err != nil {
+		m.toastError(fmt.Sprintf("%s: could not update %q: %v", msg.name, msg.description, msg.err))
+	}
+}
+
+// sourceNames returns every source in the sources view: the watched
+// paths, then the remote sources
+func (m Model) sourceNames() []string {
+	var names []string
+	for _, w := range m.watched {
+		names = append(names, w.path)
+	}
+	for _, s := range m.remoteSources {
+		names = append(names, s.Name())
+	}
+	return names
+}
+
+// sourceOf returns the name of the source r came from, or "" if it was
+// added in the TUI or comes from a file that isn't watched
+func (m Model) sourceOf(r *reminder.Reminder) string {
+	if r.Source != "" {
+		return r.Source
+	}
+	if w, ok := m.watchedFor(r.SourceFile); ok {
+		return w.path
+	}
+	return ""
+}
+
+// openSources shows the sources view
+func (m *Model) openSources() {
+	if len(m.sourceNames()) == 0 {
+		m.toastInfo("No sources configured")
+		return
+	}
+	if m.sourceIndex >= len(m.sourceNames()) {
+		m.sourceIndex = 0
+	}
+	m.mode = modeSources
+}
+
+func (m Model) updateSourcesMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	names := m.sourceNames()
+	name := names[m.sourceIndex]
+	st := m.sourceStates[name]
+	switch {
+	case msg.Type == tea.KeyEscape, msg.String() == "q", key.Matches(msg, keys.Sources):
+		m.mode = modeNormal
+	case msg.String() == "r":
+		switch {
+		case st.removed:
+			m.toastInfo("Removed: press d to restore it")
+		case st.paused:
+			m.toastInfo("Paused: press p to resume it")
+		case !st.fetching:
+			return m, m.refreshSource(name)
+		}
+	case msg.String() == "p":
+		st.paused = !st.paused
+		m.saveSourceSettings()
+		if st.paused {
+			m.toastInfo("Paused " + name)
+			return m, nil
+		}
+		m.toastSuccess("Resumed " + name)
+		if !st.removed {
+			return m, m.refreshSource(name)
+		}
+	case msg.String() == "d":
+		if st.removed {
+			st.removed = false
+			m.saveSourceSettings()
+			m.toastSuccess("Restored " + name)
+			if !st.paused {
+				return m, m.refreshSource(name)
+			}
+			return m, nil
+		}
+		m.removeSource(name)
+	case key.Matches(msg, keys.Up):
+		if m.sourceIndex > 0 {
+			m.sourceIndex--
+		}
+	case key.Matches(msg, keys.Down):
+		if m.sourceIndex < len(names)-1 {
+			m.sourceIndex++
+		}
+	}
+	return m, nil
+}
+
+// refreshSource fetches a remote source or parses a watched path now
+func (m *Model) refreshSource(name string) tea.Cmd {
+	for _, s := range m.remoteSources {
+		if s.Name() == name {
+			return m.fetchSource(s)
+		}
+	}
+	return m.refreshPath(name)
+}
+
+// removeSource stops refreshing a source and deletes its reminders
+func (m *Model) removeSource(name string) {
+	st := m.sourceStates[name]
+	st.removed = true
+	st.err = nil
+	st.failures = 0
+
+	var kept []*reminder.Reminder
+	n := 0
+	for _, r := range m.reminders {
+		if m.sourceOf(r) == name {
+			n++
+			continue
+		}
+		kept = append(kept, r)
+	}
+	m.reminders = kept
+	m.refreshList()
+	m.saveState()
+	m.saveSourceSettings()
+	m.toastInfo(fmt.Sprintf("Removed %s and its %d reminder(s)", name, n))
+}
+
+// saveSourceSettings saves which sources are paused or removed
+func (m *Model) saveSourceSettings() {
+	if m.sourceSettings == nil {
+		return
+	}
+	var settings state.SourceSettings
+	for _, name := range m.sourceNames() {
+		if st := m.sourceStates[name]; st.removed {
+			settings.Removed = append(settings.Removed, name)
+		} else if st.paused {
+			settings.Paused = append(settings.Paused, name)
+		}
+	}
+	if err := m.sourceSettings.SaveSourceSettings(settings); err != nil {
+		m.toastError("Could not save source settings: " + err.Error())
+	}
+}
+
+// sourcesView renders every source with its reminder count and how
+// refreshing it is going
+func (m Model) sourcesView() string {
+	var b strings.Builder
+	b.WriteString(inputLabelStyle.Render("Sources"))
+	b.WriteString("\n\n")
+
+	counts := make(map[string]int)
+	for _, r := range m.reminders {
+		counts[m.sourceOf(r)]++
+	}
+	names := m.sourceNames()
+	labels := make([]string, len(names))
+	width := 0
+	for i, name := range names {
+		labels[i] = name
+		if w, ok := m.watchedFor(name); ok && w.dir {
+			labels[i] += string(filepath.Separator)
+		}
+		labels[i] = ansi.Truncate(labels[i], 50, glyphs.Ellipsis)
+		width = max(width, ansi.StringWidth(labels[i]))
+	}
+
+	now := m.now()
+	for i, name := range names {
+		cursor := "  "
+		style := normalStyle
+		if i == m.sourceIndex {
+			cursor = glyphs.Cursor + " "
+			style = selectedItemStyle
+		}
+		label := labels[i] + strings.Repeat(" ", width-ansi.StringWidth(labels[i]))
+		line := cursor + style.Render(label) + sourceStyle.Render(fmt.Sprintf("  %4d", counts[name]))
+
+		st := m.sourceStates[name]
+		switch {
+		case st.removed:
+			line += sourceStyle.Render("  removed")
+		case st.fetching:
+			line += inputHintStyle.Render("  refreshing" + glyphs.Ellipsis)
+		case st.err != nil:
+			line += triggeredStyle.Render("  " + glyphs.Warning + " " + ansi.Truncate(st.err.Error(), 50, glyphs.Ellipsis))
+		case st.refreshed.IsZero():
+			line += inputHintStyle.Render("  not refreshed yet")
+		default:
+			line += inputHintStyle.Render("  refreshed " + formatCountdown(now.Sub(st.refreshed)) + " ago")
+		}
+		if since := st.staleSince(); !since.IsZero() {
+			line += triggeredStyle.Render("  stale since " + formatStaleSince(since, now))
+		}
+		if st.paused && !st.removed {
+			line += sourceStyle.Render("  (paused)")
+		}
+		b.WriteString(line + "\n")
+	}
+
+	sep := " " + glyphs.Bullet + " "
+	b.WriteString("\n")
+	b.WriteString(inputHintStyle.Render("r refresh now" + sep + "p pause or resume" + sep + "d remove or restore" + sep + "esc to close"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modalBox(b.String()))
+}
+
+// formatStaleSince formats when a source went stale: the time if it was
+// today, else the date too
+func formatStaleSince(t, now time.Time) string {
+	if t.YearDay() == now.YearDay() && t.Year() == now.Year() {
+		return t.Format("15:04")
+	}
+	return t.Format("Jan 2 15:04")
+}
+
+// staleSegment warns in the status bar about sources that keep failing
+func (m Model) staleSegment() string {
+	var stale []string
+	for _, name := range m.sourceNames() {
+		if !m.sourceStates[name].staleSince().IsZero() {
+			stale = append(stale, name)
+		}
+	}
+	switch len(stale) {
+	case 0:
+		return ""
+	case 1:
+		since := m.sourceStates[stale[0]].staleSince()
+		return triggeredStyle.Render(glyphs.Warning + " " + ansi.Truncate(stale[0], 30, glyphs.Ellipsis) + " stale since " + formatStaleSince(since, m.now()))
+	default:
+		return triggeredStyle.Render(fmt.Sprintf("%s %d sources stale", glyphs.Warning, len(stale)))
+	}
+}
@@ -0,0 +1,76 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// splitMinWidth is the narrowest terminal the split pane is shown in;
+	// below it the detail view stays a modal
+	splitMinWidth = 140
+	// splitListShare is the percentage of the width the list keeps
+	splitListShare = 55
+	// splitGap separates the list from the detail pane
+	splitGap = 2
+)
+
+// splitActive reports whether the list is shown with the detail pane
+// beside it
+func (m Model) splitActive() bool {
+	return m.splitPane && m.width >= splitMinWidth
+}
+
+// listWidth returns the terminal width the list is laid out for: all of
+// it, or the share the split pane leaves
+func (m Model) listWidth() int {
+	if !m.splitActive() {
+		return m.width
+	}
+	return m.width * splitListShare / 100
+}
+
+// layoutList sizes the list and the card grid to the width they get
+func (m *Model) layoutList() {
+	listHeight := m.height - 5
+	if listHeight < 5 {
+		listHeight = 5
+	}
+	m.list.SetSize(m.listWidth()-4, listHeight)
+	m.gridColumns = gridColumnsFor(m.listWidth())
+}
+
+// toggleSplit turns the split pane on or off
+func (m *Model) toggleSplit() {
+	m.splitPane = !m.splitPane
+	m.layoutList()
+	switch {
+	case !m.splitPane:
+		m.toastInfo("Split pane off")
+	case !m.splitActive():
+		m.toastInfo(fmt.Sprintf("Split pane on, shown when the terminal is %d columns or wider", splitMinWidth))
+	default:
+		m.toastInfo("Split pane on: " + keys.Detail.Help().Key + " to focus it")
+	}
+}
+
+// splitView renders the list with a detail pane beside it: the selected
+// reminder, or the one the pane is focused on
+func (m Model) splitView() string {
+	lm := m
+	lm.width = m.listWidth()
+	listWidth := lm.width - appStyle.GetHorizontalPadding()
+	list := lipgloss.NewStyle().Width(listWidth).MaxWidth(listWidth).Render(lm.listContent())
+
+	r, focused := m.selectedReminder(), false
+	if m.mode == modeDetail && m.detailReminder != nil {
+		r, focused = m.detailReminder, true
+	}
+	if r == nil {
+		return list
+	}
+	paneWidth := m.width - appStyle.GetHorizontalPadding() - listWidth - splitGap
+	pane := m.detailCard(r, paneWidth-2, focused) // Less the border
+	return lipgloss.JoinHorizontal(lipgloss.Top, list, lipgloss.NewStyle().Width(splitGap).Render(""), pane)
+}
@@ -0,0 +1,245 @@
+package tui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"go_remind/stats"
+)
+
+func (m Model) updateStatsMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "enter", "q":
+		m.mode = modeNormal
+	default:
+		if key.Matches(msg, keys.Stats) {
+			m.mode = modeNormal
+		}
+	}
+	return m, nil
+}
+
+// maxContributionWeeks is how far back the contribution graph goes, about
+// six months
+const maxContributionWeeks = 26
+
+// openStats loads the acknowledgment history and shows the stats view
+func (m *Model) openStats() {
+	m.ackHistory = nil
+	if m.history != nil {
+		events, err := m.history.Load()
+		if err != nil {
+			m.toastError("Could not read history: " + err.Error())
+		}
+		m.ackHistory = events
+	}
+	m.mode = modeStats
+}
+
+// statsView renders completion streaks, a contribution graph of
+// acknowledgments, a heatmap of when reminders are due, and how tracked
+// time compares to estimates
+func (m Model) statsView() string {
+	now := m.now()
+
+	var b strings.Builder
+	b.WriteString(inputLabelStyle.Render(glyphs.Stats + " Stats"))
+	b.WriteString("\n\n")
+	b.WriteString(m.streakView(now))
+	b.WriteString("\n")
+	b.WriteString(m.contributionView(now))
+	b.WriteString("\n")
+	b.WriteString(inputLabelStyle.Render("When reminders are due"))
+	b.WriteString("\n\n")
+	b.WriteString(m.heatmapView())
+	b.WriteString("\n")
+	if est := m.estimatesView(now); est != "" {
+		b.WriteString(inputLabelStyle.Render("Estimates vs. tracked time"))
+		b.WriteString("\n\n")
+		b.WriteString(est)
+		b.WriteString("\n")
+	}
+	b.WriteString(inputHintStyle.Render("Press esc to close"))
+	return b.String()
+}
+
+// streakView renders the current and longest completion streaks
+func (m Model) streakView(now time.Time) string {
+	s := stats.Streaks(m.reminders, m.ackHistory, now)
+	line := selectedItemStyle.Render(fmt.Sprintf("%s Current streak: %s", glyphs.Streak, pluralDays(s.Current)))
+	line += sourceStyle.Render("  " + glyphs.Bullet + "  ")
+	line += normalStyle.Render("Longest: " + pluralDays(s.Longest))
+	return line + "\n" + inputHintStyle.Render("Days where everything due was acknowledged") + "\n"
+}
+
+// pluralDays formats a number of days
+func pluralDays(n int) string {
+	if n == 1 {
+		return "1 day"
+	}
+	return fmt.Sprintf("%d days", n)
+}
+
+// contributionView renders acknowledgments per day as a GitHub-style graph,
+// one column per week, as many weeks as fit the terminal
+func (m Model) contributionView(now time.Time) string {
+	weeks := maxContributionWeeks
+	if m.width > 0 {
+		weeks = min(weeks, (m.width-4-4)/2)
+	}
+	weeks = max(weeks, 4)
+	grid := stats.Contributions(m.ackHistory, now, weeks)
+
+	most, total := 0, 0
+	for _, week := range grid {
+		for _, n := range week {
+			most = max(most, n)
+			total += n
+		}
+	}
+
+	// Month labels over the first week of each month, skipped where
+	// they'd run into the previous one
+	header := []byte(strings.Repeat(" ", 4+2*weeks))
+	start := now.AddDate(0, 0, -int(now.Weekday())-7*(weeks-1))
+	labelEnd := 0
+	for w := 0; w < weeks; w++ {
+		weekStart := start.AddDate(0, 0, 7*w)
+		pos := 4 + 2*w
+		if (w == 0 || weekStart.Day() <= 7) && pos >= labelEnd && pos+3 <= len(header) {
+			copy(header[pos:], weekStart.Format("Jan"))
+			labelEnd = pos + 4
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(sourceStyle.Render(strings.TrimRight(string(header), " ")))
+	b.WriteString("\n")
+	levels := len(glyphs.Shades)
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		label := "    "
+		if day == time.Monday || day == time.Wednesday || day == time.Friday {
+			label = day.String()[:3] + " "
+		}
+		b.WriteString(normalStyle.Render(label))
+		for w, week := range grid {
+			if w == len(grid)-1 && day > now.Weekday() {
+				break // Future days this week
+			}
+			shade := glyphs.Shades[stats.Level(week[day], most, levels)]
+			if week[day] == 0 {
+				b.WriteString(sourceStyle.Render(shade + " "))
+			} else {
+				b.WriteString(selectedItemStyle.Render(shade + " "))
+			}
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(sourceStyle.Render(fmt.Sprintf("    %d acknowledged in the last %d weeks", total, weeks)))
+	b.WriteString("\n")
+	return b.String()
+}
+
+// heatmapView renders when reminders fall across the week as a 7x24 grid,
+// one cell per hour, shaded by how many reminders are due then
+func (m Model) heatmapView() string {
+	h := stats.BuildHeatmap(m.reminders)
+	if h.Total() == 0 {
+		return normalStyle.Render("No reminders yet") + "\n"
+	}
+
+	var b strings.Builder
+	// Hour labels every three hours, over cells two columns wide
+	var header strings.Builder
+	header.WriteString("    ")
+	for hour := 0; hour < 24; hour += 3 {
+		header.WriteString(fmt.Sprintf("%-6s", hourLabel(hour)))
+	}
+	b.WriteString(sourceStyle.Render(header.String()))
+	b.WriteString("\n")
+
+	levels := len(glyphs.Shades)
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		b.WriteString(normalStyle.Render(day.String()[:3] + " "))
+		for hour := 0; hour < 24; hour++ {
+			count := h[day][hour]
+			shade := glyphs.Shades[stats.Level(count, h.Max(), levels)]
+			if count == 0 {
+				b.WriteString(sourceStyle.Render(shade + shade))
+			} else {
+				b.WriteString(selectedItemStyle.Render(shade + shade))
+			}
+		}
+		b.WriteString(sourceStyle.Render(fmt.Sprintf(" %d", h.DayTotal(day))))
+		b.WriteString("\n")
+	}
+
+	// Legend
+	b.WriteString("\n")
+	b.WriteString(sourceStyle.Render("    fewer "))
+	for _, shade := range glyphs.Shades[1:] {
+		b.WriteString(selectedItemStyle.Render(shade + shade))
+	}
+	b.WriteString(sourceStyle.Render(fmt.Sprintf(" more (up to %d in an hour)", h.Max())))
+	b.WriteString("\n\n")
+
+	day, hour := h.Busiest()
+	sep := "  " + glyphs.Bullet + "  "
+	b.WriteString(normalStyle.Render(fmt.Sprintf("%d reminders", h.Total())))
+	b.WriteString(sourceStyle.Render(sep))
+	b.WriteString(normalStyle.Render(fmt.Sprintf("busiest slot %s %s (%d)", day.String()[:3], hourLabel(hour), h[day][hour])))
+	b.WriteString(sourceStyle.Render(sep))
+	b.WriteString(normalStyle.Render("busiest day " + h.BusiestDay().String()))
+	b.WriteString(sourceStyle.Render(sep))
+	b.WriteString(normalStyle.Render("busiest hour " + hourLabel(h.BusiestHour())))
+	b.WriteString("\n")
+	return b.String()
+}
+
+// maxEstimateTags is how many tags the estimates table lists
+const maxEstimateTags = 8
+
+// estimatesView renders estimated against tracked time for done reminders,
+// per tag and overall, or nothing if none were both estimated and timed
+func (m Model) estimatesView(now time.Time) string {
+	byTag, total := stats.EstimateAccuracy(m.reminders, now)
+	if total.Count == 0 {
+		return ""
+	}
+	if len(byTag) > maxEstimateTags {
+		byTag = byTag[:maxEstimateTags]
+	}
+
+	width := len("All")
+	for _, e := range byTag {
+		width = max(width, len(e.Tag)+1)
+	}
+	var b strings.Builder
+	row := func(label string, e stats.Estimates) {
+		b.WriteString(normalStyle.Render(fmt.Sprintf("%-*s  %3d done  est %-7s  actual %-7s  ", width, label, e.Count, formatSpent(e.Estimated), formatSpent(e.Tracked))))
+		switch pct := int(math.Round(e.Over() * 100)); {
+		case pct > 0:
+			b.WriteString(triggeredStyle.Render(fmt.Sprintf("%d%% over", pct)))
+		case pct < 0:
+			b.WriteString(selectedItemStyle.Render(fmt.Sprintf("%d%% under", -pct)))
+		default:
+			b.WriteString(selectedItemStyle.Render("on estimate"))
+		}
+		b.WriteString("\n")
+	}
+	for _, e := range byTag {
+		row("#"+e.Tag, e)
+	}
+	row("All", total)
+	return b.String()
+}
+
+// hourLabel formats an hour of the day as 12am, 3pm, ...
+func hourLabel(hour int) string {
+	return time.Date(0, 1, 1, hour, 0, 0, 0, time.UTC).Format("3pm")
+}
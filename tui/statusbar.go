@@ -0,0 +1,180 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"go_remind/config"
+	"go_remind/pkg/reminder"
+)
+
+// overflowLines is the most lines the selected reminder's whole
+// description takes above the status bar
+const overflowLines = 3
+
+// statusBarView renders the persistent bar above the help line: the running
+// timer, counts, stale sources, filter, and layout on the left, the next due
+// reminder on the right
+func (m Model) statusBarView() string {
+	sep := sourceStyle.Render("  " + glyphs.Bullet + "  ")
+
+	var left []string
+	if progress := m.progressSegment(); progress != "" {
+		left = append(left, progress)
+	}
+	if timer := m.timerSegment(m.now()); timer != "" {
+		left = append(left, timer)
+	}
+	if m.profile != "" && m.profile != config.DefaultProfile {
+		left = append(left, inputLabelStyle.Render(glyphs.Profile+" "+m.profile))
+	}
+	left = append(left, m.countsSegment())
+	if stale := m.staleSegment(); stale != "" {
+		left = append(left, stale)
+	}
+	if filter := m.filterInput.Value(); filter != "" {
+		left = append(left, inputLabelStyle.Render(glyphs.Search+" "+filter))
+	}
+	if m.searching() {
+		left = append(left, inputLabelStyle.Render(glyphs.Search+" find: "+m.searchInput.Value()))
+	}
+	if m.dateRange != rangeAny {
+		left = append(left, inputLabelStyle.Render(glyphs.Calendar+" "+dateRangeNames[m.dateRange]))
+	}
+	sortName := "unsorted"
+	if m.sortEnabled {
+		sortName = "by " + groupingNames[m.grouping] + " " + glyphs.Dot + " " + m.sortOrder.label()
+		if m.lowEnergy {
+			sortName += " " + glyphs.Dot + " low energy"
+		}
+	}
+	left = append(left, sourceStyle.Render(layoutNames[currentLayout]+" "+glyphs.Dot+" "+sortName))
+
+	right := m.nextDueSegment(m.now())
+
+	width := m.width - appStyle.GetHorizontalPadding()
+	if width <= 0 {
+		width = 80
+	}
+
+	// Drop segments from the end until everything fits
+	for len(left) > 1 && lipgloss.Width(strings.Join(left, sep))+lipgloss.Width(right)+2 > width {
+		left = left[:len(left)-1]
+	}
+	leftStr := strings.Join(left, sep)
+	if right == "" || lipgloss.Width(leftStr)+lipgloss.Width(right)+2 > width {
+		return leftStr
+	}
+
+	gap := width - lipgloss.Width(leftStr) - lipgloss.Width(right)
+	return leftStr + strings.Repeat(" ", gap) + right
+}
+
+// overflowView shows the whole of the selected reminder's description when
+// the list cut it short, on up to overflowLines lines above the status bar.
+// The split pane shows it already.
+func (m Model) overflowView() string {
+	r := m.selectedReminder()
+	if r == nil || m.splitActive() {
+		return ""
+	}
+	if currentLayout == LayoutCard {
+		if _, cut := fitLines(titled(r), cardDescWidth(cardWidthFor(m.width)), 2); !cut {
+			return ""
+		}
+	} else if !compactCut(r, m.width-appStyle.GetHorizontalPadding()) {
+		return ""
+	}
+
+	width := m.width - appStyle.GetHorizontalPadding()
+	if width <= 0 {
+		width = 80
+	}
+	lines, _ := fitLines(titled(r), width-2, overflowLines)
+	for i, line := range lines {
+		prefix := "  "
+		if i == 0 {
+			prefix = glyphs.Cursor + " "
+		}
+		lines[i] = inputHintStyle.Render(prefix) + normalStyle.Render(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// countsSegment summarizes the reminders by status
+func (m Model) countsSegment() string {
+	var pending, triggered, acknowledged, waiting, someday int
+	for _, r := range m.reminders {
+		switch r.Status {
+		case reminder.Pending, reminder.Snoozed:
+			pending++
+		case reminder.Triggered:
+			triggered++
+		case reminder.Acknowledged:
+			acknowledged++
+		case reminder.Waiting:
+			waiting++
+		case reminder.Someday:
+			someday++
+		}
+	}
+
+	parts := []string{
+		normalStyle.Render(fmt.Sprintf("%d total", len(m.reminders))),
+		normalStyle.Render(fmt.Sprintf("%d pending", pending)),
+	}
+	if triggered > 0 {
+		parts = append(parts, triggeredStyle.Render(fmt.Sprintf("%d triggered", triggered)))
+	} else {
+		parts = append(parts, normalStyle.Render("0 triggered"))
+	}
+	if waiting > 0 {
+		parts = append(parts, waitingStyle.Render(fmt.Sprintf("%d waiting", waiting)))
+	}
+	if someday > 0 {
+		parts = append(parts, somedayStyle.Render(fmt.Sprintf("%d someday", someday)))
+	}
+	parts = append(parts, sourceStyle.Render(fmt.Sprintf("%d done", acknowledged)))
+	return strings.Join(parts, sourceStyle.Render(" "+glyphs.Dot+" "))
+}
+
+// nextDueSegment describes the soonest pending reminder, e.g. "next in 12m: Standup"
+func (m Model) nextDueSegment(now time.Time) string {
+	next := nextDue(m.reminders, now)
+	if next == nil {
+		return ""
+	}
+	return inputHintStyle.Render("next in "+formatCountdown(next.DateTime.Sub(now))+": ") +
+		normalStyle.Render(next.Description)
+}
+
+// nextDue returns the pending or snoozed reminder that will trigger soonest, or nil
+func nextDue(reminders []*reminder.Reminder, now time.Time) *reminder.Reminder {
+	var next *reminder.Reminder
+	for _, r := range reminders {
+		if !r.Status.Scheduled() || !r.DateTime.After(now) {
+			continue
+		}
+		if next == nil || r.DateTime.Before(next.DateTime) {
+			next = r
+		}
+	}
+	return next
+}
+
+// formatCountdown formats a duration compactly for the status bar, e.g. "2h 5m"
+func formatCountdown(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+	default:
+		return fmt.Sprintf("%dd %dh", int(d.Hours())/24, int(d.Hours())%24)
+	}
+}
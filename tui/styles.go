@@ -1,63 +1,105 @@
 package tui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	"go_remind/pkg/reminder"
+)
 
 // Styles
 var (
 	appStyle = lipgloss.NewStyle().Padding(1, 2)
 
 	titleStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("205")).
+			Foreground(lipgloss.AdaptiveColor{Light: "127", Dark: "205"}).
 			Bold(true).
 			MarginLeft(2)
 
 	normalStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("252"))
+			Foreground(lipgloss.AdaptiveColor{Light: "235", Dark: "252"})
 
 	triggeredStyle = lipgloss.NewStyle().
 			Bold(true).
-			Foreground(lipgloss.Color("196"))
+			Foreground(lipgloss.AdaptiveColor{Light: "160", Dark: "196"})
 
 	acknowledgedStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("241")).
+				Foreground(lipgloss.AdaptiveColor{Light: "245", Dark: "241"}).
 				Strikethrough(true)
 
+	snoozedStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.AdaptiveColor{Light: "235", Dark: "252"}).
+			Italic(true)
+
+	waitingStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.AdaptiveColor{Light: "127", Dark: "205"})
+
+	somedayStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.AdaptiveColor{Light: "245", Dark: "241"}).
+			Italic(true)
+
 	sourceStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("241"))
+			Foreground(lipgloss.AdaptiveColor{Light: "245", Dark: "241"})
 
 	tagStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("81"))
+			Foreground(lipgloss.AdaptiveColor{Light: "31", Dark: "81"})
 
 	selectedItemStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("170")).
+				Foreground(lipgloss.AdaptiveColor{Light: "91", Dark: "170"}).
 				Bold(true)
 
 	// Input box styles
 	inputBoxStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("205")).
+			Border(glyphs.Border).
+			BorderForeground(lipgloss.AdaptiveColor{Light: "127", Dark: "205"}).
 			Padding(0, 1).
 			MarginTop(1).
 			MarginBottom(1)
 
 	inputLabelStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("205")).
+			Foreground(lipgloss.AdaptiveColor{Light: "127", Dark: "205"}).
 			Bold(true)
 
 	inputHintStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("241")).
+			Foreground(lipgloss.AdaptiveColor{Light: "245", Dark: "241"}).
 			Italic(true)
 
 	// Welcome screen styles
 	welcomeTitleStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("205")).
+				Foreground(lipgloss.AdaptiveColor{Light: "127", Dark: "205"}).
 				Bold(true).
 				MarginBottom(1)
 
 	welcomeTextStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("252"))
+				Foreground(lipgloss.AdaptiveColor{Light: "235", Dark: "252"})
 
 	welcomeHighlightStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("170")).
+				Foreground(lipgloss.AdaptiveColor{Light: "91", Dark: "170"}).
 				Bold(true)
 )
+
+// statusStyle returns the style a reminder is drawn in for its status
+func statusStyle(status reminder.Status) lipgloss.Style {
+	switch status {
+	case reminder.Triggered:
+		return triggeredStyle
+	case reminder.Acknowledged:
+		return acknowledgedStyle
+	case reminder.Snoozed:
+		return snoozedStyle
+	case reminder.Waiting:
+		return waitingStyle
+	case reminder.Someday:
+		return somedayStyle
+	default:
+		return normalStyle
+	}
+}
+
+// modalBox draws content in the bordered box the modal views share
+func modalBox(content string) string {
+	return lipgloss.NewStyle().
+		Border(glyphs.Border).
+		BorderForeground(inputLabelStyle.GetForeground()).
+		Padding(1, 2).
+		Render(content)
+}
@@ -0,0 +1,59 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"go_remind/pkg/reminder"
+	"go_remind/statesync"
+)
+
+// SyncDoneMsg is sent when a background sync finishes
+type SyncDoneMsg struct {
+	base   []*reminder.Reminder // Snapshot the sync started from
+	merged []*reminder.Reminder
+	err    error
+}
+
+// WithSyncer returns a copy of the model that syncs state every interval
+func (m Model) WithSyncer(s statesync.Syncer, interval time.Duration) Model {
+	m.syncer = s
+	m.syncInterval = interval
+	m.nextSync = m.now().Add(interval)
+	return m
+}
+
+// checkSync starts a background sync if one is due
+func (m *Model) checkSync(now time.Time) tea.Cmd {
+	if m.syncer == nil || m.syncing || now.Before(m.nextSync) {
+		return nil
+	}
+	m.syncing = true
+	m.reportProgress(ProgressMsg{Op: "sync", Label: "Syncing"})
+
+	syncer := m.syncer
+	base := statesync.Snapshot(m.reminders)
+	return func() tea.Msg {
+		merged, err := syncer.Sync(base)
+		return SyncDoneMsg{base: base, merged: merged, err: err}
+	}
+}
+
+// applySync merges the result of a background sync into the current reminders.
+// Changes made while the sync was running are preserved.
+func (m *Model) applySync(msg SyncDoneMsg) {
+	m.syncing = false
+	m.reportProgress(ProgressMsg{Op: "sync", Finished: true})
+	m.nextSync = m.now().Add(m.syncInterval)
+
+	if msg.merged != nil {
+		m.reminders = statesync.Merge(msg.base, m.reminders, msg.merged)
+		m.refreshList()
+		m.saveState()
+		m.dupeCheckDue = true
+	}
+	if msg.err != nil {
+		m.toastError("Sync failed: " + msg.err.Error())
+	}
+}
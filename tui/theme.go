@@ -2,85 +2,133 @@ package tui
 
 import "github.com/charmbracelet/lipgloss"
 
+// Theme is a named set of colors. Each color has a light and a dark variant,
+// chosen by the terminal's background.
 type Theme struct {
 	Name        string
-	Title       lipgloss.Color
-	Normal      lipgloss.Color
-	Triggered   lipgloss.Color
-	Acknowledged lipgloss.Color
-	Source      lipgloss.Color
-	Selected    lipgloss.Color
-	Accent      lipgloss.Color
-	Muted       lipgloss.Color
+	Title       lipgloss.AdaptiveColor
+	Normal      lipgloss.AdaptiveColor
+	Triggered   lipgloss.AdaptiveColor
+	Acknowledged lipgloss.AdaptiveColor
+	Source      lipgloss.AdaptiveColor
+	Selected    lipgloss.AdaptiveColor
+	Accent      lipgloss.AdaptiveColor
+	Muted       lipgloss.AdaptiveColor
 }
 
 var themes = []Theme{
 	{
 		Name:        "Everforest",
-		Title:       lipgloss.Color("#A7C080"),
-		Normal:      lipgloss.Color("#D3C6AA"),
-		Triggered:   lipgloss.Color("#E67E80"),
-		Acknowledged: lipgloss.Color("#859289"),
-		Source:      lipgloss.Color("#859289"),
-		Selected:    lipgloss.Color("#83C092"),
-		Accent:      lipgloss.Color("#7FBBB3"),
-		Muted:       lipgloss.Color("#7A8478"),
+		Title:       lipgloss.AdaptiveColor{Light: "#8DA101", Dark: "#A7C080"},
+		Normal:      lipgloss.AdaptiveColor{Light: "#5C6A72", Dark: "#D3C6AA"},
+		Triggered:   lipgloss.AdaptiveColor{Light: "#F85552", Dark: "#E67E80"},
+		Acknowledged: lipgloss.AdaptiveColor{Light: "#939F91", Dark: "#859289"},
+		Source:      lipgloss.AdaptiveColor{Light: "#939F91", Dark: "#859289"},
+		Selected:    lipgloss.AdaptiveColor{Light: "#35A77C", Dark: "#83C092"},
+		Accent:      lipgloss.AdaptiveColor{Light: "#3A94C5", Dark: "#7FBBB3"},
+		Muted:       lipgloss.AdaptiveColor{Light: "#A6B0A0", Dark: "#7A8478"},
 	},
 	{
 		Name:        "Kiro Purple",
-		Title:       lipgloss.Color("205"),
-		Normal:      lipgloss.Color("252"),
-		Triggered:   lipgloss.Color("196"),
-		Acknowledged: lipgloss.Color("241"),
-		Source:      lipgloss.Color("241"),
-		Selected:    lipgloss.Color("170"),
-		Accent:      lipgloss.Color("205"),
-		Muted:       lipgloss.Color("241"),
+		Title:       lipgloss.AdaptiveColor{Light: "127", Dark: "205"},
+		Normal:      lipgloss.AdaptiveColor{Light: "235", Dark: "252"},
+		Triggered:   lipgloss.AdaptiveColor{Light: "160", Dark: "196"},
+		Acknowledged: lipgloss.AdaptiveColor{Light: "245", Dark: "241"},
+		Source:      lipgloss.AdaptiveColor{Light: "245", Dark: "241"},
+		Selected:    lipgloss.AdaptiveColor{Light: "91", Dark: "170"},
+		Accent:      lipgloss.AdaptiveColor{Light: "127", Dark: "205"},
+		Muted:       lipgloss.AdaptiveColor{Light: "245", Dark: "241"},
 	},
 	{
 		Name:        "Dracula",
-		Title:       lipgloss.Color("#bd93f9"),
-		Normal:      lipgloss.Color("#f8f8f2"),
-		Triggered:   lipgloss.Color("#ff5555"),
-		Acknowledged: lipgloss.Color("#6272a4"),
-		Source:      lipgloss.Color("#6272a4"),
-		Selected:    lipgloss.Color("#50fa7b"),
-		Accent:      lipgloss.Color("#ff79c6"),
-		Muted:       lipgloss.Color("#6272a4"),
+		Title:       lipgloss.AdaptiveColor{Light: "#644AC9", Dark: "#bd93f9"},
+		Normal:      lipgloss.AdaptiveColor{Light: "#1F1F1F", Dark: "#f8f8f2"},
+		Triggered:   lipgloss.AdaptiveColor{Light: "#CB3A2A", Dark: "#ff5555"},
+		Acknowledged: lipgloss.AdaptiveColor{Light: "#635D97", Dark: "#6272a4"},
+		Source:      lipgloss.AdaptiveColor{Light: "#635D97", Dark: "#6272a4"},
+		Selected:    lipgloss.AdaptiveColor{Light: "#14710A", Dark: "#50fa7b"},
+		Accent:      lipgloss.AdaptiveColor{Light: "#A3144D", Dark: "#ff79c6"},
+		Muted:       lipgloss.AdaptiveColor{Light: "#635D97", Dark: "#6272a4"},
 	},
 	{
 		Name:        "Nord",
-		Title:       lipgloss.Color("#88c0d0"),
-		Normal:      lipgloss.Color("#eceff4"),
-		Triggered:   lipgloss.Color("#bf616a"),
-		Acknowledged: lipgloss.Color("#4c566a"),
-		Source:      lipgloss.Color("#4c566a"),
-		Selected:    lipgloss.Color("#a3be8c"),
-		Accent:      lipgloss.Color("#81a1c1"),
-		Muted:       lipgloss.Color("#4c566a"),
+		Title:       lipgloss.AdaptiveColor{Light: "#5E81AC", Dark: "#88c0d0"},
+		Normal:      lipgloss.AdaptiveColor{Light: "#2E3440", Dark: "#eceff4"},
+		Triggered:   lipgloss.AdaptiveColor{Light: "#BF616A", Dark: "#bf616a"},
+		Acknowledged: lipgloss.AdaptiveColor{Light: "#7B88A1", Dark: "#4c566a"},
+		Source:      lipgloss.AdaptiveColor{Light: "#7B88A1", Dark: "#4c566a"},
+		Selected:    lipgloss.AdaptiveColor{Light: "#5A7D43", Dark: "#a3be8c"},
+		Accent:      lipgloss.AdaptiveColor{Light: "#5E81AC", Dark: "#81a1c1"},
+		Muted:       lipgloss.AdaptiveColor{Light: "#7B88A1", Dark: "#4c566a"},
 	},
 	{
 		Name:        "Solarized",
-		Title:       lipgloss.Color("#268bd2"),
-		Normal:      lipgloss.Color("#839496"),
-		Triggered:   lipgloss.Color("#dc322f"),
-		Acknowledged: lipgloss.Color("#586e75"),
-		Source:      lipgloss.Color("#586e75"),
-		Selected:    lipgloss.Color("#859900"),
-		Accent:      lipgloss.Color("#2aa198"),
-		Muted:       lipgloss.Color("#586e75"),
+		Title:       lipgloss.AdaptiveColor{Light: "#268BD2", Dark: "#268bd2"},
+		Normal:      lipgloss.AdaptiveColor{Light: "#657B83", Dark: "#839496"},
+		Triggered:   lipgloss.AdaptiveColor{Light: "#DC322F", Dark: "#dc322f"},
+		Acknowledged: lipgloss.AdaptiveColor{Light: "#93A1A1", Dark: "#586e75"},
+		Source:      lipgloss.AdaptiveColor{Light: "#93A1A1", Dark: "#586e75"},
+		Selected:    lipgloss.AdaptiveColor{Light: "#859900", Dark: "#859900"},
+		Accent:      lipgloss.AdaptiveColor{Light: "#2AA198", Dark: "#2aa198"},
+		Muted:       lipgloss.AdaptiveColor{Light: "#93A1A1", Dark: "#586e75"},
 	},
 	{
 		Name:        "Monokai",
-		Title:       lipgloss.Color("#f92672"),
-		Normal:      lipgloss.Color("#f8f8f2"),
-		Triggered:   lipgloss.Color("#f92672"),
-		Acknowledged: lipgloss.Color("#75715e"),
-		Source:      lipgloss.Color("#75715e"),
-		Selected:    lipgloss.Color("#a6e22e"),
-		Accent:      lipgloss.Color("#66d9ef"),
-		Muted:       lipgloss.Color("#75715e"),
+		Title:       lipgloss.AdaptiveColor{Light: "#E14775", Dark: "#f92672"},
+		Normal:      lipgloss.AdaptiveColor{Light: "#29242A", Dark: "#f8f8f2"},
+		Triggered:   lipgloss.AdaptiveColor{Light: "#E14775", Dark: "#f92672"},
+		Acknowledged: lipgloss.AdaptiveColor{Light: "#A59FA0", Dark: "#75715e"},
+		Source:      lipgloss.AdaptiveColor{Light: "#A59FA0", Dark: "#75715e"},
+		Selected:    lipgloss.AdaptiveColor{Light: "#269D69", Dark: "#a6e22e"},
+		Accent:      lipgloss.AdaptiveColor{Light: "#1C8CA8", Dark: "#66d9ef"},
+		Muted:       lipgloss.AdaptiveColor{Light: "#A59FA0", Dark: "#75715e"},
 	},
+	{
+		Name:        highContrastTheme,
+		Title:       lipgloss.AdaptiveColor{Light: "#000000", Dark: "#FFFF00"},
+		Normal:      lipgloss.AdaptiveColor{Light: "#000000", Dark: "#FFFFFF"},
+		Triggered:   lipgloss.AdaptiveColor{Light: "#B00000", Dark: "#FF5F5F"},
+		Acknowledged: lipgloss.AdaptiveColor{Light: "#303030", Dark: "#D0D0D0"},
+		Source:      lipgloss.AdaptiveColor{Light: "#303030", Dark: "#D0D0D0"},
+		Selected:    lipgloss.AdaptiveColor{Light: "#0000C0", Dark: "#00FF00"},
+		Accent:      lipgloss.AdaptiveColor{Light: "#0000C0", Dark: "#00FFFF"},
+		Muted:       lipgloss.AdaptiveColor{Light: "#303030", Dark: "#D0D0D0"},
+	},
+}
+
+// highContrastTheme is the built-in theme toggled by the contrast key
+const highContrastTheme = "High Contrast"
+
+// setHighContrast switches to the high-contrast theme, or back to the theme
+// that was active before it
+func (m *Model) setHighContrast(on bool) {
+	hc := themeIndexByName(highContrastTheme)
+	if on == (m.themeIndex == hc) {
+		return
+	}
+	if on {
+		m.plainTheme = m.themeIndex
+		m.themeIndex = hc
+	} else {
+		m.themeIndex = m.plainTheme
+	}
+	themes[m.themeIndex].applyStyles()
+}
+
+// useConfigTheme switches to the theme named in the config, if it's loaded.
+// With high contrast on, it becomes the theme high contrast returns to.
+func (m *Model) useConfigTheme() {
+	name := m.config.UI.Theme
+	i := themeIndexByName(name)
+	if name == "" || themes[i].Name != name {
+		return
+	}
+	if m.themeIndex == themeIndexByName(highContrastTheme) {
+		m.plainTheme = i
+		return
+	}
+	m.themeIndex = i
+	themes[i].applyStyles()
 }
 
 func (t Theme) applyStyles() {
@@ -100,6 +148,17 @@ func (t Theme) applyStyles() {
 		Foreground(t.Acknowledged).
 		Strikethrough(true)
 
+	snoozedStyle = lipgloss.NewStyle().
+		Foreground(t.Normal).
+		Italic(true)
+
+	waitingStyle = lipgloss.NewStyle().
+		Foreground(t.Accent)
+
+	somedayStyle = lipgloss.NewStyle().
+		Foreground(t.Muted).
+		Italic(true)
+
 	sourceStyle = lipgloss.NewStyle().
 		Foreground(t.Source)
 
@@ -108,7 +167,7 @@ func (t Theme) applyStyles() {
 		Bold(true)
 
 	inputBoxStyle = lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(glyphs.Border).
 		BorderForeground(t.Accent).
 		Padding(0, 1).
 		MarginTop(1).
@@ -134,3 +193,14 @@ func (t Theme) applyStyles() {
 		Foreground(t.Selected).
 		Bold(true)
 }
+
+// SetBackground overrides terminal background detection, which picks the
+// light or dark variant of each color: "light", "dark", or "auto" to detect
+func SetBackground(mode string) {
+	switch mode {
+	case "light":
+		lipgloss.SetHasDarkBackground(false)
+	case "dark":
+		lipgloss.SetHasDarkBackground(true)
+	}
+}
@@ -0,0 +1,174 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"go_remind/pkg/parser"
+	"go_remind/pkg/reminder"
+)
+
+const (
+	timelineLabelWidth = 24            // Width of the descriptions down the left
+	timelineDayWidth   = 2             // Columns per day
+	timelineMaxWeeks   = 8             // The most weeks shown, however wide the terminal
+	timelineWorkday    = 8 * time.Hour // How much of an estimate a bar draws as one day
+)
+
+// openTimeline shows a timeline of the reminders with a tag, starting with
+// the selected reminder's first tag
+func (m *Model) openTimeline() {
+	tags := m.getAllTags()
+	if len(tags) == 0 {
+		m.toastInfo("No tagged reminders to show a timeline for")
+		return
+	}
+	slices.SortFunc(tags, func(a, b string) int { return strings.Compare(strings.ToLower(a), strings.ToLower(b)) })
+	m.timelineTags = tags
+	m.timelineIndex = 0
+	if r := m.selectedReminder(); r != nil && len(r.Tags) > 0 {
+		m.timelineIndex = max(slices.Index(tags, r.Tags[0]), 0)
+	}
+	m.mode = modeTimeline
+}
+
+func (m Model) updateTimelineMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.String() == "esc", msg.String() == "q", key.Matches(msg, keys.Timeline):
+		m.mode = modeNormal
+	case key.Matches(msg, keys.Left), msg.String() == "shift+tab":
+		m.timelineIndex = (m.timelineIndex + len(m.timelineTags) - 1) % len(m.timelineTags)
+	case key.Matches(msg, keys.Right), msg.String() == "tab":
+		m.timelineIndex = (m.timelineIndex + 1) % len(m.timelineTags)
+	}
+	return m, nil
+}
+
+// timelineReminders returns the reminders tagged tag, soonest first.
+// Acknowledged ones are left out unless they fall in the timeline.
+func (m Model) timelineReminders(tag string, start time.Time) []*reminder.Reminder {
+	var rs []*reminder.Reminder
+	for _, r := range m.reminders {
+		if !slices.ContainsFunc(r.Tags, func(t string) bool { return strings.EqualFold(t, tag) }) {
+			continue
+		}
+		if r.Status == reminder.Acknowledged && r.DateTime.Before(start) {
+			continue
+		}
+		rs = append(rs, r)
+	}
+	slices.SortStableFunc(rs, func(a, b *reminder.Reminder) int { return a.DateTime.Compare(b.DateTime) })
+	return rs
+}
+
+// timelineWeeks is how many weeks of the timeline fit the terminal
+func (m Model) timelineWeeks() int {
+	width := m.width - 4 - timelineLabelWidth - 10 // Padding, labels, and due dates
+	return min(max(width/(7*timelineDayWidth), 1), timelineMaxWeeks)
+}
+
+// timelineView renders the selected tag's reminders across the coming
+// weeks, Gantt style: one row each, with a bar ending on its due day that
+// is as many days long as its estimate takes in working days
+func (m Model) timelineView() string {
+	tag := m.timelineTags[m.timelineIndex]
+	start := startOfDay(m.now())
+	days := m.timelineWeeks() * 7
+	end := start.AddDate(0, 0, days)
+
+	var b strings.Builder
+	b.WriteString(inputLabelStyle.Render(fmt.Sprintf("%s Timeline ", glyphs.Calendar)))
+	b.WriteString(tagStyle.Render("#" + tag))
+	b.WriteString(inputHintStyle.Render(fmt.Sprintf("  (%d of %d tags)", m.timelineIndex+1, len(m.timelineTags))))
+	b.WriteString("\n\n")
+
+	// Week labels across the top, then a mark for each day
+	var weeks, ticks strings.Builder
+	for d := 0; d < days; d += 7 {
+		weeks.WriteString(fmt.Sprintf("%-*s", 7*timelineDayWidth, start.AddDate(0, 0, d).Format("Jan 2")))
+	}
+	for d := range days {
+		tick := glyphs.Dot
+		if d%7 == 0 {
+			tick = "|"
+		}
+		ticks.WriteString(tick + strings.Repeat(" ", timelineDayWidth-1))
+	}
+	indent := strings.Repeat(" ", timelineLabelWidth+1)
+	b.WriteString(indent + inputHintStyle.Render(weeks.String()) + "\n")
+	b.WriteString(indent + sourceStyle.Render(ticks.String()) + "\n")
+
+	rs := m.timelineReminders(tag, start)
+	rows := max(m.height-10, 1)
+	for i, r := range rs {
+		if i == rows {
+			b.WriteString(inputHintStyle.Render(fmt.Sprintf("%s %d more", glyphs.Ellipsis, len(rs)-rows)) + "\n")
+			break
+		}
+		b.WriteString(m.timelineRow(r, start, end, days) + "\n")
+	}
+	if len(rs) == 0 {
+		b.WriteString(inputHintStyle.Render("Nothing coming up for #"+tag) + "\n")
+	}
+
+	sep := " " + glyphs.Bullet + " "
+	b.WriteString("\n")
+	b.WriteString(inputHintStyle.Render("←/→ another tag" + sep + "esc to close"))
+	return b.String()
+}
+
+// timelineRow renders one reminder's row of the timeline. Overdue
+// reminders are drawn on today; ones due after the timeline show their
+// date at the end instead.
+func (m Model) timelineRow(r *reminder.Reminder, start, end time.Time, days int) string {
+	due := startOfDay(r.DateTime)
+	style := statusStyle(r.Status)
+	overdue := due.Before(start)
+	if overdue {
+		due = start
+		style = triggeredStyle
+	}
+	barDays := 0
+	if r.Estimate > 0 {
+		barDays = int((r.Estimate + timelineWorkday - 1) / timelineWorkday)
+	}
+	barStart := due.AddDate(0, 0, -barDays)
+
+	label := ansi.Truncate(r.Description, timelineLabelWidth, glyphs.Ellipsis)
+	row := normalStyle.Render(label) + strings.Repeat(" ", timelineLabelWidth-lipgloss.Width(label)+1)
+
+	fill, bar, empty := glyphs.Shades[len(glyphs.Shades)-1], glyphs.Shades[2], glyphs.Shades[0]
+	for d := range days {
+		day := start.AddDate(0, 0, d)
+		switch {
+		case day.Equal(due):
+			row += style.Render(strings.Repeat(fill, timelineDayWidth))
+		case !day.Before(barStart) && day.Before(due):
+			row += style.Render(strings.Repeat(bar, timelineDayWidth))
+		default:
+			row += sourceStyle.Render(empty + strings.Repeat(" ", timelineDayWidth-1))
+		}
+	}
+
+	var notes []string
+	switch {
+	case overdue:
+		notes = append(notes, "overdue")
+	case !due.Before(end):
+		notes = append(notes, glyphs.Cursor+" "+r.DateTime.Format("Jan 2"))
+	}
+	if r.Estimate > 0 {
+		notes = append(notes, "~"+parser.FormatEstimate(r.Estimate))
+	}
+	if len(notes) > 0 {
+		row += " " + sourceStyle.Render(strings.Join(notes, " "))
+	}
+	return row
+}
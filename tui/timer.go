@@ -0,0 +1,77 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"go_remind/pkg/reminder"
+)
+
+// toggleTimer starts timing work on r, stopping any other running timer
+// first, or stops r's timer if it's running
+func (m *Model) toggleTimer(r *reminder.Reminder) {
+	if r == nil {
+		return
+	}
+	now := m.now()
+	if r.TimerRunning() {
+		r.StopTimer(now)
+		m.saveState()
+		m.toastInfo(fmt.Sprintf("Stopped timer: %s (%s total)", r.Description, formatSpent(r.TimeSpent(now))))
+		return
+	}
+	if r.Status == reminder.Acknowledged {
+		m.toastInfo("Reopen it to track time: " + r.Description)
+		return
+	}
+	if running := m.runningTimer(); running != nil {
+		running.StopTimer(now)
+	}
+	r.StartTimer(now)
+	m.saveState()
+	m.toastInfo("Started timer: " + r.Description)
+}
+
+// runningTimer returns the reminder whose timer is running, or nil
+func (m Model) runningTimer() *reminder.Reminder {
+	for _, r := range m.reminders {
+		if r.TimerRunning() {
+			return r
+		}
+	}
+	return nil
+}
+
+// timerSegment shows the running timer in the status bar, e.g.
+// "⏱ 12:05 Write report"
+func (m Model) timerSegment(now time.Time) string {
+	r := m.runningTimer()
+	if r == nil {
+		return ""
+	}
+	session := r.Sessions[len(r.Sessions)-1]
+	return triggeredStyle.Render(glyphs.Timer+" "+formatElapsed(now.Sub(session.Start))) + " " + normalStyle.Render(r.Description)
+}
+
+// formatElapsed formats a running timer like a stopwatch, e.g. "4:05" or
+// "1:02:03"
+func formatElapsed(d time.Duration) string {
+	d = max(d, 0).Truncate(time.Second)
+	h, m, s := int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%d:%02d", m, s)
+}
+
+// formatSpent formats total time spent, e.g. "1h 20m"
+func formatSpent(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "<1m"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	default:
+		return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+}
@@ -0,0 +1,136 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// toastLevel is the severity of a toast, which sets its color and lifetime
+type toastLevel int
+
+const (
+	toastInfo toastLevel = iota
+	toastSuccess
+	toastError
+)
+
+// maxToasts is how many toasts are shown at once; older ones are dropped
+const maxToasts = 3
+
+// toastDurations is how long each level of toast stays on screen
+var toastDurations = map[toastLevel]time.Duration{
+	toastInfo:    3 * time.Second,
+	toastSuccess: 3 * time.Second,
+	toastError:   6 * time.Second,
+}
+
+// toast is a transient message shown in the corner of the TUI
+type toast struct {
+	text    string
+	level   toastLevel
+	expires time.Time
+}
+
+// pushToast queues a message, dropping the oldest when the queue is full
+func (m *Model) pushToast(level toastLevel, text string) {
+	m.toasts = append(m.toasts, toast{
+		text:    text,
+		level:   level,
+		expires: m.now().Add(toastDurations[level]),
+	})
+	if len(m.toasts) > maxToasts {
+		m.toasts = m.toasts[len(m.toasts)-maxToasts:]
+	}
+}
+
+// toastInfo shows a neutral message
+func (m *Model) toastInfo(text string) { m.pushToast(toastInfo, text) }
+
+// toastSuccess shows a message confirming a completed action
+func (m *Model) toastSuccess(text string) { m.pushToast(toastSuccess, text) }
+
+// toastError shows a failure message, which stays up longer
+func (m *Model) toastError(text string) { m.pushToast(toastError, text) }
+
+// expireToasts removes toasts whose time is up
+func (m *Model) expireToasts(now time.Time) {
+	kept := m.toasts[:0]
+	for _, t := range m.toasts {
+		if now.Before(t.expires) {
+			kept = append(kept, t)
+		}
+	}
+	m.toasts = kept
+}
+
+// toastView renders the queued toasts stacked newest-last
+func (m Model) toastView() string {
+	if len(m.toasts) == 0 {
+		return ""
+	}
+
+	maxWidth := m.width / 2
+	if maxWidth < 20 {
+		maxWidth = 20
+	}
+
+	var boxes []string
+	for _, t := range m.toasts {
+		var color lipgloss.TerminalColor
+		icon := glyphs.Info
+		switch t.level {
+		case toastSuccess:
+			color = selectedItemStyle.GetForeground()
+			icon = glyphs.Success
+		case toastError:
+			color = triggeredStyle.GetForeground()
+			icon = glyphs.Error
+		default:
+			color = inputLabelStyle.GetForeground()
+		}
+
+		text := ansi.Truncate(icon+" "+t.text, maxWidth, glyphs.Ellipsis)
+		boxes = append(boxes, lipgloss.NewStyle().
+			Border(glyphs.Border).
+			BorderForeground(color).
+			Foreground(color).
+			Padding(0, 1).
+			Render(text))
+	}
+	return lipgloss.JoinVertical(lipgloss.Right, boxes...)
+}
+
+// withToasts draws the toast stack over the top-right corner of view
+func (m Model) withToasts(view string) string {
+	overlay := m.toastView()
+	if overlay == "" {
+		return view
+	}
+
+	width := m.width
+	if width <= 0 {
+		width = lipgloss.Width(view)
+	}
+
+	lines := strings.Split(view, "\n")
+	for i, line := range strings.Split(overlay, "\n") {
+		row := i + 1 // Leave the top padding row clear
+		for row >= len(lines) {
+			lines = append(lines, "")
+		}
+
+		x := width - lipgloss.Width(line) - 1
+		if x < 0 {
+			x = 0
+		}
+		base := ansi.Truncate(lines[row], x, "")
+		if pad := x - lipgloss.Width(base); pad > 0 {
+			base += strings.Repeat(" ", pad)
+		}
+		lines[row] = base + line
+	}
+	return strings.Join(lines, "\n")
+}
@@ -1,10 +1,28 @@
 package tui
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
-	"go_remind/reminder"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"go_remind/config"
+	"go_remind/pkg/clock"
+	"go_remind/pkg/parser"
+	"go_remind/pkg/reminder"
+	"go_remind/pkg/state"
+	"go_remind/pkg/watcher"
+	"go_remind/sources"
 )
 
 // createTestModel creates a properly initialized Model for testing
@@ -264,11 +282,12 @@ func TestEditPrefillFormat(t *testing.T) {
 		DateTime:    testTime,
 		Description: "Test reminder",
 		Status:      reminder.Pending,
+		Estimate:    45 * time.Minute,
 	}
 
 	// This is the format used in updateNormalMode when pressing 'e'
-	prefill := r.DateTime.Format("2006-01-02 15:04") + " " + r.Description
-	expected := "2026-01-15 14:30 Test reminder"
+	prefill := editText(r)
+	expected := "2026-01-15 14:30 Test reminder ~45m"
 
 	if prefill != expected {
 		t.Errorf("Prefill format = %q, want %q", prefill, expected)
@@ -289,4 +308,3436 @@ func TestEditPrefillFormat(t *testing.T) {
 	if !r.DateTime.Equal(testTime) {
 		t.Errorf("DateTime after round-trip = %v, want %v", r.DateTime, testTime)
 	}
+
+	if r.Estimate != 45*time.Minute {
+		t.Errorf("Estimate after round-trip = %v, want 45m", r.Estimate)
+	}
+}
+
+func TestPasteFromClipboard(t *testing.T) {
+	m := createTestModel(t, nil)
+
+	m.applyClipboard(ClipboardMsg{Text: "[remind_me +1h Call mom]\n+2h Water plants"})
+	if m.mode != modePaste {
+		t.Fatalf("mode = %v, want modePaste", m.mode)
+	}
+	if len(m.reminders) != 0 {
+		t.Fatal("reminders should not be added before confirming")
+	}
+
+	updated, _ := m.updatePasteMode(tea.KeyMsg{Type: tea.KeyEnter})
+	got := updated.(Model)
+	if got.mode != modeNormal {
+		t.Errorf("mode after confirm = %v, want modeNormal", got.mode)
+	}
+	if len(got.reminders) != 2 {
+		t.Fatalf("got %d reminders after confirm, want 2", len(got.reminders))
+	}
+	for _, r := range got.reminders {
+		if r.ID == "" {
+			t.Errorf("pasted reminder %q has no ID", r.Description)
+		}
+	}
+}
+
+func TestPasteNothingParseable(t *testing.T) {
+	m := createTestModel(t, nil)
+
+	m.applyClipboard(ClipboardMsg{Text: "just some text"})
+	if m.mode != modeNormal {
+		t.Errorf("mode = %v, want modeNormal when nothing parses", m.mode)
+	}
+}
+
+func TestStatusBarNextDue(t *testing.T) {
+	now := time.Now()
+	reminders := []*reminder.Reminder{
+		{Description: "Later", DateTime: now.Add(2 * time.Hour), Status: reminder.Pending},
+		{Description: "Standup", DateTime: now.Add(12*time.Minute + 30*time.Second), Status: reminder.Pending},
+		{Description: "Overdue", DateTime: now.Add(-time.Hour), Status: reminder.Triggered},
+		{Description: "Done", DateTime: now.Add(time.Minute), Status: reminder.Acknowledged},
+	}
+
+	next := nextDue(reminders, now)
+	if next == nil || next.Description != "Standup" {
+		t.Fatalf("nextDue() = %v, want Standup", next)
+	}
+	if got := formatCountdown(next.DateTime.Sub(now)); got != "12m" {
+		t.Errorf("formatCountdown() = %q, want %q", got, "12m")
+	}
+
+	m := createTestModel(t, reminders)
+	m.width = 200
+	bar := m.statusBarView()
+	for _, want := range []string{"4 total", "2 pending", "1 triggered", "1 done", "next in 12m: Standup"} {
+		if !strings.Contains(bar, want) {
+			t.Errorf("status bar %q missing %q", bar, want)
+		}
+	}
+}
+
+func TestToastQueue(t *testing.T) {
+	m := createTestModel(t, nil)
+	m.width = 100
+
+	m.toastInfo("one")
+	m.toastSuccess("two")
+	m.toastError("three")
+	m.toastInfo("four")
+	if len(m.toasts) != maxToasts {
+		t.Fatalf("got %d toasts, want %d", len(m.toasts), maxToasts)
+	}
+	if m.toasts[0].text != "two" {
+		t.Errorf("oldest toast = %q, want %q (one should be dropped)", m.toasts[0].text, "two")
+	}
+
+	view := m.withToasts(strings.Repeat("base line\n", 12))
+	for _, want := range []string{"two", "three", "four", "base line"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+
+	// Errors outlive info and success toasts
+	m.expireToasts(time.Now().Add(4 * time.Second))
+	if len(m.toasts) != 1 || m.toasts[0].level != toastError {
+		t.Errorf("after 4s got %v, want only the error toast", m.toasts)
+	}
+	m.expireToasts(time.Now().Add(7 * time.Second))
+	if len(m.toasts) != 0 {
+		t.Errorf("after 7s got %d toasts, want 0", len(m.toasts))
+	}
+}
+
+func TestProgressOperations(t *testing.T) {
+	m := createTestModel(t, nil)
+
+	if cmd := m.applyProgress(ProgressMsg{Op: "parse", Label: "Parsing notes"}); cmd == nil {
+		t.Error("first operation should start the spinner")
+	}
+	if cmd := m.applyProgress(ProgressMsg{Op: "sync", Label: "Syncing"}); cmd != nil {
+		t.Error("spinner is already running, no new tick expected")
+	}
+	m.applyProgress(ProgressMsg{Op: "parse", Label: "Parsing notes", Done: 3, Total: 10})
+
+	segment := m.progressSegment()
+	for _, want := range []string{"Parsing notes 3/10", "Syncing"} {
+		if !strings.Contains(segment, want) {
+			t.Errorf("progress segment %q missing %q", segment, want)
+		}
+	}
+
+	m.applyProgress(ProgressMsg{Op: "parse", Finished: true})
+	m.applyProgress(ProgressMsg{Op: "sync", Finished: true})
+	if m.progressSegment() != "" {
+		t.Errorf("progress segment should be empty when idle, got %q", m.progressSegment())
+	}
+}
+
+const testThemeTOML = `name = "%s"
+title = "#ff0000"
+normal = "252"
+triggered = "#f00"
+acknowledged = "241"
+source = "241"
+selected = "#00ff00"
+accent = "%s"
+muted = "241"
+`
+
+func writeTheme(t *testing.T, dir, file, name, accent string) {
+	t.Helper()
+	content := fmt.Sprintf(testThemeTOML, name, accent)
+	if err := os.WriteFile(filepath.Join(dir, file), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write theme: %v", err)
+	}
+}
+
+func TestLoadThemes(t *testing.T) {
+	dir := t.TempDir()
+	writeTheme(t, dir, "a.toml", "Mine", "#123456")
+	writeTheme(t, dir, "b.toml", "Broken", "not-a-color")
+	writeTheme(t, dir, "c.toml", "Mine", "#654321")
+
+	loaded, err := LoadThemes(dir)
+	if len(loaded) != 1 || loaded[0].Name != "Mine" {
+		t.Fatalf("LoadThemes() = %v, want only Mine", loaded)
+	}
+	if err == nil {
+		t.Fatal("LoadThemes() should report the invalid and duplicate themes")
+	}
+	for _, want := range []string{"b.toml", "accent", "c.toml", "duplicate"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q missing %q", err, want)
+		}
+	}
+
+	if _, err := LoadThemes(filepath.Join(dir, "missing")); err != nil {
+		t.Errorf("missing directory should not be an error: %v", err)
+	}
+}
+
+func TestThemesHotReload(t *testing.T) {
+	t.Cleanup(func() { setUserThemes(nil) })
+
+	dir := t.TempDir()
+	writeTheme(t, dir, "mine.toml", "Mine", "#123456")
+
+	m := createTestModel(t, nil)
+	*m = m.WithThemes(dir)
+	if len(themes) != builtinThemeCount+1 {
+		t.Fatalf("got %d themes, want %d", len(themes), builtinThemeCount+1)
+	}
+	m.themeIndex = len(themes) - 1
+
+	writeTheme(t, dir, "mine.toml", "Mine", "#abcdef")
+	select {
+	case msg := <-m.themeEvents:
+		m.applyThemes(msg)
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timeout waiting for theme reload")
+	}
+
+	if themes[m.themeIndex].Name != "Mine" {
+		t.Errorf("selected theme = %q, want Mine", themes[m.themeIndex].Name)
+	}
+	if got := themes[m.themeIndex].Accent; got.Dark != "#abcdef" {
+		t.Errorf("Accent after reload = %v, want #abcdef", got)
+	}
+}
+
+func TestLoadThemeLightVariant(t *testing.T) {
+	dir := t.TempDir()
+	content := fmt.Sprintf(testThemeTOML, "Mine", "#123456") + `
+[light]
+accent = "#fedcba"
+`
+	if err := os.WriteFile(filepath.Join(dir, "mine.toml"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write theme: %v", err)
+	}
+
+	loaded, err := LoadThemes(dir)
+	if err != nil || len(loaded) != 1 {
+		t.Fatalf("LoadThemes() = %v, %v", loaded, err)
+	}
+	want := lipgloss.AdaptiveColor{Light: "#fedcba", Dark: "#123456"}
+	if loaded[0].Accent != want {
+		t.Errorf("Accent = %+v, want %+v", loaded[0].Accent, want)
+	}
+	// Slots without a light override use the dark color on both
+	if loaded[0].Title.Light != "#ff0000" {
+		t.Errorf("Title.Light = %q, want the dark color #ff0000", loaded[0].Title.Light)
+	}
+}
+
+func TestSetKeyBindings(t *testing.T) {
+	original := keys
+	t.Cleanup(func() { keys = original })
+
+	now := time.Now()
+	r := &reminder.Reminder{Description: "Task", DateTime: now.Add(time.Hour), Status: reminder.Pending}
+
+	if err := SetKeyBindings(map[string][]string{"detail": {"i"}, "up": {"up", "K"}}); err != nil {
+		t.Fatalf("SetKeyBindings() error: %v", err)
+	}
+	if got := keys.Detail.Help().Key; got != "i" {
+		t.Errorf("Detail help key = %q, want %q", got, "i")
+	}
+	if got := keys.Up.Help().Key; got != "↑/K" {
+		t.Errorf("Up help key = %q, want %q", got, "↑/K")
+	}
+
+	m := createTestModel(t, []*reminder.Reminder{r})
+	updated, _ := m.updateNormalMode(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")})
+	if got := updated.(Model); got.mode != modeDetail {
+		t.Errorf("mode after remapped detail key = %v, want modeDetail", got.mode)
+	}
+}
+
+func TestSetKeyBindingsRejectsConflicts(t *testing.T) {
+	original := keys
+	t.Cleanup(func() { keys = original })
+
+	err := SetKeyBindings(map[string][]string{"detail": {"q"}})
+	if err == nil || !strings.Contains(err.Error(), "detail and quit") {
+		t.Errorf("SetKeyBindings() error = %v, want conflict between detail and quit", err)
+	}
+	if got := keys.Detail.Keys(); len(got) != 1 || got[0] != "K" {
+		t.Errorf("Detail keys = %v, want defaults kept after a conflict", got)
+	}
+
+	// Match keys share n and N with other actions, but not with each other
+	err = SetKeyBindings(map[string][]string{"next_match": {"N"}})
+	if err == nil || !strings.Contains(err.Error(), "next_match and prev_match") {
+		t.Errorf("SetKeyBindings() error = %v, want conflict between next_match and prev_match", err)
+	}
+
+	if err := SetKeyBindings(map[string][]string{"nope": {"x"}}); err == nil {
+		t.Error("SetKeyBindings() should reject unknown actions")
+	}
+}
+
+func TestCheatsheet(t *testing.T) {
+	original := keys
+	t.Cleanup(func() { keys = original })
+	if err := SetKeyBindings(map[string][]string{"detail": {"i"}}); err != nil {
+		t.Fatalf("SetKeyBindings() error: %v", err)
+	}
+
+	m := createTestModel(t, nil)
+	m.openCheatsheet()
+	if m.mode != modeCheatsheet {
+		t.Fatalf("mode = %v, want modeCheatsheet", m.mode)
+	}
+
+	m.helpSearch.SetValue("snooze")
+	titles, rows := m.cheatsheetRows()
+	if len(titles) != 1 || titles[0] != "Reminders" || len(rows[0]) != 3 {
+		t.Errorf("search for snooze = %v %v, want the three snooze keys under Reminders", titles, rows)
+	}
+
+	m.helpSearch.SetValue("detail")
+	_, rows = m.cheatsheetRows()
+	if len(rows) != 1 || rows[0][0].keys != "i" || rows[0][0].defaultKey != "K" {
+		t.Errorf("remapped detail row = %v, want key i with default K", rows)
+	}
+
+	// Escape clears the search first, then closes
+	updated, _ := m.updateCheatsheetMode(tea.KeyMsg{Type: tea.KeyEscape})
+	got := updated.(Model)
+	if got.mode != modeCheatsheet || got.helpSearch.Value() != "" {
+		t.Errorf("first esc should clear the search, mode = %v, search = %q", got.mode, got.helpSearch.Value())
+	}
+	updated, _ = got.updateCheatsheetMode(tea.KeyMsg{Type: tea.KeyEscape})
+	if got := updated.(Model); got.mode != modeNormal {
+		t.Errorf("second esc should close, mode = %v", got.mode)
+	}
+}
+
+func TestASCIIMode(t *testing.T) {
+	originalKeys, originalDefaults := keys, defaultKeys
+	originalSymbols := make(map[string]string)
+	for k, v := range keySymbols {
+		originalSymbols[k] = v
+	}
+	t.Cleanup(func() {
+		glyphs, keys, defaultKeys, keySymbols = unicodeGlyphs, originalKeys, originalDefaults, originalSymbols
+	})
+	SetASCII(true)
+
+	now := time.Now()
+	m := createTestModel(t, []*reminder.Reminder{
+		{ID: "1", DateTime: now.Add(-time.Hour), Description: "Past", Status: reminder.Triggered, SourceFile: "a.md"},
+		{ID: "2", DateTime: now.Add(time.Hour), Description: "Later", Status: reminder.Pending, SourceFile: "a.md"},
+	})
+	m.width, m.height = 100, 40
+	m.toastError("Save failed")
+
+	for _, layout := range []LayoutMode{LayoutCompact, LayoutCard} {
+		currentLayout = layout
+		view := m.View()
+		for _, r := range view {
+			if r > 127 {
+				t.Errorf("%s layout contains non-ASCII %q:\n%s", layoutNames[layout], r, view)
+				break
+			}
+		}
+		if !strings.Contains(view, glyphs.Triggered) {
+			t.Errorf("%s layout does not mark the triggered reminder", layoutNames[layout])
+		}
+	}
+	currentLayout = LayoutCompact
+}
+
+func TestHighContrastToggle(t *testing.T) {
+	m := createTestModel(t, nil)
+	m.themeIndex = 2
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("C")})
+	got := updated.(Model)
+	if themes[got.themeIndex].Name != highContrastTheme {
+		t.Fatalf("theme = %q, want %q", themes[got.themeIndex].Name, highContrastTheme)
+	}
+
+	updated, _ = got.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("C")})
+	if got := updated.(Model); got.themeIndex != 2 {
+		t.Errorf("toggling off restored theme %d, want 2", got.themeIndex)
+	}
+	themes[0].applyStyles()
+}
+
+func TestNarrowLayouts(t *testing.T) {
+	now := time.Now()
+	m := createTestModel(t, []*reminder.Reminder{
+		{ID: "1", DateTime: now.Add(-time.Hour), Description: "Renew the car registration before the office closes", Status: reminder.Triggered, SourceFile: "/notes/errands.md"},
+		{ID: "2", DateTime: now.Add(time.Hour), Description: "Standup", Status: reminder.Pending, SourceFile: "/notes/work.md", Tags: []string{"work"}},
+	})
+	t.Cleanup(func() { currentLayout = LayoutCard })
+
+	for _, width := range []int{120, 80, 50} {
+		updated, _ := m.Update(tea.WindowSizeMsg{Width: width, Height: 40})
+		got := updated.(Model)
+		for _, layout := range []LayoutMode{LayoutCompact, LayoutCard} {
+			currentLayout = layout
+			view := got.mainView()
+			for _, line := range strings.Split(view, "\n") {
+				if lipgloss.Width(line) > width {
+					t.Errorf("%s at width %d: line is %d wide: %q", layoutNames[layout], width, lipgloss.Width(line), line)
+				}
+			}
+		}
+	}
+
+	r := m.reminders[0]
+	if line := ansi.Strip(renderCompactLine(">", r, 120, now, "", normalStyle)); !strings.Contains(line, "TRIGGERED") || !strings.Contains(line, "errands.md") || lipgloss.Width(line) > 120 {
+		t.Errorf("wide compact line = %q; want full status and source, within 120", line)
+	}
+	if line := ansi.Strip(renderCompactLine(">", r, 70, now, "", normalStyle)); strings.Contains(line, "errands.md") || !strings.Contains(line, " DUE ") || lipgloss.Width(line) > 70 {
+		t.Errorf("narrow compact line = %q; want abbreviated status, no source, within 70", line)
+	}
+}
+
+func TestStatsView(t *testing.T) {
+	// Tuesday, January 13, 2026 at 9am
+	tue9 := time.Date(2026, 1, 13, 9, 0, 0, 0, time.Local)
+	m := createTestModel(t, []*reminder.Reminder{
+		{ID: "1", DateTime: tue9, Description: "Standup", Status: reminder.Acknowledged},
+		{ID: "2", DateTime: tue9.AddDate(0, 0, 7), Description: "Standup", Status: reminder.Pending},
+	})
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("H")})
+	got := updated.(Model)
+	if got.mode != modeStats {
+		t.Fatalf("mode = %v, want modeStats", got.mode)
+	}
+	view := got.statsView()
+	if !strings.Contains(view, "busiest slot Tue 9am (2)") {
+		t.Errorf("stats view missing busiest slot:\n%s", view)
+	}
+	// The second standup was never acknowledged, ending the streak
+	if !strings.Contains(view, "Current streak: 0 days") || !strings.Contains(view, "Longest: 1 day") {
+		t.Errorf("stats view missing streaks:\n%s", view)
+	}
+
+	updated, _ = got.Update(tea.KeyMsg{Type: tea.KeyEscape})
+	if got := updated.(Model); got.mode != modeNormal {
+		t.Errorf("esc left mode = %v, want modeNormal", got.mode)
+	}
+}
+
+func TestActivityView(t *testing.T) {
+	store := state.NewStore(filepath.Join(t.TempDir(), "reminders_state.json"))
+	due := time.Date(2026, 1, 13, 9, 0, 0, 0, time.Local)
+	r := &reminder.Reminder{ID: "1", DateTime: due, Description: "Standup", Status: reminder.Pending}
+	other := &reminder.Reminder{ID: "2", DateTime: due.Add(time.Hour), Description: "Lunch", Status: reminder.Pending}
+	if err := store.Save([]*reminder.Reminder{r, other}); err != nil {
+		t.Fatal(err)
+	}
+	r.Snooze(time.Hour, time.Now())
+	if err := store.Save([]*reminder.Reminder{r, other}); err != nil {
+		t.Fatal(err)
+	}
+
+	m := createTestModel(t, []*reminder.Reminder{r, other}).WithActivity(store.Activity())
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("A")})
+	got := updated.(Model)
+	view := got.View()
+	if got.mode != modeActivity || !strings.Contains(view, "snoozed       Standup  until Jan 13 10:00") || !strings.Contains(view, "created       Lunch") {
+		t.Fatalf("A should open the activity view with every change:\n%s", view)
+	}
+	// Newest first
+	if strings.Index(view, "snoozed") > strings.Index(view, "created") {
+		t.Errorf("activity view should list the newest change first:\n%s", view)
+	}
+	updated, _ = got.Update(tea.KeyMsg{Type: tea.KeyEscape})
+	got = updated.(Model)
+
+	// The detail view's History tab shows just this reminder's changes
+	got.list.Select(0)
+	updated, _ = got.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("K")})
+	for range 2 {
+		updated, _ = updated.(Model).Update(tea.KeyMsg{Type: tea.KeyTab})
+	}
+	got = updated.(Model)
+	view = got.View()
+	if got.detailTab != detailTabHistory || !strings.Contains(view, "snoozed") || strings.Contains(view, "Lunch") {
+		t.Errorf("History tab should show the standup's changes:\n%s", view)
+	}
+	updated, _ = got.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	updated, _ = updated.(Model).Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	if view := updated.(Model).View(); !strings.Contains(view, "Description:") {
+		t.Errorf("shift+tab should go back to the Info tab:\n%s", view)
+	}
+}
+
+func TestDetailTabs(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local)
+	long := strings.Repeat("Draft the quarterly planning document with the whole team ", 8)
+	rs := []*reminder.Reminder{
+		{ID: "a", DateTime: now.Add(time.Hour), Description: long, Tags: []string{"work"}, SourceFile: "/notes/work.md", Status: reminder.Pending},
+		{ID: "b", DateTime: now.Add(2 * time.Hour), Description: "Review the budget", Tags: []string{"work", "money"}, SourceFile: "/notes/other.md", Status: reminder.Pending},
+		{ID: "c", DateTime: now.Add(3 * time.Hour), Description: "Book a room", SourceFile: "/notes/work.md", Status: reminder.Pending},
+		{ID: "d", DateTime: now.Add(4 * time.Hour), Description: "Water the plants", SourceFile: reminder.StandaloneSource, Status: reminder.Pending},
+	}
+	m := New(rs, nil, nil).WithClock(clock.Fixed(now))
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	got := updated.(Model)
+	got.list.Select(0)
+	updated, _ = got.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("K")})
+	if view := updated.(Model).View(); !strings.Contains(view, "more lines on the Notes tab") {
+		t.Errorf("Info tab should cut a long description short:\n%s", view)
+	}
+
+	// Notes has the whole description
+	updated, _ = updated.(Model).Update(tea.KeyMsg{Type: tea.KeyTab})
+	if view := updated.(Model).View(); strings.Count(view, "Draft") != 8 {
+		t.Errorf("Notes tab should show the whole description:\n%s", view)
+	}
+
+	// Related lists reminders with the same tag or file
+	for range 4 {
+		updated, _ = updated.(Model).Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	}
+	got = updated.(Model)
+	view := got.View()
+	if got.detailTab != detailTabRelated || !strings.Contains(view, "Review the budget") || !strings.Contains(view, "#work") ||
+		!strings.Contains(view, "Book a room") || !strings.Contains(view, "same file") || strings.Contains(view, "Water the plants") {
+		t.Fatalf("Related tab should list the reminders sharing a tag or file:\n%s", view)
+	}
+
+	// Enter opens the selected one
+	for range 3 {
+		updated, _ = updated.(Model).Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	updated, _ = updated.(Model).Update(tea.KeyMsg{Type: tea.KeyEnter})
+	got = updated.(Model)
+	if got.detailReminder != rs[2] || got.detailTab != detailTabInfo || rs[0].Status != reminder.Pending {
+		t.Errorf("enter opened %q on tab %d, want the last related reminder's info", got.detailReminder.Description, got.detailTab)
+	}
+}
+
+func TestSplitPane(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local)
+	rs := []*reminder.Reminder{
+		{ID: "a", DateTime: now.Add(time.Hour), Description: "Standup", Status: reminder.Pending},
+		{ID: "b", DateTime: now.Add(2 * time.Hour), Description: "Review the budget", From: "Ada <ada@example.com>", Status: reminder.Pending},
+	}
+	m := New(rs, nil, nil).WithClock(clock.Fixed(now))
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	updated, _ = updated.(Model).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("|")})
+	got := updated.(Model)
+	if !got.splitActive() || got.listWidth() >= 160 {
+		t.Fatalf("| should turn on the split pane: active %v, list width %d", got.splitActive(), got.listWidth())
+	}
+
+	// The pane follows the cursor
+	got.list.Select(1)
+	got.gridIndex = 1
+	view := got.View()
+	if !strings.Contains(view, "From: Ada <ada@example.com>") || !strings.Contains(view, "Press K to focus") {
+		t.Fatalf("pane should show the selected reminder:\n%s", view)
+	}
+
+	// K focuses it, keeping the list beside it, and esc goes back
+	updated, _ = got.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("K")})
+	got = updated.(Model)
+	view = got.View()
+	if got.mode != modeDetail || !strings.Contains(view, "Standup") || !strings.Contains(view, "Press ESC to return to the list") {
+		t.Fatalf("K should focus the pane beside the list:\n%s", view)
+	}
+	updated, _ = got.Update(tea.KeyMsg{Type: tea.KeyEscape})
+	if got := updated.(Model); got.mode != modeNormal {
+		t.Errorf("esc left mode %v, want the list focused", got.mode)
+	}
+
+	// Too narrow, the detail view is a modal again
+	updated, _ = updated.(Model).Update(tea.WindowSizeMsg{Width: 100, Height: 40})
+	if got := updated.(Model); got.splitActive() || got.listWidth() != 100 {
+		t.Errorf("split pane shown at 100 columns")
+	}
+}
+
+func TestGrouping(t *testing.T) {
+	saved := currentLayout
+	currentLayout = LayoutCompact
+	t.Cleanup(func() { currentLayout = saved })
+
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local)
+	rs := []*reminder.Reminder{
+		{ID: "a", DateTime: now.Add(time.Hour), Description: "Water the plants", Tags: []string{"home"}, Status: reminder.Pending},
+		{ID: "b", DateTime: now.Add(2 * time.Hour), Description: "Ship the release", Tags: []string{"work"}, Priority: reminder.PriorityHigh, Status: reminder.Pending},
+		{ID: "c", DateTime: now.Add(3 * time.Hour), Description: "Fix the gate", Tags: []string{"home", "diy"}, Status: reminder.Pending},
+		{ID: "d", DateTime: now.Add(4 * time.Hour), Description: "Call mom", Status: reminder.Pending},
+	}
+	m := New(rs, nil, nil).WithClock(clock.Fixed(now))
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	if view := updated.(Model).View(); !strings.Contains(view, "Coming Up! (4)") {
+		t.Fatalf("time sections should show their counts:\n%s", view)
+	}
+
+	press := func(k string) {
+		t.Helper()
+		updated, _ = updated.(Model).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+	}
+	press("b")
+	press("b")
+	got := updated.(Model)
+	view := got.View()
+	for _, want := range []string{"#home (2)", "#work (1)", "Untagged (1)", "by tag"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("grouped by tag, view is missing %q:\n%s", want, view)
+		}
+	}
+	if order := got.getFilteredReminders(); order[0].ID != "a" || order[1].ID != "c" || order[3].ID != "d" {
+		t.Errorf("tag groups should be alphabetical with untagged last, got %s %s %s %s", order[0].ID, order[1].ID, order[2].ID, order[3].ID)
+	}
+
+	// The section keys move between groups
+	press("}")
+	if got := updated.(Model); got.compactIndex != 2 {
+		t.Errorf("} moved to %d, want the #work group at 2", got.compactIndex)
+	}
+
+	press("b")
+	view = updated.(Model).View()
+	if !strings.Contains(view, "High (1)") || !strings.Contains(view, "No Priority (3)") {
+		t.Errorf("grouped by priority:\n%s", view)
+	}
+	press("b")
+	if got := updated.(Model); got.grouping != groupByTime {
+		t.Errorf("b should cycle back to time sections, got %s", groupingNames[got.grouping])
+	}
+}
+
+func TestSectionBadges(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local)
+	rs := []*reminder.Reminder{
+		{ID: "a", DateTime: now.Add(-3 * time.Hour), Description: "Standup", Status: reminder.Triggered},
+		{ID: "b", DateTime: now.Add(-2 * time.Hour), Description: "Expenses", Status: reminder.Triggered},
+		{ID: "c", DateTime: now.Add(-time.Hour), Description: "Email", Status: reminder.Acknowledged},
+		{ID: "d", DateTime: now.AddDate(0, 0, 1), Description: "Groceries", Status: reminder.Pending},
+	}
+	m := New(rs, nil, nil).WithClock(clock.Fixed(now))
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	view := updated.(Model).View()
+	for _, want := range []string{"Due (3, 2 unacknowledged)", "Tomorrow (1)", "2 unacknowledged · Due 3 · Tomorrow 1"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view is missing %q:\n%s", want, view)
+		}
+	}
+}
+
+// fakeViewSettings keeps view settings in memory
+type fakeViewSettings struct {
+	saved state.ViewSettings
+}
+
+func (f *fakeViewSettings) LoadViewSettings() (state.ViewSettings, error) { return f.saved, nil }
+
+func (f *fakeViewSettings) SaveViewSettings(s state.ViewSettings) error {
+	f.saved = s
+	return nil
+}
+
+func TestSortOrder(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local)
+	rs := []*reminder.Reminder{
+		{ID: "a", DateTime: now.Add(time.Hour), Description: "standup", CreatedAt: now.Add(-3 * time.Hour), Status: reminder.Pending},
+		{ID: "b", DateTime: now.Add(2 * time.Hour), Description: "Budget review", Priority: reminder.PriorityLow, CreatedAt: now.Add(-time.Hour), Status: reminder.Pending},
+		{ID: "c", DateTime: now.Add(3 * time.Hour), Description: "Call mom", Priority: reminder.PriorityHigh, CreatedAt: now.Add(-2 * time.Hour), Status: reminder.Pending},
+		{ID: "d", DateTime: now.Add(3 * time.Hour), Description: "Answer email", Status: reminder.Pending},
+	}
+	settings := &fakeViewSettings{}
+	m := New(rs, nil, nil).WithClock(clock.Fixed(now)).WithViewSettings(settings)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	order := func() string {
+		var ids string
+		for _, r := range updated.(Model).getFilteredReminders() {
+			ids += r.ID
+		}
+		return ids
+	}
+	// Each press of r moves to the next order; ties keep their saved order
+	want := []string{"abcd", "cdba", "cbad", "cbad", "bcad", "dbca", "abcd"}
+	for i, w := range want {
+		if i > 0 {
+			updated, _ = updated.(Model).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+		}
+		if got := order(); got != w {
+			t.Errorf("sorted by %s: got %s, want %s", sortOrderNames[updated.(Model).sortOrder], got, w)
+		}
+	}
+
+	// The order is shown in the status bar and kept for the next session
+	updated, _ = updated.(Model).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	if bar := updated.(Model).statusBarView(); !strings.Contains(bar, "by time · due ↓") {
+		t.Errorf("status bar should show the sort order: %q", bar)
+	}
+	if settings.saved.Sort != "due-desc" {
+		t.Errorf("saved sort = %q, want due-desc", settings.saved.Sort)
+	}
+	if next := New(rs, nil, nil).WithViewSettings(settings); next.sortOrder != sortDueDesc {
+		t.Errorf("next session starts sorted by %s", sortOrderNames[next.sortOrder])
+	}
+}
+
+func TestSessionRestore(t *testing.T) {
+	saved := currentLayout
+	t.Cleanup(func() { currentLayout = saved })
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local)
+	var rs []*reminder.Reminder
+	for i, desc := range []string{"Call mom", "Pay rent", "Call dad", "Water plants", "Call the bank"} {
+		rs = append(rs, &reminder.Reminder{ID: string(rune('a' + i)), DateTime: now.Add(time.Duration(i+1) * time.Hour), Description: desc, Status: reminder.Pending})
+	}
+	settings := &fakeViewSettings{}
+	m := New(rs, nil, nil).WithClock(clock.Fixed(now)).WithViewSettings(settings)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	send := func(msgs ...tea.KeyMsg) Model {
+		t.Helper()
+		for _, msg := range msgs {
+			updated, _ = updated.(Model).Update(msg)
+		}
+		return updated.(Model)
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	// Quitting saves the layout, filter, and selection
+	send(runes("v"), runes("/"), runes("call"), tea.KeyMsg{Type: tea.KeyEnter}, runes("j"), runes("q"))
+	got := settings.saved
+	if got.Layout != "Compact" || got.Filter != "call" || got.Selected != "c" {
+		t.Fatalf("saved %+v, want the compact layout, filter call, and c selected", got)
+	}
+
+	// The next session starts the same way once it knows its size
+	currentLayout = LayoutCard
+	next := New(rs, nil, nil).WithClock(clock.Fixed(now)).WithViewSettings(settings)
+	restored, _ := next.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = restored.(Model)
+	if currentLayout != LayoutCompact {
+		t.Errorf("layout = %s, want Compact", layoutNames[currentLayout])
+	}
+	if m.filterInput.Value() != "call" || len(m.getFilteredReminders()) != 3 {
+		t.Errorf("filter = %q with %d reminders, want call with 3", m.filterInput.Value(), len(m.getFilteredReminders()))
+	}
+	if r := m.selectedReminder(); r == nil || r.ID != "c" {
+		t.Errorf("selected %v, want c", r)
+	}
+}
+
+func TestMutedReminders(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local)
+	parse := func() []*reminder.Reminder {
+		var rs []*reminder.Reminder
+		for i, desc := range []string{"Call mom", "Pay rent"} {
+			rs = append(rs, &reminder.Reminder{ID: reminder.FileID("/notes/today.md", desc), DateTime: now.Add(time.Duration(i+1) * time.Hour),
+				Description: desc, SourceFile: "/notes/today.md", Status: reminder.Pending})
+		}
+		return rs
+	}
+	m := New(parse(), nil, nil).WithClock(clock.Fixed(now))
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	send := func(msgs ...tea.Msg) Model {
+		t.Helper()
+		for _, msg := range msgs {
+			updated, _ = updated.(Model).Update(msg)
+		}
+		return updated.(Model)
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+	descriptions := func(m Model) []string {
+		var descs []string
+		for _, r := range m.reminders {
+			descs = append(descs, r.Description)
+		}
+		return descs
+	}
+
+	// A deleted file reminder stays deleted when the file is saved again
+	got := send(runes("d"), runes("d"), FileUpdateMsg{FilePath: "/notes/today.md", Reminders: parse()})
+	if descs := descriptions(got); !slices.Equal(descs, []string{"Pay rent"}) {
+		t.Fatalf("reminders after the file was saved = %v, want only Pay rent", descs)
+	}
+
+	// It's listed in the muted panel, and unmuting brings it back
+	if got = send(runes("M")); got.mode != modeMuted || !strings.Contains(got.View(), "Call mom") {
+		t.Fatalf("muted panel should list Call mom:\n%s", got.View())
+	}
+	if got = send(runes("u")); got.mode != modeNormal || len(got.muted) != 0 {
+		t.Errorf("mode = %v with %d mutes after unmuting the last one", got.mode, len(got.muted))
+	}
+	got = send(FileUpdateMsg{FilePath: "/notes/today.md", Reminders: parse()})
+	if descs := descriptions(got); len(descs) != 2 {
+		t.Errorf("reminders after unmuting = %v, want both", descs)
+	}
+}
+
+func TestWriteBack(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local)
+	path := filepath.Join(t.TempDir(), "today.md")
+	if err := os.WriteFile(path, []byte("# Today\n\n- [remind_me 3pm Call mom #family]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	parse := func() []*reminder.Reminder {
+		t.Helper()
+		rs, err := parser.ParseFile(path, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rs
+	}
+	cfg := config.Default()
+	cfg.Parser.WriteBack = true
+	m := New(parse(), nil, nil).WithConfig(cfg).WithClock(clock.Fixed(now))
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	send := func(msgs ...tea.Msg) Model {
+		t.Helper()
+		for _, msg := range msgs {
+			updated, _ = updated.(Model).Update(msg)
+		}
+		return updated.(Model)
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	// Editing rewrites the token in the file
+	send(runes("e"), tea.KeyMsg{Type: tea.KeyCtrlU}, runes("2026-03-05 09:00 Call mom back #family"), tea.KeyMsg{Type: tea.KeyEnter})
+	data, _ := os.ReadFile(path)
+	if want := "- [remind_me 2026-03-05 09:00 Call mom back #family]\n"; !strings.HasSuffix(string(data), want) {
+		t.Fatalf("file after the edit:\n%s\nwant it to end with %q", data, want)
+	}
+
+	// The watcher's update for it merges into the edited reminder quietly
+	got := send(FileUpdateMsg{FilePath: path, Reminders: parse()})
+	if len(got.reminders) != 1 || got.reminders[0].Description != "Call mom back" {
+		t.Errorf("reminders after the file update = %v, want only the edited one", got.reminders)
+	}
+	for _, toast := range got.toasts {
+		if strings.Contains(toast.text, "File updated") {
+			t.Errorf("the write-back was announced as a file update")
+		}
+	}
+}
+
+func TestYearlyReminders(t *testing.T) {
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.Local)
+	r, err := parser.ParseEntry("every year Mar 14 1958 7d before Mom's birthday", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.ID = "bday"
+	clk := clock.NewFake(now)
+	m := New([]*reminder.Reminder{r}, nil, nil).WithClock(clk)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	// Cards show it repeats and which birthday is coming up
+	if view := updated.(Model).View(); !strings.Contains(view, "yearly 68th") {
+		t.Errorf("view should label the yearly reminder with its count:\n%s", view)
+	}
+
+	// Once acknowledged and the day has passed, it comes back for next year
+	r.Acknowledge(time.Date(2026, 3, 7, 9, 5, 0, 0, time.Local))
+	clk.Set(time.Date(2026, 3, 15, 9, 0, 0, 0, time.Local))
+	updated, _ = updated.(Model).Update(TickMsg(clk.Now()))
+	if want := time.Date(2027, 3, 7, 9, 0, 0, 0, time.Local); r.Status != reminder.Pending || !r.DateTime.Equal(want) {
+		t.Errorf("after the birthday, reminder is %v at %v, want pending at %v", r.Status, r.DateTime, want)
+	}
+	if view := updated.(Model).View(); !strings.Contains(view, "yearly 69th") {
+		t.Errorf("view should count next year's birthday:\n%s", view)
+	}
+}
+
+func TestRelativeReminders(t *testing.T) {
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.Local)
+	release := &reminder.Reminder{ID: "rel", Description: "Ship it", DateTime: now.Add(24 * time.Hour), Tags: []string{"release"}, Status: reminder.Pending}
+	retro, err := parser.ParseEntry("2d after #release Retro meeting", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	retro.ID = "retro"
+	m := New([]*reminder.Reminder{release, retro}, nil, nil).WithClock(clock.Fixed(now))
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	if want := now.Add(3 * 24 * time.Hour); !retro.DateTime.Equal(want) {
+		t.Fatalf("retro at %v, want %v", retro.DateTime, want)
+	}
+
+	// Moving the release moves the retro with it
+	got := updated.(Model)
+	got.selectReminder(release)
+	if err := got.updateReminder(release, "2026-03-10 09:00 Ship it #release"); err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2026, 3, 12, 9, 0, 0, 0, time.Local); !retro.DateTime.Equal(want) {
+		t.Errorf("retro at %v after the release moved, want %v", retro.DateTime, want)
+	}
+	if text := editText(retro); text != "2d after #release Retro meeting" {
+		t.Errorf("editText() = %q, want the relative time", text)
+	}
+
+	// Without the tag, the reminder stays put and says why
+	release.Tags = nil
+	got.refreshList()
+	if len(got.toasts) == 0 || !strings.Contains(got.toasts[len(got.toasts)-1].text, "no reminder is tagged #release") {
+		t.Errorf("toasts = %v, want one saying the release is missing", got.toasts)
+	}
+}
+
+func TestTimeline(t *testing.T) {
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.Local)
+	rs := []*reminder.Reminder{
+		{ID: "1", Description: "Write docs", DateTime: now.Add(-24 * time.Hour), Tags: []string{"launch"}, Status: reminder.Triggered},
+		{ID: "2", Description: "Ship it", DateTime: now.AddDate(0, 0, 10), Tags: []string{"launch"}, Estimate: 16 * time.Hour, Status: reminder.Pending},
+		{ID: "3", Description: "Announce", DateTime: now.AddDate(0, 3, 0), Tags: []string{"launch"}, Status: reminder.Pending},
+		{ID: "4", Description: "Buy milk", DateTime: now.Add(time.Hour), Tags: []string{"home"}, Status: reminder.Pending},
+	}
+	m := New(rs, nil, nil).WithClock(clock.Fixed(now))
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	send := func(msgs ...tea.Msg) Model {
+		t.Helper()
+		for _, msg := range msgs {
+			updated, _ = updated.(Model).Update(msg)
+		}
+		return updated.(Model)
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	// It opens on the selected reminder's tag
+	got := send(runes("W"))
+	view := got.View()
+	if got.mode != modeTimeline || !strings.Contains(view, "#launch") {
+		t.Fatalf("mode %v, view:\n%s\nwant the #launch timeline", got.mode, view)
+	}
+	for _, want := range []string{"Write docs", "overdue", "Ship it", "~16h", "Announce", "Jun 2", "Mar 2"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("timeline should show %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "Buy milk") {
+		t.Errorf("timeline shows a reminder without the tag:\n%s", view)
+	}
+
+	// The arrows switch tags, and esc goes back to the list
+	if view := send(tea.KeyMsg{Type: tea.KeyRight}).View(); !strings.Contains(view, "#home") || !strings.Contains(view, "Buy milk") {
+		t.Errorf("right should show the next tag:\n%s", view)
+	}
+	if got := send(tea.KeyMsg{Type: tea.KeyEsc}); got.mode != modeNormal {
+		t.Errorf("esc left the mode at %v", got.mode)
+	}
+}
+
+func TestWorkspaces(t *testing.T) {
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.Local)
+	rs := []*reminder.Reminder{
+		{ID: "1", Description: "Review PR", DateTime: now.Add(time.Hour), Tags: []string{"work"}, Status: reminder.Pending},
+		{ID: "2", Description: "Buy milk", DateTime: now.Add(2 * time.Hour), Tags: []string{"home"}, Status: reminder.Pending},
+	}
+	path := filepath.Join(t.TempDir(), "config.toml")
+	cfg := config.Default()
+	cfg.Workspaces = []config.WorkspaceConfig{{Name: "Home", Filter: "#home", Group: "file", Layout: "compact"}}
+	t.Cleanup(func() { currentLayout = LayoutCard })
+	currentLayout = LayoutCard
+	m := New(rs, nil, nil).WithConfig(cfg).WithClock(clock.Fixed(now)).WithWorkspaces(path)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	send := func(msgs ...tea.Msg) Model {
+		t.Helper()
+		for _, msg := range msgs {
+			updated, _ = updated.(Model).Update(msg)
+		}
+		return updated.(Model)
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	// Number keys switch to a workspace's filter, grouping, and layout
+	got := send(runes("V"), runes("1"))
+	if got.mode != modeNormal || got.filterInput.Value() != "#home" || got.grouping != groupByFile || currentLayout != LayoutCompact {
+		t.Fatalf("after switching: mode %v, filter %q, grouping %v, layout %v", got.mode, got.filterInput.Value(), got.grouping, currentLayout)
+	}
+	if items := got.getFilteredReminders(); len(items) != 1 || items[0].ID != "2" {
+		t.Errorf("Home workspace shows %v, want only Buy milk", items)
+	}
+
+	// The current view is saved to the config file under a name
+	send(runes("V"), runes("s"), runes("Errands"), tea.KeyMsg{Type: tea.KeyEnter})
+	loaded, err := config.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := config.WorkspaceConfig{Name: "Errands", Filter: "#home", Group: "file", Sort: "due", Layout: "compact"}
+	if len(loaded.Workspaces) != 1 || loaded.Workspaces[0] != want {
+		t.Errorf("saved workspaces = %+v, want %+v", loaded.Workspaces, want)
+	}
+	if got := send(); got.mode != modeWorkspaces || !strings.Contains(got.View(), "2 Errands") {
+		t.Errorf("the switcher should list the new workspace second:\n%s", got.View())
+	}
+}
+
+func TestMergePreview(t *testing.T) {
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.Local)
+	const path = "/notes/plan.md"
+	parsed := func(descs ...string) []*reminder.Reminder {
+		var rs []*reminder.Reminder
+		for _, desc := range descs {
+			rs = append(rs, &reminder.Reminder{ID: reminder.FileID(path, desc), Description: desc, DateTime: now.Add(time.Hour), SourceFile: path, Status: reminder.Pending})
+		}
+		return rs
+	}
+	cfg := config.Default()
+	cfg.Merge.ConfirmRemovals = 3
+	m := New(parsed("A", "B", "C", "D", "E"), nil, nil).WithConfig(cfg).WithClock(clock.Fixed(now))
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	send := func(msgs ...tea.Msg) Model {
+		t.Helper()
+		for _, msg := range msgs {
+			updated, _ = updated.(Model).Update(msg)
+		}
+		return updated.(Model)
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	// A small change goes straight through
+	got := send(FileUpdateMsg{FilePath: path, Reminders: parsed("A", "B", "C", "D", "E", "F")})
+	if got.mode != modeNormal || len(got.reminders) != 6 {
+		t.Fatalf("mode %v with %d reminders, want the new one merged", got.mode, len(got.reminders))
+	}
+
+	// Removing most of them asks first, showing the diff
+	got = send(FileUpdateMsg{FilePath: path, Reminders: parsed("A", "G")})
+	if got.mode != modeMergePreview || len(got.reminders) != 6 {
+		t.Fatalf("mode %v with %d reminders, want the change held for review", got.mode, len(got.reminders))
+	}
+	view := got.View()
+	for _, want := range []string{"Large change to plan.md", "remove 5 reminders", "Removed (5)", "- B", "Added (1)", "+ G", "Kept (1)"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("preview should show %q:\n%s", want, view)
+		}
+	}
+
+	// Rejecting it leaves the reminders alone; approving applies it
+	if got = send(runes("n")); got.mode != modeNormal || len(got.reminders) != 6 {
+		t.Errorf("after rejecting: mode %v with %d reminders, want all 6 kept", got.mode, len(got.reminders))
+	}
+	send(FileUpdateMsg{FilePath: path, Reminders: parsed("A", "G")})
+	if got = send(runes("y")); got.mode != modeNormal || len(got.reminders) != 2 {
+		t.Errorf("after approving: mode %v with %d reminders, want A and G", got.mode, len(got.reminders))
+	}
+}
+
+func TestMergeLog(t *testing.T) {
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.Local)
+	const path = "/notes/plan.md"
+	parsed := func(descs ...string) []*reminder.Reminder {
+		var rs []*reminder.Reminder
+		for _, desc := range descs {
+			rs = append(rs, &reminder.Reminder{ID: reminder.FileID(path, desc), Description: desc, DateTime: now.Add(time.Hour), SourceFile: path, Status: reminder.Pending})
+		}
+		return rs
+	}
+	cfg := config.Default()
+	cfg.Merge.ConfirmRemovals = 2
+	m := New(parsed("A", "B", "C"), nil, nil).WithConfig(cfg).WithClock(clock.Fixed(now))
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	send := func(msgs ...tea.Msg) Model {
+		t.Helper()
+		for _, msg := range msgs {
+			updated, _ = updated.(Model).Update(msg)
+		}
+		return updated.(Model)
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	if got := send(runes("I")); !strings.Contains(got.View(), "No files have been updated yet") {
+		t.Errorf("empty merge log should say so:\n%s", got.View())
+	}
+	send(tea.KeyMsg{Type: tea.KeyEscape})
+
+	// One update applied, one large one turned down
+	send(FileUpdateMsg{FilePath: path, Reminders: parsed("A", "C", "D")})
+	send(FileUpdateMsg{FilePath: path, Reminders: parsed("E")}, runes("n"))
+
+	got := send(runes("I"))
+	if got.mode != modeMergeLog || len(got.mergeLog) != 2 {
+		t.Fatalf("mode %v with %d logged, want the merge log with 2 updates", got.mode, len(got.mergeLog))
+	}
+	view := got.View()
+	for _, want := range []string{"1 added, 3 removed, 0 kept, not applied", "1 added, 1 removed, 2 kept", "Removed (3)", "+ E"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("merge log should show %q:\n%s", want, view)
+		}
+	}
+
+	// The older update's details
+	view = send(tea.KeyMsg{Type: tea.KeyDown}).View()
+	for _, want := range []string{"- B", "+ D", "Kept (2)", "2 of 2"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("older update should show %q:\n%s", want, view)
+		}
+	}
+	if got = send(tea.KeyMsg{Type: tea.KeyEscape}); got.mode != modeNormal {
+		t.Errorf("mode %v after esc, want normal", got.mode)
+	}
+}
+
+func TestWatcherHealth(t *testing.T) {
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.Local)
+	health := watcher.Health{
+		Files:     12,
+		Dirs:      3,
+		LastEvent: map[string]time.Time{"/notes/todo.md": now.Add(-time.Minute), "/notes/work/plan.md": now.Add(-2 * time.Minute)},
+	}
+	m := New(nil, nil, nil).WithClock(clock.Fixed(now)).
+		WithWatcherHealth(func() map[string]watcher.Health { return map[string]watcher.Health{"/notes": health} })
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	send := func(msgs ...tea.Msg) Model {
+		t.Helper()
+		for _, msg := range msgs {
+			updated, _ = updated.(Model).Update(msg)
+		}
+		return updated.(Model)
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	// A healthy watcher raises nothing
+	if got := send(TickMsg(now)); len(got.toasts) != 0 {
+		t.Errorf("toasts = %+v, want none while healthy", got.toasts)
+	}
+
+	// New problems are announced once
+	health.Overflows = 1
+	health.Failures = []watcher.Failure{{Path: "/notes/big", Err: "no space left on device", At: now}}
+	got := send(TickMsg(now))
+	if len(got.toasts) != 1 || !strings.Contains(got.toasts[0].text, "2 new problems") {
+		t.Errorf("toasts = %+v, want one about 2 new problems", got.toasts)
+	}
+	if got = send(TickMsg(now)); len(got.toasts) != 1 {
+		t.Errorf("toasts = %+v, want no second warning for the same problems", got.toasts)
+	}
+
+	got = send(runes("X"))
+	if got.mode != modeWatcherHealth {
+		t.Fatalf("mode = %v, want the watcher health panel", got.mode)
+	}
+	view := got.View()
+	for _, want := range []string{"/notes", "12 files", "3 directories", "1 overflow", "Changes were lost", "10:00:00", "Failures (1)", "big: no space left on device"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("panel should show %q:\n%s", want, view)
+		}
+	}
+	if todo, plan := strings.Index(view, "todo.md"), strings.Index(view, "work/plan.md"); todo < 0 || plan < todo {
+		t.Errorf("last changes should be newest first:\n%s", view)
+	}
+	if got = send(tea.KeyMsg{Type: tea.KeyEscape}); got.mode != modeNormal {
+		t.Errorf("mode %v after esc, want normal", got.mode)
+	}
+
+	// Running out of watches is explained, once
+	health.Limited, health.Polled = true, 2
+	got = send(TickMsg(now), TickMsg(now))
+	if len(got.toasts) != 2 || !strings.Contains(got.toasts[1].text, "Too many files to watch") {
+		t.Errorf("toasts = %+v, want one about the watch limit", got.toasts)
+	}
+	view = send(runes("X")).watcherHealthView()
+	for _, want := range []string{"2 directories polled", "limit on file watches"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("panel should show %q:\n%s", want, view)
+		}
+	}
+}
+
+func TestJumpList(t *testing.T) {
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.Local)
+	var rs []*reminder.Reminder
+	for i, desc := range []string{"A", "B", "C", "D", "E"} {
+		rs = append(rs, &reminder.Reminder{ID: desc, Description: desc, DateTime: now.Add(time.Duration(i+1) * time.Hour), Status: reminder.Pending})
+	}
+	currentLayout = LayoutCompact
+	t.Cleanup(func() { currentLayout = LayoutCard })
+	m := New(rs, nil, nil).WithClock(clock.Fixed(now))
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	send := func(msgs ...tea.Msg) Model {
+		t.Helper()
+		for _, msg := range msgs {
+			updated, _ = updated.(Model).Update(msg)
+		}
+		return updated.(Model)
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+	back, forward := tea.KeyMsg{Type: tea.KeyCtrlO}, tea.KeyMsg{Type: tea.KeyTab}
+	selected := func(m Model) string {
+		if r := m.selectedReminder(); r != nil {
+			return r.Description
+		}
+		return ""
+	}
+
+	if got := send(back); got.jumpIndex != 0 || selected(got) != "A" {
+		t.Errorf("ctrl+o with no jumps should stay on A, got %q", selected(got))
+	}
+
+	// Moving line by line isn't a jump, but G is
+	send(runes("j"), runes("j"), runes("G"))
+	if got := send(back); selected(got) != "C" {
+		t.Errorf("ctrl+o after G should return to C, got %q", selected(got))
+	}
+	if got := send(forward); selected(got) != "E" {
+		t.Errorf("ctrl+i should return to E, got %q", selected(got))
+	}
+
+	// Back through the detail view to the list, then forward into it again
+	send(back, runes("K"))
+	if got := send(back); got.mode != modeNormal || selected(got) != "C" {
+		t.Errorf("ctrl+o from detail should return to C in the list, got %q in mode %v", selected(got), got.mode)
+	}
+	if got := send(forward); got.mode != modeDetail || got.detailReminder.Description != "C" {
+		t.Errorf("ctrl+i should reopen C's detail, got mode %v", got.mode)
+	}
+
+	// A new jump forgets the positions gone back past
+	send(back, runes("{"))
+	if got := send(forward); selected(got) != "A" || got.mode != modeNormal {
+		t.Errorf("ctrl+i after a new jump should stay put, got %q in mode %v", selected(got), got.mode)
+	}
+	if got := send(back); selected(got) != "C" {
+		t.Errorf("ctrl+o after { should return to C, got %q", selected(got))
+	}
+}
+
+func TestMarks(t *testing.T) {
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.Local)
+	rs := []*reminder.Reminder{
+		{ID: "1", Description: "Standup", DateTime: now.Add(time.Hour), Status: reminder.Pending, Tags: []string{"work"}},
+		{ID: "2", Description: "Renew passport", DateTime: now.Add(2 * time.Hour), Status: reminder.Pending},
+		{ID: "3", Description: "Call the plumber", DateTime: now.Add(3 * time.Hour), Status: reminder.Pending},
+	}
+	currentLayout = LayoutCompact
+	t.Cleanup(func() { currentLayout = LayoutCard })
+	m := New(rs, nil, nil).WithClock(clock.Fixed(now))
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	send := func(msgs ...tea.Msg) Model {
+		t.Helper()
+		for _, msg := range msgs {
+			updated, _ = updated.(Model).Update(msg)
+		}
+		return updated.(Model)
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+	selected := func(m Model) string {
+		if r := m.selectedReminder(); r != nil {
+			return r.Description
+		}
+		return ""
+	}
+
+	// Mark the passport renewal as a, and go back to it from the top
+	send(runes("j"), runes("m"), runes("a"), runes("k"))
+	if got := send(runes("'"), runes("a")); selected(got) != "Renew passport" {
+		t.Errorf("'a should go to the marked reminder, got %q", selected(got))
+	}
+	if got := send(tea.KeyMsg{Type: tea.KeyCtrlO}); selected(got) != "Standup" {
+		t.Errorf("going to a mark should be a jump, got %q after ctrl+o", selected(got))
+	}
+
+	// Even when the filter hides it
+	got := send(runes("/"), runes("#work"), tea.KeyMsg{Type: tea.KeyEnter}, runes("'"), runes("a"))
+	if selected(got) != "Renew passport" || got.filterInput.Value() != "" {
+		t.Errorf("'a should clear the filter hiding the mark, got %q with filter %q", selected(got), got.filterInput.Value())
+	}
+
+	if got := send(runes("'"), runes("b")); selected(got) != "Renew passport" {
+		t.Errorf("an unset mark shouldn't move the cursor, got %q", selected(got))
+	}
+	if got := send(runes(":")); !strings.Contains(got.commandView(), "'a Renew passport") {
+		t.Errorf("command line should list the marks:\n%s", got.commandView())
+	}
+	send(tea.KeyMsg{Type: tea.KeyEscape})
+
+	// A deleted reminder's mark goes with it
+	send(runes("d"), runes("d"), runes("'"), runes("a"))
+	if got := send(); len(got.marks) != 0 {
+		t.Errorf("mark of a deleted reminder should be dropped, got %v", got.marks)
+	}
+}
+
+func TestLabels(t *testing.T) {
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.Local)
+	rs := []*reminder.Reminder{
+		{ID: "1", Description: "Standup", DateTime: now.Add(time.Hour), Status: reminder.Pending},
+		{ID: "2", Description: "Renew passport", DateTime: now.Add(2 * time.Hour), Status: reminder.Pending},
+	}
+	m := New(rs, nil, nil).WithClock(clock.Fixed(now))
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	send := func(msgs ...tea.Msg) Model {
+		t.Helper()
+		for _, msg := range msgs {
+			updated, _ = updated.(Model).Update(msg)
+		}
+		return updated.(Model)
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	got := send(runes("c"), runes("c"))
+	if rs[0].Label != reminder.LabelYellow {
+		t.Fatalf("pressing c twice should label yellow, got %v", rs[0].Label)
+	}
+	if view := got.View(); strings.Count(view, glyphs.Label) != 1 {
+		t.Errorf("card layout should show one label dot:\n%s", view)
+	}
+	currentLayout = LayoutCompact
+	t.Cleanup(func() { currentLayout = LayoutCard })
+	if view := got.View(); strings.Count(view, glyphs.Label) != 1 {
+		t.Errorf("compact layout should show one label dot:\n%s", view)
+	}
+
+	got = send(runes("/"), runes("label:yellow"), tea.KeyMsg{Type: tea.KeyEnter})
+	if items := got.getFilteredReminders(); len(items) != 1 || items[0] != rs[0] {
+		t.Errorf("label:yellow should filter to the labeled reminder, got %d", len(items))
+	}
+
+	if got = send(runes("K")); !strings.Contains(got.View(), "yellow") {
+		t.Errorf("detail view should show the label:\n%s", got.View())
+	}
+	send(runes("c"), runes("c"), runes("c"), runes("c"))
+	if rs[0].Label != reminder.LabelNone {
+		t.Errorf("cycling past purple should clear the label, got %v", rs[0].Label)
+	}
+}
+
+func TestSourcePreview(t *testing.T) {
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.Local)
+	path := filepath.Join(t.TempDir(), "plan.md")
+	var lines []string
+	for i := 1; i <= 20; i++ {
+		lines = append(lines, fmt.Sprintf("line %d", i))
+	}
+	lines[9] = "- Demo [remind_me 3pm Show the prototype] to the team"
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	rs, err := parser.ParseFile(path, now)
+	if err != nil || len(rs) != 1 {
+		t.Fatalf("ParseFile() = %v, %v", rs, err)
+	}
+	m := New(rs, nil, nil).WithClock(clock.Fixed(now))
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	send := func(msgs ...tea.Msg) Model {
+		t.Helper()
+		for _, msg := range msgs {
+			updated, _ = updated.(Model).Update(msg)
+		}
+		return updated.(Model)
+	}
+
+	got := send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("K")}, tea.KeyMsg{Type: tea.KeyShiftTab}, tea.KeyMsg{Type: tea.KeyShiftTab})
+	view := got.View()
+	if got.detailTab != detailTabSource || !strings.Contains(view, "[remind_me 3pm Show the prototype]") ||
+		!strings.Contains(view, "line 5") || strings.Contains(view, "line 4 ") || !strings.Contains(view, "line 15") || strings.Contains(view, "line 16") {
+		t.Fatalf("Source tab should show five lines either side of the token:\n%s", view)
+	}
+
+	// Lines added above move the token, and the preview follows it
+	lines = append([]string{"# Plan", "", "Intro"}, lines...)
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	reparsed, _ := parser.ParseFile(path, now)
+	got = send(FileUpdateMsg{FilePath: path, Reminders: reparsed})
+	if got.detailSource.line != 13 || !strings.Contains(got.View(), "line 12") {
+		t.Errorf("preview should be read again when the file changes, token on line %d:\n%s", got.detailSource.line, got.View())
+	}
+
+	standalone := &reminder.Reminder{ID: "x", Description: "Water the plants", DateTime: now, SourceFile: reminder.StandaloneSource}
+	got.openDetail(standalone)
+	got.detailTab = detailTabSource
+	if view := got.View(); !strings.Contains(view, "no file to show") {
+		t.Errorf("a reminder added in the TUI has no file to show:\n%s", view)
+	}
+}
+
+func TestLinks(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local)
+	plan := &reminder.Reminder{ID: "1", DateTime: now.Add(time.Hour), Status: reminder.Pending, SourceFile: "/notes/plan.md",
+		Description: "Review https://example.com/pr/7, and the notes in ./design.md."}
+	bare := &reminder.Reminder{ID: "2", DateTime: now.Add(2 * time.Hour), Status: reminder.Pending, SourceFile: "/notes/plan.md",
+		Description: "Read (https://go.dev/blog)"}
+	none := &reminder.Reminder{ID: "3", DateTime: now.Add(3 * time.Hour), Status: reminder.Pending, SourceFile: "/notes/plan.md",
+		Description: "Stretch"}
+
+	ls := links(plan)
+	if len(ls) != 2 || ls[0].target != "https://example.com/pr/7" || ls[1].text != "./design.md" || ls[1].target != "/notes/design.md" {
+		t.Fatalf("links() = %v, want the URL and the path relative to the file", ls)
+	}
+
+	var opened []string
+	defer func(orig func(string) tea.Cmd) { openURL = orig }(openURL)
+	openURL = func(url string) tea.Cmd { opened = append(opened, url); return nil }
+
+	m := New([]*reminder.Reminder{plan, bare, none}, nil, nil).WithClock(clock.Fixed(now))
+	var updated tea.Model = m
+	updated, _ = updated.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	send := func(msgs ...tea.Msg) Model {
+		t.Helper()
+		for _, msg := range msgs {
+			updated, _ = updated.(Model).Update(msg)
+		}
+		return updated.(Model)
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	// With more than one link, o shows them on the Links tab to pick from
+	got := send(runes("o"))
+	view := got.View()
+	if got.mode != modeDetail || got.detailTab != detailTabLinks || len(opened) != 0 ||
+		!strings.Contains(view, "https://example.com/pr/7") || !strings.Contains(view, "/notes/design.md") {
+		t.Fatalf("o should list the links on the Links tab, mode %v, tab %v, opened %v:\n%s", got.mode, got.detailTab, opened, view)
+	}
+	got = send(runes("j"), tea.KeyMsg{Type: tea.KeyEnter})
+	if len(opened) != 1 || opened[0] != "/notes/design.md" {
+		t.Errorf("enter opened %v, want the selected path", opened)
+	}
+	got = send(runes("k"), runes("o"))
+	if len(opened) != 2 || opened[1] != "https://example.com/pr/7" {
+		t.Errorf("o on the Links tab opened %v, want the selected URL", opened)
+	}
+
+	// With one, o opens it straight away
+	got = send(tea.KeyMsg{Type: tea.KeyEscape})
+	got.selectReminder(bare)
+	updated = got
+	got = send(runes("o"))
+	if got.mode != modeNormal || len(opened) != 3 || opened[2] != "https://go.dev/blog" {
+		t.Errorf("o should open the only link, mode %v, opened %v", got.mode, opened)
+	}
+
+	got.selectReminder(none)
+	updated = got
+	if got = send(runes("o")); len(opened) != 3 || !strings.Contains(got.View(), "No link to open") {
+		t.Errorf("o with no links opened %v:\n%s", opened, got.View())
+	}
+
+	// A failed opener says so
+	if got = send(LinkOpenedMsg{target: "/notes/design.md", err: errors.New("exit status 3")}); !strings.Contains(got.View(), "Could not open /notes/design.md") {
+		t.Errorf("a failed opener should be reported:\n%s", got.View())
+	}
+}
+
+func TestPeople(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local)
+	rs := []*reminder.Reminder{
+		{ID: "1", DateTime: now.Add(time.Hour), Status: reminder.Waiting, Description: "Contract back from @alice"},
+		{ID: "2", DateTime: now.Add(2 * time.Hour), Status: reminder.Waiting, Description: "Budget sign-off from @Alice and @bob"},
+		{ID: "3", DateTime: now.Add(3 * time.Hour), Status: reminder.Pending, Description: "Chase @alice about the offsite"},
+		{ID: "4", DateTime: now.Add(-time.Hour), Status: reminder.Acknowledged, Description: "Thank @carol"},
+		{ID: "5", DateTime: now.Add(4 * time.Hour), Status: reminder.Pending, Description: "Email ada@example.com"},
+	}
+
+	m := New(rs, nil, nil).WithClock(clock.Fixed(now))
+	var updated tea.Model = m
+	updated, _ = updated.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	send := func(msgs ...tea.Msg) Model {
+		t.Helper()
+		for _, msg := range msgs {
+			updated, _ = updated.(Model).Update(msg)
+		}
+		return updated.(Model)
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	ps := send().people()
+	if len(ps) != 3 || ps[0].displayName() != "Alice" || len(ps[0].open) != 3 || ps[0].waiting != 2 ||
+		ps[1].mention != "bob" || len(ps[1].open) != 1 || ps[2].mention != "carol" || len(ps[2].open) != 0 {
+		t.Fatalf("people() = %+v, want Alice with 3 open, then bob, then carol with none", ps)
+	}
+
+	got := send(runes("@"))
+	view := got.View()
+	if got.mode != modePeople || !strings.Contains(view, "waiting on Alice: 2 items") || !strings.Contains(view, "Chase @alice about the offsite") ||
+		strings.Contains(view, "ada@example.com") {
+		t.Fatalf("People view should show Alice's open reminders, mode %v:\n%s", got.mode, view)
+	}
+
+	// enter filters the list to the selected person
+	got = send(runes("j"), tea.KeyMsg{Type: tea.KeyEnter})
+	filtered := got.getFilteredReminders()
+	if got.mode != modeNormal || got.filterInput.Value() != "@bob" || len(filtered) != 1 || filtered[0].ID != "2" {
+		t.Errorf("enter should filter by bob: mode %v, filter %q, %d shown", got.mode, got.filterInput.Value(), len(filtered))
+	}
+}
+
+func TestDrafts(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local)
+	standup := &reminder.Reminder{ID: "1", DateTime: now.Add(time.Hour), Status: reminder.Pending, Description: "Standup"}
+	store := state.NewStore(filepath.Join(t.TempDir(), "state.json"))
+	open := func() (func(msgs ...tea.Msg) Model, func(s string) tea.KeyMsg) {
+		m := New([]*reminder.Reminder{standup}, nil, nil).WithClock(clock.Fixed(now)).WithDrafts(store)
+		var updated tea.Model = m
+		updated, _ = updated.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+		send := func(msgs ...tea.Msg) Model {
+			t.Helper()
+			for _, msg := range msgs {
+				updated, _ = updated.(Model).Update(msg)
+			}
+			return updated.(Model)
+		}
+		return send, func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+	}
+
+	// What's typed is saved on the tick
+	send, runes := open()
+	send(runes("n"), runes("friday 3pm Write up the launch plan"), TickMsg(now))
+	if draft, err := store.LoadDraft(); err != nil || draft.Text != "friday 3pm Write up the launch plan" || draft.Editing != "" {
+		t.Fatalf("draft = %+v, %v; want the typed text", draft, err)
+	}
+
+	// The terminal died; the next session offers it back
+	send, runes = open()
+	got := send(runes("n"))
+	if !got.draftPrompt || !strings.Contains(got.View(), "Restore the draft") || got.addInput.Value() != "" {
+		t.Fatalf("add box should offer the draft, prompt %v:\n%s", got.draftPrompt, got.View())
+	}
+	got = send(runes("y"))
+	if got.draftPrompt || got.addInput.Value() != "friday 3pm Write up the launch plan" {
+		t.Fatalf("y should restore the draft, box %q", got.addInput.Value())
+	}
+	got = send(tea.KeyMsg{Type: tea.KeyEnter})
+	if draft, _ := store.LoadDraft(); len(got.reminders) != 2 || draft.Text != "" {
+		t.Errorf("saving the reminder should clear the draft: %d reminders, draft %+v", len(got.reminders), draft)
+	}
+
+	// A draft of an edit is only offered when editing that reminder
+	edit := state.Draft{Text: "+2h Standup moved", Editing: "1", SavedAt: now}
+	if err := store.SaveDraft(edit); err != nil {
+		t.Fatal(err)
+	}
+	send, runes = open()
+	if got = send(runes("n")); got.draftPrompt {
+		t.Error("a new reminder was offered the draft of an edit")
+	}
+	send(tea.KeyMsg{Type: tea.KeyEscape})
+	if draft, _ := store.LoadDraft(); draft.Text != edit.Text || draft.Editing != "1" {
+		t.Errorf("cancelling another box threw away the edit's draft: %+v", draft)
+	}
+	got = send(runes("e"))
+	if !got.draftPrompt || got.addInput.Value() != editText(standup) {
+		t.Fatalf("editing the reminder should offer its draft, box %q", got.addInput.Value())
+	}
+	got = send(runes("n"))
+	if draft, _ := store.LoadDraft(); got.draftPrompt || got.addInput.Value() != editText(standup) || draft.Text != "" {
+		t.Errorf("n should throw the draft away: box %q, draft %+v", got.addInput.Value(), draft)
+	}
+}
+
+func TestInputHistory(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local)
+	store := state.NewStore(filepath.Join(t.TempDir(), "state.json"))
+	open := func() func(msgs ...tea.Msg) Model {
+		m := New(nil, nil, nil).WithClock(clock.Fixed(now)).WithInputHistory(store)
+		var updated tea.Model = m
+		updated, _ = updated.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+		return func(msgs ...tea.Msg) Model {
+			t.Helper()
+			for _, msg := range msgs {
+				updated, _ = updated.(Model).Update(msg)
+			}
+			return updated.(Model)
+		}
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+	up := tea.KeyMsg{Type: tea.KeyUp}
+	down := tea.KeyMsg{Type: tea.KeyDown}
+
+	send := open()
+	send(runes("n"), runes("+1h Water the plants #home"), enter)
+	send(runes("n"), runes("+2h Call the dentist"), enter)
+	send(runes("/"), runes("#home"), enter)
+
+	// Up and down step through what was added before, back to what's typed
+	send = open()
+	got := send(runes("n"), runes("+3h"), up)
+	if got.addInput.Value() != "+2h Call the dentist" {
+		t.Fatalf("up should recall the last reminder added, box %q", got.addInput.Value())
+	}
+	if got = send(up, up); got.addInput.Value() != "+1h Water the plants #home" {
+		t.Errorf("up past the oldest should stay on it, box %q", got.addInput.Value())
+	}
+	if got = send(down, down); got.addInput.Value() != "+3h" {
+		t.Errorf("down past the newest should bring back what was typed, box %q", got.addInput.Value())
+	}
+
+	// ctrl+r searches back through them
+	got = send(tea.KeyMsg{Type: tea.KeyCtrlR}, runes("plant"))
+	if !got.addHistory.searching || !strings.Contains(got.View(), "(reverse-i-search)`plant': +1h Water the plants #home") {
+		t.Fatalf("ctrl+r should search the history:\n%s", got.View())
+	}
+	if got = send(enter); got.addHistory.searching || got.mode != modeAdd || got.addInput.Value() != "+1h Water the plants #home" {
+		t.Fatalf("enter should put the match in the box to edit: mode %v, box %q", got.mode, got.addInput.Value())
+	}
+	got = send(tea.KeyMsg{Type: tea.KeyCtrlR}, runes("zzz"))
+	if !strings.Contains(got.View(), "failed reverse-i-search") {
+		t.Errorf("a search matching nothing should say so:\n%s", got.View())
+	}
+	if got = send(tea.KeyMsg{Type: tea.KeyEscape}); got.mode != modeAdd || got.addInput.Value() != "+1h Water the plants #home" {
+		t.Errorf("esc should cancel the search only: mode %v, box %q", got.mode, got.addInput.Value())
+	}
+
+	// Filters have their own history
+	got = send(tea.KeyMsg{Type: tea.KeyEscape}, runes("/"), up)
+	if got.filterInput.Value() != "#home" {
+		t.Errorf("up in the filter should recall the last filter, box %q", got.filterInput.Value())
+	}
+}
+
+func TestNotes(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local)
+	m := New(nil, nil, nil).WithClock(clock.Fixed(now))
+	var updated tea.Model = m
+	updated, _ = updated.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	send := func(msgs ...tea.Msg) Model {
+		t.Helper()
+		for _, msg := range msgs {
+			updated, _ = updated.(Model).Update(msg)
+		}
+		return updated.(Model)
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+
+	// alt+enter and ctrl+j start a new line; the lines below the first are notes
+	got := send(runes("n"), runes("+1h Pack for the trip #travel"), tea.KeyMsg{Type: tea.KeyEnter, Alt: true},
+		runes("- passport"), tea.KeyMsg{Type: tea.KeyCtrlJ}, runes("- chargers"))
+	if got.addInput.LineCount() != 3 || got.addInput.Height() != 3 {
+		t.Fatalf("the box should grow to its 3 lines: %d lines, height %d", got.addInput.LineCount(), got.addInput.Height())
+	}
+	got = send(enter)
+	if len(got.reminders) != 1 {
+		t.Fatalf("enter should add the reminder, error %q", got.inputError)
+	}
+	r := got.reminders[0]
+	if r.Description != "Pack for the trip" || r.Notes != "- passport\n- chargers" || !slices.Equal(r.Tags, []string{"travel"}) {
+		t.Errorf("added %q with notes %q and tags %v", r.Description, r.Notes, r.Tags)
+	}
+
+	// The Notes tab shows them line by line
+	got = send(runes("K"), tea.KeyMsg{Type: tea.KeyTab})
+	if view := got.View(); !strings.Contains(view, "- passport") || !strings.Contains(view, "- chargers") {
+		t.Errorf("Notes tab should show the notes:\n%s", view)
+	}
+
+	// Editing brings them back, and up moves between lines before history
+	got = send(tea.KeyMsg{Type: tea.KeyEscape}, runes("e"))
+	if got.addInput.Value() != editText(r) || !strings.HasSuffix(got.addInput.Value(), "\n- passport\n- chargers") {
+		t.Fatalf("edit box = %q, want the reminder and its notes", got.addInput.Value())
+	}
+	got = send(tea.KeyMsg{Type: tea.KeyUp})
+	if got.addInput.Value() != editText(r) || got.addInput.Line() != 1 {
+		t.Errorf("up should move to the line above, line %d, box %q", got.addInput.Line(), got.addInput.Value())
+	}
+	send(tea.KeyMsg{Type: tea.KeyDown}, tea.KeyMsg{Type: tea.KeyCtrlU}, runes("- cables"), enter)
+	if r.Notes != "- passport\n- cables" || r.Description != "Pack for the trip" {
+		t.Errorf("after editing: %q with notes %q", r.Description, r.Notes)
+	}
+}
+
+func TestCompose(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local)
+	m := New(nil, nil, nil).WithClock(clock.Fixed(now))
+	var updated tea.Model = m
+	updated, _ = updated.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	// send runs each message and the command it returns, as the editor
+	// would be run
+	send := func(msgs ...tea.Msg) Model {
+		t.Helper()
+		for _, msg := range msgs {
+			var cmd tea.Cmd
+			updated, cmd = updated.(Model).Update(msg)
+			if _, ok := msg.(tea.KeyMsg); ok && cmd != nil {
+				if composed, ok := cmd().(ComposedMsg); ok {
+					updated, _ = updated.(Model).Update(composed)
+				}
+			}
+		}
+		return updated.(Model)
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	// The editor is faked: edit gets what the file started with and
+	// returns what it's saved with
+	var opened string
+	var edit func(text string) string
+	defer func(orig func(*exec.Cmd, tea.ExecCallback) tea.Cmd) { execProcess = orig }(execProcess)
+	execProcess = func(cmd *exec.Cmd, fn tea.ExecCallback) tea.Cmd {
+		path := cmd.Args[len(cmd.Args)-1]
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		opened = string(data)
+		if err := os.WriteFile(path, []byte(edit(opened)), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return func() tea.Msg { return fn(nil) }
+	}
+	hints := "\n\n" + strings.Join(composeHints, "\n") + "\n"
+
+	// Without an editor set, it says so
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "")
+	got := send(runes("a"))
+	if len(got.toasts) == 0 || !strings.Contains(got.toasts[len(got.toasts)-1].text, "$EDITOR") {
+		t.Errorf("toasts = %+v, want one asking for an editor", got.toasts)
+	}
+
+	// A new reminder starts from the hints; the first line is the
+	// reminder and the lines below are its notes
+	t.Setenv("EDITOR", "vi")
+	edit = func(text string) string { return "+1h Call the bank\n- account number\n- last statement\n" + text }
+	got = send(runes("a"))
+	if opened != hints {
+		t.Errorf("the file started as %q, want just the hints", opened)
+	}
+	if len(got.reminders) != 1 {
+		t.Fatalf("saving should add the reminder: %d reminders, error %q", len(got.reminders), got.inputError)
+	}
+	r := got.reminders[0]
+	if r.Description != "Call the bank" || r.Notes != "- account number\n- last statement" || !r.DateTime.Equal(now.Add(time.Hour)) {
+		t.Errorf("added %q at %v with notes %q", r.Description, r.DateTime, r.Notes)
+	}
+
+	// From the detail view it edits the reminder, starting from its text
+	edit = func(text string) string {
+		return strings.Replace(text, "Call the bank", "Call the bank about the fee", 1)
+	}
+	before := editText(r)
+	got = send(runes("K"), runes("a"))
+	if opened != before+hints {
+		t.Errorf("the file started as %q, want the reminder then the hints", opened)
+	}
+	if len(got.reminders) != 1 || r.Description != "Call the bank about the fee" || r.Notes != "- account number\n- last statement" {
+		t.Errorf("after editing: %d reminders, %q with notes %q", len(got.reminders), r.Description, r.Notes)
+	}
+
+	// Saving it unchanged, or with the first line emptied, changes nothing
+	edit = func(text string) string { return text }
+	got = send(tea.KeyMsg{Type: tea.KeyEscape}, runes("K"), runes("a"))
+	if last := got.toasts[len(got.toasts)-1].text; last != "No changes" {
+		t.Errorf("last toast = %q, want No changes", last)
+	}
+	edit = func(text string) string { return "\n" + text }
+	got = send(tea.KeyMsg{Type: tea.KeyEscape}, runes("a"))
+	if last := got.toasts[len(got.toasts)-1].text; last != "Compose cancelled" || len(got.reminders) != 1 {
+		t.Errorf("last toast = %q with %d reminders, want the compose cancelled", last, len(got.reminders))
+	}
+
+	// ctrl+x hands what's in the add box to the editor, and what can't be
+	// parsed comes back to the box to fix
+	edit = func(text string) string { return strings.Replace(text, "Water", "sometime Water", 1) }
+	got = send(runes("n"), runes("Water the plants"), tea.KeyMsg{Type: tea.KeyCtrlX})
+	if !strings.HasPrefix(opened, "Water the plants\n") {
+		t.Errorf("the file started as %q, want the add box's text", opened)
+	}
+	if got.mode != modeAdd || got.addInput.Value() != "sometime Water the plants" || got.inputError == "" {
+		t.Errorf("mode %v, box %q, error %q; want the add box back with the error", got.mode, got.addInput.Value(), got.inputError)
+	}
+	if len(got.reminders) != 1 {
+		t.Errorf("%d reminders, want nothing added", len(got.reminders))
+	}
+}
+
+func TestDescriptions(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local)
+	passport := &reminder.Reminder{ID: "1", DateTime: now.Add(24 * time.Hour), Description: "Renew the passport", Status: reminder.Pending}
+	cfg := config.Default()
+	cfg.Descriptions.Tidy = true
+	m := New([]*reminder.Reminder{passport}, nil, nil).WithConfig(cfg).WithClock(clock.Fixed(now))
+	var updated tea.Model = m
+	updated, _ = updated.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	send := func(msgs ...tea.Msg) Model {
+		t.Helper()
+		for _, msg := range msgs {
+			updated, _ = updated.(Model).Update(msg)
+		}
+		return updated.(Model)
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	// A description like an open reminder's is flagged while it's typed
+	got := send(runes("n"), runes("+2h renew the pasport"))
+	if view := got.View(); !strings.Contains(view, "Already open: Renew the passport") {
+		t.Errorf("the add box should flag the open passport reminder:\n%s", view)
+	}
+
+	// and tidied when it's added
+	got = send(tea.KeyMsg{Type: tea.KeyCtrlU}, runes("+2h water   the plants."), tea.KeyMsg{Type: tea.KeyEnter})
+	if len(got.reminders) != 2 {
+		t.Fatalf("enter should add the reminder, error %q", got.inputError)
+	}
+	if i := slices.IndexFunc(got.reminders, func(r *reminder.Reminder) bool { return r.Description == "Water the plants" }); i < 0 {
+		t.Errorf("added %q, want it tidied to Water the plants", got.reminders[0].Description)
+	}
+
+	// Editing a reminder doesn't flag it against itself, and both can be
+	// turned off
+	got = send(runes("e"))
+	if view := got.View(); strings.Contains(view, "Already open") {
+		t.Errorf("editing shouldn't flag the reminder itself:\n%s", view)
+	}
+	cfg.Descriptions.Tidy = false
+	cfg.Descriptions.WarnDuplicates = false
+	got = send(tea.KeyMsg{Type: tea.KeyEscape}, runes("n"), runes("+2h renew the passport"))
+	if view := got.View(); strings.Contains(view, "Already open") {
+		t.Errorf("warn_duplicates = false shouldn't flag anything:\n%s", view)
+	}
+	got = send(tea.KeyMsg{Type: tea.KeyEnter}, tea.KeyMsg{Type: tea.KeyEnter})
+	if i := slices.IndexFunc(got.reminders, func(r *reminder.Reminder) bool { return r.Description == "renew the passport" }); i < 0 {
+		t.Errorf("reminders = %v, want renew the passport added as typed", got.reminders)
+	}
+}
+
+func TestColumns(t *testing.T) {
+	t.Cleanup(func() { SetColumns(nil) })
+	for _, names := range [][]string{{"time", "when", "description"}, {"time", "status"}, {"description", "tags", "Tags"}} {
+		if err := SetColumns(names); err == nil {
+			t.Errorf("SetColumns(%q) should fail", names)
+		}
+	}
+
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local)
+	rs := []*reminder.Reminder{
+		{ID: "1", DateTime: now.Add(-90 * time.Minute), Description: "Submit expense report", Status: reminder.Triggered,
+			Priority: reminder.PriorityHigh, Tags: []string{"work"}, SourceFile: "/notes/work.md", Label: reminder.LabelRed},
+		{ID: "2", DateTime: now.AddDate(0, 0, 20).Add(2 * time.Hour), Description: "Renew car insurance", Status: reminder.Pending,
+			Tags: []string{"home", "car"}, SourceFile: "/notes/home.md"},
+	}
+	if err := SetColumns([]string{"countdown", "Priority", "description", "tags", "time", "source"}); err != nil {
+		t.Fatal(err)
+	}
+	lines := make([]string, len(rs))
+	for i, r := range rs {
+		lines[i] = ansi.Strip(renderCompactLine(">", r, 120, now, "", normalStyle))
+		if w := lipgloss.Width(lines[i]); w > 120 {
+			t.Errorf("line %d is %d wide: %q", i, w, lines[i])
+		}
+	}
+	for _, want := range []string{"> 1h 30m ago !!! Submit expense report", "#work", "Mar 4 8:30am", "work.md"} {
+		if !strings.Contains(lines[0], want) {
+			t.Errorf("line %q should contain %q", lines[0], want)
+		}
+	}
+	if !strings.HasPrefix(lines[1], "> in 20d 2h      Renew car insurance") || !strings.Contains(lines[1], "#home #car") {
+		t.Errorf("line = %q, want the countdown, no priority, then the description and tags", lines[1])
+	}
+	// Each column starts in the same place on every line, label or not
+	for _, col := range [][2]string{{"#work", "#home"}, {"Mar 4", "Mar 24"}, {"work.md", "home.md"}} {
+		if a, b := strings.Index(lines[0], col[0]), strings.Index(lines[1], col[1]); lipgloss.Width(lines[0][:a]) != lipgloss.Width(lines[1][:b]) {
+			t.Errorf("%s and %s aren't aligned:\n%s\n%s", col[0], col[1], lines[0], lines[1])
+		}
+	}
+
+	// Narrow terminals drop columns before the description gets too narrow
+	line := ansi.Strip(renderCompactLine(">", rs[0], 60, now, "", normalStyle))
+	if strings.Contains(line, "work.md") || strings.Contains(line, "#work") || !strings.Contains(line, "ago") || !strings.Contains(line, "Submit expense report") {
+		t.Errorf("narrow line = %q, want the source and tags dropped", line)
+	}
+}
+
+func TestOverflow(t *testing.T) {
+	saved := currentLayout
+	currentLayout = LayoutCompact
+	t.Cleanup(func() { currentLayout = saved })
+
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local)
+	long := "Ask the landlord about the boiler service, the broken window latch, and whether the deposit covers the carpet"
+	rs := []*reminder.Reminder{
+		{ID: "1", DateTime: now.Add(time.Hour), Description: long, Status: reminder.Pending, SourceFile: "/notes/home.md"},
+		{ID: "2", DateTime: now.Add(2 * time.Hour), Description: "Standup", Status: reminder.Pending, SourceFile: "/notes/work.md"},
+	}
+	m := New(rs, nil, nil).WithClock(clock.Fixed(now))
+	var updated tea.Model = m
+	updated, _ = updated.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	got := updated.(Model)
+
+	// The row is cut to leave the source its column, and the whole
+	// description shows above the status bar
+	view := ansi.Strip(got.View())
+	for _, line := range strings.Split(view, "\n") {
+		if strings.Contains(line, "Ask the landlord") && strings.Contains(line, "home.md") {
+			if !strings.Contains(line, glyphs.Ellipsis+" ") || lipgloss.Width(line) > 120 {
+				t.Errorf("row = %q, want the description cut with an ellipsis before the source", line)
+			}
+		}
+	}
+	if !strings.Contains(view, "carpet") {
+		t.Errorf("the whole description should show for the selected row:\n%s", view)
+	}
+
+	// Nothing extra for a row that fits
+	updated, _ = got.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if view := ansi.Strip(updated.(Model).View()); strings.Contains(view, "carpet") {
+		t.Errorf("the whole description should only show while its row is selected:\n%s", view)
+	}
+
+	// Cards wrap to two lines and cut the rest, so it shows for them too
+	currentLayout = LayoutCard
+	if view := ansi.Strip(got.View()); !strings.Contains(view, "covers the carpet") {
+		t.Errorf("the whole description should show for the selected card:\n%s", view)
+	}
+
+	lines, cut := fitLines("a wonderfully long word like antidisestablishmentarianism", 12, 2)
+	if !cut || len(lines) != 2 || lipgloss.Width(lines[1]) > 12 || !strings.HasSuffix(lines[1], glyphs.Ellipsis) {
+		t.Errorf("fitLines = %q, %v; want two lines of 12 or fewer, the last cut", lines, cut)
+	}
+}
+
+func TestTriggeredBanner(t *testing.T) {
+	saved := currentLayout
+	currentLayout = LayoutCompact
+	t.Cleanup(func() { currentLayout = saved })
+
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local)
+	rs := []*reminder.Reminder{
+		{ID: "1", DateTime: now.Add(-time.Hour), Description: "Pay rent", Status: reminder.Triggered},
+		{ID: "2", DateTime: now.Add(-30 * time.Minute), Description: "Call the dentist", Status: reminder.Triggered},
+		{ID: "3", DateTime: now.Add(time.Hour), Description: "Standup #work", Tags: []string{"work"}, Status: reminder.Pending},
+	}
+	m := New(rs, nil, nil).WithClock(clock.Fixed(now))
+	var updated tea.Model = m
+	updated, _ = updated.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	send := func(msg tea.Msg) Model {
+		updated, _ = updated.Update(msg)
+		return updated.(Model)
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	// The banner shows even when the filter hides what triggered
+	got := send(runes("/"))
+	for _, r := range "#work" {
+		got = send(runes(string(r)))
+	}
+	got = send(tea.KeyMsg{Type: tea.KeyEnter})
+	if len(got.matchingReminders()) != 1 {
+		t.Fatalf("filter should leave just the standup, got %d", len(got.matchingReminders()))
+	}
+	if view := ansi.Strip(got.View()); !strings.Contains(view, "2 triggered reminders") || !strings.Contains(view, "press ! to view") {
+		t.Errorf("the banner should count what's triggered:\n%s", view)
+	}
+
+	// ! shows just those, whatever the filter was
+	got = send(runes("!"))
+	if got.filterInput.Value() != triggeredFilter || len(got.matchingReminders()) != 2 {
+		t.Errorf("! should show the 2 triggered reminders, got filter %q and %d", got.filterInput.Value(), len(got.matchingReminders()))
+	}
+	if view := ansi.Strip(got.View()); !strings.Contains(view, "press ! to go back") {
+		t.Errorf("the banner should offer the way back:\n%s", view)
+	}
+
+	// and again goes back to the filter it had
+	got = send(runes("!"))
+	if got.filterInput.Value() != "#work" || got.triggeredReturn != nil {
+		t.Errorf("! again should restore the filter, got %q", got.filterInput.Value())
+	}
+
+	// No banner once nothing is triggered
+	rs[0].Status, rs[1].Status = reminder.Acknowledged, reminder.Acknowledged
+	if view := ansi.Strip(got.View()); strings.Contains(view, "press !") {
+		t.Errorf("the banner should go once nothing is triggered:\n%s", view)
+	}
+	got = send(runes("!"))
+	if got.filterInput.Value() != "#work" {
+		t.Errorf("! with nothing triggered should leave the filter, got %q", got.filterInput.Value())
+	}
+}
+
+func TestCatchUp(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local)
+	newReminders := func() []*reminder.Reminder {
+		return []*reminder.Reminder{
+			{ID: "1", DateTime: now.Add(-26 * time.Hour), Description: "Pay rent", Status: reminder.Pending},
+			{ID: "2", DateTime: now.Add(-time.Hour), Description: "Call the dentist", Status: reminder.Snoozed},
+			{ID: "3", DateTime: now.Add(-2 * time.Hour), Description: "Water the plants", Status: reminder.Acknowledged},
+			{ID: "4", DateTime: now.Add(time.Hour), Description: "Standup", Status: reminder.Pending},
+		}
+	}
+	start := func(cfg *config.Config) (Model, tea.Cmd) {
+		m := New(newReminders(), nil, nil).WithConfig(cfg).WithClock(clock.Fixed(now))
+		var updated tea.Model = m
+		updated, _ = updated.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+		updated, cmd := updated.Update(StartupMsg{})
+		return updated.(Model), cmd
+	}
+
+	// What came due while closed triggers at once, listed in place of alerts
+	got, cmd := start(config.Default())
+	if cmd != nil {
+		t.Error("no notification should be sent unless away.notify is on")
+	}
+	for id, want := range map[string]reminder.Status{"1": reminder.Triggered, "2": reminder.Triggered, "3": reminder.Acknowledged, "4": reminder.Pending} {
+		if r := got.reminderByID(id); r.Status != want {
+			t.Errorf("reminder %s is %v, want %v", id, r.Status, want)
+		}
+	}
+	if got.mode != modeAway || len(got.alerts) != 0 {
+		t.Fatalf("mode = %v with %d alerts, want the away box and no alerts", got.mode, len(got.alerts))
+	}
+	view := ansi.Strip(got.View())
+	for _, want := range []string{"While you were away", "2 reminders came due", "Pay rent", "Call the dentist"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("away box should show %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "Standup") {
+		t.Errorf("away box should only list what came due:\n%s", view)
+	}
+
+	// enter goes on to the triggered reminders
+	updated, _ := got.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := updated.(Model); got.mode != modeNormal || !got.showingTriggered() {
+		t.Errorf("enter should show the triggered reminders, got mode %v and filter %q", got.mode, got.filterInput.Value())
+	}
+
+	// The box can be turned off, and one notification sent instead
+	cfg := config.Default()
+	cfg.Away.Pane = false
+	cfg.Away.Notify = true
+	got, cmd = start(cfg)
+	if got.mode != modeNormal || cmd == nil {
+		t.Errorf("mode = %v with notification %v, want the list and a notification", got.mode, cmd != nil)
+	}
+	if body := awayBody(got.missedReminders()); body != "Pay rent\nCall the dentist" {
+		t.Errorf("notification body = %q", body)
+	}
+
+	// Nothing to catch up on
+	m := New(newReminders()[2:], nil, nil).WithClock(clock.Fixed(now))
+	updated, cmd = m.Update(StartupMsg{})
+	if updated.(Model).mode != modeNormal || cmd != nil {
+		t.Error("nothing missed should leave the list as it is")
+	}
+}
+
+func TestCleanupRules(t *testing.T) {
+	now := time.Now()
+	stale := &reminder.Reminder{ID: "1", DateTime: now.Add(-40 * 24 * time.Hour), Description: "Stale", Status: reminder.Triggered}
+	old := &reminder.Reminder{ID: "2", DateTime: now.Add(-200 * 24 * time.Hour), Description: "Old", Status: reminder.Acknowledged,
+		AcknowledgedAt: now.Add(-100 * 24 * time.Hour)}
+	fresh := &reminder.Reminder{ID: "3", DateTime: now.Add(time.Hour), Description: "Fresh", Status: reminder.Pending}
+	noted := func() *reminder.Reminder {
+		return &reminder.Reminder{ID: reminder.FileID("/notes/old.md", "Noted"), DateTime: now.Add(-200 * 24 * time.Hour), Description: "Noted",
+			SourceFile: "/notes/old.md", Status: reminder.Pending}
+	}
+	done := noted()
+	done.Acknowledge(now.Add(-100 * 24 * time.Hour))
+
+	cfg := config.Default()
+	cfg.Cleanup.AcknowledgeAfter = "30d"
+	cfg.Cleanup.DeleteAfter = "90d"
+	m := New([]*reminder.Reminder{stale, old, fresh, done}, nil, nil).WithConfig(cfg)
+
+	// The first tick after startup runs the rules
+	now = now.Add(time.Second)
+	updated, _ := m.Update(TickMsg(now))
+	got := updated.(Model)
+	if len(got.reminders) != 2 || stale.Status != reminder.Acknowledged {
+		t.Errorf("after cleanup: %d reminders, stale status %v; want 2 and acknowledged", len(got.reminders), stale.Status)
+	}
+	if len(got.toasts) != 1 || !strings.Contains(got.toasts[0].text, "auto-acknowledged 1, deleted 2") {
+		t.Errorf("toasts = %+v, want a cleanup summary", got.toasts)
+	}
+	if !got.nextCleanup.After(now) {
+		t.Errorf("nextCleanup = %v, want it rescheduled after %v", got.nextCleanup, now)
+	}
+
+	// The deleted file reminder stays deleted when its file is parsed again,
+	// rather than coming back as new and triggering at once
+	updated, _ = got.Update(FileUpdateMsg{FilePath: "/notes/old.md", Reminders: []*reminder.Reminder{noted()}})
+	if got := updated.(Model); len(got.reminders) != 2 || len(got.muted) != 1 {
+		t.Errorf("after parsing old.md again: %d reminders and %d mutes, want 2 and 1", len(got.reminders), len(got.muted))
+	}
+}
+
+func TestWaitingAndSomeday(t *testing.T) {
+	now := time.Now()
+	blocked := &reminder.Reminder{ID: "1", DateTime: now.Add(-time.Hour), Description: "Hear back from Sam", Status: reminder.Triggered}
+	later := &reminder.Reminder{ID: "2", DateTime: now.Add(time.Hour), Description: "Standup", Status: reminder.Pending}
+	m := createTestModel(t, []*reminder.Reminder{blocked, later})
+	m.sortEnabled = true
+
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	updated, _ = updated.(Model).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	got := updated.(Model)
+	if blocked.Status != reminder.Waiting {
+		t.Fatalf("status = %v, want waiting", blocked.Status)
+	}
+	// Parked reminders sort after everything with a date
+	if items := got.getFilteredReminders(); items[0] != later || items[1] != blocked {
+		t.Errorf("sorted order = %s, %s; want Standup first", items[0].Description, items[1].Description)
+	}
+	if !strings.Contains(got.compactViewContent(), "Waiting") {
+		t.Errorf("compact view missing Waiting section:\n%s", got.compactViewContent())
+	}
+
+	// Waiting reminders never trigger
+	updated, _ = got.Update(TickMsg(now))
+	got = updated.(Model)
+	if blocked.Status != reminder.Waiting {
+		t.Errorf("after tick status = %v, want waiting", blocked.Status)
+	}
+
+	got.gridIndex = 1
+	updated, _ = got.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	if blocked.Status != reminder.Triggered {
+		t.Errorf("pressing w again left status %v, want triggered", blocked.Status)
+	}
+
+	// Reopened and due again, it sorts first and Standup is selected
+	updated.(Model).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("1")})
+	if later.Status != reminder.Snoozed {
+		t.Errorf("snoozed reminder has status %v, want snoozed", later.Status)
+	}
+}
+
+func TestOrphanedReminders(t *testing.T) {
+	dir := t.TempDir()
+	kept := filepath.Join(dir, "kept.md")
+	if err := os.WriteFile(kept, []byte("notes"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	moved, deleted := filepath.Join(dir, "moved.md"), filepath.Join(dir, "deleted.md")
+
+	now := time.Now()
+	m := createTestModel(t, []*reminder.Reminder{
+		{ID: "1", DateTime: now.Add(time.Hour), Description: "Moved", Status: reminder.Pending, SourceFile: moved},
+		{ID: "2", DateTime: now.Add(2 * time.Hour), Description: "Kept", Status: reminder.Pending, SourceFile: kept},
+		{ID: "3", DateTime: now.Add(3 * time.Hour), Description: "Deleted", Status: reminder.Pending, SourceFile: deleted},
+		{ID: "4", DateTime: now.Add(4 * time.Hour), Description: "Standalone", Status: reminder.Pending, SourceFile: reminder.StandaloneSource},
+	})
+
+	updated, _ := m.Update(m.checkOrphans(now)())
+	got := updated.(Model)
+	if len(got.orphans()) != 2 {
+		t.Fatalf("found %d orphans, want 2", len(got.orphans()))
+	}
+	// Orphans are grouped after the dated sections
+	if items := got.getFilteredReminders(); items[2].Description != "Moved" || items[3].Description != "Deleted" {
+		t.Errorf("sorted order = %s, %s, %s, %s; want orphans last", items[0].Description, items[1].Description, items[2].Description, items[3].Description)
+	}
+
+	updated, _ = got.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("O")})
+	got = updated.(Model)
+	if got.mode != modeOrphans {
+		t.Fatalf("mode = %v, want modeOrphans", got.mode)
+	}
+
+	// Files are listed by path, so deleted.md comes first
+	updated, _ = got.Update(tea.KeyMsg{Type: tea.KeyDown})
+	updated, _ = updated.(Model).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	got = updated.(Model)
+	got.repointInput.SetValue(kept)
+	updated, _ = got.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	got = updated.(Model)
+	if r := got.reminders[0]; r.SourceFile != kept {
+		t.Errorf("re-pointed source = %q, want %q", r.SourceFile, kept)
+	}
+
+	updated, _ = got.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("D")})
+	got = updated.(Model)
+	if len(got.reminders) != 3 || got.mode != modeNormal {
+		t.Errorf("after deleting: %d reminders, mode %v; want 3 and back to normal", len(got.reminders), got.mode)
+	}
+}
+
+func TestDuplicates(t *testing.T) {
+	now := time.Now()
+	newReminders := func() []*reminder.Reminder {
+		return []*reminder.Reminder{
+			{ID: "1", DateTime: now.Add(time.Hour), Description: "Call mom", Status: reminder.Pending, SourceFile: "/notes/a.md"},
+			{ID: "2", DateTime: now.Add(time.Hour + time.Minute), Description: "Call Mom", Status: reminder.Pending, SourceFile: "/notes/b.md", Tags: []string{"family"}},
+			{ID: "3", DateTime: now.Add(2 * time.Hour), Description: "Standup", Status: reminder.Pending, SourceFile: "/notes/a.md"},
+		}
+	}
+
+	m := createTestModel(t, newReminders())
+	updated, _ := m.Update(TickMsg(now))
+	got := updated.(Model)
+	if len(got.toasts) != 1 || !strings.Contains(got.toasts[0].text, "1 possible duplicate") {
+		t.Fatalf("toasts = %+v, want a duplicate notice", got.toasts)
+	}
+
+	updated, _ = got.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("=")})
+	got = updated.(Model)
+	if got.mode != modeDuplicates {
+		t.Fatalf("mode = %v, want modeDuplicates", got.mode)
+	}
+	updated, _ = got.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+	got = updated.(Model)
+	if len(got.reminders) != 2 || got.mode != modeNormal {
+		t.Fatalf("after merging: %d reminders, mode %v; want 2 and back to normal", len(got.reminders), got.mode)
+	}
+	if tags := got.reminders[0].Tags; len(tags) != 1 || tags[0] != "family" {
+		t.Errorf("merged tags = %v, want [family]", tags)
+	}
+
+	// The merged copy stays merged when its file is saved again
+	copyInB := func() FileUpdateMsg {
+		return FileUpdateMsg{FilePath: "/notes/b.md", Reminders: []*reminder.Reminder{newReminders()[1]}}
+	}
+	updated, _ = got.Update(copyInB())
+	updated, _ = updated.(Model).Update(TickMsg(now))
+	if got := updated.(Model); len(got.reminders) != 2 || len(got.findDuplicates()) != 0 {
+		t.Errorf("after saving b.md again: %d reminders and %d groups, want 2 and none", len(got.reminders), len(got.findDuplicates()))
+	}
+
+	// With auto_merge, duplicates are merged on the first tick, and not
+	// again each time the copy's file is saved
+	cfg := config.Default()
+	cfg.Duplicates.AutoMerge = true
+	auto := New(newReminders(), nil, nil).WithConfig(cfg)
+	updated, _ = auto.Update(TickMsg(now))
+	if got := updated.(Model); len(got.reminders) != 2 {
+		t.Errorf("auto merge left %d reminders, want 2", len(got.reminders))
+	}
+	merges := func(m Model) int {
+		return len(slices.DeleteFunc(slices.Clone(m.toasts), func(t toast) bool { return !strings.Contains(t.text, "Merged") }))
+	}
+	updated, _ = updated.(Model).Update(copyInB())
+	updated, _ = updated.(Model).Update(TickMsg(now))
+	if got := updated.(Model); len(got.reminders) != 2 || merges(got) != 1 {
+		t.Errorf("after saving b.md again: %d reminders and %d merge toasts, want 2 and 1", len(got.reminders), merges(got))
+	}
+
+	// Groups marked as not duplicates stay that way in the next session
+	store := state.NewStore(filepath.Join(t.TempDir(), "state.json"))
+	ignoring := New(newReminders(), nil, nil).WithIgnoredDuplicates(store)
+	updated, _ = ignoring.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("=")})
+	updated.(Model).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")})
+	if got := New(newReminders(), nil, nil).WithIgnoredDuplicates(store); len(got.findDuplicates()) != 0 {
+		t.Errorf("ignored group flagged again in a new session: %v", got.findDuplicates())
+	}
+}
+
+func TestProfileSwitcher(t *testing.T) {
+	m := createTestModel(t, nil).WithProfiles("", []string{"personal", "work"})
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("P")})
+	got := updated.(Model)
+	if got.mode != modeProfiles || got.profiles[got.profileIndex] != config.DefaultProfile {
+		t.Fatalf("mode = %v, selected %q; want the switcher on the default profile", got.mode, got.profiles[got.profileIndex])
+	}
+
+	updated, _ = got.Update(tea.KeyMsg{Type: tea.KeyDown})
+	updated, _ = updated.(Model).Update(tea.KeyMsg{Type: tea.KeyDown})
+	updated, cmd := updated.(Model).Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := updated.(Model).SwitchProfile(); got != "work" {
+		t.Errorf("SwitchProfile() = %q, want work", got)
+	}
+	if cmd == nil {
+		t.Fatal("switching profiles should quit the TUI")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("switching profiles should quit the TUI")
+	}
+}
+
+func TestOnboarding(t *testing.T) {
+	notes := t.TempDir()
+	path := filepath.Join(t.TempDir(), "config.toml")
+	var o tea.Model = NewOnboarding(path, config.Default())
+	send := func(msgs ...tea.KeyMsg) tea.Cmd {
+		var cmd tea.Cmd
+		for _, msg := range msgs {
+			o, cmd = o.Update(msg)
+		}
+		return cmd
+	}
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+
+	// A notes path that doesn't exist is refused
+	send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(notes + "/missing")}, enter)
+	if o.(Onboarding).step != stepNotes || o.(Onboarding).err == "" {
+		t.Fatal("setup should stay on the notes step for a missing path")
+	}
+	clearInput := tea.KeyMsg{Type: tea.KeyCtrlU}
+	send(clearInput, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(notes)}, enter)
+	send(tea.KeyMsg{Type: tea.KeyDown}, enter)
+	send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")}, enter)
+	send(clearInput, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("08:00-16:00")})
+	if cmd := send(enter); cmd == nil {
+		t.Fatal("finishing setup should quit")
+	}
+
+	cfg := o.(Onboarding).Config()
+	if cfg == nil {
+		t.Fatalf("Config() = nil after finishing, err %q", o.(Onboarding).err)
+	}
+	loaded, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if len(loaded.Paths) != 1 || loaded.Paths[0] != notes {
+		t.Errorf("paths = %v, want [%s]", loaded.Paths, notes)
+	}
+	if loaded.UI.Theme != themes[1].Name || loaded.Notifications.Enabled {
+		t.Errorf("theme %q, notifications %v; want %q and off", loaded.UI.Theme, loaded.Notifications.Enabled, themes[1].Name)
+	}
+	if loaded.WorkHours.Start != "08:00" || loaded.WorkHours.End != "16:00" {
+		t.Errorf("work hours = %+v, want 08:00-16:00", loaded.WorkHours)
+	}
+	themes[0].applyStyles()
+}
+
+func TestTickUsesClock(t *testing.T) {
+	now := time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(now)
+	r := &reminder.Reminder{Description: "Standup", DateTime: now.Add(time.Minute), Status: reminder.Pending}
+	m := createTestModel(t, []*reminder.Reminder{r}).WithClock(fake)
+
+	updated, _ := m.Update(TickMsg(now))
+	if r.Status != reminder.Pending {
+		t.Fatalf("status = %v before the due time, want pending", r.Status)
+	}
+
+	fake.Advance(2 * time.Minute)
+	updated.(Model).Update(TickMsg(now))
+	if r.Status != reminder.Triggered {
+		t.Errorf("status = %v after the clock passed the due time, want triggered", r.Status)
+	}
+}
+
+// countingStore records each save
+type countingStore struct {
+	mu    sync.Mutex
+	saves [][]*reminder.Reminder
+	err   error
+}
+
+func (s *countingStore) Save(reminders []*reminder.Reminder) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.saves = append(s.saves, reminders)
+	return s.err
+}
+
+func (s *countingStore) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.saves)
+}
+
+func TestSaverDebounces(t *testing.T) {
+	store := &countingStore{}
+	sv := newSaver(store, 20*time.Millisecond, make(chan ProgressMsg, 32))
+
+	r := &reminder.Reminder{Description: "first"}
+	for i := 0; i < 5; i++ {
+		sv.request([]*reminder.Reminder{r})
+	}
+	r.Description = "changed after the request"
+	time.Sleep(100 * time.Millisecond)
+
+	if got := store.count(); got != 1 {
+		t.Fatalf("%d saves for a burst of requests, want 1", got)
+	}
+	if got := store.saves[0][0].Description; got != "first" {
+		t.Errorf("saved %q, want the state as requested", got)
+	}
+
+	// Flush writes a waiting request straight away, and only once
+	sv.request([]*reminder.Reminder{r})
+	if err := sv.flush(); err != nil {
+		t.Fatal(err)
+	}
+	if got := store.count(); got != 2 {
+		t.Errorf("%d saves after flush, want 2", got)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if got := store.count(); got != 2 {
+		t.Errorf("%d saves after the flushed request's delay, want still 2", got)
+	}
+
+	// Failures are reported
+	store.err = fmt.Errorf("disk full")
+	sv.request([]*reminder.Reminder{r})
+	select {
+	case err := <-sv.errors:
+		if err != store.err {
+			t.Errorf("reported %v, want disk full", err)
+		}
+	case <-time.After(time.Second):
+		t.Error("failed save wasn't reported")
+	}
+}
+
+func TestReschedulePicker(t *testing.T) {
+	now := time.Date(2026, 1, 30, 12, 0, 0, 0, time.UTC)
+	r := &reminder.Reminder{ID: "1", DateTime: now.Add(-time.Hour), Description: "Pay rent", Tags: []string{"home"}, Status: reminder.Triggered}
+	m := New([]*reminder.Reminder{r}, nil, nil).WithClock(clock.Fixed(now))
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	send := func(msgs ...tea.KeyMsg) Model {
+		t.Helper()
+		for _, msg := range msgs {
+			updated, _ = updated.(Model).Update(msg)
+		}
+		return updated.(Model)
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	got := send(runes("R"))
+	if got.mode != modeReschedule {
+		t.Fatalf("mode = %v after R, want the date picker", got.mode)
+	}
+	if view := got.View(); !strings.Contains(view, "January 2026") || !strings.Contains(view, "Pay rent") {
+		t.Errorf("picker view missing month or description:\n%s", view)
+	}
+
+	// Next month keeps the day within it, then a day forward and an hour later
+	got = send(runes("]"))
+	if want := time.Date(2026, 2, 28, 11, 0, 0, 0, time.UTC); !got.picker.at.Equal(want) {
+		t.Errorf("after ] picker at %v, want %v", got.picker.at, want)
+	}
+	got = send(runes("l"), tea.KeyMsg{Type: tea.KeyTab}, runes("k"), tea.KeyMsg{Type: tea.KeyTab}, runes("k"), runes("k"))
+	want := time.Date(2026, 3, 1, 12, 10, 0, 0, time.UTC)
+	if !got.picker.at.Equal(want) {
+		t.Errorf("picker at %v, want %v", got.picker.at, want)
+	}
+
+	got = send(tea.KeyMsg{Type: tea.KeyEnter})
+	if got.mode != modeNormal {
+		t.Errorf("mode = %v after enter, want normal", got.mode)
+	}
+	if !r.DateTime.Equal(want) || r.Description != "Pay rent" || len(r.Tags) != 1 || r.Tags[0] != "home" {
+		t.Errorf("rescheduled reminder = %v %q %v, want only the time changed", r.DateTime, r.Description, r.Tags)
+	}
+	if r.Status != reminder.Pending {
+		t.Errorf("status = %v, want pending now that it's in the future", r.Status)
+	}
+
+	// Esc leaves the reminder alone, and the detail view is returned to
+	got.mode = modeDetail
+	got.detailReminder = r
+	updated = got
+	got = send(runes("R"), runes("h"), tea.KeyMsg{Type: tea.KeyEscape})
+	if got.mode != modeDetail || !r.DateTime.Equal(want) {
+		t.Errorf("after esc mode = %v and time %v, want detail view and %v", got.mode, r.DateTime, want)
+	}
+}
+
+func TestBulkShift(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+	late := &reminder.Reminder{ID: "1", DateTime: now.Add(-time.Hour), Description: "Ship beta", Tags: []string{"launch"}, Status: reminder.Triggered}
+	later := &reminder.Reminder{ID: "2", DateTime: now.Add(2 * time.Hour), Description: "Launch party", Tags: []string{"launch"}, Status: reminder.Pending}
+	done := &reminder.Reminder{ID: "3", DateTime: now.Add(-2 * time.Hour), Description: "Write copy", Tags: []string{"launch"}, Status: reminder.Acknowledged}
+	other := &reminder.Reminder{ID: "4", DateTime: now.Add(time.Hour), Description: "Dentist", Status: reminder.Pending}
+	m := New([]*reminder.Reminder{done, late, other, later}, nil, nil).WithClock(clock.Fixed(now))
+	m.filterInput.SetValue("#launch")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	send := func(msgs ...tea.KeyMsg) Model {
+		t.Helper()
+		for _, msg := range msgs {
+			updated, _ = updated.(Model).Update(msg)
+		}
+		return updated.(Model)
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	got := send(runes("S"), runes("+1w"))
+	if got.mode != modeShift {
+		t.Fatalf("mode = %v after S, want shift", got.mode)
+	}
+	view := got.View()
+	if !strings.Contains(view, "Shift 2 reminders") || !strings.Contains(view, "Wed Mar 11 9:00am") {
+		t.Errorf("preview missing count or shifted time:\n%s", view)
+	}
+
+	got = send(tea.KeyMsg{Type: tea.KeyEnter})
+	if got.mode != modeNormal {
+		t.Errorf("mode = %v after enter, want normal", got.mode)
+	}
+	if want := now.Add(-time.Hour).AddDate(0, 0, 7); !late.DateTime.Equal(want) || late.Status != reminder.Pending {
+		t.Errorf("overdue reminder = %v %v, want %v pending", late.DateTime, late.Status, want)
+	}
+	if want := now.Add(2*time.Hour).AddDate(0, 0, 7); !later.DateTime.Equal(want) {
+		t.Errorf("later reminder at %v, want %v", later.DateTime, want)
+	}
+	if !done.DateTime.Equal(now.Add(-2*time.Hour)) || !other.DateTime.Equal(now.Add(time.Hour)) {
+		t.Errorf("done or filtered-out reminder moved: %v, %v", done.DateTime, other.DateTime)
+	}
+
+	send(runes("U"))
+	if !late.DateTime.Equal(now.Add(-time.Hour)) || late.Status != reminder.Triggered || !later.DateTime.Equal(now.Add(2*time.Hour)) {
+		t.Errorf("after undo = %v %v and %v, want the original times", late.DateTime, late.Status, later.DateTime)
+	}
+
+	// A bad offset is reported and nothing moves
+	got = send(runes("S"), runes("next week"), tea.KeyMsg{Type: tea.KeyEnter})
+	if got.mode != modeShift || got.inputError == "" {
+		t.Errorf("bad offset: mode = %v, error %q; want to stay with an error", got.mode, got.inputError)
+	}
+}
+
+func TestParseCommand(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local)
+	at := func(days, hour int) time.Time { return time.Date(2026, 3, 4+days, hour, 0, 0, 0, time.Local) }
+	reminders := []*reminder.Reminder{
+		{ID: "1", Description: "Standup Monday", DateTime: at(-2, 9), Status: reminder.Triggered, Tags: []string{"standup"}},
+		{ID: "2", Description: "Standup today", DateTime: at(0, 9), Status: reminder.Triggered, Tags: []string{"standup"}},
+		{ID: "3", Description: "Standup tomorrow", DateTime: at(1, 9), Status: reminder.Pending, Tags: []string{"standup"}},
+		{ID: "4", Description: "Report", DateTime: at(-1, 17), Status: reminder.Triggered},
+		{ID: "5", Description: "Done", DateTime: at(-1, 8), Status: reminder.Acknowledged},
+		{ID: "6", Description: "Blocked", DateTime: at(-1, 12), Status: reminder.Waiting},
+	}
+
+	tests := []struct {
+		input   string
+		want    []string
+		wantErr bool
+	}{
+		{input: "ack all #standup before today", want: []string{"1"}},
+		{input: ":ack overdue", want: []string{"1", "2", "4"}},
+		{input: "done #standup after today", want: []string{"2", "3"}},
+		{input: "reschedule overdue to tomorrow 9am", want: []string{"1", "2", "4"}},
+		{input: "shift today by +1d", want: []string{"2"}},
+		{input: "reopen all before today", want: []string{"5", "6"}},
+		{input: "ack tomorrow", want: []string{"3"}},
+		{input: "delete before mar 3 2026 12pm", want: []string{"1", "5"}},
+		{input: "wait #Standup", want: []string{"1", "2", "3"}},
+		{input: "", wantErr: true},
+		{input: "frobnicate all", wantErr: true},
+		{input: "ack", wantErr: true},
+		{input: "ack everything", wantErr: true},
+		{input: "reschedule overdue", wantErr: true},
+		{input: "reschedule overdue to whenever", wantErr: true},
+		{input: "shift all by 3 days", wantErr: true},
+		{input: "ack before", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			cmd, err := parseCommand(tt.input, now)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("parseCommand(%q) succeeded, want an error", tt.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseCommand(%q) error: %v", tt.input, err)
+			}
+			var got []string
+			for _, r := range cmd.targets(reminders) {
+				got = append(got, r.ID)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("parseCommand(%q) selects %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearch(t *testing.T) {
+	saved := currentLayout
+	currentLayout = LayoutCompact
+	t.Cleanup(func() { currentLayout = saved })
+
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local)
+	rs := []*reminder.Reminder{
+		{ID: "a", DateTime: now.Add(time.Hour), Description: "Review the budget", Status: reminder.Pending},
+		{ID: "b", DateTime: now.Add(2 * time.Hour), Description: "Standup", Status: reminder.Pending},
+		{ID: "c", DateTime: now.Add(3 * time.Hour), Description: "Code review", Status: reminder.Pending},
+		{ID: "d", DateTime: now.Add(4 * time.Hour), Description: "Lunch", Status: reminder.Pending},
+	}
+	m := New(rs, nil, nil).WithClock(clock.Fixed(now))
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	send := func(msgs ...tea.KeyMsg) Model {
+		t.Helper()
+		for _, msg := range msgs {
+			updated, _ = updated.(Model).Update(msg)
+		}
+		return updated.(Model)
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	// The cursor follows the first match as the search is typed, and
+	// nothing is filtered out
+	got := send(runes("f"), runes("REVIEW"))
+	if got.mode != modeSearch || got.compactIndex != 0 || !strings.Contains(got.View(), "2 found") {
+		t.Fatalf("searching: mode %v, cursor %d:\n%s", got.mode, got.compactIndex, got.View())
+	}
+	got = send(tea.KeyMsg{Type: tea.KeyEnter})
+	if len(got.getFilteredReminders()) != 4 || !strings.Contains(got.statusBarView(), "find: REVIEW") {
+		t.Errorf("a search should keep every reminder and show in the status bar: %q", got.statusBarView())
+	}
+
+	// n and N move between matches, wrapping around
+	for _, step := range []struct {
+		key  string
+		want int
+	}{{"n", 2}, {"n", 0}, {"N", 2}} {
+		if got = send(runes(step.key)); got.compactIndex != step.want {
+			t.Errorf("%s moved to %d, want %d", step.key, got.compactIndex, step.want)
+		}
+	}
+
+	// esc ends the search, and n adds a reminder again
+	got = send(tea.KeyMsg{Type: tea.KeyEscape}, runes("n"))
+	if got.searching() || got.mode != modeAdd {
+		t.Errorf("after esc, n should open add mode, got mode %v", got.mode)
+	}
+
+	// Highlighting keeps the text and leaves the columns before the
+	// description alone
+	line := ansi.Strip(renderCompactLine(">", rs[0], 120, now, "", normalStyle))
+	if out := renderCompactLine(">", rs[0], 120, now, "review", normalStyle); ansi.Strip(out) != line {
+		t.Errorf("renderCompactLine changed the text: %q", ansi.Strip(out))
+	}
+}
+
+func TestDateRangeFilter(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local) // A Wednesday
+	rs := []*reminder.Reminder{
+		{ID: "late", DateTime: now.Add(-24 * time.Hour), Description: "Expense report", Tags: []string{"work"}, Status: reminder.Triggered},
+		{ID: "today", DateTime: now.Add(2 * time.Hour), Description: "Standup", Tags: []string{"work"}, Status: reminder.Pending},
+		{ID: "friday", DateTime: now.AddDate(0, 0, 2), Description: "Review", Tags: []string{"work"}, Status: reminder.Pending},
+		{ID: "saturday", DateTime: now.AddDate(0, 0, 3), Description: "Groceries", Status: reminder.Pending},
+		{ID: "monday", DateTime: now.AddDate(0, 0, 5), Description: "Dentist", Status: reminder.Pending},
+	}
+	m := New(rs, nil, nil).WithClock(clock.Fixed(now))
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	send := func(msgs ...tea.KeyMsg) Model {
+		t.Helper()
+		for _, msg := range msgs {
+			updated, _ = updated.(Model).Update(msg)
+		}
+		return updated.(Model)
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+	ids := func(got Model) string {
+		var s []string
+		for _, r := range got.matchingReminders() {
+			s = append(s, r.ID)
+		}
+		return strings.Join(s, " ")
+	}
+
+	// Number keys in the filter box pick a range
+	tests := []struct {
+		key, want string
+	}{
+		{"1", "today"},
+		{"2", "today friday"},
+		{"3", "today friday saturday"},
+		{"4", "late"},
+		{"0", "late today friday saturday monday"},
+	}
+	for _, tt := range tests {
+		got := send(runes("/"), runes(tt.key), enter)
+		if ids(got) != tt.want {
+			t.Errorf("range %s: got %q, want %q", dateRangeNames[got.dateRange], ids(got), tt.want)
+		}
+	}
+
+	// The command palette sets one too, and it combines with a tag filter
+	got := send(runes(":"), runes("filter next 3 days"), enter, runes("/"), runes("#work"), enter)
+	if got.dateRange != rangeNext3Days || ids(got) != "today friday" {
+		t.Errorf("next 3 days of #work = %q", ids(got))
+	}
+	if bar := got.statusBarView(); !strings.Contains(bar, "next 3 days") {
+		t.Errorf("status bar should show the range: %q", bar)
+	}
+	if got = send(runes(":"), runes("filter soon"), enter); got.mode != modeCommand || got.inputError == "" {
+		t.Errorf("unknown range should be an error, got mode %v", got.mode)
+	}
+	if got = send(tea.KeyMsg{Type: tea.KeyEscape}, runes(":"), runes("filter clear"), enter); got.dateRange != rangeAny {
+		t.Errorf("filter clear left range %s", dateRangeNames[got.dateRange])
+	}
+}
+
+func TestCommandMode(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local)
+	late := &reminder.Reminder{ID: "1", DateTime: now.Add(-time.Hour), Description: "Standup", Tags: []string{"standup"}, Status: reminder.Triggered}
+	soon := &reminder.Reminder{ID: "2", DateTime: now.Add(time.Hour), Description: "Review", Status: reminder.Pending}
+	m := New([]*reminder.Reminder{late, soon}, nil, nil).WithClock(clock.Fixed(now))
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	send := func(msgs ...tea.KeyMsg) Model {
+		t.Helper()
+		for _, msg := range msgs {
+			updated, _ = updated.(Model).Update(msg)
+		}
+		return updated.(Model)
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+
+	got := send(runes(":"), runes("reschedule overdue to tomorrow 9am"), enter)
+	if got.command == nil {
+		t.Fatalf("no confirmation after enter, error %q", got.inputError)
+	}
+	if view := got.View(); !strings.Contains(view, "Reschedule 1 reminder to tomorrow 9am?") {
+		t.Errorf("confirmation missing summary:\n%s", view)
+	}
+	if !late.DateTime.Equal(now.Add(-time.Hour)) {
+		t.Fatalf("reminder moved before confirming")
+	}
+
+	got = send(runes("y"))
+	want := time.Date(2026, 3, 5, 9, 0, 0, 0, time.Local)
+	if got.mode != modeNormal || !late.DateTime.Equal(want) || late.Status != reminder.Pending {
+		t.Errorf("after confirming: mode %v, reminder %v %v; want normal, %v pending", got.mode, late.DateTime, late.Status, want)
+	}
+	if !soon.DateTime.Equal(now.Add(time.Hour)) {
+		t.Errorf("unselected reminder moved to %v", soon.DateTime)
+	}
+
+	send(runes("U"))
+	if !late.DateTime.Equal(now.Add(-time.Hour)) || late.Status != reminder.Triggered {
+		t.Errorf("after undo = %v %v, want the original time, triggered", late.DateTime, late.Status)
+	}
+
+	// Declining goes back to the command, and nothing matching is an error
+	got = send(runes(":"), runes("ack all"), enter, runes("n"))
+	if got.mode != modeCommand || got.command != nil || late.Status == reminder.Acknowledged {
+		t.Errorf("after declining: mode %v, command %v, status %v", got.mode, got.command, late.Status)
+	}
+	got = send(tea.KeyMsg{Type: tea.KeyEscape}, runes(":"), runes("ack #nothing"), enter)
+	if got.command != nil || got.inputError == "" {
+		t.Errorf("command matching nothing: error %q, want one", got.inputError)
+	}
+}
+
+func TestExportCommand(t *testing.T) {
+	t.Chdir(t.TempDir())
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local)
+	rs := []*reminder.Reminder{
+		{ID: "1", DateTime: now.Add(time.Hour), Description: "Standup", Tags: []string{"work"}, Status: reminder.Pending},
+		{ID: "2", DateTime: now.Add(2 * time.Hour), Description: "Groceries", Status: reminder.Pending},
+	}
+	m := New(rs, nil, nil).WithClock(clock.Fixed(now))
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	send := func(msgs ...tea.KeyMsg) Model {
+		t.Helper()
+		for _, msg := range msgs {
+			updated, _ = updated.(Model).Update(msg)
+		}
+		return updated.(Model)
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+
+	// Only the filtered view is exported
+	got := send(runes("/"), runes("#work"), enter, runes(":"), runes("export csv"), enter)
+	if got.mode != modeNormal {
+		t.Fatalf("mode %v after export, error %q", got.mode, got.inputError)
+	}
+	data, err := os.ReadFile("reminders-20260304-100000.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if csv := string(data); !strings.Contains(csv, "Standup,#work,pending") || strings.Contains(csv, "Groceries") {
+		t.Errorf("export of #work:\n%s", csv)
+	}
+
+	if got = send(runes(":"), runes("export pdf"), enter); got.mode != modeCommand || got.inputError == "" {
+		t.Errorf("unknown format should be an error, got mode %v", got.mode)
+	}
+}
+
+func TestTimer(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local)
+	report := &reminder.Reminder{ID: "1", DateTime: now.Add(time.Hour), Description: "Write report", Status: reminder.Pending}
+	review := &reminder.Reminder{ID: "2", DateTime: now.Add(2 * time.Hour), Description: "Review", Status: reminder.Pending}
+	clk := clock.NewFake(now)
+	m := New([]*reminder.Reminder{report, review}, nil, nil).WithClock(clk)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	send := func(msgs ...tea.KeyMsg) Model {
+		t.Helper()
+		for _, msg := range msgs {
+			updated, _ = updated.(Model).Update(msg)
+		}
+		return updated.(Model)
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	got := send(runes("T"))
+	if !report.TimerRunning() {
+		t.Fatal("T should start a timer on the selected reminder")
+	}
+	clk.Advance(12*time.Minute + 5*time.Second)
+	if bar := got.statusBarView(); !strings.Contains(bar, "12:05") || !strings.Contains(bar, "Write report") {
+		t.Errorf("status bar should show the running timer: %q", bar)
+	}
+
+	// Starting another timer stops the first
+	send(runes("j"), runes("T"))
+	if report.TimerRunning() || !review.TimerRunning() {
+		t.Errorf("running: report %v, review %v; want only review", report.TimerRunning(), review.TimerRunning())
+	}
+	clk.Advance(30 * time.Minute)
+	got = send(runes("T"))
+	if review.TimerRunning() || got.runningTimer() != nil {
+		t.Error("T again should stop the timer")
+	}
+	if spent := report.TimeSpent(clk.Now()); spent != 12*time.Minute+5*time.Second {
+		t.Errorf("time spent on report = %v, want 12m5s", spent)
+	}
+	if bar := got.statusBarView(); strings.Contains(bar, glyphs.Timer) {
+		t.Errorf("status bar still shows a timer: %q", bar)
+	}
+}
+
+func TestStatsEstimates(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local)
+	worked := func(d time.Duration) []reminder.Session {
+		return []reminder.Session{{Start: now.Add(-d - time.Hour), End: now.Add(-time.Hour)}}
+	}
+	m := New([]*reminder.Reminder{
+		{ID: "1", DateTime: now, Description: "Draft", Tags: []string{"writing"}, Estimate: time.Hour, Sessions: worked(90 * time.Minute), Status: reminder.Acknowledged},
+		{ID: "2", DateTime: now, Description: "Edit", Tags: []string{"writing"}, Estimate: time.Hour, Sessions: worked(time.Hour), Status: reminder.Acknowledged},
+		{ID: "3", DateTime: now, Description: "Standup", Tags: []string{"work"}, Estimate: 30 * time.Minute, Sessions: worked(15 * time.Minute), Status: reminder.Acknowledged},
+	}, nil, nil).WithClock(clock.Fixed(now))
+
+	view := ansi.Strip(m.statsView())
+	for _, want := range []string{
+		"Estimates vs. tracked time",
+		"#writing    2 done  est 2h 0m    actual 2h 30m   25% over",
+		"#work       1 done  est 30m      actual 15m      50% under",
+		"All         3 done  est 2h 30m   actual 2h 45m   10% over",
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("stats view missing %q:\n%s", want, view)
+		}
+	}
+
+	// Without estimates the section is left out
+	m = New([]*reminder.Reminder{{ID: "1", DateTime: now, Description: "Draft", Status: reminder.Acknowledged}}, nil, nil).WithClock(clock.Fixed(now))
+	if view := m.statsView(); strings.Contains(view, "Estimates") {
+		t.Errorf("stats view should leave out estimates when there are none:\n%s", view)
+	}
+}
+
+func TestLowEnergyMode(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local)
+	report := &reminder.Reminder{ID: "1", DateTime: now.Add(time.Hour), Description: "Write report", Effort: reminder.EffortHard, Status: reminder.Pending}
+	plan := &reminder.Reminder{ID: "2", DateTime: now.Add(2 * time.Hour), Description: "Plan sprint", Status: reminder.Pending}
+	emails := &reminder.Reminder{ID: "3", DateTime: now.Add(3 * time.Hour), Description: "Reply to emails", Status: reminder.Pending}
+	m := New([]*reminder.Reminder{report, plan, emails}, nil, nil).WithClock(clock.Fixed(now))
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	send := func(msgs ...tea.KeyMsg) Model {
+		t.Helper()
+		for _, msg := range msgs {
+			updated, _ = updated.(Model).Update(msg)
+		}
+		return updated.(Model)
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+	order := func(m Model) []string {
+		var descs []string
+		for _, r := range m.getFilteredReminders() {
+			descs = append(descs, r.Description)
+		}
+		return descs
+	}
+
+	// E cycles the selected reminder's effort
+	send(runes("G"), runes("E"))
+	if emails.Effort != reminder.EffortEasy {
+		t.Fatalf("E set effort %v, want easy", emails.Effort)
+	}
+	got := send(runes("E"), runes("E"), runes("E"))
+	if emails.Effort != reminder.EffortNone {
+		t.Fatalf("E four times left effort %v, want none", emails.Effort)
+	}
+	got = send(runes("E"))
+
+	if want := []string{"Write report", "Plan sprint", "Reply to emails"}; !slices.Equal(order(got), want) {
+		t.Errorf("order = %v, want %v", order(got), want)
+	}
+	got = send(runes("L"))
+	if want := []string{"Reply to emails", "Plan sprint", "Write report"}; !slices.Equal(order(got), want) {
+		t.Errorf("low energy order = %v, want %v", order(got), want)
+	}
+	if bar := got.statusBarView(); !strings.Contains(bar, "low energy") {
+		t.Errorf("status bar should show low energy mode: %q", bar)
+	}
+	got = send(runes("L"))
+	if want := []string{"Write report", "Plan sprint", "Reply to emails"}; !slices.Equal(order(got), want) {
+		t.Errorf("order after L again = %v, want %v", order(got), want)
+	}
+}
+
+func TestFocusView(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local)
+	standup := &reminder.Reminder{ID: "1", DateTime: now.Add(-time.Hour), Description: "Standup", Status: reminder.Triggered}
+	review := &reminder.Reminder{ID: "2", DateTime: now.Add(12*time.Minute + 5*time.Second), Description: "Review PR", Status: reminder.Pending}
+	lunch := &reminder.Reminder{ID: "3", DateTime: now.Add(2 * time.Hour), Description: "Lunch", Status: reminder.Pending}
+	clk := clock.NewFake(now)
+	m := New([]*reminder.Reminder{standup, review, lunch}, nil, nil).WithClock(clk)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	send := func(msgs ...tea.Msg) Model {
+		t.Helper()
+		for _, msg := range msgs {
+			updated, _ = updated.(Model).Update(msg)
+		}
+		return updated.(Model)
+	}
+	focus := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Z")}
+
+	got := send(focus)
+	if got.mode != modeFocus {
+		t.Fatalf("Z left mode = %v, want modeFocus", got.mode)
+	}
+	view := got.View()
+	if !strings.Contains(view, "Review PR") || strings.Contains(view, "Lunch") || strings.Contains(view, "Standup") {
+		t.Errorf("focus view should show only the next reminder due:\n%s", view)
+	}
+	if big := strings.Split(bigText("12:05"), "\n"); !strings.Contains(view, big[0]) || !strings.Contains(view, big[4]) {
+		t.Errorf("focus view missing the big countdown:\n%s", view)
+	}
+
+	// Any key goes back to the list
+	if got = send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")}); got.mode != modeNormal {
+		t.Errorf("a key left mode = %v, want modeNormal", got.mode)
+	}
+
+	// So does the reminder triggering, with the cursor on it
+	send(focus)
+	clk.Advance(13 * time.Minute)
+	got = send(TickMsg(clk.Now()))
+	if got.mode != modeNormal || got.selectedReminder() != review {
+		t.Errorf("after triggering: mode %v, selected %v; want modeNormal on Review PR", got.mode, got.selectedReminder())
+	}
+
+	// With nothing coming up there's nothing to focus on
+	lunch.Acknowledge(clk.Now())
+	if got = send(focus); got.mode != modeNormal {
+		t.Errorf("Z with nothing due left mode = %v, want modeNormal", got.mode)
+	}
+}
+
+func TestTriggeredAlert(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local)
+	standup := &reminder.Reminder{ID: "1", DateTime: now.Add(time.Minute), Description: "Standup", Status: reminder.Pending}
+	review := &reminder.Reminder{ID: "2", DateTime: now.Add(2 * time.Minute), Description: "Review PR", SourceFile: "https://example.com/pr/1", Status: reminder.Pending}
+	lunch := &reminder.Reminder{ID: "3", DateTime: now.Add(3 * time.Minute), Description: "Lunch", Status: reminder.Pending}
+	clk := clock.NewFake(now)
+	m := New([]*reminder.Reminder{standup, review, lunch}, nil, nil).WithConfig(config.Default()).WithClock(clk)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	send := func(msgs ...tea.Msg) Model {
+		t.Helper()
+		for _, msg := range msgs {
+			updated, _ = updated.(Model).Update(msg)
+		}
+		return updated.(Model)
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	clk.Advance(2*time.Minute + 5*time.Second)
+	got := send(TickMsg(clk.Now()))
+	if got.mode != modeAlert {
+		t.Fatalf("mode = %v after reminders triggered, want modeAlert", got.mode)
+	}
+	view := got.View()
+	if !strings.Contains(view, "Reminder due (1 of 2)") || !strings.Contains(view, "Standup") {
+		t.Errorf("alert should show the first reminder of two:\n%s", view)
+	}
+	if big := strings.Split(bigText("1:05"), "\n"); !strings.Contains(view, big[0]) {
+		t.Errorf("alert missing how long it's been overdue:\n%s", view)
+	}
+
+	// A snooze deals with it and moves on to the next
+	got = send(runes("1"))
+	if standup.Status != reminder.Snoozed || got.mode != modeAlert || got.alertReminder() != review {
+		t.Fatalf("after snoozing: status %v, mode %v, alert %v; want the next alert", standup.Status, got.mode, got.alertReminder())
+	}
+
+	var opened string
+	defer func(orig func(string) tea.Cmd) { openURL = orig }(openURL)
+	openURL = func(url string) tea.Cmd { opened = url; return nil }
+	got = send(runes("o"))
+	if opened != review.SourceFile || got.mode != modeNormal || review.Status != reminder.Triggered {
+		t.Errorf("o opened %q, mode %v, status %v; want the link opened and the reminder left triggered", opened, got.mode, review.Status)
+	}
+
+	// Alerts wait until the list is showing
+	got = send(runes("n"))
+	clk.Advance(time.Minute)
+	if got = send(TickMsg(clk.Now())); got.mode != modeAdd {
+		t.Errorf("an alert interrupted typing: mode %v", got.mode)
+	}
+	got = send(tea.KeyMsg{Type: tea.KeyEscape}, TickMsg(clk.Now()))
+	if got.mode != modeAlert || got.alertReminder() != lunch {
+		t.Fatalf("alert for Lunch should show once back at the list, mode %v", got.mode)
+	}
+	got = send(tea.KeyMsg{Type: tea.KeyEnter})
+	if lunch.Status != reminder.Acknowledged || got.mode != modeNormal {
+		t.Errorf("enter should acknowledge and close the alert: status %v, mode %v", lunch.Status, got.mode)
+	}
+}
+
+func TestTerminalAttention(t *testing.T) {
+	rung := 0
+	defer func(orig func()) { ringBell = orig }(ringBell)
+	ringBell = func() { rung++ }
+
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local)
+	missed := &reminder.Reminder{ID: "1", DateTime: now.Add(-time.Hour), Description: "Missed", Status: reminder.Triggered}
+	standup := &reminder.Reminder{ID: "2", DateTime: now.Add(time.Minute), Description: "Standup", Status: reminder.Pending}
+	clk := clock.NewFake(now)
+	cfg := config.Default()
+	cfg.UI.Alerts = false
+	var updated tea.Model = New([]*reminder.Reminder{missed, standup}, nil, nil).WithConfig(cfg).WithClock(clk)
+	tick := func() Model {
+		t.Helper()
+		updated, _ = updated.(Model).Update(TickMsg(clk.Now()))
+		return updated.(Model)
+	}
+
+	// Reminders already triggered at startup show in the title but don't ring
+	if got := tick(); got.title != "go_remind — 1 due!" || rung != 0 {
+		t.Errorf("title %q, bell rung %d times; want 1 due and no bell", got.title, rung)
+	}
+	clk.Advance(2 * time.Minute)
+	if got := tick(); got.title != "go_remind — 2 due!" || rung != 1 {
+		t.Errorf("title %q, bell rung %d times; want 2 due and one bell", got.title, rung)
+	}
+	tick()
+	missed.Acknowledge(clk.Now())
+	standup.Acknowledge(clk.Now())
+	if got := tick(); got.title != "go_remind" || rung != 1 {
+		t.Errorf("title %q, bell rung %d times; want the plain title and no more bells", got.title, rung)
+	}
+}
+
+func TestQuickCapture(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local)
+	var q tea.Model = NewQuickCapture(config.Default()).WithClock(clock.Fixed(now))
+	send := func(msg tea.Msg) tea.Cmd {
+		t.Helper()
+		var cmd tea.Cmd
+		q, cmd = q.Update(msg)
+		return cmd
+	}
+
+	for _, r := range "Call" {
+		send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if view := ansi.Strip(q.View()); !strings.Contains(view, "no time yet") {
+		t.Errorf("preview should say there's no time yet:\n%s", view)
+	}
+	if send(tea.KeyMsg{Type: tea.KeyEnter}) != nil || q.(QuickCapture).Reminder() != nil {
+		t.Fatal("enter without a time shouldn't add anything")
+	}
+	if view := ansi.Strip(q.View()); !strings.Contains(view, "must have both datetime and description") {
+		t.Errorf("enter without a time should show why:\n%s", view)
+	}
+
+	q = NewQuickCapture(config.Default()).WithClock(clock.Fixed(now))
+	send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("+1h Call mom #family ~30m")})
+	view := ansi.Strip(q.View())
+	if !strings.Contains(view, "Wed Mar 4 at 11:00 AM · in 1h 0m  Call mom  #family ~30m") {
+		t.Errorf("preview should show the reminder to be added:\n%s", view)
+	}
+	cmd := send(tea.KeyMsg{Type: tea.KeyEnter})
+	r := q.(QuickCapture).Reminder()
+	if r == nil || r.Description != "Call mom" || r.ID == "" || r.SourceFile != reminder.StandaloneSource {
+		t.Fatalf("Reminder() = %+v, want a standalone Call mom", r)
+	}
+	if cmd == nil || cmd() != tea.Quit() {
+		t.Error("enter should exit once the reminder is entered")
+	}
+}
+
+func TestConflictWarning(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local)
+	standup := &reminder.Reminder{ID: "1", DateTime: now.Add(time.Hour), Description: "Standup", Status: reminder.Pending}
+	m := New([]*reminder.Reminder{standup}, nil, nil).WithClock(clock.Fixed(now))
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	send := func(msgs ...tea.KeyMsg) Model {
+		t.Helper()
+		for _, msg := range msgs {
+			updated, _ = updated.(Model).Update(msg)
+		}
+		return updated.(Model)
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+
+	got := send(runes("n"), runes("2026-03-04 11:10 Review"), enter)
+	if got.mode != modeAdd || !strings.Contains(got.inputError, "Standup") {
+		t.Fatalf("mode %v, error %q; want a warning naming Standup", got.mode, got.inputError)
+	}
+	if len(got.reminders) != 1 {
+		t.Fatalf("reminder added before the warning was confirmed")
+	}
+	got = send(enter)
+	if got.mode != modeNormal || len(got.reminders) != 2 {
+		t.Fatalf("enter again: mode %v, %d reminders; want it saved", got.mode, len(got.reminders))
+	}
+
+	// The digest marks both
+	got.mode = modeDigest
+	if view := got.View(); strings.Count(view, "conflict") != 2 {
+		t.Errorf("digest should mark 2 conflicts:\n%s", view)
+	}
+
+	// Editing a reminder doesn't conflict with itself, and a free time
+	// saves straight away
+	got.mode = modeNormal
+	updated = got
+	got = send(runes("e"), tea.KeyMsg{Type: tea.KeyCtrlU}, runes("2026-03-04 11:00 Standup moved"), enter)
+	if got.editingReminder != standup {
+		t.Fatalf("editing %v, want Standup", got.editingReminder)
+	}
+	if !strings.Contains(got.inputError, "Review") || strings.Contains(got.inputError, "Standup (") {
+		t.Errorf("editing Standup warned %q, want only Review named", got.inputError)
+	}
+	updated = got
+	got = send(tea.KeyMsg{Type: tea.KeyEscape}, runes("n"), runes("2026-03-04 15:00 Lunch"), enter)
+	if got.mode != modeNormal || len(got.reminders) != 3 {
+		t.Errorf("free time: mode %v, error %q; want it saved", got.mode, got.inputError)
+	}
+}
+
+func TestFindSlot(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local)
+	standup := &reminder.Reminder{ID: "1", DateTime: now.Add(time.Hour), Description: "Standup", Status: reminder.Pending}
+	m := New([]*reminder.Reminder{standup}, nil, nil).WithClock(clock.Fixed(now))
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	send := func(msgs ...tea.KeyMsg) Model {
+		t.Helper()
+		for _, msg := range msgs {
+			updated, _ = updated.(Model).Update(msg)
+		}
+		return updated.(Model)
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+
+	got := send(runes("n"), runes("+1h Call mom"), tea.KeyMsg{Type: tea.KeyCtrlF}, runes("30m"))
+	if got.mode != modeSlots {
+		t.Fatalf("mode %v, want the slot finder", got.mode)
+	}
+	// Around Standup, then the rest of the working day, then tomorrow
+	want := []string{"10:00am", "11:30am", "9:00am"}
+	if len(got.slots) != len(want) {
+		t.Fatalf("found %d slots, want %d", len(got.slots), len(want))
+	}
+	for i, s := range got.slots {
+		if s.Start.Format("3:04pm") != want[i] {
+			t.Errorf("slot %d starts %s, want %s", i, s.Start.Format("3:04pm"), want[i])
+		}
+	}
+	if view := got.View(); !strings.Contains(view, "Tomorrow") {
+		t.Errorf("view should list tomorrow's slot:\n%s", view)
+	}
+
+	// The picked slot replaces the typed time, keeping the description
+	got = send(tea.KeyMsg{Type: tea.KeyDown}, enter)
+	if got.mode != modeAdd || got.addInput.Value() != "2026-03-04 11:30 Call mom" {
+		t.Fatalf("mode %v, input %q; want the slot's time in the add input", got.mode, got.addInput.Value())
+	}
+	got = send(enter)
+	if got.mode != modeNormal || len(got.reminders) != 2 {
+		t.Fatalf("mode %v, error %q; want it saved without a conflict", got.mode, got.inputError)
+	}
+
+	// esc goes back to the input untouched
+	got = send(runes("n"), runes("Lunch"), tea.KeyMsg{Type: tea.KeyCtrlF}, tea.KeyMsg{Type: tea.KeyEscape})
+	if got.mode != modeAdd || got.addInput.Value() != "Lunch" {
+		t.Errorf("esc: mode %v, input %q; want add mode with Lunch", got.mode, got.addInput.Value())
+	}
+}
+
+// fakeSource is a remote source returning canned reminders and recording
+// acknowledgments
+type fakeSource struct {
+	reminders []*reminder.Reminder
+	err       error
+	acked     []string
+}
+
+func (f *fakeSource) Name() string { return "fake:tracker" }
+
+func (f *fakeSource) Fetch() ([]*reminder.Reminder, error) {
+	var out []*reminder.Reminder
+	for _, r := range f.reminders {
+		c := *r
+		out = append(out, &c)
+	}
+	return out, f.err
+}
+
+func (f *fakeSource) Acknowledge(r *reminder.Reminder) error {
+	f.acked = append(f.acked, r.Description)
+	return nil
+}
+
+func TestRemoteSources(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local)
+	src := &fakeSource{reminders: []*reminder.Reminder{
+		{ID: "issue-1", Description: "Ship it (app#1)", DateTime: now.Add(-time.Hour), SourceFile: "https://example.com/1", From: "Ada <ada@example.com>", Status: reminder.Pending},
+	}}
+	m := New(nil, nil, nil).WithClock(clock.Fixed(now)).WithSources([]sources.Source{src}, time.Hour)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	got := updated.(Model)
+	fetch := got.checkSources(now)
+	if fetch == nil {
+		t.Fatal("checkSources() should fetch a source that has never been fetched")
+	}
+	updated, _ = got.Update(fetch())
+	got = updated.(Model)
+	if len(got.reminders) != 1 || got.reminders[0].Source != "fake:tracker" {
+		t.Fatalf("reminders after fetch = %v, want the issue from fake:tracker", got.reminders)
+	}
+	if got.checkSources(now.Add(time.Minute)) != nil {
+		t.Error("source fetched again before its interval")
+	}
+
+	// The detail view shows who it's from
+	got.list.Select(0)
+	detail, _ := got.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("K")})
+	if view := detail.(Model).View(); !strings.Contains(view, "From: Ada <ada@example.com>") {
+		t.Errorf("detail view should show the sender:\n%s", view)
+	}
+
+	// o opens the issue
+	var opened string
+	defer func(orig func(string) tea.Cmd) { openURL = orig }(openURL)
+	openURL = func(url string) tea.Cmd { opened = url; return nil }
+	got.list.Select(0)
+	updated, _ = got.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	if opened != "https://example.com/1" {
+		t.Errorf("opened %q, want the issue's link", opened)
+	}
+	got = updated.(Model)
+
+	// Acknowledging tells the source
+	updated, ack := got.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if ack == nil {
+		t.Fatal("acknowledging a sourced reminder should tell its source")
+	}
+	updated, _ = updated.(Model).Update(ack())
+	if len(src.acked) != 1 || src.acked[0] != "Ship it (app#1)" {
+		t.Errorf("source acked %q, want the issue", src.acked)
+	}
+
+	// A failed fetch keeps what was pulled before
+	src.err = fmt.Errorf("rate limited")
+	got = updated.(Model)
+	updated, _ = got.Update(got.checkSources(now.Add(2 * time.Hour))())
+	if got := updated.(Model); len(got.reminders) != 1 || got.sourceStates["fake:tracker"].err == nil {
+		t.Errorf("after a failed fetch: %d reminders, error %v", len(got.reminders), got.sourceStates["fake:tracker"].err)
+	}
+}
+
+func TestSourceBackoff(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local)
+	clk := clock.NewFake(now)
+	src := &fakeSource{reminders: []*reminder.Reminder{
+		{ID: "issue-1", Description: "Ship it (app#1)", DateTime: now.Add(time.Hour), Status: reminder.Pending},
+	}}
+	m := New(nil, nil, nil).WithClock(clk).WithSources([]sources.Source{src}, 15*time.Minute)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	got := updated.(Model)
+	updated, _ = got.Update(got.checkSources(now)())
+	got = updated.(Model)
+
+	// Failures are retried sooner than the interval, backing off each time
+	src.err = fmt.Errorf("rate limited")
+	clk.Advance(15 * time.Minute)
+	var toasts int
+	for i, want := range []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 8 * time.Minute, 16 * time.Minute, 32 * time.Minute, time.Hour} {
+		fetch := got.checkSources(clk.Now())
+		if fetch == nil {
+			t.Fatalf("failure %d: source not retried when due", i)
+		}
+		updated, _ = got.Update(fetch())
+		got = updated.(Model)
+		toasts += len(got.toasts)
+		got.toasts = nil
+		if wait := got.sourceStates["fake:tracker"].next.Sub(clk.Now()); wait != want {
+			t.Errorf("after failure %d, retried in %v, want %v", i+1, wait, want)
+		}
+		clk.Set(got.sourceStates["fake:tracker"].next)
+	}
+	if toasts != 1 {
+		t.Errorf("%d error toasts, want only the first failure", toasts)
+	}
+
+	// It's shown as stale since it last worked
+	if bar := got.statusBarView(); !strings.Contains(bar, "fake:tracker stale since 10:00") {
+		t.Errorf("status bar should warn about the stale source: %q", bar)
+	}
+	updated, _ = got.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("F")})
+	if view := updated.(Model).View(); !strings.Contains(view, "stale since 10:00") {
+		t.Errorf("sources view should say since when the source is stale:\n%s", view)
+	}
+
+	// Working again clears it
+	src.err = nil
+	updated, _ = got.Update(got.checkSources(clk.Now())())
+	got = updated.(Model)
+	if st := got.sourceStates["fake:tracker"]; st.failures != 0 || st.next.Sub(clk.Now()) != 15*time.Minute {
+		t.Errorf("after a success: %d failures, next in %v", st.failures, st.next.Sub(clk.Now()))
+	}
+	if bar := got.statusBarView(); strings.Contains(bar, "stale") {
+		t.Errorf("status bar still warns after a success: %q", bar)
+	}
+}
+
+// scheduledSource is a fakeSource with its own refresh interval
+type scheduledSource struct {
+	*fakeSource
+	every time.Duration
+}
+
+func (s scheduledSource) Interval() time.Duration { return s.every }
+
+// fakeSourceSettings keeps source settings in memory
+type fakeSourceSettings struct {
+	saved state.SourceSettings
+}
+
+func (f *fakeSourceSettings) LoadSourceSettings() (state.SourceSettings, error) { return f.saved, nil }
+
+func (f *fakeSourceSettings) SaveSourceSettings(s state.SourceSettings) error {
+	f.saved = s
+	return nil
+}
+
+func TestSourcesView(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local)
+	feed := scheduledSource{&fakeSource{reminders: []*reminder.Reminder{
+		{ID: "cfp-1", Description: "GopherCon talks", DateTime: now.Add(time.Hour), Status: reminder.Pending},
+	}}, 24 * time.Hour}
+	settings := &fakeSourceSettings{}
+	m := New(nil, nil, nil).WithClock(clock.Fixed(now)).WithSources([]sources.Source{feed}, time.Hour).WithSourceSettings(settings)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	got := updated.(Model)
+	updated, _ = got.Update(got.checkSources(now)())
+	got = updated.(Model)
+
+	// A feed refreshes on its own interval
+	if got.checkSources(now.Add(2*time.Hour)) != nil || got.checkSources(now.Add(25*time.Hour)) == nil {
+		t.Error("feed should be fetched again after a day, not the hour set for all sources")
+	}
+	got.sourceStates["fake:tracker"].fetching = false
+
+	updated, _ = got.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("F")})
+	got = updated.(Model)
+	if view := got.View(); got.mode != modeSources || !strings.Contains(view, "fake:tracker     1  refreshed 0s ago") {
+		t.Fatalf("F should open the sources view with the feed, its count, and when it was refreshed:\n%s", view)
+	}
+
+	// Pausing keeps its reminders but stops fetching it
+	updated, _ = got.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	got = updated.(Model)
+	if len(got.reminders) != 1 || got.checkSources(now.Add(48*time.Hour)) != nil || len(settings.saved.Paused) != 1 {
+		t.Errorf("after pausing: %d reminders, saved %+v; want it kept, not fetched, and saved", len(got.reminders), settings.saved)
+	}
+	updated, refresh := got.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	got = updated.(Model)
+	if refresh == nil {
+		t.Fatal("resuming should refresh the source")
+	}
+	updated, _ = got.Update(refresh())
+	got = updated.(Model)
+
+	// Removing it deletes its reminders, stops fetching it, and is saved
+	updated, _ = got.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	got = updated.(Model)
+	if len(got.reminders) != 0 || got.checkSources(now.Add(48*time.Hour)) != nil {
+		t.Errorf("after removing: %d reminders, still fetched", len(got.reminders))
+	}
+	if len(settings.saved.Removed) != 1 || settings.saved.Removed[0] != "fake:tracker" {
+		t.Errorf("saved settings = %+v, want the feed removed", settings.saved)
+	}
+	if reloaded := New(nil, nil, nil).WithSources([]sources.Source{feed}, time.Hour).WithSourceSettings(settings); !reloaded.sourceStates["fake:tracker"].removed {
+		t.Error("a removed source should stay removed after a restart")
+	}
+
+	// Pressing d again restores it
+	updated, refresh = got.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	if refresh == nil || len(settings.saved.Removed) != 0 {
+		t.Error("a restored source should be fetched right away")
+	}
+}
+
+func TestSourcesViewWatchedPaths(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local)
+	dir := t.TempDir()
+	note := filepath.Join(dir, "todo.md")
+	parsed := []*reminder.Reminder{{ID: "a", Description: "Call Bob", DateTime: now.Add(time.Hour), SourceFile: note, Status: reminder.Pending}}
+	parse := func(path string) InitialParseMsg {
+		return InitialParseMsg{Path: path, Reminders: parsed}
+	}
+	m := New(nil, nil, nil).WithClock(clock.Fixed(now)).WithWatchedPaths([]string{dir}, parse)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	updated, _ = updated.(Model).Update(parse(dir))
+	got := updated.(Model)
+	if len(got.reminders) != 1 || got.sourceStates[dir].refreshed.IsZero() {
+		t.Fatalf("initial parse: %d reminders, refreshed %v", len(got.reminders), got.sourceStates[dir].refreshed)
+	}
+
+	updated, _ = got.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("F")})
+	got = updated.(Model)
+	if view := got.View(); !strings.Contains(view, dir+string(filepath.Separator)) {
+		t.Fatalf("sources view should list the watched directory:\n%s", view)
+	}
+
+	// A paused path ignores changes to its files
+	updated, _ = got.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	got = updated.(Model)
+	updated, _ = got.Update(FileUpdateMsg{FilePath: note})
+	if got := updated.(Model); len(got.reminders) != 1 {
+		t.Errorf("a change while paused was merged: %d reminders", len(got.reminders))
+	}
+
+	// Removing it deletes the reminders from its files
+	updated, _ = got.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	got = updated.(Model)
+	if len(got.reminders) != 0 {
+		t.Errorf("after removing: %d reminders, want none", len(got.reminders))
+	}
+
+	// r parses it again once it's back
+	updated, _ = got.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	updated, _ = updated.(Model).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	got = updated.(Model)
+	updated, refresh := got.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	if refresh != nil {
+		t.Error("r while a refresh is running should wait for it")
+	}
+	got = updated.(Model)
+	got.sourceStates[dir].fetching = false
+	_, refresh = got.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	if refresh == nil {
+		t.Fatal("r should parse the path again")
+	}
+	if msg, ok := refresh().(InitialParseMsg); !ok || msg.Path != dir {
+		t.Errorf("refresh = %#v, want a parse of %s", msg, dir)
+	}
+
+	// With an interval, it's parsed again that often
+	m = New(nil, nil, nil).WithClock(clock.Fixed(now)).WithWatchedPaths([]string{dir}, parse).
+		WithPathIntervals(map[string]time.Duration{dir: 30 * time.Minute})
+	updated, _ = m.Update(parse(dir))
+	got = updated.(Model)
+	if got.checkSources(now.Add(29*time.Minute)) != nil {
+		t.Error("watched path parsed again before its interval")
+	}
+	if refresh := got.checkSources(now.Add(30 * time.Minute)); refresh == nil {
+		t.Error("watched path should be parsed again after its interval")
+	}
 }
@@ -1,14 +1,16 @@
 package tui
 
 import (
-	"fmt"
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 
-	"go_remind/reminder"
+	"go_remind/export"
+	"go_remind/pkg/reminder"
 )
 
 // Update handles messages and updates the model
@@ -25,16 +27,91 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.updateThemeMode(msg)
 		case modeDetail:
 			return m.updateDetailMode(msg)
+		case modeDigest:
+			return m.updateDigestMode(msg)
+		case modePaste:
+			return m.updatePasteMode(msg)
+		case modeCheatsheet:
+			return m.updateCheatsheetMode(msg)
+		case modeStats:
+			return m.updateStatsMode(msg)
+		case modeOrphans:
+			return m.updateOrphansMode(msg)
+		case modeDuplicates:
+			return m.updateDuplicatesMode(msg)
+		case modeProfiles:
+			return m.updateProfilesMode(msg)
+		case modeSources:
+			return m.updateSourcesMode(msg)
+		case modeActivity:
+			return m.updateActivityMode(msg)
+		case modeReschedule:
+			return m.updateRescheduleMode(msg)
+		case modeShift:
+			return m.updateShiftMode(msg)
+		case modeCommand:
+			return m.updateCommandMode(msg)
+		case modeSlots:
+			return m.updateSlotsMode(msg)
+		case modeSearch:
+			return m.updateSearchMode(msg)
+		case modeFocus:
+			return m.updateFocusMode(msg)
+		case modeAlert:
+			return m.updateAlertMode(msg)
+		case modeMuted:
+			return m.updateMutedMode(msg)
+		case modeTimeline:
+			return m.updateTimelineMode(msg)
+		case modeWorkspaces:
+			return m.updateWorkspacesMode(msg)
+		case modeMergePreview:
+			return m.updateMergePreviewMode(msg)
+		case modeMergeLog:
+			return m.updateMergeLogMode(msg)
+		case modeWatcherHealth:
+			return m.updateWatcherHealthMode(msg)
+		case modePeople:
+			return m.updatePeopleMode(msg)
+		case modeAway:
+			return m.updateAwayMode(msg)
 		default:
 			return m.updateNormalMode(msg)
 		}
 
+	case ClipboardMsg:
+		m.applyClipboard(msg)
+		return m, nil
+
+	case SourceOpenedMsg:
+		if msg.err != nil {
+			m.toastError("Editor: " + msg.err.Error())
+		}
+		return m, nil
+
+	case ComposedMsg:
+		return m, m.applyComposed(msg)
+
+	case LinkOpenedMsg:
+		if msg.err != nil {
+			m.toastError("Could not open " + msg.target + ": " + msg.err.Error())
+		}
+		return m, nil
+
+	case StartupMsg:
+		return m, m.catchUp(m.now())
+
 	case TickMsg:
 		// Check for newly triggered reminders
+		now := m.now()
 		changed := false
 		for _, r := range m.reminders {
-			if r.Status == reminder.Pending && r.IsDue() {
+			if r.RollOver(now) {
+				changed = true
+			}
+			if r.Status.Scheduled() && r.IsDueAt(now) {
 				r.Status = reminder.Triggered
+				m.queueAlert(r)
 				changed = true
 			}
 		}
@@ -42,33 +119,77 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.refreshList()
 			m.saveState()
 		}
-		// Clear status message after 3 seconds
-		if m.statusMessage != "" && time.Since(m.statusMessageTime) > 3*time.Second {
-			m.statusMessage = ""
-		}
-		return m, tickCmd()
+		m.checkFocus(now)
+		m.checkMergePreview()
+		m.checkWatchers()
+		m.checkAlerts()
+		m.expireToasts(now)
+		m.checkCleanup(now)
+		m.checkDuplicates()
+		m.saveDraft()
+		attention := m.checkAttention()
+		return m, tea.Batch(tickCmd(), attention, m.checkDigest(now), m.checkSync(now), m.checkSources(now), m.checkOrphans(now))
 
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
 		m.help.Width = msg.Width
-		listHeight := msg.Height - 4
-		if listHeight < 5 {
-			listHeight = 5
-		}
-		m.list.SetSize(msg.Width-4, listHeight)
-		// Calculate grid columns (card width ~40 + margin)
-		m.gridColumns = (msg.Width - 4) / 40
-		if m.gridColumns < 1 {
-			m.gridColumns = 1
+		m.layoutList()
+		m.restoreSelection()
+
+	case DaemonStateMsg:
+		m.applyDaemonState(msg)
+		m.restoreSelection()
+		return m, m.waitForDaemonState()
+
+	case DaemonErrorMsg:
+		m.toastError("Daemon: " + msg.err.Error())
+		if msg.err == errDaemonDisconnected {
+			return m, nil
 		}
+		return m, m.waitForDaemonError()
+
+	case SyncDoneMsg:
+		m.applySync(msg)
+		return m, nil
+
+	case SourceDoneMsg:
+		m.applySource(msg)
+		return m, nil
+
+	case SourceAckMsg:
+		m.applySourceAck(msg)
+		return m, nil
+
+	case SaveErrorMsg:
+		m.toastError("Could not save: " + msg.err.Error())
+		return m, m.waitForSaveError()
+
+	case OrphanScanMsg:
+		m.applyOrphanScan(msg)
+		return m, nil
+
+	case ProgressMsg:
+		cmd := m.applyProgress(msg)
+		return m, tea.Batch(cmd, m.waitForProgress())
+
+	case spinner.TickMsg:
+		return m, m.updateSpinner(msg)
+
+	case ThemesChangedMsg:
+		m.applyThemes(msg)
+		return m, m.waitForThemeChange()
+
+	case InitialParseMsg:
+		m.applyInitialParse(msg)
+		m.restoreSelection()
+		return m, nil
 
 	case FileUpdateMsg:
-		m.reminders = reminder.MergeFromFile(m.reminders, msg.FilePath, msg.Reminders)
-		reminder.SortByDateTime(m.reminders)
-		m.refreshList()
-		m.saveState()
-		m.setStatusMessage(fmt.Sprintf("File updated: %d reminders", len(msg.Reminders)))
+		if !m.takeFileUpdate(msg.FilePath) || m.holdMerge(msg) {
+			return m, m.waitForFileUpdate()
+		}
+		m.applyFileUpdate(msg)
 		return m, m.waitForFileUpdate()
 	}
 
@@ -78,14 +199,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) updateNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// The letter after m or ' names a mark
+	if m.pendingMark || m.pendingGotoMark {
+		m.takeMarkKey(msg.String())
+		return m, nil
+	}
+
 	// Handle 'dd' for delete (vim-style)
-	if msg.String() == "d" {
+	if key.Matches(msg, keys.Delete) {
 		if m.pendingDelete {
 			r := m.selectedReminder()
 			if r != nil {
 				desc := r.Description
 				m.deleteCurrentReminder()
-				m.setStatusMessage("Deleted: " + desc)
+				m.toastInfo("Deleted: " + desc)
 			}
 			m.pendingDelete = false
 		} else {
@@ -97,9 +224,10 @@ func (m Model) updateNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	m.pendingDelete = false
 
 	// Handle 'gg' for go to first (vim-style)
-	if msg.String() == "g" {
+	if key.Matches(msg, keys.GotoFirst) {
 		if m.pendingG {
 			// gg - go to first item
+			m.recordJump()
 			m.gotoFirstItem()
 			m.pendingG = false
 		} else {
@@ -110,23 +238,65 @@ func (m Model) updateNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	m.pendingG = false
 
 	// Handle 'G' for go to last
-	if msg.String() == "G" {
+	if key.Matches(msg, keys.GotoLast) {
+		m.recordJump()
 		m.gotoLastItem()
 		return m, nil
 	}
 
 	// Handle '{' and '}' for section navigation
-	if msg.String() == "{" {
+	if key.Matches(msg, keys.PrevSection) {
+		m.recordJump()
 		m.gotoPrevSection()
 		return m, nil
 	}
-	if msg.String() == "}" {
+	if key.Matches(msg, keys.NextSection) {
+		m.recordJump()
 		m.gotoNextSection()
 		return m, nil
 	}
 
+	// Back and forward through the positions jumped from, as in vim
+	if key.Matches(msg, keys.JumpBack) {
+		m.jumpBack()
+		return m, nil
+	}
+	if key.Matches(msg, keys.JumpForward) {
+		m.jumpForward()
+		return m, nil
+	}
+
+	// Handle 'm' and "'" for setting and going to marks
+	if key.Matches(msg, keys.SetMark) {
+		m.pendingMark = true
+		return m, nil
+	}
+	if key.Matches(msg, keys.GotoMark) {
+		m.pendingGotoMark = true
+		return m, nil
+	}
+
+	// While a search is kept, n and N (by default) move between its matches,
+	// as in vim
+	if m.searching() {
+		switch {
+		case key.Matches(msg, keys.NextMatch):
+			m.recordJump()
+			m.jumpToMatch(1)
+			return m, nil
+		case key.Matches(msg, keys.PrevMatch):
+			m.recordJump()
+			m.jumpToMatch(-1)
+			return m, nil
+		case msg.String() == "esc":
+			m.clearSearch()
+			return m, nil
+		}
+	}
+
 	switch {
 	case key.Matches(msg, keys.Quit):
+		m.saveViewSettings()
 		return m, tea.Quit
 
 	case key.Matches(msg, keys.Theme):
@@ -135,18 +305,44 @@ func (m Model) updateNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.previewTheme = m.themeIndex
 		return m, nil
 
+	case key.Matches(msg, keys.Contrast):
+		hc := themeIndexByName(highContrastTheme)
+		m.setHighContrast(m.themeIndex != hc)
+		m.toastInfo("Theme: " + themes[m.themeIndex].Name)
+		return m, nil
+
 	case key.Matches(msg, keys.Layout):
 		currentLayout = (currentLayout + 1) % LayoutMode(len(layoutNames))
-		m.list.SetDelegate(itemDelegate{})
+		m.refreshHighlight()
 		return m, nil
 
 	case key.Matches(msg, keys.Sort):
 		m.sortEnabled = !m.sortEnabled
 		return m, nil
 
+	case key.Matches(msg, keys.SortOrder):
+		m.cycleSortOrder()
+		return m, nil
+
+	case key.Matches(msg, keys.Group):
+		m.cycleGrouping()
+		return m, nil
+
+	case key.Matches(msg, keys.LowEnergy):
+		m.toggleLowEnergy()
+		return m, nil
+
+	case key.Matches(msg, keys.Focus):
+		m.openFocus()
+		return m, nil
+
+	case key.Matches(msg, keys.Search):
+		return m, m.openSearch()
+
 	case key.Matches(msg, keys.Filter):
 		m.mode = modeFilter
 		m.filterInput.Focus()
+		resetInputHistory(&m.filterHistory)
 		return m, textinput.Blink
 
 	case key.Matches(msg, keys.Add):
@@ -155,7 +351,10 @@ func (m Model) updateNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.addInput.Focus()
 		m.inputError = ""
 		m.editingReminder = nil
-		return m, textinput.Blink
+		m.offerDraft()
+		resetInputHistory(&m.addHistory)
+		m.fitAddInput()
+		return m, textarea.Blink
 
 	case key.Matches(msg, keys.Edit):
 		r := m.selectedReminder()
@@ -164,39 +363,61 @@ func (m Model) updateNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		m.mode = modeAdd
 		m.editingReminder = r
-		// Format: yyyy-mm-dd hh:mm description
-		prefill := r.DateTime.Format("2006-01-02 15:04") + " " + r.Description
-		m.addInput.SetValue(prefill)
+		m.addInput.SetValue(editText(r))
 		m.addInput.Focus()
 		m.addInput.CursorEnd()
 		m.inputError = ""
-		return m, textinput.Blink
+		m.offerDraft()
+		resetInputHistory(&m.addHistory)
+		m.fitAddInput()
+		return m, textarea.Blink
+
+	case key.Matches(msg, keys.Compose):
+		m.draftOwned = false
+		return m, m.compose("", nil)
+
+	case key.Matches(msg, keys.Reschedule):
+		m.openReschedule(m.selectedReminder())
+		return m, nil
+
+	case key.Matches(msg, keys.Shift):
+		return m, m.openShift()
+
+	case key.Matches(msg, keys.Command):
+		return m, m.openCommand()
+
+	case key.Matches(msg, keys.Undo):
+		m.undoBulk()
+		return m, nil
+
+	case key.Matches(msg, keys.OpenLink):
+		return m, m.openLink(m.selectedReminder())
 
 	case key.Matches(msg, keys.Help):
 		m.help.ShowAll = !m.help.ShowAll
 		return m, nil
 
+	case key.Matches(msg, keys.Cheatsheet):
+		return m, m.openCheatsheet()
+
 	case key.Matches(msg, keys.Acknowledge):
 		r := m.selectedReminder()
-		if r != nil && (r.Status == reminder.Pending || r.Status == reminder.Triggered) {
-			r.Status = reminder.Acknowledged
+		if r != nil && r.Status != reminder.Acknowledged {
+			r.Acknowledge(m.now())
 			m.refreshList()
 			m.saveState()
-			m.setStatusMessage("Acknowledged: " + r.Description)
+			m.toastSuccess("Acknowledged: " + r.Description)
+			return m, m.acknowledgeAtSources(r)
 		}
 		return m, nil
 
 	case key.Matches(msg, keys.Unacknowledge):
 		r := m.selectedReminder()
 		if r != nil && r.Status == reminder.Acknowledged {
-			if r.IsDue() {
-				r.Status = reminder.Triggered
-			} else {
-				r.Status = reminder.Pending
-			}
+			r.Unacknowledge(m.now())
 			m.refreshList()
 			m.saveState()
-			m.setStatusMessage("Unacknowledged: " + r.Description)
+			m.toastInfo("Unacknowledged: " + r.Description)
 		}
 		return m, nil
 
@@ -212,12 +433,101 @@ func (m Model) updateNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.snooze(24 * time.Hour)
 		return m, nil
 
-	case msg.String() == "K":
-		r := m.selectedReminder()
-		if r != nil {
-			m.mode = modeDetail
-			m.detailReminder = r
-			m.detailScroll = 0
+	case key.Matches(msg, keys.Waiting):
+		m.togglePark(m.selectedReminder(), reminder.Waiting)
+		return m, nil
+
+	case key.Matches(msg, keys.Someday):
+		m.togglePark(m.selectedReminder(), reminder.Someday)
+		return m, nil
+
+	case key.Matches(msg, keys.Timer):
+		m.toggleTimer(m.selectedReminder())
+		return m, nil
+
+	case key.Matches(msg, keys.Effort):
+		m.cycleEffort(m.selectedReminder())
+		return m, nil
+
+	case key.Matches(msg, keys.Label):
+		m.cycleLabel(m.selectedReminder())
+		return m, nil
+
+	case key.Matches(msg, keys.Yank):
+		m.yankSelected()
+		return m, nil
+
+	case key.Matches(msg, keys.ExportView):
+		m.exportView(export.Snippet)
+		return m, nil
+
+	case key.Matches(msg, keys.Paste):
+		return m, readClipboardCmd()
+
+	case key.Matches(msg, keys.Digest):
+		m.mode = modeDigest
+		return m, nil
+
+	case key.Matches(msg, keys.Stats):
+		m.openStats()
+		return m, nil
+
+	case key.Matches(msg, keys.Activity):
+		m.openActivity()
+		return m, nil
+
+	case key.Matches(msg, keys.Split):
+		m.toggleSplit()
+		return m, nil
+
+	case key.Matches(msg, keys.Orphans):
+		m.openOrphans()
+		return m, nil
+
+	case key.Matches(msg, keys.Muted):
+		m.openMuted()
+		return m, nil
+
+	case key.Matches(msg, keys.People):
+		m.openPeople()
+		return m, nil
+
+	case key.Matches(msg, keys.Triggered):
+		m.toggleTriggered()
+		return m, nil
+
+	case key.Matches(msg, keys.MergeLog):
+		m.openMergeLog()
+		return m, nil
+
+	case key.Matches(msg, keys.WatcherHealth):
+		m.openWatcherHealth()
+		return m, nil
+
+	case key.Matches(msg, keys.Timeline):
+		m.openTimeline()
+		return m, nil
+
+	case key.Matches(msg, keys.Workspaces):
+		m.openWorkspaces()
+		return m, nil
+
+	case key.Matches(msg, keys.Duplicates):
+		m.openDuplicates()
+		return m, nil
+
+	case key.Matches(msg, keys.Sources):
+		m.openSources()
+		return m, nil
+
+	case key.Matches(msg, keys.Profiles):
+		m.openProfiles()
+		return m, nil
+
+	case key.Matches(msg, keys.Detail):
+		if r := m.selectedReminder(); r != nil {
+			m.recordJump()
+			m.openDetail(r)
 		}
 		return m, nil
 	}
@@ -244,13 +554,13 @@ func (m Model) updateNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 			m.scrollToSelection()
 			return m, nil
-		case msg.String() == "h" || msg.String() == "left":
+		case key.Matches(msg, keys.Left):
 			if m.gridIndex > 0 {
 				m.gridIndex--
 			}
 			m.scrollToSelection()
 			return m, nil
-		case msg.String() == "l" || msg.String() == "right":
+		case key.Matches(msg, keys.Right):
 			if m.gridIndex < maxIdx {
 				m.gridIndex++
 			}
@@ -288,6 +598,11 @@ func (m Model) updateNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) updateFilterMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if updateInputHistory(&m.filterHistory, &m.filterInput, msg) {
+		m.refreshList()
+		return m, nil
+	}
+
 	switch msg.Type {
 	case tea.KeyEscape:
 		m.mode = modeNormal
@@ -298,29 +613,86 @@ func (m Model) updateFilterMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case tea.KeyEnter:
 		m.mode = modeNormal
 		m.filterInput.Blur()
+		m.rememberInput(&m.filterHistory, m.filterInput.Value())
 		// Keep the filter applied
 		return m, nil
 	}
 
+	// Before any text is typed, number keys pick a date range
+	if m.filterInput.Value() == "" && len(msg.Runes) == 1 {
+		if i := int(msg.Runes[0] - '0'); i >= 0 && i < len(dateRangeNames) {
+			m.setDateRange(dateRange(i))
+			return m, nil
+		}
+	}
+
 	var cmd tea.Cmd
 	m.filterInput, cmd = m.filterInput.Update(msg)
 	m.refreshList()
 	return m, cmd
 }
 
+// closeAdd closes the add or edit box, emptying it
+func (m *Model) closeAdd() {
+	m.mode = modeNormal
+	m.addInput.Blur()
+	m.addInput.Reset()
+	m.fitAddInput()
+	m.inputError = ""
+	m.editingReminder = nil
+	m.conflictWarned = ""
+}
+
 func (m Model) updateAddMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.draftPrompt {
+		return m.updateDraftPrompt(msg)
+	}
+	// Up and down move between the box's lines, and past them through
+	// its history
+	onEdge := (msg.Type != tea.KeyUp || m.addInput.Line() == 0) &&
+		(msg.Type != tea.KeyDown || m.addInput.Line() == m.addInput.LineCount()-1)
+	if onEdge && updateInputHistory(&m.addHistory, &m.addInput, msg) {
+		m.fitAddInput()
+		return m, nil
+	}
+
 	switch msg.Type {
 	case tea.KeyEscape:
-		m.mode = modeNormal
-		m.addInput.Blur()
-		m.addInput.Reset()
-		m.inputError = ""
-		m.editingReminder = nil
+		m.discardDraft()
+		m.closeAdd()
+		return m, nil
+	case tea.KeyTab:
+		// Pick the date instead of typing it
+		if r := m.editingReminder; r != nil {
+			m.discardDraft()
+			m.closeAdd()
+			m.openReschedule(r)
+		}
 		return m, nil
+	case tea.KeyCtrlX:
+		// Write it in $EDITOR instead
+		return m, m.composeFromAdd()
+	case tea.KeyCtrlF:
+		// Find a free time instead of typing one
+		m.conflictWarned = ""
+		return m, m.openSlots()
 	case tea.KeyEnter:
+		if msg.Alt {
+			break // A new line
+		}
+		// Warn once about double-booking; enter again saves
+		input := m.addInput.Value()
+		if warning := m.conflictWarning(input); warning != "" && m.conflictWarned != input {
+			m.inputError = warning
+			m.conflictWarned = input
+			return m, nil
+		}
 		var err error
-		if m.editingReminder != nil {
-			err = m.updateReminder(m.editingReminder, m.addInput.Value())
+		if r := m.editingReminder; r != nil {
+			before := *r
+			if err = m.updateReminder(r, m.addInput.Value()); err == nil {
+				m.writeBack(&before, r)
+			}
 		} else {
 			err = m.addReminder(m.addInput.Value())
 		}
@@ -328,16 +700,15 @@ func (m Model) updateAddMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.inputError = err.Error()
 			return m, nil
 		}
-		m.mode = modeNormal
-		m.addInput.Blur()
-		m.addInput.Reset()
-		m.inputError = ""
-		m.editingReminder = nil
+		m.rememberInput(&m.addHistory, input)
+		m.discardDraft()
+		m.closeAdd()
 		return m, nil
 	}
 
 	var cmd tea.Cmd
 	m.addInput, cmd = m.addInput.Update(msg)
+	m.fitAddInput()
 	return m, cmd
 }
 
@@ -368,13 +739,13 @@ func (m Model) updateThemeMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	switch msg.String() {
-	case "k":
+	switch {
+	case key.Matches(msg, keys.Up):
 		if m.previewTheme > 0 {
 			m.previewTheme--
 			themes[m.previewTheme].applyStyles()
 		}
-	case "j":
+	case key.Matches(msg, keys.Down):
 		if m.previewTheme < len(themes)-1 {
 			m.previewTheme++
 			themes[m.previewTheme].applyStyles()
@@ -385,12 +756,12 @@ func (m Model) updateThemeMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 func (m Model) updateDetailMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Handle 'dd' for delete
-	if msg.String() == "d" {
+	if key.Matches(msg, keys.Delete) {
 		if m.pendingDelete {
 			if m.detailReminder != nil {
 				desc := m.detailReminder.Description
-				m.deleteCurrentReminder()
-				m.setStatusMessage("Deleted: " + desc)
+				m.deleteReminder(m.detailReminder)
+				m.toastInfo("Deleted: " + desc)
 			}
 			m.pendingDelete = false
 			m.mode = modeNormal
@@ -403,6 +774,20 @@ func (m Model) updateDetailMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	}
 	m.pendingDelete = false
 
+	// Enter on the Related tab opens the selected reminder
+	if msg.Type == tea.KeyEnter && m.detailTab == detailTabRelated {
+		if rs := m.related(m.detailReminder); m.detailScroll < len(rs) {
+			m.recordJump()
+			m.openDetail(rs[m.detailScroll])
+		}
+		return m, nil
+	}
+
+	// Enter on the Links tab opens the selected link
+	if msg.Type == tea.KeyEnter && m.detailTab == detailTabLinks {
+		return m, m.openSelectedLink()
+	}
+
 	switch msg.Type {
 	case tea.KeyEscape:
 		m.mode = modeNormal
@@ -415,75 +800,109 @@ func (m Model) updateDetailMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 	case tea.KeyDown:
-		m.detailScroll++
+		m.scrollDetailDown()
 		return m, nil
-	case tea.KeyEnter, tea.KeySpace:
-		if m.detailReminder != nil && (m.detailReminder.Status == reminder.Pending || m.detailReminder.Status == reminder.Triggered) {
-			m.detailReminder.Status = reminder.Acknowledged
-			m.refreshList()
-			m.saveState()
-			m.setStatusMessage("Acknowledged: " + m.detailReminder.Description)
-		}
+	case tea.KeyTab:
+		m.switchDetailTab(1)
+		return m, nil
+	case tea.KeyShiftTab:
+		m.switchDetailTab(-1)
 		return m, nil
 	}
 
-	switch msg.String() {
-	case "k":
+	switch {
+	case key.Matches(msg, keys.Cheatsheet):
+		return m, m.openCheatsheet()
+	case key.Matches(msg, keys.JumpBack):
+		m.jumpBack()
+	case key.Matches(msg, keys.JumpForward):
+		m.jumpForward()
+	case key.Matches(msg, keys.Detail):
+		// Back to the list, or focus it in the split pane
+		m.mode = modeNormal
+		m.detailReminder = nil
+		m.detailScroll = 0
+	case key.Matches(msg, keys.Acknowledge):
+		if m.detailReminder != nil && m.detailReminder.Status != reminder.Acknowledged {
+			m.detailReminder.Acknowledge(m.now())
+			m.refreshList()
+			m.saveState()
+			m.toastSuccess("Acknowledged: " + m.detailReminder.Description)
+			return m, m.acknowledgeAtSources(m.detailReminder)
+		}
+	case key.Matches(msg, keys.Up):
 		if m.detailScroll > 0 {
 			m.detailScroll--
 		}
-	case "j":
-		m.detailScroll++
-	case "u":
+	case key.Matches(msg, keys.Down):
+		m.scrollDetailDown()
+	case key.Matches(msg, keys.Unacknowledge):
 		if m.detailReminder != nil && m.detailReminder.Status == reminder.Acknowledged {
-			if m.detailReminder.IsDue() {
-				m.detailReminder.Status = reminder.Triggered
-			} else {
-				m.detailReminder.Status = reminder.Pending
-			}
+			m.detailReminder.Unacknowledge(m.now())
 			m.refreshList()
 			m.saveState()
-			m.setStatusMessage("Unacknowledged: " + m.detailReminder.Description)
+			m.toastInfo("Unacknowledged: " + m.detailReminder.Description)
 		}
-	case "1":
+	case key.Matches(msg, keys.Snooze5m):
 		if m.detailReminder != nil && m.detailReminder.Snoozeable() {
-			m.detailReminder.DateTime = m.detailReminder.DateTime.Add(5 * time.Minute)
-			m.detailReminder.Status = reminder.Pending
+			m.detailReminder.Snooze(5*time.Minute, m.now())
 			reminder.SortByDateTime(m.reminders)
 			m.refreshList()
 			m.saveState()
-			m.setStatusMessage("Snoozed 5 minutes: " + m.detailReminder.Description)
+			m.toastInfo("Snoozed 5 minutes: " + m.detailReminder.Description)
 		}
-	case "2":
+	case key.Matches(msg, keys.Snooze1h):
 		if m.detailReminder != nil && m.detailReminder.Snoozeable() {
-			m.detailReminder.DateTime = m.detailReminder.DateTime.Add(1 * time.Hour)
-			m.detailReminder.Status = reminder.Pending
+			m.detailReminder.Snooze(1*time.Hour, m.now())
 			reminder.SortByDateTime(m.reminders)
 			m.refreshList()
 			m.saveState()
-			m.setStatusMessage("Snoozed 1 hour: " + m.detailReminder.Description)
+			m.toastInfo("Snoozed 1 hour: " + m.detailReminder.Description)
 		}
-	case "3":
+	case key.Matches(msg, keys.Snooze1d):
 		if m.detailReminder != nil && m.detailReminder.Snoozeable() {
-			m.detailReminder.DateTime = m.detailReminder.DateTime.Add(24 * time.Hour)
-			m.detailReminder.Status = reminder.Pending
+			m.detailReminder.Snooze(24*time.Hour, m.now())
 			reminder.SortByDateTime(m.reminders)
 			m.refreshList()
 			m.saveState()
-			m.setStatusMessage("Snoozed 1 day: " + m.detailReminder.Description)
+			m.toastInfo("Snoozed 1 day: " + m.detailReminder.Description)
+		}
+	case key.Matches(msg, keys.Waiting):
+		m.togglePark(m.detailReminder, reminder.Waiting)
+	case key.Matches(msg, keys.Someday):
+		m.togglePark(m.detailReminder, reminder.Someday)
+	case key.Matches(msg, keys.Timer):
+		m.toggleTimer(m.detailReminder)
+	case key.Matches(msg, keys.Effort):
+		m.cycleEffort(m.detailReminder)
+	case key.Matches(msg, keys.Label):
+		m.cycleLabel(m.detailReminder)
+	case key.Matches(msg, keys.Reschedule):
+		m.openReschedule(m.detailReminder)
+	case key.Matches(msg, keys.OpenLink):
+		if m.detailTab == detailTabLinks {
+			return m, m.openSelectedLink()
 		}
-	case "e":
+		return m, m.openLink(m.detailReminder)
+	case key.Matches(msg, keys.Edit):
 		if m.detailReminder != nil {
 			m.mode = modeAdd
 			m.editingReminder = m.detailReminder
-			prefill := m.detailReminder.DateTime.Format("2006-01-02 15:04") + " " + m.detailReminder.Description
-			m.addInput.SetValue(prefill)
+			m.addInput.SetValue(editText(m.detailReminder))
 			m.addInput.Focus()
 			m.addInput.CursorEnd()
 			m.inputError = ""
+			m.offerDraft()
+			resetInputHistory(&m.addHistory)
+			m.fitAddInput()
 			m.detailReminder = nil
 			m.detailScroll = 0
-			return m, textinput.Blink
+			return m, textarea.Blink
+		}
+	case key.Matches(msg, keys.Compose):
+		if r := m.detailReminder; r != nil {
+			m.draftOwned = false
+			return m, m.compose(editText(r), r)
 		}
 	}
 	return m, nil
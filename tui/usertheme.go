@@ -0,0 +1,257 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/fsnotify/fsnotify"
+)
+
+// builtinThemeCount is the number of themes that ship with go_remind; user
+// themes are appended after them
+var builtinThemeCount = len(themes)
+
+// themeFile is the on-disk format of a user theme. The top-level colors are
+// used on dark backgrounds; an optional [light] table overrides them on
+// light backgrounds.
+type themeFile struct {
+	Name string `toml:"name"`
+	themeColors
+	Light themeColors `toml:"light"`
+}
+
+// themeColors holds the eight color slots of a theme file
+type themeColors struct {
+	Title        string `toml:"title"`
+	Normal       string `toml:"normal"`
+	Triggered    string `toml:"triggered"`
+	Acknowledged string `toml:"acknowledged"`
+	Source       string `toml:"source"`
+	Selected     string `toml:"selected"`
+	Accent       string `toml:"accent"`
+	Muted        string `toml:"muted"`
+}
+
+// Pattern matches #RGB and #RRGGBB hex colors
+var hexColorPattern = regexp.MustCompile(`^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// ThemesChangedMsg is sent when the user themes directory changes on disk
+type ThemesChangedMsg struct {
+	Themes []Theme
+	Err    error
+}
+
+// LoadThemes reads every *.toml theme in dir. Valid themes are returned even
+// when others fail; the failures are joined into the error. A missing
+// directory is not an error.
+func LoadThemes(dir string) ([]Theme, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.toml"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	var loaded []Theme
+	var errs []error
+	seen := make(map[string]bool)
+	for _, path := range paths {
+		t, err := loadThemeFile(path)
+		if err == nil && seen[t.Name] {
+			err = fmt.Errorf("duplicate theme name %q", t.Name)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", filepath.Base(path), err))
+			continue
+		}
+		seen[t.Name] = true
+		loaded = append(loaded, t)
+	}
+	return loaded, errors.Join(errs...)
+}
+
+// loadThemeFile parses and validates a single theme file
+func loadThemeFile(path string) (Theme, error) {
+	var f themeFile
+	if _, err := toml.DecodeFile(path, &f); err != nil {
+		return Theme{}, err
+	}
+	if f.Name == "" {
+		return Theme{}, errors.New("missing name")
+	}
+
+	if err := f.themeColors.validate(false); err != nil {
+		return Theme{}, err
+	}
+	if err := f.Light.validate(true); err != nil {
+		return Theme{}, fmt.Errorf("light.%w", err)
+	}
+
+	adaptive := func(dark, light string) lipgloss.AdaptiveColor {
+		if light == "" {
+			light = dark
+		}
+		return lipgloss.AdaptiveColor{Light: light, Dark: dark}
+	}
+	return Theme{
+		Name:         f.Name,
+		Title:        adaptive(f.Title, f.Light.Title),
+		Normal:       adaptive(f.Normal, f.Light.Normal),
+		Triggered:    adaptive(f.Triggered, f.Light.Triggered),
+		Acknowledged: adaptive(f.Acknowledged, f.Light.Acknowledged),
+		Source:       adaptive(f.Source, f.Light.Source),
+		Selected:     adaptive(f.Selected, f.Light.Selected),
+		Accent:       adaptive(f.Accent, f.Light.Accent),
+		Muted:        adaptive(f.Muted, f.Light.Muted),
+	}, nil
+}
+
+// validate checks every color slot. Empty slots are allowed when optional.
+func (c themeColors) validate(optional bool) error {
+	slots := []struct {
+		key   string
+		value string
+	}{
+		{"title", c.Title},
+		{"normal", c.Normal},
+		{"triggered", c.Triggered},
+		{"acknowledged", c.Acknowledged},
+		{"source", c.Source},
+		{"selected", c.Selected},
+		{"accent", c.Accent},
+		{"muted", c.Muted},
+	}
+	for _, slot := range slots {
+		if optional && slot.value == "" {
+			continue
+		}
+		if err := validateColor(slot.value); err != nil {
+			return fmt.Errorf("%s: %w", slot.key, err)
+		}
+	}
+	return nil
+}
+
+// validateColor accepts hex colors (#RGB, #RRGGBB) and ANSI codes 0-255
+func validateColor(s string) error {
+	if s == "" {
+		return errors.New("missing color")
+	}
+	if hexColorPattern.MatchString(s) {
+		return nil
+	}
+	if n, err := strconv.Atoi(s); err == nil && n >= 0 && n <= 255 {
+		return nil
+	}
+	return fmt.Errorf("invalid color %q (want #RRGGBB or 0-255)", s)
+}
+
+// setUserThemes replaces the user themes after the built-in ones
+func setUserThemes(user []Theme) {
+	themes = append(themes[:builtinThemeCount:builtinThemeCount], user...)
+}
+
+// WithThemes returns a copy of the model with the user themes in dir loaded
+// into the theme picker. The directory is watched so edits apply live.
+func (m Model) WithThemes(dir string) Model {
+	if dir == "" {
+		return m
+	}
+	user, err := LoadThemes(dir)
+	setUserThemes(user)
+	if err != nil {
+		m.toastError("Themes: " + err.Error())
+	}
+	m.useConfigTheme() // It may be one of the user's themes
+
+	if events, err := watchThemes(dir); err == nil {
+		m.themeEvents = events
+	}
+	return m
+}
+
+// watchThemes reloads the themes in dir whenever a file in it changes
+func watchThemes(dir string) (<-chan ThemesChangedMsg, error) {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := w.Add(dir); err != nil {
+		w.Close()
+		return nil, err
+	}
+
+	events := make(chan ThemesChangedMsg, 1)
+	go func() {
+		defer w.Close()
+		var reload <-chan time.Time
+		for {
+			select {
+			case event, ok := <-w.Events:
+				if !ok {
+					return
+				}
+				if filepath.Ext(event.Name) == ".toml" {
+					// Editors often write a file in several steps; wait for quiet
+					reload = time.After(100 * time.Millisecond)
+				}
+			case _, ok := <-w.Errors:
+				if !ok {
+					return
+				}
+			case <-reload:
+				reload = nil
+				loaded, err := LoadThemes(dir)
+				events <- ThemesChangedMsg{Themes: loaded, Err: err}
+			}
+		}
+	}()
+	return events, nil
+}
+
+// waitForThemeChange waits for the themes directory to change
+func (m Model) waitForThemeChange() tea.Cmd {
+	return func() tea.Msg {
+		return <-m.themeEvents
+	}
+}
+
+// applyThemes swaps in reloaded user themes, keeping the current theme
+// selected by name and re-applying it in case its colors changed
+func (m *Model) applyThemes(msg ThemesChangedMsg) {
+	current := themes[m.themeIndex].Name
+	preview := themes[m.previewTheme].Name
+
+	setUserThemes(msg.Themes)
+	m.themeIndex = themeIndexByName(current)
+	m.previewTheme = themeIndexByName(preview)
+
+	if m.mode == modeTheme {
+		themes[m.previewTheme].applyStyles()
+	} else {
+		themes[m.themeIndex].applyStyles()
+	}
+
+	if msg.Err != nil {
+		m.toastError("Themes: " + msg.Err.Error())
+	} else {
+		m.toastInfo("Themes reloaded")
+	}
+}
+
+// themeIndexByName finds a theme by name, falling back to the first theme
+func themeIndexByName(name string) int {
+	for i, t := range themes {
+		if t.Name == name {
+			return i
+		}
+	}
+	return 0
+}
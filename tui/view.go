@@ -3,11 +3,11 @@ package tui
 import (
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/charmbracelet/lipgloss"
 
-	"go_remind/reminder"
+	"go_remind/pkg/clock"
+	"go_remind/pkg/reminder"
 )
 
 // welcomeView renders the welcome screen for standalone mode
@@ -51,39 +51,7 @@ func (m Model) compactViewContent() string {
 	}
 
 	// Sort into sections
-	now := time.Now()
-	todayEnd := time.Date(now.Year(), now.Month(), now.Day(), 23, 59, 59, 0, now.Location())
-	tomorrowEnd := todayEnd.Add(24 * time.Hour)
-
-	// Calculate week boundaries (week starts on Sunday)
-	daysUntilEndOfWeek := (7 - int(now.Weekday())) % 7
-	thisWeekEnd := time.Date(now.Year(), now.Month(), now.Day()+daysUntilEndOfWeek, 23, 59, 59, 0, now.Location())
-	nextWeekEnd := thisWeekEnd.Add(7 * 24 * time.Hour)
-
-	// Calculate month boundaries
-	thisMonthEnd := time.Date(now.Year(), now.Month()+1, 0, 23, 59, 59, 0, now.Location())
-	nextMonthEnd := time.Date(now.Year(), now.Month()+2, 0, 23, 59, 59, 0, now.Location())
-
-	var due, comingUp, tomorrow, laterThisWeek, nextWeek, laterThisMonth, beyondNextMonth []*reminder.Reminder
-	for _, r := range items {
-		if r.DateTime.Before(now) {
-			due = append(due, r)
-		} else if r.DateTime.Before(todayEnd) {
-			comingUp = append(comingUp, r)
-		} else if r.DateTime.Before(tomorrowEnd) {
-			tomorrow = append(tomorrow, r)
-		} else if r.DateTime.Before(thisWeekEnd) {
-			laterThisWeek = append(laterThisWeek, r)
-		} else if r.DateTime.Before(nextWeekEnd) {
-			nextWeek = append(nextWeek, r)
-		} else if r.DateTime.Before(thisMonthEnd) {
-			laterThisMonth = append(laterThisMonth, r)
-		} else if r.DateTime.Before(nextMonthEnd) {
-			beyondNextMonth = append(beyondNextMonth, r)
-		} else {
-			beyondNextMonth = append(beyondNextMonth, r)
-		}
-	}
+	groups, titles := m.groupSections(items, m.now())
 
 	sectionStyle := lipgloss.NewStyle().
 		Foreground(titleStyle.GetForeground()).
@@ -100,11 +68,11 @@ func (m Model) compactViewContent() string {
 		endItem = totalItems
 	}
 
-	var output []string
+	output := []string{m.rollupView(groups, titles)}
 
 	// Scroll up indicator
 	if m.compactScroll > 0 {
-		output = append(output, sourceStyle.Render(fmt.Sprintf("  ↑ %d more items above", m.compactScroll)))
+		output = append(output, sourceStyle.Render(fmt.Sprintf("  %s %d more items above", glyphs.Up, m.compactScroll)))
 	}
 
 	// Render only items in visible range, with section headers
@@ -114,24 +82,20 @@ func (m Model) compactViewContent() string {
 			sectionStart := itemIdx
 			sectionEnd := itemIdx + len(items)
 			if sectionEnd > startItem && sectionStart < endItem {
-				output = append(output, sectionStyle.Render(title))
+				output = append(output, sectionHeader(sectionStyle, title, items))
 				output = append(output, m.renderCompactLinesInRange(items, sectionStart, startItem, endItem)...)
 			}
 			itemIdx = sectionEnd
 		}
 	}
 
-	addSection(due, "Due")
-	addSection(comingUp, "Coming Up!")
-	addSection(tomorrow, "Tomorrow")
-	addSection(laterThisWeek, "Later This Week")
-	addSection(nextWeek, "Next Week")
-	addSection(laterThisMonth, "Later This Month")
-	addSection(beyondNextMonth, "Next Month & Beyond")
+	for i, group := range groups {
+		addSection(group, titles[i])
+	}
 
 	// Scroll down indicator
 	if endItem < totalItems {
-		output = append(output, sourceStyle.Render(fmt.Sprintf("  ↓ %d more items below", totalItems-endItem)))
+		output = append(output, sourceStyle.Render(fmt.Sprintf("  %s %d more items below", glyphs.Down, totalItems-endItem)))
 	}
 
 	if len(output) == 0 {
@@ -146,6 +110,7 @@ func (m Model) compactViewContent() string {
 // startItem/endItem define the visible range
 func (m Model) renderCompactLinesInRange(items []*reminder.Reminder, sectionStart, startItem, endItem int) []string {
 	var lines []string
+	query := m.highlightQuery()
 
 	for i, r := range items {
 		globalIdx := sectionStart + i
@@ -155,47 +120,32 @@ func (m Model) renderCompactLinesInRange(items []*reminder.Reminder, sectionStar
 			continue
 		}
 
-		timeStr := r.DateTime.Format("Jan 2 3:04pm")
-
-		var statusIcon string
-		var style lipgloss.Style
-
-		switch r.Status {
-		case reminder.Triggered:
-			statusIcon = "🔔"
-			style = triggeredStyle
-		case reminder.Acknowledged:
-			statusIcon = "✓"
-			style = acknowledgedStyle
-		default:
-			statusIcon = "○"
-			style = normalStyle
-		}
+		statusIcon := statusGlyph(r.Status)
+		style := statusStyle(r.Status)
 
 		// Highlight selected item
 		if globalIdx == m.compactIndex {
-			statusIcon = "▸"
+			statusIcon = glyphs.Cursor
 			if r.Status != reminder.Triggered && r.Status != reminder.Acknowledged {
 				style = selectedItemStyle
 			}
 		}
 
-		line := fmt.Sprintf("%s %-18s %-12s %s", statusIcon, timeStr, r.Status.String(), r.Description)
-		lines = append(lines, style.Render(line))
+		lines = append(lines, renderCompactLine(statusIcon, r, m.width-4, m.now(), query, style))
 	}
 	return lines
 }
 
 func (m Model) themePickerView() string {
 	var b strings.Builder
-	b.WriteString(inputLabelStyle.Render("🎨 Select Theme"))
-	b.WriteString(inputHintStyle.Render("  (↑/k ↓/j to preview, enter to select, esc to cancel)"))
+	b.WriteString(inputLabelStyle.Render(glyphs.Theme + " Select Theme"))
+	b.WriteString(inputHintStyle.Render("  (" + keys.Up.Help().Key + " " + keys.Down.Help().Key + " to preview, enter to select, esc to cancel)"))
 	b.WriteString("\n\n")
 
 	for i, t := range themes {
 		cursor := "  "
 		if i == m.previewTheme {
-			cursor = "▸ "
+			cursor = glyphs.Cursor + " "
 		}
 		name := t.Name
 		if i == m.previewTheme {
@@ -208,16 +158,19 @@ func (m Model) themePickerView() string {
 	return b.String()
 }
 
-// View renders the UI
+// View renders the UI with any toasts on top. The clock is stopped for the
+// render, so every part of the view agrees on the time.
 func (m Model) View() string {
-	var b strings.Builder
+	m.clock = clock.Fixed(m.now())
+	return m.withToasts(m.withAlert(m.mainView()))
+}
 
-	// Show welcome screen if no reminders and in standalone mode
-	if len(m.reminders) == 0 && m.watcherEvents == nil && m.mode == modeNormal {
-		b.WriteString(m.welcomeView())
-		b.WriteString("\n\n")
-		b.WriteString(m.help.View(m.keys))
-		return appStyle.Render(b.String())
+// listContent renders the reminders in the current layout
+func (m Model) listContent() string {
+	var b strings.Builder
+	if banner := m.triggeredBanner(); banner != "" {
+		b.WriteString(banner)
+		b.WriteString("\n")
 	}
 
 	// Use grid view for card layout, list view for compact
@@ -237,19 +190,121 @@ func (m Model) View() string {
 		// Unsorted compact uses built-in list scrolling
 		b.WriteString(m.list.View())
 	}
+	return b.String()
+}
+
+// mainView renders the UI for the current mode
+func (m Model) mainView() string {
+	var b strings.Builder
+
+	// Show welcome screen if no reminders and in standalone mode
+	if len(m.reminders) == 0 && m.watcherEvents == nil && m.mode == modeNormal {
+		b.WriteString(m.welcomeView())
+		b.WriteString("\n\n")
+		b.WriteString(m.help.View(m.keys))
+		return appStyle.Render(b.String())
+	}
+
+	if m.splitActive() {
+		b.WriteString(m.splitView())
+	} else {
+		b.WriteString(m.listContent())
+	}
 
 	// Show input boxes based on mode
 	switch m.mode {
 	case modeDetail:
-		return appStyle.Render(m.detailView())
+		if !m.splitActive() {
+			return appStyle.Render(m.detailView())
+		}
+		b.WriteString("\n")
+		b.WriteString(m.statusBarView())
+		b.WriteString("\n")
+		b.WriteString(m.help.View(m.keys))
+
+	case modeDigest:
+		return appStyle.Render(m.digestView())
+
+	case modePaste:
+		return appStyle.Render(m.pasteView())
+
+	case modeCheatsheet:
+		return appStyle.Render(m.cheatsheetView())
+
+	case modeStats:
+		return appStyle.Render(m.statsView())
+
+	case modeFocus:
+		return m.focusView()
+
+	case modeActivity:
+		return appStyle.Render(m.activityView())
+
+	case modeOrphans:
+		return appStyle.Render(m.orphansView())
+
+	case modeMuted:
+		return appStyle.Render(m.mutedView())
+
+	case modePeople:
+		return appStyle.Render(m.peopleView())
+
+	case modeAway:
+		return appStyle.Render(m.awayView())
+
+	case modeMergePreview:
+		return appStyle.Render(m.mergePreviewView())
+
+	case modeMergeLog:
+		return appStyle.Render(m.mergeLogView())
+
+	case modeWatcherHealth:
+		return appStyle.Render(m.watcherHealthView())
+
+	case modeTimeline:
+		return appStyle.Render(m.timelineView())
+
+	case modeDuplicates:
+		return appStyle.Render(m.duplicatesView())
+
+	case modeSources:
+		return appStyle.Render(m.sourcesView())
+
+	case modeReschedule:
+		return appStyle.Render(m.rescheduleView())
+
+	case modeShift:
+		return appStyle.Render(m.shiftView())
+
+	case modeCommand:
+		if m.command != nil {
+			return appStyle.Render(m.commandView())
+		}
+		b.WriteString("\n")
+		b.WriteString(m.commandView())
+
+	case modeSlots:
+		b.WriteString("\n")
+		b.WriteString(m.slotsView())
+
+	case modeSearch:
+		b.WriteString("\n")
+		b.WriteString(m.searchView())
 
 	case modeFilter:
-		label := inputLabelStyle.Render("🔍 Filter: ")
+		label := inputLabelStyle.Render(glyphs.Search + " Filter: ")
 		input := m.filterInput.View()
 		hint := inputHintStyle.Render("  (enter to apply, esc to cancel)")
 		box := inputBoxStyle.Render(label + input + hint)
 		b.WriteString("\n")
 		b.WriteString(box)
+		if m.filterHistory.searching {
+			b.WriteString("\n")
+			b.WriteString(m.filterHistory.historySearchView())
+		} else if m.filterInput.Value() == "" {
+			b.WriteString("\n")
+			b.WriteString(inputHintStyle.Render("  " + dateRangeHint()))
+		}
 
 		// Show matching tags when typing a tag filter
 		filterText := m.filterInput.Value()
@@ -277,47 +332,80 @@ func (m Model) View() string {
 			}
 		}
 
+		// And the people mentioned when typing a mention filter
+		if name, ok := strings.CutPrefix(filterText, "@"); ok {
+			var names []string
+			for _, p := range m.people() {
+				if strings.HasPrefix(strings.ToLower(p.mention), strings.ToLower(name)) {
+					names = append(names, "@"+p.mention)
+				}
+			}
+			if len(names) > 0 {
+				b.WriteString("\n")
+				b.WriteString(inputHintStyle.Render("  People: ") + tagStyle.Render(strings.Join(names, "  ")))
+			}
+		}
+
 	case modeAdd:
 		var label string
 		if m.editingReminder != nil {
-			label = inputLabelStyle.Render("✏️  Edit Reminder: ")
+			label = inputLabelStyle.Render(glyphs.Edit + " Edit Reminder: ")
 		} else {
-			label = inputLabelStyle.Render("➕ New Reminder: ")
+			label = inputLabelStyle.Render(glyphs.Add + " New Reminder: ")
 		}
 		input := m.addInput.View()
-		box := inputBoxStyle.Render(label + input)
+		box := inputBoxStyle.Render(lipgloss.JoinHorizontal(lipgloss.Top, label, input))
 		b.WriteString("\n")
 		b.WriteString(box)
 
-		hint := inputHintStyle.Render("  Format: <time> <description>  •  Examples: +1h Call mom  |  2025-01-15 14:30 Meeting")
+		hint := inputHintStyle.Render("  Format: <time> <description>  " + glyphs.Bullet + "  Examples: +1h Call mom  |  2025-01-15 14:30 Meeting")
 		b.WriteString("\n")
 		b.WriteString(hint)
+		b.WriteString("\n")
+		b.WriteString(inputHintStyle.Render("  alt+enter or ctrl+j for a new line; the lines below the first are notes"))
+		b.WriteString("\n")
+		if m.editingReminder != nil {
+			b.WriteString(inputHintStyle.Render("  tab to pick the date on a calendar instead " + glyphs.Bullet + " ctrl+f to find a free slot " + glyphs.Bullet + " ctrl+x to write it in $EDITOR"))
+		} else {
+			b.WriteString(inputHintStyle.Render("  ctrl+f to find a free slot " + glyphs.Bullet + " up or ctrl+r for earlier entries " + glyphs.Bullet + " ctrl+x to write it in $EDITOR"))
+		}
+		if warning := m.duplicateWarning(m.addInput.Value()); warning != "" {
+			b.WriteString("\n")
+			b.WriteString(waitingStyle.Render("  " + glyphs.Warning + " " + warning))
+		}
+		if m.draftPrompt {
+			b.WriteString("\n\n")
+			b.WriteString(m.draftPromptView())
+		} else if m.addHistory.searching {
+			b.WriteString("\n\n")
+			b.WriteString(m.addHistory.historySearchView())
+		}
 
 		if m.inputError != "" {
-			errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+			errStyle := lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "160", Dark: "196"})
 			b.WriteString("\n")
-			b.WriteString(errStyle.Render("  ⚠ " + m.inputError))
+			b.WriteString(errStyle.Render("  " + glyphs.Warning + " " + m.inputError))
 		}
 
 	case modeTheme:
 		b.WriteString("\n")
 		b.WriteString(m.themePickerView())
 
-	default:
-		// Show filter indicator if filter is active
-		if m.filterInput.Value() != "" {
-			filterIndicator := inputLabelStyle.Render(fmt.Sprintf("🔍 Filtered: %q", m.filterInput.Value()))
-			clearHint := inputHintStyle.Render("  (/ to modify, esc in filter to clear)")
-			b.WriteString("\n")
-			b.WriteString(filterIndicator + clearHint)
-		}
+	case modeProfiles:
+		b.WriteString("\n")
+		b.WriteString(m.profilesView())
 
-		// Show status message if present
-		if m.statusMessage != "" {
+	case modeWorkspaces:
+		b.WriteString("\n")
+		b.WriteString(m.workspacesView())
+
+	default:
+		if overflow := m.overflowView(); overflow != "" {
 			b.WriteString("\n")
-			b.WriteString(inputLabelStyle.Render(m.statusMessage))
+			b.WriteString(overflow)
 		}
-
+		b.WriteString("\n")
+		b.WriteString(m.statusBarView())
 		b.WriteString("\n")
 		b.WriteString(m.help.View(m.keys))
 	}
@@ -0,0 +1,189 @@
+package tui
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"go_remind/pkg/watcher"
+)
+
+// watcherHealthRows is how many recent changes and failures the watcher
+// health panel lists for each watched path
+const watcherHealthRows = 5
+
+// watchLimitHint says how to raise Linux's limit on file watches
+const watchLimitHint = "sudo sysctl fs.inotify.max_user_watches=524288"
+
+// WithWatcherHealth returns a copy of the model whose watcher health panel
+// reads the file watchers' health, by watched path, from health
+func (m Model) WithWatcherHealth(health func() map[string]watcher.Health) Model {
+	m.watcherHealth = health
+	return m
+}
+
+// checkWatchers warns when the file watchers have had new problems, which
+// would otherwise go unseen behind the TUI
+func (m *Model) checkWatchers() {
+	if m.watcherHealth == nil {
+		return
+	}
+	problems := 0
+	limited := false
+	for _, h := range m.watcherHealth() {
+		problems += h.Overflows + len(h.Failures)
+		limited = limited || h.Limited
+	}
+	if limited && !m.watchLimitWarned {
+		m.watchLimitWarned = true
+		m.toastError("Too many files to watch; some folders are polled instead (" + keys.WatcherHealth.Help().Key + " for details)")
+	}
+	if problems > m.watcherProblems {
+		m.toastError(fmt.Sprintf("File watching had %s; press %s for details",
+			counted(problems-m.watcherProblems, "new problem", "new problems"), keys.WatcherHealth.Help().Key))
+	}
+	m.watcherProblems = problems
+}
+
+// openWatcherHealth shows the watcher health panel
+func (m *Model) openWatcherHealth() {
+	m.mode = modeWatcherHealth
+}
+
+func (m Model) updateWatcherHealthMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.Type == tea.KeyEscape, msg.String() == "q", key.Matches(msg, keys.WatcherHealth):
+		m.mode = modeNormal
+	}
+	return m, nil
+}
+
+// watcherHealthView shows what each file watcher is watching, the files
+// that changed most recently, and what has gone wrong
+func (m Model) watcherHealthView() string {
+	var b strings.Builder
+	b.WriteString(inputLabelStyle.Render("Watcher health"))
+	b.WriteString("\n\n")
+
+	var health map[string]watcher.Health
+	if m.watcherHealth != nil {
+		health = m.watcherHealth()
+	}
+	if len(health) == 0 {
+		b.WriteString(inputHintStyle.Render("No files are being watched here. When a daemon is running, it does the watching."))
+		b.WriteString("\n\n")
+		b.WriteString(inputHintStyle.Render("Press esc to close"))
+		return b.String()
+	}
+
+	now := m.now()
+	sep := " " + glyphs.Dot + " "
+	for _, root := range slices.Sorted(maps.Keys(health)) {
+		h := health[root]
+		b.WriteString(normalStyle.Render(root))
+		b.WriteString(sourceStyle.Render("  " + counted(h.Files, "file", "files") + sep + counted(h.Dirs, "directory", "directories") + sep))
+		if h.Polled > 0 {
+			b.WriteString(waitingStyle.Render(counted(h.Polled, "directory", "directories") + " polled"))
+			b.WriteString(sourceStyle.Render(sep))
+		}
+		overflows := counted(h.Overflows, "overflow", "overflows")
+		if h.Overflows > 0 {
+			b.WriteString(triggeredStyle.Render(overflows))
+		} else {
+			b.WriteString(sourceStyle.Render(overflows))
+		}
+		b.WriteString("\n")
+		if h.Limited {
+			b.WriteString(inputHintStyle.Render(fmt.Sprintf("  Reached the system's limit on file watches. Files are watched through their directories, and directories past the limit are checked every %s.", watcher.PollInterval)))
+			b.WriteString("\n")
+			if runtime.GOOS == "linux" {
+				b.WriteString(inputHintStyle.Render("  To watch everything, raise the limit and restart: " + watchLimitHint))
+				b.WriteString("\n")
+			}
+		}
+		if h.Overflows > 0 {
+			b.WriteString(inputHintStyle.Render("  Changes were lost; save a file again, or refresh it from the sources view, to pick them up"))
+			b.WriteString("\n")
+		}
+
+		b.WriteString(inputLabelStyle.Render("  Last changes"))
+		b.WriteString("\n")
+		changed := slices.SortedFunc(maps.Keys(h.LastEvent), func(a, b string) int {
+			return cmp.Compare(h.LastEvent[b].UnixNano(), h.LastEvent[a].UnixNano())
+		})
+		if len(changed) == 0 {
+			b.WriteString(inputHintStyle.Render("    None yet"))
+			b.WriteString("\n")
+		}
+		for i, path := range changed {
+			if i == watcherHealthRows {
+				b.WriteString(inputHintStyle.Render(fmt.Sprintf("    %s and %d more", glyphs.Ellipsis, len(changed)-i)))
+				b.WriteString("\n")
+				break
+			}
+			b.WriteString("    " + sourceStyle.Render(formatWatchTime(h.LastEvent[path], now)) + "  " + normalStyle.Render(relativeTo(root, path)))
+			b.WriteString("\n")
+		}
+
+		if len(h.Failures) > 0 {
+			b.WriteString(inputLabelStyle.Render(fmt.Sprintf("  Failures (%d)", len(h.Failures))))
+			b.WriteString("\n")
+			for i := len(h.Failures) - 1; i >= 0; i-- {
+				if shown := len(h.Failures) - 1 - i; shown == watcherHealthRows {
+					b.WriteString(inputHintStyle.Render(fmt.Sprintf("    %s and %d older", glyphs.Ellipsis, i+1)))
+					b.WriteString("\n")
+					break
+				}
+				f := h.Failures[i]
+				text := f.Err
+				if f.Path != "" {
+					text = relativeTo(root, f.Path) + ": " + text
+				}
+				b.WriteString("    " + sourceStyle.Render(formatWatchTime(f.At, now)) + "  " + triggeredStyle.Render(text))
+				b.WriteString("\n")
+			}
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(inputHintStyle.Render("Press esc to close"))
+	return b.String()
+}
+
+// formatWatchTime formats when a watcher saw something, with the date if
+// it wasn't today
+func formatWatchTime(t, now time.Time) string {
+	t = t.Local()
+	if sameDay(t, now) {
+		return t.Format("15:04:05")
+	}
+	return t.Format("Jan 2 15:04")
+}
+
+// relativeTo shortens path to be relative to the watched root, if it's
+// inside it
+func relativeTo(root, path string) string {
+	rel, err := filepath.Rel(absPath(root), path)
+	switch {
+	case err != nil || strings.HasPrefix(rel, ".."):
+		return path
+	case rel == ".":
+		return filepath.Base(path)
+	}
+	return rel
+}
+
+// counted formats n of something, e.g. "1 file" or "3 files"
+func counted(n int, one, many string) string {
+	if n == 1 {
+		return "1 " + one
+	}
+	return fmt.Sprintf("%d %s", n, many)
+}
@@ -0,0 +1,198 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"go_remind/config"
+)
+
+// WithWorkspaces returns a copy of the model that saves new workspaces to
+// the config file at path. Without it, workspaces can be switched to but
+// not saved.
+func (m Model) WithWorkspaces(path string) Model {
+	m.workspacePath = path
+	return m
+}
+
+func newWorkspaceInput() textinput.Model {
+	ti := textinput.New()
+	ti.Placeholder = "Morning triage"
+	ti.CharLimit = 40
+	ti.Width = 30
+	return ti
+}
+
+// openWorkspaces shows the workspace switcher
+func (m *Model) openWorkspaces() {
+	m.workspaceIndex = 0
+	m.workspaceInput.Blur()
+	m.mode = modeWorkspaces
+}
+
+func (m Model) updateWorkspacesMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.workspaceInput.Focused() {
+		return m.updateWorkspaceName(msg)
+	}
+	workspaces := m.config.Workspaces
+	switch {
+	case msg.Type == tea.KeyEscape, msg.String() == "q", key.Matches(msg, keys.Workspaces):
+		m.mode = modeNormal
+	case msg.String() == "s":
+		if m.workspacePath == "" {
+			m.toastError("No config file to save workspaces to")
+			return m, nil
+		}
+		if len(workspaces) >= config.MaxWorkspaces {
+			m.toastError(fmt.Sprintf("Already %d workspaces; remove one from the config file first", config.MaxWorkspaces))
+			return m, nil
+		}
+		m.workspaceInput.Reset()
+		m.inputError = ""
+		return m, m.workspaceInput.Focus()
+	case msg.Type == tea.KeyEnter && len(workspaces) > 0:
+		m.mode = modeNormal
+		m.applyWorkspace(workspaces[m.workspaceIndex])
+	case len(msg.Runes) == 1 && msg.Runes[0] >= '1' && msg.Runes[0] <= '9':
+		if i := int(msg.Runes[0] - '1'); i < len(workspaces) {
+			m.mode = modeNormal
+			m.applyWorkspace(workspaces[i])
+		}
+	case key.Matches(msg, keys.Up):
+		if m.workspaceIndex > 0 {
+			m.workspaceIndex--
+		}
+	case key.Matches(msg, keys.Down):
+		if m.workspaceIndex < len(workspaces)-1 {
+			m.workspaceIndex++
+		}
+	}
+	return m, nil
+}
+
+// updateWorkspaceName handles typing the name of a workspace being saved
+func (m Model) updateWorkspaceName(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEscape:
+		m.workspaceInput.Blur()
+		m.inputError = ""
+		return m, nil
+	case tea.KeyEnter:
+		name := strings.TrimSpace(m.workspaceInput.Value())
+		if name == "" {
+			m.inputError = "Give the workspace a name"
+			return m, nil
+		}
+		m.workspaceInput.Blur()
+		m.inputError = ""
+		m.saveWorkspace(name)
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.workspaceInput, cmd = m.workspaceInput.Update(msg)
+	return m, cmd
+}
+
+// currentWorkspace returns the list's current arrangement as a workspace
+func (m Model) currentWorkspace(name string) config.WorkspaceConfig {
+	return config.WorkspaceConfig{
+		Name:   name,
+		Filter: m.filterInput.Value(),
+		Group:  groupingNames[m.grouping],
+		Sort:   sortOrderNames[m.sortOrder],
+		Layout: strings.ToLower(layoutNames[currentLayout]),
+	}
+}
+
+// saveWorkspace saves the list's current arrangement to the config file
+// as a workspace called name
+func (m *Model) saveWorkspace(name string) {
+	w := m.currentWorkspace(name)
+	if err := config.AppendWorkspace(m.workspacePath, w); err != nil {
+		m.toastError("Could not save workspace: " + err.Error())
+		return
+	}
+	m.config.Workspaces = append(m.config.Workspaces, w)
+	m.workspaceIndex = len(m.config.Workspaces) - 1
+	m.toastSuccess(fmt.Sprintf("Saved workspace %d: %s", len(m.config.Workspaces), name))
+}
+
+// applyWorkspace switches the list to w's arrangement. The filter is
+// always set, so a workspace without one shows everything; its other
+// settings are left as they are when empty or unknown.
+func (m *Model) applyWorkspace(w config.WorkspaceConfig) {
+	m.filterInput.SetValue(w.Filter)
+	if i := slices.Index(groupingNames, strings.ToLower(w.Group)); i >= 0 {
+		m.grouping = grouping(i)
+		m.sortEnabled = true // Groups only show in the sorted views
+	}
+	if i := slices.Index(sortOrderNames, strings.ToLower(w.Sort)); i >= 0 {
+		m.sortOrder = sortOrder(i)
+		m.sortEnabled = true
+	}
+	if i := slices.IndexFunc(layoutNames, func(name string) bool { return strings.EqualFold(name, w.Layout) }); i >= 0 {
+		currentLayout = LayoutMode(i)
+	}
+	m.gridIndex, m.gridScroll = 0, 0
+	m.compactIndex, m.compactScroll = 0, 0
+	m.refreshList()
+	m.saveViewSettings()
+	m.toastInfo("Workspace: " + w.Name)
+}
+
+// workspacesView renders the workspace switcher, or the name box while
+// saving one
+func (m Model) workspacesView() string {
+	var b strings.Builder
+	if m.workspaceInput.Focused() {
+		b.WriteString(inputBoxStyle.Render(inputLabelStyle.Render("Save workspace as: ") + m.workspaceInput.View()))
+		b.WriteString("\n")
+		w := m.currentWorkspace("")
+		b.WriteString(inputHintStyle.Render("  " + workspaceSummary(w) + "  (enter to save, esc to cancel)"))
+		if m.inputError != "" {
+			b.WriteString("\n")
+			b.WriteString(triggeredStyle.Render("  " + glyphs.Warning + " " + m.inputError))
+		}
+		return b.String()
+	}
+
+	b.WriteString(inputLabelStyle.Render("Workspaces"))
+	b.WriteString(inputHintStyle.Render("  (1-9 or enter to switch, s to save the current view, esc to cancel)"))
+	b.WriteString("\n\n")
+	if len(m.config.Workspaces) == 0 {
+		b.WriteString(inputHintStyle.Render("No workspaces yet. Press s to save this view as one."))
+		b.WriteString("\n")
+	}
+	for i, w := range m.config.Workspaces {
+		cursor := "  "
+		style := normalStyle
+		if i == m.workspaceIndex {
+			cursor = glyphs.Cursor + " "
+			style = selectedItemStyle
+		}
+		b.WriteString(cursor + style.Render(fmt.Sprintf("%d %s", i+1, w.Name)))
+		b.WriteString(sourceStyle.Render("  " + workspaceSummary(w)))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// workspaceSummary describes w's settings in a line
+func workspaceSummary(w config.WorkspaceConfig) string {
+	filter := w.Filter
+	if filter == "" {
+		filter = "none"
+	}
+	parts := []string{"filter " + filter}
+	for _, s := range [][2]string{{"group", w.Group}, {"sort", w.Sort}, {"layout", w.Layout}} {
+		if s[1] != "" {
+			parts = append(parts, s[0]+" "+s[1])
+		}
+	}
+	return strings.Join(parts, " "+glyphs.Dot+" ")
+}
@@ -0,0 +1,37 @@
+package tui
+
+import (
+	"time"
+
+	"go_remind/pkg/parser"
+	"go_remind/pkg/reminder"
+)
+
+// writeBackQuiet is how long after a write-back the watcher's update for
+// the file is merged without announcing it
+const writeBackQuiet = 5 * time.Second
+
+// writeBack rewrites an edited reminder's token in its source file, when
+// write-back is on, so the next parse doesn't bring the old text back.
+// before is the reminder as it was parsed.
+func (m *Model) writeBack(before, r *reminder.Reminder) {
+	if m.config == nil || !m.config.Parser.WriteBack || !before.FromFile() {
+		return
+	}
+	if err := parser.RewriteToken(before, r, m.now()); err != nil {
+		m.toastError("Not written to " + before.SourceFile + ": " + err.Error())
+		return
+	}
+	if m.wroteBack == nil {
+		m.wroteBack = make(map[string]time.Time)
+	}
+	m.wroteBack[before.SourceFile] = m.now()
+}
+
+// takeWriteBack reports whether an update to file is the watcher seeing
+// our own write-back
+func (m *Model) takeWriteBack(file string) bool {
+	at, ok := m.wroteBack[file]
+	delete(m.wroteBack, file)
+	return ok && m.now().Sub(at) < writeBackQuiet
+}
@@ -0,0 +1,47 @@
+package tui
+
+import (
+	"fmt"
+
+	"go_remind/pkg/reminder"
+)
+
+// yearlyText describes how r repeats for the detail view, e.g. "every year
+// on March 14, 7 days ahead (68th)"
+func yearlyText(r *reminder.Reminder) string {
+	y := r.Yearly
+	text := fmt.Sprintf("every year on %s %d", y.Month, y.Day)
+	if y.Before > 0 {
+		text += ", " + pluralDays(y.Before) + " ahead"
+	}
+	if n := y.Years(r.Occurrence()); n > 0 {
+		text += " (" + ordinal(n) + ")"
+	}
+	return text
+}
+
+// yearlyLabel is r's short yearly label for cards, with the count of
+// years coming up if known, e.g. "yearly 68th"
+func yearlyLabel(r *reminder.Reminder) string {
+	if n := r.Yearly.Years(r.Occurrence()); n > 0 {
+		return "yearly " + ordinal(n)
+	}
+	return "yearly"
+}
+
+// ordinal formats n as 1st, 2nd, 3rd, 4th, and so on
+func ordinal(n int) string {
+	suffix := "th"
+	switch n % 10 {
+	case 1:
+		suffix = "st"
+	case 2:
+		suffix = "nd"
+	case 3:
+		suffix = "rd"
+	}
+	if n%100 >= 11 && n%100 <= 13 {
+		suffix = "th"
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
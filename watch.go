@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	"go_remind/pkg/reminder"
+	"go_remind/pkg/watcher"
+)
+
+// watchPath parses the reminders in a file or directory and starts watching it.
+// Returns the initial reminders, a channel of subsequent file events, and the
+// watcher, to be stopped when done. progress, if non-nil, is called as files
+// are parsed.
+func watchPath(path string, progress func(done, total int)) ([]*reminder.Reminder, <-chan watcher.FileEvent, *watcher.Watcher, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("resolving path: %w", err)
+	}
+
+	// Parse reminders from files
+	fileReminders, isDir, err := watcher.ParseInitialWithProgress(absPath, progress)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("parsing: %w", err)
+	}
+
+	// Set up file watcher
+	w, err := watcher.New()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("creating watcher: %w", err)
+	}
+
+	if isDir {
+		if err := w.WatchDirectory(absPath); err != nil {
+			w.Stop()
+			return nil, nil, nil, fmt.Errorf("watching directory: %w", err)
+		}
+	} else {
+		// WatchFile watches the parent directory, which handles editors
+		// that do atomic saves (write temp + rename)
+		if err := w.WatchFile(absPath); err != nil {
+			w.Stop()
+			return nil, nil, nil, fmt.Errorf("watching file: %w", err)
+		}
+	}
+
+	events := make(chan watcher.FileEvent, 10)
+
+	// Track which file to watch for single-file mode
+	watchPath := absPath
+	watchSingleFile := !isDir
+
+	w.Start()
+	go func() {
+		for event := range w.Events {
+			if event.Err != nil {
+				continue
+			}
+			// When watching a single file, filter out events for other files
+			if watchSingleFile && event.FilePath != watchPath {
+				continue
+			}
+			events <- event
+		}
+		close(events)
+	}()
+
+	return fileReminders, events, w, nil
+}
+
+// watcherSet keeps the running watchers by the path they watch, for the
+// TUI's watcher health panel
+type watcherSet struct {
+	mu       sync.Mutex
+	watchers map[string]*watcher.Watcher
+}
+
+func (s *watcherSet) add(path string, w *watcher.Watcher) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.watchers == nil {
+		s.watchers = make(map[string]*watcher.Watcher)
+	}
+	s.watchers[path] = w
+}
+
+// health returns the health of each watcher by path
+func (s *watcherSet) health() map[string]watcher.Health {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	health := make(map[string]watcher.Health, len(s.watchers))
+	for path, w := range s.watchers {
+		health[path] = w.Health()
+	}
+	return health
+}